@@ -197,6 +197,52 @@ func (c *TermiteClient) Chunk(ctx context.Context, text string, config ChunkConf
 	return resp.JSON200.Chunks, nil
 }
 
+// EmbedDocument extracts the text of a document, chunks it and embeds each chunk.
+// The document is a data URI, HTTP(S), file:// or s3:// URL; mimeType overrides the
+// detected document type if set. PDF and DOCX documents need a server built with
+// the docextract tag.
+func (c *TermiteClient) EmbedDocument(ctx context.Context, model, document, mimeType string, config ChunkConfig) (*oapi.EmbedDocumentResponse, error) {
+	req := oapi.EmbedDocumentRequest{
+		Model:    model,
+		Document: document,
+		MimeType: mimeType,
+		Chunking: oapi.ChunkConfig{
+			Model:         config.Model,
+			TargetTokens:  config.TargetTokens,
+			OverlapTokens: config.OverlapTokens,
+			Separator:     config.Separator,
+			MaxChunks:     config.MaxChunks,
+			Threshold:     config.Threshold,
+		},
+	}
+
+	resp, err := c.client.GenerateDocumentEmbeddingsWithResponse(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	if resp.JSON400 != nil {
+		return nil, fmt.Errorf("bad request: %s", resp.JSON400.Error)
+	}
+	if resp.JSON404 != nil {
+		return nil, fmt.Errorf("model not found: %s", resp.JSON404.Error)
+	}
+	if resp.JSON413 != nil {
+		return nil, fmt.Errorf("document too large: %s", resp.JSON413.Error)
+	}
+	if resp.JSON415 != nil {
+		return nil, fmt.Errorf("unsupported document type: %s", resp.JSON415.Error)
+	}
+	if resp.JSON500 != nil {
+		return nil, fmt.Errorf("server error: %s", resp.JSON500.Error)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+
+	return resp.JSON200, nil
+}
+
 // Rerank re-scores pre-rendered text prompts based on relevance to a query.
 func (c *TermiteClient) Rerank(ctx context.Context, model string, query string, prompts []string) ([]float32, error) {
 	req := oapi.RerankRequest{
@@ -229,6 +275,33 @@ func (c *TermiteClient) Rerank(ctx context.Context, model string, query string,
 	return resp.JSON200.Scores, nil
 }
 
+// Similarity scores every vector in a against every vector in b using the given
+// metric (cosine if empty). Returns a matrix where scores[i][j] compares a[i] with b[j].
+func (c *TermiteClient) Similarity(ctx context.Context, a, b [][]float32, metric oapi.SimilarityMetric) ([][]float32, error) {
+	req := oapi.SimilarityRequest{
+		A:      a,
+		B:      b,
+		Metric: metric,
+	}
+
+	resp, err := c.client.ComputeSimilarityWithResponse(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	if resp.JSON400 != nil {
+		return nil, fmt.Errorf("bad request: %s", resp.JSON400.Error)
+	}
+	if resp.JSON500 != nil {
+		return nil, fmt.Errorf("server error: %s", resp.JSON500.Error)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+
+	return resp.JSON200.Scores, nil
+}
+
 // ListModels returns available models for embedding, chunking, and reranking.
 func (c *TermiteClient) ListModels(ctx context.Context) (*oapi.ModelsResponse, error) {
 	resp, err := c.client.ListModelsWithResponse(ctx)
@@ -260,6 +333,20 @@ func (c *TermiteClient) GetVersion(ctx context.Context) (*oapi.VersionResponse,
 	return resp.JSON200, nil
 }
 
+// GetInfo returns the backend, GPU, build, and model information of a running Termite.
+func (c *TermiteClient) GetInfo(ctx context.Context) (*oapi.InfoResponse, error) {
+	resp, err := c.client.GetInfoWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+
+	return resp.JSON200, nil
+}
+
 // deserializeFloatArrays reconstructs a 2D float32 array from binary format.
 // Format: uint64(numVectors) + uint64(dimension) + float32 values in little endian
 func deserializeFloatArrays(r io.Reader) ([][]float32, error) {
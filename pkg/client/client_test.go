@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/antflydb/termite/pkg/client/oapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -340,6 +341,101 @@ func TestClient_Rerank_ServiceUnavailable(t *testing.T) {
 	assert.Contains(t, err.Error(), "service unavailable")
 }
 
+func TestClient_EmbedDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/embed/document", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var req oapi.EmbedDocumentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "bge-small", req.Model)
+		assert.Equal(t, "s3://minio:9000/docs/report.pdf", req.Document)
+		assert.Empty(t, req.MimeType)
+		assert.Equal(t, 200, req.Chunking.TargetTokens)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(oapi.EmbedDocumentResponse{
+			Model:    "bge-small",
+			MimeType: "application/pdf",
+			Chunks: []oapi.DocumentChunk{
+				{Id: 0, Text: "First chunk", StartChar: 0, EndChar: 11, Embedding: []float32{0.1, 0.2}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	termiteClient, err := NewTermiteClient(server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := termiteClient.EmbedDocument(context.Background(), "bge-small",
+		"s3://minio:9000/docs/report.pdf", "", ChunkConfig{TargetTokens: 200})
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", resp.MimeType)
+	require.Len(t, resp.Chunks, 1)
+	assert.Equal(t, "First chunk", resp.Chunks[0].Text)
+	assert.Equal(t, []float32{0.1, 0.2}, resp.Chunks[0].Embedding)
+}
+
+func TestClient_EmbedDocument_Unsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unsupported document type: application/pdf"})
+	}))
+	defer server.Close()
+
+	termiteClient, err := NewTermiteClient(server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = termiteClient.EmbedDocument(context.Background(), "bge-small", "file:///tmp/report.pdf", "", ChunkConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported document type")
+}
+
+func TestClient_Similarity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/similarity", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var req oapi.SimilarityRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, [][]float32{{1, 0}}, req.A)
+		assert.Len(t, req.B, 2)
+		assert.Equal(t, oapi.SimilarityMetricDot, req.Metric)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(oapi.SimilarityResponse{
+			Metric: oapi.SimilarityMetricDot,
+			Scores: [][]float32{{1, 0}},
+		})
+	}))
+	defer server.Close()
+
+	termiteClient, err := NewTermiteClient(server.URL, nil)
+	require.NoError(t, err)
+
+	scores, err := termiteClient.Similarity(context.Background(),
+		[][]float32{{1, 0}}, [][]float32{{1, 0}, {0, 1}}, oapi.SimilarityMetricDot)
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}}, scores)
+}
+
+func TestClient_Similarity_BadRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "dimension mismatch: b[0] has 3 dimensions, expected 2"})
+	}))
+	defer server.Close()
+
+	termiteClient, err := NewTermiteClient(server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = termiteClient.Similarity(context.Background(), [][]float32{{1, 0}}, [][]float32{{1, 0, 0}}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dimension mismatch")
+}
+
 func TestClient_ListModels(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/models", r.URL.Path)
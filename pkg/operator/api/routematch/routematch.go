@@ -0,0 +1,141 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routematch judges whether the match criteria of two TermiteRoutes
+// may match the same request. It only uses the standard library, so that the
+// proxy can share it with the operator's admission webhook without importing
+// the API types.
+package routematch
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RegexModelPatternPrefix marks a match.models entry as a raw regular
+// expression (e.g. "re:^(bge|gte)-.*$") rather than a wildcard pattern
+const RegexModelPatternPrefix = "re:"
+
+// ModelPatternExpr returns the regular expression of a match.models pattern:
+// the expression following RegexModelPatternPrefix, or the pattern anchored
+// with each * matching any run of characters
+func ModelPatternExpr(pattern string) string {
+	if expr, ok := strings.CutPrefix(pattern, RegexModelPatternPrefix); ok {
+		return expr
+	}
+	return "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`) + "$"
+}
+
+// Criteria are the conditions restricting the requests a route matches.
+// Empty fields match anything.
+type Criteria struct {
+	Operations            []string
+	SourceTables          []string
+	SourceNamespaces      []string
+	SourceServiceAccounts []string
+	Headers               map[string]StringMatch
+	// Models are the compiled patterns of the non-negated match.models
+	// entries. A nil pattern may match any model.
+	Models []*regexp.Regexp
+}
+
+// StringMatch is a header condition. Regular expressions are not compared,
+// so only whether one is set matters.
+type StringMatch struct {
+	Exact  string
+	Prefix string
+	Regex  bool
+}
+
+// Overlap reports whether some request may satisfy both criteria. It is
+// conservative: conditions that cannot be compared statically, such as
+// regular expressions, are assumed to overlap.
+func Overlap(a, b *Criteria) bool {
+	if !listsOverlap(a.Operations, b.Operations) ||
+		!listsOverlap(a.SourceTables, b.SourceTables) ||
+		!listsOverlap(a.SourceNamespaces, b.SourceNamespaces) ||
+		!listsOverlap(a.SourceServiceAccounts, b.SourceServiceAccounts) {
+		return false
+	}
+
+	for header, ma := range a.Headers {
+		if mb, ok := b.Headers[header]; ok && !ma.overlaps(mb) {
+			return false
+		}
+	}
+
+	if len(a.Models) == 0 || len(b.Models) == 0 {
+		return true
+	}
+	for _, pa := range a.Models {
+		for _, pb := range b.Models {
+			if modelPatternsOverlap(pa, pb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listsOverlap reports whether two match lists share a value; an empty list
+// matches anything
+func listsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlaps reports whether some value may satisfy both matches
+func (s StringMatch) overlaps(o StringMatch) bool {
+	if s.Regex || o.Regex {
+		return true
+	}
+	if s.Exact != "" && o.matches(s.Exact) || o.Exact != "" && s.matches(o.Exact) {
+		return true
+	}
+	return s.Prefix != "" && o.Prefix != "" &&
+		(strings.HasPrefix(s.Prefix, o.Prefix) || strings.HasPrefix(o.Prefix, s.Prefix))
+}
+
+// matches reports whether value satisfies the exact or prefix match
+func (s StringMatch) matches(value string) bool {
+	return s.Exact != "" && value == s.Exact || s.Prefix != "" && strings.HasPrefix(value, s.Prefix)
+}
+
+// modelPatternsOverlap reports whether some model name may match both
+// patterns. Anchored patterns are compared by their literal prefixes;
+// unanchored regular expressions are assumed to overlap.
+func modelPatternsOverlap(a, b *regexp.Regexp) bool {
+	if a == nil || b == nil || !strings.HasPrefix(a.String(), "^") || !strings.HasPrefix(b.String(), "^") {
+		return true
+	}
+	prefixA, completeA := a.LiteralPrefix()
+	prefixB, completeB := b.LiteralPrefix()
+	switch {
+	case completeA:
+		return b.MatchString(prefixA)
+	case completeB:
+		return a.MatchString(prefixB)
+	default:
+		return strings.HasPrefix(prefixA, prefixB) || strings.HasPrefix(prefixB, prefixA)
+	}
+}
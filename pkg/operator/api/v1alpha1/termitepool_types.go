@@ -357,11 +357,14 @@ type ProbeConfig struct {
 
 // RoutingConfig defines routing hints for the proxy
 type RoutingConfig struct {
-	// Weight is the relative routing weight (0-100)
+	// Weight is the pool's relative share (0-100) of the requests that no
+	// TermiteRoute matches, among the pools serving the requested model.
+	// 0 keeps default traffic off the pool unless no other pool serves the model.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:default=100
-	Weight int32 `json:"weight,omitempty"`
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
 
 	// DrainTimeout is the time to drain before termination
 	// +optional
@@ -17,39 +17,54 @@ package v1alpha1
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// ValidateCreate validates the pool configuration when creating a new pool
-func (r *TermitePool) ValidateCreate() error {
+// ValidateCreate validates the pool configuration when creating a new pool.
+// Warnings are returned for valid but risky configurations.
+func (r *TermitePool) ValidateCreate() (admission.Warnings, error) {
 	return r.validateTermitePool()
 }
 
 // ValidateUpdate validates the pool configuration when updating an existing pool
-func (r *TermitePool) ValidateUpdate(old runtime.Object) error {
+func (r *TermitePool) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	oldPool := old.(*TermitePool)
 	if err := r.validateImmutability(oldPool); err != nil {
-		return err
+		return nil, err
 	}
 	return r.validateTermitePool()
 }
 
 // ValidateDelete validates pool deletion (no validation needed)
-func (r *TermitePool) ValidateDelete() error {
+func (r *TermitePool) ValidateDelete() (admission.Warnings, error) {
 	// No validation needed for delete operations
-	return nil
+	return nil, nil
 }
 
-// validateTermitePool performs all validation checks
-func (r *TermitePool) validateTermitePool() error {
+// validateTermitePool performs all validation checks, returning non-blocking
+// warnings alongside any errors
+func (r *TermitePool) validateTermitePool() (admission.Warnings, error) {
 	var allErrors []string
+	warnings := admission.Warnings(r.spotWarnings())
 
 	if err := r.validateGKEConfig(); err != nil {
 		allErrors = append(allErrors, err.Error())
 	}
 
+	if err := r.validateTPUConfig(); err != nil {
+		allErrors = append(allErrors, err.Error())
+	}
+
+	if err := r.validateAutopilotResources(); err != nil {
+		allErrors = append(allErrors, err.Error())
+	}
+
 	if err := r.validateNoConflictingSettings(); err != nil {
 		allErrors = append(allErrors, err.Error())
 	}
@@ -58,12 +73,16 @@ func (r *TermitePool) validateTermitePool() error {
 		allErrors = append(allErrors, err.Error())
 	}
 
+	if err := r.validateRouting(); err != nil {
+		allErrors = append(allErrors, err.Error())
+	}
+
 	if len(allErrors) > 0 {
-		return fmt.Errorf("TermitePool validation failed:\n  - %s",
+		return warnings, fmt.Errorf("TermitePool validation failed:\n  - %s",
 			strings.Join(allErrors, "\n  - "))
 	}
 
-	return nil
+	return warnings, nil
 }
 
 // validateGKEConfig validates GKE-specific configuration
@@ -135,6 +154,218 @@ Example (TPU with Spot pricing):
 	return nil
 }
 
+// tpuResourceName is the extended resource GKE uses to schedule TPU chips
+const tpuResourceName = "google.com/tpu"
+
+// tpuAcceleratorSpec describes the topologies GKE accepts for a TPU accelerator type
+type tpuAcceleratorSpec struct {
+	// topologies lists the allowed 2D topologies; nil means any 3D AxBxC topology
+	// whose chip count is a multiple of chipsPerHost
+	topologies []string
+
+	// maxSingleHostChips is the largest chip count served by a single node
+	maxSingleHostChips int
+
+	// chipsPerHost is the number of chips on each node of a multi-host slice
+	chipsPerHost int
+}
+
+// tpuAccelerators lists the TPU accelerator types validated by the webhook.
+// Other accelerators starting with "tpu-" only require google.com/tpu limits.
+var tpuAccelerators = map[string]tpuAcceleratorSpec{
+	"tpu-v4-podslice": {maxSingleHostChips: 4, chipsPerHost: 4},
+	"tpu-v5p-slice":   {maxSingleHostChips: 4, chipsPerHost: 4},
+	"tpu-v5-lite-podslice": {
+		topologies:         []string{"1x1", "2x2", "2x4", "4x4", "4x8", "8x8", "8x16", "16x16"},
+		maxSingleHostChips: 8,
+		chipsPerHost:       4,
+	},
+	"tpu-v5-lite-device": {
+		topologies:         []string{"1x1", "2x2", "2x4"},
+		maxSingleHostChips: 8,
+		chipsPerHost:       8,
+	},
+	"tpu-v6e-slice": {
+		topologies:         []string{"1x1", "2x2", "2x4", "4x4", "4x8", "8x8", "8x16", "16x16"},
+		maxSingleHostChips: 8,
+		chipsPerHost:       4,
+	},
+}
+
+// validateTPUConfig validates that TPU pools request google.com/tpu chips
+// and use a topology that matches the accelerator type
+func (r *TermitePool) validateTPUConfig() error {
+	accelerator := r.Spec.Hardware.Accelerator
+	if !strings.HasPrefix(accelerator, "tpu-") {
+		return nil
+	}
+
+	if !r.hasTPUResources() {
+		return fmt.Errorf(`spec.hardware.accelerator='%s' requires google.com/tpu resource limits
+
+Problem: TPU pods are only scheduled onto TPU nodes when they request TPU chips.
+
+Solution: Add google.com/tpu to spec.resources.limits (and requests) matching the topology
+
+Example:
+  spec:
+    hardware:
+      accelerator: "tpu-v5-lite-podslice"
+      topology: "2x2"
+    resources:
+      limits:
+        google.com/tpu: "4"`, accelerator)
+	}
+
+	spec, known := tpuAccelerators[accelerator]
+	if !known {
+		return nil
+	}
+
+	topology := r.Spec.Hardware.Topology
+	chips, ok := spec.topologyChips(topology)
+	if !ok {
+		allowed := "3D topologies like 2x2x1, 2x2x2, 2x4x4 (chip count a multiple of 4)"
+		if spec.topologies != nil {
+			allowed = strings.Join(spec.topologies, ", ")
+		}
+		return fmt.Errorf(`spec.hardware.topology='%s' is not valid for accelerator '%s'
+
+Problem: GKE only provisions TPU slices with supported topologies for each accelerator type.
+
+Solution: Use one of: %s`, topology, accelerator, allowed)
+	}
+
+	// Single-host slices put every chip on one node; multi-host slices spread them across nodes
+	expected := chips
+	if chips > spec.maxSingleHostChips {
+		expected = spec.chipsPerHost
+	}
+
+	limit := r.Spec.Resources.Limits[tpuResourceName]
+	if limit.Value() != int64(expected) {
+		return fmt.Errorf(`spec.resources.limits[google.com/tpu]=%s does not match topology '%s'
+
+Problem: Each pod must request exactly the TPU chips available on one node of the slice.
+Topology '%s' on '%s' has %d chips per node.
+
+Solution: Set google.com/tpu to "%d" in spec.resources.limits and spec.resources.requests`,
+			limit.String(), topology, topology, accelerator, expected, expected)
+	}
+
+	return nil
+}
+
+// topologyChips returns the total chip count of topology, or false if the
+// topology is not allowed for this accelerator type
+func (s tpuAcceleratorSpec) topologyChips(topology string) (int, bool) {
+	parts := strings.Split(topology, "x")
+
+	if s.topologies != nil {
+		if !slices.Contains(s.topologies, topology) {
+			return 0, false
+		}
+	} else if len(parts) != 3 {
+		return 0, false
+	}
+
+	chips := 1
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		chips *= n
+	}
+
+	if s.topologies == nil && chips%s.chipsPerHost != 0 {
+		return 0, false
+	}
+	return chips, true
+}
+
+// GKE Autopilot resource rules for general-purpose pods
+var (
+	autopilotMinCPU       = resource.MustParse("250m")
+	autopilotCPUIncrement = resource.MustParse("250m")
+	autopilotMinMemory    = resource.MustParse("512Mi")
+)
+
+// Memory-to-CPU ratio bounds (GiB per vCPU) enforced by Autopilot
+const (
+	autopilotMinMemoryPerCPU = 1.0
+	autopilotMaxMemoryPerCPU = 6.5
+)
+
+// validateAutopilotResources validates that CPU/memory requests satisfy the
+// GKE Autopilot minimums, CPU increments, and memory-to-CPU ratio.
+// Accelerator pods follow different rules and are not checked.
+func (r *TermitePool) validateAutopilotResources() error {
+	if r.Spec.GKE == nil || !r.Spec.GKE.Autopilot || r.Spec.Resources == nil {
+		return nil
+	}
+	if r.hasGPUResources() || r.hasTPUResources() {
+		return nil
+	}
+
+	cpu, hasCPU := r.effectiveRequest(corev1.ResourceCPU)
+	memory, hasMemory := r.effectiveRequest(corev1.ResourceMemory)
+
+	var problems []string
+	if hasCPU {
+		if cpu.Cmp(autopilotMinCPU) < 0 {
+			problems = append(problems, fmt.Sprintf("cpu request %s is below the Autopilot minimum of %s",
+				cpu.String(), autopilotMinCPU.String()))
+		} else if cpu.MilliValue()%autopilotCPUIncrement.MilliValue() != 0 {
+			problems = append(problems, fmt.Sprintf("cpu request %s is not a multiple of %s",
+				cpu.String(), autopilotCPUIncrement.String()))
+		}
+	}
+	if hasMemory && memory.Cmp(autopilotMinMemory) < 0 {
+		problems = append(problems, fmt.Sprintf("memory request %s is below the Autopilot minimum of %s",
+			memory.String(), autopilotMinMemory.String()))
+	}
+	if hasCPU && hasMemory && cpu.MilliValue() > 0 {
+		ratio := (float64(memory.Value()) / (1 << 30)) / (float64(cpu.MilliValue()) / 1000)
+		if ratio < autopilotMinMemoryPerCPU || ratio > autopilotMaxMemoryPerCPU {
+			problems = append(problems, fmt.Sprintf("memory:cpu ratio %.2f GiB per vCPU is outside the Autopilot range of %.1f-%.1f",
+				ratio, autopilotMinMemoryPerCPU, autopilotMaxMemoryPerCPU))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(`spec.resources does not meet GKE Autopilot requirements (spec.gke.autopilot=true):
+    * %s
+
+Problem: Autopilot rejects or resizes pods whose requests fall outside its limits.
+Requirements: cpu >= %s in %s increments, memory >= %s, %.1f-%.1f GiB memory per vCPU.
+
+Solution: Adjust spec.resources.requests (or limits, if requests are unset)
+
+Example:
+  spec:
+    resources:
+      requests:
+        cpu: "1"
+        memory: "4Gi"`,
+		strings.Join(problems, "\n    * "),
+		autopilotMinCPU.String(), autopilotCPUIncrement.String(), autopilotMinMemory.String(),
+		autopilotMinMemoryPerCPU, autopilotMaxMemoryPerCPU)
+}
+
+// effectiveRequest returns the requested quantity for name, falling back to
+// the limit as Kubernetes does when only a limit is set
+func (r *TermitePool) effectiveRequest(name corev1.ResourceName) (resource.Quantity, bool) {
+	if q, ok := r.Spec.Resources.Requests[name]; ok {
+		return q, true
+	}
+	q, ok := r.Spec.Resources.Limits[name]
+	return q, ok
+}
+
 // validateNoConflictingSettings validates that hardware.spot doesn't conflict with Autopilot
 func (r *TermitePool) validateNoConflictingSettings() error {
 	if r.Spec.GKE == nil || !r.Spec.GKE.Autopilot {
@@ -163,6 +394,19 @@ Example:
 	return nil
 }
 
+// spotWarnings warns when spot capacity is used without enough replicas to
+// keep serving through a preemption
+func (r *TermitePool) spotWarnings() []string {
+	spot := r.Spec.Hardware.Spot ||
+		(r.Spec.GKE != nil && r.Spec.GKE.AutopilotComputeClass == "autopilot-spot")
+	if !spot || r.Spec.Replicas.Min >= 2 {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"spot instances can be preempted at any time and spec.replicas.min=%d; set spec.replicas.min >= 2 to keep serving during preemptions",
+		r.Spec.Replicas.Min)}
+}
+
 // validateReplicaCounts validates that replica counts are valid
 func (r *TermitePool) validateReplicaCounts() error {
 	if r.Spec.Replicas.Min < 0 {
@@ -181,6 +425,17 @@ func (r *TermitePool) validateReplicaCounts() error {
 	return nil
 }
 
+// validateRouting validates the routing hints read by the proxy
+func (r *TermitePool) validateRouting() error {
+	if r.Spec.Routing == nil || r.Spec.Routing.Weight == nil {
+		return nil
+	}
+	if weight := *r.Spec.Routing.Weight; weight < 0 || weight > 100 {
+		return fmt.Errorf("spec.routing.weight must be between 0 and 100, got %d", weight)
+	}
+	return nil
+}
+
 // validateImmutability validates that immutable fields haven't changed
 func (r *TermitePool) validateImmutability(old *TermitePool) error {
 	var errors []string
@@ -216,6 +471,32 @@ Attempted change: "%s"`,
 		}
 	}
 
+	// Hardware fields select the node pool and are baked into the StatefulSet
+	// pod template, so changing them would reschedule every replica
+	hardwareFields := []struct {
+		field    string
+		old, new string
+	}{
+		{"spec.hardware.accelerator", old.Spec.Hardware.Accelerator, r.Spec.Hardware.Accelerator},
+		{"spec.hardware.topology", old.Spec.Hardware.Topology, r.Spec.Hardware.Topology},
+		{"spec.hardware.spot", strconv.FormatBool(old.Spec.Hardware.Spot), strconv.FormatBool(r.Spec.Hardware.Spot)},
+	}
+	for _, f := range hardwareFields {
+		if f.old == f.new {
+			continue
+		}
+		errors = append(errors, fmt.Sprintf(
+			`field '%s' is immutable after deployment
+
+Problem: Changing hardware requires node and pod recreation, which may disrupt model serving.
+
+Solution: Delete and recreate the pool to change this setting.
+
+Current value: "%s"
+Attempted change: "%s"`,
+			f.field, f.old, f.new))
+	}
+
 	// Handle case where GKE config is being added/removed after creation
 	if (r.Spec.GKE != nil && old.Spec.GKE == nil) || (r.Spec.GKE == nil && old.Spec.GKE != nil) {
 		if old.Spec.GKE != nil && old.Spec.GKE.Autopilot {
@@ -234,6 +515,15 @@ Solution: Delete and recreate the pool to change this setting.`)
 	return nil
 }
 
+// hasTPUResources checks if TPU resources are present in spec.resources
+func (r *TermitePool) hasTPUResources() bool {
+	if r.Spec.Resources == nil || r.Spec.Resources.Limits == nil {
+		return false
+	}
+	_, hasTPU := r.Spec.Resources.Limits[tpuResourceName]
+	return hasTPU
+}
+
 // hasGPUResources checks if GPU resources are present in spec.resources
 func (r *TermitePool) hasGPUResources() bool {
 	if r.Spec.Resources == nil || r.Spec.Resources.Limits == nil {
@@ -0,0 +1,264 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+// checkValidation asserts that pool passes validation when wantErr is empty,
+// and otherwise fails with an error containing wantErr
+func checkValidation(t *testing.T, pool *TermitePool, wantErr string) {
+	t.Helper()
+	_, err := pool.ValidateCreate()
+	checkValidationError(t, err, wantErr)
+}
+
+// checkValidationError checks the error of a create or update validation
+func checkValidationError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("expected error containing %q, got nil", wantErr)
+	}
+	if !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("expected error containing %q, got: %v", wantErr, err)
+	}
+}
+
+func newTPUPool(accelerator, topology, tpuLimit string) *TermitePool {
+	pool := &TermitePool{
+		Spec: TermitePoolSpec{
+			Replicas: ReplicaConfig{Min: 1, Max: 2},
+			Hardware: HardwareConfig{
+				Accelerator: accelerator,
+				Topology:    topology,
+			},
+		},
+	}
+	if tpuLimit != "" {
+		pool.Spec.Resources = &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				tpuResourceName: resource.MustParse(tpuLimit),
+			},
+		}
+	}
+	return pool
+}
+
+func TestValidateTPUConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    *TermitePool
+		wantErr string
+	}{
+		{
+			name: "valid single-host v5e",
+			pool: newTPUPool("tpu-v5-lite-podslice", "2x4", "8"),
+		},
+		{
+			name: "valid multi-host v5e uses chips per host",
+			pool: newTPUPool("tpu-v5-lite-podslice", "4x4", "4"),
+		},
+		{
+			name: "valid v4 3D topology",
+			pool: newTPUPool("tpu-v4-podslice", "2x2x1", "4"),
+		},
+		{
+			name: "non-TPU accelerator is not checked",
+			pool: newTPUPool("nvidia-l4", "", ""),
+		},
+		{
+			name:    "missing google.com/tpu limit",
+			pool:    newTPUPool("tpu-v5-lite-podslice", "2x2", ""),
+			wantErr: "requires google.com/tpu resource limits",
+		},
+		{
+			name:    "2D topology on 3D accelerator",
+			pool:    newTPUPool("tpu-v4-podslice", "2x2", "4"),
+			wantErr: "is not valid for accelerator 'tpu-v4-podslice'",
+		},
+		{
+			name:    "3D topology on 2D accelerator",
+			pool:    newTPUPool("tpu-v5-lite-podslice", "2x2x1", "4"),
+			wantErr: "is not valid for accelerator 'tpu-v5-lite-podslice'",
+		},
+		{
+			name:    "limit inconsistent with topology",
+			pool:    newTPUPool("tpu-v5-lite-podslice", "2x2", "8"),
+			wantErr: "does not match topology '2x2'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, tt.pool, tt.wantErr)
+		})
+	}
+}
+
+func newAutopilotPool(cpu, memory string) *TermitePool {
+	return &TermitePool{
+		Spec: TermitePoolSpec{
+			Replicas: ReplicaConfig{Min: 1, Max: 2},
+			GKE:      &GKEConfig{Autopilot: true},
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		},
+	}
+}
+
+func TestValidateAutopilotResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    *TermitePool
+		wantErr string
+	}{
+		{
+			name: "valid request",
+			pool: newAutopilotPool("1", "4Gi"),
+		},
+		{
+			name:    "cpu below minimum",
+			pool:    newAutopilotPool("100m", "512Mi"),
+			wantErr: "cpu request 100m is below the Autopilot minimum of 250m",
+		},
+		{
+			name:    "memory below minimum",
+			pool:    newAutopilotPool("250m", "256Mi"),
+			wantErr: "memory request 256Mi is below the Autopilot minimum of 512Mi",
+		},
+		{
+			name:    "cpu not a multiple of increment",
+			pool:    newAutopilotPool("300m", "1Gi"),
+			wantErr: "cpu request 300m is not a multiple of 250m",
+		},
+		{
+			name:    "memory to cpu ratio too high",
+			pool:    newAutopilotPool("1", "16Gi"),
+			wantErr: "memory:cpu ratio 16.00 GiB per vCPU",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, tt.pool, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateUpdate_Immutability(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*TermitePool)
+		wantErr string
+	}{
+		{
+			name:   "changing replica counts is allowed",
+			mutate: func(p *TermitePool) { p.Spec.Replicas = ReplicaConfig{Min: 2, Max: 5} },
+		},
+		{
+			name: "changing accelerator is rejected",
+			mutate: func(p *TermitePool) {
+				p.Spec.Hardware.Accelerator = "tpu-v6e-slice"
+			},
+			wantErr: "field 'spec.hardware.accelerator' is immutable",
+		},
+		{
+			name: "changing topology is rejected",
+			mutate: func(p *TermitePool) {
+				p.Spec.Hardware.Topology = "1x1"
+				p.Spec.Resources.Limits[tpuResourceName] = resource.MustParse("1")
+			},
+			wantErr: "field 'spec.hardware.topology' is immutable",
+		},
+		{
+			name:    "changing spot is rejected",
+			mutate:  func(p *TermitePool) { p.Spec.Hardware.Spot = true },
+			wantErr: "field 'spec.hardware.spot' is immutable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newTPUPool("tpu-v5-lite-podslice", "2x2", "4")
+			updated := old.DeepCopy()
+			tt.mutate(updated)
+
+			_, err := updated.ValidateUpdate(old)
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCreate_SpotWarnings(t *testing.T) {
+	pool := newTPUPool("tpu-v5-lite-podslice", "2x2", "4")
+	pool.Spec.Hardware.Spot = true
+	pool.Spec.Replicas = ReplicaConfig{Min: 1, Max: 3}
+
+	warnings, err := pool.ValidateCreate()
+	if err != nil {
+		t.Fatalf("expected spot pool to be accepted, got: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "spec.replicas.min=1") {
+		t.Fatalf("expected a spot preemption warning, got: %v", warnings)
+	}
+
+	pool.Spec.Replicas.Min = 2
+	warnings, err = pool.ValidateCreate()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings with min replicas >= 2, got: %v", warnings)
+	}
+}
+
+func TestValidateRoutingWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		routing *RoutingConfig
+		wantErr string
+	}{
+		{name: "no routing"},
+		{name: "default weight", routing: &RoutingConfig{}},
+		{name: "zero weight", routing: &RoutingConfig{Weight: ptr.To[int32](0)}},
+		{name: "full weight", routing: &RoutingConfig{Weight: ptr.To[int32](100)}},
+		{name: "negative weight", routing: &RoutingConfig{Weight: ptr.To[int32](-1)}, wantErr: "spec.routing.weight must be between 0 and 100, got -1"},
+		{name: "weight above 100", routing: &RoutingConfig{Weight: ptr.To[int32](150)}, wantErr: "spec.routing.weight must be between 0 and 100, got 150"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newTPUPool("tpu-v5-lite-podslice", "2x2", "4")
+			pool.Spec.Routing = tt.routing
+			checkValidation(t, pool, tt.wantErr)
+		})
+	}
+}
@@ -26,8 +26,11 @@ type TermiteRouteSpec struct {
 	// +kubebuilder:default=100
 	Priority int32 `json:"priority,omitempty"`
 
-	// Match defines when this route applies
-	Match RouteMatch `json:"match"`
+	// Match defines when this route applies. A route with an empty match is
+	// a catch-all: it is evaluated after all other routes, regardless of
+	// priority, and receives the requests none of them match.
+	// +optional
+	Match RouteMatch `json:"match,omitempty"`
 
 	// Route defines where to send matching requests
 	Route []RouteDestination `json:"route"`
@@ -43,6 +46,11 @@ type TermiteRouteSpec struct {
 	// Retry configures retry behavior for this route
 	// +optional
 	Retry *RouteRetry `json:"retry,omitempty"`
+
+	// Headers modifies request headers before they are forwarded to the
+	// destination pool
+	// +optional
+	Headers *RouteHeaders `json:"headers,omitempty"`
 }
 
 // RouteMatch defines the conditions for a route to match
@@ -51,7 +59,10 @@ type RouteMatch struct {
 	// +optional
 	Operations []OperationType `json:"operations,omitempty"`
 
-	// Models matches model names (supports wildcards: "bge-*", "*-rerank-*")
+	// Models matches model names (supports wildcards: "bge-*", "*-rerank-*").
+	// Entries prefixed with "re:" are regular expressions, compiled as written
+	// (e.g. "re:^(bge|gte)-.*$"). Entries prefixed with "!" exclude matching
+	// models (e.g. "!bge-large").
 	// +optional
 	Models []string `json:"models,omitempty"`
 
@@ -66,6 +77,19 @@ type RouteMatch struct {
 	// TimeWindow restricts when this route is active
 	// +optional
 	TimeWindow *TimeWindowMatch `json:"timeWindow,omitempty"`
+
+	// Percentage matches a deterministic share (0-100) of requests for canaries.
+	// Requests are bucketed by a stable hash of HashHeader, so the same key
+	// always gets the same decision.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// HashHeader is the request header hashed for Percentage matching.
+	// When unset or missing from a request, the model name is hashed instead.
+	// +optional
+	HashHeader string `json:"hashHeader,omitempty"`
 }
 
 // OperationType represents a Termite API operation
@@ -98,16 +122,25 @@ type SourceMatch struct {
 	// +optional
 	Tables []string `json:"tables,omitempty"`
 
-	// Namespaces matches requests from specific Kubernetes namespaces
+	// Namespaces matches requests from specific Kubernetes namespaces, as
+	// identified by the X-Termite-Source-Namespace header. The proxy only
+	// accepts the header from its trusted source networks.
 	// +optional
 	Namespaces []string `json:"namespaces,omitempty"`
 
-	// ServiceAccounts matches requests from specific service accounts
+	// ServiceAccounts matches requests from specific service accounts, as
+	// identified by the X-Termite-Source-Service-Account header (accepted
+	// only from trusted source networks, like the namespace). Entries are
+	// "name" (any namespace) or "namespace/name".
 	// +optional
 	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
 }
 
-// TimeWindowMatch restricts when a route is active
+// TimeWindowMatch restricts when a route is active: from Start (inclusive)
+// to End (exclusive). A window whose End is before its Start is overnight
+// (e.g., 22:00-06:00) and belongs to the day it starts on, so with Days set
+// the early hours after midnight are active when the previous day is listed.
+// Start and End must differ.
 type TimeWindowMatch struct {
 	// Start is the start time in HH:MM format (UTC)
 	Start string `json:"start"`
@@ -126,12 +159,22 @@ type RouteDestination struct {
 	Pool string `json:"pool"`
 
 	// Weight is the relative weight for this destination (0-100)
-	// Used for traffic splitting between multiple destinations
+	// Used for traffic splitting between multiple destinations. A weight of 0
+	// makes this a fallback that only receives traffic when no weighted
+	// destination is eligible.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:default=100
 	Weight int32 `json:"weight,omitempty"`
 
+	// Cost ranks destinations by price, e.g. 0 for spot and 10 for on-demand
+	// capacity. Only the lowest-cost eligible destinations receive traffic,
+	// split by weight; higher-cost ones are used when no cheaper destination
+	// is eligible.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Cost int32 `json:"cost,omitempty"`
+
 	// Condition makes this destination conditional
 	// +optional
 	Condition *RouteCondition `json:"condition,omitempty"`
@@ -140,18 +183,25 @@ type RouteDestination struct {
 // RouteCondition defines when a destination is eligible
 type RouteCondition struct {
 	// QueueDepth activates when queue depth matches
-	// Supports operators: ">50", "<10", ">=100"
+	// Supports operators: ">50", "<10", ">=100". Values are unitless counts.
 	// +optional
 	QueueDepth string `json:"queueDepth,omitempty"`
 
-	// AvailableReplicas activates when replica count matches
+	// AvailableReplicas activates when replica count matches.
+	// Values are unitless counts (e.g., ">=2").
 	// +optional
 	AvailableReplicas string `json:"availableReplicas,omitempty"`
 
-	// Latency activates when P99 latency matches (e.g., ">100ms")
+	// Latency activates when P99 latency matches (e.g., ">100ms", "<2s").
+	// Values without a unit are interpreted as seconds.
 	// +optional
 	Latency string `json:"latency,omitempty"`
 
+	// Metric activates when a Prometheus metric for this pool matches.
+	// Requires the proxy to be configured with a Prometheus URL.
+	// +optional
+	Metric *MetricCondition `json:"metric,omitempty"`
+
 	// ModelLoaded activates only if the model is loaded on this pool
 	// +optional
 	ModelLoaded *bool `json:"modelLoaded,omitempty"`
@@ -161,6 +211,17 @@ type RouteCondition struct {
 	TimeOfDay *TimeWindowMatch `json:"timeOfDay,omitempty"`
 }
 
+// MetricCondition compares a Prometheus query result against a threshold
+type MetricCondition struct {
+	// Query is a PromQL expression returning a single value.
+	// "$pool" is replaced with the destination pool name
+	// (e.g., avg(DCGM_FI_DEV_GPU_UTIL{pool="$pool"})).
+	Query string `json:"query"`
+
+	// Threshold the query result must satisfy (e.g., "<80", ">=0.5")
+	Threshold string `json:"threshold"`
+}
+
 // RouteFallback defines fallback behavior
 type RouteFallback struct {
 	// Action is what to do when all destinations fail
@@ -178,6 +239,14 @@ type RouteFallback struct {
 	// ErrorResponse customizes the error response (for action=reject)
 	// +optional
 	ErrorResponse *ErrorResponseConfig `json:"errorResponse,omitempty"`
+
+	// FailFast applies the fallback as soon as the primary destination in
+	// spec.route, the one with the highest weight (the first on ties), has no
+	// healthy endpoints, without evaluating the other
+	// destinations. With action=reject, latency-sensitive callers get the
+	// errorResponse status immediately instead of spilling over.
+	// +optional
+	FailFast bool `json:"failFast,omitempty"`
 }
 
 // FallbackAction defines fallback actions
@@ -206,8 +275,15 @@ type ErrorResponseConfig struct {
 
 // RouteRateLimiting configures rate limiting
 type RouteRateLimiting struct {
-	// RequestsPerSecond limits requests per second
-	RequestsPerSecond int32 `json:"requestsPerSecond"`
+	// RequestsPerSecond limits requests per second.
+	// Exactly one of requestsPerSecond or requestsPerMinute must be set.
+	// +optional
+	RequestsPerSecond int32 `json:"requestsPerSecond,omitempty"`
+
+	// RequestsPerMinute limits requests per minute, allowing rates below
+	// one request per second (e.g., 12 for one request every 5 seconds)
+	// +optional
+	RequestsPerMinute int32 `json:"requestsPerMinute,omitempty"`
 
 	// BurstSize allows temporary bursts
 	// +optional
@@ -233,6 +309,31 @@ type RouteRetry struct {
 	RetryOn []string `json:"retryOn,omitempty"` // e.g., "5xx", "reset", "connect-failure"
 }
 
+// RouteHeaders modifies the headers of routed requests. Set is applied
+// first, then Add, then Remove.
+type RouteHeaders struct {
+	// Add appends a value to a header, keeping any values the client sent
+	// +optional
+	Add []HeaderValue `json:"add,omitempty"`
+
+	// Set replaces a header's values
+	// +optional
+	Set []HeaderValue `json:"set,omitempty"`
+
+	// Remove strips headers (e.g., client credentials not meant for the pool)
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// HeaderValue is a header name and value
+type HeaderValue struct {
+	// Name is the header name
+	Name string `json:"name"`
+
+	// Value is the header value
+	Value string `json:"value"`
+}
+
 // TermiteRouteStatus defines the observed state of TermiteRoute
 type TermiteRouteStatus struct {
 	// Active indicates if the route is currently active
@@ -260,7 +361,9 @@ type DestinationStatus struct {
 	// Healthy indicates if the destination is healthy
 	Healthy bool `json:"healthy"`
 
-	// ActiveConnections is the current connection count
+	// ActiveConnections is the in-flight request count, reported
+	// periodically by the proxy. With several proxy replicas, it is the
+	// count of the last replica to report.
 	ActiveConnections int32 `json:"activeConnections,omitempty"`
 
 	// RequestsRouted is the total requests routed to this destination
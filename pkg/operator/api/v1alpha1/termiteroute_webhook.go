@@ -15,33 +15,47 @@
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/antflydb/termite/pkg/operator/api/routematch"
 )
 
-// ValidateCreate validates the TermiteRoute configuration when creating a new route
-func (r *TermiteRoute) ValidateCreate() error {
+// ValidateCreate validates the TermiteRoute configuration when creating a new route.
+// Warnings are returned for valid but likely unintended configurations.
+func (r *TermiteRoute) ValidateCreate() (admission.Warnings, error) {
 	return r.validateTermiteRoute()
 }
 
 // ValidateUpdate validates the TermiteRoute configuration when updating an existing route
-func (r *TermiteRoute) ValidateUpdate(old runtime.Object) error {
+func (r *TermiteRoute) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	return r.validateTermiteRoute()
 }
 
 // ValidateDelete validates route deletion (no validation needed)
-func (r *TermiteRoute) ValidateDelete() error {
-	return nil
+func (r *TermiteRoute) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
 }
 
-// validateTermiteRoute performs all validation checks
-func (r *TermiteRoute) validateTermiteRoute() error {
+// validateTermiteRoute performs all validation checks, returning non-blocking
+// warnings alongside any errors
+func (r *TermiteRoute) validateTermiteRoute() (admission.Warnings, error) {
 	var allErrors []string
+	var warnings admission.Warnings
 
-	if err := r.validateRouteDestinations(); err != nil {
+	destWarnings, err := r.validateRouteDestinations()
+	warnings = append(warnings, destWarnings...)
+	if err != nil {
 		allErrors = append(allErrors, err.Error())
 	}
 
@@ -49,7 +63,9 @@ func (r *TermiteRoute) validateTermiteRoute() error {
 		allErrors = append(allErrors, err.Error())
 	}
 
-	if err := r.validateFallback(); err != nil {
+	fallbackWarnings, err := r.validateFallback()
+	warnings = append(warnings, fallbackWarnings...)
+	if err != nil {
 		allErrors = append(allErrors, err.Error())
 	}
 
@@ -61,18 +77,25 @@ func (r *TermiteRoute) validateTermiteRoute() error {
 		allErrors = append(allErrors, err.Error())
 	}
 
+	if err := r.validateHeaders(); err != nil {
+		allErrors = append(allErrors, err.Error())
+	}
+
 	if len(allErrors) > 0 {
-		return fmt.Errorf("TermiteRoute validation failed:\n  - %s",
+		return warnings, fmt.Errorf("TermiteRoute validation failed:\n  - %s",
 			strings.Join(allErrors, "\n  - "))
 	}
 
-	return nil
+	return warnings, nil
 }
 
+// metricThresholdRegex matches unitless threshold conditions like "<80" or ">=0.5"
+var metricThresholdRegex = regexp.MustCompile(`^\s*(>=|<=|==|>|<)?\s*-?[0-9]+(\.[0-9]+)?\s*$`)
+
 // validateRouteDestinations validates that route destinations are properly configured
-func (r *TermiteRoute) validateRouteDestinations() error {
+func (r *TermiteRoute) validateRouteDestinations() ([]string, error) {
 	if len(r.Spec.Route) == 0 {
-		return fmt.Errorf("spec.route must have at least one destination")
+		return nil, fmt.Errorf("spec.route must have at least one destination")
 	}
 
 	totalWeight := int32(0)
@@ -80,36 +103,59 @@ func (r *TermiteRoute) validateRouteDestinations() error {
 
 	for i, dest := range r.Spec.Route {
 		if dest.Pool == "" {
-			return fmt.Errorf("spec.route[%d].pool is required", i)
+			return nil, fmt.Errorf("spec.route[%d].pool is required", i)
 		}
 
 		// Check for duplicate pools without conditions
 		if dest.Condition == nil {
 			if poolNames[dest.Pool] {
-				return fmt.Errorf("duplicate pool '%s' in route destinations without conditions", dest.Pool)
+				return nil, fmt.Errorf("duplicate pool '%s' in route destinations without conditions", dest.Pool)
 			}
 			poolNames[dest.Pool] = true
 		}
 
 		// Validate weight range
 		if dest.Weight < 0 || dest.Weight > 100 {
-			return fmt.Errorf("spec.route[%d].weight must be between 0 and 100, got %d", i, dest.Weight)
+			return nil, fmt.Errorf("spec.route[%d].weight must be between 0 and 100, got %d", i, dest.Weight)
+		}
+
+		// Validate time of day condition
+		if dest.Condition != nil && dest.Condition.TimeOfDay != nil {
+			if err := validateTimeWindow(dest.Condition.TimeOfDay); err != nil {
+				return nil, fmt.Errorf("spec.route[%d].condition.timeOfDay: %w", i, err)
+			}
+		}
+
+		// Validate metric condition
+		if dest.Condition != nil && dest.Condition.Metric != nil {
+			metric := dest.Condition.Metric
+			if strings.TrimSpace(metric.Query) == "" {
+				return nil, fmt.Errorf("spec.route[%d].condition.metric.query is required", i)
+			}
+			if !metricThresholdRegex.MatchString(metric.Threshold) {
+				return nil, fmt.Errorf("spec.route[%d].condition.metric.threshold '%s' must be a comparison like '<80' or '>=0.5'",
+					i, metric.Threshold)
+			}
 		}
 
 		totalWeight += dest.Weight
 	}
 
-	// Warn if weights don't sum to 100 (when no conditions present)
+	// Warn if weights don't sum to 100 (when no conditions present).
+	// The config is still accepted - weights are normalized by the proxy.
 	unconditionalRoutes := 0
 	for _, dest := range r.Spec.Route {
 		if dest.Condition == nil {
 			unconditionalRoutes++
 		}
 	}
-	// Note: When unconditionalRoutes > 0 && totalWeight != 100 && unconditionalRoutes == len(r.Spec.Route),
-	// we accept it without warning - weights are normalized by the proxy.
+	var warnings []string
+	if unconditionalRoutes == len(r.Spec.Route) && totalWeight != 100 {
+		warnings = append(warnings, fmt.Sprintf(
+			"spec.route weights sum to %d, not 100; the proxy will normalize them proportionally", totalWeight))
+	}
 
-	return nil
+	return warnings, nil
 }
 
 // validateMatch validates the route match configuration
@@ -128,11 +174,25 @@ func (r *TermiteRoute) validateMatch() error {
 		}
 	}
 
-	// Validate model patterns (wildcards)
-	for i, pattern := range match.Models {
-		if pattern == "" {
+	// Validate model patterns (wildcards, optionally negated with a leading "!")
+	for i, entry := range match.Models {
+		if entry == "" {
 			return fmt.Errorf("spec.match.models[%d] cannot be empty", i)
 		}
+		pattern, negated := strings.CutPrefix(entry, "!")
+		if negated && pattern == "" {
+			return fmt.Errorf("spec.match.models[%d] negation '!' must be followed by a pattern", i)
+		}
+		// Validate regex patterns compile as written
+		if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+			if expr == "" {
+				return fmt.Errorf("spec.match.models[%d] regex prefix 're:' must be followed by an expression", i)
+			}
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("invalid model regex '%s': %v", expr, err)
+			}
+			continue
+		}
 		// Validate wildcard patterns are valid glob patterns
 		if strings.Contains(pattern, "*") {
 			// Convert glob to regex to validate
@@ -143,6 +203,14 @@ func (r *TermiteRoute) validateMatch() error {
 		}
 	}
 
+	// Validate percentage canary
+	if match.Percentage != nil && (*match.Percentage < 0 || *match.Percentage > 100) {
+		return fmt.Errorf("spec.match.percentage must be between 0 and 100, got %d", *match.Percentage)
+	}
+	if match.HashHeader != "" && match.Percentage == nil {
+		return fmt.Errorf("spec.match.hashHeader requires spec.match.percentage")
+	}
+
 	// Validate time window
 	if match.TimeWindow != nil {
 		if err := validateTimeWindow(match.TimeWindow); err != nil {
@@ -150,6 +218,13 @@ func (r *TermiteRoute) validateMatch() error {
 		}
 	}
 
+	// Validate source identities
+	if match.Source != nil {
+		if err := validateSourceMatch(match.Source); err != nil {
+			return fmt.Errorf("spec.match.source: %w", err)
+		}
+	}
+
 	// Validate header matchers
 	for header, matcher := range match.Headers {
 		if header == "" {
@@ -170,19 +245,49 @@ func (r *TermiteRoute) validateMatch() error {
 	return nil
 }
 
-// validateTimeWindow validates time window configuration
-func validateTimeWindow(tw *TimeWindowMatch) error {
-	// Validate time format (HH:MM)
-	timeRegex := regexp.MustCompile(`^([01]?[0-9]|2[0-3]):([0-5][0-9])$`)
+// validateSourceMatch validates source namespaces and service accounts
+func validateSourceMatch(source *SourceMatch) error {
+	for i, ns := range source.Namespaces {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("namespaces[%d] '%s' is not a valid namespace: %s", i, ns, strings.Join(errs, "; "))
+		}
+	}
+	for i, entry := range source.ServiceAccounts {
+		name := entry
+		if ns, sa, ok := strings.Cut(entry, "/"); ok {
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return fmt.Errorf("serviceAccounts[%d] '%s' has an invalid namespace: %s", i, entry, strings.Join(errs, "; "))
+			}
+			name = sa
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("serviceAccounts[%d] '%s' has an invalid name: %s", i, entry, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
 
-	if tw.Start != "" && !timeRegex.MatchString(tw.Start) {
+// timeOfDayRegex matches HH:MM times (UTC)
+var timeOfDayRegex = regexp.MustCompile(`^([01]?[0-9]|2[0-3]):([0-5][0-9])$`)
+
+// validateTimeWindow validates time window configuration. A window whose
+// start equals its end is rejected: it would never be active.
+func validateTimeWindow(tw *TimeWindowMatch) error {
+	if tw.Start != "" && !timeOfDayRegex.MatchString(tw.Start) {
 		return fmt.Errorf("start time '%s' is not in HH:MM format", tw.Start)
 	}
 
-	if tw.End != "" && !timeRegex.MatchString(tw.End) {
+	if tw.End != "" && !timeOfDayRegex.MatchString(tw.End) {
 		return fmt.Errorf("end time '%s' is not in HH:MM format", tw.End)
 	}
 
+	// Compare parsed times so that "9:00" and "09:00" are equal; an empty
+	// time is midnight, as in the proxy
+	if minutesOfDay(tw.Start) == minutesOfDay(tw.End) {
+		return fmt.Errorf("start and end times are both %02d:%02d; the window would never be active",
+			minutesOfDay(tw.Start)/60, minutesOfDay(tw.Start)%60)
+	}
+
 	// Validate days (0-6)
 	for _, day := range tw.Days {
 		if day < 0 || day > 6 {
@@ -193,10 +298,22 @@ func validateTimeWindow(tw *TimeWindowMatch) error {
 	return nil
 }
 
+// minutesOfDay converts a validated HH:MM time to minutes after midnight,
+// treating an empty time as midnight
+func minutesOfDay(hhmm string) int {
+	m := timeOfDayRegex.FindStringSubmatch(hhmm)
+	if m == nil {
+		return 0
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	return hours*60 + minutes
+}
+
 // validateFallback validates fallback configuration
-func (r *TermiteRoute) validateFallback() error {
+func (r *TermiteRoute) validateFallback() ([]string, error) {
 	if r.Spec.Fallback == nil {
-		return nil
+		return nil, nil
 	}
 
 	fb := r.Spec.Fallback
@@ -208,17 +325,27 @@ func (r *TermiteRoute) validateFallback() error {
 		FallbackActionRedirect: true,
 	}
 	if !validActions[fb.Action] {
-		return fmt.Errorf("invalid fallback action '%s'. Must be one of: queue, reject, redirect", fb.Action)
+		return nil, fmt.Errorf("invalid fallback action '%s'. Must be one of: queue, reject, redirect", fb.Action)
 	}
 
 	// Validate redirect pool is specified when action is redirect
 	if fb.Action == FallbackActionRedirect && fb.RedirectPool == "" {
-		return fmt.Errorf("spec.fallback.redirectPool is required when action is 'redirect'")
+		return nil, fmt.Errorf("spec.fallback.redirectPool is required when action is 'redirect'")
 	}
 
-	// Note: maxQueueTime is optional when action is queue - proxy will use default if not specified.
+	// Failing fast skips straight to the fallback, so it must not wait in a queue
+	if fb.FailFast && fb.Action == FallbackActionQueue {
+		return nil, fmt.Errorf("spec.fallback.failFast requires action 'reject' or 'redirect', not 'queue'")
+	}
 
-	return nil
+	// maxQueueTime is optional when action is queue - proxy will use default if not specified
+	var warnings []string
+	if fb.Action == FallbackActionQueue && fb.MaxQueueTime == nil {
+		warnings = append(warnings,
+			"spec.fallback.action is 'queue' without spec.fallback.maxQueueTime; the proxy default queue time will be used")
+	}
+
+	return warnings, nil
 }
 
 // validateRateLimiting validates rate limiting configuration
@@ -229,9 +356,15 @@ func (r *TermiteRoute) validateRateLimiting() error {
 
 	rl := r.Spec.RateLimiting
 
-	if rl.RequestsPerSecond <= 0 {
+	if rl.RequestsPerSecond < 0 {
 		return fmt.Errorf("spec.rateLimiting.requestsPerSecond must be > 0, got %d", rl.RequestsPerSecond)
 	}
+	if rl.RequestsPerMinute < 0 {
+		return fmt.Errorf("spec.rateLimiting.requestsPerMinute must be > 0, got %d", rl.RequestsPerMinute)
+	}
+	if (rl.RequestsPerSecond > 0) == (rl.RequestsPerMinute > 0) {
+		return fmt.Errorf("spec.rateLimiting must set exactly one of requestsPerSecond or requestsPerMinute")
+	}
 
 	if rl.BurstSize != nil && *rl.BurstSize < 0 {
 		return fmt.Errorf("spec.rateLimiting.burstSize must be >= 0, got %d", *rl.BurstSize)
@@ -271,3 +404,205 @@ func (r *TermiteRoute) validateRetry() error {
 
 	return nil
 }
+
+// reservedHeaders are headers the proxy manages itself when forwarding, so
+// routes may not modify them
+var reservedHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"transfer-encoding": true,
+	"connection":        true,
+}
+
+// validateHeaders validates header transformations
+func (r *TermiteRoute) validateHeaders() error {
+	if r.Spec.Headers == nil {
+		return nil
+	}
+
+	h := r.Spec.Headers
+	modified := make(map[string]string) // lowercased name -> field path
+	checkName := func(field, name string) error {
+		if name == "" {
+			return fmt.Errorf("%s cannot be empty", field)
+		}
+		if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 {
+			return fmt.Errorf("%s '%s' is not a valid header name: %s", field, name, strings.Join(errs, "; "))
+		}
+		if reservedHeaders[strings.ToLower(name)] {
+			return fmt.Errorf("%s '%s' is managed by the proxy and cannot be modified", field, name)
+		}
+		return nil
+	}
+
+	for i, hv := range h.Set {
+		field := fmt.Sprintf("spec.headers.set[%d].name", i)
+		if err := checkName(field, hv.Name); err != nil {
+			return err
+		}
+		key := strings.ToLower(hv.Name)
+		if prev, ok := modified[key]; ok {
+			return fmt.Errorf("%s '%s' is already set by %s", field, hv.Name, prev)
+		}
+		modified[key] = field
+	}
+	for i, hv := range h.Add {
+		if err := checkName(fmt.Sprintf("spec.headers.add[%d].name", i), hv.Name); err != nil {
+			return err
+		}
+		modified[strings.ToLower(hv.Name)] = fmt.Sprintf("spec.headers.add[%d].name", i)
+	}
+	for i, name := range h.Remove {
+		field := fmt.Sprintf("spec.headers.remove[%d]", i)
+		if err := checkName(field, name); err != nil {
+			return err
+		}
+		if prev, ok := modified[strings.ToLower(name)]; ok {
+			return fmt.Errorf("%s '%s' removes the header set by %s", field, name, prev)
+		}
+	}
+
+	return nil
+}
+
+// PriorityCollisionWarnings returns a warning for each of others that shares
+// the route's priority and may match the same requests. Ties are broken by
+// name, which may not be the order the user intended. Overlap is judged
+// conservatively: regexes and time windows are assumed to overlap.
+func (r *TermiteRoute) PriorityCollisionWarnings(others []TermiteRoute) admission.Warnings {
+	var warnings admission.Warnings
+	criteria := r.Spec.Match.criteria()
+	for i := range others {
+		other := &others[i]
+		if other.Namespace == r.Namespace && other.Name == r.Name {
+			continue
+		}
+		// Catch-all routes are evaluated after all others, whatever their priority
+		if other.Spec.Priority != r.Spec.Priority || other.Spec.Match.isCatchAll() != r.Spec.Match.isCatchAll() {
+			continue
+		}
+		if otherCriteria := other.Spec.Match.criteria(); !routematch.Overlap(&criteria, &otherCriteria) {
+			continue
+		}
+		first := r.routeName()
+		if name := other.routeName(); name < first {
+			first = name
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"spec.priority %d is shared by route %s, which may match the same requests; %s is evaluated first",
+			r.Spec.Priority, other.routeName(), first))
+	}
+	return warnings
+}
+
+// routeName is the route's name as the proxy orders it
+func (r *TermiteRoute) routeName() string {
+	return r.Namespace + "/" + r.Name
+}
+
+// isCatchAll reports whether the match has no conditions
+func (m *RouteMatch) isCatchAll() bool {
+	return len(m.Operations) == 0 && len(m.Models) == 0 && len(m.Headers) == 0 &&
+		m.Source == nil && m.TimeWindow == nil && m.Percentage == nil
+}
+
+// criteria returns the match conditions compared for overlap, compiling
+// the model patterns the way the proxy does. Patterns that fail to compile
+// may match any model.
+func (m *RouteMatch) criteria() routematch.Criteria {
+	var c routematch.Criteria
+	for _, op := range m.Operations {
+		c.Operations = append(c.Operations, string(op))
+	}
+	if m.Source != nil {
+		c.SourceTables = m.Source.Tables
+		c.SourceNamespaces = m.Source.Namespaces
+		c.SourceServiceAccounts = m.Source.ServiceAccounts
+	}
+	if len(m.Headers) > 0 {
+		c.Headers = make(map[string]routematch.StringMatch, len(m.Headers))
+		for header, hm := range m.Headers {
+			c.Headers[header] = routematch.StringMatch{Exact: hm.Exact, Prefix: hm.Prefix, Regex: hm.Regex != ""}
+		}
+	}
+	for _, entry := range m.Models {
+		if strings.HasPrefix(entry, "!") {
+			continue
+		}
+		re, err := regexp.Compile(routematch.ModelPatternExpr(entry))
+		if err != nil {
+			re = nil
+		}
+		c.Models = append(c.Models, re)
+	}
+	return c
+}
+
+// TermiteRouteValidator validates TermiteRoutes for an admission webhook.
+// Unlike TermiteRoute's own Validate methods, it reads the other routes to
+// warn about priority collisions.
+// +kubebuilder:object:generate=false
+type TermiteRouteValidator struct {
+	Reader client.Reader
+}
+
+var _ admission.CustomValidator = &TermiteRouteValidator{}
+
+// TermiteRouteWebhookPath is the path the TermiteRoute validating webhook is
+// served on
+const TermiteRouteWebhookPath = "/validate-antfly-io-v1alpha1-termiteroute"
+
+// SetupWebhookWithManager registers the TermiteRoute validating webhook with
+// the manager's webhook server
+func (r *TermiteRoute) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&TermiteRouteValidator{Reader: mgr.GetClient()}).
+		Complete()
+}
+
+// ValidateCreate validates a new route, warning about the routes it
+// collides with
+func (v *TermiteRouteValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	route, ok := obj.(*TermiteRoute)
+	if !ok {
+		return nil, fmt.Errorf("expected a TermiteRoute, got %T", obj)
+	}
+	warnings, err := route.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	return v.withCollisionWarnings(ctx, route, warnings)
+}
+
+// ValidateUpdate validates an updated route, warning about the routes it
+// collides with
+func (v *TermiteRouteValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	route, ok := newObj.(*TermiteRoute)
+	if !ok {
+		return nil, fmt.Errorf("expected a TermiteRoute, got %T", newObj)
+	}
+	warnings, err := route.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+	return v.withCollisionWarnings(ctx, route, warnings)
+}
+
+// ValidateDelete validates route deletion (no validation needed)
+func (v *TermiteRouteValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// withCollisionWarnings appends the priority collision warnings of route to
+// warnings. The warnings are advisory, so failing to list the other routes
+// is logged rather than rejecting the route.
+func (v *TermiteRouteValidator) withCollisionWarnings(ctx context.Context, route *TermiteRoute, warnings admission.Warnings) (admission.Warnings, error) {
+	routes := &TermiteRouteList{}
+	if err := v.Reader.List(ctx, routes); err != nil {
+		logf.FromContext(ctx).Error(err, "listing TermiteRoutes for priority collision warnings",
+			"namespace", route.Namespace, "name", route.Name)
+		return warnings, nil
+	}
+	return append(warnings, route.PriorityCollisionWarnings(routes.Items)...), nil
+}
@@ -0,0 +1,460 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestTermiteRouteValidateCreate_Warnings(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        TermiteRouteSpec
+		wantWarning string
+	}{
+		{
+			name: "weights sum to 100",
+			spec: TermiteRouteSpec{
+				Route: []RouteDestination{{Pool: "a", Weight: 70}, {Pool: "b", Weight: 30}},
+			},
+		},
+		{
+			name: "weights not summing to 100",
+			spec: TermiteRouteSpec{
+				Route: []RouteDestination{{Pool: "a", Weight: 50}, {Pool: "b", Weight: 30}},
+			},
+			wantWarning: "spec.route weights sum to 80",
+		},
+		{
+			name: "queue fallback without maxQueueTime",
+			spec: TermiteRouteSpec{
+				Route:    []RouteDestination{{Pool: "a", Weight: 100}},
+				Fallback: &RouteFallback{Action: FallbackActionQueue},
+			},
+			wantWarning: "without spec.fallback.maxQueueTime",
+		},
+		{
+			name: "queue fallback with maxQueueTime",
+			spec: TermiteRouteSpec{
+				Route: []RouteDestination{{Pool: "a", Weight: 100}},
+				Fallback: &RouteFallback{
+					Action:       FallbackActionQueue,
+					MaxQueueTime: &metav1.Duration{Duration: 5 * time.Second},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: tt.spec}
+			warnings, err := route.ValidateCreate()
+			if err != nil {
+				t.Fatalf("expected route to be accepted, got: %v", err)
+			}
+			if tt.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warnings, got: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarning) {
+				t.Fatalf("expected one warning containing %q, got: %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_ErrorsKeepWarnings(t *testing.T) {
+	route := &TermiteRoute{Spec: TermiteRouteSpec{
+		Route:    []RouteDestination{{Pool: "a", Weight: 50}},
+		Fallback: &RouteFallback{Action: FallbackActionRedirect},
+	}}
+
+	warnings, err := route.ValidateCreate()
+	if err == nil || !strings.Contains(err.Error(), "redirectPool is required") {
+		t.Fatalf("expected redirectPool error, got: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected weight warning alongside the error, got: %v", warnings)
+	}
+}
+
+func TestTermiteRouteValidateCreate_NegatedModelPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  []string
+		wantErr string
+	}{
+		{name: "include and exclude", models: []string{"bge-*", "!bge-large"}},
+		{name: "exclude only", models: []string{"!*-rerank-*"}},
+		{name: "bare negation", models: []string{"bge-*", "!"}, wantErr: "spec.match.models[1] negation '!' must be followed by a pattern"},
+		{name: "regex", models: []string{"re:^(bge|gte)-.*$"}},
+		{name: "negated regex", models: []string{"bge-*", "!re:-(large|xl)$"}},
+		{name: "invalid regex", models: []string{"re:^(bge|gte-.*$"}, wantErr: "invalid model regex '^(bge|gte-.*$'"},
+		{name: "empty regex", models: []string{"re:"}, wantErr: "spec.match.models[0] regex prefix 're:' must be followed by an expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: TermiteRouteSpec{
+				Match: RouteMatch{Models: tt.models},
+				Route: []RouteDestination{{Pool: "a", Weight: 100}},
+			}}
+			_, err := route.ValidateCreate()
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_Percentage(t *testing.T) {
+	valid := int32(5)
+	invalid := int32(150)
+	tests := []struct {
+		name    string
+		match   RouteMatch
+		wantErr string
+	}{
+		{name: "percentage with hash header", match: RouteMatch{Percentage: &valid, HashHeader: "X-Request-Id"}},
+		{name: "percentage without hash header", match: RouteMatch{Percentage: &valid}},
+		{name: "percentage out of range", match: RouteMatch{Percentage: &invalid}, wantErr: "spec.match.percentage must be between 0 and 100"},
+		{name: "hash header without percentage", match: RouteMatch{HashHeader: "X-Request-Id"}, wantErr: "spec.match.hashHeader requires spec.match.percentage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: TermiteRouteSpec{
+				Match: tt.match,
+				Route: []RouteDestination{{Pool: "canary", Weight: 100}},
+			}}
+			_, err := route.ValidateCreate()
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_SourceMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  SourceMatch
+		wantErr string
+	}{
+		{name: "namespaces and service accounts", source: SourceMatch{
+			Namespaces:      []string{"search", "batch-jobs"},
+			ServiceAccounts: []string{"indexer", "search/query-api"},
+		}},
+		{name: "invalid namespace", source: SourceMatch{Namespaces: []string{"Search"}}, wantErr: "namespaces[0] 'Search' is not a valid namespace"},
+		{name: "invalid service account", source: SourceMatch{ServiceAccounts: []string{"indexer_sa"}}, wantErr: "serviceAccounts[0] 'indexer_sa' has an invalid name"},
+		{name: "invalid service account namespace", source: SourceMatch{ServiceAccounts: []string{"/indexer"}}, wantErr: "serviceAccounts[0] '/indexer' has an invalid namespace"},
+		{name: "empty service account name", source: SourceMatch{ServiceAccounts: []string{"search/"}}, wantErr: "serviceAccounts[0] 'search/' has an invalid name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: TermiteRouteSpec{
+				Match: RouteMatch{Source: &tt.source},
+				Route: []RouteDestination{{Pool: "default", Weight: 100}},
+			}}
+			_, err := route.ValidateCreate()
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_MetricCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  *MetricCondition
+		wantErr string
+	}{
+		{name: "valid", metric: &MetricCondition{Query: `avg(gpu_util{pool="$pool"})`, Threshold: "<80"}},
+		{name: "missing query", metric: &MetricCondition{Threshold: "<80"}, wantErr: "condition.metric.query is required"},
+		{name: "threshold with unit", metric: &MetricCondition{Query: "up", Threshold: ">100ms"}, wantErr: "condition.metric.threshold '>100ms'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: TermiteRouteSpec{
+				Route: []RouteDestination{{
+					Pool:      "gpu",
+					Weight:    100,
+					Condition: &RouteCondition{Metric: tt.metric},
+				}},
+			}}
+			_, err := route.ValidateCreate()
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_RateLimiting(t *testing.T) {
+	tests := []struct {
+		name    string
+		rl      *RouteRateLimiting
+		wantErr string
+	}{
+		{name: "requests per second", rl: &RouteRateLimiting{RequestsPerSecond: 10}},
+		{name: "requests per minute", rl: &RouteRateLimiting{RequestsPerMinute: 12}},
+		{name: "neither set", rl: &RouteRateLimiting{}, wantErr: "exactly one of requestsPerSecond or requestsPerMinute"},
+		{name: "both set", rl: &RouteRateLimiting{RequestsPerSecond: 1, RequestsPerMinute: 12}, wantErr: "exactly one of requestsPerSecond or requestsPerMinute"},
+		{name: "negative per minute", rl: &RouteRateLimiting{RequestsPerMinute: -1}, wantErr: "requestsPerMinute must be > 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: TermiteRouteSpec{
+				Route:        []RouteDestination{{Pool: "a", Weight: 100}},
+				RateLimiting: tt.rl,
+			}}
+			_, err := route.ValidateCreate()
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_Headers(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers *RouteHeaders
+		wantErr string
+	}{
+		{name: "valid", headers: &RouteHeaders{
+			Add:    []HeaderValue{{Name: "X-Model-Variant", Value: "int8"}},
+			Set:    []HeaderValue{{Name: "X-Tenant", Value: "search"}},
+			Remove: []string{"Authorization"},
+		}},
+		{name: "invalid name", headers: &RouteHeaders{Set: []HeaderValue{{Name: "X Tenant", Value: "a"}}}, wantErr: "spec.headers.set[0].name 'X Tenant' is not a valid header name"},
+		{name: "empty remove", headers: &RouteHeaders{Remove: []string{""}}, wantErr: "spec.headers.remove[0] cannot be empty"},
+		{name: "reserved", headers: &RouteHeaders{Set: []HeaderValue{{Name: "Host", Value: "b"}}}, wantErr: "managed by the proxy"},
+		{name: "duplicate set", headers: &RouteHeaders{Set: []HeaderValue{{Name: "X-A", Value: "1"}, {Name: "x-a", Value: "2"}}}, wantErr: "already set by spec.headers.set[0].name"},
+		{name: "removed and added", headers: &RouteHeaders{
+			Add:    []HeaderValue{{Name: "X-A", Value: "1"}},
+			Remove: []string{"x-a"},
+		}, wantErr: "removes the header set by spec.headers.add[0].name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &TermiteRoute{Spec: TermiteRouteSpec{
+				Route:   []RouteDestination{{Pool: "a", Weight: 100}},
+				Headers: tt.headers,
+			}}
+			_, err := route.ValidateCreate()
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_TimeWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  TimeWindowMatch
+		wantErr string
+	}{
+		{name: "normal", window: TimeWindowMatch{Start: "09:00", End: "17:00"}},
+		{name: "overnight", window: TimeWindowMatch{Start: "22:00", End: "06:00", Days: []int{5}}},
+		{name: "equal", window: TimeWindowMatch{Start: "09:00", End: "09:00"}, wantErr: "start and end times are both 09:00"},
+		{name: "equal unpadded", window: TimeWindowMatch{Start: "9:00", End: "09:00"}, wantErr: "never be active"},
+		{name: "equal midnight", window: TimeWindowMatch{Start: "00:00"}, wantErr: "start and end times are both 00:00"},
+		{name: "bad format", window: TimeWindowMatch{Start: "24:00", End: "06:00"}, wantErr: "not in HH:MM format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, route := range []*TermiteRoute{
+				{Spec: TermiteRouteSpec{
+					Match: RouteMatch{TimeWindow: &tt.window},
+					Route: []RouteDestination{{Pool: "a", Weight: 100}},
+				}},
+				{Spec: TermiteRouteSpec{
+					Route: []RouteDestination{{Pool: "a", Weight: 100, Condition: &RouteCondition{TimeOfDay: &tt.window}}},
+				}},
+			} {
+				_, err := route.ValidateCreate()
+				checkValidationError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTermiteRouteValidateCreate_FailFast(t *testing.T) {
+	for action, wantErr := range map[FallbackAction]bool{
+		FallbackActionReject:   false,
+		FallbackActionRedirect: false,
+		FallbackActionQueue:    true,
+	} {
+		route := &TermiteRoute{Spec: TermiteRouteSpec{
+			Route:    []RouteDestination{{Pool: "gpu", Weight: 100}, {Pool: "cpu", Weight: 0}},
+			Fallback: &RouteFallback{Action: action, RedirectPool: "cpu", FailFast: true},
+		}}
+		_, err := route.ValidateCreate()
+		if wantErr && (err == nil || !strings.Contains(err.Error(), "spec.fallback.failFast requires action")) {
+			t.Errorf("action %s: expected failFast error, got: %v", action, err)
+		} else if !wantErr && err != nil {
+			t.Errorf("action %s: expected no error, got: %v", action, err)
+		}
+	}
+}
+
+func TestTermiteRouteValidateCreate_CatchAll(t *testing.T) {
+	route := &TermiteRoute{Spec: TermiteRouteSpec{
+		Route: []RouteDestination{{Pool: "default", Weight: 100}},
+	}}
+	warnings, err := route.ValidateCreate()
+	if err != nil {
+		t.Fatalf("expected an empty match to validate, got: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got: %v", warnings)
+	}
+}
+
+func TestTermiteRoutePriorityCollisionWarnings(t *testing.T) {
+	newRoute := func(name string, priority int32, match RouteMatch) TermiteRoute {
+		return TermiteRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: TermiteRouteSpec{
+				Priority: priority,
+				Match:    match,
+				Route:    []RouteDestination{{Pool: "a", Weight: 100}},
+			},
+		}
+	}
+	bge := RouteMatch{Operations: []OperationType{OperationEmbed}, Models: []string{"bge-*"}}
+
+	tests := []struct {
+		name        string
+		match       RouteMatch
+		other       TermiteRoute
+		wantWarning string
+	}{
+		{
+			name:        "same priority with overlapping models",
+			match:       bge,
+			other:       newRoute("a-small", 100, RouteMatch{Models: []string{"bge-small"}}),
+			wantWarning: "spec.priority 100 is shared by route default/a-small",
+		},
+		{
+			name:  "different priority",
+			match: bge,
+			other: newRoute("a-small", 50, RouteMatch{Models: []string{"bge-small"}}),
+		},
+		{
+			name:  "disjoint models",
+			match: bge,
+			other: newRoute("a-gte", 100, RouteMatch{Models: []string{"gte-*"}}),
+		},
+		{
+			name:  "disjoint operations",
+			match: bge,
+			other: newRoute("a-rerank", 100, RouteMatch{Operations: []OperationType{OperationRerank}}),
+		},
+		{
+			name:  "different exact headers",
+			match: RouteMatch{Headers: map[string]StringMatch{"X-Tenant": {Exact: "team-1"}}},
+			other: newRoute("a-tenant", 100, RouteMatch{Headers: map[string]StringMatch{"X-Tenant": {Exact: "team-2"}}}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := newRoute("b-bge", 100, tt.match)
+			// The route itself appears in the list and must be skipped
+			warnings := route.PriorityCollisionWarnings([]TermiteRoute{route, tt.other})
+			if tt.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Errorf("expected no warnings, got: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarning) {
+				t.Fatalf("expected one warning containing %q, got: %v", tt.wantWarning, warnings)
+			}
+			if !strings.Contains(warnings[0], "default/a-small is evaluated first") {
+				t.Errorf("expected warning to name the route evaluated first, got: %q", warnings[0])
+			}
+		})
+	}
+}
+
+func TestTermiteRouteValidator_PriorityCollisionWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("adding to scheme: %v", err)
+	}
+	existing := &TermiteRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-small", Namespace: "default"},
+		Spec: TermiteRouteSpec{
+			Priority: 100,
+			Match:    RouteMatch{Models: []string{"bge-small"}},
+			Route:    []RouteDestination{{Pool: "a", Weight: 100}},
+		},
+	}
+	validator := &TermiteRouteValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build(),
+	}
+
+	route := &TermiteRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "b-bge", Namespace: "default"},
+		Spec: TermiteRouteSpec{
+			Priority: 100,
+			Match:    RouteMatch{Models: []string{"bge-*"}},
+			Route:    []RouteDestination{{Pool: "a", Weight: 100}},
+		},
+	}
+	warnings, err := validator.ValidateCreate(context.Background(), route)
+	if err != nil {
+		t.Fatalf("ValidateCreate: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "shared by route default/a-small") {
+		t.Errorf("ValidateCreate warnings = %v, want the collision with default/a-small", warnings)
+	}
+
+	warnings, err = validator.ValidateUpdate(context.Background(), route.DeepCopy(), route)
+	if err != nil {
+		t.Fatalf("ValidateUpdate: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("ValidateUpdate warnings = %v, want the collision with default/a-small", warnings)
+	}
+
+	// Invalid routes are rejected before other routes are read
+	route.Spec.Route = nil
+	if _, err := validator.ValidateCreate(context.Background(), route); err == nil {
+		t.Error("expected an error for a route without destinations")
+	}
+
+	// Failing to read the other routes does not reject a valid route
+	route.Spec.Route = []RouteDestination{{Pool: "a", Weight: 100}}
+	validator.Reader = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+			return errors.New("cache not synced")
+		},
+	}).Build()
+	warnings, err = validator.ValidateCreate(context.Background(), route)
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("ValidateCreate with a failing List = %v, %v; want no warnings and no error", warnings, err)
+	}
+}
@@ -192,6 +192,21 @@ func (in *HardwareConfig) DeepCopy() *HardwareConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderValue) DeepCopyInto(out *HeaderValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValue.
+func (in *HeaderValue) DeepCopy() *HeaderValue {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadedModelStatus) DeepCopyInto(out *LoadedModelStatus) {
 	*out = *in
@@ -207,6 +222,21 @@ func (in *LoadedModelStatus) DeepCopy() *LoadedModelStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricCondition) DeepCopyInto(out *MetricCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricCondition.
+func (in *MetricCondition) DeepCopy() *MetricCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelConfig) DeepCopyInto(out *ModelConfig) {
 	*out = *in
@@ -362,6 +392,11 @@ func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteCondition) DeepCopyInto(out *RouteCondition) {
 	*out = *in
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(MetricCondition)
+		**out = **in
+	}
 	if in.ModelLoaded != nil {
 		in, out := &in.ModelLoaded, &out.ModelLoaded
 		*out = new(bool)
@@ -429,6 +464,36 @@ func (in *RouteFallback) DeepCopy() *RouteFallback {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteHeaders) DeepCopyInto(out *RouteHeaders) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]HeaderValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make([]HeaderValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteHeaders.
+func (in *RouteHeaders) DeepCopy() *RouteHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMatch) DeepCopyInto(out *RouteMatch) {
 	*out = *in
@@ -459,6 +524,11 @@ func (in *RouteMatch) DeepCopyInto(out *RouteMatch) {
 		*out = new(TimeWindowMatch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMatch.
@@ -519,6 +589,11 @@ func (in *RouteRetry) DeepCopy() *RouteRetry {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 	if in.DrainTimeout != nil {
 		in, out := &in.DrainTimeout, &out.DrainTimeout
 		*out = new(metav1.Duration)
@@ -885,6 +960,11 @@ func (in *TermiteRouteSpec) DeepCopyInto(out *TermiteRouteSpec) {
 		*out = new(RouteRetry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(RouteHeaders)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TermiteRouteSpec.
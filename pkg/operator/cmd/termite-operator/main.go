@@ -30,6 +30,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	antflyaiv1alpha1 "github.com/antflydb/termite/pkg/operator/api/v1alpha1"
 	"github.com/antflydb/termite/pkg/operator/controllers"
@@ -108,6 +109,9 @@ Examples:
 	cmd.Flags().String("metrics-bind-address", ":8080", "The address the metric endpoint binds to")
 	cmd.Flags().String("health-probe-bind-address", ":8081", "The address the probe endpoint binds to")
 	cmd.Flags().Bool("leader-elect", false, "Enable leader election for controller manager")
+	cmd.Flags().Bool("enable-webhooks", false, "Serve the admission webhooks (requires a serving certificate in --webhook-cert-dir)")
+	cmd.Flags().Int("webhook-port", 9443, "The port the admission webhook server binds to")
+	cmd.Flags().String("webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "Directory holding the webhook server's tls.crt and tls.key")
 
 	// Operator-specific flags
 	cmd.Flags().String("termite-image", "antfly/termite:latest", "Default Termite container image")
@@ -118,6 +122,9 @@ Examples:
 	mustBindFlag(cmd, "metrics-bind-address", "metrics_bind_address")
 	mustBindFlag(cmd, "health-probe-bind-address", "health_probe_bind_address")
 	mustBindFlag(cmd, "leader-elect", "leader_elect")
+	mustBindFlag(cmd, "enable-webhooks", "enable_webhooks")
+	mustBindFlag(cmd, "webhook-port", "webhook_port")
+	mustBindFlag(cmd, "webhook-cert-dir", "webhook_cert_dir")
 	mustBindFlag(cmd, "termite-image", "termite_image")
 
 	return cmd
@@ -168,6 +175,7 @@ func runOperator(cmd *cobra.Command, args []string) error {
 	metricsAddr := viper.GetString("metrics_bind_address")
 	probeAddr := viper.GetString("health_probe_bind_address")
 	enableLeaderElection := viper.GetBool("leader_elect")
+	enableWebhooks := viper.GetBool("enable_webhooks")
 	termiteImage := viper.GetString("termite_image")
 
 	// Setup logger using antfly's logging package for consistency
@@ -191,6 +199,10 @@ func runOperator(cmd *cobra.Command, args []string) error {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "termite-operator.antfly.io",
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    viper.GetInt("webhook_port"),
+			CertDir: viper.GetString("webhook_cert_dir"),
+		}),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to start manager: %w", err)
@@ -213,6 +225,14 @@ func runOperator(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to create TermiteRoute controller: %w", err)
 	}
 
+	// Setup admission webhooks, which are opt-in since they need a serving
+	// certificate
+	if enableWebhooks {
+		if err := (&antflyaiv1alpha1.TermiteRoute{}).SetupWebhookWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create TermiteRoute webhook: %w", err)
+		}
+	}
+
 	// Setup health checks
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return fmt.Errorf("unable to set up health check: %w", err)
@@ -225,6 +245,7 @@ func runOperator(cmd *cobra.Command, args []string) error {
 		"metricsAddr", metricsAddr,
 		"probeAddr", probeAddr,
 		"leaderElection", enableLeaderElection,
+		"webhooks", enableWebhooks,
 		"termiteImage", termiteImage,
 	)
 
@@ -87,6 +87,10 @@ var _ = Describe("TermitePool Controller", func() {
 			Expect(createdService.Spec.Ports[0].Name).To(Equal("http"))
 			Expect(createdService.Spec.Ports[0].Port).To(Equal(int32(TermiteAPIPort)))
 
+			// Deleting the pool garbage collects the Service and its
+			// EndpointSlices, which the proxy watches to drop the endpoints
+			Expect(metav1.IsControlledBy(createdService, createdPool)).To(BeTrue())
+
 			// Verify the ConfigMap was created
 			configMapLookupKey := types.NamespacedName{Name: poolName + "-config", Namespace: poolNamespace}
 			createdConfigMap := &corev1.ConfigMap{}
@@ -37,4 +37,20 @@
 //	// Get individual resources
 //	sa := manifests.ServiceAccount()
 //	role := manifests.ClusterRole()
+//
+// The operator can also run in a read-only mode that only watches resources
+// and reports status, using a least-privilege ClusterRole:
+//
+//	resources := manifests.AllRBACResourcesForMode(manifests.RBACModeReadOnly)
+//	role := manifests.ReadOnlyClusterRole()
+//
+// # Install Bundle
+//
+// A complete install (namespace, CRDs, RBAC, operator and proxy) can be
+// rendered as one multi-document YAML:
+//
+//	bundle, err := manifests.InstallBundleYAML(manifests.InstallOptions{
+//		Namespace:     "termite-system",
+//		OperatorImage: "ghcr.io/antflydb/termite-operator:v1.0.0",
+//	})
 package manifests
@@ -0,0 +1,391 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+// Default images used by the install bundle
+const (
+	DefaultOperatorImage = "ghcr.io/antflydb/termite-operator:latest"
+	DefaultProxyImage    = "ghcr.io/antflydb/termite-proxy:latest"
+	DefaultTermiteImage  = "ghcr.io/antflydb/termite:xla-tpu"
+)
+
+// Workload names used by the install bundle
+const (
+	// OperatorDeploymentName is the name of the operator's Deployment.
+	OperatorDeploymentName = "termite-operator"
+
+	// ProxyDeploymentName is the name of the proxy's Deployment and Service.
+	ProxyDeploymentName = "termite-proxy"
+)
+
+// InstallOptions parameterizes the install bundle. Zero values use defaults.
+type InstallOptions struct {
+	// Namespace to install the operator and proxy into (default: OperatorNamespace)
+	Namespace string
+
+	// OperatorImage is the operator container image (default: DefaultOperatorImage)
+	OperatorImage string
+
+	// ProxyImage is the proxy container image (default: DefaultProxyImage)
+	ProxyImage string
+
+	// TermiteImage is the default image for TermitePools (default: DefaultTermiteImage)
+	TermiteImage string
+
+	// RBACMode selects the operator ClusterRole (default: RBACModeFull)
+	RBACMode RBACMode
+
+	// EnableWebhooks serves the operator's admission webhooks, which warn
+	// about TermiteRoute priority collisions. It requires cert-manager.
+	EnableWebhooks bool
+}
+
+func (o InstallOptions) withDefaults() InstallOptions {
+	if o.Namespace == "" {
+		o.Namespace = OperatorNamespace
+	}
+	if o.OperatorImage == "" {
+		o.OperatorImage = DefaultOperatorImage
+	}
+	if o.ProxyImage == "" {
+		o.ProxyImage = DefaultProxyImage
+	}
+	if o.TermiteImage == "" {
+		o.TermiteImage = DefaultTermiteImage
+	}
+	return o
+}
+
+// OperatorDeployment returns the Deployment running the Termite operator.
+func OperatorDeployment(opts InstallOptions) *appsv1.Deployment {
+	opts = opts.withDefaults()
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "termite-operator",
+		"app.kubernetes.io/component":  "controller",
+		"app.kubernetes.io/part-of":    "termite-operator",
+		"app.kubernetes.io/managed-by": "termite-operator",
+	}
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      OperatorDeploymentName,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "termite-operator",
+					"app.kubernetes.io/component": "controller",
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/default-container": "termite-operator-manager",
+						"prometheus.io/scrape":                    "true",
+						"prometheus.io/port":                      "8080",
+						"prometheus.io/path":                      "/metrics",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:            ServiceAccountName,
+					SecurityContext:               restrictedPodSecurityContext(),
+					TerminationGracePeriodSeconds: ptr.To[int64](10),
+					Containers: []corev1.Container{
+						{
+							Name:            "termite-operator-manager",
+							Image:           opts.OperatorImage,
+							ImagePullPolicy: corev1.PullAlways,
+							Env: []corev1.EnvVar{
+								{Name: "TERMITE_OPERATOR_METRICS_BIND_ADDRESS", Value: ":8080"},
+								{Name: "TERMITE_OPERATOR_HEALTH_PROBE_BIND_ADDRESS", Value: ":8081"},
+								{Name: "TERMITE_OPERATOR_LEADER_ELECT", Value: "true"},
+								{Name: "TERMITE_OPERATOR_TERMITE_IMAGE", Value: opts.TermiteImage},
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: "metrics", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+								{Name: "health", ContainerPort: 8081, Protocol: corev1.ProtocolTCP},
+							},
+							Resources:       smallResources(),
+							LivenessProbe:   httpProbe("/healthz", 15, 20, 5, 3),
+							ReadinessProbe:  httpProbe("/readyz", 5, 10, 5, 3),
+							SecurityContext: restrictedContainerSecurityContext(),
+						},
+					},
+				},
+			},
+		},
+	}
+	if opts.EnableWebhooks {
+		pod := &deployment.Spec.Template.Spec
+		container := &pod.Containers[0]
+		container.Env = append(container.Env, corev1.EnvVar{Name: "TERMITE_OPERATOR_ENABLE_WEBHOOKS", Value: "true"})
+		container.Ports = append(container.Ports,
+			corev1.ContainerPort{Name: "webhook", ContainerPort: webhookPort, Protocol: corev1.ProtocolTCP})
+		container.VolumeMounts = append(container.VolumeMounts,
+			corev1.VolumeMount{Name: "webhook-cert", MountPath: webhookCertDir, ReadOnly: true})
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: "webhook-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: WebhookCertificateName},
+			},
+		})
+	}
+	return deployment
+}
+
+// ProxyDeployment returns the Deployment running the Termite proxy.
+func ProxyDeployment(opts InstallOptions) *appsv1.Deployment {
+	opts = opts.withDefaults()
+	labels := proxyLabels()
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ProxyDeploymentName,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](3),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "termite-proxy",
+					"app.kubernetes.io/component": "proxy",
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "4200",
+						"prometheus.io/path":   "/metrics",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:            ProxyServiceAccountName,
+					SecurityContext:               restrictedPodSecurityContext(),
+					TerminationGracePeriodSeconds: ptr.To[int64](30),
+					Containers: []corev1.Container{
+						{
+							Name:            "proxy",
+							Image:           opts.ProxyImage,
+							ImagePullPolicy: corev1.PullAlways,
+							Env: []corev1.EnvVar{
+								{Name: "TERMITE_PROXY_LISTEN", Value: ":8080"},
+								{Name: "TERMITE_PROXY_HEALTH_PORT", Value: "4200"},
+								{Name: "TERMITE_PROXY_REFRESH_INTERVAL", Value: "10s"},
+								{
+									Name: "TERMITE_PROXY_NAMESPACE",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+									},
+								},
+								{Name: "TERMITE_PROXY_SELECTOR", Value: "app.kubernetes.io/name=termite"},
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: "http", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+								{Name: "health", ContainerPort: 4200, Protocol: corev1.ProtocolTCP},
+							},
+							Resources:       smallResources(),
+							LivenessProbe:   httpProbe("/healthz", 5, 10, 5, 3),
+							ReadinessProbe:  httpProbe("/readyz", 5, 5, 3, 2),
+							SecurityContext: restrictedContainerSecurityContext(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ProxyService returns the ClusterIP Service in front of the Termite proxy.
+func ProxyService(opts InstallOptions) *corev1.Service {
+	opts = opts.withDefaults()
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ProxyDeploymentName,
+			Namespace: opts.Namespace,
+			Labels:    proxyLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{"app.kubernetes.io/name": "termite-proxy"},
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+				{Name: "health", Port: 4200, TargetPort: intstr.FromString("health")},
+			},
+		},
+	}
+}
+
+// InstallBundleYAML returns a multi-document YAML that installs the Termite
+// operator and proxy, in apply order: namespace, CRDs, RBAC, then workloads.
+// With EnableWebhooks, the webhook Service, its cert-manager certificate and
+// the ValidatingWebhookConfiguration follow.
+func InstallBundleYAML(opts InstallOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	namespace := Namespace()
+	namespace.Name = opts.Namespace
+	head, err := MarshalYAMLDocuments(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	resources := []any{
+		ServiceAccount(),
+		ProxyServiceAccount(),
+		ClusterRoleForMode(opts.RBACMode),
+		ProxyClusterRole(),
+		ClusterRoleBinding(),
+		ProxyClusterRoleBinding(),
+		LeaderElectionRole(),
+		LeaderElectionRoleBinding(),
+	}
+	for _, obj := range resources {
+		setNamespace(obj, opts.Namespace)
+	}
+	resources = append(resources,
+		OperatorDeployment(opts),
+		ProxyDeployment(opts),
+		ProxyService(opts),
+	)
+	if opts.EnableWebhooks {
+		resources = append(resources, WebhookService(opts))
+		resources = append(resources, WebhookCertificateResources(opts)...)
+		resources = append(resources, ValidatingWebhookConfiguration(opts))
+	}
+	tail, err := MarshalYAMLDocuments(resources...)
+	if err != nil {
+		return "", err
+	}
+
+	return head + "---\n" + strings.TrimPrefix(AllCRDsYAML(), "---\n") + "\n---\n" + tail, nil
+}
+
+// MarshalYAMLDocuments marshals objects into a single multi-document YAML
+// string, separated by "---" in the order given.
+func MarshalYAMLDocuments(objs ...any) (string, error) {
+	docs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", fmt.Errorf("marshaling %T: %w", obj, err)
+		}
+		docs = append(docs, string(data))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// setNamespace moves a namespaced RBAC object, and any ServiceAccount
+// subjects it binds, into namespace.
+func setNamespace(obj any, namespace string) {
+	switch o := obj.(type) {
+	case *corev1.ServiceAccount:
+		o.Namespace = namespace
+	case *rbacv1.Role:
+		o.Namespace = namespace
+	case *rbacv1.RoleBinding:
+		o.Namespace = namespace
+		setSubjectNamespace(o.Subjects, namespace)
+	case *rbacv1.ClusterRoleBinding:
+		setSubjectNamespace(o.Subjects, namespace)
+	}
+}
+
+func setSubjectNamespace(subjects []rbacv1.Subject, namespace string) {
+	for i := range subjects {
+		if subjects[i].Kind == "ServiceAccount" {
+			subjects[i].Namespace = namespace
+		}
+	}
+}
+
+func proxyLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "termite-proxy",
+		"app.kubernetes.io/component":  "proxy",
+		"app.kubernetes.io/part-of":    "termite-operator",
+		"app.kubernetes.io/managed-by": "termite-operator",
+	}
+}
+
+func restrictedPodSecurityContext() *corev1.PodSecurityContext {
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+func restrictedContainerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		ReadOnlyRootFilesystem:   ptr.To(true),
+	}
+}
+
+func smallResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+		},
+	}
+}
+
+func httpProbe(path string, initialDelay, period, timeout, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromString("health")},
+		},
+		InitialDelaySeconds: initialDelay,
+		PeriodSeconds:       period,
+		TimeoutSeconds:      timeout,
+		FailureThreshold:    failureThreshold,
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/antflydb/termite/pkg/operator/api/v1alpha1"
+)
+
+// fakeApplier applies objects in order, rejecting any that depend on a
+// namespace or CRD that has not been applied yet, like a real API server would.
+type fakeApplier struct {
+	namespaces map[string]bool
+	crds       bool
+	applied    []*unstructured.Unstructured
+}
+
+func (f *fakeApplier) apply(obj *unstructured.Unstructured) error {
+	if ns := obj.GetNamespace(); ns != "" && !f.namespaces[ns] {
+		return fmt.Errorf("%s/%s: namespace %q not found", obj.GetKind(), obj.GetName(), ns)
+	}
+	switch obj.GetKind() {
+	case "Namespace":
+		f.namespaces[obj.GetName()] = true
+	case "CustomResourceDefinition":
+		f.crds = true
+	case "Deployment":
+		if !f.crds {
+			return fmt.Errorf("deployment %s applied before CRDs", obj.GetName())
+		}
+	}
+	f.applied = append(f.applied, obj)
+	return nil
+}
+
+func applyBundle(t *testing.T, bundle string) *fakeApplier {
+	t.Helper()
+	applier := &fakeApplier{namespaces: map[string]bool{}}
+	for doc := range strings.SplitSeq(bundle, "\n---\n") {
+		if strings.TrimSpace(strings.TrimPrefix(doc, "---")) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &obj.Object); err != nil {
+			t.Fatalf("parsing bundle document: %v\n%s", err, doc)
+		}
+		if err := applier.apply(obj); err != nil {
+			t.Fatalf("applying bundle: %v", err)
+		}
+	}
+	return applier
+}
+
+func TestInstallBundleYAML(t *testing.T) {
+	bundle, err := InstallBundleYAML(InstallOptions{
+		Namespace:     "termite-system",
+		OperatorImage: "example.com/termite-operator:v1",
+		ProxyImage:    "example.com/termite-proxy:v1",
+	})
+	if err != nil {
+		t.Fatalf("InstallBundleYAML: %v", err)
+	}
+
+	applied := applyBundle(t, bundle).applied
+
+	var kinds []string
+	for _, obj := range applied {
+		kinds = append(kinds, obj.GetKind())
+	}
+	wantKinds := []string{
+		"Namespace",
+		"CustomResourceDefinition", "CustomResourceDefinition",
+		"ServiceAccount", "ServiceAccount",
+		"ClusterRole", "ClusterRole",
+		"ClusterRoleBinding", "ClusterRoleBinding",
+		"Role", "RoleBinding",
+		"Deployment", "Deployment", "Service",
+	}
+	if strings.Join(kinds, ",") != strings.Join(wantKinds, ",") {
+		t.Fatalf("bundle kinds = %v, want %v", kinds, wantKinds)
+	}
+
+	if got := applied[0].GetName(); got != "termite-system" {
+		t.Errorf("namespace name = %q, want termite-system", got)
+	}
+	for _, obj := range applied[1:] {
+		if obj.GetKind() == "CustomResourceDefinition" || strings.HasPrefix(obj.GetKind(), "Cluster") {
+			continue
+		}
+		if obj.GetNamespace() != "termite-system" {
+			t.Errorf("%s/%s namespace = %q, want termite-system", obj.GetKind(), obj.GetName(), obj.GetNamespace())
+		}
+	}
+
+	for _, want := range []string{
+		"image: example.com/termite-operator:v1",
+		"image: example.com/termite-proxy:v1",
+		"value: " + DefaultTermiteImage,
+	} {
+		if !strings.Contains(bundle, want) {
+			t.Errorf("bundle missing %q", want)
+		}
+	}
+	if strings.Contains(bundle, OperatorNamespace) {
+		t.Errorf("bundle still references default namespace %q", OperatorNamespace)
+	}
+}
+
+func TestInstallBundleYAML_Webhooks(t *testing.T) {
+	bundle, err := InstallBundleYAML(InstallOptions{Namespace: "termite-system", EnableWebhooks: true})
+	if err != nil {
+		t.Fatalf("InstallBundleYAML: %v", err)
+	}
+	applied := applyBundle(t, bundle).applied
+
+	var kinds []string
+	for _, obj := range applied[len(applied)-4:] {
+		kinds = append(kinds, obj.GetKind())
+	}
+	wantKinds := []string{"Service", "Issuer", "Certificate", "ValidatingWebhookConfiguration"}
+	if strings.Join(kinds, ",") != strings.Join(wantKinds, ",") {
+		t.Fatalf("trailing bundle kinds = %v, want %v", kinds, wantKinds)
+	}
+
+	for _, want := range []string{
+		"name: TERMITE_OPERATOR_ENABLE_WEBHOOKS",
+		"secretName: " + WebhookCertificateName,
+		"cert-manager.io/inject-ca-from: termite-system/" + WebhookCertificateName,
+		"path: " + v1alpha1.TermiteRouteWebhookPath,
+		"- " + WebhookServiceName + ".termite-system.svc",
+	} {
+		if !strings.Contains(bundle, want) {
+			t.Errorf("bundle missing %q", want)
+		}
+	}
+
+	// Webhooks are opt-in
+	bundle, err = InstallBundleYAML(InstallOptions{})
+	if err != nil {
+		t.Fatalf("InstallBundleYAML: %v", err)
+	}
+	if strings.Contains(bundle, "ValidatingWebhookConfiguration") || strings.Contains(bundle, "ENABLE_WEBHOOKS") {
+		t.Error("bundle without EnableWebhooks configures admission webhooks")
+	}
+}
@@ -96,7 +96,8 @@ func ServiceAccount() *corev1.ServiceAccount {
 }
 
 // ClusterRole returns the ClusterRole for the Termite operator.
-// This is generated from kubebuilder RBAC annotations in the controller.
+// Its rules mirror the kubebuilder RBAC annotations in the controllers;
+// TestClusterRoleMatchesGeneratedYAML fails if they drift from rbac/role.yaml.
 func ClusterRole() *rbacv1.ClusterRole {
 	return &rbacv1.ClusterRole{
 		TypeMeta: metav1.TypeMeta{
@@ -115,15 +116,35 @@ func ClusterRole() *rbacv1.ClusterRole {
 			// TermitePool CRD management
 			{
 				APIGroups: []string{"antfly.io"},
-				Resources: []string{"termitepools", "termitepools/status", "termitepools/finalizers"},
+				Resources: []string{"termitepools"},
 				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
 			},
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termitepools/status"},
+				Verbs:     []string{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termitepools/finalizers"},
+				Verbs:     []string{"update"},
+			},
 			// TermiteRoute CRD management
 			{
 				APIGroups: []string{"antfly.io"},
-				Resources: []string{"termiteroutes", "termiteroutes/status", "termiteroutes/finalizers"},
+				Resources: []string{"termiteroutes"},
 				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
 			},
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termiteroutes/status"},
+				Verbs:     []string{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termiteroutes/finalizers"},
+				Verbs:     []string{"update"},
+			},
 			// StatefulSet management (created by operator for TermitePools)
 			{
 				APIGroups: []string{"apps"},
@@ -170,6 +191,85 @@ func ClusterRole() *rbacv1.ClusterRole {
 	}
 }
 
+// RBACMode selects the permissions granted to the operator's ClusterRole.
+type RBACMode string
+
+const (
+	// RBACModeFull grants the operator full management of the resources it
+	// reconciles. This is the default.
+	RBACModeFull RBACMode = "full"
+
+	// RBACModeReadOnly only lets the operator watch resources and report
+	// status, for observability deployments that must not modify workloads.
+	RBACModeReadOnly RBACMode = "read-only"
+)
+
+// ClusterRoleForMode returns the operator ClusterRole for the given mode.
+// An empty mode selects RBACModeFull.
+func ClusterRoleForMode(mode RBACMode) *rbacv1.ClusterRole {
+	if mode == RBACModeReadOnly {
+		return ReadOnlyClusterRole()
+	}
+	return ClusterRole()
+}
+
+// ReadOnlyClusterRole returns a least-privilege ClusterRole for running the
+// operator in read-only mode. It grants get/list/watch on every resource the
+// full ClusterRole manages, plus patch on the CRD status subresources.
+// It uses the same name as ClusterRole so ClusterRoleBinding applies to either.
+func ReadOnlyClusterRole() *rbacv1.ClusterRole {
+	readVerbs := []string{"get", "list", "watch"}
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ClusterRoleName,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "termite-operator",
+				"app.kubernetes.io/component":  "rbac",
+				"app.kubernetes.io/managed-by": "termite-operator",
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			// TermitePool and TermiteRoute watching
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termitepools", "termiteroutes"},
+				Verbs:     readVerbs,
+			},
+			// Status reporting
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termitepools/status", "termiteroutes/status"},
+				Verbs:     []string{"get", "patch"},
+			},
+			// Workload watching (for status)
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"statefulsets"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"services", "configmaps", "pods"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{"policy"},
+				Resources: []string{"poddisruptionbudgets"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{"autoscaling"},
+				Resources: []string{"horizontalpodautoscalers"},
+				Verbs:     readVerbs,
+			},
+		},
+	}
+}
+
 // ClusterRoleBinding returns the ClusterRoleBinding for the Termite operator.
 func ClusterRoleBinding() *rbacv1.ClusterRoleBinding {
 	return &rbacv1.ClusterRoleBinding{
@@ -288,10 +388,16 @@ func ClusterRoleYAML() string {
 // AllRBACResources returns all RBAC resources needed for the Termite operator.
 // Resources are returned in the order they should be applied.
 func AllRBACResources() []any {
+	return AllRBACResourcesForMode(RBACModeFull)
+}
+
+// AllRBACResourcesForMode returns all RBAC resources needed for the Termite
+// operator, using the ClusterRole for the given mode.
+func AllRBACResourcesForMode(mode RBACMode) []any {
 	return []any{
 		Namespace(),
 		ServiceAccount(),
-		ClusterRole(),
+		ClusterRoleForMode(mode),
 		ClusterRoleBinding(),
 		LeaderElectionRole(),
 		LeaderElectionRoleBinding(),
@@ -379,6 +485,18 @@ func ProxyClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{"termiteroutes"},
 				Verbs:     []string{"get", "list", "watch"},
 			},
+			// Reporting whether each TermiteRoute was accepted
+			{
+				APIGroups: []string{"antfly.io"},
+				Resources: []string{"termiteroutes/status"},
+				Verbs:     []string{"get", "patch"},
+			},
+			// Recording TermiteRoute events
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"create", "patch"},
+			},
 		},
 	}
 }
@@ -0,0 +1,141 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Permission is a single verb granted on a resource (or non-resource URL).
+// Policy rules are expanded into permissions so that roles can be compared
+// regardless of how their rules are grouped or ordered.
+type Permission struct {
+	APIGroup       string
+	Resource       string
+	ResourceName   string
+	NonResourceURL string
+	Verb           string
+}
+
+// String formats the permission like "apps/statefulsets:create".
+func (p Permission) String() string {
+	if p.NonResourceURL != "" {
+		return p.NonResourceURL + ":" + p.Verb
+	}
+	target := p.Resource
+	if p.APIGroup != "" {
+		target = p.APIGroup + "/" + target
+	}
+	if p.ResourceName != "" {
+		target += "[" + p.ResourceName + "]"
+	}
+	return target + ":" + p.Verb
+}
+
+func comparePermissions(a, b Permission) int {
+	return cmp.Or(
+		cmp.Compare(a.APIGroup, b.APIGroup),
+		cmp.Compare(a.Resource, b.Resource),
+		cmp.Compare(a.ResourceName, b.ResourceName),
+		cmp.Compare(a.NonResourceURL, b.NonResourceURL),
+		cmp.Compare(a.Verb, b.Verb),
+	)
+}
+
+// NormalizeRules expands policy rules into a sorted, de-duplicated list of
+// permissions.
+func NormalizeRules(rules []rbacv1.PolicyRule) []Permission {
+	var perms []Permission
+	for _, rule := range rules {
+		for _, verb := range rule.Verbs {
+			for _, url := range rule.NonResourceURLs {
+				perms = append(perms, Permission{NonResourceURL: url, Verb: verb})
+			}
+			resourceNames := rule.ResourceNames
+			if len(resourceNames) == 0 {
+				resourceNames = []string{""}
+			}
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					for _, name := range resourceNames {
+						perms = append(perms, Permission{
+							APIGroup:     group,
+							Resource:     resource,
+							ResourceName: name,
+							Verb:         verb,
+						})
+					}
+				}
+			}
+		}
+	}
+	slices.SortFunc(perms, comparePermissions)
+	return slices.Compact(perms)
+}
+
+// ClusterRoleDiff lists the permissions that differ between two ClusterRoles.
+type ClusterRoleDiff struct {
+	// Missing are permissions granted by the expected role but not the actual one.
+	Missing []Permission
+
+	// Extra are permissions granted by the actual role but not the expected one.
+	Extra []Permission
+}
+
+// Empty reports whether both roles grant exactly the same permissions.
+func (d ClusterRoleDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0
+}
+
+// String formats the diff with one "-" or "+" line per permission.
+func (d ClusterRoleDiff) String() string {
+	var b strings.Builder
+	for _, p := range d.Missing {
+		fmt.Fprintf(&b, "- %s\n", p)
+	}
+	for _, p := range d.Extra {
+		fmt.Fprintf(&b, "+ %s\n", p)
+	}
+	return b.String()
+}
+
+// DiffClusterRole compares the rules of two ClusterRoles order-insensitively.
+// Metadata is ignored.
+func DiffClusterRole(expected, actual *rbacv1.ClusterRole) ClusterRoleDiff {
+	want := NormalizeRules(expected.Rules)
+	got := NormalizeRules(actual.Rules)
+
+	var diff ClusterRoleDiff
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case j == len(got) || (i < len(want) && comparePermissions(want[i], got[j]) < 0):
+			diff.Missing = append(diff.Missing, want[i])
+			i++
+		case i == len(want) || comparePermissions(want[i], got[j]) > 0:
+			diff.Extra = append(diff.Extra, got[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return diff
+}
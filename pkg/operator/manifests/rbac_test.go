@@ -0,0 +1,110 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"slices"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// verbsFor returns all verbs the role grants on resource in apiGroup.
+func verbsFor(role *rbacv1.ClusterRole, apiGroup, resource string) []string {
+	var verbs []string
+	for _, rule := range role.Rules {
+		if slices.Contains(rule.APIGroups, apiGroup) && slices.Contains(rule.Resources, resource) {
+			verbs = append(verbs, rule.Verbs...)
+		}
+	}
+	return verbs
+}
+
+func TestReadOnlyClusterRole(t *testing.T) {
+	role := ReadOnlyClusterRole()
+
+	for _, target := range []struct{ apiGroup, resource string }{
+		{"apps", "statefulsets"},
+		{"", "services"},
+	} {
+		verbs := verbsFor(role, target.apiGroup, target.resource)
+		if !slices.Contains(verbs, "watch") {
+			t.Errorf("expected read-only role to watch %s, got verbs %v", target.resource, verbs)
+		}
+		for _, forbidden := range []string{"create", "update", "patch", "delete"} {
+			if slices.Contains(verbs, forbidden) {
+				t.Errorf("read-only role must not grant %q on %s", forbidden, target.resource)
+			}
+		}
+	}
+
+	if verbs := verbsFor(role, "antfly.io", "termitepools/status"); !slices.Contains(verbs, "patch") {
+		t.Errorf("expected read-only role to patch termitepools/status, got verbs %v", verbs)
+	}
+	if role.Name != ClusterRoleBinding().RoleRef.Name {
+		t.Errorf("read-only role name %q does not match ClusterRoleBinding roleRef", role.Name)
+	}
+}
+
+func TestClusterRoleForMode(t *testing.T) {
+	if got := verbsFor(ClusterRoleForMode(""), "apps", "statefulsets"); !slices.Contains(got, "create") {
+		t.Errorf("expected default mode to use the full role, got verbs %v", got)
+	}
+	if got := verbsFor(ClusterRoleForMode(RBACModeReadOnly), "apps", "statefulsets"); slices.Contains(got, "create") {
+		t.Errorf("expected read-only mode to omit create, got verbs %v", got)
+	}
+}
+
+func TestClusterRoleMatchesGeneratedYAML(t *testing.T) {
+	generated, err := ClusterRoleFromYAML()
+	if err != nil {
+		t.Fatalf("parsing generated ClusterRole: %v", err)
+	}
+
+	if diff := DiffClusterRole(generated, ClusterRole()); !diff.Empty() {
+		t.Errorf("ClusterRole() has drifted from rbac/role.yaml (- missing, + extra):\n%s", diff)
+	}
+}
+
+func TestDiffClusterRole(t *testing.T) {
+	expected := &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{
+		{APIGroups: []string{"apps"}, Resources: []string{"statefulsets"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: []string{"get"}},
+	}}
+
+	// Same permissions regrouped and reordered
+	regrouped := &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"statefulsets"}, Verbs: []string{"list", "get"}},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+	}}
+	if diff := DiffClusterRole(expected, regrouped); !diff.Empty() {
+		t.Errorf("expected no diff for regrouped rules, got:\n%s", diff)
+	}
+
+	changed := &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{
+		{APIGroups: []string{"apps"}, Resources: []string{"statefulsets"}, Verbs: []string{"get", "list", "delete"}},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+	}}
+	diff := DiffClusterRole(expected, changed)
+	wantMissing := []Permission{{Resource: "services", Verb: "get"}}
+	wantExtra := []Permission{{APIGroup: "apps", Resource: "statefulsets", Verb: "delete"}}
+	if !slices.Equal(diff.Missing, wantMissing) {
+		t.Errorf("Missing = %v, want %v", diff.Missing, wantMissing)
+	}
+	if !slices.Equal(diff.Extra, wantExtra) {
+		t.Errorf("Extra = %v, want %v", diff.Extra, wantExtra)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// Names of the admission webhook resources in the install bundle
+const (
+	// WebhookServiceName is the name of the Service in front of the
+	// operator's webhook server.
+	WebhookServiceName = "termite-operator-webhook"
+
+	// WebhookCertificateName is the name of the cert-manager Certificate
+	// for the webhook server, and of the Secret it is issued into.
+	WebhookCertificateName = "termite-operator-webhook-cert"
+
+	// WebhookConfigurationName is the name of the
+	// ValidatingWebhookConfiguration.
+	WebhookConfigurationName = "termite-operator-validating-webhook"
+)
+
+// webhookPort is the port the operator's webhook server listens on
+const webhookPort = 9443
+
+// webhookCertDir is where the operator reads the webhook serving certificate
+const webhookCertDir = "/tmp/k8s-webhook-server/serving-certs"
+
+// termiteRouteWebhookPath matches v1alpha1.TermiteRouteWebhookPath, which is
+// not imported to keep this package free of controller-runtime
+const termiteRouteWebhookPath = "/validate-antfly-io-v1alpha1-termiteroute"
+
+// WebhookService returns the Service the API server reaches the operator's
+// admission webhooks through.
+func WebhookService(opts InstallOptions) *corev1.Service {
+	opts = opts.withDefaults()
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      WebhookServiceName,
+			Namespace: opts.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				"app.kubernetes.io/name":      "termite-operator",
+				"app.kubernetes.io/component": "controller",
+			},
+			Ports: []corev1.ServicePort{
+				{Name: "webhook", Port: 443, TargetPort: intstr.FromString("webhook")},
+			},
+		},
+	}
+}
+
+// WebhookCertificateResources returns a self-signed cert-manager Issuer and
+// the Certificate it issues for the webhook Service. cert-manager must be
+// installed in the cluster.
+func WebhookCertificateResources(opts InstallOptions) []any {
+	opts = opts.withDefaults()
+	issuer := map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Issuer",
+		"metadata": map[string]any{
+			"name":      "termite-operator-selfsigned",
+			"namespace": opts.Namespace,
+		},
+		"spec": map[string]any{"selfSigned": map[string]any{}},
+	}
+	certificate := map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]any{
+			"name":      WebhookCertificateName,
+			"namespace": opts.Namespace,
+		},
+		"spec": map[string]any{
+			"secretName": WebhookCertificateName,
+			"dnsNames": []string{
+				WebhookServiceName + "." + opts.Namespace + ".svc",
+				WebhookServiceName + "." + opts.Namespace + ".svc.cluster.local",
+			},
+			"issuerRef": map[string]any{"kind": "Issuer", "name": "termite-operator-selfsigned"},
+		},
+	}
+	return []any{issuer, certificate}
+}
+
+// ValidatingWebhookConfiguration returns the configuration that sends
+// TermiteRoute writes to the operator, whose CA bundle is injected by
+// cert-manager. Writes are admitted when the webhook is unreachable, as they
+// are when webhooks are disabled.
+func ValidatingWebhookConfiguration(opts InstallOptions) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	opts = opts.withDefaults()
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: WebhookConfigurationName,
+			Annotations: map[string]string{
+				"cert-manager.io/inject-ca-from": opts.Namespace + "/" + WebhookCertificateName,
+			},
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name: "vtermiteroute.antfly.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: opts.Namespace,
+						Name:      WebhookServiceName,
+						Path:      ptr.To(termiteRouteWebhookPath),
+					},
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{
+							admissionregistrationv1.Create,
+							admissionregistrationv1.Update,
+						},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"antfly.io"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"termiteroutes"},
+						},
+					},
+				},
+				FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+				SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+				AdmissionReviewVersions: []string{"v1"},
+				TimeoutSeconds:          ptr.To[int32](5),
+			},
+		},
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BatchRequest is the body of /api/batch: several embed, chunk or rerank
+// requests, possibly for different models, sent in one call
+type BatchRequest struct {
+	// SingleRoute rejects the batch unless all items match the same route,
+	// instead of splitting it across routes
+	SingleRoute bool `json:"single_route,omitempty"`
+
+	Requests []BatchItem `json:"requests"`
+}
+
+// BatchItem is one request of a batch
+type BatchItem struct {
+	Operation string          `json:"operation"` // embed, chunk or rerank
+	Body      json.RawMessage `json:"body"`      // Request body for /api/<operation>
+}
+
+// BatchResponse holds the responses to a batch, in request order
+type BatchResponse struct {
+	Responses []BatchItemResponse `json:"responses"`
+}
+
+// BatchItemResponse is the outcome of one batch item
+type BatchItemResponse struct {
+	Status int             `json:"status"`
+	Route  string          `json:"route,omitempty"`
+	Pool   string          `json:"pool,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// batchOperations are the operations a batch item may carry
+var batchOperations = map[string]bool{"embed": true, "chunk": true, "rerank": true}
+
+// handleBatch routes each item of a batch like a standalone request and
+// recombines the responses. Items matching the same route share one
+// destination pool so that a homogeneous batch is routed coherently.
+func (p *Proxy) handleBatch(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	var batch BatchRequest
+	r.Body = http.MaxBytesReader(w, r.Body, p.maxBatchBytes)
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("batch exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if len(batch.Requests) == 0 {
+		http.Error(w, "batch has no requests", http.StatusBadRequest)
+		return
+	}
+	if len(batch.Requests) > p.maxBatchItems {
+		http.Error(w, fmt.Sprintf("batch has %d requests, at most %d are allowed", len(batch.Requests), p.maxBatchItems), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	routeReqs := make([]*RouteRequest, len(batch.Requests))
+	for i, item := range batch.Requests {
+		if !batchOperations[item.Operation] {
+			http.Error(w, fmt.Sprintf("requests[%d]: invalid operation %q", i, item.Operation), http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			Model string `json:"model"`
+		}
+		if err := json.Unmarshal(item.Body, &req); err != nil {
+			http.Error(w, fmt.Sprintf("requests[%d]: invalid JSON body", i), http.StatusBadRequest)
+			return
+		}
+		routeReqs[i] = newRouteRequest(r, OperationType(item.Operation), req.Model, start)
+	}
+
+	routes, err := p.router.RouteManager().MatchBatch(routeReqs, batch.SingleRoute)
+	if err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	// Select one pool per route, then forward items concurrently, at most
+	// batchConcurrency at a time
+	type poolChoice struct {
+		pool     string
+		fallback bool
+		rejected *routeRejection
+	}
+	choices := make(map[*Route]poolChoice)
+	responses := make([]BatchItemResponse, len(batch.Requests))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, p.batchConcurrency)
+	for i, item := range batch.Requests {
+		route := routes[i]
+		model := routeReqs[i].Model
+		var choice poolChoice
+		if route != nil {
+			responses[i].Route = route.Name
+			if route.RateLimiter != nil && !route.RateLimiter.Allow(model) {
+				responses[i].Status = statusForError(ErrRateLimited)
+				responses[i].Error = ErrRateLimited.Error()
+				observeRouting(route, "", routeOutcomeRejected, start)
+				continue
+			}
+			var ok bool
+			if choice, ok = choices[route]; !ok {
+				choice.pool, choice.fallback, choice.rejected = p.selectPool(route, routeReqs[i])
+				choices[route] = choice
+			}
+			if choice.rejected != nil {
+				responses[i].Status = choice.rejected.StatusCode
+				responses[i].Error = choice.rejected.Message
+				observeRouting(route, "", routeOutcomeRejected, start)
+				continue
+			}
+		} else {
+			unmatchedRequests.WithLabelValues(item.Operation).Inc()
+		}
+
+		pool := choice.pool
+		if pool == "" {
+			pool = p.fallbackPool(r, model)
+		}
+		responses[i].Pool = pool
+
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if route != nil {
+				defer p.router.RouteManager().trackConnection(route, pool)()
+			}
+			p.forwardBatchItem(r, route, item, model, pool, start, &responses[i])
+			if route != nil {
+				outcome := routeOutcomeMatched
+				if choice.fallback {
+					outcome = routeOutcomeFallback
+				}
+				observeRouting(route, pool, outcome, start)
+			}
+		})
+	}
+	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(BatchResponse{Responses: responses})
+}
+
+// forwardBatchItem sends one batch item to an endpoint of pool, retrying as
+// its route configures, and records the outcome in resp
+func (p *Proxy) forwardBatchItem(r *http.Request, route *Route, item BatchItem, model, pool string, start time.Time, resp *BatchItemResponse) {
+	result, err := p.forward(r, route, item.Operation, model, pool, item.Body, start, false)
+	if err != nil {
+		resp.Status = statusForError(err)
+		resp.Error = err.Error()
+		return
+	}
+	resp.Status = result.Status
+	if json.Valid(result.Body) {
+		resp.Body = result.Body
+	} else {
+		resp.Error = strings.TrimSpace(string(result.Body))
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// newBatchTestProxy starts a fake Termite endpoint per pool, each echoing the
+// pool name and requested model, and routes bge-* models to the "gpu" pool
+func newBatchTestProxy(t *testing.T, pools ...string) *Proxy {
+	t.Helper()
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	for _, pool := range pools {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Model string `json:"model"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"pool": pool, "model": req.Model, "path": r.URL.Path})
+		}))
+		t.Cleanup(srv.Close)
+		p.RegisterEndpoint(srv.URL, pool, "")
+		p.Registry().UpdateModels(srv.URL, []string{"bge-small", "bge-base", "mxbai-rerank"})
+	}
+
+	bge, err := CompileModelPattern("bge-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Router().RouteManager().AddRoute(&Route{
+		Name:          "default/bge",
+		Priority:      100,
+		ModelPatterns: []*regexp.Regexp{bge},
+		Destinations:  []Destination{{Pool: "gpu", Weight: 100}},
+	})
+	return p
+}
+
+func postBatch(t *testing.T, p *Proxy, body string) (*httptest.ResponseRecorder, BatchResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	p.handleBatch(rec, httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(body)))
+	var resp BatchResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decoding batch response: %v", err)
+		}
+	}
+	return rec, resp
+}
+
+func TestHandleBatch_Homogeneous(t *testing.T) {
+	p := newBatchTestProxy(t, "gpu", "default")
+
+	rec, resp := postBatch(t, p, `{"single_route": true, "requests": [
+		{"operation": "embed", "body": {"model": "bge-small", "input": ["a"]}},
+		{"operation": "embed", "body": {"model": "bge-base", "input": ["b"]}}
+	]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(resp.Responses) != 2 {
+		t.Fatalf("got %d responses, want 2", len(resp.Responses))
+	}
+	for i, model := range []string{"bge-small", "bge-base"} {
+		item := resp.Responses[i]
+		if item.Status != http.StatusOK || item.Route != "default/bge" || item.Pool != "gpu" {
+			t.Errorf("responses[%d] = %+v", i, item)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(item.Body, &body); err != nil {
+			t.Fatalf("responses[%d] body: %v", i, err)
+		}
+		if body["model"] != model || body["pool"] != "gpu" || body["path"] != "/api/embed" {
+			t.Errorf("responses[%d] body = %v, want %s from gpu", i, body, model)
+		}
+	}
+}
+
+func TestHandleBatch_MixedSplitsAcrossRoutes(t *testing.T) {
+	p := newBatchTestProxy(t, "gpu", "default")
+
+	rec, resp := postBatch(t, p, `{"requests": [
+		{"operation": "rerank", "body": {"model": "mxbai-rerank", "query": "q", "prompts": ["a"]}},
+		{"operation": "embed", "body": {"model": "bge-small", "input": ["b"]}}
+	]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	want := []struct{ route, pool, path string }{
+		{"", "default", "/api/rerank"},
+		{"default/bge", "gpu", "/api/embed"},
+	}
+	for i, w := range want {
+		item := resp.Responses[i]
+		if item.Status != http.StatusOK || item.Route != w.route || item.Pool != w.pool {
+			t.Errorf("responses[%d] = %+v, want route %q pool %q", i, item, w.route, w.pool)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(item.Body, &body); err != nil {
+			t.Fatalf("responses[%d] body: %v", i, err)
+		}
+		if body["pool"] != w.pool || body["path"] != w.path {
+			t.Errorf("responses[%d] body = %v", i, body)
+		}
+	}
+}
+
+func TestHandleBatch_SingleRouteRejectsMixed(t *testing.T) {
+	p := newBatchTestProxy(t, "gpu", "default")
+	route := p.Router().RouteManager().routes[0]
+
+	rec, _ := postBatch(t, p, `{"single_route": true, "requests": [
+		{"operation": "embed", "body": {"model": "bge-small"}},
+		{"operation": "embed", "body": {"model": "mxbai-rerank"}}
+	]}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), ErrMixedBatch.Error()) {
+		t.Errorf("status = %d, body %q; want 400 mixed batch", rec.Code, rec.Body.String())
+	}
+	if route.Matches() != 0 {
+		t.Errorf("rejected batch counted %d route matches", route.Matches())
+	}
+}
+
+func TestHandleBatch_InvalidOperation(t *testing.T) {
+	p := newBatchTestProxy(t, "default")
+	rec, _ := postBatch(t, p, `{"requests": [{"operation": "generate", "body": {"model": "x"}}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleBatch_Limits(t *testing.T) {
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop(), MaxBatchItems: 2, MaxBatchBytes: 256})
+
+	rec, _ := postBatch(t, p, `{"requests": [
+		{"operation": "embed", "body": {"model": "a"}},
+		{"operation": "embed", "body": {"model": "b"}},
+		{"operation": "embed", "body": {"model": "c"}}
+	]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too many items: status = %d, want 413", rec.Code)
+	}
+
+	rec, _ = postBatch(t, p, `{"requests": [{"operation": "embed", "body": {"model": "a", "input": ["`+strings.Repeat("x", 512)+`"]}}]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body: status = %d, want 413", rec.Code)
+	}
+}
+
+func TestHandleBatch_BoundedConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop(), BatchConcurrency: 2})
+	p.RegisterEndpoint(srv.URL, "default", "")
+
+	items := make([]string, 8)
+	for i := range items {
+		items[i] = `{"operation": "embed", "body": {"model": "bge-small"}}`
+	}
+	rec, resp := postBatch(t, p, `{"requests": [`+strings.Join(items, ",")+`]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	for i, item := range resp.Responses {
+		if item.Status != http.StatusOK {
+			t.Errorf("responses[%d] = %+v", i, item)
+		}
+	}
+	if got := peak.Load(); got > 2 {
+		t.Errorf("%d items forwarded concurrently, want at most 2", got)
+	}
+}
+
+// countingTransport counts the requests it forwards
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHandleBatch_UsesConfiguredTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	transport := &countingTransport{}
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop(), Transport: transport})
+	p.RegisterEndpoint(srv.URL, "default", "")
+
+	rec, _ := postBatch(t, p, `{"requests": [{"operation": "embed", "body": {"model": "bge-small"}}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if got := transport.requests.Load(); got != 1 {
+		t.Errorf("configured transport forwarded %d requests, want 1", got)
+	}
+}
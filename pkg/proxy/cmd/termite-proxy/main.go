@@ -17,8 +17,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -42,6 +45,7 @@ func main() {
 	viper.SetDefault("refresh_interval", "10s")
 	viper.SetDefault("namespace", "")
 	viper.SetDefault("selector", "app.kubernetes.io/name=termite")
+	viper.SetDefault("prometheus_cache_ttl", "15s")
 	viper.SetDefault("log.level", "info")
 	// Default to JSON logging in Kubernetes for structured log aggregation
 	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
@@ -99,7 +103,10 @@ Examples:
   termite-proxy --listen :8080 --health-port 4200
 
   # Run with Kubernetes watcher
-  termite-proxy --namespace my-namespace --selector app=termite`,
+  termite-proxy --namespace my-namespace --selector app=termite
+
+  # Watch pods of two pools, or any pod labeled app=termite except canaries
+  termite-proxy --selector 'antfly.io/pool in (a,b);app=termite,!canary'`,
 		RunE: runProxy,
 	}
 
@@ -112,11 +119,25 @@ Examples:
 	// Kubernetes flags
 	cmd.Flags().String("kubeconfig", "", "Path to kubeconfig (uses in-cluster config if empty)")
 	cmd.Flags().String("namespace", "", "Namespace to watch (empty for all namespaces)")
-	cmd.Flags().String("selector", "app.kubernetes.io/name=termite", "Label selector for Termite pods")
+	cmd.Flags().String("selector", "app.kubernetes.io/name=termite", "Label selector for Termite pods; separate several selectors with ';' to watch pods matching any of them")
+	cmd.Flags().Duration("resync-period", proxy.DefaultResyncPeriod, "Informer resync period, plus up to 10% jitter (0 disables periodic resync)")
 
 	// Route watching flags
 	cmd.Flags().Bool("enable-route-watching", true, "Enable watching TermiteRoute CRs for routing rules")
 	cmd.Flags().String("route-namespace", "", "Namespace to watch for TermiteRoutes (empty for all)")
+	cmd.Flags().Duration("failover-cooldown", 0, "How long a route keeps using its failover destination after its primary recovers (0 disables)")
+	cmd.Flags().Duration("slow-start-window", 0, "How long a recovered route destination ramps up to its full traffic weight (0 disables)")
+	cmd.Flags().String("admin-token", "", "Bearer token required by the /admin/* endpoints (disabled when empty)")
+	cmd.Flags().StringSlice("trusted-source-cidrs", nil, "Networks (CIDRs) of the mesh sidecars or gateways allowed to set the X-Termite-Source-Namespace and X-Termite-Source-Service-Account headers; they are stripped from other requests")
+
+	// Batch flags
+	cmd.Flags().Int("max-batch-items", proxy.DefaultMaxBatchItems, "Maximum number of requests in one /api/batch call")
+	cmd.Flags().Int64("max-batch-bytes", proxy.DefaultMaxBatchBytes, "Maximum size of an /api/batch request body in bytes")
+	cmd.Flags().Int("batch-concurrency", proxy.DefaultBatchConcurrency, "Maximum number of batch items forwarded concurrently per batch")
+
+	// Metric condition flags
+	cmd.Flags().String("prometheus-url", "", "Prometheus server URL for metric route conditions (e.g. http://prometheus:9090)")
+	cmd.Flags().Duration("prometheus-cache-ttl", 15*time.Second, "How often Prometheus queries used by routes are refreshed")
 
 	// Logging flags
 	cmd.Flags().String("log-level", "info", "Log level (debug, info, warn, error)")
@@ -130,11 +151,23 @@ Examples:
 	mustBindFlag(cmd, "kubeconfig", "kubeconfig")
 	mustBindFlag(cmd, "namespace", "namespace")
 	mustBindFlag(cmd, "selector", "selector")
+	mustBindFlag(cmd, "resync-period", "resync_period")
 	mustBindFlag(cmd, "enable-route-watching", "enable_route_watching")
 	mustBindFlag(cmd, "route-namespace", "route_namespace")
+	mustBindFlag(cmd, "failover-cooldown", "failover_cooldown")
+	mustBindFlag(cmd, "slow-start-window", "slow_start_window")
+	mustBindFlag(cmd, "trusted-source-cidrs", "trusted_source_cidrs")
+	mustBindFlag(cmd, "admin-token", "admin_token")
+	mustBindFlag(cmd, "max-batch-items", "max_batch_items")
+	mustBindFlag(cmd, "max-batch-bytes", "max_batch_bytes")
+	mustBindFlag(cmd, "batch-concurrency", "batch_concurrency")
+	mustBindFlag(cmd, "prometheus-url", "prometheus_url")
+	mustBindFlag(cmd, "prometheus-cache-ttl", "prometheus_cache_ttl")
 	mustBindFlag(cmd, "log-level", "log.level")
 	mustBindFlag(cmd, "log-style", "log.style")
 
+	cmd.AddCommand(buildEvaluateRouteCommand())
+
 	return cmd
 }
 
@@ -159,21 +192,41 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	kubeconfig := viper.GetString("kubeconfig")
 	namespace := viper.GetString("namespace")
 	labelSelector := viper.GetString("selector")
+	resyncPeriod := viper.GetDuration("resync_period")
 	enableRouteWatching := viper.GetBool("enable_route_watching")
 	routeNamespace := viper.GetString("route_namespace")
 
+	var trustedSources []netip.Prefix
+	for _, cidr := range viper.GetStringSlice("trusted_source_cidrs") {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid trusted source CIDR %q: %w", cidr, err)
+		}
+		trustedSources = append(trustedSources, prefix.Masked())
+	}
+
 	// Determine if we're running in Kubernetes
 	inKubernetes := kubeconfig != "" || os.Getenv("KUBERNETES_SERVICE_HOST") != ""
 
 	// Create proxy
 	cfg := proxy.Config{
-		ListenAddr:           listenAddr,
-		DefaultPool:          defaultPool,
-		RefreshInterval:      refreshInterval,
-		EnableRouteWatching:  enableRouteWatching && inKubernetes,
-		RouteWatchNamespace:  routeNamespace,
-		RouteWatchKubeconfig: kubeconfig,
-		Logger:               logger,
+		ListenAddr:            listenAddr,
+		DefaultPool:           defaultPool,
+		RefreshInterval:       refreshInterval,
+		EnableRouteWatching:   enableRouteWatching && inKubernetes,
+		RouteWatchNamespace:   routeNamespace,
+		RouteWatchKubeconfig:  kubeconfig,
+		RouteResyncPeriod:     resyncPeriod,
+		PrometheusURL:         viper.GetString("prometheus_url"),
+		PrometheusCacheTTL:    viper.GetDuration("prometheus_cache_ttl"),
+		FailoverCooldown:      viper.GetDuration("failover_cooldown"),
+		SlowStartWindow:       viper.GetDuration("slow_start_window"),
+		Logger:                logger,
+		TrustedSourceNetworks: trustedSources,
+		AdminToken:            viper.GetString("admin_token"),
+		MaxBatchItems:         viper.GetInt("max_batch_items"),
+		MaxBatchBytes:         viper.GetInt64("max_batch_bytes"),
+		BatchConcurrency:      viper.GetInt("batch_concurrency"),
 	}
 	p := proxy.NewProxy(cfg)
 
@@ -183,10 +236,15 @@ func runProxy(cmd *cobra.Command, args []string) error {
 
 	// Start Kubernetes watcher if configured
 	if inKubernetes {
+		var labelSelectors []string
+		if labelSelector != "" {
+			labelSelectors = strings.Split(labelSelector, ";")
+		}
 		watcher, err := proxy.NewK8sWatcher(p, proxy.K8sWatcherConfig{
-			Kubeconfig:    kubeconfig,
-			Namespace:     namespace,
-			LabelSelector: labelSelector,
+			Kubeconfig:     kubeconfig,
+			Namespace:      namespace,
+			LabelSelectors: labelSelectors,
+			ResyncPeriod:   resyncPeriod,
 		})
 		if err != nil {
 			logger.Fatal("failed to create k8s watcher", zap.Error(err))
@@ -0,0 +1,202 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antflydb/termite/pkg/proxy"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func buildEvaluateRouteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evaluate-route -f <routes.yaml> --operation <op> --model <model>",
+		Short: "Dry-run route matching against TermiteRoute manifests",
+		Long: `Load TermiteRoute manifests from files and show how a request would be
+routed: which route matches, why higher-priority routes did not, and which
+destination would be chosen.
+
+Destination pools are simulated: unless overridden with --pool, each pool
+has one healthy endpoint with the requested model loaded.
+
+Examples:
+  # Which route handles an embed request for bge-small?
+  termite-proxy evaluate-route -f routes.yaml --operation embed --model bge-small
+
+  # Include headers, a source identity and a time of day
+  termite-proxy evaluate-route -f routes.yaml --operation embed --model bge-small \
+    --header X-Tenant=team-1 --source-namespace search --source-service-account indexer \
+    --time 2025-01-06T22:30:00Z
+
+  # Simulate a pool with no available replicas
+  termite-proxy evaluate-route -f routes.yaml --operation rerank --model bge-reranker \
+    --pool gpu=0`,
+		Args: cobra.NoArgs,
+		RunE: runEvaluateRoute,
+	}
+
+	cmd.Flags().StringSliceP("file", "f", nil, "TermiteRoute manifest files (may contain several documents)")
+	cmd.Flags().String("operation", "", "Request operation (e.g. embed, chunk, rerank)")
+	cmd.Flags().String("model", "", "Requested model")
+	cmd.Flags().StringArray("header", nil, "Request header as Name=Value (repeatable)")
+	cmd.Flags().String("source-table", "", "Source table of the request")
+	cmd.Flags().String("source-namespace", "", "Source namespace of the request")
+	cmd.Flags().String("source-service-account", "", "Source service account of the request")
+	cmd.Flags().String("time", "", "Request time in RFC 3339 format (default: now)")
+	cmd.Flags().StringArray("pool", nil, "Simulated pool as Name=Replicas (repeatable)")
+	cmd.Flags().Uint64("seed", 0, "Seed for weighted destination selection (default: random)")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runEvaluateRoute(cmd *cobra.Command, args []string) error {
+	files, _ := cmd.Flags().GetStringSlice("file")
+	operation, _ := cmd.Flags().GetString("operation")
+	model, _ := cmd.Flags().GetString("model")
+	headerFlags, _ := cmd.Flags().GetStringArray("header")
+	sourceTable, _ := cmd.Flags().GetString("source-table")
+	sourceNamespace, _ := cmd.Flags().GetString("source-namespace")
+	sourceServiceAccount, _ := cmd.Flags().GetString("source-service-account")
+	timeFlag, _ := cmd.Flags().GetString("time")
+	poolFlags, _ := cmd.Flags().GetStringArray("pool")
+
+	req := &proxy.RouteRequest{
+		Operation:            proxy.OperationType(operation),
+		Model:                model,
+		Headers:              make(map[string]string),
+		SourceTable:          sourceTable,
+		SourceNamespace:      sourceNamespace,
+		SourceServiceAccount: sourceServiceAccount,
+		Timestamp:            time.Now(),
+	}
+	for _, h := range headerFlags {
+		name, value, ok := strings.Cut(h, "=")
+		if !ok {
+			return fmt.Errorf("invalid header %q: expected Name=Value", h)
+		}
+		// The proxy matches against canonicalized request header names
+		req.Headers[http.CanonicalHeaderKey(name)] = value
+	}
+	if timeFlag != "" {
+		ts, err := time.Parse(time.RFC3339, timeFlag)
+		if err != nil {
+			return fmt.Errorf("invalid time %q: %w", timeFlag, err)
+		}
+		req.Timestamp = ts
+	}
+
+	replicas := make(map[string]int)
+	for _, p := range poolFlags {
+		name, count, ok := strings.Cut(p, "=")
+		n, err := strconv.Atoi(count)
+		if !ok || err != nil || n < 0 {
+			return fmt.Errorf("invalid pool %q: expected Name=Replicas", p)
+		}
+		replicas[name] = n
+	}
+
+	routes, err := proxy.LoadRouteFiles(files, zap.NewNop())
+	if err != nil {
+		return err
+	}
+
+	var opts []proxy.RouteManagerOption
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetUint64("seed")
+		opts = append(opts, proxy.WithRandomSource(proxy.NewSeededRandomSource(seed)))
+	}
+	rm := proxy.NewRouteManager(opts...)
+	registry := proxy.NewModelRegistry(time.Minute)
+	for _, route := range routes {
+		rm.AddRoute(route)
+		for _, dest := range route.Destinations {
+			if _, ok := replicas[dest.Pool]; !ok {
+				replicas[dest.Pool] = 1
+			}
+		}
+	}
+	for pool, n := range replicas {
+		for i := range n {
+			address := fmt.Sprintf("%s-%d", pool, i)
+			registry.RegisterEndpoint(address, pool, "")
+			registry.UpdateModels(address, []string{model})
+		}
+	}
+
+	eval, err := rm.Evaluate(req, registry)
+	if err != nil {
+		return err
+	}
+	printEvaluation(cmd.OutOrStdout(), eval)
+	return nil
+}
+
+func printEvaluation(out io.Writer, eval *proxy.Evaluation) {
+	for _, r := range eval.Routes {
+		status := "no match"
+		if r.Matched {
+			status = "MATCH"
+		}
+		_, _ = fmt.Fprintf(out, "route %s (priority %d): %s\n", r.Route, r.Priority, status)
+		printConditions(out, r.Conditions)
+	}
+
+	if eval.Matched == nil {
+		_, _ = fmt.Fprintln(out, "\nNo route matched; the request uses default routing")
+		return
+	}
+
+	_, _ = fmt.Fprintf(out, "\nDestinations of %s:\n", eval.Matched.Name)
+	for _, d := range eval.Destinations {
+		status := "ineligible"
+		if d.Eligible {
+			status = "eligible"
+		}
+		_, _ = fmt.Fprintf(out, "  pool %s (weight %d, cost %d): %s\n", d.Pool, d.Weight, d.Cost, status)
+		printConditions(out, d.Conditions)
+	}
+
+	if eval.Selected != nil {
+		_, _ = fmt.Fprintf(out, "\nSelected pool: %s\n", eval.Selected.Pool)
+		return
+	}
+	action := "default routing"
+	if eval.Matched.Fallback != nil {
+		action = "fallback action " + eval.Matched.Fallback.Action
+	}
+	_, _ = fmt.Fprintf(out, "\nNo eligible destination; the request uses %s\n", action)
+}
+
+func printConditions(out io.Writer, conditions []proxy.ConditionResult) {
+	if len(conditions) == 0 {
+		_, _ = fmt.Fprintln(out, "    (no conditions)")
+		return
+	}
+	for _, c := range conditions {
+		mark := "pass"
+		if !c.Passed {
+			mark = "FAIL"
+		}
+		_, _ = fmt.Fprintf(out, "    [%s] %s: %s\n", mark, c.Condition, c.Detail)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// trackConnection counts a request dispatched to pool on behalf of route as
+// in flight, both on the route and on the destination, until the returned
+// release func is called. Callers should defer release so that the counts
+// drop on error and panic paths too; calling it more than once is harmless.
+func (rm *RouteManager) trackConnection(route *Route, pool string) (release func()) {
+	counter := rm.connectionCounter(route.Name, pool)
+	atomic.AddInt32(&route.ActiveConnections, 1)
+	atomic.AddInt32(counter, 1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// Decrement the counters incremented above, even if the route
+			// has since been replaced or removed
+			atomic.AddInt32(&route.ActiveConnections, -1)
+			atomic.AddInt32(counter, -1)
+		})
+	}
+}
+
+// connectionCounter returns the in-flight counter of a route destination,
+// creating it on first use
+func (rm *RouteManager) connectionCounter(route, pool string) *int32 {
+	key := destinationKey{route: route, pool: pool}
+	rm.connMu.RLock()
+	counter, ok := rm.connections[key]
+	rm.connMu.RUnlock()
+	if ok {
+		return counter
+	}
+
+	rm.connMu.Lock()
+	defer rm.connMu.Unlock()
+	if counter, ok := rm.connections[key]; ok {
+		return counter
+	}
+	counter = new(int32)
+	rm.connections[key] = counter
+	return counter
+}
+
+// ActiveConnections returns the number of in-flight requests the proxy has
+// dispatched to pool on behalf of the named route, for status reporting and
+// least-connections balancing
+func (rm *RouteManager) ActiveConnections(route, pool string) int32 {
+	rm.connMu.RLock()
+	defer rm.connMu.RUnlock()
+	if counter, ok := rm.connections[destinationKey{route: route, pool: pool}]; ok {
+		return atomic.LoadInt32(counter)
+	}
+	return 0
+}
+
+// clearConnections forgets the destination counters of a removed route.
+// Requests still in flight release the counters they hold.
+func (rm *RouteManager) clearConnections(route string) {
+	rm.connMu.Lock()
+	defer rm.connMu.Unlock()
+	for key := range rm.connections {
+		if key.route == route {
+			delete(rm.connections, key)
+		}
+	}
+}
+
+// DestinationStatus is the state of a route destination reported in the
+// TermiteRoute status
+type DestinationStatus struct {
+	Pool              string `json:"pool"`
+	Healthy           bool   `json:"healthy"`
+	ActiveConnections int32  `json:"activeConnections,omitempty"`
+}
+
+// DestinationStatuses returns the status of each destination of the named
+// route, or nil if there is no such route. A destination is healthy while
+// its pool has healthy endpoints.
+func (rm *RouteManager) DestinationStatuses(name string, registry *ModelRegistry) []DestinationStatus {
+	rm.mu.RLock()
+	var route *Route
+	for _, r := range rm.routes {
+		if r.Name == name {
+			route = r
+			break
+		}
+	}
+	rm.mu.RUnlock()
+	if route == nil {
+		return nil
+	}
+
+	statuses := make([]DestinationStatus, 0, len(route.Destinations))
+	for _, dest := range route.Destinations {
+		statuses = append(statuses, DestinationStatus{
+			Pool:              dest.Pool,
+			Healthy:           len(registry.GetEndpointsForPool(dest.Pool)) > 0,
+			ActiveConnections: rm.ActiveConnections(name, dest.Pool),
+		})
+	}
+	return statuses
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// newConnectionTestProxy routes every request to the gpu pool, served by
+// handler
+func newConnectionTestProxy(t *testing.T, handler http.Handler) (*Proxy, *Route, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	p.RegisterEndpoint(srv.URL, "gpu", "")
+	route := &Route{
+		Name:         "conns/all",
+		Destinations: []Destination{{Pool: "gpu", Weight: 100}},
+	}
+	p.Router().RouteManager().AddRoute(route)
+	return p, route, srv
+}
+
+func embedThroughProxy(p *Proxy) int {
+	rec := httptest.NewRecorder()
+	p.handleEmbed(rec, httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small"}`)))
+	return rec.Code
+}
+
+func TestProxyRequest_ActiveConnections(t *testing.T) {
+	received := make(chan struct{})
+	unblock := make(chan struct{})
+	p, route, _ := newConnectionTestProxy(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-unblock
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	rm := p.Router().RouteManager()
+
+	done := make(chan int)
+	go func() { done <- embedThroughProxy(p) }()
+	<-received
+
+	if got := atomic.LoadInt32(&route.ActiveConnections); got != 1 {
+		t.Errorf("route active connections = %d during request, want 1", got)
+	}
+	if got := rm.ActiveConnections("conns/all", "gpu"); got != 1 {
+		t.Errorf("destination active connections = %d during request, want 1", got)
+	}
+
+	close(unblock)
+	if code := <-done; code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if got := atomic.LoadInt32(&route.ActiveConnections); got != 0 {
+		t.Errorf("route active connections = %d after request, want 0", got)
+	}
+	if got := rm.ActiveConnections("conns/all", "gpu"); got != 0 {
+		t.Errorf("destination active connections = %d after request, want 0", got)
+	}
+}
+
+func TestProxyRequest_ActiveConnectionsOnError(t *testing.T) {
+	p, route, srv := newConnectionTestProxy(t, http.NotFoundHandler())
+	srv.Close() // Connections to the endpoint are refused
+
+	if code := embedThroughProxy(p); code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want 502", code)
+	}
+	if got := atomic.LoadInt32(&route.ActiveConnections); got != 0 {
+		t.Errorf("route active connections = %d after failed request, want 0", got)
+	}
+	if got := p.Router().RouteManager().ActiveConnections("conns/all", "gpu"); got != 0 {
+		t.Errorf("destination active connections = %d after failed request, want 0", got)
+	}
+}
+
+func TestTrackConnection_ReleasedOnPanic(t *testing.T) {
+	rm := NewRouteManager()
+	route := &Route{Name: "conns/panic"}
+
+	func() {
+		defer func() { _ = recover() }()
+		defer rm.trackConnection(route, "gpu")()
+		panic(http.ErrAbortHandler) // As httputil.ReverseProxy does on copy errors
+	}()
+
+	if got := atomic.LoadInt32(&route.ActiveConnections); got != 0 {
+		t.Errorf("route active connections = %d after panic, want 0", got)
+	}
+	if got := rm.ActiveConnections("conns/panic", "gpu"); got != 0 {
+		t.Errorf("destination active connections = %d after panic, want 0", got)
+	}
+}
+
+func TestTrackConnection_ReleaseIdempotent(t *testing.T) {
+	rm := NewRouteManager()
+	route := &Route{Name: "conns/twice"}
+
+	release := rm.trackConnection(route, "gpu")
+	release()
+	release()
+	if got := rm.ActiveConnections("conns/twice", "gpu"); got != 0 {
+		t.Errorf("active connections = %d after double release, want 0", got)
+	}
+}
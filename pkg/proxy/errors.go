@@ -0,0 +1,50 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrNoHealthyEndpoints is returned when no healthy endpoint can serve a
+	// request
+	ErrNoHealthyEndpoints = errors.New("no healthy endpoints available")
+
+	// ErrRateLimited is returned when a route's rate limiter rejects a
+	// request
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// statusForError maps an error from routing or forwarding a request to the
+// HTTP status code returned to the client. Errors are matched by kind, so
+// the status does not depend on the order in which a handler checks them;
+// anything unrecognized is an upstream failure.
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrMixedBatch):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrNoHealthyEndpoints):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
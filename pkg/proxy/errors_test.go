@@ -0,0 +1,58 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestStatusForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"mixed batch", fmt.Errorf("%w: item 0 matches a, item 1 matches b", ErrMixedBatch), http.StatusBadRequest},
+		{"rate limited", ErrRateLimited, http.StatusTooManyRequests},
+		{"no healthy endpoints", fmt.Errorf("%w for model bge", ErrNoHealthyEndpoints), http.StatusServiceUnavailable},
+		{"deadline exceeded", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), http.StatusBadGateway},
+		{"unknown", errors.New("boom"), http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusForError(tt.err); got != tt.want {
+				t.Errorf("statusForError(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouter_RouteRequest_NoHealthyEndpoints(t *testing.T) {
+	router := NewRouter(NewModelRegistry(time.Minute))
+	_, err := router.RouteRequest(context.Background(), "bge-small-en", "gpu", WorkloadTypeReadHeavy)
+	if !errors.Is(err, ErrNoHealthyEndpoints) {
+		t.Fatalf("RouteRequest error = %v, want ErrNoHealthyEndpoints", err)
+	}
+	if got := statusForError(err); got != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", got)
+	}
+}
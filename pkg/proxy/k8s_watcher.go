@@ -17,12 +17,15 @@ package proxy
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -31,14 +34,39 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// DefaultResyncPeriod is the informer resync period used by the proxy
+// command unless overridden
+const DefaultResyncPeriod = 30 * time.Second
+
+// resyncJitter is the largest fraction of the resync period added as jitter
+const resyncJitter = 0.1
+
+// newSharedInformerFactory is replaced in tests to observe resync periods
+var newSharedInformerFactory = informers.NewSharedInformerFactoryWithOptions
+
+// jitteredResync spreads informer resyncs by adding up to 10% of period at
+// random, so that informers (and proxy replicas) started together do not
+// resync in lockstep. A period of zero disables periodic resync.
+func jitteredResync(period time.Duration) time.Duration {
+	if period <= 0 {
+		return 0
+	}
+	return period + time.Duration(rand.Float64()*resyncJitter*float64(period))
+}
+
 // K8sWatcher watches Kubernetes endpoints for Termite pods
 type K8sWatcher struct {
-	proxy     *Proxy
-	clientset *kubernetes.Clientset
-	namespace string
+	proxy        *Proxy
+	clientset    kubernetes.Interface
+	namespace    string
+	resyncPeriod time.Duration
 
-	// Label selector for Termite pods
-	labelSelector labels.Selector
+	// Label selectors for Termite pods; a pod is watched if it matches any
+	labelSelectors []labels.Selector
+
+	// EndpointSlices known to the informer, used to notice when the last
+	// slice of a pool is deleted
+	endpointSlices cache.Store
 }
 
 // K8sWatcherConfig holds configuration for the K8s watcher
@@ -46,12 +74,28 @@ type K8sWatcherConfig struct {
 	Kubeconfig    string
 	Namespace     string
 	LabelSelector string // e.g., "app.kubernetes.io/name=termite"
+
+	// LabelSelectors are further selectors for Termite pods: a pod is watched
+	// if it matches LabelSelector or any of these. Each may use set-based
+	// requirements and exclusions, e.g. "antfly.io/pool in (a,b),!canary".
+	// Without any selector every pod is watched.
+	LabelSelectors []string
+
+	// ResyncPeriod is how often informers replay their cache to the event
+	// handlers, plus jitter; 0 disables periodic resync. Resyncs only
+	// repair state that missed an event, so longer periods trade slower
+	// self-healing for less CPU; watch events are delivered regardless.
+	ResyncPeriod time.Duration
 }
 
 // NewK8sWatcher creates a new Kubernetes watcher
 func NewK8sWatcher(proxy *Proxy, cfg K8sWatcherConfig) (*K8sWatcher, error) {
+	selectors, err := parseLabelSelectors(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	var config *rest.Config
-	var err error
 
 	if cfg.Kubeconfig != "" {
 		config, err = clientcmd.BuildConfigFromFlags("", cfg.Kubeconfig)
@@ -67,34 +111,67 @@ func NewK8sWatcher(proxy *Proxy, cfg K8sWatcherConfig) (*K8sWatcher, error) {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
-	selector, err := labels.Parse(cfg.LabelSelector)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse label selector: %w", err)
-	}
-
 	return &K8sWatcher{
-		proxy:         proxy,
-		clientset:     clientset,
-		namespace:     cfg.Namespace,
-		labelSelector: selector,
+		proxy:          proxy,
+		clientset:      clientset,
+		namespace:      cfg.Namespace,
+		resyncPeriod:   cfg.ResyncPeriod,
+		labelSelectors: selectors,
 	}, nil
 }
 
+// parseLabelSelectors parses the pod selectors of cfg. An empty selector in
+// LabelSelectors is rejected, since it would match every pod.
+func parseLabelSelectors(cfg K8sWatcherConfig) ([]labels.Selector, error) {
+	var exprs []string
+	if cfg.LabelSelector != "" {
+		exprs = append(exprs, cfg.LabelSelector)
+	}
+	exprs = append(exprs, cfg.LabelSelectors...)
+	if len(exprs) == 0 {
+		return []labels.Selector{labels.Everything()}, nil
+	}
+
+	selectors := make([]labels.Selector, 0, len(exprs))
+	for _, expr := range exprs {
+		if strings.TrimSpace(expr) == "" {
+			return nil, errors.New("failed to parse label selector: empty selector")
+		}
+		selector, err := labels.Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse label selector %q: %w", expr, err)
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+// matchesPod reports whether pod matches any of the watcher's selectors
+func (w *K8sWatcher) matchesPod(pod *corev1.Pod) bool {
+	set := labels.Set(pod.Labels)
+	for _, selector := range w.labelSelectors {
+		if selector.Matches(set) {
+			return true
+		}
+	}
+	return false
+}
+
 // Start begins watching Kubernetes endpoints
 func (w *K8sWatcher) Start(ctx context.Context) error {
-	var factory informers.SharedInformerFactory
-	if w.namespace != "" {
-		factory = informers.NewSharedInformerFactoryWithOptions(
-			w.clientset,
-			30*time.Second,
-			informers.WithNamespace(w.namespace),
-		)
-	} else {
-		factory = informers.NewSharedInformerFactory(w.clientset, 30*time.Second)
-	}
+	// Jitter each informer separately so they do not resync together
+	factory := newSharedInformerFactory(
+		w.clientset,
+		jitteredResync(w.resyncPeriod),
+		informers.WithNamespace(w.namespace), // "" watches all namespaces
+		informers.WithCustomResyncConfig(map[metav1.Object]time.Duration{
+			&corev1.Pod{}: jitteredResync(w.resyncPeriod),
+		}),
+	)
 
 	// Watch EndpointSlices (discovery.k8s.io/v1) for service discovery
 	endpointSliceInformer := factory.Discovery().V1().EndpointSlices().Informer()
+	w.endpointSlices = endpointSliceInformer.GetStore()
 	_, err := endpointSliceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    w.onEndpointSliceAdd,
 		UpdateFunc: w.onEndpointSliceUpdate,
@@ -137,7 +214,15 @@ func (w *K8sWatcher) onEndpointSliceUpdate(oldObj, newObj any) {
 }
 
 func (w *K8sWatcher) onEndpointSliceDelete(obj any) {
-	endpointSlice := obj.(*discoveryv1.EndpointSlice)
+	// The informer hands over a tombstone when it missed the delete event
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	endpointSlice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return
+	}
+
 	// Remove all addresses from this EndpointSlice
 	for _, endpoint := range endpointSlice.Endpoints {
 		for _, addr := range endpoint.Addresses {
@@ -145,15 +230,23 @@ func (w *K8sWatcher) onEndpointSliceDelete(obj any) {
 			w.proxy.UnregisterEndpoint(address)
 		}
 	}
+
+	// Once the last slice of a pool is gone (e.g. its TermitePool was deleted),
+	// drop any endpoints whose own delete events were missed
+	if pool, ok := endpointSlicePool(endpointSlice); ok && !w.poolHasEndpointSlices(endpointSlice.Namespace, pool) {
+		w.proxy.UnregisterPool(endpointSlice.Namespace, pool)
+	}
 }
 
-func (w *K8sWatcher) processEndpointSlice(endpointSlice *discoveryv1.EndpointSlice) {
+// endpointSlicePool returns the pool of a Termite EndpointSlice, and false
+// for slices of other services
+func endpointSlicePool(endpointSlice *discoveryv1.EndpointSlice) (string, bool) {
 	// Get the service name from the kubernetes.io/service-name label
 	serviceName := endpointSlice.Labels["kubernetes.io/service-name"]
 
 	// Check if this is a Termite service
 	if !strings.HasPrefix(serviceName, "termite-") && endpointSlice.Labels["app.kubernetes.io/name"] != "termite" {
-		return
+		return "", false
 	}
 
 	// Get pool name from service name or labels
@@ -161,6 +254,30 @@ func (w *K8sWatcher) processEndpointSlice(endpointSlice *discoveryv1.EndpointSli
 	if pool == "" {
 		pool = strings.TrimPrefix(serviceName, "termite-")
 	}
+	return pool, true
+}
+
+// poolHasEndpointSlices reports whether the informer still knows an
+// EndpointSlice of the pool in namespace
+func (w *K8sWatcher) poolHasEndpointSlices(namespace, pool string) bool {
+	if w.endpointSlices == nil {
+		return false
+	}
+	for _, obj := range w.endpointSlices.List() {
+		if endpointSlice, ok := obj.(*discoveryv1.EndpointSlice); ok && endpointSlice.Namespace == namespace {
+			if p, ok := endpointSlicePool(endpointSlice); ok && p == pool {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *K8sWatcher) processEndpointSlice(endpointSlice *discoveryv1.EndpointSlice) {
+	pool, ok := endpointSlicePool(endpointSlice)
+	if !ok {
+		return
+	}
 
 	// Get workload type from labels
 	workloadTypeStr := endpointSlice.Labels["antfly.io/workload-type"]
@@ -184,12 +301,15 @@ func (w *K8sWatcher) processEndpointSlice(endpointSlice *discoveryv1.EndpointSli
 	for _, endpoint := range endpointSlice.Endpoints {
 		// Check if endpoint is ready
 		ready := endpoint.Conditions.Ready != nil && *endpoint.Conditions.Ready
+		zones := endpointZones(endpoint)
 
 		for _, addr := range endpoint.Addresses {
 			address := fmt.Sprintf("http://%s:%d", addr, port)
 
 			if ready {
 				w.proxy.RegisterEndpoint(address, pool, workloadType)
+				w.proxy.SetEndpointNamespace(address, endpointSlice.Namespace)
+				w.proxy.SetEndpointZones(address, zones)
 			} else {
 				w.proxy.UnregisterEndpoint(address)
 			}
@@ -197,27 +317,68 @@ func (w *K8sWatcher) processEndpointSlice(endpointSlice *discoveryv1.EndpointSli
 	}
 }
 
+// endpointZones returns the zones an endpoint should serve. Kubernetes sets
+// topology hints when it has balanced endpoints across zones; without them
+// the endpoint serves its own zone.
+func endpointZones(endpoint discoveryv1.Endpoint) []string {
+	if endpoint.Hints != nil && len(endpoint.Hints.ForZones) > 0 {
+		zones := make([]string, 0, len(endpoint.Hints.ForZones))
+		for _, z := range endpoint.Hints.ForZones {
+			zones = append(zones, z.Name)
+		}
+		return zones
+	}
+	if endpoint.Zone != nil && *endpoint.Zone != "" {
+		return []string{*endpoint.Zone}
+	}
+	return nil
+}
+
 func (w *K8sWatcher) onPodAdd(obj any) {
 	pod := obj.(*corev1.Pod)
 	w.processPod(pod)
 }
 
 func (w *K8sWatcher) onPodUpdate(oldObj, newObj any) {
+	oldPod := oldObj.(*corev1.Pod)
 	pod := newObj.(*corev1.Pod)
+
+	// A pod relabeled out of the selectors stops receiving traffic
+	if w.matchesPod(oldPod) && !w.matchesPod(pod) {
+		if oldPod.Status.PodIP != "" {
+			w.proxy.UnregisterEndpoint(podAddress(oldPod))
+		}
+		return
+	}
 	w.processPod(pod)
 }
 
 func (w *K8sWatcher) onPodDelete(obj any) {
-	pod := obj.(*corev1.Pod)
+	// The informer hands over a tombstone when it missed the delete event
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || !w.matchesPod(pod) {
+		return
+	}
 	if pod.Status.PodIP != "" {
-		address := fmt.Sprintf("http://%s:11433", pod.Status.PodIP)
-		w.proxy.UnregisterEndpoint(address)
+		w.proxy.UnregisterEndpoint(podAddress(pod))
 	}
 }
 
 func (w *K8sWatcher) processPod(pod *corev1.Pod) {
-	// Check if pod matches our selector
-	if !w.labelSelector.Matches(labels.Set(pod.Labels)) {
+	// Check if pod matches one of our selectors
+	if !w.matchesPod(pod) {
+		return
+	}
+
+	// A pod being deleted (e.g. evicted for a node drain) stays ready until
+	// its containers stop; drain it now rather than when readiness changes
+	if pod.DeletionTimestamp != nil {
+		if pod.Status.PodIP != "" {
+			w.proxy.DrainEndpoint(podAddress(pod))
+		}
 		return
 	}
 
@@ -240,7 +401,19 @@ func (w *K8sWatcher) processPod(pod *corev1.Pod) {
 		workloadType = WorkloadTypeGeneral
 	}
 
-	// Get port from container spec
+	address := podAddress(pod)
+
+	if ready {
+		w.proxy.RegisterEndpoint(address, pool, workloadType)
+		w.proxy.SetEndpointNamespace(address, pod.Namespace)
+	} else {
+		w.proxy.UnregisterEndpoint(address)
+	}
+}
+
+// podAddress returns the API address of a Termite pod, using the port of
+// the termite container's http or api port if it declares one
+func podAddress(pod *corev1.Pod) string {
 	port := 11433
 	for _, container := range pod.Spec.Containers {
 		if container.Name == "termite" {
@@ -252,12 +425,5 @@ func (w *K8sWatcher) processPod(pod *corev1.Pod) {
 			}
 		}
 	}
-
-	address := fmt.Sprintf("http://%s:%d", pod.Status.PodIP, port)
-
-	if ready {
-		w.proxy.RegisterEndpoint(address, pool, workloadType)
-	} else {
-		w.proxy.UnregisterEndpoint(address)
-	}
+	return fmt.Sprintf("http://%s:%d", pod.Status.PodIP, port)
 }
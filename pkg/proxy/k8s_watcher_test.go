@@ -0,0 +1,382 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+func newTestEndpointSlice(endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "termite-gpu-abcde",
+			Labels: map[string]string{"kubernetes.io/service-name": "termite-gpu"},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+func endpointZonesByAddress(p *Proxy, pool string) map[string][]string {
+	zones := make(map[string][]string)
+	for _, ep := range p.Registry().GetEndpointsForPool(pool) {
+		zones[ep.Address] = ep.Zones
+	}
+	return zones
+}
+
+func TestProcessEndpointSlice_ZoneHints(t *testing.T) {
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	w := &K8sWatcher{proxy: p}
+
+	w.processEndpointSlice(newTestEndpointSlice(
+		discoveryv1.Endpoint{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+			Zone:       ptr.To("us-east-1a"),
+			Hints: &discoveryv1.EndpointHints{
+				ForZones: []discoveryv1.ForZone{{Name: "us-east-1b"}, {Name: "us-east-1c"}},
+			},
+		},
+		discoveryv1.Endpoint{
+			Addresses:  []string{"10.0.0.2"},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+			Zone:       ptr.To("us-east-1a"),
+		},
+		discoveryv1.Endpoint{
+			Addresses:  []string{"10.0.0.3"},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+		},
+	))
+
+	zones := endpointZonesByAddress(p, "gpu")
+	want := map[string][]string{
+		"http://10.0.0.1:11433": {"us-east-1b", "us-east-1c"}, // Hints win over the endpoint's zone
+		"http://10.0.0.2:11433": {"us-east-1a"},
+		"http://10.0.0.3:11433": nil,
+	}
+	if len(zones) != len(want) {
+		t.Fatalf("registered %d endpoints, want %d: %v", len(zones), len(want), zones)
+	}
+	for address, wantZones := range want {
+		if !slices.Equal(zones[address], wantZones) {
+			t.Errorf("%s zones = %v, want %v", address, zones[address], wantZones)
+		}
+	}
+}
+
+func TestProcessEndpointSlice_HintsUpdated(t *testing.T) {
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	w := &K8sWatcher{proxy: p}
+	endpoint := discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.1"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+		Zone:       ptr.To("us-east-1a"),
+		Hints:      &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "us-east-1b"}}},
+	}
+	w.processEndpointSlice(newTestEndpointSlice(endpoint))
+
+	// Kubernetes drops hints when it can no longer balance zones
+	endpoint.Hints = nil
+	w.processEndpointSlice(newTestEndpointSlice(endpoint))
+
+	if got := endpointZonesByAddress(p, "gpu")["http://10.0.0.1:11433"]; !slices.Equal(got, []string{"us-east-1a"}) {
+		t.Errorf("zones after hints removed = %v, want [us-east-1a]", got)
+	}
+}
+
+func TestUnregisterPool(t *testing.T) {
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	gpu := []string{"http://gpu-0:11433", "http://gpu-1:11433", "http://gpu-2:11433"}
+	for _, address := range gpu {
+		p.RegisterEndpoint(address, "gpu", WorkloadTypeGeneral)
+		p.SetEndpointNamespace(address, "team-a")
+		p.Registry().UpdateModels(address, []string{"bge-small"})
+	}
+	p.RegisterEndpoint("http://cpu-0:11433", "cpu", WorkloadTypeGeneral)
+	p.SetEndpointNamespace("http://cpu-0:11433", "team-a")
+	p.Registry().UpdateModels("http://cpu-0:11433", []string{"bge-small"})
+	// A pool of the same name in another namespace
+	p.RegisterEndpoint("http://gpu-b-0:11433", "gpu", WorkloadTypeGeneral)
+	p.SetEndpointNamespace("http://gpu-b-0:11433", "team-b")
+
+	p.UnregisterPool("team-a", "gpu")
+
+	eps := p.Registry().GetEndpointsForPool("gpu")
+	if len(eps) != 1 || eps[0].Address != "http://gpu-b-0:11433" {
+		t.Errorf("gpu pool endpoints = %v, want only team-b's gpu-b-0", eps)
+	}
+	endpoints := p.Registry().GetEndpoints()
+	for _, address := range gpu {
+		if _, ok := endpoints[address]; ok {
+			t.Errorf("%s still registered", address)
+		}
+		if p.Registry().GetCircuitBreaker(address) != nil {
+			t.Errorf("%s still has a circuit breaker", address)
+		}
+	}
+	modelEndpoints := p.Registry().GetEndpointsForModel("bge-small")
+	if len(modelEndpoints) != 1 || modelEndpoints[0].Address != "http://cpu-0:11433" {
+		t.Errorf("bge-small endpoints = %v, want only cpu-0", modelEndpoints)
+	}
+	if len(p.Registry().GetEndpointsForPool("cpu")) != 1 {
+		t.Error("cpu pool should be untouched")
+	}
+}
+
+func TestOnEndpointSliceDelete_LastSliceUnregistersPool(t *testing.T) {
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	w := &K8sWatcher{proxy: p, endpointSlices: store}
+
+	slice := newTestEndpointSlice(discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.1"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+	})
+	other := newTestEndpointSlice(discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.2"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+	})
+	other.Name = "termite-gpu-fghij"
+	w.processEndpointSlice(slice)
+	w.processEndpointSlice(other)
+	// An endpoint whose delete event was missed
+	p.RegisterEndpoint("http://10.0.0.9:11433", "gpu", WorkloadTypeGeneral)
+
+	// Another slice of the pool remains, so only the deleted addresses go
+	if err := store.Add(other); err != nil {
+		t.Fatal(err)
+	}
+	w.onEndpointSliceDelete(slice)
+	if n := len(p.Registry().GetEndpointsForPool("gpu")); n != 2 {
+		t.Fatalf("gpu has %d endpoints after deleting one slice, want 2", n)
+	}
+
+	// The last slice vanishes, delivered as a tombstone
+	if err := store.Delete(other); err != nil {
+		t.Fatal(err)
+	}
+	w.onEndpointSliceDelete(cache.DeletedFinalStateUnknown{Key: "termite-gpu-fghij", Obj: other})
+	if eps := p.Registry().GetEndpointsForPool("gpu"); len(eps) != 0 {
+		t.Errorf("gpu still has endpoints after its last slice was deleted: %v", eps)
+	}
+}
+
+func TestJitteredResync(t *testing.T) {
+	for _, period := range []time.Duration{0, -time.Second} {
+		if got := jitteredResync(period); got != 0 {
+			t.Errorf("jitteredResync(%s) = %s, want 0 (disabled)", period, got)
+		}
+	}
+
+	period := 30 * time.Second
+	seen := make(map[time.Duration]bool)
+	for range 100 {
+		got := jitteredResync(period)
+		if got < period || got >= period+period/10 {
+			t.Fatalf("jitteredResync(%s) = %s, want within [30s, 33s)", period, got)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Error("jitteredResync returned the same period every time")
+	}
+}
+
+func TestK8sWatcher_ResyncPeriod(t *testing.T) {
+	var resync time.Duration
+	defer func(orig func(kubernetes.Interface, time.Duration, ...informers.SharedInformerOption) informers.SharedInformerFactory) {
+		newSharedInformerFactory = orig
+	}(newSharedInformerFactory)
+	newSharedInformerFactory = func(client kubernetes.Interface, defaultResync time.Duration, options ...informers.SharedInformerOption) informers.SharedInformerFactory {
+		resync = defaultResync
+		return informers.NewSharedInformerFactoryWithOptions(client, defaultResync, options...)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Start returns once the factory is built and started
+
+	for _, period := range []time.Duration{time.Minute, 0} {
+		w := &K8sWatcher{
+			proxy:        NewProxy(Config{Logger: zap.NewNop()}),
+			clientset:    k8sfake.NewClientset(),
+			resyncPeriod: period,
+		}
+		_ = w.Start(ctx)
+		if resync < period || resync > period+period/10 {
+			t.Errorf("resync period %s passed to the informer factory, want %s plus jitter", resync, period)
+		}
+	}
+}
+
+func newTestPod(name, ip string, podLabels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: podLabels},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func newSelectorTestWatcher(t *testing.T, cfg K8sWatcherConfig) *K8sWatcher {
+	t.Helper()
+	selectors, err := parseLabelSelectors(cfg)
+	if err != nil {
+		t.Fatalf("parseLabelSelectors: %v", err)
+	}
+	return &K8sWatcher{proxy: NewProxy(Config{Logger: zap.NewNop()}), labelSelectors: selectors}
+}
+
+// registeredPools returns the pool of each registered endpoint by address
+func registeredPools(p *Proxy, pools ...string) map[string]string {
+	registered := make(map[string]string)
+	for _, pool := range pools {
+		for _, ep := range p.Registry().GetEndpointsForPool(pool) {
+			registered[ep.Address] = pool
+		}
+	}
+	return registered
+}
+
+func TestProcessPod_SetBasedSelector(t *testing.T) {
+	w := newSelectorTestWatcher(t, K8sWatcherConfig{LabelSelector: "antfly.io/pool in (a,b),!canary"})
+
+	w.processPod(newTestPod("a-0", "10.0.0.1", map[string]string{"antfly.io/pool": "a"}))
+	w.processPod(newTestPod("b-0", "10.0.0.2", map[string]string{"antfly.io/pool": "b"}))
+	w.processPod(newTestPod("c-0", "10.0.0.3", map[string]string{"antfly.io/pool": "c"}))
+	w.processPod(newTestPod("a-canary", "10.0.0.4", map[string]string{"antfly.io/pool": "a", "canary": "true"}))
+	w.processPod(newTestPod("unlabeled", "10.0.0.5", nil))
+
+	got := registeredPools(w.proxy, "a", "b", "c", "")
+	want := map[string]string{"http://10.0.0.1:11433": "a", "http://10.0.0.2:11433": "b"}
+	if len(got) != len(want) {
+		t.Fatalf("registered endpoints = %v, want %v", got, want)
+	}
+	for address, pool := range want {
+		if got[address] != pool {
+			t.Errorf("%s registered in pool %q, want %q", address, got[address], pool)
+		}
+	}
+}
+
+func TestProcessPod_AnySelectorMatches(t *testing.T) {
+	w := newSelectorTestWatcher(t, K8sWatcherConfig{
+		LabelSelectors: []string{"antfly.io/pool=a", "app=termite,antfly.io/pool notin (a)"},
+	})
+
+	w.processPod(newTestPod("a-0", "10.0.0.1", map[string]string{"antfly.io/pool": "a"}))
+	w.processPod(newTestPod("b-0", "10.0.0.2", map[string]string{"antfly.io/pool": "b", "app": "termite"}))
+	w.processPod(newTestPod("b-1", "10.0.0.3", map[string]string{"antfly.io/pool": "b"}))
+
+	got := registeredPools(w.proxy, "a", "b")
+	if len(got) != 2 || got["http://10.0.0.1:11433"] != "a" || got["http://10.0.0.2:11433"] != "b" {
+		t.Errorf("registered endpoints = %v, want a-0 and b-0", got)
+	}
+}
+
+func TestOnPodUpdate_RelabeledOutOfSelector(t *testing.T) {
+	w := newSelectorTestWatcher(t, K8sWatcherConfig{LabelSelector: "antfly.io/pool in (a,b)"})
+	pod := newTestPod("a-0", "10.0.0.1", map[string]string{"antfly.io/pool": "a"})
+	w.onPodAdd(pod)
+
+	relabeled := pod.DeepCopy()
+	relabeled.Labels["antfly.io/pool"] = "c"
+	w.onPodUpdate(pod, relabeled)
+
+	if got := registeredPools(w.proxy, "a", "c"); len(got) != 0 {
+		t.Errorf("registered endpoints = %v after the pod left the selector", got)
+	}
+}
+
+func TestOnPodUpdate_DeletionTimestampDrains(t *testing.T) {
+	w := newSelectorTestWatcher(t, K8sWatcherConfig{})
+	pod := newTestPod("a-0", "10.0.0.1", map[string]string{"antfly.io/pool": "a"})
+	w.onPodAdd(pod)
+	w.proxy.Registry().UpdateModels("http://10.0.0.1:11433", []string{"bge-small"})
+
+	// Eviction sets the deletion timestamp while the pod is still ready
+	evicted := pod.DeepCopy()
+	evicted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	w.onPodUpdate(pod, evicted)
+
+	if got := registeredPools(w.proxy, "a"); len(got) != 0 {
+		t.Errorf("routable endpoints = %v after the pod started terminating", got)
+	}
+	if eps := w.proxy.Registry().GetEndpointsForModel("bge-small"); len(eps) != 0 {
+		t.Errorf("bge-small routes to %d endpoints, want none", len(eps))
+	}
+	ep, ok := w.proxy.Registry().GetEndpoints()["http://10.0.0.1:11433"]
+	if !ok || !ep.Draining {
+		t.Fatalf("endpoint = %+v, want it kept registered and draining", ep)
+	}
+
+	// An EndpointSlice that has not caught up yet does not revive it
+	w.processEndpointSlice(&discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "termite-a-abcde",
+			Labels: map[string]string{"kubernetes.io/service-name": "termite-a"},
+		},
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+		}},
+	})
+	if got := registeredPools(w.proxy, "a"); len(got) != 0 {
+		t.Errorf("routable endpoints = %v after a stale EndpointSlice update", got)
+	}
+
+	w.onPodDelete(evicted)
+	if _, ok := w.proxy.Registry().GetEndpoints()["http://10.0.0.1:11433"]; ok {
+		t.Error("endpoint still registered after the pod was deleted")
+	}
+}
+
+func TestParseLabelSelectors(t *testing.T) {
+	selectors, err := parseLabelSelectors(K8sWatcherConfig{})
+	if err != nil || len(selectors) != 1 || !selectors[0].Empty() {
+		t.Errorf("no selectors = %v, %v; want one matching everything", selectors, err)
+	}
+
+	for _, cfg := range []K8sWatcherConfig{
+		{LabelSelector: "antfly.io/pool in (a,b"},
+		{LabelSelector: "app=termite", LabelSelectors: []string{"!!canary"}},
+		{LabelSelectors: []string{"app=termite", ""}},
+	} {
+		if _, err := parseLabelSelectors(cfg); err == nil {
+			t.Errorf("parseLabelSelectors(%+v) succeeded, want error", cfg)
+		}
+	}
+
+	// Invalid selectors are rejected before connecting to the cluster
+	if _, err := NewK8sWatcher(nil, K8sWatcherConfig{LabelSelector: "antfly.io/pool in (a,b"}); err == nil ||
+		!strings.Contains(err.Error(), "label selector") {
+		t.Errorf("NewK8sWatcher error = %v, want label selector error", err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricSource evaluates a metric expression to a single value
+type MetricSource interface {
+	Query(ctx context.Context, query string) (float64, error)
+}
+
+// PrometheusClient queries the Prometheus HTTP API for instant values.
+// Once Run is started, queries are refreshed in the background every
+// cacheTTL and Query only reads the cache, so destination conditions do not
+// wait on Prometheus while routing a request. A query is fetched inline with
+// the caller's context only the first time it is seen (or, without Run, once
+// its cached value is older than cacheTTL).
+type PrometheusClient struct {
+	endpoint string
+	client   *http.Client
+	cacheTTL time.Duration
+
+	mu      sync.Mutex
+	cache   map[string]*cachedMetric
+	polling bool
+}
+
+type cachedMetric struct {
+	value     float64
+	err       error
+	fetchedAt time.Time
+	used      bool // Read since the last background refresh
+}
+
+// NewPrometheusClient creates a client for the Prometheus server at endpoint
+// (e.g. "http://prometheus:9090")
+func NewPrometheusClient(endpoint string, cacheTTL time.Duration) *PrometheusClient {
+	return &PrometheusClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]*cachedMetric),
+	}
+}
+
+// Query returns the value of a PromQL expression that yields a scalar or a
+// single-series vector. Results, including errors, are cached.
+func (c *PrometheusClient) Query(ctx context.Context, query string) (float64, error) {
+	c.mu.Lock()
+	if cached, ok := c.cache[query]; ok && (c.polling || time.Since(cached.fetchedAt) < c.cacheTTL) {
+		cached.used = true
+		value, err := cached.value, cached.err
+		c.mu.Unlock()
+		return value, err
+	}
+	c.mu.Unlock()
+
+	value, err := c.query(ctx, query)
+
+	c.mu.Lock()
+	c.cache[query] = &cachedMetric{value: value, err: err, fetchedAt: time.Now(), used: true}
+	c.mu.Unlock()
+
+	return value, err
+}
+
+// Run refreshes cached queries every cacheTTL until ctx is cancelled.
+// Queries not read since the previous refresh are dropped rather than
+// refreshed, so removed routes stop generating Prometheus load.
+func (c *PrometheusClient) Run(ctx context.Context) {
+	if c.cacheTTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.polling = true
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.polling = false
+		c.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(c.cacheTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+// refresh re-fetches every query read since the last refresh
+func (c *PrometheusClient) refresh(ctx context.Context) {
+	c.mu.Lock()
+	queries := make([]string, 0, len(c.cache))
+	for query, cached := range c.cache {
+		if !cached.used {
+			delete(c.cache, query)
+			continue
+		}
+		cached.used = false
+		queries = append(queries, query)
+	}
+	c.mu.Unlock()
+
+	for _, query := range queries {
+		value, err := c.query(ctx, query)
+		if ctx.Err() != nil {
+			return
+		}
+		c.mu.Lock()
+		if cached, ok := c.cache[query]; ok {
+			cached.value, cached.err, cached.fetchedAt = value, err, time.Now()
+		}
+		c.mu.Unlock()
+	}
+}
+
+// promResponse is the subset of the Prometheus /api/v1/query response we use
+type promResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (c *PrometheusClient) query(ctx context.Context, query string) (float64, error) {
+	reqURL := c.endpoint + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("querying prometheus: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var pr promResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return 0, fmt.Errorf("decoding prometheus response: %w", err)
+	}
+	if pr.Status != "success" {
+		return 0, fmt.Errorf("prometheus query failed: %s", pr.Error)
+	}
+
+	var sample []any
+	switch pr.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(pr.Data.Result, &sample); err != nil {
+			return 0, fmt.Errorf("decoding scalar result: %w", err)
+		}
+	case "vector":
+		var series []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(pr.Data.Result, &series); err != nil {
+			return 0, fmt.Errorf("decoding vector result: %w", err)
+		}
+		if len(series) != 1 {
+			return 0, fmt.Errorf("query returned %d series, expected 1 (aggregate it, e.g. avg(...))", len(series))
+		}
+		sample = series[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", pr.Data.ResultType)
+	}
+
+	// Samples are [timestamp, "value"]
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("malformed sample: %v", sample)
+	}
+	valueStr, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("malformed sample value: %v", sample[1])
+	}
+	return strconv.ParseFloat(valueStr, 64)
+}
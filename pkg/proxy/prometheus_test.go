@@ -0,0 +1,197 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMockPrometheus serves /api/v1/query with a single-series vector whose
+// value is read from value on each request
+func newMockPrometheus(t *testing.T, value *atomic.Value, queries *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		queries.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pool":"gpu"},"value":[1700000000.0,"%s"]}]}}`,
+			value.Load().(string))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEvaluateConditions_PrometheusMetric(t *testing.T) {
+	var value atomic.Value
+	var queries atomic.Int32
+	value.Store("95")
+	server := newMockPrometheus(t, &value, &queries)
+
+	rm := NewRouteManager()
+	rm.SetMetricSource(NewPrometheusClient(server.URL, time.Hour))
+
+	threshold, err := ParseCountCondition("<80")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := &Destination{
+		Pool: "gpu",
+		MetricCondition: &MetricCondition{
+			Query:     `avg(gpu_util{pool="gpu"})`,
+			Threshold: threshold,
+		},
+	}
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "gpu", "")
+	req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+
+	if rm.evaluateConditions(dest, req, registry) {
+		t.Error("destination should be excluded when the metric exceeds the threshold")
+	}
+
+	// The cached value is reused rather than querying Prometheus again
+	value.Store("10")
+	if rm.evaluateConditions(dest, req, registry) {
+		t.Error("expected cached metric value to still exclude the destination")
+	}
+	if got := queries.Load(); got != 1 {
+		t.Errorf("expected 1 Prometheus query, got %d", got)
+	}
+
+	// Once the cache expires the new value is used
+	rm.SetMetricSource(NewPrometheusClient(server.URL, 0))
+	if !rm.evaluateConditions(dest, req, registry) {
+		t.Error("destination should be eligible when the metric is below the threshold")
+	}
+}
+
+func TestEvaluateConditions_MetricWithoutSource(t *testing.T) {
+	threshold, _ := ParseCountCondition("<80")
+	dest := &Destination{
+		Pool:            "gpu",
+		MetricCondition: &MetricCondition{Query: "up", Threshold: threshold},
+	}
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "gpu", "")
+
+	rm := NewRouteManager()
+	if rm.evaluateConditions(dest, &RouteRequest{Timestamp: time.Now()}, registry) {
+		t.Error("metric condition should fail closed without a metric source")
+	}
+}
+
+func TestPrometheusClient_MultipleSeries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"value":[0,"1"]},{"value":[0,"2"]}]}}`)
+	}))
+	defer server.Close()
+
+	_, err := NewPrometheusClient(server.URL, time.Minute).Query(t.Context(), "up")
+	if err == nil {
+		t.Fatal("expected an error for a query returning multiple series")
+	}
+}
+
+func TestPrometheusClient_RunRefreshesInBackground(t *testing.T) {
+	var value atomic.Value
+	var queries atomic.Int32
+	value.Store("95")
+	server := newMockPrometheus(t, &value, &queries)
+
+	client := NewPrometheusClient(server.URL, 20*time.Millisecond)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go client.Run(ctx)
+
+	if got, err := client.Query(t.Context(), "up"); err != nil || got != 95 {
+		t.Fatalf("Query() = %v, %v, want 95", got, err)
+	}
+
+	// The poller picks up the new value; Query never fetches it itself
+	value.Store("10")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := client.Query(t.Context(), "up")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got == 10 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh did not update the cached value")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPrometheusClient_RunDropsUnusedQueries(t *testing.T) {
+	var value atomic.Value
+	var queries atomic.Int32
+	value.Store("1")
+	server := newMockPrometheus(t, &value, &queries)
+
+	client := NewPrometheusClient(server.URL, time.Hour)
+	if _, err := client.Query(t.Context(), "up"); err != nil {
+		t.Fatal(err)
+	}
+
+	client.refresh(t.Context()) // Used since the query: refreshed
+	client.refresh(t.Context()) // Not read since: dropped
+	if got := queries.Load(); got != 2 {
+		t.Errorf("expected 2 Prometheus queries, got %d", got)
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if _, ok := client.cache["up"]; ok {
+		t.Error("expected the unused query to be dropped from the cache")
+	}
+}
+
+func TestEvaluateConditions_MetricUsesRequestContext(t *testing.T) {
+	var value atomic.Value
+	var queries atomic.Int32
+	value.Store("10")
+	server := newMockPrometheus(t, &value, &queries)
+
+	rm := NewRouteManager()
+	rm.SetMetricSource(NewPrometheusClient(server.URL, time.Hour))
+
+	threshold, _ := ParseCountCondition("<80")
+	dest := &Destination{
+		Pool:            "gpu",
+		MetricCondition: &MetricCondition{Query: "up", Threshold: threshold},
+	}
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "gpu", "")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if rm.evaluateConditions(dest, &RouteRequest{Timestamp: time.Now(), Context: ctx}, registry) {
+		t.Error("metric condition should fail closed when the request context is cancelled")
+	}
+	if got := queries.Load(); got != 0 {
+		t.Errorf("expected no Prometheus query with a cancelled context, got %d", got)
+	}
+}
@@ -16,15 +16,19 @@
 package proxy
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"net/netip"
 	"net/url"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -77,6 +81,31 @@ var (
 		[]string{"pool", "endpoint"},
 	)
 
+	retriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "termite_proxy_retries_total",
+			Help: "Retried request attempts by route and operation",
+		},
+		[]string{"route", "operation"},
+	)
+
+	routingLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "termite_proxy_routing_duration_seconds",
+			Help:    "End-to-end latency of requests matched to a route, including forwarding, by route, destination pool and outcome",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+		[]string{"route", "pool", "outcome"},
+	)
+
+	unmatchedRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "termite_proxy_unmatched_requests_total",
+			Help: "Requests that matched no route, by operation",
+		},
+		[]string{"operation"},
+	)
+
 	activeConnections = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "termite_proxy_active_connections",
@@ -84,8 +113,28 @@ var (
 		},
 		[]string{"pool", "endpoint"},
 	)
+
+	rateLimitTokens = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "termite_proxy_rate_limit_tokens",
+			Help: "Tokens left in a route's rate limit bucket, by route and model (empty for a route-wide bucket), sampled every refresh interval",
+		},
+		[]string{"route", "model"},
+	)
+)
+
+// Outcomes of routing a request matched to a route
+const (
+	routeOutcomeMatched  = "matched"  // Sent to a destination of the route
+	routeOutcomeFallback = "fallback" // No eligible destination; redirected or default routing
+	routeOutcomeRejected = "rejected" // Rate limited or rejected by the route's fallback
 )
 
+// observeRouting records the end-to-end latency of a request matched to route
+func observeRouting(route *Route, pool, outcome string, start time.Time) {
+	routingLatency.WithLabelValues(route.Name, pool, outcome).Observe(time.Since(start).Seconds())
+}
+
 // WorkloadType represents the type of workload a pool handles
 type WorkloadType string
 
@@ -100,12 +149,24 @@ const (
 type Endpoint struct {
 	Address      string
 	Pool         string
+	Namespace    string // Kubernetes namespace of the pool, empty for static endpoints
 	WorkloadType WorkloadType
 	Models       map[string]*ModelInfo
 	QueueDepth   int32
 	LastSeen     time.Time
 	Healthy      bool
 	Connections  int32 // Active connections
+
+	// ModelsRefreshed is when the endpoint last reported its loaded models
+	ModelsRefreshed time.Time
+
+	// Zones the endpoint should serve: Kubernetes topology hints when the
+	// EndpointSlice has them, otherwise the endpoint's own zone
+	Zones []string
+
+	// Draining is set once the endpoint's pod is being deleted: it gets no
+	// new requests, while requests in flight complete, until it unregisters
+	Draining bool
 }
 
 // ModelInfo contains information about a loaded model
@@ -189,9 +250,17 @@ type ModelRegistry struct {
 	refreshInterval time.Duration
 	client          *http.Client
 
+	// inventoryTTL is how long a reported model inventory counts for
+	// modelLoaded conditions; 0 never expires it
+	inventoryTTL time.Duration
+
 	mu sync.RWMutex
 }
 
+// modelInventoryRefreshes is how many refresh intervals a model inventory
+// stays valid, so that one failed refresh does not make models disappear
+const modelInventoryRefreshes = 3
+
 // NewModelRegistry creates a new ModelRegistry
 func NewModelRegistry(refreshInterval time.Duration) *ModelRegistry {
 	return &ModelRegistry{
@@ -200,6 +269,7 @@ func NewModelRegistry(refreshInterval time.Duration) *ModelRegistry {
 		pools:           make(map[string][]*Endpoint),
 		circuitBreakers: make(map[string]*CircuitBreaker),
 		refreshInterval: refreshInterval,
+		inventoryTTL:    modelInventoryRefreshes * refreshInterval,
 		client: &http.Client{
 			Timeout: 5 * time.Second,
 		},
@@ -239,6 +309,38 @@ func (r *ModelRegistry) RegisterEndpoint(address, pool string, workloadType Work
 	}
 }
 
+// SetEndpointZones records the zones an endpoint serves
+func (r *ModelRegistry) SetEndpointZones(address string, zones []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ep, exists := r.endpoints[address]; exists {
+		ep.Zones = zones
+	}
+}
+
+// SetEndpointNamespace records the Kubernetes namespace of an endpoint's pool
+func (r *ModelRegistry) SetEndpointNamespace(address, namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ep, exists := r.endpoints[address]; exists {
+		ep.Namespace = namespace
+	}
+}
+
+// DrainEndpoint stops routing new requests to an endpoint without removing
+// it. Registering the endpoint again does not undo this, so a stale
+// EndpointSlice still listing the endpoint as ready cannot revive it.
+func (r *ModelRegistry) DrainEndpoint(address string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ep, exists := r.endpoints[address]; exists {
+		ep.Draining = true
+	}
+}
+
 // UnregisterEndpoint removes an endpoint
 func (r *ModelRegistry) UnregisterEndpoint(address string) {
 	r.mu.Lock()
@@ -259,19 +361,47 @@ func (r *ModelRegistry) UnregisterEndpoint(address string) {
 	}
 	r.pools[pool] = newPoolEndpoints
 
-	// Remove from model index
+	r.removeEndpointLocked(ep)
+}
+
+// UnregisterPool removes every endpoint of the pool in a namespace, for when
+// the pool itself is gone and per-endpoint delete events may have been missed.
+// Pools of the same name in other namespaces keep their endpoints.
+func (r *ModelRegistry) UnregisterPool(namespace, pool string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remaining := make([]*Endpoint, 0)
+	for _, ep := range r.pools[pool] {
+		if ep.Namespace != namespace {
+			remaining = append(remaining, ep)
+			continue
+		}
+		// Also indexed under the pool it was first registered with
+		if ep.Pool == pool {
+			r.removeEndpointLocked(ep)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(r.pools, pool)
+	} else {
+		r.pools[pool] = remaining
+	}
+}
+
+func (r *ModelRegistry) removeEndpointLocked(ep *Endpoint) {
 	for model := range ep.Models {
 		newModelEndpoints := make([]*Endpoint, 0)
 		for _, e := range r.models[model] {
-			if e.Address != address {
+			if e.Address != ep.Address {
 				newModelEndpoints = append(newModelEndpoints, e)
 			}
 		}
 		r.models[model] = newModelEndpoints
 	}
 
-	delete(r.endpoints, address)
-	delete(r.circuitBreakers, address)
+	delete(r.endpoints, ep.Address)
+	delete(r.circuitBreakers, ep.Address)
 }
 
 // UpdateModels refreshes the model list for an endpoint
@@ -334,6 +464,27 @@ func (r *ModelRegistry) UpdateModels(address string, models []string) {
 	}
 
 	ep.LastSeen = time.Now()
+	ep.ModelsRefreshed = ep.LastSeen
+}
+
+// SetModelInventoryTTL sets how long a reported model inventory counts for
+// modelLoaded conditions (0 never expires it)
+func (r *ModelRegistry) SetModelInventoryTTL(ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inventoryTTL = ttl
+}
+
+// HasModel reports whether ep reported model as loaded in an inventory that
+// has not expired
+func (r *ModelRegistry) HasModel(ep *Endpoint, model string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := ep.Models[model]; !ok {
+		return false
+	}
+	return r.inventoryTTL <= 0 || time.Since(ep.ModelsRefreshed) <= r.inventoryTTL
 }
 
 // GetEndpointsForModel returns endpoints that have a specific model loaded
@@ -344,7 +495,7 @@ func (r *ModelRegistry) GetEndpointsForModel(model string) []*Endpoint {
 	endpoints := r.models[model]
 	result := make([]*Endpoint, 0, len(endpoints))
 	for _, ep := range endpoints {
-		if ep.Healthy && r.circuitBreakers[ep.Address].Allow() {
+		if ep.Healthy && !ep.Draining && r.circuitBreakers[ep.Address].Allow() {
 			result = append(result, ep)
 		}
 	}
@@ -359,16 +510,21 @@ func (r *ModelRegistry) GetEndpointsForPool(pool string) []*Endpoint {
 	endpoints := r.pools[pool]
 	result := make([]*Endpoint, 0, len(endpoints))
 	for _, ep := range endpoints {
-		if ep.Healthy && r.circuitBreakers[ep.Address].Allow() {
+		if ep.Healthy && !ep.Draining && r.circuitBreakers[ep.Address].Allow() {
 			result = append(result, ep)
 		}
 	}
 	return result
 }
 
-// RefreshEndpoint fetches current model list and health from an endpoint
+// RefreshEndpoint fetches the loaded models of an endpoint from its
+// /api/models and marks it healthy, or unhealthy if the request fails
 func (r *ModelRegistry) RefreshEndpoint(ctx context.Context, address string) error {
-	resp, err := r.client.Get(address + "/api/models")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+"/api/models", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
 	if err != nil {
 		r.markUnhealthy(address)
 		return err
@@ -380,25 +536,61 @@ func (r *ModelRegistry) RefreshEndpoint(ctx context.Context, address string) err
 		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 
-	var modelsResp struct {
-		Models []struct {
-			Name string `json:"name"`
-		} `json:"models"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&modelsResp); err != nil {
+	var inventory modelInventory
+	if err := json.NewDecoder(resp.Body).Decode(&inventory); err != nil {
 		return err
 	}
 
-	models := make([]string, len(modelsResp.Models))
-	for i, m := range modelsResp.Models {
-		models[i] = m.Name
-	}
-
-	r.UpdateModels(address, models)
+	r.UpdateModels(address, inventory.loadedModels())
 	r.markHealthy(address)
 	return nil
 }
 
+// modelInventory is the part of a Termite /api/models response that lists
+// the models an endpoint can serve without loading them first
+type modelInventory struct {
+	Embedders       []string `json:"embedders"`
+	EmbedderDetails []struct {
+		Name   string `json:"name"`
+		Loaded bool   `json:"loaded"`
+	} `json:"embedder_details"`
+	Rerankers []string            `json:"rerankers"`
+	Chunkers  []string            `json:"chunkers"`
+	Aliases   map[string][]string `json:"aliases"`
+
+	// Models is the list format of other backends
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+// loadedModels returns the loaded model names, with their aliases. Lazily
+// loaded embedders only count once loaded, which embedder_details reports;
+// without details every listed embedder counts as loaded.
+func (inv *modelInventory) loadedModels() []string {
+	var models []string
+	if len(inv.EmbedderDetails) > 0 {
+		for _, d := range inv.EmbedderDetails {
+			if d.Loaded {
+				models = append(models, d.Name)
+			}
+		}
+	} else {
+		models = append(models, inv.Embedders...)
+	}
+	models = append(models, inv.Rerankers...)
+	models = append(models, inv.Chunkers...)
+	for _, m := range inv.Models {
+		models = append(models, m.Name)
+	}
+
+	var aliases []string
+	for _, name := range models {
+		aliases = append(aliases, inv.Aliases[name]...)
+	}
+	return append(models, aliases...)
+}
+
 func (r *ModelRegistry) markHealthy(address string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -478,7 +670,7 @@ func (r *Router) RouteRequest(ctx context.Context, model string, pool string, wo
 	}
 
 	if len(endpoints) == 0 {
-		return nil, fmt.Errorf("no healthy endpoints available for model %s", model)
+		return nil, fmt.Errorf("%w for model %s", ErrNoHealthyEndpoints, model)
 	}
 
 	// Apply routing strategy based on workload type
@@ -503,7 +695,7 @@ func (r *Router) RouteManager() *RouteManager {
 // but picks the least loaded among top candidates
 func (r *Router) consistentHashWithLeastLoaded(endpoints []*Endpoint, model string) (*Endpoint, error) {
 	if len(endpoints) == 0 {
-		return nil, fmt.Errorf("no endpoints available")
+		return nil, ErrNoHealthyEndpoints
 	}
 
 	// Get consistent hash candidates (top 3)
@@ -519,7 +711,7 @@ func (r *Router) consistentHashWithLeastLoaded(endpoints []*Endpoint, model stri
 // leastLoaded selects the endpoint with fewest active connections
 func (r *Router) leastLoaded(endpoints []*Endpoint) (*Endpoint, error) {
 	if len(endpoints) == 0 {
-		return nil, fmt.Errorf("no endpoints available")
+		return nil, ErrNoHealthyEndpoints
 	}
 
 	// Sort by connections (ascending)
@@ -535,7 +727,7 @@ func (r *Router) leastLoaded(endpoints []*Endpoint) (*Endpoint, error) {
 // roundRobinWithQueueAwareness distributes load but respects queue limits
 func (r *Router) roundRobinWithQueueAwareness(endpoints []*Endpoint, maxQueue int32) (*Endpoint, error) {
 	if len(endpoints) == 0 {
-		return nil, fmt.Errorf("no endpoints available")
+		return nil, ErrNoHealthyEndpoints
 	}
 
 	// Filter out endpoints with full queues
@@ -611,22 +803,59 @@ type Proxy struct {
 	registry     *ModelRegistry
 	router       *Router
 	routeWatcher *RouteWatcher
+	metrics      *PrometheusClient // Polled in the background when configured
 	server       *http.Server
 	logger       *zap.Logger
 
-	defaultPool string
-	listenAddr  string
+	defaultPool    string
+	listenAddr     string
+	trustedSources []netip.Prefix
+	adminToken     string
+
+	client           *http.Client // Forwards requests to Termite endpoints
+	maxBatchItems    int
+	maxBatchBytes    int64
+	batchConcurrency int
 }
 
+// Batch limits used when Config leaves them unset
+const (
+	DefaultMaxBatchItems    = 256
+	DefaultMaxBatchBytes    = 32 << 20
+	DefaultBatchConcurrency = 16
+)
+
 // Config holds proxy configuration
 type Config struct {
 	ListenAddr           string
 	DefaultPool          string
 	RefreshInterval      time.Duration
-	EnableRouteWatching  bool        // Enable watching TermiteRoute CRs
-	RouteWatchNamespace  string      // Namespace to watch for routes (empty for all)
-	RouteWatchKubeconfig string      // Optional kubeconfig path for route watching
-	Logger               *zap.Logger // Optional logger (defaults to production logger)
+	EnableRouteWatching  bool          // Enable watching TermiteRoute CRs
+	RouteWatchNamespace  string        // Namespace to watch for routes (empty for all)
+	RouteWatchKubeconfig string        // Optional kubeconfig path for route watching
+	RouteResyncPeriod    time.Duration // TermiteRoute informer resync period before jitter (0 disables)
+	PrometheusURL        string        // Prometheus server for metric route conditions (optional)
+	PrometheusCacheTTL   time.Duration // How often metric queries are refreshed in the background
+	FailoverCooldown     time.Duration // How long routes stay on a failover destination (0 disables)
+	SlowStartWindow      time.Duration // How long recovered destinations ramp up to full weight (0 disables)
+	Logger               *zap.Logger   // Optional logger (defaults to production logger)
+
+	// Transport forwards requests to Termite endpoints (defaults to
+	// http.DefaultTransport)
+	Transport http.RoundTripper
+
+	MaxBatchItems    int   // Most requests in one /api/batch call (defaults to DefaultMaxBatchItems)
+	MaxBatchBytes    int64 // Largest /api/batch body (defaults to DefaultMaxBatchBytes)
+	BatchConcurrency int   // Most batch items forwarded at once (defaults to DefaultBatchConcurrency)
+
+	// TrustedSourceNetworks are the peers (e.g. mesh sidecars or gateways)
+	// allowed to identify the source namespace and service account of a
+	// request. The headers are stripped from requests from any other peer.
+	TrustedSourceNetworks []netip.Prefix
+
+	// AdminToken is the bearer token required by the /admin/* endpoints,
+	// which are disabled when it is empty
+	AdminToken string
 }
 
 // NewProxy creates a new Proxy
@@ -640,22 +869,48 @@ func NewProxy(cfg Config) *Proxy {
 	}
 
 	p := &Proxy{
-		registry:    registry,
-		router:      router,
-		defaultPool: cfg.DefaultPool,
-		listenAddr:  cfg.ListenAddr,
-		logger:      logger,
+		registry:       registry,
+		router:         router,
+		defaultPool:    cfg.DefaultPool,
+		listenAddr:     cfg.ListenAddr,
+		trustedSources: cfg.TrustedSourceNetworks,
+		adminToken:     cfg.AdminToken,
+		logger:         logger,
+
+		client:           &http.Client{Transport: cfg.Transport},
+		maxBatchItems:    cfg.MaxBatchItems,
+		maxBatchBytes:    cfg.MaxBatchBytes,
+		batchConcurrency: cfg.BatchConcurrency,
+	}
+	if p.maxBatchItems <= 0 {
+		p.maxBatchItems = DefaultMaxBatchItems
+	}
+	if p.maxBatchBytes <= 0 {
+		p.maxBatchBytes = DefaultMaxBatchBytes
+	}
+	if p.batchConcurrency <= 0 {
+		p.batchConcurrency = DefaultBatchConcurrency
+	}
+
+	router.RouteManager().SetFailoverCooldown(cfg.FailoverCooldown)
+	router.RouteManager().SetSlowStartWindow(cfg.SlowStartWindow)
+	if cfg.PrometheusURL != "" {
+		p.metrics = NewPrometheusClient(cfg.PrometheusURL, cfg.PrometheusCacheTTL)
+		router.RouteManager().SetMetricSource(p.metrics)
 	}
 
 	// Initialize RouteWatcher if enabled
 	if cfg.EnableRouteWatching {
 		routeWatcher, err := NewRouteWatcher(router.RouteManager(), RouteWatcherConfig{
-			Kubeconfig: cfg.RouteWatchKubeconfig,
-			Namespace:  cfg.RouteWatchNamespace,
+			Kubeconfig:   cfg.RouteWatchKubeconfig,
+			Namespace:    cfg.RouteWatchNamespace,
+			ResyncPeriod: cfg.RouteResyncPeriod,
 		}, logger)
 		if err != nil {
 			logger.Error("failed to create RouteWatcher, route-based routing disabled", zap.Error(err))
 		} else {
+			routeWatcher.poolDeleted = p.UnregisterPool
+			routeWatcher.registry = registry
 			p.routeWatcher = routeWatcher
 		}
 	}
@@ -670,17 +925,26 @@ func (p *Proxy) Start(ctx context.Context) error {
 	apiMux.HandleFunc("/api/embed", p.handleEmbed)
 	apiMux.HandleFunc("/api/chunk", p.handleChunk)
 	apiMux.HandleFunc("/api/rerank", p.handleRerank)
+	apiMux.HandleFunc("/api/batch", p.handleBatch)
 	apiMux.HandleFunc("/healthz", p.handleHealth)
 	apiMux.HandleFunc("/readyz", p.handleReady)
 
+	// Admin endpoints require a bearer token and are disabled without one
+	if p.adminToken != "" {
+		apiMux.HandleFunc("GET /admin/ratelimits", p.handleRateLimits)
+	}
+
 	p.server = &http.Server{
 		Addr:              p.listenAddr,
-		Handler:           apiMux,
+		Handler:           p.withTrustedSource(apiMux),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
 	// Start background refresh
 	go p.refreshLoop(ctx)
+	if p.metrics != nil {
+		go p.metrics.Run(ctx)
+	}
 
 	// Start RouteWatcher if configured
 	if p.routeWatcher != nil {
@@ -732,81 +996,65 @@ func (p *Proxy) proxyRequest(w http.ResponseWriter, r *http.Request, operation s
 		return
 	}
 
-	// Build headers map for route matching
-	headers := make(map[string]string)
-	for k := range r.Header {
-		headers[k] = r.Header.Get(k)
-	}
-
 	// Try route-based matching first
 	var pool string
-	routeReq := &RouteRequest{
-		Operation: OperationType(operation),
-		Model:     req.Model,
-		Headers:   headers,
-		Timestamp: start,
-	}
+	routeReq := newRouteRequest(r, OperationType(operation), req.Model, start)
+	matchedRoute := p.router.RouteManager().Match(routeReq)
+	if matchedRoute != nil {
+		outcome := routeOutcomeMatched
+		defer func() { observeRouting(matchedRoute, pool, outcome, start) }()
 
-	if matchedRoute := p.router.RouteManager().Match(routeReq); matchedRoute != nil {
 		// Check rate limiting
 		if matchedRoute.RateLimiter != nil && !matchedRoute.RateLimiter.Allow(req.Model) {
-			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			outcome = routeOutcomeRejected
+			http.Error(w, ErrRateLimited.Error(), statusForError(ErrRateLimited))
 			return
 		}
 
-		// Select destination from matched route
-		dest, err := p.router.RouteManager().SelectDestination(matchedRoute, routeReq, p.registry)
-		if err == nil && dest != nil {
-			pool = dest.Pool
-		} else if matchedRoute.Fallback != nil {
-			// Handle fallback
-			switch matchedRoute.Fallback.Action {
-			case "reject":
-				statusCode := matchedRoute.Fallback.StatusCode
-				if statusCode == 0 {
-					statusCode = 503
-				}
-				msg := matchedRoute.Fallback.Message
-				if msg == "" {
-					msg = "no healthy endpoints available"
-				}
-				if matchedRoute.Fallback.RetryAfter > 0 {
-					w.Header().Set("Retry-After", fmt.Sprintf("%d", matchedRoute.Fallback.RetryAfter))
-				}
-				http.Error(w, msg, statusCode)
-				return
-			case "redirect":
-				pool = matchedRoute.Fallback.RedirectPool
+		var fallback bool
+		var rejected *routeRejection
+		pool, fallback, rejected = p.selectPool(matchedRoute, routeReq)
+		if rejected != nil {
+			outcome = routeOutcomeRejected
+			if rejected.RetryAfter > 0 {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", rejected.RetryAfter))
 			}
+			http.Error(w, rejected.Message, rejected.StatusCode)
+			return
 		}
+		if fallback {
+			outcome = routeOutcomeFallback
+		}
+	} else {
+		unmatchedRequests.WithLabelValues(operation).Inc()
 	}
 
-	// Fall back to X-Termite-Pool header or default pool
 	if pool == "" {
-		pool = r.Header.Get("X-Termite-Pool")
+		pool = p.fallbackPool(r, req.Model)
 	}
-	if pool == "" {
-		pool = p.defaultPool
+	workloadType := workloadTypeFor(r, operation)
+
+	if matchedRoute != nil {
+		defer p.router.RouteManager().trackConnection(matchedRoute, pool)()
 	}
 
-	// Determine workload type from header or infer from operation
-	workloadType := WorkloadType(r.Header.Get("X-Termite-Workload-Type"))
-	if workloadType == "" {
-		switch operation {
-		case "embed", "rerank":
-			workloadType = WorkloadTypeReadHeavy
-		case "chunk":
-			workloadType = WorkloadTypeWriteHeavy
-		default:
-			workloadType = WorkloadTypeGeneral
+	// Retried requests buffer the responses of attempts that may be retried,
+	// and stream the final one
+	if matchedRoute != nil && matchedRoute.RetryAttempts > 0 {
+		result, err := p.forward(r, matchedRoute, operation, req.Model, pool, body, start, true)
+		if err != nil {
+			http.Error(w, err.Error(), statusForError(err))
+			return
 		}
+		writeAttemptResult(w, result)
+		return
 	}
 
 	// Route the request
 	endpoint, err := p.router.RouteRequest(r.Context(), req.Model, pool, workloadType)
 	if err != nil {
 		requestsTotal.WithLabelValues(pool, req.Model, operation, "no_endpoint").Inc()
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		http.Error(w, err.Error(), statusForError(err))
 		return
 	}
 
@@ -821,8 +1069,12 @@ func (p *Proxy) proxyRequest(w http.ResponseWriter, r *http.Request, operation s
 	// Proxy the request
 	targetURL, _ := url.Parse(endpoint.Address)
 	proxy := httputil.NewSingleHostReverseProxy(targetURL)
+	proxy.Transport = p.client.Transport
 
 	// Restore body for proxying
+	if matchedRoute != nil {
+		r.Header = matchedRoute.Headers.Apply(r.Header)
+	}
 	r.Body = io.NopCloser(&bodyReader{data: body})
 	r.ContentLength = int64(len(body))
 
@@ -849,6 +1101,251 @@ func (p *Proxy) proxyRequest(w http.ResponseWriter, r *http.Request, operation s
 	proxy.ServeHTTP(w, r)
 }
 
+// forward sends body to an endpoint of pool and buffers the response,
+// retrying as route configures (route may be nil). Each attempt picks an
+// endpoint afresh, so a retry can land on a healthier one. The route's header
+// transformations are applied to the forwarded request. Finding no
+// endpoint yields a 503 result rather than an error.
+func (p *Proxy) forward(r *http.Request, route *Route, operation, model, pool string, body []byte, start time.Time, stream bool) (*attemptResult, error) {
+	header := r.Header
+	if route != nil {
+		header = route.Headers.Apply(header)
+	}
+	if !stream {
+		// Buffered responses are embedded in batch responses, so ask for
+		// plain JSON that the transport decompresses
+		header = header.Clone()
+		header.Set("Accept", "application/json")
+		header.Del("Accept-Encoding")
+	}
+	attempt := func(ctx context.Context, last bool) (*attemptResult, error) {
+		var streamStatus func(status int) bool
+		if stream {
+			streamStatus = func(status int) bool {
+				return last || !route.RetryOnStatuses[status]
+			}
+		}
+		return p.forwardAttempt(ctx, header, operation, model, pool, workloadTypeFor(r, operation), body, start, streamStatus)
+	}
+	if route == nil || route.RetryAttempts <= 0 {
+		return attempt(r.Context(), true)
+	}
+	result, attempts, err := executeWithRetry(r.Context(), route, p.router.RouteManager().random, attempt)
+	if attempts > 1 {
+		retriesTotal.WithLabelValues(route.Name, operation).Add(float64(attempts - 1))
+	}
+	return result, err
+}
+
+// forwardAttempt makes one attempt at sending body to an endpoint of pool.
+// The response is streamed when stream reports true for its status.
+func (p *Proxy) forwardAttempt(ctx context.Context, header http.Header, operation, model, pool string, workloadType WorkloadType, body []byte, start time.Time, stream func(status int) bool) (*attemptResult, error) {
+	endpoint, err := p.router.RouteRequest(ctx, model, pool, workloadType)
+	if err != nil {
+		requestsTotal.WithLabelValues(pool, model, operation, "no_endpoint").Inc()
+		return &attemptResult{
+			Status: statusForError(err),
+			Header: http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+			Body:   []byte(err.Error()),
+		}, nil
+	}
+
+	atomic.AddInt32(&endpoint.Connections, 1)
+	activeConnections.WithLabelValues(endpoint.Pool, endpoint.Address).Inc()
+	defer func() {
+		atomic.AddInt32(&endpoint.Connections, -1)
+		activeConnections.WithLabelValues(endpoint.Pool, endpoint.Address).Dec()
+	}()
+
+	result, err := postAttempt(ctx, p.client, header, endpoint.Address+"/api/"+operation, body, stream)
+	cb := p.registry.GetCircuitBreaker(endpoint.Address)
+	if err != nil || result.Status >= 400 {
+		if cb != nil {
+			cb.RecordFailure()
+		}
+		requestsTotal.WithLabelValues(endpoint.Pool, model, operation, "error").Inc()
+	} else {
+		if cb != nil {
+			cb.RecordSuccess()
+		}
+		requestsTotal.WithLabelValues(endpoint.Pool, model, operation, "success").Inc()
+	}
+	requestLatency.WithLabelValues(endpoint.Pool, model, operation).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+// postAttempt posts the JSON body to url with the caller's headers,
+// including Accept and Accept-Encoding. The response is left unread in
+// Stream when stream reports true for its status, and buffered otherwise.
+func postAttempt(ctx context.Context, client *http.Client, header http.Header, url string, body []byte, stream func(status int) bool) (*attemptResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header.Clone()
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if stream != nil && stream(resp.StatusCode) {
+		return &attemptResult{Status: resp.StatusCode, Header: resp.Header, Stream: resp.Body}, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &attemptResult{Status: resp.StatusCode, Header: resp.Header, Body: respBody}, nil
+}
+
+// writeAttemptResult writes a forwarded response, flushing streamed bodies
+// as they arrive so that NDJSON responses reach the client incrementally
+func writeAttemptResult(w http.ResponseWriter, result *attemptResult) {
+	for k, v := range result.Header {
+		w.Header()[k] = v
+	}
+	w.Header().Del("Content-Length")
+	w.WriteHeader(result.Status)
+	if result.Stream == nil {
+		_, _ = w.Write(result.Body)
+		return
+	}
+	defer func() { _ = result.Stream.Close() }()
+
+	rc := http.NewResponseController(w)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := result.Stream.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			_ = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// withTrustedSource strips the source identity headers from requests that
+// don't come from a trusted network, so clients can't claim another
+// namespace or service account to match its routes
+func (p *Proxy) withTrustedSource(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !p.trustedSource(r.RemoteAddr) {
+			r.Header.Del(HeaderSourceNamespace)
+			r.Header.Del(HeaderSourceServiceAccount)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// trustedSource reports whether the peer address is in a trusted network
+func (p *Proxy) trustedSource(remoteAddr string) bool {
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range p.trustedSources {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRouteRequest builds the route matching input for an incoming request
+func newRouteRequest(r *http.Request, operation OperationType, model string, now time.Time) *RouteRequest {
+	headers := make(map[string]string)
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+	return &RouteRequest{
+		Operation:            operation,
+		Model:                model,
+		Headers:              headers,
+		SourceTable:          r.Header.Get(HeaderSourceTable),
+		SourceNamespace:      r.Header.Get(HeaderSourceNamespace),
+		SourceServiceAccount: r.Header.Get(HeaderSourceServiceAccount),
+		Timestamp:            now,
+		Context:              r.Context(),
+	}
+}
+
+// routeRejection is a route fallback that rejects the request
+type routeRejection struct {
+	StatusCode int
+	Message    string
+	RetryAfter int
+}
+
+// selectPool picks the pool for a request matched to route, applying the
+// route's fallback when no destination is eligible, which it reports as
+// fallback. It returns "" when the request should use the X-Termite-Pool
+// header or default pool.
+func (p *Proxy) selectPool(route *Route, routeReq *RouteRequest) (pool string, fallback bool, rejected *routeRejection) {
+	dest, err := p.router.RouteManager().SelectDestination(route, routeReq, p.registry)
+	if err == nil && dest != nil {
+		return dest.Pool, false, nil
+	}
+	if route.Fallback == nil {
+		return "", true, nil
+	}
+	switch route.Fallback.Action {
+	case "reject":
+		rejected := &routeRejection{
+			StatusCode: route.Fallback.StatusCode,
+			Message:    route.Fallback.Message,
+			RetryAfter: route.Fallback.RetryAfter,
+		}
+		if rejected.StatusCode == 0 {
+			rejected.StatusCode = 503
+		}
+		if rejected.Message == "" {
+			rejected.Message = ErrNoHealthyEndpoints.Error()
+		}
+		return "", false, rejected
+	case "redirect":
+		return route.Fallback.RedirectPool, true, nil
+	}
+	return "", true, nil
+}
+
+// fallbackPool picks the pool for a request that its route, if any, left to
+// default routing: the X-Termite-Pool header, then a pool serving model
+// chosen by TermitePool routing weight, then the default pool. It returns ""
+// to balance across every endpoint serving model.
+func (p *Proxy) fallbackPool(r *http.Request, model string) string {
+	if pool := r.Header.Get("X-Termite-Pool"); pool != "" {
+		return pool
+	}
+	if pool := p.router.RouteManager().SelectDefaultPool(model, p.registry); pool != "" {
+		return pool
+	}
+	return p.defaultPool
+}
+
+// workloadTypeFor reads the workload type from the X-Termite-Workload-Type
+// header, inferring it from the operation when unset
+func workloadTypeFor(r *http.Request, operation string) WorkloadType {
+	if workloadType := WorkloadType(r.Header.Get("X-Termite-Workload-Type")); workloadType != "" {
+		return workloadType
+	}
+	switch operation {
+	case "embed", "rerank":
+		return WorkloadTypeReadHeavy
+	case "chunk":
+		return WorkloadTypeWriteHeavy
+	default:
+		return WorkloadTypeGeneral
+	}
+}
+
 type bodyReader struct {
 	data []byte
 	pos  int
@@ -873,7 +1370,7 @@ func (p *Proxy) handleReady(w http.ResponseWriter, r *http.Request) {
 	p.registry.mu.RLock()
 	hasHealthy := false
 	for _, ep := range p.registry.endpoints {
-		if ep.Healthy {
+		if ep.Healthy && !ep.Draining {
 			hasHealthy = true
 			break
 		}
@@ -889,6 +1386,42 @@ func (p *Proxy) handleReady(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleRateLimits reports the token buckets of rate-limited routes
+func (p *Proxy) handleRateLimits(w http.ResponseWriter, r *http.Request) {
+	if !p.checkAdminToken(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(p.router.RouteManager().RateLimits())
+}
+
+// checkAdminToken reports whether the request carries the configured admin bearer token
+func (p *Proxy) checkAdminToken(r *http.Request) bool {
+	if p.adminToken == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(p.adminToken)) == 1
+}
+
+// observeRateLimits replaces the rate limit gauges with limits, dropping
+// routes that were removed
+func observeRateLimits(limits map[string]RateLimiterState) {
+	rateLimitTokens.Reset()
+	for route, state := range limits {
+		if state.Tokens != nil {
+			rateLimitTokens.WithLabelValues(route, "").Set(*state.Tokens)
+		}
+		for model, tokens := range state.Models {
+			rateLimitTokens.WithLabelValues(route, model).Set(tokens)
+		}
+	}
+}
+
 func (p *Proxy) refreshLoop(ctx context.Context) {
 	ticker := time.NewTicker(p.registry.refreshInterval)
 	defer ticker.Stop()
@@ -920,6 +1453,8 @@ func (p *Proxy) refreshLoop(ctx context.Context) {
 				queueDepth.WithLabelValues(pool).Set(float64(totalQueue))
 			}
 			p.registry.mu.RUnlock()
+
+			observeRateLimits(p.router.RouteManager().RateLimits())
 		}
 	}
 }
@@ -939,7 +1474,29 @@ func (p *Proxy) RegisterEndpoint(address, pool string, workloadType WorkloadType
 	p.registry.RegisterEndpoint(address, pool, workloadType)
 }
 
+// SetEndpointNamespace records the namespace of an endpoint's pool (called
+// from K8s watcher)
+func (p *Proxy) SetEndpointNamespace(address, namespace string) {
+	p.registry.SetEndpointNamespace(address, namespace)
+}
+
+// SetEndpointZones records the zones an endpoint serves (called from K8s watcher)
+func (p *Proxy) SetEndpointZones(address string, zones []string) {
+	p.registry.SetEndpointZones(address, zones)
+}
+
+// DrainEndpoint stops routing new requests to an endpoint (called from K8s watcher)
+func (p *Proxy) DrainEndpoint(address string) {
+	p.registry.DrainEndpoint(address)
+}
+
 // UnregisterEndpoint removes an endpoint (called from K8s watcher)
 func (p *Proxy) UnregisterEndpoint(address string) {
 	p.registry.UnregisterEndpoint(address)
 }
+
+// UnregisterPool removes all endpoints of a pool in a namespace (called from
+// K8s watcher)
+func (p *Proxy) UnregisterPool(namespace, pool string) {
+	p.registry.UnregisterPool(namespace, pool)
+}
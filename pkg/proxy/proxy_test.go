@@ -0,0 +1,416 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// routingSamples returns how many requests routingLatency observed with the
+// given labels
+func routingSamples(t *testing.T, route, pool, outcome string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := routingLatency.WithLabelValues(route, pool, outcome).(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("reading routing latency: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestProxyRequest_RoutingMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	p.RegisterEndpoint(srv.URL, "gpu", "")
+	bge, err := CompileModelPattern("bge-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gte, err := CompileModelPattern("gte-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Router().RouteManager().AddRoute(&Route{
+		Name:          "metrics/bge",
+		ModelPatterns: []*regexp.Regexp{bge},
+		Destinations:  []Destination{{Pool: "gpu", Weight: 100}},
+	})
+	p.Router().RouteManager().AddRoute(&Route{
+		Name:          "metrics/gte",
+		ModelPatterns: []*regexp.Regexp{gte},
+		Destinations:  []Destination{{Pool: "empty", Weight: 100}},
+		Fallback:      &Fallback{Action: "reject", StatusCode: http.StatusServiceUnavailable},
+	})
+
+	embed := func(model string) int {
+		rec := httptest.NewRecorder()
+		p.handleEmbed(rec, httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"`+model+`"}`)))
+		return rec.Code
+	}
+
+	matched := routingSamples(t, "metrics/bge", "gpu", routeOutcomeMatched)
+	if code := embed("bge-small"); code != http.StatusOK {
+		t.Fatalf("bge-small status = %d", code)
+	}
+	if got := routingSamples(t, "metrics/bge", "gpu", routeOutcomeMatched); got != matched+1 {
+		t.Errorf("matched samples = %d, want %d", got, matched+1)
+	}
+
+	rejected := routingSamples(t, "metrics/gte", "", routeOutcomeRejected)
+	if code := embed("gte-base"); code != http.StatusServiceUnavailable {
+		t.Fatalf("gte-base status = %d", code)
+	}
+	if got := routingSamples(t, "metrics/gte", "", routeOutcomeRejected); got != rejected+1 {
+		t.Errorf("rejected samples = %d, want %d", got, rejected+1)
+	}
+
+	unmatched := testutil.ToFloat64(unmatchedRequests.WithLabelValues("embed"))
+	embed("mxbai-embed")
+	if got := testutil.ToFloat64(unmatchedRequests.WithLabelValues("embed")); got != unmatched+1 {
+		t.Errorf("unmatched requests = %g, want %g", got, unmatched+1)
+	}
+}
+
+func TestProxyRequest_DefaultRoutingPoolWeights(t *testing.T) {
+	hits := make(map[string]*atomic.Int32)
+	// The termite-proxy --default-pool flag defaults to "default"; pool
+	// weights take precedence over it
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	for _, pool := range []string{"large", "small", "drained"} {
+		hits[pool] = &atomic.Int32{}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[pool].Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer srv.Close()
+		p.RegisterEndpoint(srv.URL, pool, "")
+		p.Registry().UpdateModels(srv.URL, []string{"bge-small"})
+	}
+
+	// Pool weights come from the TermitePool spec.routing.weight
+	w := &RouteWatcher{routeManager: p.Router().RouteManager(), logger: zap.NewNop()}
+	for pool, weight := range map[string]int64{"large": 80, "small": 20, "drained": 0} {
+		w.onPoolAdd(&unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"namespace": "default", "name": pool},
+			"spec":     map[string]any{"routing": map[string]any{"weight": weight}},
+		}})
+	}
+
+	const requests = 500
+	for range requests {
+		rec := httptest.NewRecorder()
+		p.handleEmbed(rec, httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small"}`)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	}
+
+	if n := hits["drained"].Load(); n != 0 {
+		t.Errorf("zero-weight pool served %d requests", n)
+	}
+	if share := float64(hits["large"].Load()) / requests; share < 0.7 || share > 0.9 {
+		t.Errorf("large pool served %.0f%% of requests, want about 80%%", share*100)
+	}
+	if hits["large"].Load()+hits["small"].Load() != requests {
+		t.Errorf("hits = large:%d small:%d, want %d in total", hits["large"].Load(), hits["small"].Load(), requests)
+	}
+}
+
+func TestProxyRequest_HeaderTransform(t *testing.T) {
+	var upstream http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// The reverse proxy path and the buffered (retried) path both apply the
+	// transformation
+	for _, retry := range []string{"", "\n  retry:\n    attempts: 1"} {
+		routes, err := LoadRoutes(strings.NewReader(`
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: headers
+spec:
+  route:
+    - pool: default
+  headers:
+    set:
+      - name: x-tenant
+        value: search
+    add:
+      - name: X-Model-Variant
+        value: int8
+    remove: ["authorization", "bad header"]`+retry+`
+`), zap.NewNop())
+		if err != nil {
+			t.Fatalf("LoadRoutes: %v", err)
+		}
+		route := routes[0]
+		if len(route.Warnings) != 1 || !strings.Contains(route.Warnings[0], `"bad header"`) {
+			t.Errorf("warnings = %v, want the invalid header name dropped", route.Warnings)
+		}
+
+		p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+		p.RegisterEndpoint(srv.URL, "default", "")
+		p.Router().RouteManager().AddRoute(route)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small"}`))
+		req.Header.Set("Authorization", "Bearer client-token")
+		req.Header.Set("X-Tenant", "client")
+		req.Header.Set("X-Model-Variant", "fp32")
+		rec := httptest.NewRecorder()
+		p.handleEmbed(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+
+		if v, ok := upstream["Authorization"]; ok {
+			t.Errorf("removed Authorization header reached the upstream: %q", v)
+		}
+		if got := upstream.Get("X-Tenant"); got != "search" {
+			t.Errorf("X-Tenant = %q, want search", got)
+		}
+		if got := upstream.Values("X-Model-Variant"); len(got) != 2 || got[0] != "fp32" || got[1] != "int8" {
+			t.Errorf("X-Model-Variant = %q, want [fp32 int8]", got)
+		}
+	}
+}
+
+func TestProxy_TrustedSourceHeaders(t *testing.T) {
+	var tenantNamespace atomic.Value
+	tenant := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantNamespace.Store(r.Header.Get(HeaderSourceNamespace))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer tenant.Close()
+	var defaultNamespace atomic.Value
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultNamespace.Store(r.Header.Get(HeaderSourceNamespace))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer other.Close()
+
+	routes, err := LoadRoutes(strings.NewReader(`
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: search-tenant
+spec:
+  match:
+    source:
+      namespaces: [search]
+  route:
+    - pool: tenant
+`), zap.NewNop())
+	if err != nil {
+		t.Fatalf("LoadRoutes: %v", err)
+	}
+	p := NewProxy(Config{
+		DefaultPool:           "default",
+		Logger:                zap.NewNop(),
+		TrustedSourceNetworks: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	})
+	p.RegisterEndpoint(tenant.URL, "tenant", "")
+	p.RegisterEndpoint(other.URL, "default", "")
+	p.Router().RouteManager().AddRoute(routes[0])
+	handler := p.withTrustedSource(http.HandlerFunc(p.handleEmbed))
+
+	send := func(remoteAddr string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small"}`))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(HeaderSourceNamespace, "search")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	}
+
+	// A client outside the trusted networks can't claim the namespace
+	send("192.0.2.1:4321")
+	if got, ok := defaultNamespace.Load().(string); !ok || got != "" {
+		t.Errorf("untrusted request reached default with namespace %q (ok=%t), want stripped", got, ok)
+	}
+	if tenantNamespace.Load() != nil {
+		t.Error("untrusted request matched the namespace route")
+	}
+
+	send("10.1.2.3:4321")
+	if got, _ := tenantNamespace.Load().(string); got != "search" {
+		t.Errorf("trusted request: tenant saw namespace %q, want search", got)
+	}
+}
+
+func TestProxy_RateLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	p := NewProxy(Config{DefaultPool: "default", AdminToken: "secret", Logger: zap.NewNop()})
+	p.RegisterEndpoint(srv.URL, "default", "")
+	p.Router().RouteManager().AddRoute(&Route{
+		Name:         "limits/per-model",
+		Destinations: []Destination{{Pool: "default", Weight: 100}},
+		RateLimiter:  NewRateLimiterWithRate(0.001, 5, true),
+	})
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		p.handleEmbed(rec, httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small"}`)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	}
+
+	// Limiter state is only served to callers with the admin token
+	for _, auth := range []string{"", "Bearer wrong"} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/ratelimits", nil)
+		req.Header.Set("Authorization", auth)
+		rec := httptest.NewRecorder()
+		p.handleRateLimits(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: status = %d, want 401", auth, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/ratelimits", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	p.handleRateLimits(rec, req)
+	var limits map[string]RateLimiterState
+	if err := json.NewDecoder(rec.Body).Decode(&limits); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	state, ok := limits["limits/per-model"]
+	if !ok || !state.PerModel || state.BurstSize != 5 {
+		t.Fatalf("rate limits = %+v, want the per-model route", limits)
+	}
+	// Two tokens taken; the slow refill adds a negligible fraction
+	if tokens := state.Models["bge-small"]; tokens < 3 || tokens > 3.01 {
+		t.Errorf("bge-small tokens = %g, want 3", tokens)
+	}
+
+	observeRateLimits(limits)
+	if got := testutil.ToFloat64(rateLimitTokens.WithLabelValues("limits/per-model", "bge-small")); got != state.Models["bge-small"] {
+		t.Errorf("rate limit gauge = %g, want %g", got, state.Models["bge-small"])
+	}
+}
+
+// slowMetricSource counts queries, answering each after a delay
+type slowMetricSource struct {
+	delay   time.Duration
+	queries atomic.Int32
+}
+
+func (s *slowMetricSource) Query(ctx context.Context, query string) (float64, error) {
+	s.queries.Add(1)
+	time.Sleep(s.delay)
+	return 0, nil
+}
+
+func TestProxyRequest_FailFast(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	for _, failFast := range []bool{true, false} {
+		// The primary gpu destination is not the first in spec order
+		routes, err := LoadRoutes(strings.NewReader(fmt.Sprintf(`
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: latency-sensitive
+spec:
+  route:
+    - pool: cpu
+      weight: 0
+      condition:
+        metric:
+          query: queue_depth{pool="$pool"}
+          threshold: "<80"
+    - pool: gpu
+      weight: 100
+  fallback:
+    action: reject
+    failFast: %t
+    errorResponse:
+      statusCode: 429
+      message: gpu pool unavailable
+`, failFast)), zap.NewNop())
+		if err != nil {
+			t.Fatalf("LoadRoutes: %v", err)
+		}
+		if routes[0].Fallback.FailFast != failFast {
+			t.Fatalf("FailFast = %t, want %t", routes[0].Fallback.FailFast, failFast)
+		}
+
+		// The gpu pool has no endpoints; the cpu pool's condition is slow
+		metrics := &slowMetricSource{delay: 200 * time.Millisecond}
+		p := NewProxy(Config{Logger: zap.NewNop()})
+		p.RegisterEndpoint(srv.URL, "cpu", "")
+		p.Router().RouteManager().SetMetricSource(metrics)
+		p.Router().RouteManager().AddRoute(routes[0])
+
+		start := time.Now()
+		rec := httptest.NewRecorder()
+		p.handleEmbed(rec, httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small"}`)))
+		elapsed := time.Since(start)
+
+		if failFast {
+			if rec.Code != http.StatusTooManyRequests || !strings.Contains(rec.Body.String(), "gpu pool unavailable") {
+				t.Errorf("fail fast: response = %d %q, want 429 gpu pool unavailable", rec.Code, rec.Body.String())
+			}
+			if n := metrics.queries.Load(); n != 0 || elapsed >= metrics.delay {
+				t.Errorf("fail fast: %d metric queries, took %s; want an immediate rejection", n, elapsed)
+			}
+		} else {
+			if rec.Code != http.StatusOK {
+				t.Errorf("spill-over: status = %d, want 200 from the cpu pool", rec.Code)
+			}
+			if n := metrics.queries.Load(); n == 0 {
+				t.Error("spill-over: cpu destination condition not evaluated")
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// regexCacheSize bounds the number of compiled expressions kept in memory
+const regexCacheSize = 1024
+
+// regexCache is a process-wide LRU of compiled expressions, so routes that
+// use the same pattern share one *regexp.Regexp and CR updates don't
+// recompile unchanged patterns. Compiled regexps are safe for concurrent use.
+var regexCache = newRegexLRU(regexCacheSize)
+
+type regexLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+}
+
+type regexEntry struct {
+	expr  string
+	regex *regexp.Regexp
+}
+
+func newRegexLRU(capacity int) *regexLRU {
+	return &regexLRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// compile returns the cached regex for expr, compiling and caching it on a
+// miss. Compile errors are not cached.
+func (c *regexLRU) compile(expr string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*regexEntry).regex, nil
+	}
+	c.mu.Unlock()
+
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another caller may have compiled the same expression meanwhile
+	if elem, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*regexEntry).regex, nil
+	}
+	c.entries[expr] = c.order.PushFront(&regexEntry{expr: expr, regex: regex})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexEntry).expr)
+	}
+	return regex, nil
+}
+
+// CompileRegex compiles expr through the shared regex cache
+func CompileRegex(expr string) (*regexp.Regexp, error) {
+	return regexCache.compile(expr)
+}
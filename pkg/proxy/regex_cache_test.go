@@ -0,0 +1,92 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newRouteObject(name, model, headerRegex string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"namespace": "default", "name": name},
+		"spec": map[string]any{
+			"match": map[string]any{
+				"models": []any{model},
+				"headers": map[string]any{
+					"x-tenant": map[string]any{"regex": headerRegex},
+				},
+			},
+		},
+	}}
+}
+
+func TestConvertRoute_SharesCompiledRegex(t *testing.T) {
+	w := &RouteWatcher{logger: zap.NewNop()}
+
+	a, err := w.convertRoute(newRouteObject("a", "bge-*", "^team-[0-9]+$"))
+	if err != nil {
+		t.Fatalf("convertRoute(a): %v", err)
+	}
+	b, err := w.convertRoute(newRouteObject("b", "bge-*", "^team-[0-9]+$"))
+	if err != nil {
+		t.Fatalf("convertRoute(b): %v", err)
+	}
+	if a.ModelPatterns[0] != b.ModelPatterns[0] {
+		t.Error("routes with the same model pattern hold different regex instances")
+	}
+	if a.HeaderMatchers["x-tenant"].Regex != b.HeaderMatchers["x-tenant"].Regex {
+		t.Error("routes with the same header regex hold different regex instances")
+	}
+
+	// An update with unchanged pattern text reuses the compiled regex
+	updated, err := w.convertRoute(newRouteObject("a", "bge-*", "^team-[0-9]+$"))
+	if err != nil {
+		t.Fatalf("convertRoute(updated): %v", err)
+	}
+	if updated.ModelPatterns[0] != a.ModelPatterns[0] {
+		t.Error("update recompiled an unchanged model pattern")
+	}
+}
+
+func TestRegexLRU_Eviction(t *testing.T) {
+	c := newRegexLRU(2)
+	first, _ := c.compile("a")
+	if _, err := c.compile("b"); err != nil {
+		t.Fatal(err)
+	}
+	// Touch "a" so "b" is the least recently used
+	if again, _ := c.compile("a"); again != first {
+		t.Fatal("cache hit returned a different instance")
+	}
+	if _, err := c.compile("c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.entries["b"]; ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	if again, _ := c.compile("a"); again != first {
+		t.Error("recently used entry was evicted")
+	}
+
+	if _, err := c.compile("("); err == nil {
+		t.Error("expected compile error for invalid expression")
+	}
+	if len(c.entries) != 2 {
+		t.Errorf("cache holds %d entries, want 2", len(c.entries))
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// Retries back off exponentially from retryBaseInterval up to
+// retryMaxInterval between attempts
+const (
+	retryBaseInterval = 25 * time.Millisecond
+	retryMaxInterval  = 250 * time.Millisecond
+
+	// defaultRetryBudget bounds the total time of a retried request whose
+	// route sets no perTryTimeout
+	defaultRetryBudget = 30 * time.Second
+)
+
+// attemptResult is the response to one attempt of a request. The body is
+// buffered in Body unless the attempt is final, when it may be left unread
+// in Stream instead.
+type attemptResult struct {
+	Status int
+	Header http.Header
+	Body   []byte
+	Stream io.ReadCloser
+}
+
+// attemptFunc performs one attempt of a request, bounded by ctx; last is set
+// when no retry can follow it
+type attemptFunc func(ctx context.Context, last bool) (*attemptResult, error)
+
+// executeWithRetry runs attempt, retrying the failures covered by the
+// route's retryOn conditions with jittered exponential backoff. Each attempt
+// is bounded by the route's perTryTimeout and all of them by its retry
+// budget; cancelling ctx aborts at once. It returns the outcome of the last
+// attempt and the number of attempts made. An attempt that returns a Stream
+// is final; the caller must close the stream.
+func executeWithRetry(ctx context.Context, route *Route, random RandomSource, attempt attemptFunc) (*attemptResult, int, error) {
+	budgetCtx, cancel := context.WithTimeout(ctx, retryBudget(route))
+	streaming := false
+	defer func() {
+		if !streaming {
+			cancel()
+		}
+	}()
+
+	for n := 1; ; n++ {
+		attemptCtx, cancelAttempt := budgetCtx, context.CancelFunc(func() {})
+		if route.RetryTimeout > 0 {
+			attemptCtx, cancelAttempt = context.WithTimeout(budgetCtx, route.RetryTimeout)
+		}
+		result, err := attempt(attemptCtx, n > int(route.RetryAttempts))
+		if err == nil && result.Stream != nil {
+			// The caller reads the stream within the attempt's deadlines,
+			// which closing it releases
+			streaming = true
+			result.Stream = &cancelOnClose{ReadCloser: result.Stream, cancel: func() {
+				cancelAttempt()
+				cancel()
+			}}
+			return result, n, nil
+		}
+		timedOut := err != nil && budgetCtx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded)
+		cancelAttempt()
+
+		if ctx.Err() != nil {
+			return nil, n, ctx.Err()
+		}
+		if budgetCtx.Err() != nil || n > int(route.RetryAttempts) || !route.retriable(result, err, timedOut) {
+			return result, n, err
+		}
+
+		delay := retryBackoff(n, retryBaseInterval, retryMaxInterval, random)
+		if deadline, ok := budgetCtx.Deadline(); ok && time.Until(deadline) < delay {
+			return result, n, err // The budget has no room for another attempt
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, n, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// cancelOnClose cancels the contexts of a streamed response when closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// retryBudget bounds the total time of a request on route, across all
+// attempts and the backoff between them
+func retryBudget(route *Route) time.Duration {
+	if route.RetryTimeout <= 0 {
+		return defaultRetryBudget
+	}
+	return time.Duration(route.RetryAttempts+1)*route.RetryTimeout + time.Duration(route.RetryAttempts)*retryMaxInterval
+}
+
+// retryBackoff returns the delay before retry n (1 for the first retry). It
+// doubles from base with each retry up to maxDelay, and its upper half is
+// jittered so that clients failing together do not retry in lockstep.
+func retryBackoff(n int, base, maxDelay time.Duration, random RandomSource) time.Duration {
+	d := maxDelay
+	if shift := n - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		d = base << shift
+	}
+	half := d / 2
+	jitter := int32(min(half/time.Microsecond, math.MaxInt32-1))
+	return d - half + time.Duration(random.Int32N(jitter+1))*time.Microsecond
+}
+
+// retriable reports whether the route retries an attempt that ended with
+// result or err; timedOut is set when the attempt hit its perTryTimeout
+func (r *Route) retriable(result *attemptResult, err error, timedOut bool) bool {
+	switch {
+	case timedOut:
+		return r.RetryOnTimeout
+	case err == nil:
+		return r.RetryOnStatuses[result.Status]
+	case isConnectFailure(err):
+		return r.RetryOnConnectFailure
+	case isConnectionReset(err):
+		return r.RetryOnReset
+	}
+	return false
+}
+
+// isConnectFailure reports whether err means the endpoint could not be
+// reached, so the request was never sent
+func isConnectFailure(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, syscall.ECONNREFUSED) || (errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+// isConnectionReset reports whether err means the connection was dropped
+// while the request was in flight
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// statusAttempts returns an attempt func answering with the given statuses in
+// turn, repeating the last one, and counting calls
+func statusAttempts(calls *int, statuses ...int) attemptFunc {
+	return func(ctx context.Context, _ bool) (*attemptResult, error) {
+		status := statuses[min(*calls, len(statuses)-1)]
+		*calls++
+		return &attemptResult{Status: status}, nil
+	}
+}
+
+func TestExecuteWithRetry_Attempts(t *testing.T) {
+	route := &Route{RetryAttempts: 2, RetryOnStatuses: map[int]bool{503: true}}
+
+	var calls int
+	result, attempts, err := executeWithRetry(context.Background(), route, globalRandom{}, statusAttempts(&calls, 503))
+	if err != nil || result.Status != 503 {
+		t.Fatalf("result = %+v, %v; want the last 503", result, err)
+	}
+	if attempts != 3 || calls != 3 {
+		t.Errorf("made %d attempts (%d calls), want the first attempt plus 2 retries", attempts, calls)
+	}
+
+	calls = 0
+	result, attempts, err = executeWithRetry(context.Background(), route, globalRandom{}, statusAttempts(&calls, 503, 200))
+	if err != nil || result.Status != 200 || attempts != 2 {
+		t.Errorf("result = %+v after %d attempts, %v; want 200 after 2", result, attempts, err)
+	}
+}
+
+func TestExecuteWithRetry_NonRetriableStopsImmediately(t *testing.T) {
+	route := &Route{RetryAttempts: 3, RetryOnStatuses: map[int]bool{503: true}, RetryOnConnectFailure: true}
+
+	var calls int
+	result, attempts, _ := executeWithRetry(context.Background(), route, globalRandom{}, statusAttempts(&calls, 400))
+	if result.Status != 400 || attempts != 1 || calls != 1 {
+		t.Errorf("400: %d attempts, want 1", attempts)
+	}
+
+	boom := errors.New("boom")
+	calls = 0
+	_, attempts, err := executeWithRetry(context.Background(), route, globalRandom{}, func(ctx context.Context, _ bool) (*attemptResult, error) {
+		calls++
+		return nil, boom
+	})
+	if !errors.Is(err, boom) || attempts != 1 || calls != 1 {
+		t.Errorf("unclassified error: %d attempts, %v; want 1, boom", attempts, err)
+	}
+}
+
+func TestExecuteWithRetry_BackoffGrows(t *testing.T) {
+	route := &Route{RetryAttempts: 4, RetryOnStatuses: map[int]bool{503: true}}
+
+	var times []time.Time
+	_, attempts, _ := executeWithRetry(context.Background(), route, &sequenceSource{picks: []int32{0}}, func(ctx context.Context, _ bool) (*attemptResult, error) {
+		times = append(times, time.Now())
+		return &attemptResult{Status: 503}, nil
+	})
+	if attempts != 5 {
+		t.Fatalf("made %d attempts, want 5", attempts)
+	}
+	for i := 1; i < len(times); i++ {
+		gap := times[i].Sub(times[i-1])
+		if floor := retryBackoff(i, retryBaseInterval, retryMaxInterval, &sequenceSource{picks: []int32{0}}); gap < floor {
+			t.Errorf("gap before retry %d = %s, want at least %s", i, gap, floor)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	base, maxDelay := 10*time.Millisecond, 100*time.Millisecond
+
+	// Without jitter the delay is half the exponential step, growing until capped
+	low := &sequenceSource{picks: []int32{0}}
+	want := []time.Duration{5, 10, 20, 40, 50, 50}
+	for i, w := range want {
+		if got := retryBackoff(i+1, base, maxDelay, low); got != w*time.Millisecond {
+			t.Errorf("retryBackoff(%d) = %s, want %s", i+1, got, w*time.Millisecond)
+		}
+	}
+
+	// Jitter stays within the step and never exceeds the cap
+	random := NewSeededRandomSource(1)
+	for n := 1; n <= 40; n++ {
+		step := min(base<<min(n-1, 20), maxDelay)
+		if got := retryBackoff(n, base, maxDelay, random); got < step/2 || got > step {
+			t.Errorf("retryBackoff(%d) = %s, want within [%s, %s]", n, got, step/2, step)
+		}
+	}
+}
+
+func TestExecuteWithRetry_PerTryTimeout(t *testing.T) {
+	hang := func(calls *int) attemptFunc {
+		return func(ctx context.Context, _ bool) (*attemptResult, error) {
+			*calls++
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}
+
+	route := &Route{RetryAttempts: 2, RetryTimeout: 20 * time.Millisecond, RetryOnTimeout: true}
+	var calls int
+	start := time.Now()
+	_, attempts, err := executeWithRetry(context.Background(), route, globalRandom{}, hang(&calls))
+	if !errors.Is(err, context.DeadlineExceeded) || attempts != 3 {
+		t.Errorf("%d attempts, %v; want 3 timed out attempts", attempts, err)
+	}
+	if elapsed := time.Since(start); elapsed > retryBudget(route) {
+		t.Errorf("took %s, beyond the retry budget %s", elapsed, retryBudget(route))
+	}
+
+	route.RetryOnTimeout = false
+	calls = 0
+	if _, attempts, _ := executeWithRetry(context.Background(), route, globalRandom{}, hang(&calls)); attempts != 1 {
+		t.Errorf("timeouts retried %d times without deadline-exceeded", attempts-1)
+	}
+}
+
+func TestExecuteWithRetry_CancelAborts(t *testing.T) {
+	route := &Route{RetryAttempts: 100, RetryOnStatuses: map[int]bool{503: true}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+	_, attempts, err := executeWithRetry(ctx, route, globalRandom{}, func(ctx context.Context, _ bool) (*attemptResult, error) {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return &attemptResult{Status: 503}, nil
+	})
+	if !errors.Is(err, context.Canceled) || attempts != 2 {
+		t.Errorf("%d attempts, %v; want to stop after the cancelled second attempt", attempts, err)
+	}
+}
+
+func TestProxyRequest_RetriesRouteFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "warming up", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"embeddings":[[1]]}`))
+	}))
+	defer srv.Close()
+
+	routes, err := LoadRoutes(strings.NewReader(`
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: retried
+spec:
+  route:
+    - pool: default
+  retry:
+    attempts: 3
+    perTryTimeout: 5s
+    retryOn: ["5xx", "connect-failure"]
+`), zap.NewNop())
+	if err != nil {
+		t.Fatalf("LoadRoutes: %v", err)
+	}
+	route := routes[0]
+	if route.RetryAttempts != 3 || route.RetryTimeout != 5*time.Second || !route.RetryOnStatuses[503] || !route.RetryOnConnectFailure || route.RetryOnReset {
+		t.Fatalf("retry config = %d attempts, %s per try, 503 %t, connect-failure %t, reset %t",
+			route.RetryAttempts, route.RetryTimeout, route.RetryOnStatuses[503], route.RetryOnConnectFailure, route.RetryOnReset)
+	}
+
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	p.RegisterEndpoint(srv.URL, "default", "")
+	p.Router().RouteManager().AddRoute(route)
+
+	rec := httptest.NewRecorder()
+	p.handleEmbed(rec, httptest.NewRequest(http.MethodPost, "/api/embed", strings.NewReader(`{"model":"bge-small","input":["a"]}`)))
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"embeddings":[[1]]}` {
+		t.Errorf("response = %d %q, want the third attempt's 200", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+	}
+	if calls.Load() != 3 {
+		t.Errorf("endpoint called %d times, want 3", calls.Load())
+	}
+}
+
+func TestProxyRequest_RetriedRouteStreamsFinalResponse(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "warming up", http.StatusServiceUnavailable)
+			return
+		}
+		switch r.Header.Get("Accept") {
+		case "application/x-ndjson":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte(`{"index":0}` + "\n"))
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			_, _ = w.Write([]byte(`{"index":1}` + "\n"))
+		case "application/json":
+			if r.Header.Get("Accept-Encoding") != "gzip" {
+				t.Errorf("Accept-Encoding = %q, want the client's gzip", r.Header.Get("Accept-Encoding"))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			_, _ = gz.Write([]byte(`{"embeddings":[[1]]}`))
+			_ = gz.Close()
+		default:
+			t.Errorf("Accept = %q, want the client's", r.Header.Get("Accept"))
+		}
+	}))
+	defer srv.Close()
+
+	routes, err := LoadRoutes(strings.NewReader(`
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: retried
+spec:
+  route:
+    - pool: default
+  retry:
+    attempts: 2
+    retryOn: ["5xx"]
+`), zap.NewNop())
+	if err != nil {
+		t.Fatalf("LoadRoutes: %v", err)
+	}
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	p.RegisterEndpoint(srv.URL, "default", "")
+	p.Router().RouteManager().AddRoute(routes[0])
+	proxySrv := httptest.NewServer(http.HandlerFunc(p.handleEmbed))
+	defer proxySrv.Close()
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	post := func(accept, acceptEncoding string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, proxySrv.URL, strings.NewReader(`{"model":"bge-small","input":["a","b"]}`))
+		req.Header.Set("Accept", accept)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("POST: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d", resp.StatusCode)
+		}
+		return resp
+	}
+
+	// NDJSON lines reach the client as the endpoint writes them
+	resp := post("application/x-ndjson", "")
+	lines := bufio.NewReader(resp.Body)
+	if line, err := lines.ReadString('\n'); err != nil || line != `{"index":0}`+"\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+	close(release)
+	if line, err := lines.ReadString('\n'); err != nil || line != `{"index":1}`+"\n" {
+		t.Errorf("second line = %q, %v", line, err)
+	}
+	_ = resp.Body.Close()
+
+	// A gzip response passes through encoded
+	calls.Store(0)
+	resp = post("application/json", "gzip")
+	defer func() { _ = resp.Body.Close() }()
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", resp.Header.Get("Content-Encoding"))
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	if body, err := io.ReadAll(gz); err != nil || string(body) != `{"embeddings":[[1]]}` {
+		t.Errorf("body = %q, %v", body, err)
+	}
+}
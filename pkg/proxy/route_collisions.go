@@ -0,0 +1,95 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/antflydb/termite/pkg/operator/api/routematch"
+)
+
+// PriorityCollision reports two routes with the same priority that may match
+// the same requests. Such ties are broken by name, so First always takes the
+// requests both match, which may not be the intended order.
+type PriorityCollision struct {
+	Priority int32
+	First    string // Evaluated first
+	Second   string // Shadowed by First where both match
+}
+
+func (c PriorityCollision) String() string {
+	return fmt.Sprintf("routes %s and %s share priority %d and may match the same requests; %s is evaluated first",
+		c.First, c.Second, c.Priority, c.First)
+}
+
+// PriorityCollisions lists the pairs of routes that share a priority and
+// whose match criteria may overlap, in evaluation order. Overlap is judged
+// conservatively: criteria that cannot be compared statically, such as
+// regular expressions and time windows, are assumed to overlap.
+func (rm *RouteManager) PriorityCollisions() []PriorityCollision {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	var collisions []PriorityCollision
+	for i, a := range rm.routes {
+		for _, b := range rm.routes[i+1:] {
+			// Catch-all routes are ordered after all others, whatever their priority
+			if a.Priority != b.Priority || a.IsCatchAll() != b.IsCatchAll() || !routesOverlap(a, b) {
+				continue
+			}
+			collisions = append(collisions, PriorityCollision{Priority: a.Priority, First: a.Name, Second: b.Name})
+		}
+	}
+	return collisions
+}
+
+// PriorityCollisionsFor lists the priority collisions involving the named route
+func (rm *RouteManager) PriorityCollisionsFor(name string) []PriorityCollision {
+	var collisions []PriorityCollision
+	for _, c := range rm.PriorityCollisions() {
+		if c.First == name || c.Second == name {
+			collisions = append(collisions, c)
+		}
+	}
+	return collisions
+}
+
+// routesOverlap reports whether some request may match both routes
+func routesOverlap(a, b *Route) bool {
+	ca, cb := a.criteria(), b.criteria()
+	return routematch.Overlap(&ca, &cb)
+}
+
+// criteria returns the match conditions of the route compared for overlap
+func (r *Route) criteria() routematch.Criteria {
+	c := routematch.Criteria{
+		SourceTables:          slices.Collect(maps.Keys(r.SourceTables)),
+		SourceNamespaces:      slices.Collect(maps.Keys(r.SourceNamespaces)),
+		SourceServiceAccounts: slices.Collect(maps.Keys(r.SourceServiceAccounts)),
+		Models:                r.ModelPatterns,
+	}
+	for op := range r.Operations {
+		c.Operations = append(c.Operations, string(op))
+	}
+	if len(r.HeaderMatchers) > 0 {
+		c.Headers = make(map[string]routematch.StringMatch, len(r.HeaderMatchers))
+		for header, m := range r.HeaderMatchers {
+			c.Headers[header] = routematch.StringMatch{Exact: m.Exact, Prefix: m.Prefix, Regex: m.Regex != nil}
+		}
+	}
+	return c
+}
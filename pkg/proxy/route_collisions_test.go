@@ -0,0 +1,111 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPriorityCollisions(t *testing.T) {
+	embed := map[OperationType]bool{"embed": true}
+	rerank := map[OperationType]bool{"rerank": true}
+
+	tests := []struct {
+		name string
+		a, b *Route
+		want bool
+	}{
+		{
+			"overlapping models at same priority",
+			&Route{Name: "default/b", Priority: 10, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "bge-*")}},
+			&Route{Name: "default/a", Priority: 10, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "bge-small")}},
+			true,
+		},
+		{
+			"different priorities",
+			&Route{Name: "default/a", Priority: 10, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "bge-*")}},
+			&Route{Name: "default/b", Priority: 20, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "bge-small")}},
+			false,
+		},
+		{
+			"disjoint model globs",
+			&Route{Name: "default/a", Priority: 10, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "bge-*")}},
+			&Route{Name: "default/b", Priority: 10, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "gte-*")}},
+			false,
+		},
+		{
+			"disjoint operations",
+			&Route{Name: "default/a", Priority: 10, Operations: embed},
+			&Route{Name: "default/b", Priority: 10, Operations: rerank},
+			false,
+		},
+		{
+			"operations overlap with unrestricted route",
+			&Route{Name: "default/a", Priority: 10, Operations: embed},
+			&Route{Name: "default/b", Priority: 10, ModelPatterns: []*regexp.Regexp{mustModelPattern(t, "bge-*")}},
+			true,
+		},
+		{
+			"different exact header values",
+			&Route{Name: "default/a", Priority: 10, HeaderMatchers: map[string]*StringMatcher{"X-Tenant": {Exact: "team-1"}}},
+			&Route{Name: "default/b", Priority: 10, HeaderMatchers: map[string]*StringMatcher{"X-Tenant": {Exact: "team-2"}}},
+			false,
+		},
+		{
+			"exact header within prefix",
+			&Route{Name: "default/a", Priority: 10, HeaderMatchers: map[string]*StringMatcher{"X-Tenant": {Prefix: "team-"}}},
+			&Route{Name: "default/b", Priority: 10, HeaderMatchers: map[string]*StringMatcher{"X-Tenant": {Exact: "team-2"}}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := NewRouteManager()
+			rm.AddRoute(tt.a)
+			rm.AddRoute(tt.b)
+
+			got := rm.PriorityCollisions()
+			if !tt.want {
+				if len(got) != 0 {
+					t.Errorf("PriorityCollisions() = %v, want none", got)
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("PriorityCollisions() = %v, want one collision", got)
+			}
+			if got[0].First != "default/a" || got[0].Second != "default/b" {
+				t.Errorf("collision = %s, want default/a before default/b", got[0])
+			}
+			if got[0].Priority != tt.a.Priority {
+				t.Errorf("collision priority = %d, want %d", got[0].Priority, tt.a.Priority)
+			}
+			if n := len(rm.PriorityCollisionsFor("default/b")); n != 1 {
+				t.Errorf("PriorityCollisionsFor(default/b) returned %d collisions, want 1", n)
+			}
+		})
+	}
+}
+
+func mustModelPattern(t *testing.T, pattern string) *regexp.Regexp {
+	t.Helper()
+	re, err := CompileModelPattern(pattern)
+	if err != nil {
+		t.Fatalf("compiling %q: %v", pattern, err)
+	}
+	return re
+}
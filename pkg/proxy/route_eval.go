@@ -0,0 +1,364 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// LoadRoutes parses TermiteRoute manifests from r, which may hold several
+// YAML documents. Documents of other kinds are skipped, and routes without a
+// namespace are placed in "default" as kubectl would.
+func LoadRoutes(r io.Reader, logger *zap.Logger) ([]*Route, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	var routes []*Route
+	for {
+		var obj map[string]any
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return routes, nil
+			}
+			return nil, fmt.Errorf("decoding manifest: %w", err)
+		}
+		if obj == nil {
+			continue // Empty document
+		}
+
+		u := &unstructured.Unstructured{Object: obj}
+		if u.GetKind() != "TermiteRoute" {
+			continue
+		}
+		if u.GetNamespace() == "" {
+			u.SetNamespace("default")
+		}
+		route, err := convertRoute(u, logger)
+		if err != nil {
+			return nil, fmt.Errorf("converting TermiteRoute %s: %w", u.GetName(), err)
+		}
+		routes = append(routes, route)
+	}
+}
+
+// LoadRouteFiles parses TermiteRoute manifests from the given files
+func LoadRouteFiles(paths []string, logger *zap.Logger) ([]*Route, error) {
+	var routes []*Route
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		loaded, err := LoadRoutes(f, logger)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		routes = append(routes, loaded...)
+	}
+	return routes, nil
+}
+
+// ConditionResult is the outcome of a single route or destination condition
+type ConditionResult struct {
+	Condition string
+	Passed    bool
+	Detail    string
+}
+
+// RouteEvaluation explains whether a route matched a request
+type RouteEvaluation struct {
+	Route      string
+	Priority   int32
+	Matched    bool
+	Conditions []ConditionResult
+}
+
+// DestinationEvaluation explains whether a destination was eligible
+type DestinationEvaluation struct {
+	Pool       string
+	Weight     int32
+	Cost       int32
+	Eligible   bool
+	Conditions []ConditionResult
+}
+
+// Evaluation is the result of a routing dry-run
+type Evaluation struct {
+	// Routes lists the routes considered in priority order, up to and
+	// including the matched route
+	Routes []RouteEvaluation
+
+	// Matched is the matched route, nil if no route matched
+	Matched *Route
+
+	// Destinations explains each destination of the matched route
+	Destinations []DestinationEvaluation
+
+	// Selected is the chosen destination, nil if none was eligible and the
+	// route's fallback applies
+	Selected *Destination
+}
+
+// Evaluate performs a dry-run of routing req: it reports which route
+// matches, why the routes before it did not, and which destination would be
+// chosen. Unlike Match and SelectDestination it does not update route
+// statistics, slow-start or failover state, or rate limits.
+func (rm *RouteManager) Evaluate(req *RouteRequest, registry *ModelRegistry) (*Evaluation, error) {
+	rm.mu.RLock()
+	routes := rm.routes
+	rm.mu.RUnlock()
+
+	eval := &Evaluation{}
+	for _, route := range routes {
+		conditions := explainRoute(route, req)
+		matched := !slices.ContainsFunc(conditions, func(c ConditionResult) bool { return !c.Passed })
+		eval.Routes = append(eval.Routes, RouteEvaluation{
+			Route:      route.Name,
+			Priority:   route.Priority,
+			Matched:    matched,
+			Conditions: conditions,
+		})
+		if matched {
+			eval.Matched = route
+			break
+		}
+	}
+	if eval.Matched == nil {
+		return eval, nil
+	}
+
+	for i := range eval.Matched.Destinations {
+		dest := &eval.Matched.Destinations[i]
+		conditions := rm.explainConditions(dest, req, registry)
+		eval.Destinations = append(eval.Destinations, DestinationEvaluation{
+			Pool:       dest.Pool,
+			Weight:     dest.Weight,
+			Cost:       dest.Cost,
+			Eligible:   !slices.ContainsFunc(conditions, func(c ConditionResult) bool { return !c.Passed }),
+			Conditions: conditions,
+		})
+	}
+
+	selected, err := rm.selectDestination(eval.Matched, req, registry, false)
+	if err != nil {
+		return nil, err
+	}
+	eval.Selected = selected
+	return eval, nil
+}
+
+// explainRoute mirrors matchRoute, recording every condition the route sets
+// instead of stopping at the first failure
+func explainRoute(route *Route, req *RouteRequest) []ConditionResult {
+	var results []ConditionResult
+
+	if len(route.Operations) > 0 {
+		ops := make([]string, 0, len(route.Operations))
+		for op := range route.Operations {
+			ops = append(ops, string(op))
+		}
+		sort.Strings(ops)
+		results = append(results, ConditionResult{
+			Condition: "operations",
+			Passed:    route.Operations[req.Operation],
+			Detail:    fmt.Sprintf("%q in [%s]", req.Operation, strings.Join(ops, ", ")),
+		})
+	}
+
+	if len(route.ModelPatterns) > 0 || len(route.ExcludedModelPatterns) > 0 {
+		results = append(results, ConditionResult{
+			Condition: "models",
+			Passed:    route.matchModel(req.Model),
+			Detail:    fmt.Sprintf("%q against %s", req.Model, describePatterns(route)),
+		})
+	}
+
+	headers := make([]string, 0, len(route.HeaderMatchers))
+	for name := range route.HeaderMatchers {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	for _, name := range headers {
+		value, exists := req.Headers[name]
+		detail := "header not set"
+		if exists {
+			detail = fmt.Sprintf("%q", value)
+		}
+		results = append(results, ConditionResult{
+			Condition: "header " + name,
+			Passed:    exists && route.HeaderMatchers[name].Matches(value),
+			Detail:    detail,
+		})
+	}
+
+	if len(route.SourceTables) > 0 {
+		results = append(results, ConditionResult{
+			Condition: "source tables",
+			Passed:    route.SourceTables[req.SourceTable],
+			Detail:    fmt.Sprintf("%q", req.SourceTable),
+		})
+	}
+
+	if len(route.SourceNamespaces) > 0 {
+		results = append(results, ConditionResult{
+			Condition: "source namespaces",
+			Passed:    route.SourceNamespaces[req.SourceNamespace],
+			Detail:    fmt.Sprintf("%q", req.SourceNamespace),
+		})
+	}
+
+	if len(route.SourceServiceAccounts) > 0 {
+		results = append(results, ConditionResult{
+			Condition: "source service accounts",
+			Passed:    route.matchServiceAccount(req),
+			Detail:    fmt.Sprintf("%q in namespace %q", req.SourceServiceAccount, req.SourceNamespace),
+		})
+	}
+
+	if route.TimeWindow != nil {
+		results = append(results, ConditionResult{
+			Condition: "time window",
+			Passed:    route.TimeWindow.IsActive(req.Timestamp),
+			Detail:    describeTimeWindow(route.TimeWindow, req),
+		})
+	}
+
+	if route.Percentage != nil {
+		key := req.Model
+		if value, ok := req.Headers[route.HashHeader]; ok && route.HashHeader != "" {
+			key = value
+		}
+		bucket := percentageBucket(key)
+		results = append(results, ConditionResult{
+			Condition: "percentage",
+			Passed:    bucket < *route.Percentage,
+			Detail:    fmt.Sprintf("bucket %d of %q below %d", bucket, key, *route.Percentage),
+		})
+	}
+
+	return results
+}
+
+// explainConditions mirrors evaluateConditions, recording every condition
+// the destination sets instead of stopping at the first failure
+func (rm *RouteManager) explainConditions(dest *Destination, req *RouteRequest, registry *ModelRegistry) []ConditionResult {
+	endpoints := registry.GetEndpointsForPool(dest.Pool)
+	results := []ConditionResult{{
+		Condition: "healthy endpoints",
+		Passed:    len(endpoints) > 0,
+		Detail:    fmt.Sprintf("%d in pool %q", len(endpoints), dest.Pool),
+	}}
+	if len(endpoints) == 0 {
+		return results // No stats to evaluate the remaining conditions against
+	}
+
+	var totalQueueDepth int32
+	var modelLoaded bool
+	for _, ep := range endpoints {
+		totalQueueDepth += atomic.LoadInt32(&ep.QueueDepth)
+		if !modelLoaded && registry.HasModel(ep, req.Model) {
+			modelLoaded = true
+		}
+	}
+	avgQueueDepth := float64(totalQueueDepth) / float64(len(endpoints))
+
+	if c := dest.QueueDepthCondition; c != nil {
+		results = append(results, ConditionResult{
+			Condition: "queueDepth",
+			Passed:    c.Evaluate(avgQueueDepth),
+			Detail:    fmt.Sprintf("average %g, want %s", avgQueueDepth, formatThreshold(c)),
+		})
+	}
+
+	if c := dest.ReplicaCondition; c != nil {
+		results = append(results, ConditionResult{
+			Condition: "availableReplicas",
+			Passed:    c.Evaluate(float64(len(endpoints))),
+			Detail:    fmt.Sprintf("%d, want %s", len(endpoints), formatThreshold(c)),
+		})
+	}
+
+	if c := dest.LatencyCondition; c != nil {
+		result := ConditionResult{Condition: "latency", Passed: true}
+		if latency, ok := poolModelLatency(endpoints, req.Model); ok {
+			result.Passed = c.EvaluateDuration(latency)
+			result.Detail = fmt.Sprintf("%s, want %s", latency, formatThreshold(c))
+		} else {
+			result.Detail = "no latency reported yet, skipped"
+		}
+		results = append(results, result)
+	}
+
+	if c := dest.MetricCondition; c != nil {
+		result := ConditionResult{Condition: "metric"}
+		if metrics := rm.metricSource(); metrics == nil {
+			result.Detail = "no metric source configured"
+		} else if value, err := metrics.Query(req.ctx(), c.Query); err != nil {
+			result.Detail = fmt.Sprintf("query failed: %v", err)
+		} else {
+			result.Passed = c.Threshold.Evaluate(value)
+			result.Detail = fmt.Sprintf("%g, want %s", value, formatThreshold(c.Threshold))
+		}
+		results = append(results, result)
+	}
+
+	if dest.RequireModelLoaded {
+		results = append(results, ConditionResult{
+			Condition: "modelLoaded",
+			Passed:    modelLoaded,
+			Detail:    fmt.Sprintf("%q loaded: %t", req.Model, modelLoaded),
+		})
+	}
+
+	if dest.TimeCondition != nil {
+		results = append(results, ConditionResult{
+			Condition: "timeOfDay",
+			Passed:    dest.TimeCondition.IsActive(req.Timestamp),
+			Detail:    describeTimeWindow(dest.TimeCondition, req),
+		})
+	}
+
+	return results
+}
+
+func describePatterns(route *Route) string {
+	patterns := make([]string, 0, len(route.ModelPatterns)+len(route.ExcludedModelPatterns))
+	for _, p := range route.ModelPatterns {
+		patterns = append(patterns, p.String())
+	}
+	for _, p := range route.ExcludedModelPatterns {
+		patterns = append(patterns, "!"+p.String())
+	}
+	return "[" + strings.Join(patterns, ", ") + "]"
+}
+
+func describeTimeWindow(tw *TimeWindow, req *RouteRequest) string {
+	return fmt.Sprintf("%s within %02d:%02d-%02d:%02d UTC",
+		req.Timestamp.UTC().Format("Mon 15:04"), tw.StartHour, tw.StartMinute, tw.EndHour, tw.EndMinute)
+}
+
+func formatThreshold(c *ThresholdCondition) string {
+	return fmt.Sprintf("%s%g%s", c.Operator, c.Value, c.Unit)
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const sampleRoutes = `
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: tenant-gpu
+  namespace: search
+spec:
+  priority: 200
+  match:
+    operations: [embed]
+    models: ["bge-*"]
+    headers:
+      X-Tenant:
+        prefix: team-
+  route:
+    - pool: gpu
+      weight: 100
+      condition:
+        availableReplicas: ">=2"
+    - pool: cpu
+      weight: 0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+---
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: catch-all
+spec:
+  priority: 100
+  route:
+    - pool: default
+`
+
+func loadSampleRoutes(t *testing.T) *RouteManager {
+	t.Helper()
+	routes, err := LoadRoutes(strings.NewReader(sampleRoutes), zap.NewNop())
+	if err != nil {
+		t.Fatalf("LoadRoutes: %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("loaded %d routes, want 2", len(routes))
+	}
+	rm := NewRouteManager()
+	for _, route := range routes {
+		rm.AddRoute(route)
+	}
+	return rm
+}
+
+func TestLoadRouteFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	if err := os.WriteFile(path, []byte(sampleRoutes), 0644); err != nil {
+		t.Fatal(err)
+	}
+	routes, err := LoadRouteFiles([]string{path}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("LoadRouteFiles: %v", err)
+	}
+
+	names := make([]string, 0, len(routes))
+	for _, r := range routes {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "search/tenant-gpu,default/catch-all" {
+		t.Errorf("route names = %v", names)
+	}
+	if routes[0].Priority != 200 || len(routes[0].Destinations) != 2 {
+		t.Errorf("tenant-gpu = priority %d, %d destinations", routes[0].Priority, len(routes[0].Destinations))
+	}
+	if routes[0].Destinations[1].Weight != 0 {
+		t.Errorf("cpu weight = %d, want 0", routes[0].Destinations[1].Weight)
+	}
+}
+
+func TestEvaluate_MatchAndDestination(t *testing.T) {
+	rm := loadSampleRoutes(t)
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("gpu-0", "gpu", "")
+	registry.RegisterEndpoint("gpu-1", "gpu", "")
+	registry.RegisterEndpoint("cpu-0", "cpu", "")
+
+	req := &RouteRequest{
+		Operation: "embed",
+		Model:     "bge-small",
+		Headers:   map[string]string{"X-Tenant": "team-1"},
+		Timestamp: time.Now(),
+	}
+	eval, err := rm.Evaluate(req, registry)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if eval.Matched == nil || eval.Matched.Name != "search/tenant-gpu" {
+		t.Fatalf("matched %v, want search/tenant-gpu", eval.Matched)
+	}
+	if len(eval.Routes) != 1 || len(eval.Routes[0].Conditions) != 3 {
+		t.Fatalf("route evaluations = %+v", eval.Routes)
+	}
+	if eval.Selected == nil || eval.Selected.Pool != "gpu" {
+		t.Errorf("selected %v, want gpu", eval.Selected)
+	}
+	if eval.Matched.Matches() != 0 {
+		t.Error("Evaluate updated route statistics")
+	}
+}
+
+func TestEvaluate_ReportsFailedConditions(t *testing.T) {
+	rm := loadSampleRoutes(t)
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("default-0", "default", "")
+
+	req := &RouteRequest{
+		Operation: "embed",
+		Model:     "bge-small",
+		Headers:   map[string]string{"X-Tenant": "other"},
+		Timestamp: time.Now(),
+	}
+	eval, err := rm.Evaluate(req, registry)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if eval.Matched == nil || eval.Matched.Name != "default/catch-all" {
+		t.Fatalf("matched %v, want default/catch-all", eval.Matched)
+	}
+	if len(eval.Routes) != 2 || eval.Routes[0].Matched {
+		t.Fatalf("route evaluations = %+v", eval.Routes)
+	}
+	for _, c := range eval.Routes[0].Conditions {
+		wantPass := c.Condition != "header X-Tenant"
+		if c.Passed != wantPass {
+			t.Errorf("condition %s passed = %t, want %t", c.Condition, c.Passed, wantPass)
+		}
+	}
+	if eval.Selected == nil || eval.Selected.Pool != "default" {
+		t.Errorf("selected %v, want default", eval.Selected)
+	}
+}
+
+func TestEvaluate_IneligibleDestination(t *testing.T) {
+	rm := loadSampleRoutes(t)
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("gpu-0", "gpu", "") // Below availableReplicas >=2
+	registry.RegisterEndpoint("cpu-0", "cpu", "")
+
+	req := &RouteRequest{
+		Operation: "embed",
+		Model:     "bge-small",
+		Headers:   map[string]string{"X-Tenant": "team-1"},
+		Timestamp: time.Now(),
+	}
+	eval, err := rm.Evaluate(req, registry)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(eval.Destinations) != 2 {
+		t.Fatalf("destination evaluations = %+v", eval.Destinations)
+	}
+	gpu := eval.Destinations[0]
+	if gpu.Eligible {
+		t.Errorf("gpu eligible with one replica: %+v", gpu.Conditions)
+	}
+	for i := range eval.Matched.Destinations {
+		want := rm.evaluateConditions(&eval.Matched.Destinations[i], req, registry)
+		if eval.Destinations[i].Eligible != want {
+			t.Errorf("%s: Eligible = %t, evaluateConditions = %t", eval.Destinations[i].Pool, eval.Destinations[i].Eligible, want)
+		}
+	}
+	if eval.Selected == nil || eval.Selected.Pool != "cpu" {
+		t.Errorf("selected %v, want zero-weight cpu fallback", eval.Selected)
+	}
+}
+
+func TestEvaluate_NoMatch(t *testing.T) {
+	rm := NewRouteManager()
+	eval, err := rm.Evaluate(&RouteRequest{Operation: "embed", Model: "bge-small"}, NewModelRegistry(time.Minute))
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if eval.Matched != nil || eval.Selected != nil || len(eval.Routes) != 0 {
+		t.Errorf("evaluation = %+v, want no match", eval)
+	}
+}
+
+func TestEvaluate_DoesNotRecordSelectionState(t *testing.T) {
+	rm := loadSampleRoutes(t)
+	rm.SetFailoverCooldown(time.Minute)
+	rm.SetSlowStartWindow(time.Minute)
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("gpu-0", "gpu", "") // Ineligible, so cpu is a failover
+	registry.RegisterEndpoint("cpu-0", "cpu", "")
+
+	req := &RouteRequest{
+		Operation: "embed",
+		Model:     "bge-small",
+		Headers:   map[string]string{"X-Tenant": "team-1"},
+		Timestamp: time.Now(),
+	}
+	eval, err := rm.Evaluate(req, registry)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if eval.Selected == nil || eval.Selected.Pool != "cpu" {
+		t.Fatalf("selected %v, want cpu", eval.Selected)
+	}
+	if len(rm.failovers) != 0 {
+		t.Errorf("Evaluate started a failover cooldown: %+v", rm.failovers)
+	}
+	if len(rm.destHealth) != 0 {
+		t.Errorf("Evaluate recorded destination health: %+v", rm.destHealth)
+	}
+
+	// A real selection records both
+	if _, err := rm.SelectDestination(eval.Matched, req, registry); err != nil {
+		t.Fatalf("SelectDestination: %v", err)
+	}
+	if len(rm.failovers) != 1 || len(rm.destHealth) == 0 {
+		t.Errorf("SelectDestination: failovers %+v, health %+v", rm.failovers, rm.destHealth)
+	}
+}
@@ -17,21 +17,34 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 )
 
 // TermiteRouteGVR is the GroupVersionResource for TermiteRoute
@@ -41,18 +54,52 @@ var TermiteRouteGVR = schema.GroupVersionResource{
 	Resource: "termiteroutes",
 }
 
-// RouteWatcher watches TermiteRoute CRs and updates the RouteManager
+// TermitePoolGVR is the GroupVersionResource for TermitePool
+var TermitePoolGVR = schema.GroupVersionResource{
+	Group:    "antfly.io",
+	Version:  "v1alpha1",
+	Resource: "termitepools",
+}
+
+// newDynamicInformerFactory is replaced in tests to observe resync periods
+var newDynamicInformerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory
+
+// RouteWatcher watches TermiteRoute CRs and updates the RouteManager. It
+// also watches TermitePool CRs for the pool weights of default routing.
 type RouteWatcher struct {
 	routeManager *RouteManager
 	client       dynamic.Interface
 	namespace    string // empty for all namespaces
+	resyncPeriod time.Duration
 	logger       *zap.Logger
+
+	// recorder records Events on TermiteRoutes; nil disables them
+	recorder    record.EventRecorder
+	broadcaster record.EventBroadcaster
+	// routes is the informer's store of TermiteRoutes, set by Start
+	routes cache.Store
+	// poolDeleted is called with the namespace and name of each deleted
+	// TermitePool
+	poolDeleted func(namespace, pool string)
+	// registry provides pool health for the destination status; nil
+	// disables destination status reporting
+	registry *ModelRegistry
 }
 
+// destinationStatusInterval is how often the destination status of routes
+// is reported
+const destinationStatusInterval = 30 * time.Second
+
 // RouteWatcherConfig holds configuration for the route watcher
 type RouteWatcherConfig struct {
 	Kubeconfig string
 	Namespace  string // empty for all namespaces
+
+	// ResyncPeriod is how often the informer replays TermiteRoutes to the
+	// route manager, plus jitter; 0 disables periodic resync. Resyncs of
+	// unchanged routes are cheap but not free, so longer periods trade
+	// slower recovery from a missed event for less CPU.
+	ResyncPeriod time.Duration
 }
 
 // NewRouteWatcher creates a new TermiteRoute watcher
@@ -74,33 +121,36 @@ func NewRouteWatcher(routeManager *RouteManager, cfg RouteWatcherConfig, logger
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
 	if logger == nil {
 		logger, _ = zap.NewProduction()
 	}
 
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+
 	return &RouteWatcher{
 		routeManager: routeManager,
 		client:       client,
 		namespace:    cfg.Namespace,
+		resyncPeriod: cfg.ResyncPeriod,
 		logger:       logger,
+		recorder:     broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "termite-proxy"}),
+		broadcaster:  broadcaster,
 	}, nil
 }
 
 // Start begins watching TermiteRoute resources
 func (w *RouteWatcher) Start(ctx context.Context) error {
-	var factory dynamicinformer.DynamicSharedInformerFactory
-	if w.namespace != "" {
-		factory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(
-			w.client,
-			30*time.Second,
-			w.namespace,
-			nil,
-		)
-	} else {
-		factory = dynamicinformer.NewDynamicSharedInformerFactory(w.client, 30*time.Second)
-	}
+	// An empty namespace watches all namespaces
+	factory := newDynamicInformerFactory(w.client, jitteredResync(w.resyncPeriod), w.namespace, nil)
 
 	informer := factory.ForResource(TermiteRouteGVR).Informer()
+	w.routes = informer.GetStore()
 
 	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    w.onRouteAdd,
@@ -111,17 +161,41 @@ func (w *RouteWatcher) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to add event handler: %w", err)
 	}
 
+	poolInformer := factory.ForResource(TermitePoolGVR).Informer()
+	_, err = poolInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.onPoolAdd,
+		UpdateFunc: w.onPoolUpdate,
+		DeleteFunc: w.onPoolDelete,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add TermitePool event handler: %w", err)
+	}
+
 	factory.Start(ctx.Done())
 
 	// Wait for cache sync
 	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
 		return fmt.Errorf("failed to sync TermiteRoute cache")
 	}
+	if !cache.WaitForCacheSync(ctx.Done(), poolInformer.HasSynced) {
+		return fmt.Errorf("failed to sync TermitePool cache")
+	}
 
 	w.logger.Info("TermiteRoute watcher started", zap.String("namespace", w.namespace))
 
-	<-ctx.Done()
-	return nil
+	ticker := time.NewTicker(destinationStatusInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if w.broadcaster != nil {
+				w.broadcaster.Shutdown()
+			}
+			return nil
+		case <-ticker.C:
+			w.reportDestinationStatus(ctx)
+		}
+	}
 }
 
 func (w *RouteWatcher) onRouteAdd(obj any) {
@@ -133,9 +207,20 @@ func (w *RouteWatcher) onRouteAdd(obj any) {
 
 	w.routeManager.AddRoute(route)
 	w.logger.Info("added route", zap.String("name", route.Name), zap.Int32("priority", route.Priority))
+	if w.reportAccepted(obj, route) {
+		w.recordRouteEvents(obj, route)
+	}
+	w.warnPriorityCollisions(route)
 }
 
 func (w *RouteWatcher) onRouteUpdate(oldObj, newObj any) {
+	// Status updates (including our own) and resyncs leave the spec unchanged
+	oldU, oldOK := oldObj.(*unstructured.Unstructured)
+	newU, newOK := newObj.(*unstructured.Unstructured)
+	if oldOK && newOK && newU.GetGeneration() != 0 && oldU.GetGeneration() == newU.GetGeneration() {
+		return
+	}
+
 	route, err := w.convertRoute(newObj)
 	if err != nil {
 		w.logger.Error("failed to convert TermiteRoute", zap.Error(err))
@@ -144,6 +229,216 @@ func (w *RouteWatcher) onRouteUpdate(oldObj, newObj any) {
 
 	w.routeManager.AddRoute(route) // AddRoute handles updates by name
 	w.logger.Info("updated route", zap.String("name", route.Name), zap.Int32("priority", route.Priority))
+	if w.reportAccepted(newObj, route) {
+		w.recordRouteEvents(newObj, route)
+	}
+	w.warnPriorityCollisions(route)
+}
+
+// warnPriorityCollisions logs the routes that share route's priority and
+// may match the same requests, since their order is decided by name
+func (w *RouteWatcher) warnPriorityCollisions(route *Route) {
+	for _, c := range w.routeManager.PriorityCollisionsFor(route.Name) {
+		w.logger.Warn("route priority collision",
+			zap.String("first", c.First),
+			zap.String("second", c.Second),
+			zap.Int32("priority", c.Priority))
+	}
+}
+
+// RouteConditionAccepted is the TermiteRoute status condition reporting
+// whether the proxy applied the whole spec. It is False, with the dropped
+// fields in its message, when parts of the spec failed to parse.
+const RouteConditionAccepted = "Accepted"
+
+// acceptedCondition builds the Accepted condition for a converted route
+func acceptedCondition(route *Route, generation int64) metav1.Condition {
+	if len(route.Warnings) > 0 {
+		return metav1.Condition{
+			Type:               RouteConditionAccepted,
+			Status:             metav1.ConditionFalse,
+			Reason:             "InvalidFields",
+			Message:            "route applied without invalid fields: " + strings.Join(route.Warnings, "; "),
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               RouteConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Accepted",
+		Message:            "route applied",
+		ObservedGeneration: generation,
+	}
+}
+
+// reportAccepted sets the Accepted condition on the TermiteRoute status,
+// skipping the write when the condition is already current. It reports
+// whether the condition changed.
+//
+// The status patch carries the resourceVersion it was computed from, so a
+// concurrent status change makes it conflict instead of being overwritten;
+// the condition is then reapplied to the latest object.
+func (w *RouteWatcher) reportAccepted(obj any, route *Route) bool {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+
+	cond := acceptedCondition(route, u.GetGeneration())
+	if existing := meta.FindStatusCondition(statusConditions(u), RouteConditionAccepted); existing != nil &&
+		existing.Status == cond.Status && existing.Reason == cond.Reason &&
+		existing.Message == cond.Message && existing.ObservedGeneration == cond.ObservedGeneration {
+		return false
+	}
+	if w.client == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resource := w.client.Resource(TermiteRouteGVR).Namespace(u.GetNamespace())
+	current := u
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if current == nil {
+			latest, err := resource.Get(ctx, u.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if latest.GetGeneration() != u.GetGeneration() {
+				return nil // The newer spec reports its own condition
+			}
+			current = latest
+		}
+		conditions := statusConditions(current)
+		meta.SetStatusCondition(&conditions, cond)
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{"resourceVersion": current.GetResourceVersion()},
+			"status":   map[string]any{"conditions": conditions},
+		})
+		if err != nil {
+			return err
+		}
+		current = nil
+		_, err = resource.Patch(ctx, u.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		return err
+	})
+	if err != nil {
+		w.logger.Warn("failed to update TermiteRoute status", zap.String("name", route.Name), zap.Error(err))
+	}
+	return true
+}
+
+// statusConditions decodes the status conditions of a TermiteRoute
+func statusConditions(u *unstructured.Unstructured) []metav1.Condition {
+	var conditions []metav1.Condition
+	if raw, found, _ := unstructured.NestedSlice(u.Object, "status", "conditions"); found {
+		if data, err := json.Marshal(raw); err == nil {
+			_ = json.Unmarshal(data, &conditions)
+		}
+	}
+	return conditions
+}
+
+// reportDestinationStatus sets status.destinationStatus on each TermiteRoute
+// whose destinations changed since the last report. With several proxy
+// replicas, each reports the connections it handles, and the last report
+// wins.
+func (w *RouteWatcher) reportDestinationStatus(ctx context.Context) {
+	if w.registry == nil || w.routes == nil || w.client == nil {
+		return
+	}
+	for _, obj := range w.routes.List() {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		statuses := w.routeManager.DestinationStatuses(u.GetNamespace()+"/"+u.GetName(), w.registry)
+		if statuses == nil {
+			continue
+		}
+
+		var current []DestinationStatus
+		if raw, found, _ := unstructured.NestedSlice(u.Object, "status", "destinationStatus"); found {
+			if data, err := json.Marshal(raw); err == nil {
+				_ = json.Unmarshal(data, &current)
+			}
+		}
+		if slices.Equal(current, statuses) {
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]any{"status": map[string]any{"destinationStatus": statuses}})
+		if err != nil {
+			continue
+		}
+		_, err = w.client.Resource(TermiteRouteGVR).Namespace(u.GetNamespace()).
+			Patch(ctx, u.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		if err != nil {
+			w.logger.Warn("failed to update TermiteRoute destination status",
+				zap.String("name", u.GetNamespace()+"/"+u.GetName()), zap.Error(err))
+		}
+	}
+}
+
+// Reasons of the Events recorded on TermiteRoutes
+const (
+	EventReasonRouteAccepted         = "RouteAccepted"
+	EventReasonInvalidFields         = "InvalidFields"
+	EventReasonConditionCompileError = "ConditionCompileError"
+	EventReasonDestinationUnhealthy  = "DestinationUnhealthy"
+)
+
+// recordRouteEvents records an Event for the outcome of applying a route:
+// RouteAccepted when the whole spec applied, or a Warning per kind of
+// dropped field, with destination conditions reported separately
+func (w *RouteWatcher) recordRouteEvents(obj any, route *Route) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || w.recorder == nil {
+		return
+	}
+	if len(route.Warnings) == 0 {
+		w.recorder.Event(u, corev1.EventTypeNormal, EventReasonRouteAccepted, "route applied")
+		return
+	}
+
+	var conditionWarnings, otherWarnings []string
+	for _, warning := range route.Warnings {
+		if strings.Contains(warning, ".condition.") {
+			conditionWarnings = append(conditionWarnings, warning)
+		} else {
+			otherWarnings = append(otherWarnings, warning)
+		}
+	}
+	if len(conditionWarnings) > 0 {
+		w.recorder.Event(u, corev1.EventTypeWarning, EventReasonConditionCompileError,
+			"dropped invalid destination conditions: "+strings.Join(conditionWarnings, "; "))
+	}
+	if len(otherWarnings) > 0 {
+		w.recorder.Event(u, corev1.EventTypeWarning, EventReasonInvalidFields,
+			"route applied without invalid fields: "+strings.Join(otherWarnings, "; "))
+	}
+}
+
+// recordDestinationUnhealthy records a DestinationUnhealthy Event on each
+// TermiteRoute in the same namespace that routes to pool
+func (w *RouteWatcher) recordDestinationUnhealthy(namespace, pool, message string) {
+	if w.recorder == nil || w.routes == nil {
+		return
+	}
+	for _, obj := range w.routes.List() {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok || u.GetNamespace() != namespace {
+			continue
+		}
+		destinations, _, _ := unstructured.NestedSlice(u.Object, "spec", "route")
+		for _, d := range destinations {
+			if dest, ok := d.(map[string]any); ok && getString(dest, "pool") == pool {
+				w.recorder.Eventf(u, corev1.EventTypeWarning, EventReasonDestinationUnhealthy,
+					"destination pool %s %s", pool, message)
+				break
+			}
+		}
+	}
 }
 
 func (w *RouteWatcher) onRouteDelete(obj any) {
@@ -158,8 +453,71 @@ func (w *RouteWatcher) onRouteDelete(obj any) {
 	w.logger.Info("removed route", zap.String("name", name))
 }
 
+// onPoolAdd applies the spec.routing.weight of a TermitePool, which weighs
+// the pool in default routing
+func (w *RouteWatcher) onPoolAdd(obj any) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		w.logger.Error("failed to cast object to Unstructured")
+		return
+	}
+
+	weight := DefaultPoolWeight
+	if routing, ok, _ := unstructured.NestedMap(u.Object, "spec", "routing"); ok {
+		weight = getInt32(routing, "weight", DefaultPoolWeight)
+	}
+	w.routeManager.SetPoolWeight(u.GetNamespace(), u.GetName(), weight)
+	w.logger.Debug("set pool weight", zap.String("pool", u.GetName()), zap.Int32("weight", weight))
+}
+
+// onPoolUpdate applies the pool weight and reports routes whose
+// destination pool lost its last ready replica
+func (w *RouteWatcher) onPoolUpdate(oldObj, newObj any) {
+	w.onPoolAdd(newObj)
+
+	oldU, oldOK := oldObj.(*unstructured.Unstructured)
+	newU, newOK := newObj.(*unstructured.Unstructured)
+	if !oldOK || !newOK {
+		return
+	}
+	if readyReplicas(oldU) > 0 && readyReplicas(newU) == 0 {
+		w.recordDestinationUnhealthy(newU.GetNamespace(), newU.GetName(), "has no ready replicas")
+	}
+}
+
+// onPoolDelete drops the weight of a deleted TermitePool and its endpoints.
+// The pool's Service is owned by the TermitePool, so its EndpointSlices are
+// garbage collected too, but the proxy doesn't wait for their delete events.
+func (w *RouteWatcher) onPoolDelete(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		w.logger.Error("failed to cast object to Unstructured")
+		return
+	}
+	w.routeManager.RemovePoolWeight(u.GetNamespace(), u.GetName())
+	w.recordDestinationUnhealthy(u.GetNamespace(), u.GetName(), "was deleted")
+	if w.poolDeleted != nil {
+		w.poolDeleted(u.GetNamespace(), u.GetName())
+	}
+}
+
+// readyReplicas returns status.replicas.ready of a TermitePool
+func readyReplicas(u *unstructured.Unstructured) int64 {
+	ready, _, _ := unstructured.NestedInt64(u.Object, "status", "replicas", "ready")
+	return ready
+}
+
 // convertRoute converts an unstructured TermiteRoute to the proxy's Route type
 func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
+	return convertRoute(obj, w.logger)
+}
+
+// convertRoute converts an unstructured TermiteRoute to the proxy's Route
+// type, logging (and skipping) patterns and conditions that fail to parse
+func convertRoute(obj any, logger *zap.Logger) (*Route, error) {
 	u, ok := obj.(*unstructured.Unstructured)
 	if !ok {
 		return nil, fmt.Errorf("object is not Unstructured")
@@ -177,13 +535,15 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 	fullName := namespace + "/" + name
 
 	route := &Route{
-		Name:           fullName,
-		Priority:       getInt32(spec, "priority", 100),
-		Operations:     make(map[OperationType]bool),
-		ModelPatterns:  make([]*regexp.Regexp, 0),
-		HeaderMatchers: make(map[string]*StringMatcher),
-		SourceTables:   make(map[string]bool),
-		Destinations:   make([]Destination, 0),
+		Name:                  fullName,
+		Priority:              getInt32(spec, "priority", 100),
+		Operations:            make(map[OperationType]bool),
+		ModelPatterns:         make([]*regexp.Regexp, 0),
+		HeaderMatchers:        make(map[string]*StringMatcher),
+		SourceTables:          make(map[string]bool),
+		SourceNamespaces:      make(map[string]bool),
+		SourceServiceAccounts: make(map[string]bool),
+		Destinations:          make([]Destination, 0),
 	}
 
 	// Parse match conditions
@@ -201,12 +561,18 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 		if models, ok := match["models"].([]any); ok {
 			for _, model := range models {
 				if modelStr, ok := model.(string); ok {
-					pattern, err := CompileModelPattern(modelStr)
+					patternStr, negated := ParseModelPattern(modelStr)
+					pattern, err := CompileModelPattern(patternStr)
 					if err != nil {
-						w.logger.Warn("failed to compile model pattern", zap.String("pattern", modelStr), zap.Error(err))
+						logger.Warn("failed to compile model pattern", zap.String("pattern", modelStr), zap.Error(err))
+						route.Warnings = append(route.Warnings, fmt.Sprintf("spec.match.models: dropped pattern %q: %v", modelStr, err))
 						continue
 					}
-					route.ModelPatterns = append(route.ModelPatterns, pattern)
+					if negated {
+						route.ExcludedModelPatterns = append(route.ExcludedModelPatterns, pattern)
+					} else {
+						route.ModelPatterns = append(route.ModelPatterns, pattern)
+					}
 				}
 			}
 		}
@@ -223,8 +589,11 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 						matcher.Prefix = prefix
 					}
 					if regexStr, ok := matchMap["regex"].(string); ok {
-						if regex, err := regexp.Compile(regexStr); err == nil {
+						if regex, err := CompileRegex(regexStr); err == nil {
 							matcher.Regex = regex
+						} else {
+							logger.Warn("failed to compile header regex", zap.String("header", headerName), zap.String("regex", regexStr), zap.Error(err))
+							route.Warnings = append(route.Warnings, fmt.Sprintf("spec.match.headers.%s: dropped regex %q: %v", headerName, regexStr, err))
 						}
 					}
 					route.HeaderMatchers[headerName] = matcher
@@ -232,21 +601,26 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 			}
 		}
 
-		// Source tables
+		// Source tables, namespaces and service accounts
 		if source, ok := match["source"].(map[string]any); ok {
-			if tables, ok := source["tables"].([]any); ok {
-				for _, table := range tables {
-					if tableStr, ok := table.(string); ok {
-						route.SourceTables[tableStr] = true
-					}
-				}
-			}
+			route.SourceTables = getStringSet(source, "tables")
+			route.SourceNamespaces = getStringSet(source, "namespaces")
+			route.SourceServiceAccounts = getStringSet(source, "serviceAccounts")
 		}
 
 		// Time window
 		if tw, ok := match["timeWindow"].(map[string]any); ok {
 			route.TimeWindow = parseTimeWindow(tw)
 		}
+
+		// Percentage canary
+		if _, ok := match["percentage"]; ok {
+			percentage := min(max(getInt32(match, "percentage", 100), 0), 100)
+			route.Percentage = &percentage
+			if header := getString(match, "hashHeader"); header != "" {
+				route.HashHeader = http.CanonicalHeaderKey(header)
+			}
+		}
 	}
 
 	// Parse destinations
@@ -256,23 +630,53 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 				dest := Destination{
 					Pool:   getString(destMap, "pool"),
 					Weight: getInt32(destMap, "weight", 100),
+					Cost:   max(getInt32(destMap, "cost", 0), 0),
+				}
+				if dest.Weight < 0 || dest.Weight > 100 {
+					clamped := min(max(dest.Weight, 0), 100)
+					logger.Warn("destination weight out of range", zap.String("pool", dest.Pool), zap.Int32("weight", dest.Weight))
+					route.Warnings = append(route.Warnings, fmt.Sprintf("spec.route[%s].weight: %d out of range [0, 100], using %d", dest.Pool, dest.Weight, clamped))
+					dest.Weight = clamped
 				}
 
 				// Parse condition
 				if condition, ok := destMap["condition"].(map[string]any); ok {
 					if qd, ok := condition["queueDepth"].(string); ok {
-						if cond, err := ParseThresholdCondition(qd); err == nil {
+						if cond, err := ParseCountCondition(qd); err == nil {
 							dest.QueueDepthCondition = cond
+						} else {
+							logger.Warn("invalid queueDepth condition", zap.String("condition", qd), zap.Error(err))
+							route.Warnings = append(route.Warnings, fmt.Sprintf("spec.route[%s].condition.queueDepth: dropped %q: %v", dest.Pool, qd, err))
 						}
 					}
 					if ar, ok := condition["availableReplicas"].(string); ok {
-						if cond, err := ParseThresholdCondition(ar); err == nil {
+						if cond, err := ParseCountCondition(ar); err == nil {
 							dest.ReplicaCondition = cond
+						} else {
+							logger.Warn("invalid availableReplicas condition", zap.String("condition", ar), zap.Error(err))
+							route.Warnings = append(route.Warnings, fmt.Sprintf("spec.route[%s].condition.availableReplicas: dropped %q: %v", dest.Pool, ar, err))
 						}
 					}
 					if lat, ok := condition["latency"].(string); ok {
 						if cond, err := ParseThresholdCondition(lat); err == nil {
 							dest.LatencyCondition = cond
+						} else {
+							logger.Warn("invalid latency condition", zap.String("condition", lat), zap.Error(err))
+							route.Warnings = append(route.Warnings, fmt.Sprintf("spec.route[%s].condition.latency: dropped %q: %v", dest.Pool, lat, err))
+						}
+					}
+					if metric, ok := condition["metric"].(map[string]any); ok {
+						query := getString(metric, "query")
+						threshold := getString(metric, "threshold")
+						if cond, err := ParseCountCondition(threshold); err == nil && query != "" {
+							dest.MetricCondition = &MetricCondition{
+								Query:     strings.ReplaceAll(query, "$pool", dest.Pool),
+								Threshold: cond,
+							}
+						} else {
+							logger.Warn("invalid metric condition",
+								zap.String("query", query), zap.String("threshold", threshold), zap.Error(err))
+							route.Warnings = append(route.Warnings, fmt.Sprintf("spec.route[%s].condition.metric: dropped query %q with threshold %q", dest.Pool, query, threshold))
 						}
 					}
 					if ml, ok := condition["modelLoaded"].(bool); ok && ml {
@@ -288,6 +692,8 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 		}
 	}
 
+	route.normalizeWeights()
+
 	// Parse fallback
 	if fallback, ok := spec["fallback"].(map[string]any); ok {
 		route.Fallback = &Fallback{
@@ -303,26 +709,56 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 		if rp := getString(fallback, "redirectPool"); rp != "" {
 			route.Fallback.RedirectPool = rp
 		}
+		route.Fallback.FailFast, _ = fallback["failFast"].(bool)
 	}
 
 	// Parse rate limiting
 	if rl, ok := spec["rateLimiting"].(map[string]any); ok {
-		rps := getInt32(rl, "requestsPerSecond", 0)
-		burst := getInt32(rl, "burstSize", rps)
+		// requestsPerMinute allows rates below 1 RPS (e.g. 12/min = one every 5s)
+		rate := float64(getInt32(rl, "requestsPerSecond", 0))
+		if rpm := getInt32(rl, "requestsPerMinute", 0); rpm > 0 {
+			rate = float64(rpm) / 60
+		}
+		burst := getInt32(rl, "burstSize", int32(math.Ceil(rate)))
 		perModel, _ := rl["perModel"].(bool)
-		if rps > 0 {
-			route.RateLimiter = NewRateLimiter(rps, burst, perModel)
+		if rate > 0 {
+			route.RateLimiter = NewRateLimiterWithRate(rate, burst, perModel)
 		}
 	}
 
+	// Parse header transformations
+	if headers, ok := spec["headers"].(map[string]any); ok {
+		route.Headers = parseHeaderTransform(headers, route, logger)
+	}
+
 	// Parse retry config
 	if retry, ok := spec["retry"].(map[string]any); ok {
 		route.RetryAttempts = getInt32(retry, "attempts", 3)
 
+		if perTry := getString(retry, "perTryTimeout"); perTry != "" {
+			if d, err := time.ParseDuration(perTry); err == nil && d > 0 {
+				route.RetryTimeout = d
+			} else {
+				route.Warnings = append(route.Warnings, fmt.Sprintf("spec.retry.perTryTimeout: dropped %q", perTry))
+			}
+		}
+
 		if retryOn, ok := retry["retryOn"].([]any); ok {
 			route.RetryOnStatuses = make(map[int]bool)
 			for _, r := range retryOn {
-				if rs, ok := r.(string); ok {
+				rs, _ := r.(string)
+				switch rs {
+				case "connect-failure", "refused-stream":
+					route.RetryOnConnectFailure = true
+				case "reset":
+					route.RetryOnReset = true
+				case "deadline-exceeded":
+					route.RetryOnTimeout = true
+				case "retriable-4xx":
+					route.RetryOnStatuses[http.StatusConflict] = true
+				case "resource-exhausted":
+					route.RetryOnStatuses[http.StatusTooManyRequests] = true
+				default:
 					// Handle "5xx" pattern
 					if before, ok0 := strings.CutSuffix(rs, "xx"); ok0 {
 						prefix := before
@@ -340,6 +776,48 @@ func (w *RouteWatcher) convertRoute(obj any) (*Route, error) {
 	return route, nil
 }
 
+// parseHeaderTransform converts spec.headers, dropping entries with invalid
+// header names (recorded as route warnings)
+func parseHeaderTransform(spec map[string]any, route *Route, logger *zap.Logger) *HeaderTransform {
+	h := &HeaderTransform{Set: make(http.Header), Add: make(http.Header)}
+	validName := func(field, name string) bool {
+		if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 || name == "" {
+			logger.Warn("invalid header name", zap.String("field", field), zap.String("header", name))
+			route.Warnings = append(route.Warnings, fmt.Sprintf("spec.headers.%s: dropped invalid header name %q", field, name))
+			return false
+		}
+		return true
+	}
+	for _, field := range []string{"set", "add"} {
+		entries, _ := spec[field].([]any)
+		for _, entry := range entries {
+			hv, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+			name := getString(hv, "name")
+			if !validName(field, name) {
+				continue
+			}
+			if field == "set" {
+				h.Set.Set(name, getString(hv, "value"))
+			} else {
+				h.Add.Add(name, getString(hv, "value"))
+			}
+		}
+	}
+	removes, _ := spec["remove"].([]any)
+	for _, entry := range removes {
+		if name, _ := entry.(string); validName("remove", name) {
+			h.Remove = append(h.Remove, http.CanonicalHeaderKey(name))
+		}
+	}
+	if len(h.Set) == 0 && len(h.Add) == 0 && len(h.Remove) == 0 {
+		return nil
+	}
+	return h
+}
+
 // Helper functions for parsing unstructured data
 
 func getString(m map[string]any, key string) string {
@@ -349,6 +827,18 @@ func getString(m map[string]any, key string) string {
 	return ""
 }
 
+func getStringSet(m map[string]any, key string) map[string]bool {
+	set := make(map[string]bool)
+	if values, ok := m[key].([]any); ok {
+		for _, v := range values {
+			if str, ok := v.(string); ok {
+				set[str] = true
+			}
+		}
+	}
+	return set
+}
+
 func getInt32(m map[string]any, key string, defaultVal int32) int32 {
 	if v, ok := m[key].(float64); ok {
 		if v > math.MaxInt32 {
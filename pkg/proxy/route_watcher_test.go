@@ -0,0 +1,318 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTermiteRoute(name string, models ...any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "antfly.io/v1alpha1",
+		"kind":       "TermiteRoute",
+		"metadata":   map[string]any{"namespace": "default", "name": name, "generation": int64(1)},
+		"spec": map[string]any{
+			"match": map[string]any{"models": models},
+			"route": []any{map[string]any{"pool": "default"}},
+		},
+	}}
+	return u
+}
+
+// acceptedStatus fetches the Accepted condition of a TermiteRoute
+func acceptedStatus(t *testing.T, w *RouteWatcher, name string) *metav1.Condition {
+	t.Helper()
+	u, err := w.client.Resource(TermiteRouteGVR).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting %s: %v", name, err)
+	}
+	raw, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	var conditions []metav1.Condition
+	for _, c := range raw {
+		var cond metav1.Condition
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(c.(map[string]any), &cond); err != nil {
+			t.Fatalf("decoding condition: %v", err)
+		}
+		conditions = append(conditions, cond)
+	}
+	return meta.FindStatusCondition(conditions, RouteConditionAccepted)
+}
+
+func TestRouteWatcher_DegradedRouteCondition(t *testing.T) {
+	obj := newTermiteRoute("bad-regex", "bge-*", "re:^(bge|gte-.*$")
+	w := &RouteWatcher{
+		routeManager: NewRouteManager(),
+		client:       dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), obj.DeepCopy()),
+		logger:       zap.NewNop(),
+	}
+
+	w.onRouteAdd(obj)
+
+	// The route is still added, without the invalid pattern
+	route := w.routeManager.Match(&RouteRequest{Model: "bge-small"})
+	if route == nil || route.Name != "default/bad-regex" {
+		t.Fatalf("Match = %v, want default/bad-regex", route)
+	}
+	if len(route.ModelPatterns) != 1 || len(route.Warnings) != 1 {
+		t.Fatalf("route has %d patterns and warnings %v; want 1 pattern, 1 warning", len(route.ModelPatterns), route.Warnings)
+	}
+
+	cond := acceptedStatus(t, w, "bad-regex")
+	if cond == nil {
+		t.Fatal("Accepted condition not set")
+	}
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "InvalidFields" {
+		t.Errorf("Accepted = %s/%s, want False/InvalidFields", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "spec.match.models") || !strings.Contains(cond.Message, "re:^(bge|gte-.*$") {
+		t.Errorf("message %q does not name the dropped pattern", cond.Message)
+	}
+	if cond.ObservedGeneration != 1 {
+		t.Errorf("observedGeneration = %d, want 1", cond.ObservedGeneration)
+	}
+}
+
+func TestRouteWatcher_AcceptedRouteCondition(t *testing.T) {
+	obj := newTermiteRoute("good", "bge-*")
+	w := &RouteWatcher{
+		routeManager: NewRouteManager(),
+		client:       dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), obj.DeepCopy()),
+		logger:       zap.NewNop(),
+	}
+
+	w.onRouteAdd(obj)
+
+	cond := acceptedStatus(t, w, "good")
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("Accepted = %+v, want True", cond)
+	}
+}
+
+func TestRouteWatcher_AcceptedConditionRetriesOnConflict(t *testing.T) {
+	obj := newTermiteRoute("contended", "bge-*")
+	obj.SetResourceVersion("1")
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), obj.DeepCopy())
+	var patches []string
+	client.PrependReactor("patch", "termiteroutes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patches = append(patches, string(action.(k8stesting.PatchAction).GetPatch()))
+		if len(patches) == 1 {
+			// Another writer updated the status since the informer saw it
+			return true, nil, apierrors.NewConflict(TermiteRouteGVR.GroupResource(), "contended", nil)
+		}
+		return false, nil, nil
+	})
+	w := &RouteWatcher{routeManager: NewRouteManager(), client: client, logger: zap.NewNop()}
+
+	w.onRouteAdd(obj)
+
+	if len(patches) != 2 {
+		t.Fatalf("%d status patches, want a retry after the conflict", len(patches))
+	}
+	for _, patch := range patches {
+		if !strings.Contains(patch, `"resourceVersion"`) {
+			t.Errorf("patch %s is not guarded by resourceVersion", patch)
+		}
+	}
+	if cond := acceptedStatus(t, w, "contended"); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("Accepted = %+v, want True", cond)
+	}
+}
+
+func TestRouteWatcher_InvalidConditionEvent(t *testing.T) {
+	obj := newTermiteRoute("bad-condition", "bge-*")
+	obj.Object["spec"].(map[string]any)["route"] = []any{
+		map[string]any{"pool": "default", "condition": map[string]any{"queueDepth": ">10ms"}},
+	}
+	recorder := record.NewFakeRecorder(10)
+	w := &RouteWatcher{
+		routeManager: NewRouteManager(),
+		client:       dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), obj.DeepCopy()),
+		logger:       zap.NewNop(),
+		recorder:     recorder,
+	}
+
+	w.onRouteAdd(obj)
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.HasPrefix(event, "Warning ConditionCompileError ") || !strings.Contains(event, "queueDepth") {
+			t.Errorf("event = %q, want a ConditionCompileError warning naming queueDepth", event)
+		}
+	default:
+		t.Fatal("no event recorded")
+	}
+
+	// Reprocessing the same generation leaves the condition current, so no
+	// further event is recorded
+	w.onRouteAdd(acceptedObject(t, w, "bad-condition"))
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected event %q", event)
+	default:
+	}
+}
+
+func TestRouteWatcher_DestinationUnhealthyEvent(t *testing.T) {
+	route := newTermiteRoute("to-gpu", "bge-*")
+	route.Object["spec"].(map[string]any)["route"] = []any{map[string]any{"pool": "gpu"}}
+	other := newTermiteRoute("to-cpu", "bge-*")
+	recorder := record.NewFakeRecorder(10)
+	w := &RouteWatcher{
+		routeManager: NewRouteManager(),
+		logger:       zap.NewNop(),
+		recorder:     recorder,
+		routes:       cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+	_ = w.routes.Add(route)
+	_ = w.routes.Add(other)
+
+	pool := func(ready int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "antfly.io/v1alpha1",
+			"kind":       "TermitePool",
+			"metadata":   map[string]any{"namespace": "default", "name": "gpu"},
+			"status":     map[string]any{"replicas": map[string]any{"ready": ready}},
+		}}
+	}
+	w.onPoolUpdate(pool(2), pool(0))
+
+	if len(recorder.Events) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning DestinationUnhealthy destination pool gpu") {
+		t.Errorf("event = %q, want DestinationUnhealthy for pool gpu", event)
+	}
+}
+
+func TestRouteWatcher_PoolDeleteUnregistersEndpoints(t *testing.T) {
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	for _, address := range []string{"http://gpu-0:11433", "http://gpu-1:11433"} {
+		p.RegisterEndpoint(address, "gpu", WorkloadTypeGeneral)
+		p.SetEndpointNamespace(address, "default")
+	}
+	p.RegisterEndpoint("http://cpu-0:11433", "cpu", WorkloadTypeGeneral)
+	p.SetEndpointNamespace("http://cpu-0:11433", "default")
+	// The live pool of the same name in another namespace keeps its endpoint
+	p.RegisterEndpoint("http://gpu-b-0:11433", "gpu", WorkloadTypeGeneral)
+	p.SetEndpointNamespace("http://gpu-b-0:11433", "team-b")
+	w := &RouteWatcher{
+		routeManager: p.Router().RouteManager(),
+		logger:       zap.NewNop(),
+		poolDeleted:  p.UnregisterPool,
+	}
+
+	pool := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "antfly.io/v1alpha1",
+		"kind":       "TermitePool",
+		"metadata":   map[string]any{"namespace": "default", "name": "gpu"},
+	}}
+	w.onPoolAdd(pool)
+	// Delivered as a tombstone when the delete event itself was missed
+	w.onPoolDelete(cache.DeletedFinalStateUnknown{Key: "default/gpu", Obj: pool})
+
+	if eps := p.Registry().GetEndpointsForPool("gpu"); len(eps) != 1 || eps[0].Address != "http://gpu-b-0:11433" {
+		t.Errorf("gpu endpoints = %v, want only team-b's gpu-b-0", eps)
+	}
+	if eps := p.Registry().GetEndpointsForPool("cpu"); len(eps) != 1 {
+		t.Errorf("cpu pool has %d endpoints, want it untouched", len(eps))
+	}
+}
+
+func TestRouteWatcher_ReportDestinationStatus(t *testing.T) {
+	obj := newTermiteRoute("to-default", "bge-*")
+	p := NewProxy(Config{DefaultPool: "default", Logger: zap.NewNop()})
+	p.RegisterEndpoint("http://default-0:11433", "default", WorkloadTypeGeneral)
+	w := &RouteWatcher{
+		routeManager: p.Router().RouteManager(),
+		client:       dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), obj.DeepCopy()),
+		logger:       zap.NewNop(),
+		registry:     p.Registry(),
+		routes:       cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+	w.onRouteAdd(obj)
+	_ = w.routes.Add(acceptedObject(t, w, "to-default"))
+
+	route := w.routeManager.Match(&RouteRequest{Model: "bge-small"})
+	release := w.routeManager.trackConnection(route, "default")
+	defer release()
+
+	w.reportDestinationStatus(context.Background())
+
+	u := acceptedObject(t, w, "to-default")
+	raw, _, _ := unstructured.NestedSlice(u.Object, "status", "destinationStatus")
+	if len(raw) != 1 {
+		t.Fatalf("destinationStatus = %v, want one destination", raw)
+	}
+	dest := raw[0].(map[string]any)
+	if dest["pool"] != "default" || dest["healthy"] != true || dest["activeConnections"] != int64(1) {
+		t.Errorf("destinationStatus = %v, want healthy pool default with 1 active connection", dest)
+	}
+}
+
+// acceptedObject fetches a TermiteRoute as the informer would deliver it
+// after the status update
+func acceptedObject(t *testing.T, w *RouteWatcher, name string) *unstructured.Unstructured {
+	t.Helper()
+	u, err := w.client.Resource(TermiteRouteGVR).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting %s: %v", name, err)
+	}
+	return u
+}
+
+func TestRouteWatcher_ResyncPeriod(t *testing.T) {
+	var resync time.Duration
+	defer func(orig func(dynamic.Interface, time.Duration, string, dynamicinformer.TweakListOptionsFunc) dynamicinformer.DynamicSharedInformerFactory) {
+		newDynamicInformerFactory = orig
+	}(newDynamicInformerFactory)
+	newDynamicInformerFactory = func(client dynamic.Interface, defaultResync time.Duration, namespace string, tweak dynamicinformer.TweakListOptionsFunc) dynamicinformer.DynamicSharedInformerFactory {
+		resync = defaultResync
+		return dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, defaultResync, namespace, tweak)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Start returns once the factory is built and started
+
+	for _, period := range []time.Duration{time.Minute, 0} {
+		w := &RouteWatcher{
+			routeManager: NewRouteManager(),
+			client: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{TermiteRouteGVR: "TermiteRouteList", TermitePoolGVR: "TermitePoolList"}),
+			resyncPeriod: period,
+			logger:       zap.NewNop(),
+		}
+		_ = w.Start(ctx)
+		if resync < period || resync > period+period/10 {
+			t.Errorf("resync period %s passed to the informer factory, want %s plus jitter", resync, period)
+		}
+	}
+}
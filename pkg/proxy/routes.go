@@ -16,12 +16,22 @@
 package proxy
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"net/http"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/antflydb/termite/pkg/operator/api/routematch"
 )
 
 // Route represents a compiled TermiteRoute for fast matching
@@ -30,29 +40,64 @@ type Route struct {
 	Priority int32
 
 	// Compiled matchers
-	Operations     map[OperationType]bool
-	ModelPatterns  []*regexp.Regexp
-	HeaderMatchers map[string]*StringMatcher
-	SourceTables   map[string]bool
-	TimeWindow     *TimeWindow
+	Operations            map[OperationType]bool
+	ModelPatterns         []*regexp.Regexp
+	ExcludedModelPatterns []*regexp.Regexp // from "!pattern" entries
+	HeaderMatchers        map[string]*StringMatcher
+	SourceTables          map[string]bool
+	SourceNamespaces      map[string]bool
+	SourceServiceAccounts map[string]bool // "name" or "namespace/name"
+	TimeWindow            *TimeWindow
+
+	// Percentage canary matching: a request matches when the hash bucket of
+	// its HashHeader value (or model name) is below Percentage
+	Percentage *int32
+	HashHeader string
 
 	// Destinations
 	Destinations []Destination
 
+	// cumulativeWeights holds the running weight totals of Destinations,
+	// set by normalizeWeights when the route is a static traffic split
+	cumulativeWeights []int32
+
 	// Fallback
 	Fallback *Fallback
 
 	// Rate limiting state
 	RateLimiter *RateLimiter
 
-	// Retry config
-	RetryAttempts   int32
-	RetryTimeout    time.Duration
-	RetryOnStatuses map[int]bool
+	// Retry config: RetryAttempts retries after the first attempt, each
+	// attempt bounded by RetryTimeout (perTryTimeout, 0 for none)
+	RetryAttempts         int32
+	RetryTimeout          time.Duration
+	RetryOnStatuses       map[int]bool
+	RetryOnConnectFailure bool // connect-failure, refused-stream
+	RetryOnReset          bool // reset
+	RetryOnTimeout        bool // deadline-exceeded: an attempt hit RetryTimeout
+
+	// Headers modifies forwarded requests, nil when the route leaves them
+	// unchanged
+	Headers *HeaderTransform
+
+	// Stats, updated atomically while rm.mu is only read-locked
+	MatchedRequests   int64
+	lastMatch         int64 // Unix nanoseconds of the latest match, 0 if none
+	ActiveConnections int32 // In-flight requests across all destinations
+
+	// Warnings describes parts of the spec that failed to parse and were
+	// dropped, leaving the route degraded
+	Warnings []string
+}
 
-	// Stats
-	MatchedRequests int64
-	LastMatchTime   time.Time
+// IsCatchAll reports whether the route has no match conditions and so
+// matches every request. Catch-all routes are evaluated after all other
+// routes regardless of priority, giving unmatched requests a default
+// destination without shadowing more specific routes.
+func (r *Route) IsCatchAll() bool {
+	return len(r.Operations) == 0 && len(r.ModelPatterns) == 0 && len(r.ExcludedModelPatterns) == 0 &&
+		len(r.HeaderMatchers) == 0 && len(r.SourceTables) == 0 && len(r.SourceNamespaces) == 0 &&
+		len(r.SourceServiceAccounts) == 0 && r.TimeWindow == nil && r.Percentage == nil
 }
 
 // OperationType for matching
@@ -78,7 +123,11 @@ func (m *StringMatcher) Matches(value string) bool {
 	return false
 }
 
-// TimeWindow for time-based matching
+// TimeWindow for time-based matching. The window runs from the start time
+// (inclusive) to the end time (exclusive), UTC. An end before the start makes
+// an overnight window, which belongs to the day it starts on: with Days set,
+// the hours after midnight are active when the previous day is listed. A
+// window whose start equals its end is never active (rejected by the webhook).
 type TimeWindow struct {
 	StartHour   int
 	StartMinute int
@@ -90,45 +139,128 @@ type TimeWindow struct {
 func (tw *TimeWindow) IsActive(t time.Time) bool {
 	t = t.UTC()
 
-	// Check day of week
-	if len(tw.Days) > 0 {
-		if !tw.Days[int(t.Weekday())] {
-			return false
-		}
-	}
-
-	// Check time of day
 	currentMinutes := t.Hour()*60 + t.Minute()
 	startMinutes := tw.StartHour*60 + tw.StartMinute
 	endMinutes := tw.EndHour*60 + tw.EndMinute
+	day := int(t.Weekday())
 
+	var inWindow bool
 	if startMinutes <= endMinutes {
 		// Normal case: start before end (e.g., 09:00-17:00)
-		return currentMinutes >= startMinutes && currentMinutes < endMinutes
+		inWindow = currentMinutes >= startMinutes && currentMinutes < endMinutes
+	} else {
+		// Overnight case: end before start (e.g., 22:00-06:00). After
+		// midnight, the window started the previous day.
+		inWindow = currentMinutes >= startMinutes || currentMinutes < endMinutes
+		if currentMinutes < endMinutes {
+			day = (day + 6) % 7
+		}
 	}
-	// Overnight case: end before start (e.g., 22:00-06:00)
-	return currentMinutes >= startMinutes || currentMinutes < endMinutes
+	if !inWindow {
+		return false
+	}
+
+	// Check day of week
+	return len(tw.Days) == 0 || tw.Days[day]
 }
 
 // Destination represents a route destination
 type Destination struct {
-	Pool   string
+	Pool string
+	// Weight is the destination's share of traffic among eligible
+	// destinations. Zero-weight destinations only receive traffic when no
+	// weighted destination is eligible.
 	Weight int32
+	// Cost ranks destinations: only the lowest-cost eligible destinations
+	// receive traffic, and higher-cost ones are used only when no cheaper
+	// destination is eligible.
+	Cost int32
 
 	// Conditions
 	QueueDepthCondition *ThresholdCondition
 	ReplicaCondition    *ThresholdCondition
 	LatencyCondition    *ThresholdCondition
+	MetricCondition     *MetricCondition
 	RequireModelLoaded  bool
 	TimeCondition       *TimeWindow
 }
 
-// ThresholdCondition for numeric comparisons
+// hasConditions reports whether the destination sets any condition beyond
+// its pool having healthy endpoints
+func (d *Destination) hasConditions() bool {
+	return d.QueueDepthCondition != nil || d.ReplicaCondition != nil || d.LatencyCondition != nil ||
+		d.MetricCondition != nil || d.RequireModelLoaded || d.TimeCondition != nil
+}
+
+// normalizeWeights precomputes cumulative weights when the route is a static
+// traffic split: every weighted destination is unconditional and of the same
+// cost. Selection then reduces to a binary search over fixed thresholds, e.g.
+// weights [30, 30, 40] become [30, 60, 100]. Routes with conditional or
+// cost-tiered weighted destinations are left to per-request evaluation.
+func (r *Route) normalizeWeights() {
+	r.cumulativeWeights = staticWeights(r.Destinations)
+}
+
+// staticWeights returns the cumulative weights of a static traffic split, or
+// nil when destinations isn't one
+func staticWeights(destinations []Destination) []int32 {
+	cumulative := make([]int32, len(destinations))
+	var total, cost int32
+	for i := range destinations {
+		dest := &destinations[i]
+		if dest.Weight > 0 {
+			if dest.hasConditions() || (total > 0 && dest.Cost != cost) {
+				return nil
+			}
+			cost = dest.Cost
+			total += dest.Weight
+		}
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return nil
+	}
+	return cumulative
+}
+
+// pickWeighted returns the index of the first cumulative weight above a
+// random pick in [0, total), so each index is chosen in proportion to its
+// own weight. Zero-weight entries repeat the previous total and are never
+// chosen.
+func pickWeighted(cumulative []int32, random RandomSource) int {
+	pick := random.Int32N(cumulative[len(cumulative)-1])
+	return sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > pick })
+}
+
+// MetricCondition compares the value of a PromQL expression against a threshold
+type MetricCondition struct {
+	Query     string // "$pool" is replaced with the destination pool name
+	Threshold *ThresholdCondition
+}
+
+// ConditionUnit is the unit a ThresholdCondition value is expressed in
+type ConditionUnit string
+
+const (
+	// UnitCount marks a unitless count, used by queue depth and replica conditions
+	UnitCount ConditionUnit = ""
+
+	// UnitMilliseconds marks a latency threshold written with an "ms" suffix
+	UnitMilliseconds ConditionUnit = "ms"
+
+	// UnitSeconds marks a latency threshold written with an "s" suffix
+	UnitSeconds ConditionUnit = "s"
+)
+
+// ThresholdCondition for numeric comparisons. Value is kept in Unit, as
+// written in the route; use EvaluateDuration to compare latencies.
 type ThresholdCondition struct {
 	Operator string // ">", "<", ">=", "<=", "=="
 	Value    float64
+	Unit     ConditionUnit
 }
 
+// Evaluate compares value, which must already be in the condition's unit
 func (c *ThresholdCondition) Evaluate(value float64) bool {
 	switch c.Operator {
 	case ">":
@@ -145,6 +277,15 @@ func (c *ThresholdCondition) Evaluate(value float64) bool {
 	return false
 }
 
+// EvaluateDuration compares a latency against the condition, converting it
+// to the condition's unit. Conditions without a unit are treated as seconds.
+func (c *ThresholdCondition) EvaluateDuration(d time.Duration) bool {
+	if c.Unit == UnitMilliseconds {
+		return c.Evaluate(float64(d) / float64(time.Millisecond))
+	}
+	return c.Evaluate(d.Seconds())
+}
+
 // Fallback defines fallback behavior
 type Fallback struct {
 	Action       string // "queue", "reject", "redirect"
@@ -153,16 +294,62 @@ type Fallback struct {
 	StatusCode   int
 	Message      string
 	RetryAfter   int
+
+	// FailFast applies the fallback as soon as the route's primary
+	// destination, the one with the highest weight, has no healthy
+	// endpoints, instead of spilling over to the others
+	FailFast bool
+}
+
+// HeaderTransform modifies the headers of a routed request before it is
+// forwarded to the destination pool. Set is applied first, then Add, then
+// Remove, so a removed header never reaches the upstream.
+type HeaderTransform struct {
+	Set    http.Header
+	Add    http.Header
+	Remove []string // canonical header names
 }
 
-// RateLimiter implements token bucket rate limiting
+// Apply returns a copy of header with the transformation applied, or header
+// itself when h is nil
+func (h *HeaderTransform) Apply(header http.Header) http.Header {
+	if h == nil {
+		return header
+	}
+	out := header.Clone()
+	if out == nil {
+		out = make(http.Header)
+	}
+	for name, values := range h.Set {
+		out[name] = slices.Clone(values)
+	}
+	for name, values := range h.Add {
+		out[name] = append(out[name], values...)
+	}
+	for _, name := range h.Remove {
+		delete(out, name)
+	}
+	return out
+}
+
+// RateLimiter implements token bucket rate limiting.
+//
+// The global bucket is lock-free: it is tracked as a GCRA "theoretical
+// arrival time" in a single atomic, which is equivalent to a token bucket
+// of burstSize tokens refilled at rate. Per-model buckets live in a map and
+// are guarded by mu.
 type RateLimiter struct {
-	rate        float64
+	rate        float64 // tokens per second; may be below 1
 	burstSize   int
-	tokens      float64
-	lastUpdate  time.Time
 	perModel    bool
 	modelLimits map[string]*modelLimit
+	now         func() time.Time
+
+	// Global bucket state, in nanoseconds relative to start
+	start     time.Time
+	interval  int64        // nanoseconds per token
+	tolerance int64        // how far tat may run ahead of now (burstSize-1 tokens)
+	tat       atomic.Int64 // theoretical arrival time of the next request
 
 	mu sync.Mutex
 }
@@ -172,83 +359,449 @@ type modelLimit struct {
 	lastUpdate time.Time
 }
 
+// NewRateLimiter creates a limiter allowing rps requests per second
 func NewRateLimiter(rps int32, burst int32, perModel bool) *RateLimiter {
-	return &RateLimiter{
-		rate:        float64(rps),
+	return NewRateLimiterWithRate(float64(rps), burst, perModel)
+}
+
+// NewRateLimiterWithRate creates a limiter allowing a fractional number of
+// requests per second (e.g. 0.2 for one request every 5 seconds). The burst
+// is raised to at least 1 so that rates below 1 can still accumulate a token.
+func NewRateLimiterWithRate(rate float64, burst int32, perModel bool) *RateLimiter {
+	burst = max(burst, 1)
+
+	// Rates <= 0 never refill and always use the locked path
+	interval := int64(math.MaxInt64)
+	if rate > 0 {
+		interval = int64(min(float64(time.Second)/rate, math.MaxInt64))
+	}
+
+	rl := &RateLimiter{
+		rate:        rate,
 		burstSize:   int(burst),
-		tokens:      float64(burst),
-		lastUpdate:  time.Now(),
 		perModel:    perModel,
 		modelLimits: make(map[string]*modelLimit),
+		now:         time.Now,
+		start:       time.Now(),
+		interval:    interval,
+		tolerance:   saturatingMul(interval, int64(burst-1)),
 	}
+	// A tat in the distant past means the bucket starts full
+	rl.tat.Store(math.MinInt64)
+	return rl
 }
 
 func (rl *RateLimiter) Allow(model string) bool {
+	if !rl.perModel && rl.rate > 0 {
+		return rl.allowGlobal()
+	}
+	if !rl.perModel {
+		// A non-refilling global bucket shares the locked path under one key
+		model = ""
+	}
+
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
-	var tokens *float64
-	var lastUpdate *time.Time
-
-	if rl.perModel {
-		ml, exists := rl.modelLimits[model]
-		if !exists {
-			ml = &modelLimit{
-				tokens:     float64(rl.burstSize),
-				lastUpdate: now,
-			}
-			rl.modelLimits[model] = ml
+	now := rl.now()
+	ml, exists := rl.modelLimits[model]
+	if !exists {
+		ml = &modelLimit{
+			tokens:     float64(rl.burstSize),
+			lastUpdate: now,
 		}
-		tokens = &ml.tokens
-		lastUpdate = &ml.lastUpdate
-	} else {
-		tokens = &rl.tokens
-		lastUpdate = &rl.lastUpdate
+		rl.modelLimits[model] = ml
 	}
 
 	// Refill tokens
-	elapsed := now.Sub(*lastUpdate).Seconds()
-	*tokens += elapsed * rl.rate
-	if *tokens > float64(rl.burstSize) {
-		*tokens = float64(rl.burstSize)
+	elapsed := now.Sub(ml.lastUpdate).Seconds()
+	ml.tokens += elapsed * rl.rate
+	if ml.tokens > float64(rl.burstSize) {
+		ml.tokens = float64(rl.burstSize)
 	}
-	*lastUpdate = now
+	ml.lastUpdate = now
 
 	// Check if we have a token
-	if *tokens >= 1 {
-		*tokens--
+	if ml.tokens >= 1 {
+		ml.tokens--
 		return true
 	}
 	return false
 }
 
+// allowGlobal takes a token from the global bucket without locking. A request
+// is allowed while the theoretical arrival time is at most burstSize-1 tokens
+// ahead of now, i.e. while at least one token remains.
+func (rl *RateLimiter) allowGlobal() bool {
+	now := rl.now().Sub(rl.start).Nanoseconds()
+	for {
+		tat := rl.tat.Load()
+		next := max(tat, now)
+		if next-now > rl.tolerance {
+			return false
+		}
+		if rl.tat.CompareAndSwap(tat, saturatingAdd(next, rl.interval)) {
+			return true
+		}
+	}
+}
+
+// RateLimiterState is a snapshot of a rate limiter's token buckets
+type RateLimiterState struct {
+	Rate      float64 `json:"rate"` // Tokens per second
+	BurstSize int     `json:"burst_size"`
+	PerModel  bool    `json:"per_model"`
+
+	// Tokens left in the route-wide bucket, unset for per-model limiters
+	Tokens *float64 `json:"tokens,omitempty"`
+
+	// Models holds the tokens left in each model's bucket, for per-model
+	// limiters. Models that have not made a request have a full bucket.
+	Models map[string]float64 `json:"models,omitempty"`
+}
+
+// Tokens returns the tokens available to requests for model (any model, for
+// a route-wide limiter). Reading is side-effect free: refill is computed,
+// not applied, so the result does not depend on how often it is read.
+func (rl *RateLimiter) Tokens(model string) float64 {
+	now := rl.now()
+	if !rl.perModel && rl.rate > 0 {
+		return rl.globalTokens(now)
+	}
+	if !rl.perModel {
+		model = ""
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	ml, ok := rl.modelLimits[model]
+	if !ok {
+		return float64(rl.burstSize)
+	}
+	return rl.bucketTokens(ml, now)
+}
+
+// State returns a snapshot of the limiter's buckets
+func (rl *RateLimiter) State() RateLimiterState {
+	state := RateLimiterState{Rate: rl.rate, BurstSize: rl.burstSize, PerModel: rl.perModel}
+	if !rl.perModel {
+		tokens := rl.Tokens("")
+		state.Tokens = &tokens
+		return state
+	}
+
+	now := rl.now()
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	state.Models = make(map[string]float64, len(rl.modelLimits))
+	for model, ml := range rl.modelLimits {
+		state.Models[model] = rl.bucketTokens(ml, now)
+	}
+	return state
+}
+
+// globalTokens converts the global bucket's theoretical arrival time to a
+// token count: each token taken pushes tat one interval past now
+func (rl *RateLimiter) globalTokens(now time.Time) float64 {
+	// Compare before subtracting: an unused bucket's tat is math.MinInt64
+	tat, elapsed := rl.tat.Load(), now.Sub(rl.start).Nanoseconds()
+	if tat <= elapsed {
+		return float64(rl.burstSize)
+	}
+	return max(float64(rl.burstSize)-float64(tat-elapsed)/float64(rl.interval), 0)
+}
+
+// bucketTokens returns the tokens in a per-model bucket at now, including
+// refill since its last update. rl.mu must be held.
+func (rl *RateLimiter) bucketTokens(ml *modelLimit, now time.Time) float64 {
+	return min(ml.tokens+now.Sub(ml.lastUpdate).Seconds()*rl.rate, float64(rl.burstSize))
+}
+
+func saturatingAdd(a, b int64) int64 {
+	if b > 0 && a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int64) int64 {
+	if a != 0 && b > math.MaxInt64/a {
+		return math.MaxInt64
+	}
+	return a * b
+}
+
+// Headers identifying the source of a request. The namespace and service
+// account are only accepted from trusted networks (the caller's mesh sidecar
+// or gateway, see Config.TrustedSourceNetworks) and stripped otherwise.
+const (
+	HeaderSourceTable          = "X-Termite-Source-Table"
+	HeaderSourceNamespace      = "X-Termite-Source-Namespace"
+	HeaderSourceServiceAccount = "X-Termite-Source-Service-Account"
+)
+
 // RouteRequest contains information about a request for routing
 type RouteRequest struct {
-	Operation   OperationType
-	Model       string
-	Headers     map[string]string
-	SourceTable string
-	Timestamp   time.Time
+	Operation            OperationType
+	Model                string
+	Headers              map[string]string
+	SourceTable          string
+	SourceNamespace      string
+	SourceServiceAccount string
+	Timestamp            time.Time
+
+	// Context bounds work done while routing, such as a first-time metric
+	// query (optional, defaults to context.Background())
+	Context context.Context
+}
+
+// ctx returns the request's context, or context.Background() if unset
+func (r *RouteRequest) ctx() context.Context {
+	if r.Context != nil {
+		return r.Context
+	}
+	return context.Background()
 }
 
 // RouteManager manages all routes and performs matching
 type RouteManager struct {
-	routes []*Route // Sorted by priority (descending)
-	mu     sync.RWMutex
+	routes  []*Route // Sorted by priority (descending)
+	mu      sync.RWMutex
+	metrics MetricSource
+
+	// byOperation holds, per operation named by some route, the routes that
+	// can match it (that operation or no operation filter) in priority order.
+	// anyOperation holds only the unfiltered routes, for other operations.
+	byOperation  map[OperationType][]*Route
+	anyOperation []*Route
+
+	// random drives weighted destination selection
+	random RandomSource
+
+	// failoverCooldown keeps a route on its failover destination this long
+	// after its primary destinations were last unavailable (0 disables)
+	failoverCooldown time.Duration
+	failoverMu       sync.Mutex
+	failovers        map[string]failoverState // By route name
+
+	// slowStartWindow ramps a recovered destination from a small share of
+	// traffic up to its full weight over this long (0 disables)
+	slowStartWindow time.Duration
+	slowStartMu     sync.Mutex
+	destHealth      map[destinationKey]destinationHealth
+
+	// connections counts in-flight requests per route destination
+	connMu      sync.RWMutex
+	connections map[destinationKey]*int32
+
+	// poolWeights weighs pools for requests that no route matches, by
+	// TermitePool, as set by its spec.routing.weight
+	poolMu      sync.RWMutex
+	poolWeights map[poolKey]int32
+}
+
+// poolKey identifies a TermitePool
+type poolKey struct {
+	namespace, name string
+}
+
+// destinationKey identifies a destination of a route
+type destinationKey struct {
+	route string
+	pool  string
+}
+
+// destinationHealth tracks when a destination last became eligible
+type destinationHealth struct {
+	eligible bool
+	since    time.Time // When it recovered; zero once fully ramped or if never ineligible
+}
+
+// failoverState records the destination a route failed over to
+type failoverState struct {
+	pool  string    // Zero-weight destination pool, "" for the fallback redirect
+	until time.Time // End of the cooldown
+}
+
+// RandomSource supplies the random numbers used for weighted destination
+// selection. Implementations must be safe for concurrent use.
+type RandomSource interface {
+	// Int32N returns a number in [0, n)
+	Int32N(n int32) int32
+}
+
+// globalRandom uses the concurrency-safe top-level math/rand/v2 generator
+type globalRandom struct{}
+
+func (globalRandom) Int32N(n int32) int32 { return rand.Int32N(n) }
+
+// seededRandom serializes access to a seeded generator
+type seededRandom struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSeededRandomSource returns a RandomSource that produces the same
+// sequence for the same seed, for tests and deterministic replay
+func NewSeededRandomSource(seed uint64) RandomSource {
+	return &seededRandom{rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+func (s *seededRandom) Int32N(n int32) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Int32N(n)
+}
+
+// RouteManagerOption configures a RouteManager
+type RouteManagerOption func(*RouteManager)
+
+// WithRandomSource replaces the random source used for weighted destination
+// selection (default: the global math/rand/v2 generator)
+func WithRandomSource(source RandomSource) RouteManagerOption {
+	return func(rm *RouteManager) {
+		rm.random = source
+	}
 }
 
 // NewRouteManager creates a new RouteManager
-func NewRouteManager() *RouteManager {
-	return &RouteManager{
-		routes: make([]*Route, 0),
+func NewRouteManager(opts ...RouteManagerOption) *RouteManager {
+	rm := &RouteManager{
+		routes:      make([]*Route, 0),
+		byOperation: make(map[OperationType][]*Route),
+		random:      globalRandom{},
+		failovers:   make(map[string]failoverState),
+		destHealth:  make(map[destinationKey]destinationHealth),
+		connections: make(map[destinationKey]*int32),
+		poolWeights: make(map[poolKey]int32),
+	}
+	for _, opt := range opts {
+		opt(rm)
+	}
+	return rm
+}
+
+// SetMetricSource configures the source used to evaluate metric conditions
+func (rm *RouteManager) SetMetricSource(source MetricSource) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.metrics = source
+}
+
+// metricSource returns the configured metric source, nil if none
+func (rm *RouteManager) metricSource() MetricSource {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.metrics
+}
+
+// SetFailoverCooldown makes a route keep using the destination it failed
+// over to (a zero-weight destination or its fallback redirect) for cooldown
+// after its weighted destinations were last unavailable, so that a briefly
+// recovering primary does not bounce traffic back and forth. It must be
+// called before routes are matched.
+func (rm *RouteManager) SetFailoverCooldown(cooldown time.Duration) {
+	rm.failoverCooldown = cooldown
+}
+
+// SetSlowStartWindow makes destinations that become eligible again after
+// being ineligible (e.g. a pool recovering from an outage) receive a share of
+// traffic that grows linearly to their full weight over window, so that a
+// cold pool is not overwhelmed. It must be called before routes are matched.
+func (rm *RouteManager) SetSlowStartWindow(window time.Duration) {
+	rm.slowStartWindow = window
+}
+
+// DefaultPoolWeight is the default routing weight of a pool without a
+// configured weight
+const DefaultPoolWeight int32 = 100
+
+// SetPoolWeight sets the weight in default routing (0-100) of the pool in
+// namespace
+func (rm *RouteManager) SetPoolWeight(namespace, pool string, weight int32) {
+	rm.poolMu.Lock()
+	defer rm.poolMu.Unlock()
+	rm.poolWeights[poolKey{namespace, pool}] = min(max(weight, 0), 100)
+}
+
+// RemovePoolWeight restores the default weight of the pool in namespace
+func (rm *RouteManager) RemovePoolWeight(namespace, pool string) {
+	rm.poolMu.Lock()
+	defer rm.poolMu.Unlock()
+	delete(rm.poolWeights, poolKey{namespace, pool})
+}
+
+// poolWeight returns the weight of the pool named pool. Endpoints only
+// carry the pool name, so same-named pools in several namespaces share
+// their endpoints and the highest of their weights. The caller holds poolMu.
+func (rm *RouteManager) poolWeight(pool string) int32 {
+	weight, found := int32(0), false
+	for key, w := range rm.poolWeights {
+		if key.name == pool && (!found || w > weight) {
+			weight, found = w, true
+		}
+	}
+	if !found {
+		return DefaultPoolWeight
+	}
+	return weight
+}
+
+// RateLimits returns the state of each rate-limited route's limiter, by
+// route name
+func (rm *RouteManager) RateLimits() map[string]RateLimiterState {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	limits := make(map[string]RateLimiterState)
+	for _, route := range rm.routes {
+		if route.RateLimiter != nil {
+			limits[route.Name] = route.RateLimiter.State()
+		}
+	}
+	return limits
+}
+
+// SelectDefaultPool picks the pool for a request that no route matched,
+// choosing among the pools with healthy endpoints serving model in
+// proportion to their weights. It returns "" when no pool weights are
+// configured, or when no pool with a positive weight serves model, leaving
+// the request to be balanced across all endpoints serving the model.
+func (rm *RouteManager) SelectDefaultPool(model string, registry *ModelRegistry) string {
+	rm.poolMu.RLock()
+	defer rm.poolMu.RUnlock()
+	if len(rm.poolWeights) == 0 {
+		return ""
+	}
+
+	var pools []string
+	for _, ep := range registry.GetEndpointsForModel(model) {
+		if ep.Pool != "" && !slices.Contains(pools, ep.Pool) {
+			pools = append(pools, ep.Pool)
+		}
+	}
+	slices.Sort(pools)
+
+	cumulative := make([]int32, len(pools))
+	var total int32
+	for i, pool := range pools {
+		total += rm.poolWeight(pool)
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return ""
 	}
+	return pools[pickWeighted(cumulative, rm.random)]
 }
 
-// AddRoute adds a route (routes are re-sorted by priority)
+// AddRoute adds a route (routes are re-sorted by priority). The route must
+// not be modified afterwards; to change it, add a new Route with its name.
 func (rm *RouteManager) AddRoute(route *Route) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
+	// Routes built in code skip convertRoute. The weights are set before the
+	// route is published, as selection reads them without the lock.
+	route.normalizeWeights()
+	rm.clearFailover(route.Name)
 
 	// Remove existing route with same name
 	newRoutes := make([]*Route, 0, len(rm.routes)+1)
@@ -259,8 +812,12 @@ func (rm *RouteManager) AddRoute(route *Route) {
 	}
 	newRoutes = append(newRoutes, route)
 
-	// Sort by priority (descending), then by name (ascending) for stable ordering
+	// Sort catch-all routes last, then by priority (descending), then by name
+	// (ascending) for stable ordering
 	sort.Slice(newRoutes, func(i, j int) bool {
+		if ci, cj := newRoutes[i].IsCatchAll(), newRoutes[j].IsCatchAll(); ci != cj {
+			return cj
+		}
 		if newRoutes[i].Priority != newRoutes[j].Priority {
 			return newRoutes[i].Priority > newRoutes[j].Priority
 		}
@@ -268,12 +825,16 @@ func (rm *RouteManager) AddRoute(route *Route) {
 	})
 
 	rm.routes = newRoutes
+	rm.rebuildIndex()
 }
 
 // RemoveRoute removes a route by name
 func (rm *RouteManager) RemoveRoute(name string) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
+	rm.clearFailover(name)
+	rm.clearDestinationHealth(name)
+	rm.clearConnections(name)
 
 	newRoutes := make([]*Route, 0, len(rm.routes))
 	for _, r := range rm.routes {
@@ -282,6 +843,35 @@ func (rm *RouteManager) RemoveRoute(name string) {
 		}
 	}
 	rm.routes = newRoutes
+	rm.rebuildIndex()
+}
+
+// rebuildIndex recomputes the operation index from rm.routes, preserving
+// priority order. Callers must hold rm.mu for writing.
+func (rm *RouteManager) rebuildIndex() {
+	byOperation := make(map[OperationType][]*Route)
+	for _, r := range rm.routes {
+		for op := range r.Operations {
+			byOperation[op] = nil
+		}
+	}
+
+	var anyOperation []*Route
+	for _, r := range rm.routes {
+		if len(r.Operations) == 0 {
+			anyOperation = append(anyOperation, r)
+			for op := range byOperation {
+				byOperation[op] = append(byOperation[op], r)
+			}
+			continue
+		}
+		for op := range r.Operations {
+			byOperation[op] = append(byOperation[op], r)
+		}
+	}
+
+	rm.byOperation = byOperation
+	rm.anyOperation = anyOperation
 }
 
 // Match finds the first matching route for a request
@@ -289,17 +879,92 @@ func (rm *RouteManager) Match(req *RouteRequest) *Route {
 	rm.mu.RLock()
 	defer rm.mu.RUnlock()
 
-	for _, route := range rm.routes {
+	route := rm.findRoute(req)
+	if route != nil {
+		recordMatch(route, req)
+	}
+	return route
+}
+
+// ErrMixedBatch is returned by MatchBatch when a batch required to match a
+// single route spans several routes
+var ErrMixedBatch = errors.New("batch items match different routes")
+
+// MatchBatch finds the matching route for each request of a batch, nil
+// where none matches. With requireSingleRoute, all requests must match the
+// same route (or all match none); otherwise ErrMixedBatch is returned and no
+// route statistics are updated.
+func (rm *RouteManager) MatchBatch(reqs []*RouteRequest, requireSingleRoute bool) ([]*Route, error) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	routes := make([]*Route, len(reqs))
+	for i, req := range reqs {
+		routes[i] = rm.findRoute(req)
+		if requireSingleRoute && routes[i] != routes[0] {
+			return nil, fmt.Errorf("%w: item 0 matches %s, item %d matches %s",
+				ErrMixedBatch, routeName(routes[0]), i, routeName(routes[i]))
+		}
+	}
+	for i, route := range routes {
+		if route != nil {
+			recordMatch(route, reqs[i])
+		}
+	}
+	return routes, nil
+}
+
+// findRoute returns the first route matching req. Callers must hold rm.mu.
+func (rm *RouteManager) findRoute(req *RouteRequest) *Route {
+	candidates, ok := rm.byOperation[req.Operation]
+	if !ok {
+		candidates = rm.anyOperation
+	}
+	for _, route := range candidates {
 		if rm.matchRoute(route, req) {
-			// Update stats
-			atomic.AddInt64(&route.MatchedRequests, 1)
-			route.LastMatchTime = req.Timestamp
 			return route
 		}
 	}
 	return nil
 }
 
+// recordMatch updates the match statistics of route. It runs concurrently
+// for the same route, so the fields are only accessed atomically.
+func recordMatch(route *Route, req *RouteRequest) {
+	atomic.AddInt64(&route.MatchedRequests, 1)
+	if req.Timestamp.IsZero() {
+		return
+	}
+	ts := req.Timestamp.UnixNano()
+	for {
+		last := atomic.LoadInt64(&route.lastMatch)
+		if ts <= last || atomic.CompareAndSwapInt64(&route.lastMatch, last, ts) {
+			return
+		}
+	}
+}
+
+// LastMatchTime returns the latest request timestamp that matched the
+// route, or the zero time if none has
+func (r *Route) LastMatchTime() time.Time {
+	if ns := atomic.LoadInt64(&r.lastMatch); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// Matches returns how many requests have matched the route
+func (r *Route) Matches() int64 {
+	return atomic.LoadInt64(&r.MatchedRequests)
+}
+
+func routeName(route *Route) string {
+	if route == nil {
+		return "no route"
+	}
+	return route.Name
+}
+
 func (rm *RouteManager) matchRoute(route *Route, req *RouteRequest) bool {
 	// Match operations (if specified)
 	if len(route.Operations) > 0 {
@@ -309,17 +974,8 @@ func (rm *RouteManager) matchRoute(route *Route, req *RouteRequest) bool {
 	}
 
 	// Match models (if specified)
-	if len(route.ModelPatterns) > 0 {
-		matched := false
-		for _, pattern := range route.ModelPatterns {
-			if pattern.MatchString(req.Model) {
-				matched = true
-				break
-			}
-		}
-		if !matched {
-			return false
-		}
+	if !route.matchModel(req.Model) {
+		return false
 	}
 
 	// Match headers (if specified)
@@ -337,6 +993,20 @@ func (rm *RouteManager) matchRoute(route *Route, req *RouteRequest) bool {
 		}
 	}
 
+	// Match source namespaces (if specified)
+	if len(route.SourceNamespaces) > 0 {
+		if !route.SourceNamespaces[req.SourceNamespace] {
+			return false
+		}
+	}
+
+	// Match source service accounts (if specified)
+	if len(route.SourceServiceAccounts) > 0 {
+		if !route.matchServiceAccount(req) {
+			return false
+		}
+	}
+
 	// Match time window (if specified)
 	if route.TimeWindow != nil {
 		if !route.TimeWindow.IsActive(req.Timestamp) {
@@ -344,44 +1014,242 @@ func (rm *RouteManager) matchRoute(route *Route, req *RouteRequest) bool {
 		}
 	}
 
+	// Match percentage (if specified)
+	if route.Percentage != nil {
+		key := req.Model
+		if value, ok := req.Headers[route.HashHeader]; ok && route.HashHeader != "" {
+			key = value
+		}
+		if percentageBucket(key) >= *route.Percentage {
+			return false
+		}
+	}
+
 	return true
 }
 
+// percentageBucket deterministically maps key to a bucket in [0, 100)
+func percentageBucket(key string) int32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int32(h.Sum32() % 100)
+}
+
 // SelectDestination chooses a destination from a matched route
 // based on weights and conditions
 func (rm *RouteManager) SelectDestination(route *Route, req *RouteRequest, registry *ModelRegistry) (*Destination, error) {
-	// Collect eligible destinations
+	return rm.selectDestination(route, req, registry, true)
+}
+
+// selectDestination implements SelectDestination. With record false it only
+// reads the slow-start and failover state, for dry runs.
+func (rm *RouteManager) selectDestination(route *Route, req *RouteRequest, registry *ModelRegistry, record bool) (*Destination, error) {
+	// Fail-fast routes go straight to their fallback when the primary pool
+	// is down, without evaluating (possibly slow) conditions of the others
+	if route.Fallback != nil && route.Fallback.FailFast && len(route.Destinations) > 0 &&
+		len(registry.GetEndpointsForPool(primaryDestination(route).Pool)) == 0 {
+		return nil, nil
+	}
+
+	dest, failover := rm.pickDestination(route, req, registry, record)
+	if rm.failoverCooldown <= 0 {
+		return dest, nil
+	}
+	return rm.stickyDestination(route, req, registry, dest, failover, record), nil
+}
+
+// primaryDestination returns the destination with the highest weight, the
+// first in spec order on ties. The route must have destinations.
+func primaryDestination(route *Route) *Destination {
+	primary := &route.Destinations[0]
+	for i := range route.Destinations[1:] {
+		if dest := &route.Destinations[i+1]; dest.Weight > primary.Weight {
+			primary = dest
+		}
+	}
+	return primary
+}
+
+// pickDestination chooses a destination by weight among the lowest-cost
+// destinations whose conditions hold. failover reports that no weighted
+// destination was eligible, so a zero-weight destination or the fallback
+// redirect is used.
+func (rm *RouteManager) pickDestination(route *Route, req *RouteRequest, registry *ModelRegistry, record bool) (*Destination, bool) {
+	// Static splits use the precomputed weights while all their pools are
+	// healthy. Slow start needs per-request eligibility, so it opts out.
+	if route.cumulativeWeights != nil && rm.slowStartWindow <= 0 && weightedPoolsHealthy(route, registry) {
+		dest := route.Destinations[pickWeighted(route.cumulativeWeights, rm.random)]
+		return &dest, false
+	}
+
+	// Collect eligible destinations, keeping zero-weight ones as fallbacks
 	eligible := make([]Destination, 0)
+	cumulative := make([]int32, 0, len(route.Destinations))
+	var fallback *Destination
 	totalWeight := int32(0)
+	hasWeighted := false
 
-	for _, dest := range route.Destinations {
+	for i := range route.Destinations {
+		dest := route.Destinations[i]
+		if dest.Weight > 0 {
+			hasWeighted = true
+		}
 		// Check conditions
-		if !rm.evaluateConditions(&dest, req, registry) {
+		ok := rm.evaluateConditions(&dest, req, registry)
+		weight := dest.Weight
+		if rm.slowStartWindow > 0 && weight > 0 {
+			weight = rm.rampWeight(route.Name, &dest, ok, req.Timestamp, record)
+		}
+		if !ok {
 			continue
 		}
 
+		if dest.Weight <= 0 {
+			if fallback == nil {
+				fallback = &dest
+			}
+			continue
+		}
+		// Cheaper destinations displace any costlier ones collected so far
+		if len(eligible) > 0 && dest.Cost != eligible[0].Cost {
+			if dest.Cost > eligible[0].Cost {
+				continue
+			}
+			eligible, cumulative, totalWeight = eligible[:0], cumulative[:0], 0
+		}
+		totalWeight += weight
 		eligible = append(eligible, dest)
-		totalWeight += dest.Weight
+		cumulative = append(cumulative, totalWeight)
 	}
 
 	if len(eligible) == 0 {
-		return nil, nil // No eligible destinations
+		// Zero-weight destinations get no proportional traffic but serve as
+		// a last resort, in declaration order; nil if none are eligible
+		return fallback, hasWeighted
+	}
+
+	return &eligible[pickWeighted(cumulative, rm.random)], false
+}
+
+// weightedPoolsHealthy reports whether the pool of every weighted
+// destination of route has healthy endpoints
+func weightedPoolsHealthy(route *Route, registry *ModelRegistry) bool {
+	for i := range route.Destinations {
+		dest := &route.Destinations[i]
+		if dest.Weight > 0 && len(registry.GetEndpointsForPool(dest.Pool)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// rampWeight returns the effective weight of dest: while within the
+// slow-start window after recovering, the weight scales with the time since
+// recovery (at least 1). With record set it also records whether dest is
+// eligible, which starts the window when it recovers.
+func (rm *RouteManager) rampWeight(route string, dest *Destination, eligible bool, now time.Time, record bool) int32 {
+	rm.slowStartMu.Lock()
+	defer rm.slowStartMu.Unlock()
+
+	key := destinationKey{route: route, pool: dest.Pool}
+	state, seen := rm.destHealth[key]
+	weight := dest.Weight
+	switch {
+	case !eligible:
+		state, weight = destinationHealth{}, 0
+	case !seen:
+		// No history, e.g. at startup: nothing to recover from
+		state = destinationHealth{eligible: true}
+	case !state.eligible:
+		state = destinationHealth{eligible: true, since: now}
+	}
+
+	if weight > 0 && !state.since.IsZero() {
+		if elapsed := now.Sub(state.since); elapsed >= rm.slowStartWindow {
+			state.since = time.Time{} // Fully ramped
+		} else {
+			weight = max(int32(float64(dest.Weight)*float64(elapsed)/float64(rm.slowStartWindow)), 1)
+		}
+	}
+
+	if record {
+		rm.destHealth[key] = state
+	}
+	return weight
+}
+
+// clearDestinationHealth forgets the slow-start state of a route
+func (rm *RouteManager) clearDestinationHealth(route string) {
+	rm.slowStartMu.Lock()
+	defer rm.slowStartMu.Unlock()
+	for key := range rm.destHealth {
+		if key.route == route {
+			delete(rm.destHealth, key)
+		}
+	}
+}
+
+// stickyDestination applies the failover cooldown to a picked destination.
+// Failing over starts (or extends) the cooldown; while it lasts, the route
+// keeps its failover destination as long as that remains eligible. With
+// record false the cooldown is applied but not started, extended or ended.
+func (rm *RouteManager) stickyDestination(route *Route, req *RouteRequest, registry *ModelRegistry, dest *Destination, failover, record bool) *Destination {
+	redirect := route.Fallback != nil && route.Fallback.Action == "redirect"
+	if failover {
+		if !record {
+			return dest
+		}
+		rm.failoverMu.Lock()
+		defer rm.failoverMu.Unlock()
+		switch {
+		case dest != nil:
+			rm.failovers[route.Name] = failoverState{pool: dest.Pool, until: req.Timestamp.Add(rm.failoverCooldown)}
+		case redirect:
+			rm.failovers[route.Name] = failoverState{until: req.Timestamp.Add(rm.failoverCooldown)}
+		}
+		return dest
 	}
 
-	// Weighted random selection
-	if len(eligible) == 1 {
-		return &eligible[0], nil
+	rm.failoverMu.Lock()
+	state, ok := rm.failovers[route.Name]
+	rm.failoverMu.Unlock()
+	if !ok {
+		return dest
+	}
+	if req.Timestamp.Before(state.until) {
+		if state.pool == "" {
+			if redirect {
+				return nil // Keep redirecting via the route's fallback
+			}
+		} else {
+			// Conditions may query Prometheus, so they are evaluated
+			// without holding failoverMu
+			for i := range route.Destinations {
+				sticky := route.Destinations[i]
+				if sticky.Pool == state.pool && rm.evaluateConditions(&sticky, req, registry) {
+					return &sticky
+				}
+			}
+		}
 	}
 
-	// Simple weighted selection (could use random for true distribution)
-	// For now, pick highest weight that's eligible
-	var best *Destination
-	for i := range eligible {
-		if best == nil || eligible[i].Weight > best.Weight {
-			best = &eligible[i]
+	// The cooldown expired or the failover destination is gone; return to
+	// normal selection unless another request failed over in the meantime
+	if record {
+		rm.failoverMu.Lock()
+		if rm.failovers[route.Name] == state {
+			delete(rm.failovers, route.Name)
 		}
+		rm.failoverMu.Unlock()
 	}
-	return best, nil
+	return dest
+}
+
+// clearFailover forgets the failover state of a route
+func (rm *RouteManager) clearFailover(name string) {
+	rm.failoverMu.Lock()
+	defer rm.failoverMu.Unlock()
+	delete(rm.failovers, name)
 }
 
 func (rm *RouteManager) evaluateConditions(dest *Destination, req *RouteRequest, registry *ModelRegistry) bool {
@@ -396,7 +1264,7 @@ func (rm *RouteManager) evaluateConditions(dest *Destination, req *RouteRequest,
 	var modelLoaded bool
 	for _, ep := range endpoints {
 		totalQueueDepth += atomic.LoadInt32(&ep.QueueDepth)
-		if _, exists := ep.Models[req.Model]; exists {
+		if !modelLoaded && registry.HasModel(ep, req.Model) {
 			modelLoaded = true
 		}
 	}
@@ -416,6 +1284,27 @@ func (rm *RouteManager) evaluateConditions(dest *Destination, req *RouteRequest,
 		}
 	}
 
+	// Check latency condition (skipped until the pool reports latency for the model)
+	if dest.LatencyCondition != nil {
+		if latency, ok := poolModelLatency(endpoints, req.Model); ok {
+			if !dest.LatencyCondition.EvaluateDuration(latency) {
+				return false
+			}
+		}
+	}
+
+	// Check metric condition (fails closed when the metric is unavailable)
+	if dest.MetricCondition != nil {
+		metrics := rm.metricSource()
+		if metrics == nil {
+			return false
+		}
+		value, err := metrics.Query(req.ctx(), dest.MetricCondition.Query)
+		if err != nil || !dest.MetricCondition.Threshold.Evaluate(value) {
+			return false
+		}
+	}
+
 	// Check model loaded condition
 	if dest.RequireModelLoaded && !modelLoaded {
 		return false
@@ -431,18 +1320,89 @@ func (rm *RouteManager) evaluateConditions(dest *Destination, req *RouteRequest,
 	return true
 }
 
-// CompileModelPattern compiles a model pattern with wildcards to a regex
+// poolModelLatency averages the reported latency of model across endpoints.
+// It returns false if no endpoint has reported a latency yet.
+func poolModelLatency(endpoints []*Endpoint, model string) (time.Duration, bool) {
+	var totalMs float64
+	var samples int
+	for _, ep := range endpoints {
+		if info, exists := ep.Models[model]; exists && info.AvgLatencyMs > 0 {
+			totalMs += info.AvgLatencyMs
+			samples++
+		}
+	}
+	if samples == 0 {
+		return 0, false
+	}
+	return time.Duration(totalMs / float64(samples) * float64(time.Millisecond)), true
+}
+
+// matchModel reports whether model matches at least one positive pattern and
+// no excluded pattern. A route with only excluded patterns matches any other model.
+func (r *Route) matchModel(model string) bool {
+	for _, pattern := range r.ExcludedModelPatterns {
+		if pattern.MatchString(model) {
+			return false
+		}
+	}
+	if len(r.ModelPatterns) == 0 {
+		return true
+	}
+	for _, pattern := range r.ModelPatterns {
+		if pattern.MatchString(model) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchServiceAccount reports whether the request's service account is listed
+// either by name alone or qualified with the request's namespace
+func (r *Route) matchServiceAccount(req *RouteRequest) bool {
+	if req.SourceServiceAccount == "" {
+		return false
+	}
+	if r.SourceServiceAccounts[req.SourceServiceAccount] {
+		return true
+	}
+	return req.SourceNamespace != "" &&
+		r.SourceServiceAccounts[req.SourceNamespace+"/"+req.SourceServiceAccount]
+}
+
+// ParseModelPattern splits a match.models entry into its wildcard pattern and
+// whether it is negated with a leading "!" (e.g. "!bge-large")
+func ParseModelPattern(entry string) (pattern string, negated bool) {
+	if rest, ok := strings.CutPrefix(entry, "!"); ok {
+		return rest, true
+	}
+	return entry, false
+}
+
+// RegexModelPatternPrefix marks a match.models entry as a raw regular
+// expression (e.g. "re:^(bge|gte)-.*$") rather than a wildcard pattern
+const RegexModelPatternPrefix = routematch.RegexModelPatternPrefix
+
+// CompileModelPattern compiles a model pattern with wildcards to an anchored
+// regex. Patterns with RegexModelPatternPrefix are compiled as written.
 func CompileModelPattern(pattern string) (*regexp.Regexp, error) {
-	// Escape regex special chars except *
-	escaped := regexp.QuoteMeta(pattern)
-	// Convert * to .*
-	regexPattern := strings.ReplaceAll(escaped, `\*`, `.*`)
-	// Anchor the pattern
-	regexPattern = "^" + regexPattern + "$"
-	return regexp.Compile(regexPattern)
+	return CompileRegex(routematch.ModelPatternExpr(pattern))
+}
+
+// ParseCountCondition parses a unitless count condition like ">50" or "<10",
+// as used for queue depth and available replicas
+func ParseCountCondition(s string) (*ThresholdCondition, error) {
+	cond, err := ParseThresholdCondition(s)
+	if err != nil {
+		return nil, err
+	}
+	if cond.Unit != UnitCount {
+		return nil, fmt.Errorf("count condition %q must not have a unit", s)
+	}
+	return cond, nil
 }
 
-// ParseThresholdCondition parses conditions like ">50", ">=100", "<10"
+// ParseThresholdCondition parses conditions like ">50", ">=100", "<10", or
+// latencies like ">100ms" and "<2s". The value is kept in the written unit.
 func ParseThresholdCondition(s string) (*ThresholdCondition, error) {
 	s = strings.TrimSpace(s)
 
@@ -471,33 +1431,24 @@ func ParseThresholdCondition(s string) (*ThresholdCondition, error) {
 
 	// Parse value (handle duration suffixes like "100ms")
 	valueStr = strings.TrimSpace(valueStr)
-	var value float64
-
+	unit := UnitCount
 	if before, ok := strings.CutSuffix(valueStr, "ms"); ok {
-		// Milliseconds
 		valueStr = before
-		var v float64
-		_, err := parseFloat(valueStr, &v)
-		if err != nil {
-			return nil, err
-		}
-		value = v / 1000 // Convert to seconds
+		unit = UnitMilliseconds
 	} else if before, ok := strings.CutSuffix(valueStr, "s"); ok {
 		valueStr = before
-		_, err := parseFloat(valueStr, &value)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		_, err := parseFloat(valueStr, &value)
-		if err != nil {
-			return nil, err
-		}
+		unit = UnitSeconds
+	}
+
+	var value float64
+	if _, err := parseFloat(valueStr, &value); err != nil {
+		return nil, err
 	}
 
 	return &ThresholdCondition{
 		Operator: operator,
 		Value:    value,
+		Unit:     unit,
 	}, nil
 }
 
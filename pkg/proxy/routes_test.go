@@ -0,0 +1,1342 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newModelRoute builds a route from match.models entries the same way the
+// route watcher does
+func newModelRoute(t *testing.T, entries ...string) *Route {
+	t.Helper()
+	route := &Route{Name: "default/test"}
+	for _, entry := range entries {
+		patternStr, negated := ParseModelPattern(entry)
+		pattern, err := CompileModelPattern(patternStr)
+		if err != nil {
+			t.Fatalf("compiling %q: %v", entry, err)
+		}
+		if negated {
+			route.ExcludedModelPatterns = append(route.ExcludedModelPatterns, pattern)
+		} else {
+			route.ModelPatterns = append(route.ModelPatterns, pattern)
+		}
+	}
+	return route
+}
+
+func TestMatchRoute_ModelPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		model    string
+		want     bool
+	}{
+		{"no patterns matches any model", nil, "bge-small", true},
+		{"wildcard include", []string{"bge-*"}, "bge-small", true},
+		{"wildcard include miss", []string{"bge-*"}, "mxbai-rerank", false},
+		{"exclude overrides include", []string{"bge-*", "!bge-large"}, "bge-large", false},
+		{"include with unrelated exclude", []string{"bge-*", "!bge-large"}, "bge-base", true},
+		{"exclude with wildcard", []string{"bge-*", "!*-large"}, "bge-large", false},
+		{"exclude only matches others", []string{"!bge-large"}, "mxbai-rerank", true},
+		{"exclude only rejects excluded", []string{"!bge-large"}, "bge-large", false},
+		{"negative does not satisfy include", []string{"bge-*", "!mxbai-*"}, "mxbai-rerank", false},
+		{"regex include", []string{"re:^(bge|gte)-.*$"}, "gte-base", true},
+		{"regex include miss", []string{"re:^(bge|gte)-.*$"}, "e5-base", false},
+		{"regex is not glob escaped", []string{"re:^bge-(small|base)$"}, "bge-large", false},
+		{"regex exclude", []string{"bge-*", "!re:-(large|xl)$"}, "bge-xl", false},
+	}
+
+	rm := NewRouteManager()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := newModelRoute(t, tt.patterns...)
+			req := &RouteRequest{Model: tt.model, Timestamp: time.Now()}
+			if got := rm.matchRoute(route, req); got != tt.want {
+				t.Errorf("matchRoute(%v, %q) = %v, want %v", tt.patterns, tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseModelPattern(t *testing.T) {
+	pattern, negated := ParseModelPattern("!bge-large")
+	if pattern != "bge-large" || !negated {
+		t.Errorf("ParseModelPattern(!bge-large) = %q, %v", pattern, negated)
+	}
+	pattern, negated = ParseModelPattern("bge-*")
+	if pattern != "bge-*" || negated {
+		t.Errorf("ParseModelPattern(bge-*) = %q, %v", pattern, negated)
+	}
+}
+
+func TestMatchRoute_Percentage(t *testing.T) {
+	percentage := int32(5)
+	route := &Route{
+		Name:       "default/canary",
+		Percentage: &percentage,
+		HashHeader: "X-Request-Id",
+	}
+	rm := NewRouteManager()
+
+	const total = 10000
+	matched := 0
+	for i := range total {
+		req := &RouteRequest{
+			Model:   "bge-small",
+			Headers: map[string]string{"X-Request-Id": fmt.Sprintf("req-%d", i)},
+		}
+		if rm.matchRoute(route, req) {
+			matched++
+		}
+	}
+	// Expect ~5% of requests; allow for hash variance
+	if matched < total*4/100 || matched > total*6/100 {
+		t.Errorf("matched %d of %d requests, want ~5%%", matched, total)
+	}
+
+	// A fixed request always gets the same decision
+	req := &RouteRequest{Model: "bge-small", Headers: map[string]string{"X-Request-Id": "req-42"}}
+	first := rm.matchRoute(route, req)
+	for range 100 {
+		if rm.matchRoute(route, req) != first {
+			t.Fatal("percentage match is not deterministic for a fixed request")
+		}
+	}
+}
+
+func TestMatchRoute_PercentageBounds(t *testing.T) {
+	rm := NewRouteManager()
+	req := &RouteRequest{Model: "bge-small"}
+
+	for _, tt := range []struct {
+		percentage int32
+		want       bool
+	}{
+		{0, false},
+		{100, true},
+	} {
+		route := &Route{Name: "default/canary", Percentage: &tt.percentage}
+		if got := rm.matchRoute(route, req); got != tt.want {
+			t.Errorf("percentage %d: matchRoute = %v, want %v", tt.percentage, got, tt.want)
+		}
+	}
+}
+
+func TestParseThresholdCondition_Units(t *testing.T) {
+	tests := []struct {
+		input string
+		op    string
+		value float64
+		unit  ConditionUnit
+	}{
+		{">100ms", ">", 100, UnitMilliseconds},
+		{"<2s", "<", 2, UnitSeconds},
+		{">=50", ">=", 50, UnitCount},
+	}
+	for _, tt := range tests {
+		cond, err := ParseThresholdCondition(tt.input)
+		if err != nil {
+			t.Fatalf("ParseThresholdCondition(%q): %v", tt.input, err)
+		}
+		if cond.Operator != tt.op || cond.Value != tt.value || cond.Unit != tt.unit {
+			t.Errorf("ParseThresholdCondition(%q) = %+v, want {%s %v %q}", tt.input, *cond, tt.op, tt.value, tt.unit)
+		}
+	}
+}
+
+func TestParseCountCondition_RejectsUnits(t *testing.T) {
+	if _, err := ParseCountCondition(">10"); err != nil {
+		t.Errorf("ParseCountCondition(>10): %v", err)
+	}
+	for _, input := range []string{">100ms", "<2s"} {
+		if _, err := ParseCountCondition(input); err == nil {
+			t.Errorf("ParseCountCondition(%q) should reject a unit", input)
+		}
+	}
+}
+
+func TestThresholdCondition_EvaluateDuration(t *testing.T) {
+	ms, _ := ParseThresholdCondition(">100ms")
+	if !ms.EvaluateDuration(150 * time.Millisecond) {
+		t.Error(">100ms should match 150ms")
+	}
+	if ms.EvaluateDuration(50 * time.Millisecond) {
+		t.Error(">100ms should not match 50ms")
+	}
+
+	s, _ := ParseThresholdCondition("<2s")
+	if !s.EvaluateDuration(1500 * time.Millisecond) {
+		t.Error("<2s should match 1500ms")
+	}
+	if s.EvaluateDuration(2500 * time.Millisecond) {
+		t.Error("<2s should not match 2500ms")
+	}
+}
+
+func TestTimeWindow_IsActive(t *testing.T) {
+	// 2025-01-03 is a Friday (day 5)
+	at := func(day int, hhmm string) time.Time {
+		tod, err := time.Parse("15:04", hhmm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Date(2025, 1, 3+day-5, tod.Hour(), tod.Minute(), 0, 0, time.UTC)
+	}
+	business := &TimeWindow{StartHour: 9, EndHour: 17}
+	overnight := &TimeWindow{StartHour: 22, EndHour: 6}
+	fridayNight := &TimeWindow{StartHour: 22, EndHour: 6, Days: map[int]bool{5: true}}
+	equal := &TimeWindow{StartHour: 9, EndHour: 9}
+
+	tests := []struct {
+		name   string
+		window *TimeWindow
+		at     time.Time
+		want   bool
+	}{
+		{"normal start is inclusive", business, at(5, "09:00"), true},
+		{"normal inside", business, at(5, "12:30"), true},
+		{"normal end is exclusive", business, at(5, "17:00"), false},
+		{"normal before start", business, at(5, "08:59"), false},
+		{"overnight before midnight", overnight, at(5, "23:00"), true},
+		{"overnight after midnight", overnight, at(6, "05:59"), true},
+		{"overnight end is exclusive", overnight, at(6, "06:00"), false},
+		{"overnight daytime", overnight, at(5, "12:00"), false},
+		{"overnight day before midnight", fridayNight, at(5, "22:00"), true},
+		{"overnight day after midnight", fridayNight, at(6, "02:00"), true},
+		{"overnight other day after midnight", fridayNight, at(5, "02:00"), false},
+		{"overnight other day before midnight", fridayNight, at(6, "23:00"), false},
+		{"equal start and end", equal, at(5, "09:00"), false},
+	}
+	for _, tt := range tests {
+		if got := tt.window.IsActive(tt.at); got != tt.want {
+			t.Errorf("%s: IsActive(%s) = %t, want %t", tt.name, tt.at.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateConditions_LatencyMilliseconds(t *testing.T) {
+	cond, err := ParseThresholdCondition(">100ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := &Destination{Pool: "slow", LatencyCondition: cond}
+	req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+	rm := NewRouteManager()
+
+	for _, tt := range []struct {
+		latencyMs float64
+		want      bool
+	}{
+		{150, true},
+		{50, false},
+		{0, true}, // no latency reported yet: condition is skipped
+	} {
+		registry := NewModelRegistry(time.Minute)
+		registry.RegisterEndpoint("10.0.0.1:8080", "slow", "")
+		registry.UpdateModels("10.0.0.1:8080", []string{"bge-small"})
+		registry.GetEndpoints()["10.0.0.1:8080"].Models["bge-small"].AvgLatencyMs = tt.latencyMs
+
+		if got := rm.evaluateConditions(dest, req, registry); got != tt.want {
+			t.Errorf("latency %vms against >100ms: got %v, want %v", tt.latencyMs, got, tt.want)
+		}
+	}
+}
+
+// newInventoryServer serves a Termite /api/models response
+func newInventoryServer(t *testing.T, body string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/models" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestEvaluateConditions_ModelLoadedInventory(t *testing.T) {
+	loaded := newInventoryServer(t, `{
+		"embedders": ["bge-small", "clip"],
+		"embedder_details": [{"name": "bge-small", "loaded": true}, {"name": "clip", "loaded": false}],
+		"rerankers": [], "chunkers": ["fixed"],
+		"aliases": {"bge-small": ["small"]}
+	}`)
+	other := newInventoryServer(t, `{"embedders": ["gte-base"], "rerankers": [], "chunkers": []}`)
+
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint(loaded, "warm", "")
+	registry.RegisterEndpoint(other, "cold", "")
+	for _, addr := range []string{loaded, other} {
+		if err := registry.RefreshEndpoint(context.Background(), addr); err != nil {
+			t.Fatalf("RefreshEndpoint(%s): %v", addr, err)
+		}
+	}
+
+	rm := NewRouteManager()
+	for _, tt := range []struct {
+		pool, model string
+		want        bool
+	}{
+		{"warm", "bge-small", true},
+		{"warm", "small", true}, // alias of a loaded model
+		{"warm", "clip", false}, // available but not loaded yet
+		{"cold", "bge-small", false},
+		{"cold", "gte-base", true},
+	} {
+		dest := &Destination{Pool: tt.pool, RequireModelLoaded: true}
+		req := &RouteRequest{Model: tt.model, Timestamp: time.Now()}
+		if got := rm.evaluateConditions(dest, req, registry); got != tt.want {
+			t.Errorf("modelLoaded for %s in pool %s: got %v, want %v", tt.model, tt.pool, got, tt.want)
+		}
+	}
+
+	// An inventory older than its TTL no longer counts
+	registry.SetModelInventoryTTL(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	dest := &Destination{Pool: "warm", RequireModelLoaded: true}
+	if rm.evaluateConditions(dest, &RouteRequest{Model: "bge-small", Timestamp: time.Now()}, registry) {
+		t.Error("modelLoaded passed with an expired inventory")
+	}
+}
+
+func TestRateLimiter_FractionalRate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rl := NewRateLimiterWithRate(0.2, 1, false)
+	rl.now = func() time.Time { return now }
+
+	// The initial burst allows one request immediately
+	if !rl.Allow("m") {
+		t.Fatal("expected the first request to use the burst token")
+	}
+
+	allowed := 0
+	for range 60 { // one attempt per second for a minute
+		now = now.Add(time.Second)
+		if rl.Allow("m") {
+			allowed++
+		}
+	}
+	if allowed != 12 {
+		t.Errorf("0.2 RPS allowed %d requests in 60s, want 12", allowed)
+	}
+
+	// Less than 5s after the last token, another request is rejected
+	now = now.Add(4 * time.Second)
+	if rl.Allow("m") {
+		t.Error("expected request within 5s of the last token to be rejected")
+	}
+	now = now.Add(time.Second)
+	if !rl.Allow("m") {
+		t.Error("expected request after 5s to be allowed")
+	}
+}
+
+func TestRateLimiter_FractionalRateBurst(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rl := NewRateLimiterWithRate(0.2, 3, true)
+	rl.now = func() time.Time { return now }
+
+	for i := range 3 {
+		if !rl.Allow("m") {
+			t.Fatalf("burst request %d should be allowed", i)
+		}
+	}
+	if rl.Allow("m") {
+		t.Fatal("request beyond burst should be rejected")
+	}
+
+	// A long idle period refills only up to the burst size
+	now = now.Add(time.Hour)
+	allowed := 0
+	for range 5 {
+		if rl.Allow("m") {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("after refill allowed %d requests, want burst of 3", allowed)
+	}
+}
+
+func TestRateLimiter_Tokens(t *testing.T) {
+	for _, perModel := range []bool{false, true} {
+		now := time.Unix(1700000000, 0)
+		rl := NewRateLimiterWithRate(2, 3, perModel)
+		rl.now = func() time.Time { return now }
+
+		if got := rl.Tokens("m"); got != 3 {
+			t.Fatalf("perModel=%t: initial tokens = %g, want a full bucket of 3", perModel, got)
+		}
+		for want := 2.0; want >= 0; want-- {
+			if !rl.Allow("m") {
+				t.Fatalf("perModel=%t: burst request rejected", perModel)
+			}
+			// Reading repeatedly does not change the bucket
+			for range 3 {
+				if got := rl.Tokens("m"); got != want {
+					t.Fatalf("perModel=%t: tokens after allow = %g, want %g", perModel, got, want)
+				}
+			}
+		}
+		if rl.Allow("m") {
+			t.Fatalf("perModel=%t: request allowed with an empty bucket", perModel)
+		}
+
+		// Refills at 2 tokens/s, up to the burst size
+		now = now.Add(750 * time.Millisecond)
+		if got := rl.Tokens("m"); got != 1.5 {
+			t.Errorf("perModel=%t: tokens after 750ms = %g, want 1.5", perModel, got)
+		}
+		if !rl.Allow("m") || rl.Allow("m") {
+			t.Errorf("perModel=%t: want exactly one request allowed with 1.5 tokens", perModel)
+		}
+		now = now.Add(time.Hour)
+		if got := rl.Tokens("m"); got != 3 {
+			t.Errorf("perModel=%t: tokens after an hour = %g, want 3", perModel, got)
+		}
+
+		state := rl.State()
+		if perModel {
+			if state.Tokens != nil || state.Models["m"] != 3 {
+				t.Errorf("per-model state = %+v, want model m at 3 tokens", state)
+			}
+		} else if state.Tokens == nil || *state.Tokens != 3 || state.Models != nil {
+			t.Errorf("global state = %+v, want 3 route-wide tokens", state)
+		}
+	}
+}
+
+func TestRateLimiter_FreshTokens(t *testing.T) {
+	// With the real clock, time since the limiter was created is positive;
+	// an unused route-wide bucket must still report itself full
+	rl := NewRateLimiter(10, 5, false)
+	time.Sleep(time.Millisecond)
+	if got := rl.Tokens(""); got != 5 {
+		t.Errorf("fresh limiter tokens = %g, want a full bucket of 5", got)
+	}
+	if state := rl.State(); state.Tokens == nil || *state.Tokens != 5 {
+		t.Errorf("fresh limiter state = %+v, want 5 route-wide tokens", state)
+	}
+}
+
+func TestRateLimiter_GlobalMatchesPerModel(t *testing.T) {
+	// The lock-free global bucket must make the same decisions as the locked
+	// per-model bucket for a single model
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	global := NewRateLimiterWithRate(3, 4, false)
+	global.now = clock
+	perModel := NewRateLimiterWithRate(3, 4, true)
+	perModel.now = clock
+
+	steps := []time.Duration{0, 0, 0, 0, 0, 100 * time.Millisecond, 300 * time.Millisecond,
+		0, 2 * time.Second, 0, 0, 0, 0, 10 * time.Millisecond, time.Second, 50 * time.Millisecond}
+	for i, step := range steps {
+		now = now.Add(step)
+		if g, m := global.Allow("m"), perModel.Allow("m"); g != m {
+			t.Fatalf("step %d: global Allow = %v, per-model Allow = %v", i, g, m)
+		}
+	}
+}
+
+func TestRateLimiter_GlobalConcurrent(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rl := NewRateLimiterWithRate(10, 100, false)
+	rl.now = func() time.Time { return now }
+
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for range 64 {
+		wg.Go(func() {
+			for range 1000 {
+				if rl.Allow("m") {
+					allowed.Add(1)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	// With the clock frozen only the burst can be spent, however contended
+	if got := allowed.Load(); got != 100 {
+		t.Errorf("allowed %d requests, want burst of 100", got)
+	}
+
+	// One second later exactly rate more tokens are available
+	now = now.Add(time.Second)
+	allowed.Store(0)
+	for range 64 {
+		wg.Go(func() {
+			for range 100 {
+				if rl.Allow("m") {
+					allowed.Add(1)
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if got := allowed.Load(); got != 10 {
+		t.Errorf("allowed %d requests after 1s, want 10", got)
+	}
+}
+
+func BenchmarkRateLimiterAllow(b *testing.B) {
+	for _, bc := range []struct {
+		name     string
+		perModel bool
+	}{
+		{"mutex", true},
+		{"atomic", false},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			rl := NewRateLimiterWithRate(1e9, 1000, bc.perModel)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					rl.Allow("m")
+				}
+			})
+		})
+	}
+}
+
+// newIndexedRoutes builds n routes spread across operations, with every
+// seventh route unfiltered, for comparing indexed and linear matching
+func newIndexedRoutes(t testing.TB, n int) *RouteManager {
+	t.Helper()
+	ops := []OperationType{"embed", "chunk", "rerank", "generate", "ner"}
+	rm := NewRouteManager()
+	for i := range n {
+		route := &Route{
+			Name:       fmt.Sprintf("default/route-%03d", i),
+			Priority:   int32(i % 10),
+			Operations: make(map[OperationType]bool),
+		}
+		if i%7 != 0 {
+			route.Operations[ops[i%len(ops)]] = true
+			if i%3 == 0 {
+				route.Operations[ops[(i+1)%len(ops)]] = true
+			}
+		}
+		pattern, err := CompileModelPattern(fmt.Sprintf("model-%d-*", i%20))
+		if err != nil {
+			t.Fatal(err)
+		}
+		route.ModelPatterns = []*regexp.Regexp{pattern}
+		rm.AddRoute(route)
+	}
+	return rm
+}
+
+// matchLinear is the unindexed reference for RouteManager.Match
+func matchLinear(rm *RouteManager, req *RouteRequest) *Route {
+	for _, route := range rm.routes {
+		if rm.matchRoute(route, req) {
+			return route
+		}
+	}
+	return nil
+}
+
+func TestRouteManager_MatchIndexEqualsLinear(t *testing.T) {
+	rm := newIndexedRoutes(t, 200)
+	rm.RemoveRoute("default/route-014")
+	rm.RemoveRoute("default/route-021")
+
+	for _, op := range []OperationType{"embed", "chunk", "rerank", "generate", "ner", "unknown"} {
+		for m := range 22 {
+			req := &RouteRequest{Operation: op, Model: fmt.Sprintf("model-%d-x", m)}
+			want := matchLinear(rm, req)
+			if got := rm.Match(req); got != want {
+				t.Errorf("Match(%s, %s) = %v, want %v", op, req.Model, routeName(got), routeName(want))
+			}
+		}
+	}
+}
+
+// TestRouteManager_ConcurrentMatchStats matches one route from many
+// goroutines; run with -race to check the stats updates
+func TestRouteManager_ConcurrentMatchStats(t *testing.T) {
+	rm := NewRouteManager()
+	route := newModelRoute(t, "bge-*")
+	rm.AddRoute(route)
+
+	base := time.Unix(1700000000, 0)
+	const goroutines, perGoroutine = 8, 100
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Go(func() {
+			for i := range perGoroutine {
+				ts := base.Add(time.Duration(g*perGoroutine+i) * time.Millisecond)
+				if rm.Match(&RouteRequest{Model: "bge-small", Timestamp: ts}) != route {
+					t.Error("request did not match the route")
+					return
+				}
+				_ = route.LastMatchTime()
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := route.Matches(); got != goroutines*perGoroutine {
+		t.Errorf("Matches() = %d, want %d", got, goroutines*perGoroutine)
+	}
+	latest := base.Add((goroutines*perGoroutine - 1) * time.Millisecond)
+	if got := route.LastMatchTime(); !got.Equal(latest) {
+		t.Errorf("LastMatchTime() = %v, want the latest request time %v", got, latest)
+	}
+}
+
+func BenchmarkRouteManagerMatch(b *testing.B) {
+	rm := newIndexedRoutes(b, 500)
+	// Only lowest-priority routes match, so every candidate is evaluated
+	req := &RouteRequest{Operation: "embed", Model: "model-10-x"}
+
+	b.Run("linear", func(b *testing.B) {
+		for range b.N {
+			matchLinear(rm, req)
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		for range b.N {
+			rm.Match(req)
+		}
+	})
+}
+
+func TestRouteManager_CatchAllMatchesLast(t *testing.T) {
+	rm := NewRouteManager()
+	// The catch-all outranks the specific routes by priority but still sorts last
+	rm.AddRoute(&Route{Name: "default/catch-all", Priority: 1000, Destinations: []Destination{{Pool: "default"}}})
+	rm.AddRoute(newModelRoute(t, "bge-*"))
+	rm.AddRoute(&Route{Name: "default/rerank", Priority: 10, Operations: map[OperationType]bool{"rerank": true}})
+
+	if got := rm.routes[len(rm.routes)-1].Name; got != "default/catch-all" {
+		t.Errorf("last route = %s, want default/catch-all", got)
+	}
+
+	tests := []struct {
+		op    OperationType
+		model string
+		want  string
+	}{
+		{"embed", "bge-small", "default/test"},
+		{"rerank", "mxbai-rerank", "default/rerank"},
+		{"embed", "mxbai-embed", "default/catch-all"},
+		{"ner", "", "default/catch-all"},
+	}
+	for _, tt := range tests {
+		if got := rm.Match(&RouteRequest{Operation: tt.op, Model: tt.model}); routeName(got) != tt.want {
+			t.Errorf("Match(%s, %q) = %s, want %s", tt.op, tt.model, routeName(got), tt.want)
+		}
+	}
+}
+
+func TestRoute_IsCatchAll(t *testing.T) {
+	percentage := int32(10)
+	if !(&Route{}).IsCatchAll() {
+		t.Error("route without conditions is not a catch-all")
+	}
+	for name, route := range map[string]*Route{
+		"operations": {Operations: map[OperationType]bool{"embed": true}},
+		"models":     newModelRoute(t, "!bge-large"),
+		"headers":    {HeaderMatchers: map[string]*StringMatcher{"X-Tenant": {Exact: "a"}}},
+		"percentage": {Percentage: &percentage},
+		"timeWindow": {TimeWindow: &TimeWindow{}},
+	} {
+		if route.IsCatchAll() {
+			t.Errorf("route matching %s is a catch-all", name)
+		}
+	}
+}
+
+func TestSelectDestination_ZeroWeight(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	for i, pool := range []string{"standby", "small", "large", "spare"} {
+		registry.RegisterEndpoint(fmt.Sprintf("10.0.0.%d:8080", i+1), pool, "")
+	}
+	rm := NewRouteManager()
+	req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+
+	t.Run("mixed weights", func(t *testing.T) {
+		route := &Route{Destinations: []Destination{
+			{Pool: "standby", Weight: 0},
+			{Pool: "small", Weight: 25},
+			{Pool: "large", Weight: 75},
+		}}
+		counts := make(map[string]int)
+		for range 2000 {
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil {
+				t.Fatalf("SelectDestination = %v, %v", dest, err)
+			}
+			counts[dest.Pool]++
+		}
+		if counts["standby"] != 0 {
+			t.Errorf("zero-weight destination received %d requests", counts["standby"])
+		}
+		if counts["small"] == 0 || counts["large"] <= counts["small"] {
+			t.Errorf("traffic not split by weight: %v", counts)
+		}
+	})
+
+	t.Run("zero weight is last resort", func(t *testing.T) {
+		route := &Route{Destinations: []Destination{
+			{Pool: "missing", Weight: 100}, // no endpoints, so ineligible
+			{Pool: "standby", Weight: 0},
+		}}
+		dest, err := rm.SelectDestination(route, req, registry)
+		if err != nil || dest == nil || dest.Pool != "standby" {
+			t.Fatalf("SelectDestination = %v, %v; want standby", dest, err)
+		}
+	})
+
+	t.Run("all zero weight", func(t *testing.T) {
+		route := &Route{Destinations: []Destination{
+			{Pool: "standby", Weight: 0},
+			{Pool: "spare", Weight: 0},
+		}}
+		for range 20 {
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil || dest.Pool != "standby" {
+				t.Fatalf("SelectDestination = %v, %v; want first zero-weight destination", dest, err)
+			}
+		}
+	})
+
+	t.Run("none eligible", func(t *testing.T) {
+		route := &Route{Destinations: []Destination{{Pool: "missing", Weight: 0}}}
+		if dest, err := rm.SelectDestination(route, req, registry); err != nil || dest != nil {
+			t.Fatalf("SelectDestination = %v, %v; want nil", dest, err)
+		}
+	})
+}
+
+func TestSelectDestination_CostTiers(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	for i, pool := range []string{"spot-a", "spot-b", "on-demand"} {
+		registry.RegisterEndpoint(fmt.Sprintf("10.0.0.%d:8080", i+1), pool, "")
+	}
+	setQueueDepth := func(depth int32) {
+		for _, address := range []string{"10.0.0.1:8080", "10.0.0.2:8080"} {
+			registry.GetEndpoints()[address].QueueDepth = depth
+		}
+	}
+	healthy := &ThresholdCondition{Operator: "<", Value: 10}
+
+	routes, err := LoadRoutes(strings.NewReader(`
+apiVersion: antfly.io/v1alpha1
+kind: TermiteRoute
+metadata:
+  name: spot-first
+spec:
+  route:
+    - pool: on-demand
+      weight: 100
+      cost: 10
+    - pool: spot-a
+      weight: 50
+      condition:
+        queueDepth: "<10"
+    - pool: spot-b
+      weight: 50
+      condition:
+        queueDepth: "<10"
+`), zap.NewNop())
+	if err != nil || len(routes) != 1 {
+		t.Fatalf("LoadRoutes = %v, %v", routes, err)
+	}
+	route := routes[0]
+	if route.Destinations[0].Cost != 10 || route.Destinations[1].Cost != 0 {
+		t.Fatalf("costs = %d, %d; want 10, 0", route.Destinations[0].Cost, route.Destinations[1].Cost)
+	}
+	if *route.Destinations[1].QueueDepthCondition != *healthy {
+		t.Fatalf("spot condition = %+v", route.Destinations[1].QueueDepthCondition)
+	}
+
+	rm := NewRouteManager()
+	req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+	selectPools := func() map[string]int {
+		counts := make(map[string]int)
+		for range 500 {
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil {
+				t.Fatalf("SelectDestination = %v, %v", dest, err)
+			}
+			counts[dest.Pool]++
+		}
+		return counts
+	}
+
+	t.Run("spot while healthy", func(t *testing.T) {
+		setQueueDepth(2)
+		counts := selectPools()
+		if counts["on-demand"] != 0 {
+			t.Errorf("on-demand received %d requests while spot was healthy", counts["on-demand"])
+		}
+		if counts["spot-a"] == 0 || counts["spot-b"] == 0 {
+			t.Errorf("spot traffic not split by weight: %v", counts)
+		}
+	})
+
+	t.Run("on-demand when spot saturated", func(t *testing.T) {
+		setQueueDepth(50)
+		if counts := selectPools(); counts["on-demand"] != 500 {
+			t.Errorf("counts = %v, want all on on-demand", counts)
+		}
+	})
+}
+
+// sequenceSource returns a fixed sequence of picks, wrapping around
+type sequenceSource struct {
+	mu    sync.Mutex
+	picks []int32
+	next  int
+}
+
+func (s *sequenceSource) Int32N(n int32) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pick := s.picks[s.next%len(s.picks)] % n
+	s.next++
+	return pick
+}
+
+func TestSelectDestination_RandomSource(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	for i, pool := range []string{"small", "large", "canary"} {
+		registry.RegisterEndpoint(fmt.Sprintf("10.0.0.%d:8080", i+1), pool, "")
+	}
+	req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+	route := &Route{Destinations: []Destination{
+		{Pool: "small", Weight: 20},
+		{Pool: "large", Weight: 75},
+		{Pool: "canary", Weight: 5},
+	}}
+
+	t.Run("fixed sequence", func(t *testing.T) {
+		rm := NewRouteManager(WithRandomSource(&sequenceSource{picks: []int32{0, 19, 20, 94, 95, 99}}))
+		want := []string{"small", "small", "large", "large", "canary", "canary"}
+		for i, pool := range want {
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil {
+				t.Fatalf("SelectDestination = %v, %v", dest, err)
+			}
+			if dest.Pool != pool {
+				t.Errorf("selection %d = %s, want %s", i, dest.Pool, pool)
+			}
+		}
+	})
+
+	t.Run("same seed replays", func(t *testing.T) {
+		sequence := func() []string {
+			rm := NewRouteManager(WithRandomSource(NewSeededRandomSource(42)))
+			pools := make([]string, 50)
+			for i := range pools {
+				dest, err := rm.SelectDestination(route, req, registry)
+				if err != nil || dest == nil {
+					t.Fatalf("SelectDestination = %v, %v", dest, err)
+				}
+				pools[i] = dest.Pool
+			}
+			return pools
+		}
+		first, second := sequence(), sequence()
+		for i := range first {
+			if first[i] != second[i] {
+				t.Fatalf("selection %d differs between runs: %s vs %s", i, first[i], second[i])
+			}
+		}
+	})
+}
+
+func TestConvertRoute_NormalizesWeights(t *testing.T) {
+	convert := func(dests ...map[string]any) *Route {
+		t.Helper()
+		route := make([]any, len(dests))
+		for i, d := range dests {
+			route[i] = d
+		}
+		r, err := convertRoute(&unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"namespace": "default", "name": "split"},
+			"spec":     map[string]any{"route": route},
+		}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("convertRoute: %v", err)
+		}
+		return r
+	}
+
+	t.Run("static split", func(t *testing.T) {
+		r := convert(
+			map[string]any{"pool": "a", "weight": int64(30)},
+			map[string]any{"pool": "b", "weight": int64(30)},
+			map[string]any{"pool": "c", "weight": int64(40)},
+		)
+		if got := fmt.Sprint(r.cumulativeWeights); got != "[30 60 100]" {
+			t.Errorf("cumulativeWeights = %s, want [30 60 100]", got)
+		}
+	})
+
+	t.Run("zero weight repeats total", func(t *testing.T) {
+		r := convert(
+			map[string]any{"pool": "a", "weight": int64(50)},
+			map[string]any{"pool": "spare", "weight": int64(0), "condition": map[string]any{"modelLoaded": true}},
+			map[string]any{"pool": "b", "weight": int64(50)},
+		)
+		if got := fmt.Sprint(r.cumulativeWeights); got != "[50 50 100]" {
+			t.Errorf("cumulativeWeights = %s, want [50 50 100]", got)
+		}
+	})
+
+	t.Run("conditional destinations evaluated per request", func(t *testing.T) {
+		r := convert(
+			map[string]any{"pool": "a", "weight": int64(50), "condition": map[string]any{"queueDepth": "<10"}},
+			map[string]any{"pool": "b", "weight": int64(50)},
+		)
+		if r.cumulativeWeights != nil {
+			t.Errorf("cumulativeWeights = %v, want nil", r.cumulativeWeights)
+		}
+	})
+
+	t.Run("cost tiers evaluated per request", func(t *testing.T) {
+		r := convert(
+			map[string]any{"pool": "spot", "weight": int64(100)},
+			map[string]any{"pool": "on-demand", "weight": int64(100), "cost": int64(10)},
+		)
+		if r.cumulativeWeights != nil {
+			t.Errorf("cumulativeWeights = %v, want nil", r.cumulativeWeights)
+		}
+	})
+
+	t.Run("out of range weight clamped", func(t *testing.T) {
+		r := convert(
+			map[string]any{"pool": "a", "weight": int64(-5)},
+			map[string]any{"pool": "b", "weight": int64(250)},
+		)
+		if r.Destinations[0].Weight != 0 || r.Destinations[1].Weight != 100 {
+			t.Errorf("weights = %d, %d; want 0, 100", r.Destinations[0].Weight, r.Destinations[1].Weight)
+		}
+		if len(r.Warnings) != 2 {
+			t.Errorf("warnings = %v, want 2", r.Warnings)
+		}
+	})
+}
+
+func TestAddRoute_ConcurrentSelection(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "a", "")
+	registry.RegisterEndpoint("10.0.0.2:8080", "b", "")
+	newSplit := func() *Route {
+		return &Route{Name: "default/split", Destinations: []Destination{
+			{Pool: "a", Weight: 50},
+			{Pool: "b", Weight: 50},
+		}}
+	}
+	rm := NewRouteManager()
+	rm.AddRoute(newSplit())
+
+	// Run with -race: updating the route must not race with selecting it
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range 100 {
+			rm.AddRoute(newSplit())
+		}
+	})
+	for range 4 {
+		wg.Go(func() {
+			for range 100 {
+				req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+				route := rm.Match(req)
+				if route == nil {
+					t.Error("Match = nil")
+					return
+				}
+				if _, err := rm.SelectDestination(route, req, registry); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestSelectDestination_NormalizedWeights(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	for i, pool := range []string{"a", "b", "c"} {
+		registry.RegisterEndpoint(fmt.Sprintf("10.0.0.%d:8080", i+1), pool, "")
+	}
+	req := &RouteRequest{Model: "bge-small", Timestamp: time.Now()}
+	route := &Route{Name: "default/split", Destinations: []Destination{
+		{Pool: "a", Weight: 30},
+		{Pool: "b", Weight: 30},
+		{Pool: "c", Weight: 40},
+	}}
+	rm := NewRouteManager(WithRandomSource(&sequenceSource{picks: []int32{0, 29, 30, 59, 60, 99}}))
+	rm.AddRoute(route)
+	if got := fmt.Sprint(route.cumulativeWeights); got != "[30 60 100]" {
+		t.Fatalf("cumulativeWeights = %s, want [30 60 100]", got)
+	}
+
+	t.Run("thresholds", func(t *testing.T) {
+		want := []string{"a", "a", "b", "b", "c", "c"}
+		for i, pool := range want {
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil {
+				t.Fatalf("SelectDestination = %v, %v", dest, err)
+			}
+			if dest.Pool != pool {
+				t.Errorf("selection %d = %s, want %s", i, dest.Pool, pool)
+			}
+		}
+	})
+
+	t.Run("unhealthy pool excluded", func(t *testing.T) {
+		registry.UnregisterEndpoint("10.0.0.2:8080")
+		counts := make(map[string]int)
+		for range 6 {
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil {
+				t.Fatalf("SelectDestination = %v, %v", dest, err)
+			}
+			counts[dest.Pool]++
+		}
+		if counts["b"] != 0 || counts["a"] == 0 || counts["c"] == 0 {
+			t.Errorf("counts = %v, want a and c only", counts)
+		}
+	})
+}
+
+func TestMatchRoute_SourceIdentity(t *testing.T) {
+	route, err := convertRoute(&unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"namespace": "default", "name": "indexer-only"},
+		"spec": map[string]any{
+			"match": map[string]any{
+				"source": map[string]any{
+					"namespaces":      []any{"search", "batch"},
+					"serviceAccounts": []any{"indexer", "batch/backfill"},
+				},
+			},
+		},
+	}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("convertRoute: %v", err)
+	}
+	rm := NewRouteManager()
+
+	tests := []struct {
+		namespace      string
+		serviceAccount string
+		want           bool
+	}{
+		{"search", "indexer", true},
+		{"batch", "indexer", true},
+		{"batch", "backfill", true},
+		{"search", "backfill", false}, // Qualified with another namespace
+		{"search", "query-api", false},
+		{"search", "", false}, // No identity
+		{"other", "indexer", false},
+		{"", "indexer", false},
+	}
+	for _, tt := range tests {
+		req := &RouteRequest{SourceNamespace: tt.namespace, SourceServiceAccount: tt.serviceAccount}
+		if got := rm.matchRoute(route, req); got != tt.want {
+			t.Errorf("matchRoute(namespace=%q, serviceAccount=%q) = %v, want %v",
+				tt.namespace, tt.serviceAccount, got, tt.want)
+		}
+	}
+}
+
+func TestSelectDestination_FailoverCooldown(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "primary", "")
+	registry.RegisterEndpoint("10.0.0.2:8080", "standby", "")
+
+	rm := NewRouteManager()
+	rm.SetFailoverCooldown(30 * time.Second)
+	route := &Route{Name: "default/sticky", Destinations: []Destination{
+		{Pool: "primary", Weight: 100},
+		{Pool: "standby", Weight: 0},
+	}}
+	start := time.Now()
+
+	selectAt := func(offset time.Duration) string {
+		t.Helper()
+		req := &RouteRequest{Model: "bge-small", Timestamp: start.Add(offset)}
+		dest, err := rm.SelectDestination(route, req, registry)
+		if err != nil || dest == nil {
+			t.Fatalf("SelectDestination at %s = %v, %v", offset, dest, err)
+		}
+		return dest.Pool
+	}
+
+	if pool := selectAt(0); pool != "primary" {
+		t.Fatalf("healthy primary: selected %s", pool)
+	}
+
+	// Primary flaps: down, briefly up, down, up again
+	registry.markUnhealthy("10.0.0.1:8080")
+	if pool := selectAt(time.Second); pool != "standby" {
+		t.Fatalf("primary down: selected %s, want standby", pool)
+	}
+	registry.markHealthy("10.0.0.1:8080")
+	if pool := selectAt(2 * time.Second); pool != "standby" {
+		t.Errorf("primary recovered within cooldown: selected %s, want standby", pool)
+	}
+	registry.markUnhealthy("10.0.0.1:8080")
+	if pool := selectAt(10 * time.Second); pool != "standby" {
+		t.Errorf("primary down again: selected %s, want standby", pool)
+	}
+	registry.markHealthy("10.0.0.1:8080")
+
+	// The cooldown runs from the last time the primary was unavailable
+	if pool := selectAt(39 * time.Second); pool != "standby" {
+		t.Errorf("within cooldown of last failover: selected %s, want standby", pool)
+	}
+	if pool := selectAt(40 * time.Second); pool != "primary" {
+		t.Errorf("after cooldown: selected %s, want primary", pool)
+	}
+	if pool := selectAt(41 * time.Second); pool != "primary" {
+		t.Errorf("after cooldown: selected %s, want primary", pool)
+	}
+}
+
+func TestSelectDestination_FailoverCooldownRedirect(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "primary", "")
+
+	rm := NewRouteManager()
+	rm.SetFailoverCooldown(30 * time.Second)
+	route := &Route{
+		Name:         "default/redirect",
+		Destinations: []Destination{{Pool: "primary", Weight: 100}},
+		Fallback:     &Fallback{Action: "redirect", RedirectPool: "overflow"},
+	}
+	start := time.Now()
+	req := func(offset time.Duration) *RouteRequest {
+		return &RouteRequest{Model: "bge-small", Timestamp: start.Add(offset)}
+	}
+
+	registry.markUnhealthy("10.0.0.1:8080")
+	if dest, _ := rm.SelectDestination(route, req(0), registry); dest != nil {
+		t.Fatalf("primary down: selected %s, want fallback redirect", dest.Pool)
+	}
+	registry.markHealthy("10.0.0.1:8080")
+	if dest, _ := rm.SelectDestination(route, req(5*time.Second), registry); dest != nil {
+		t.Errorf("primary recovered within cooldown: selected %s, want fallback redirect", dest.Pool)
+	}
+	if dest, _ := rm.SelectDestination(route, req(30*time.Second), registry); dest == nil || dest.Pool != "primary" {
+		t.Errorf("after cooldown: selected %v, want primary", dest)
+	}
+
+	// Without a cooldown traffic returns to the primary immediately
+	rm.SetFailoverCooldown(0)
+	registry.markUnhealthy("10.0.0.1:8080")
+	_, _ = rm.SelectDestination(route, req(31*time.Second), registry)
+	registry.markHealthy("10.0.0.1:8080")
+	if dest, _ := rm.SelectDestination(route, req(32*time.Second), registry); dest == nil || dest.Pool != "primary" {
+		t.Errorf("no cooldown: selected %v, want primary", dest)
+	}
+}
+
+// lockCheckingSource reports whether metric queries run while the route
+// manager holds its failover lock.
+type lockCheckingSource struct {
+	rm     *RouteManager
+	locked atomic.Bool
+}
+
+func (s *lockCheckingSource) Query(context.Context, string) (float64, error) {
+	if !s.rm.failoverMu.TryLock() {
+		s.locked.Store(true)
+		return 0, nil
+	}
+	s.rm.failoverMu.Unlock()
+	return 0, nil
+}
+
+func TestSelectDestination_StickyConditionsOutsideLock(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "primary", "")
+	registry.RegisterEndpoint("10.0.0.2:8080", "standby", "")
+
+	rm := NewRouteManager()
+	rm.SetFailoverCooldown(30 * time.Second)
+	source := &lockCheckingSource{rm: rm}
+	rm.SetMetricSource(source)
+	threshold, _ := ParseCountCondition("<1")
+	route := &Route{Name: "default/sticky", Destinations: []Destination{
+		{Pool: "primary", Weight: 100},
+		{Pool: "standby", Weight: 0, MetricCondition: &MetricCondition{Query: "up", Threshold: threshold}},
+	}}
+	start := time.Now()
+
+	registry.markUnhealthy("10.0.0.1:8080")
+	if dest, _ := rm.SelectDestination(route, &RouteRequest{Timestamp: start}, registry); dest == nil || dest.Pool != "standby" {
+		t.Fatalf("primary down: selected %v, want standby", dest)
+	}
+	registry.markHealthy("10.0.0.1:8080")
+	if dest, _ := rm.SelectDestination(route, &RouteRequest{Timestamp: start.Add(time.Second)}, registry); dest == nil || dest.Pool != "standby" {
+		t.Fatalf("within cooldown: selected %v, want standby", dest)
+	}
+	if source.locked.Load() {
+		t.Error("metric condition evaluated while holding the failover lock")
+	}
+}
+
+func TestSelectDestination_SlowStart(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	registry.RegisterEndpoint("10.0.0.1:8080", "warm", "")
+	registry.RegisterEndpoint("10.0.0.2:8080", "cold", "")
+
+	source := &sequenceSource{}
+	rm := NewRouteManager(WithRandomSource(source))
+	rm.SetSlowStartWindow(100 * time.Second)
+	route := &Route{Name: "default/ramp", Destinations: []Destination{
+		{Pool: "warm", Weight: 100},
+		{Pool: "cold", Weight: 100},
+	}}
+	start := time.Now()
+
+	// countAt sweeps every pick in [0, total) once at the given time and
+	// returns how many went to the cold pool
+	countAt := func(offset time.Duration, total int32) int {
+		t.Helper()
+		source.mu.Lock()
+		source.picks = make([]int32, total)
+		for i := range source.picks {
+			source.picks[i] = int32(i)
+		}
+		source.next = 0
+		source.mu.Unlock()
+
+		cold := 0
+		for range total {
+			req := &RouteRequest{Model: "bge-small", Timestamp: start.Add(offset)}
+			dest, err := rm.SelectDestination(route, req, registry)
+			if err != nil || dest == nil {
+				t.Fatalf("SelectDestination at %s = %v, %v", offset, dest, err)
+			}
+			if dest.Pool == "cold" {
+				cold++
+			}
+		}
+		return cold
+	}
+
+	// Destinations healthy from the start get their full weight
+	if cold := countAt(0, 200); cold != 100 {
+		t.Errorf("initially: cold got %d of 200, want 100", cold)
+	}
+
+	registry.markUnhealthy("10.0.0.2:8080")
+	if cold := countAt(time.Second, 100); cold != 0 {
+		t.Errorf("unhealthy: cold got %d of 100, want 0", cold)
+	}
+	registry.markHealthy("10.0.0.2:8080")
+
+	// Just recovered at 2s, the cold pool gets the minimum weight of 1
+	if cold := countAt(2*time.Second, 101); cold != 1 {
+		t.Errorf("just recovered: cold got %d of 101, want 1", cold)
+	}
+	// 10s into the ramp the cold pool has weight 10
+	if cold := countAt(12*time.Second, 110); cold != 10 {
+		t.Errorf("10%% into ramp: cold got %d of 110, want 10", cold)
+	}
+	// Half way through it has weight 50
+	if cold := countAt(52*time.Second, 150); cold != 50 {
+		t.Errorf("50%% into ramp: cold got %d of 150, want 50", cold)
+	}
+	// After the window it is back to full weight
+	if cold := countAt(102*time.Second, 200); cold != 100 {
+		t.Errorf("after ramp: cold got %d of 200, want 100", cold)
+	}
+}
+
+func TestSelectDefaultPool_Weights(t *testing.T) {
+	registry := NewModelRegistry(time.Minute)
+	for _, pool := range []string{"a", "b", "c"} {
+		registry.RegisterEndpoint(pool+"-0", pool, "")
+		registry.UpdateModels(pool+"-0", []string{"bge-small"})
+	}
+
+	// Every pick in [0, 100) is made once, so pools are chosen exactly in
+	// proportion to their weights
+	picks := make([]int32, 100)
+	for i := range picks {
+		picks[i] = int32(i)
+	}
+	rm := NewRouteManager(WithRandomSource(&sequenceSource{picks: picks}))
+
+	if pool := rm.SelectDefaultPool("bge-small", registry); pool != "" {
+		t.Errorf("SelectDefaultPool without pool weights = %q, want \"\"", pool)
+	}
+
+	rm.SetPoolWeight("default", "a", 75)
+	rm.SetPoolWeight("default", "b", 25)
+	rm.SetPoolWeight("default", "c", 0)
+	counts := make(map[string]int)
+	for range picks {
+		counts[rm.SelectDefaultPool("bge-small", registry)]++
+	}
+	if counts["a"] != 75 || counts["b"] != 25 || counts["c"] != 0 {
+		t.Errorf("selections = %v, want a:75 b:25", counts)
+	}
+
+	// Pools without a configured weight get the default weight
+	rm.SetPoolWeight("default", "a", 0)
+	rm.RemovePoolWeight("default", "b")
+	counts = make(map[string]int)
+	for range picks {
+		counts[rm.SelectDefaultPool("bge-small", registry)]++
+	}
+	if counts["b"] != 100 {
+		t.Errorf("selections = %v, want b:100", counts)
+	}
+
+	// Models no weighted pool serves are left to default balancing
+	if pool := rm.SelectDefaultPool("gte-base", registry); pool != "" {
+		t.Errorf("SelectDefaultPool for an unloaded model = %q, want \"\"", pool)
+	}
+	rm.SetPoolWeight("default", "b", 0)
+	if pool := rm.SelectDefaultPool("bge-small", registry); pool != "" {
+		t.Errorf("SelectDefaultPool with zero weights = %q, want \"\"", pool)
+	}
+
+	// Same-named pools in other namespaces keep their own weights
+	rm.SetPoolWeight("staging", "b", 50)
+	rm.RemovePoolWeight("staging", "b")
+	if pool := rm.SelectDefaultPool("bge-small", registry); pool != "" {
+		t.Errorf("SelectDefaultPool after removing another namespace's pool = %q, want \"\"", pool)
+	}
+}
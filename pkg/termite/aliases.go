@@ -0,0 +1,80 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+// ModelAliases maps alias names to canonical model names.
+type ModelAliases map[string]string
+
+// Resolve returns the canonical model name for name, or name itself when it
+// is not an alias. Aliases are not chained.
+func (a ModelAliases) Resolve(name string) string {
+	if canonical, ok := a[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// ByModel groups aliases by the canonical model they point at, restricted to
+// the given available models. Aliases of each model are sorted.
+func (a ModelAliases) ByModel(available ...[]string) map[string][]string {
+	known := modelSet(available...)
+
+	byModel := make(map[string][]string)
+	for alias, canonical := range a {
+		if known[canonical] {
+			byModel[canonical] = append(byModel[canonical], alias)
+		}
+	}
+	for _, aliases := range byModel {
+		slices.Sort(aliases)
+	}
+	return byModel
+}
+
+// warnUnresolved logs aliases whose target is not among the available models
+// and aliases that shadow a model of the same name. Requests for an
+// unresolved alias fail like any unknown model.
+func (a ModelAliases) warnUnresolved(logger *zap.Logger, available ...[]string) {
+	known := modelSet(available...)
+
+	for alias, canonical := range a {
+		if !known[canonical] {
+			logger.Warn("Model alias points at a model that is not loaded",
+				zap.String("alias", alias),
+				zap.String("model", canonical))
+		}
+		if known[alias] {
+			logger.Warn("Model alias shadows a loaded model of the same name",
+				zap.String("alias", alias),
+				zap.String("model", canonical))
+		}
+	}
+}
+
+func modelSet(lists ...[]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, names := range lists {
+		for _, name := range names {
+			set[name] = true
+		}
+	}
+	return set
+}
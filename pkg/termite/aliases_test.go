@@ -0,0 +1,106 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelAliases_Resolve(t *testing.T) {
+	aliases := ModelAliases{"text-embedding-3-small": "bge-small-en"}
+
+	assert.Equal(t, "bge-small-en", aliases.Resolve("text-embedding-3-small"))
+	assert.Equal(t, "bge-small-en", aliases.Resolve("bge-small-en"))
+	assert.Equal(t, "unknown", aliases.Resolve("unknown"))
+}
+
+func TestModelAliases_ByModel(t *testing.T) {
+	aliases := ModelAliases{
+		"text-embedding-3-small": "bge-small-en",
+		"ada-002":                "bge-small-en",
+		"rerank-english":         "mxbai-rerank",
+		"dangling":               "not-loaded",
+	}
+
+	byModel := aliases.ByModel([]string{"bge-small-en"}, []string{"mxbai-rerank"})
+	assert.Equal(t, map[string][]string{
+		"bge-small-en": {"ada-002", "text-embedding-3-small"},
+		"mxbai-rerank": {"rerank-english"},
+	}, byModel)
+}
+
+func TestTermiteNode_HandleApiEmbed_Alias(t *testing.T) {
+	embedder := &MockEmbedder{}
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+	node.modelAliases = ModelAliases{
+		"text-embedding-3-small": "bge-small-en",
+	}
+	handler := NewTermiteAPI(node.logger, node)
+
+	w := postEmbed(t, handler, "text-embedding-3-small")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp EmbedResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "text-embedding-3-small", resp.Model)
+	assert.Len(t, resp.Embeddings, 2)
+	assert.Equal(t, int32(1), embedder.GetCallCount())
+
+	// The canonical name shares the cache entry populated through the alias.
+	w = postEmbed(t, handler, "bge-small-en")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, int32(1), embedder.GetCallCount())
+}
+
+func TestTermiteNode_HandleApiEmbed_AliasToMissingModel(t *testing.T) {
+	embedder := &MockEmbedder{}
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+	node.modelAliases = ModelAliases{
+		"text-embedding-3-large": "bge-large-en",
+	}
+	handler := NewTermiteAPI(node.logger, node)
+
+	w := postEmbed(t, handler, "text-embedding-3-large")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "text-embedding-3-large (alias of bge-large-en)")
+	assert.Zero(t, embedder.GetCallCount())
+}
+
+func TestTermiteAPI_ListModels_Aliases(t *testing.T) {
+	node := newEmbedTestNode(t, "bge-small-en", &MockEmbedder{})
+	node.modelAliases = ModelAliases{
+		"text-embedding-3-small": "bge-small-en",
+		"text-embedding-3-large": "bge-large-en",
+	}
+	handler := NewTermiteAPI(node.logger, node)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/models", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp ModelsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Contains(t, resp.Embedders, "bge-small-en")
+	assert.Equal(t, map[string][]string{
+		"bge-small-en": {"text-embedding-3-small"},
+	}, resp.Aliases)
+}
@@ -22,6 +22,7 @@ import (
 	externalRef3 "github.com/antflydb/antfly-go/libaf/scraping"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // Defines values for ConfigModelStrategies.
@@ -31,6 +32,12 @@ const (
 	ConfigModelStrategiesLazy    ConfigModelStrategies = "lazy"
 )
 
+// Defines values for EmbedFusionMode.
+const (
+	EmbedFusionModeNative       EmbedFusionMode = "native"
+	EmbedFusionModeWeightedMean EmbedFusionMode = "weighted_mean"
+)
+
 // Defines values for GPUMode.
 const (
 	GPUModeAuto   GPUMode = "auto"
@@ -45,6 +52,31 @@ const (
 	ImageURLContentPartTypeImageUrl ImageURLContentPartType = "image_url"
 )
 
+// Defines values for ModelsResponseRerankerTypes.
+const (
+	ModelsResponseRerankerTypesBiEncoder    ModelsResponseRerankerTypes = "bi-encoder"
+	ModelsResponseRerankerTypesCrossEncoder ModelsResponseRerankerTypes = "cross-encoder"
+)
+
+// Defines values for RerankRequestMode.
+const (
+	RerankRequestModePairwise    RerankRequestMode = "pairwise"
+	RerankRequestModeSharedQuery RerankRequestMode = "shared_query"
+)
+
+// Defines values for RerankerModelInfoType.
+const (
+	RerankerModelInfoTypeBiEncoder    RerankerModelInfoType = "bi-encoder"
+	RerankerModelInfoTypeCrossEncoder RerankerModelInfoType = "cross-encoder"
+)
+
+// Defines values for SimilarityMetric.
+const (
+	SimilarityMetricCosine    SimilarityMetric = "cosine"
+	SimilarityMetricDot       SimilarityMetric = "dot"
+	SimilarityMetricEuclidean SimilarityMetric = "euclidean"
+)
+
 // Defines values for TextContentPartType.
 const (
 	TextContentPartTypeText TextContentPartType = "text"
@@ -83,6 +115,13 @@ type ChunkRequest struct {
 	// which includes provider selection and caching configuration.
 	Config ChunkConfig `json:"config,omitempty,omitzero"`
 
+	// EmbeddingModel Embedding model the chunks are destined for. When set,
+	// `token_counts` are computed with this model's tokenizer, so they
+	// match what the embedder will see. Defaults to the tokenizer of the
+	// chunking model. Streamed (`application/x-ndjson`) responses carry
+	// no token counts, so setting it when streaming is rejected with 400.
+	EmbeddingModel string `json:"embedding_model,omitempty,omitzero"`
+
 	// Text Text to chunk
 	Text string `json:"text"`
 }
@@ -97,14 +136,41 @@ type ChunkResponse struct {
 
 	// Model Chunking model actually used (may differ from requested if fallback occurred)
 	Model string `json:"model"`
+
+	// TokenCounts Number of tokens in each chunk, in the same order as `chunks`.
+	// Counted with the tokenizer of `embedding_model` when set,
+	// otherwise with that of the chunking model (BERT WordPiece for the
+	// built-in fixed chunkers), not a character heuristic.
+	TokenCounts []int `json:"token_counts"`
+
+	// TotalTokens Sum of `token_counts`
+	TotalTokens int `json:"total_tokens"`
+}
+
+// ChunkerModelInfo defines model for ChunkerModelInfo.
+type ChunkerModelInfo struct {
+	// Builtin Whether this is a built-in fixed-size strategy rather than a model from models_dir/chunkers/
+	Builtin bool `json:"builtin"`
+
+	// Name Model name as accepted by `/api/chunk`
+	Name string `json:"name"`
 }
 
 // Config defines model for Config.
 type Config struct {
+	// AdminToken Bearer token required by the `/admin/*` endpoints (e.g., `POST /admin/reload`).
+	// When empty (default), admin endpoints are disabled. Models can still be
+	// reloaded by sending SIGHUP to the process.
+	AdminToken string `json:"admin_token,omitempty,omitzero"`
+
 	// ApiUrl URL of the Termite embedding/chunking service
 	ApiUrl          string                             `json:"api_url"`
 	ContentSecurity externalRef3.ContentSecurityConfig `json:"content_security,omitempty,omitzero"`
-	Gpu             GPUMode                            `json:"gpu,omitempty,omitzero"`
+
+	// EmbedLimits Size limits for embed requests, checked before the model runs.
+	// Requests exceeding a limit receive 413 Request Entity Too Large naming the limit.
+	EmbedLimits EmbedLimits `json:"embed_limits,omitempty,omitzero"`
+	Gpu         GPUMode     `json:"gpu,omitempty,omitzero"`
 
 	// KeepAlive How long to keep models loaded in memory after last use (Ollama-compatible).
 	// Models are automatically unloaded after this duration of inactivity.
@@ -112,9 +178,20 @@ type Config struct {
 	// When set to "0" or omitted, models are loaded eagerly at startup and never unloaded (legacy behavior).
 	KeepAlive string `json:"keep_alive,omitempty,omitzero"`
 
+	// LivenessCheck Opt-in deep liveness check that periodically embeds a short text with a canary model.
+	// When the inference errors or hangs past the timeout failure_threshold times in a row,
+	// `/healthz` returns 503 so Kubernetes restarts the wedged pod. Disabled unless model is set.
+	LivenessCheck LivenessCheck `json:"liveness_check,omitempty,omitzero"`
+
 	// Log Logging configuration for Termite services
 	Log externalRef1.Config `json:"log,omitempty,omitzero"`
 
+	// MaxConcurrentPerModel Maximum number of concurrent inference requests per model, bounding GPU memory
+	// and CPU use when a burst targets a single model. Additional requests for that
+	// model are queued up to max_queued_per_model.
+	// Set to 0 for unlimited (default).
+	MaxConcurrentPerModel int `json:"max_concurrent_per_model,omitempty,omitzero"`
+
 	// MaxConcurrentRequests Maximum number of concurrent inference requests allowed.
 	// Additional requests will be queued up to max_queue_size.
 	// Set to 0 for unlimited (default).
@@ -137,6 +214,18 @@ type Config struct {
 	// Set to 0 for unlimited queue (default). Only effective when max_concurrent_requests > 0.
 	MaxQueueSize int `json:"max_queue_size,omitempty,omitzero"`
 
+	// MaxQueuedPerModel Maximum number of requests to queue per model when max_concurrent_per_model is reached.
+	// Further requests for that model receive 429 Too Many Requests with a Retry-After header.
+	// Set to 0 to reject immediately instead of queuing (default). Only effective when
+	// max_concurrent_per_model > 0.
+	MaxQueuedPerModel int `json:"max_queued_per_model,omitempty,omitzero"`
+
+	// ModelAliases Maps alias names to canonical embedder or reranker model names, so clients that
+	// hardcode a model name (e.g., an OpenAI model) are served by a locally loaded model.
+	// Aliases pointing at models that are not loaded are logged at startup and fail
+	// like any unknown model.
+	ModelAliases map[string]string `json:"model_aliases,omitempty,omitzero"`
+
 	// ModelStrategies Per-model loading strategy overrides. Maps model names to their loading strategy.
 	// Models not in this map use the default strategy based on keep_alive:
 	// - If keep_alive="0" (default): eager loading (load at startup, never unload)
@@ -157,17 +246,42 @@ type Config struct {
 	// Defaults to ~/.termite/models (set via viper). If not set, only built-in fixed chunking is available.
 	ModelsDir string `json:"models_dir,omitempty,omitzero"`
 
+	// ModelsManifest Path to a YAML models manifest, typically mounted from a ConfigMap, declaring the
+	// models to load at startup with their variants and aliases. Declared embedders are
+	// preloaded (and pinned when their strategy is "eager"), and their aliases are added
+	// to model_aliases. Settings in this config take precedence over the manifest.
+	ModelsManifest string `json:"models_manifest,omitempty,omitzero"`
+
+	// OtlpEndpoint OTLP/HTTP endpoint that receives OpenTelemetry traces (e.g., `http://localhost:4318`).
+	// When set, each embed, chunk and rerank request produces a span and incoming W3C
+	// `traceparent` headers are continued. When empty (default), tracing is disabled.
+	OtlpEndpoint string `json:"otlp_endpoint,omitempty,omitzero"`
+
 	// Preload List of model names to preload at startup (Ollama-compatible).
 	// These models are loaded immediately when Termite starts, avoiding first-request latency.
 	// Model names should match those in models_dir/embedders/ (e.g., "bge-small-en-v1.5").
 	// Only effective when keep_alive is non-zero (lazy loading mode).
 	Preload []string `json:"preload,omitempty,omitzero"`
 
-	// RequestTimeout Maximum time to wait for a request to complete, including queue wait time.
-	// Use Go duration format: "30s", "1m", "0" (no timeout, default).
+	// RequestTimeout Maximum time for an embed, chunk or rerank request to complete, including
+	// queue wait time and model inference. The model call is cancelled when the
+	// timeout expires. Use Go duration format: "30s" (default), "1m", "0" (no timeout).
 	// Requests exceeding this timeout receive 504 Gateway Timeout.
 	RequestTimeout string                   `json:"request_timeout,omitempty,omitzero"`
 	S3Credentials  externalRef2.Credentials `json:"s3_credentials,omitempty,omitzero"`
+
+	// TlsCertFile PEM certificate (chain) served by the API server. When set together with
+	// `tls_key_file`, Termite serves HTTPS. Both files are re-read when they change,
+	// so rotated certificates (e.g., from cert-manager) are picked up without a restart.
+	TlsCertFile string `json:"tls_cert_file,omitempty,omitzero"`
+
+	// TlsClientCaFile PEM bundle of CAs trusted to sign client certificates. When set, clients must
+	// present a certificate signed by one of these CAs (mutual TLS). Requires
+	// `tls_cert_file` and `tls_key_file`.
+	TlsClientCaFile string `json:"tls_client_ca_file,omitempty,omitzero"`
+
+	// TlsKeyFile PEM private key matching `tls_cert_file`.
+	TlsKeyFile string `json:"tls_key_file,omitempty,omitzero"`
 }
 
 // ConfigModelStrategies defines model for Config.ModelStrategies.
@@ -178,17 +292,157 @@ type ContentPart struct {
 	union json.RawMessage
 }
 
+// DecodeRequest defines model for DecodeRequest.
+type DecodeRequest struct {
+	// Ids Token IDs to decode, e.g. as returned by `/tokenize`
+	Ids []int `json:"ids"`
+
+	// Model Model whose tokenizer to use (see `TokenizeRequest.model`)
+	Model string `json:"model"`
+}
+
+// DecodeResponse defines model for DecodeResponse.
+type DecodeResponse struct {
+	// Model Model whose tokenizer was used
+	Model string `json:"model"`
+
+	// Text Decoded text. Tokenizers that normalize input (e.g. lowercasing) do not restore the original text.
+	Text string `json:"text"`
+}
+
+// DocumentChunk defines model for DocumentChunk.
+type DocumentChunk struct {
+	// Embedding Embedding of the chunk text
+	Embedding []float32 `json:"embedding"`
+
+	// EndChar Offset just past the last character of the chunk in the extracted text
+	EndChar int `json:"end_char"`
+
+	// Id Index of the chunk in the document
+	Id int `json:"id"`
+
+	// StartChar Offset of the first character of the chunk in the extracted text
+	StartChar int `json:"start_char"`
+
+	// Text Text of the chunk
+	Text string `json:"text"`
+}
+
+// EmbedDocumentRequest defines model for EmbedDocumentRequest.
+type EmbedDocumentRequest struct {
+	// Chunking Configuration for chunking requests to Termite API.
+	// This is a simplified config for the HTTP API - differs from the full ChunkerConfig
+	// which includes provider selection and caching configuration.
+	Chunking ChunkConfig `json:"chunking,omitempty,omitzero"`
+
+	// Document Document to embed, as a data URI (`data:application/pdf;base64,...`), an
+	// HTTP/HTTPS URL, a local `file://` URL or an `s3://endpoint/bucket/key` URL
+	Document string `json:"document"`
+
+	// MimeType MIME type of the document, overriding the type reported by the data URI or
+	// server, or detected from its content
+	MimeType string `json:"mime_type,omitempty,omitzero"`
+
+	// Model Name of the embedder model from models_dir/embedders/
+	Model string `json:"model"`
+}
+
+// EmbedDocumentResponse defines model for EmbedDocumentResponse.
+type EmbedDocumentResponse struct {
+	// Chunks Chunks of the extracted text, in document order, each with its embedding
+	Chunks []DocumentChunk `json:"chunks"`
+
+	// MimeType MIME type the document was extracted as
+	MimeType string `json:"mime_type"`
+
+	// Model Model used for embedding
+	Model string `json:"model"`
+}
+
+// EmbedFusion Fuses the content parts of each input item (e.g. a title and a body) into a single
+// embedding. Only models reporting `supports_fusion` in `/models` accept fusion;
+// requests for other models are rejected with 400 Bad Request.
+type EmbedFusion struct {
+	// Mode How the parts of an item are combined:
+	// - `weighted_mean`: embed each part separately and average the vectors using `weights`,
+	//   then L2-normalize the result
+	// - `native`: pass all parts of the item to the model, which fuses them itself
+	Mode EmbedFusionMode `json:"mode"`
+
+	// Weights Weight of each part for `weighted_mean`, by position within an item. Items may not
+	// have more parts than there are weights. Defaults to equal weights.
+	Weights []float32 `json:"weights,omitempty,omitzero"`
+}
+
+// EmbedFusionMode How the parts of an item are combined:
+//   - `weighted_mean`: embed each part separately and average the vectors using `weights`,
+//     then L2-normalize the result
+//   - `native`: pass all parts of the item to the model, which fuses them itself
+type EmbedFusionMode string
+
+// EmbedLimits Size limits for embed requests, checked before the model runs.
+// Requests exceeding a limit receive 413 Request Entity Too Large naming the limit.
+type EmbedLimits struct {
+	// MaxInputBytes Maximum size in bytes of a single input (text, or image after download).
+	// Set to 0 for the default (8 MiB).
+	MaxInputBytes int `json:"max_input_bytes,omitempty,omitzero"`
+
+	// MaxInputs Maximum number of inputs (texts or images) per request.
+	// Set to 0 for the default (2048).
+	MaxInputs int `json:"max_inputs,omitempty,omitzero"`
+
+	// MaxRequestBytes Maximum combined size in bytes of all inputs in a request.
+	// Set to 0 for the default (64 MiB).
+	MaxRequestBytes int `json:"max_request_bytes,omitempty,omitzero"`
+}
+
+// EmbedMultipartRequest Embed request sent as `multipart/form-data`, for uploading raw image files without
+// base64 encoding. Each `input` field and each `file` part is one input, embedded in
+// the order the parts appear.
+type EmbedMultipartRequest struct {
+	// File Image files, one per `file` part. The image type is detected from the file content
+	// and must be one the model supports. Each file is limited by `embed_limits.max_input_bytes`.
+	File []openapi_types.File `json:"file,omitempty,omitzero"`
+
+	// Input Text inputs, one per `input` field
+	Input []string `json:"input,omitempty,omitzero"`
+
+	// Model Name of the embedder model from models_dir/embedders/
+	Model string `json:"model"`
+
+	// Precision Significant digits to round embedding values to in JSON responses, trading a small
+	// loss of precision for smaller payloads. 0 keeps full float32 precision (default).
+	// Binary responses are never rounded.
+	Precision EmbedPrecision `json:"precision,omitempty,omitzero"`
+}
+
+// EmbedPrecision Significant digits to round embedding values to in JSON responses, trading a small
+// loss of precision for smaller payloads. 0 keeps full float32 precision (default).
+// Binary responses are never rounded.
+type EmbedPrecision = int
+
 // EmbedRequest defines model for EmbedRequest.
 type EmbedRequest struct {
-	// Input Input content to embed. Supports three formats:
+	// Fusion Fuses the content parts of each input item (e.g. a title and a body) into a single
+	// embedding. Only models reporting `supports_fusion` in `/models` accept fusion;
+	// requests for other models are rejected with 400 Bad Request.
+	Fusion EmbedFusion `json:"fusion,omitempty,omitzero"`
+
+	// Input Input content to embed. Supports four formats:
 	// - Single text string: `"hello world"`
 	// - Array of text strings: `["hello", "world"]`
 	// - Array of content parts (multimodal): `[{"type": "text", "text": "hello"}, {"type": "image_url", "image_url": {"url": "data:image/png;base64,..."}}]`
+	// - Array of multi-part items, with `fusion`: `[[{"type": "text", "text": "title"}, {"type": "text", "text": "body"}]]`
 	Input EmbedRequest_Input `json:"input"`
 
 	// Model Name of the embedder model from models_dir/embedders/
 	Model string `json:"model"`
 
+	// Precision Significant digits to round embedding values to in JSON responses, trading a small
+	// loss of precision for smaller payloads. 0 keeps full float32 precision (default).
+	// Binary responses are never rounded.
+	Precision EmbedPrecision `json:"precision,omitempty,omitzero"`
+
 	// Truncate Truncate input to fit model context length
 	Truncate bool `json:"truncate,omitempty,omitzero"`
 }
@@ -202,10 +456,14 @@ type EmbedRequestInput1 = []string
 // EmbedRequestInput2 Array of multimodal content parts (text or images)
 type EmbedRequestInput2 = []ContentPart
 
-// EmbedRequest_Input Input content to embed. Supports three formats:
+// EmbedRequestInput3 Array of multi-part items, each fused into one embedding (requires `fusion`)
+type EmbedRequestInput3 = [][]ContentPart
+
+// EmbedRequest_Input Input content to embed. Supports four formats:
 // - Single text string: `"hello world"`
 // - Array of text strings: `["hello", "world"]`
 // - Array of content parts (multimodal): `[{"type": "text", "text": "hello"}, {"type": "image_url", "image_url": {"url": "data:image/png;base64,..."}}]`
+// - Array of multi-part items, with `fusion`: `[[{"type": "text", "text": "title"}, {"type": "text", "text": "body"}]]`
 type EmbedRequest_Input struct {
 	union json.RawMessage
 }
@@ -219,12 +477,52 @@ type EmbedResponse struct {
 	Model string `json:"model"`
 }
 
+// EmbedderModelInfo defines model for EmbedderModelInfo.
+type EmbedderModelInfo struct {
+	// DefaultDimension Embedding dimension returned when none is requested
+	DefaultDimension int `json:"default_dimension,omitempty,omitzero"`
+
+	// Dimensions Supported output embedding dimensions
+	Dimensions []int `json:"dimensions,omitempty,omitzero"`
+
+	// Loaded Whether the model is currently loaded. Capabilities of lazily loaded models
+	// are only reported once the model has been loaded by a request.
+	Loaded bool `json:"loaded"`
+
+	// Name Model name as accepted by `/api/embed`
+	Name string `json:"name"`
+
+	// SupportedMimeTypes Input content types accepted by the model
+	SupportedMimeTypes []string `json:"supported_mime_types,omitempty,omitzero"`
+
+	// SupportsFusion Whether mixed text and image content is fused into a single embedding
+	SupportsFusion bool `json:"supports_fusion,omitempty,omitzero"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Error Error message
 	Error string `json:"error"`
 }
 
+// GPUInfo defines model for GPUInfo.
+type GPUInfo struct {
+	// Available Whether GPU acceleration is available
+	Available bool `json:"available"`
+
+	// CudaVersion CUDA version
+	CudaVersion string `json:"cuda_version,omitempty,omitzero"`
+
+	// DeviceName Name of the detected device
+	DeviceName string `json:"device_name,omitempty,omitzero"`
+
+	// DriverVersion GPU driver version
+	DriverVersion string `json:"driver_version,omitempty,omitzero"`
+
+	// Type Detected accelerator type (cuda, coreml, tpu, or none)
+	Type string `json:"type"`
+}
+
 // GPUMode GPU/accelerator mode. Configurable via TERMITE_GPU env var (viper auto-binding).
 // - "auto": Auto-detect (default). TPU > CUDA/CoreML > CPU based on availability.
 // - "tpu": Force TPU. Fails if TPU not available.
@@ -249,20 +547,119 @@ type ImageURLContentPart struct {
 // ImageURLContentPartType defines model for ImageURLContentPart.Type.
 type ImageURLContentPartType string
 
+// InfoResponse defines model for InfoResponse.
+type InfoResponse struct {
+	// Backend Inference backend compiled into this binary
+	Backend string `json:"backend"`
+
+	// BuildTime Build timestamp
+	BuildTime string `json:"build_time"`
+
+	// GitCommit Git commit hash
+	GitCommit string `json:"git_commit"`
+
+	// GoVersion Go runtime version
+	GoVersion string  `json:"go_version"`
+	Gpu       GPUInfo `json:"gpu"`
+
+	// GpuMode GPU/accelerator mode. Configurable via TERMITE_GPU env var (viper auto-binding).
+	// - "auto": Auto-detect (default). TPU > CUDA/CoreML > CPU based on availability.
+	// - "tpu": Force TPU. Fails if TPU not available.
+	// - "cuda": Force CUDA. Fails if CUDA not available.
+	// - "coreml": Force CoreML (macOS only).
+	// - "off": CPU only, disable all GPU acceleration.
+	GpuMode GPUMode `json:"gpu_mode"`
+
+	// Models Model counts per registry
+	Models ModelCounts `json:"models"`
+
+	// Version Termite version
+	Version string `json:"version"`
+}
+
+// LivenessCheck Opt-in deep liveness check that periodically embeds a short text with a canary model.
+// When the inference errors or hangs past the timeout failure_threshold times in a row,
+// `/healthz` returns 503 so Kubernetes restarts the wedged pod. Disabled unless model is set.
+type LivenessCheck struct {
+	// FailureThreshold Consecutive failed checks after which `/healthz` reports unhealthy.
+	// Set to 0 for the default (3).
+	FailureThreshold int `json:"failure_threshold,omitempty,omitzero"`
+
+	// Interval Time between checks in Go duration format.
+	Interval string `json:"interval,omitempty,omitzero"`
+
+	// Model Embedder (or alias) used for the check. Empty disables the check (default).
+	Model string `json:"model,omitempty,omitzero"`
+
+	// Timeout Time after which a check that has not completed counts as failed, in Go duration format.
+	Timeout string `json:"timeout,omitempty,omitzero"`
+}
+
+// ModelCounts Model counts per registry
+type ModelCounts struct {
+	Chunkers  RegistryModelCounts `json:"chunkers"`
+	Embedders RegistryModelCounts `json:"embedders"`
+	Rerankers RegistryModelCounts `json:"rerankers"`
+}
+
 // ModelsResponse defines model for ModelsResponse.
 type ModelsResponse struct {
+	// Aliases Configured aliases of each available embedding or reranking model, keyed by
+	// the canonical model name. Aliases can be used anywhere a model name is accepted.
+	Aliases map[string][]string `json:"aliases,omitempty,omitzero"`
+
+	// ChunkerDetails Per-model metadata for each entry in `chunkers`
+	ChunkerDetails []ChunkerModelInfo `json:"chunker_details,omitempty,omitzero"`
+
 	// Chunkers Available chunking models (always includes "fixed")
 	Chunkers []string `json:"chunkers"`
 
+	// EmbedderDetails Per-model metadata for each entry in `embedders`
+	EmbedderDetails []EmbedderModelInfo `json:"embedder_details,omitempty,omitzero"`
+
 	// Embedders Available embedding models from models_dir/embedders/
 	Embedders []string `json:"embedders"`
 
+	// RerankerDetails Per-model metadata for each entry in `rerankers`
+	RerankerDetails []RerankerModelInfo `json:"reranker_details,omitempty,omitzero"`
+
+	// RerankerTypes Type of each reranking model. Cross-encoders score the query and prompt jointly;
+	// bi-encoders embed them separately and support the `shared_query` rerank mode.
+	RerankerTypes map[string]ModelsResponseRerankerTypes `json:"reranker_types,omitempty,omitzero"`
+
 	// Rerankers Available reranking models
 	Rerankers []string `json:"rerankers"`
 }
 
+// ModelsResponseRerankerTypes defines model for ModelsResponse.RerankerTypes.
+type ModelsResponseRerankerTypes string
+
+// RegistryModelCounts defines model for RegistryModelCounts.
+type RegistryModelCounts struct {
+	// Available Number of models that can be served
+	Available int `json:"available"`
+
+	// Loaded Number of models currently loaded in memory
+	Loaded int `json:"loaded"`
+}
+
 // RerankRequest defines model for RerankRequest.
 type RerankRequest struct {
+	// Ids Optional caller-defined document IDs, one per prompt. Each entry of `results`
+	// carries the ID of its prompt, so callers need not map indices back to documents.
+	// IDs do not affect scoring or caching.
+	Ids []string `json:"ids,omitempty,omitzero"`
+
+	// MinScore Drop results scoring below this threshold from `results`.
+	// When omitted, no threshold is applied.
+	MinScore *float32 `json:"min_score,omitempty"`
+
+	// Mode How scores are computed. `pairwise` (default) scores each (query, prompt) pair
+	// independently and works with every reranker. `shared_query` embeds the query once
+	// and reuses it for every prompt; it is only supported by bi-encoder rerankers
+	// (see `reranker_types` in `/models`).
+	Mode RerankRequestMode `json:"mode,omitempty,omitzero"`
+
 	// Model Name of reranking model from models_dir/rerankers/
 	Model string `json:"model"`
 
@@ -272,17 +669,87 @@ type RerankRequest struct {
 
 	// Query Search query for relevance scoring
 	Query string `json:"query"`
+
+	// TopN Return at most this many results in `results`, highest scores first.
+	// 0 (default) or a value larger than the number of prompts returns all results.
+	TopN int `json:"top_n,omitempty,omitzero"`
 }
 
+// RerankRequestMode How scores are computed. `pairwise` (default) scores each (query, prompt) pair
+// independently and works with every reranker. `shared_query` embeds the query once
+// and reuses it for every prompt; it is only supported by bi-encoder rerankers
+// (see `reranker_types` in `/models`).
+type RerankRequestMode string
+
 // RerankResponse defines model for RerankResponse.
 type RerankResponse struct {
 	// Model Name of model used for reranking
 	Model string `json:"model"`
 
+	// Results Results sorted by score (highest first), with `top_n` and `min_score` applied.
+	// Each result carries the index of its prompt in the request.
+	Results []RerankResult `json:"results,omitempty,omitzero"`
+
 	// Scores Relevance scores (one per prompt, same order as input)
 	Scores []float32 `json:"scores"`
 }
 
+// RerankResult defines model for RerankResult.
+type RerankResult struct {
+	// Id ID of the prompt from the request's `ids`, omitted when none were given
+	Id string `json:"id,omitempty,omitzero"`
+
+	// Index Index of the prompt in the request
+	Index int `json:"index"`
+
+	// Score Relevance score for the prompt
+	Score float32 `json:"score"`
+}
+
+// RerankerModelInfo defines model for RerankerModelInfo.
+type RerankerModelInfo struct {
+	// Name Model name as accepted by `/api/rerank`
+	Name string `json:"name"`
+
+	// Type Reranker architecture (see `reranker_types`)
+	Type RerankerModelInfoType `json:"type"`
+}
+
+// RerankerModelInfoType Reranker architecture (see `reranker_types`)
+type RerankerModelInfoType string
+
+// SimilarityMetric Scoring function. `cosine` and `dot` are similarities (higher is more similar);
+// `euclidean` is a distance (lower is more similar).
+type SimilarityMetric string
+
+// SimilarityRequest defines model for SimilarityRequest.
+type SimilarityRequest struct {
+	// A First set of vectors; row `i` of the result compares `a[i]` with every vector in `b`
+	A [][]float32 `json:"a,omitempty,omitzero"`
+
+	// ABinary First set of vectors in the binary format returned by `/embed` with
+	// `Accept: application/octet-stream`, base64 encoded. Use instead of `a`.
+	ABinary []byte `json:"a_binary,omitempty,omitzero"`
+
+	// B Second set of vectors; column `j` of the result compares every vector in `a` with `b[j]`
+	B [][]float32 `json:"b,omitempty,omitzero"`
+
+	// BBinary Second set of vectors in the binary format returned by `/embed` with
+	// `Accept: application/octet-stream`, base64 encoded. Use instead of `b`.
+	BBinary []byte           `json:"b_binary,omitempty,omitzero"`
+	Metric  SimilarityMetric `json:"metric,omitempty,omitzero"`
+}
+
+// SimilarityResponse defines model for SimilarityResponse.
+type SimilarityResponse struct {
+	// Metric Scoring function. `cosine` and `dot` are similarities (higher is more similar);
+	// `euclidean` is a distance (lower is more similar).
+	Metric SimilarityMetric `json:"metric"`
+
+	// Scores Score matrix where `scores[i][j]` compares `a[i]` with `b[j]`
+	Scores [][]float32 `json:"scores"`
+}
+
 // TextContentPart Text content for embedding
 type TextContentPart struct {
 	// Text Text content to embed
@@ -293,6 +760,32 @@ type TextContentPart struct {
 // TextContentPartType defines model for TextContentPart.Type.
 type TextContentPartType string
 
+// TokenizeRequest defines model for TokenizeRequest.
+type TokenizeRequest struct {
+	// Model Model whose tokenizer to use: an embedder, chunker or reranker from models_dir
+	// (using its `tokenizer.json`), or a built-in fixed chunker
+	// (`fixed-bert-tokenizer`, `fixed-bpe-tokenizer`)
+	Model string `json:"model"`
+
+	// Text Text to tokenize
+	Text string `json:"text"`
+}
+
+// TokenizeResponse defines model for TokenizeResponse.
+type TokenizeResponse struct {
+	// Count Number of tokens, as counted for chunk `token_counts`
+	Count int `json:"count"`
+
+	// Ids Token IDs, in order
+	Ids []int `json:"ids"`
+
+	// Model Model whose tokenizer was used
+	Model string `json:"model"`
+
+	// Tokens String form of each token, in the same order as `ids`
+	Tokens []string `json:"tokens"`
+}
+
 // VersionResponse defines model for VersionResponse.
 type VersionResponse struct {
 	// BuildTime Build timestamp
@@ -311,12 +804,27 @@ type VersionResponse struct {
 // ChunkTextJSONRequestBody defines body for ChunkText for application/json ContentType.
 type ChunkTextJSONRequestBody = ChunkRequest
 
+// DecodeTokensJSONRequestBody defines body for DecodeTokens for application/json ContentType.
+type DecodeTokensJSONRequestBody = DecodeRequest
+
 // GenerateEmbeddingsJSONRequestBody defines body for GenerateEmbeddings for application/json ContentType.
 type GenerateEmbeddingsJSONRequestBody = EmbedRequest
 
+// GenerateEmbeddingsMultipartRequestBody defines body for GenerateEmbeddings for multipart/form-data ContentType.
+type GenerateEmbeddingsMultipartRequestBody = EmbedMultipartRequest
+
+// GenerateDocumentEmbeddingsJSONRequestBody defines body for GenerateDocumentEmbeddings for application/json ContentType.
+type GenerateDocumentEmbeddingsJSONRequestBody = EmbedDocumentRequest
+
 // RerankPromptsJSONRequestBody defines body for RerankPrompts for application/json ContentType.
 type RerankPromptsJSONRequestBody = RerankRequest
 
+// ComputeSimilarityJSONRequestBody defines body for ComputeSimilarity for application/json ContentType.
+type ComputeSimilarityJSONRequestBody = SimilarityRequest
+
+// TokenizeTextJSONRequestBody defines body for TokenizeText for application/json ContentType.
+type TokenizeTextJSONRequestBody = TokenizeRequest
+
 // AsTextContentPart returns the union data inside the ContentPart as a TextContentPart
 func (t ContentPart) AsTextContentPart() (TextContentPart, error) {
 	var body TextContentPart
@@ -457,6 +965,32 @@ func (t *EmbedRequest_Input) MergeEmbedRequestInput2(v EmbedRequestInput2) error
 	return err
 }
 
+// AsEmbedRequestInput3 returns the union data inside the EmbedRequest_Input as a EmbedRequestInput3
+func (t EmbedRequest_Input) AsEmbedRequestInput3() (EmbedRequestInput3, error) {
+	var body EmbedRequestInput3
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromEmbedRequestInput3 overwrites any union data inside the EmbedRequest_Input as the provided EmbedRequestInput3
+func (t *EmbedRequest_Input) FromEmbedRequestInput3(v EmbedRequestInput3) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeEmbedRequestInput3 performs a merge with any union data inside the EmbedRequest_Input, using the provided EmbedRequestInput3
+func (t *EmbedRequest_Input) MergeEmbedRequestInput3(v EmbedRequestInput3) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t EmbedRequest_Input) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -472,15 +1006,30 @@ type ServerInterface interface {
 	// Chunk text into smaller segments
 	// (POST /chunk)
 	ChunkText(w http.ResponseWriter, r *http.Request)
+	// Decode token IDs
+	// (POST /decode)
+	DecodeTokens(w http.ResponseWriter, r *http.Request)
 	// Generate embeddings
 	// (POST /embed)
 	GenerateEmbeddings(w http.ResponseWriter, r *http.Request)
+	// Embed a document
+	// (POST /embed/document)
+	GenerateDocumentEmbeddings(w http.ResponseWriter, r *http.Request)
+	// Get runtime information
+	// (GET /info)
+	GetInfo(w http.ResponseWriter, r *http.Request)
 	// List available models
 	// (GET /models)
 	ListModels(w http.ResponseWriter, r *http.Request)
 	// Rerank prompts by relevance
 	// (POST /rerank)
 	RerankPrompts(w http.ResponseWriter, r *http.Request)
+	// Compute pairwise vector similarity
+	// (POST /similarity)
+	ComputeSimilarity(w http.ResponseWriter, r *http.Request)
+	// Tokenize text
+	// (POST /tokenize)
+	TokenizeText(w http.ResponseWriter, r *http.Request)
 	// Get version information
 	// (GET /version)
 	GetVersion(w http.ResponseWriter, r *http.Request)
@@ -509,6 +1058,20 @@ func (siw *ServerInterfaceWrapper) ChunkText(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// DecodeTokens operation middleware
+func (siw *ServerInterfaceWrapper) DecodeTokens(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DecodeTokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GenerateEmbeddings operation middleware
 func (siw *ServerInterfaceWrapper) GenerateEmbeddings(w http.ResponseWriter, r *http.Request) {
 
@@ -523,6 +1086,34 @@ func (siw *ServerInterfaceWrapper) GenerateEmbeddings(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
+// GenerateDocumentEmbeddings operation middleware
+func (siw *ServerInterfaceWrapper) GenerateDocumentEmbeddings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GenerateDocumentEmbeddings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListModels operation middleware
 func (siw *ServerInterfaceWrapper) ListModels(w http.ResponseWriter, r *http.Request) {
 
@@ -551,6 +1142,34 @@ func (siw *ServerInterfaceWrapper) RerankPrompts(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
+// ComputeSimilarity operation middleware
+func (siw *ServerInterfaceWrapper) ComputeSimilarity(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ComputeSimilarity(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TokenizeText operation middleware
+func (siw *ServerInterfaceWrapper) TokenizeText(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TokenizeText(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetVersion operation middleware
 func (siw *ServerInterfaceWrapper) GetVersion(w http.ResponseWriter, r *http.Request) {
 
@@ -686,9 +1305,14 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	}
 
 	m.HandleFunc("POST "+options.BaseURL+"/chunk", wrapper.ChunkText)
+	m.HandleFunc("POST "+options.BaseURL+"/decode", wrapper.DecodeTokens)
 	m.HandleFunc("POST "+options.BaseURL+"/embed", wrapper.GenerateEmbeddings)
+	m.HandleFunc("POST "+options.BaseURL+"/embed/document", wrapper.GenerateDocumentEmbeddings)
+	m.HandleFunc("GET "+options.BaseURL+"/info", wrapper.GetInfo)
 	m.HandleFunc("GET "+options.BaseURL+"/models", wrapper.ListModels)
 	m.HandleFunc("POST "+options.BaseURL+"/rerank", wrapper.RerankPrompts)
+	m.HandleFunc("POST "+options.BaseURL+"/similarity", wrapper.ComputeSimilarity)
+	m.HandleFunc("POST "+options.BaseURL+"/tokenize", wrapper.TokenizeText)
 	m.HandleFunc("GET "+options.BaseURL+"/version", wrapper.GetVersion)
 
 	return m
@@ -697,122 +1321,228 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x8/XMbN7Lgv4KavSpLe8MPSbbj8Gp/UBzbT/fkWE+yNncXuihwpkkiwgATAEOJSen+",
-	"9lfdwHwPJXmz2d0ftspVFmeARqPR3+ie36JEZ7lWoJyNZr9FNtlAxunPt5tC3eIfKdjEiNwJraJZdMoS",
-	"fMH0ijm4d+xOuA3LtRX4ngm10ibj+Pc4iqPc6ByME0AQQaWLZMNNH+jbDTc8cWCakJg2Yi0Ul2GhDRgI",
-	"i4NKLTuA+0QWVmzhMIojt8shmkVCOViDiR7iSKT9ha7glwJUAkwV2RIM7WJTQj2YxuwoZscxG4/HAzDj",
-	"6H601qPwtBDKnRzjQtZx4/5OOyNYdnA/OLa/wOcK/UQrB8rVc60zQq2jh4c4MvBLIQyk0ewnpEsA1kI9",
-	"rs/nSwVCL3+GxOHqxA5vtVqJ9cAu6Xlh6ODZShuPklBrhiuDdZY5zT6DyYQDdnpxNp6rzxthmbCMMyuy",
-	"XIqVgBQ3sRJrAoEH8x+fP1/gcDZiqVitwFi2Mjqjd6tCSkZogfEIzNXdRiQbJlQiixQsy43eihQMsyAh",
-	"IeS4SlnCkw3iljTRHs9Vj2Mzfr+gnVi/5xUvpItmr6ZxhwAf+b3IiqzBVn4a7tqAKwzChnue5RL8/P75",
-	"ZjoF2VonWol7wNPac+S4B5qFyxQWxuydcBsw7AVNfEFkJOICc/oW1GjJLRI5TI6ZNowHEIpn4IlLv+0k",
-	"8aS1k9/w1cNk3NxChVqH1+JIb8FIni9owafo9kNFrzAtxz35qWwJ7g5ABVI+TUALOTfcadMm4lzRyXZo",
-	"iIJXTSBC0Y4q2rQ2G0D09uq4WYMb3mpvr59pcFPz+G3mEMSlvcPBLbqNAbvRMm0tNh2/iockMiVVV82h",
-	"XX764Yf/E06YHUzH09HReHrYXJmAeS2Oxyw1b6gUjzyplGENcenFHdFri1JSqY7/YWAVzaI/TWrTMwl2",
-	"Z9LUMvtVHp6d032iRbVKkVqtWaqTIgPlmNtwxxRASgK5BGZzKRwTymlmMy5leQR2PB4/qUAJqy/7KWBz",
-	"rSyQxSsx+y1CnQOLjXDRbMWlhTgqFctPTct4hOeOlmvativTkhjVHkkFCmOdxxwRf4hboL4NoI7aoL4d",
-	"hmUh0SptAPtSqaQg7A9d9djYU/eMftwAaSIDtpCO3XHLLJgtpF7F0Mya0EutJXCFKzTVbcvvMIbvKq+j",
-	"UgnCQWafxVVRzbMcYXVUbttot5QrT1zBpdyhik3ZQcZ3wRj5vQQLBykTK7biUi55cst0khTGQHr4HK3Z",
-	"YbBqdx6/uEHoQbarRKt9PDwXi8IM7O/68rz0fEqTDNkS0lSo9aSyLHheIoEW/hvn8tlkInXC5UZbN3sz",
-	"fTONGvqiMGJIUQbfZGEhKYxwu6dOjCu3krvRWi+kWPLVwiaGo2lY6BwU7uutB3gV4NUqY50XtHcpP61I",
-	"th5b5sPF9UedAvF6bTB44TSCugXIF1yKLbQNyrRnTf5D33mN4zTDWaWClZqnyBeKZZBps2N8ha6g5NYh",
-	"M7GDT1LyjI8QNe7EUsLheK4++sncAENU0J1OPP+pANCDcSi9ael16RUTiidObIXbjefq2gL7oOv3/ohm",
-	"bB69yuYRO3jFMqEKB/YwZvPoaIPPjthGF4YeTPG3gi2YsGzMgK8Rec2RTxDRHzegmAVSxn6GNkxnwjlI",
-	"45IGuI2ANgGQO8ad93OLnNyx5iooYBLWPNmxJWz4Vmhz6D2zmglfZUMsJvX6a7lK6vW6w1SBi8jx04pE",
-	"WLlF6cS2re4znMAKBEZGYMgeVx4xl1LfQTqeq9M0pdiAy/rtnZASTdUvBRSQsiJHKiNe9GBhxa8wnqsr",
-	"T/0p2fZCSYHSnLKDgGWXdi8HHU9+v/C0X/gz+9pthpMumb/H9VZkhXRcgS6s3JWMQ+xLCKMJMoA6Lo1J",
-	"K0lACTGQgHKl3vW6WNgGo5xfXjPYCnLsD59DDPZJyR2D1QqDgS1g5KVYLeYIXWk1+hWM7hDuZB/h/BYX",
-	"2fJ5RAsUORCKffzuMPjthG/YlKflMI14nhsdyLSXRF7iSiI9iyqV26QYT7fCIoZ+0VEwfgHvuSosXwNL",
-	"IacQXKtwLMiNloQZraODLNeGG4HEvk8AUr+RLZcFMu2P2twi+2u1tiIF1mPA4I8rGK0NF8rHhc5o2WXn",
-	"6bev9x1MLSZfy87NkJWgeD7ZoxMazFufWhBbfIdhaswU3NVw8dSQ3V5NT9iVt7LsWvEtF5IvJficyiU4",
-	"sxudkqbfAE/B7D9Lv9gTfL4P/3kxnZ4Am3ZoezTdH6UurDPcwbp0NCr1ddFJ+BQZ+jOk96M4kvzXXRRH",
-	"S12oFNKGL1Nr8fbZXIAZeQYLVoeFhXcULxqRgh2zjzy3jSCWzs1tQJjerNq4Ko0q2bNlxnOSQjy2QMJ6",
-	"HR8x66aamM3ViJ2tGk/+EuxleQCztq1kB/hHw+zFLZt32IPnj2Q6Y0ixDhStWAoZV2kcpgdvQKQSDjFQ",
-	"JRYso/oNOt7lXub+JOZRc+t+N6RfSu+iNs8H3LKcG4dikRuosaXxbcMdM9iC6urUsBV2kAulmlaBcCXN",
-	"Q3bQskzc4y495VCV0OaDQhBeqizPgOW6pwh+i5ZrGFEoNwI12h6NX0Wziu+SjVa3u2jmGXAodvWrLFIx",
-	"kML7jltgqTCQOFSMqIm4UHV4YItl+VYgO5YuNfpuo1TYBFnVlhvBkIFIfvNbvejDxPvfYOzkho3Yu9Ib",
-	"ryJ1DNsP+9OqHA3Oaoct+ycZMLyedUm/BqbN1feenUmg/v9k7PzGJuU49Py2grOtyMEcjpGFUawsuJhp",
-	"VEDLQkg3EqqTWiFTUyq7rnPXW2fI1wus2D+rc2Fd5ZHU2iCMb3H2oOv9eQMWBjxXkWWQCu5A7jyDl4fs",
-	"87Ux41st6MAoJB8F5cokd6CSSu8EjOxGFxJNpUs2zG20BRKL6oga3MAOYLweo0PeY/B5hBg/36NhBy1t",
-	"gst13cOfBsQojhIp8tFWOMoejnLE+uQYtXcVfPcTY51AO9Bj4UQGunBPxVOlTcbheH53XDgyebw0opT/",
-	"0Yi1gzgkfHFXwV7jeJz8aBx0MrXziIKfzP/vYx7NApYxa3jRl6W59A4NpSlReYWxDZv+kn3gDu74jn32",
-	"77osfjIdZGp7skgMpKCc4NJ+dYR8UocxDSjd1EKZE9iTR8CY+oIbN3jv4197e4CHgU69yHTKZZ0+YAeU",
-	"n9GGiYyv6WZGK3hGKP4Z7l0TgYf48fFnCP768rw158tDHJHu3JuGFCovBnZ3ho+rHTrtNzRmV0Wea4Ma",
-	"cGMAAu9Y0t9XQq0l+HSUP8QZu5lHG5BSszttZDqPbnBgO3Plh9oZu/kpDPa8F2Z8aU9p0tyyg5rihwjg",
-	"tzkd4jxCbkboHpT/C58F+A8xaw2lo0E28OMbP2c4MPw1j1Lu+IzeTnK1/l8o/q9fxuPxeB49PCCmbd3R",
-	"2HqEPniyEYpiOYPWElmuZoXOtVyPluxgyZPbO25S1tDQA2LzeJ4wUHsvtGdrsL3LNISgc1gtQbCHz05W",
-	"toSgg8eX/UnLH9A1Clm90n4EO9i41emYl5ZeGtL9fbqYQiXctYMqZwroXXeEgYxEDmVqJVxAiCh175gE",
-	"tXabgTxwR2uVeVAvvUO6K0j9YOq9Uk42mv3003Q8PTo+iUfT8fTlq9fxdDz95s23X2J8fnzykp6/ev0N",
-	"Pn/z7ZdGDrxPnV4+vLnQXoapVeWWfEbLDrQCugXylPK0bvFL9cdT9zJ9y/vMlLd3TyiLgKq9QvJrGWTP",
-	"wTUoM3h6xvhbuw49y8dtXGk0y8BavoYnUfBAhlYts7+9BT5cXE94koAEfzuIuxiz6pIbQ3T0ez+/u/x4",
-	"9vnd4sPFNQO1ZVtu2AE5w973XwpVZkpHbE5pZdSrpxQXgIPEtZIwF9dlKP72+vvTyVtt4ON59ejiug5F",
-	"g/MspM/0InCXFwj7vTYJIKgxe88Fxk0rAox+edPlxilJkfJ6Dq7ZmIQ/h2dpA5lszPNoHmQ8+XRFbn+5",
-	"X71a4TDEHB/HLBWWaMelZEizisTV7XuZMKAMfIybQvezSDn+RwujP7FaDaYOSo9gwLrjG0a3HoahUWPX",
-	"l2e9y/79VyX1JHaw1ya2L3qGh4m/fvfp8m76nx/W+jkXjfsctSHfZ8+mS5vUEmp28CkHdXrWCH6Ca3PY",
-	"o0rlHDxltyryV0qnTgDVQL48ebmKb+PBGTUBfBKnqe47F5MhLB5Qw1WSLelGy1ze8Z2ti0fm/rZuHh22",
-	"3ZzyDs9nFUYZal4X9CJ5A1KodcHl6OjrYqTKKj+GNXQzA8+z7cOBXe/ZSLwZ/eK+NrQL2YTH0DadJEMf",
-	"txLMaHs8yk6+BoWhy1NEp4lak7pDDOWTIHujhidcrs7uemdSJ1x65rSz7cFch85yN0DdC4PTVQoG0kap",
-	"A9y7UHaEkMeMqoWkoDsoy4yXmVLkGdw7wxMn1HquuEqZByiaxRMrATK1EwdZLrkDy5aw0gZYwqWswmBQ",
-	"aa6Fcr2EwplyRqeFL76iG6x2WOD14Pd0bxQesVWhUo5rc0kFGV/Fj78UYHZDlX/cJBtGb2nnBiRsuUqA",
-	"2USbrqvTRZPxPJciIVtln+32eFzqM3yM9fbpsid4L2s7bhUrDmYY0IYOcNJlixTQ8Eg94rHPu2qDIQW3",
-	"3k9tOahf65fuoVdAcIhM3fzAcFXQoLnr2bRHyoq6KYDBEKhj3Tr1QI8btr3FQ38FY4VW+xlhWQiZUgZt",
-	"IEeN7ygXZR3P8hYrH0+PX46mR6OjV5+PprOT6Ww6/X9D21oLt0h0lg2V83wQSBp8xzbcblrw+TI5Oj55",
-	"OQhSL7Z+WwMgNTOFogxfOaYJda2PxsevxtMhsHthllnZIYDbo/F0CFznmOqpDXrETeK3tjV0kt3UXOlp",
-	"1Am6f5dW/7u0ej+/7ClL6V92+HHtMmZSfdXthL9ctj1+kbAF+XvLZc4JCJ3Szmdafg+0KwIyWFn6PERa",
-	"dwooLb1rhZJgWzBLZJkd83SoA88UlsWaEk00/Y77wu0yhVBrkzCgp5qet8sWqnTdpbjci64uXF64EKEx",
-	"IvaYvSin+SrvREttxK++UsJqCTF78bPVKtSAO1MkrkBH8X9fffohZi+kXq8y59/60nBYrURCbuIt7P5C",
-	"pRos58LYmL1QWudlNbkE5cYNkjXQxwUjqsRaZSgCOK1NtsbgJ0m350ajX+aYJGDt4hZ2iyG9dPrjFfND",
-	"cGPs7PtGVv8WdtahL2t3yvF7v0NIDDgmtb4t8jF7z6W0jEo6nWanP14tTt++fXd1tfjPd/93cfY9A7UV",
-	"RivylLfcCAp3RHUT2i6a3+nCjDwyo1vYjcSgf1H60gM69qQZspfjyjvCF/ZkzDP+q1b8zo4Tnb1g2uBR",
-	"l8Wa306nU3+MH4U6+9TOWHQnR3GUCXXuM7SzoyF3kii1qOk/TPxA0PoMfu8BXL17e/nuc+Mc/oZD8Is0",
-	"zmLQXwaLRt7X9/c39ykPNXt+lzTWC5MXq1AFtWONa72v2vsQ2rTKyGM0gHJhYWGtfDI7/04Rja6uzief",
-	"z69o7asT1B3K98nYKjU5YzifRpz+eBUzql4PFWsJlzUrDeTwn9Tkz6zr7cu8L51cIFvboepz4UCGWoAw",
-	"luFYun+fnF34CjMp1C1L9R0VsFgqYIAsd7uYUpQE21fhBgjoFkHuWG7EljtgCEes2FLq5HYRHi5E7kvq",
-	"TQGH43YsHP4M0pWkatx+cvTt8Xg6Ph5/ZeKoJEbO3ea5xMCxLDewEvdQlttJmE0mVHljT/Cv68vzHlFo",
-	"jSZRxux9Y3JhgfGl1bJwEMYG5TS5tmDsJOWOTw79JL8KTlkWyS24icennJHtRuF5kdMBTbr0bMJEddWb",
-	"8HV07J3jk1L0Hc5o1QvWrMEMV2uMpY+Ov8HIYzydvInZ0bTx9zfH46PX9OvoOGZ4+kev3/jfr2N29Prb",
-	"8fGrl+H34WC3RMm8ZWHFwndxtDE/6bcihaIFPHehUrEVacFlJQoMRc2nOtClLmE2y2GnZB1Ehj7A0b4S",
-	"zAo7K36FxXLnoI3Y0fTlm1ffvJ7urcnEeci1JaBQCOrrqZkH2CpZrOBVyE2fiDWEcq9flgj7rHMqMlB1",
-	"gBmQPZ6+fLMPT5rH7kTqNpMNiPWG8MvFPSWW/QVwVdBtALfVbgzxwB+jaF+b4iNyQ313leMJeQyo4tDy",
-	"kqaNYn+tQY0bdjaZrIXbFEvUN8EhT5eTUG/VL78swwhfGhwKpqS4haD665p26u0xVYNZ6DP8eF6XM8/V",
-	"n/7EftxwykIGwFQBGNYIrZq2tCrnDegUCNcYNFyg04szKsb485/rkrkPoAL3/vnPM0ZZHSpMri/qD96e",
-	"n10c9nLpHhBNKEvpEMIVZFw5kdQ3BoRPs5Gu7B4cEcOWPUAeXlVfh7DqTJuBUcg7BsNP6UnfLhUweV+g",
-	"y47Tfnh3GbNEcmvFKiQhY9rUOux1C1UxInkXLJdcKUipiq+Uaqqs5w6oblwCR13tWMkZnh3GQk9SndhJ",
-	"ZRarowPK315bGDq+hCtmCoVBtkq51Aqot7SuHuWKeZZkiSysA0Pndk6HXZOyc+ioo+DegSEv6+IsFJ6D",
-	"SgQQkfoccTPhufC3IDe1h9zKB9LM6lTrfrLyLC5PP7Bc5CCF8qs0T63M9JPqzJBrIS1P75eCS+F2OOUt",
-	"KGe4pIgsnAzG4mrNeGK0tSwVaIiWhYOUKZ36hS7QeiS7UW6gHN4SBLp9DGW3EvgWLEO3EEcYXgV5h+HI",
-	"3gPHn+EE/8SGRMRzmr9PQ05rcnWrgLXs3NtbtuohnV6cEZjnnUspIT7liU5Bxh3h8Z1Q6DlX9+MxBa7l",
-	"Tj7Wshzc6SDTHqC/+qy2SwDxNWt0F/xMfIGHP/Lau9YGNucJBEhUkdHEiy5yq/tgyw5u9t4I3xyiDKAX",
-	"5YGFS9e3FVEQ4LUF26kcCoH+wc3fVLQVyrNuAi1QXt9yC4S9J4zn1pgRI448GQ04I2DLZcy2wqIzYEUm",
-	"JDfEz57qLc3YZZz3tf6rhKm8Uq1qCA7Z/2xyWAMG+z7w2Q6Bne4tnd5b/+xhvfWd/QjjeOT729jnz+dl",
-	"2w31UQblGpQ04d4KMbvFyuUdy4oLWclSpdWfu4emYA1spFGT7SH+V4Hq6dfKjp22OwGRa37xQ+rGH7Gq",
-	"Sd3gX5zeuiwklRfupNhBuBzccJVKjAUEyLS6F9TqkG70pEgg3EqUDoaU7BJdHcsuwff29ryN2qZIWHPK",
-	"FTrhfKt2/S2IqJHRj7ZHXOYbfkSfEvBBYTSLTsbT8UkUR1WI40+eAkNthzIluRTokeNOB9q8WWF9i2sw",
-	"Am0D3ikNKL2Xj4FpPQcQw9c1931e90WivY8vUOu5q9yI8N0DHHxdlp7/pSo9wMfvufUeTAo+fSasE0mJ",
-	"BvHVx0qc+s5KVTVUKpmmYI/Yx8d0feMOuy1pXy0xLEgm/rr0Tg5FkSSRzV5B1M3HZWvqmKYBJe6quoyl",
-	"dpvyOyFIFte4tyuP6p13rPHXzc3NzxYN3W9zxVrVsXu+FkAKNPaD/TJexSrG8BFtzQMIhxSXr1qfg8Ah",
-	"r6bT6mX7uxj+bfWy+hKFBzyfK/wX4euHuXqgXZAcVpHZWVo2qn/29w0hCv1Op7syIgCfQ2xcXU8oRVt9",
-	"6OdZjfNlVcRD+7YD42F64I03SeXxdPr3XjvchtLiQ/e1xJYpswVl8laFlBTLv/w7YuKrEwcwOFNbLkVa",
-	"JgBw3Vf/mHWDSxzCLggD48gWWcbNrmSNAf1nYZ3R155wuPfH9mvR4CeCDbWqDR865A6aZfNep8qOS2/L",
-	"T/1UzriwjSrsEEyRv/jCtt3E4Aj5ZOq9i32JCgYEKrVMhBvSfi1bI9Qr4yGE0XAB+yq903T/qC5sNmvV",
-	"LUWh1wSjLL8LP6MRhTjNKA9Xd3o2sCljzxG1TmXBsSqTYb1SrcMZO00oG9ksdA/eSL3/LhzykttTkaYh",
-	"RbEVvHZtA4laLrDv2Wu0QhDBApEh/UMbI4ZatvY3SzyrP8L7yn9Md8RAkeXh77SFja8J0H1C0vxeAEJI",
-	"IS28tsHQP/g4dBwrSVkp3wu3RRAG0kKlXDk8k9tSILqxGD6ubixIrtCfqapkdnngmsBQvlHZ9zc2FZ9O",
-	"HLiRdQZ4dlNFdxaMwBjZX1yXsV7MMm0dq+4jD3vQUI3ezCgiLAvagh5pfMqKVEUj5G/6BZ6PkeuaNbE9",
-	"7pr1vYeG9R9oyAteA6lFHPRTh+2Rn7qVZfPoS23h5+rjYFNVn5Uex22wZW8IP/I/npQTzvKNdpryOCzh",
-	"DoVmYOrvk5xQHB0ECMF/ecT1KU1THeX/QT5Qq5/sH+wDtbtacO19QtWG2bmlIGkbldIGab8HpfkJnyUN",
-	"H6h16fkhNe3LROS/kCf2cvryj183dPVqdDAKlf5LeYClhDS0oHf66o+8rMENVYO6wihLroolke9Xn8eN",
-	"bwj6suF2fXcVh5Wekvdw+kmQYa8Kx/5Xld2gG33lLNvwLbCbkXhzw2yxWon70p6G2NQvcrqvjp8dlA3p",
-	"ZFIuZGEZV7vHsWrGvcFChkzNM7bUyeq8y3K383UJ5ZSydsun6htOaMP/RBczfCWk+dmkpPzmSE83ngvr",
-	"PpZ19n+Ycuo0YeyTDlvmFv9ZuuE7/q8ZoZ0PhQJeQj3f7I/LLv3lEX3hdF9er8r71IXuTjMeKuC9b0jM",
-	"u0923/q84GXZMSCkcCK4TnVPQVZYN5urozF751OG5Xpl44CXijK5YufqeMwuCWMSvqqtYK5OxuwKVDqw",
-	"p7KNl1t2E/Z3U30+JAUr1v6jB7b5XQYHEqz/KFH4wohHme7pk8I6nYlfodHxIPVaJP3IsM6RPSc27Ih8",
-	"5Tv3krUHftaiejHWSt37tH072ZsboC/f/tLXiO2M71Opr47z2u49qbxDYhA/6tEuiGpCOJGGQznvtX18",
-	"DJDOA6SZ/+jUuhAphhNbMLbOuyEA6ggpR7P3jY6QGfsBCsMlU+Du/JekDNDkjs84V++1qTgvpF9rbqeA",
-	"oZvtjqsvAXlmGVmRwlzdSLGcVFNvWM6TW7ryp+8Nl6nzmpWebpFpa2xvVC5Cm8gf48i2e5z+wZ5sp8tl",
-	"QGWGzYcD+rcj+U81VLj6yR+/ev3todLBKRqfQTsYcpMOO1bUg6it3q62d96WNppFHnV3O70jMVtXPS8x",
-	"fcnI99d4f7ffvDIeiFDdX6tmkj9MsLptQwNUDkOaHST/HOerE564knZtzGgYcaMd+FRI494w8Gx16zjh",
-	"uYgevjz8dwAAAP//eWZ0gdVgAAA=",
+	"H4sIAAAAAAAC/+19iXLbVrbgr+Cpp8pihqSozYtSXTW2bKf1WrLVkpz0e6aLBMlLETEIsAFQMpPyfPuc",
+	"7S4ALkgqtpO8mq7urpZB4C7nnnv25dedcTpfpIlKinzn5NedfDxT85D+PJ0tk4/4x0Tl4yxaFFGa7Jzs",
+	"PA/G+EOQToNCfSqC+6iYBYs0j/D3IEqmaTYP8e/uTntnkaULlRWRohFVMhmMZ2FWH/QUnobjQmXuSEGa",
+	"RbdREsYy0UxlSiaHkfJgV30ax8s8ulMtmKpYLRSMFCWFulXZzuf2TjSpT3St/rVUyVgFyXI+gulwFzM9",
+	"6m6vHey3g4N20O12PWO2dz51btOOPF3C48MDnCgvwqz4SjujsXLvfvDd+gQ3ZvnjFN5NCvttXmRRcrvz",
+	"Gb7NYN9RpgAi7xEuMlhp6W17Ph/MEOnoZzUucHZCh9M0mUa3nl3S82VGBx8ACvCSYPYAZ1Z5kQdFGtyo",
+	"bB4VKnh+edbtJzezKA/gv2GQR/NFHE0jNcFNwEg0BB7M325uLvH1oBNMoulUZXkwzdI5/TZdxnFAy1IZ",
+	"L6Cf3M+i8QwgDIgBKwwA/+6iCQA/VzHsAxcXJjBJOJ7h2sbusmFFNYydh58GtJOc9zwNlzGcwXGvXQHA",
+	"Rfgpmi/nDlrxZ7jrTBXLDMdWn0LYp+Lv6+c7TycqLs2zM40+KTythiPHPdBXOM0yV93gFdxGmP8RffiI",
+	"wEjAVfDGR5V0RmGOQJaP24CIAH4eIgnnioFL/873xgzafO9X/OnzXtfdgllaBdfaO+mdyuJwMaAJN8Ht",
+	"jYGXfLbAPfGnwUgV90olAsrNAMzVAi5bkWZlIPYTOtkKDPHimQ8IULQjA5vSZmWI2l7h9tyqwr/V2l5v",
+	"6GWX8vA2Ad941vIOvVssZpnKZ2k8KU3W6x63fTdyQqTOfEO7fPvmzT/lhIHgdXud/W6v5c5MgzEVx2OO",
+	"09AhKbx4Iil+CnHF1x2XV75KY0M6/lempvDhX/Ys69kTvrPnUhkYUsFskwkAe+BcDnebr/QL+h7oqwFk",
+	"BWgqvFxEiaKdd4OfZoBMuSra/WRIsB+M0yXMPqR3cTXLAt4ljlYgcaIxH+V8UNEvKmsHeYpzrPoJgAco",
+	"zf0sLGhSXimc5H0EVClXcBdf8vEQDcBXzCjCdfpJ+RJ3g+siU3DVJsHuEG5CHI2JMO196iSTn/M0GbaA",
+	"luQAshxI2zjMMlhGkvK4AW+FFghbLHDUiFgLbJmGpSc5jIDnpbd51Osx3bO4PrpVnXwexnEH6MXdfvfY",
+	"i/h+XoSXCnZbw+YdS+vjFNYxScfLORw8gAHglyg1ISiNVJDDtgsg4PAvWoS+Gzmw5I2cjVb1oRk1GXYk",
+	"iuiVAWICM1CDWQT7mYZxrto7muK/d0WWfbyQKFL0ygy/p4Fh9ki8KcrygleOC//cLg31TIbaLw/1zD9W",
+	"ruDuTJzBPhheYamwg86w8IOj9sFjBERahLEhT8e9z1UG52y+ephwW4iXAMoBFgf3YQ4rye7wMiGToC/t",
+	"iYzSNFZhgsB2GWZJcsyycGXkRkPUQSCY51vRhR1LdUIcq8I0y2JXiT2CBLYEZFohk4TbNYd1sDjBexEZ",
+	"BX6KpgEgQTwKxyDhjsfLDDCrtR3fKx/Br42MTqg+SIEKQMhwaOM/6ayRCacZEhKA95CBNIQreorjWuJU",
+	"oSbDCqEcysUnWpfiMd5HudIfw5Vz5V4Lpt0Xr65ugp9g/stIAecQKayfjJZRXHRgjQ6PBMmg1Q5gdLjU",
+	"YyPlztQyi4DojpmsmMP1sLLKSZZxtSa4g3CFGy3RbY+YXKEIBssYT9oOwldOrLKARiqisgsc6gwUnTqT",
+	"IzhFSfNlKgwdLIO0k8NRIqEOYR+rAP6P3w4TI585otlgEmVWPPNeQhTZ6su4sJIeoFc4HqsFItVoFQz3",
+	"wkXEYw5L+D6epcnHVWeO6FsIY5gDQYhiwBu4VJ39jWSZ1tI2wPGC1kgHZYCGE+BbfCb13bxQwLcz4X96",
+	"QtwMojZsCL/d+26I2uIiBQxBlbF7220Hw8u31zeBvJApEHEmwxYgLAkIar4oVsGuCFeA4vSaMwgJFlEe",
+	"jmI16QYXLEqNQ+SzyPtHcF94TF5MDl/iFbs+++Fv7y61MADbHKs8rzJfuEgJ8N+58lEYOKHBMvNQu3dX",
+	"5/pGaxXLEIQ9c8eRekdjVZpvVhSLk729OB2H8SzNi5Onvae9HUf+g9vsW4romgPgTfBGsdpEv8OkmMYr",
+	"0J4HcTQKpwNYfYii/gAOO8F9nfKA1zJeRQSEr2BXG7kESYPn/Cp8ertYEg7F8dspcfN13/5w+Q6Pkrir",
+	"1R3CZZHiUB+VWgzCOLpTZd2iV1Ms/pbes4wDB41faVlbEAIwaa7mabYKwinSyzgEOQG4UrD7No7DedjB",
+	"pYHYB8iFGCnYhSiHS0HLypgZWSID8jBEVyZaAQdciBKgx9EdgBJGeQfj/5Da3/l0T4L+zvG8vxPsHgeA",
+	"4SD7IkHv7+zP8Nl+MEuXGT3o4b8TBQqaTNsG1nWLi4e/4RDN1QF+g9vmL4B3pHASQF/aGga4DVk2DQD7",
+	"AF5EItByQZq5Owty6ljdhuMV3KlZeBelWat6X47nPuzEc0rgdoFcpcYfN2HNubx9Si/j5+ntQ/EZPrmt",
+	"oLPgL5kQ0oRECbgwQNsGdUV/G3uCGQONbCoj1c4YV1CHpGHbwQj4GREcwGjBtX6CsD2FfyOmkWiADAhF",
+	"VNZg2QiT3MZKqyLPgXjgWsLYTsLiQFiA8sNSFRwn/LSEg4Ljg4PHvfIDu084sWtGix4NAGeLFxQPV5PY",
+	"yqEeeI0jZTDqNX1dKMLNSu+BqPcT3/bvmbo3bHmAHPzhmz1q2izfAIbhg7cp902ToBrtySNk4WGi0mUe",
+	"r/T1JSJCC2ZFEUUluLzIVkCsAGTJQChMCi1GMxLAm/a6nl+9CxQwGVxYaxtgBG8TGE6BKI7USnDTElsc",
+	"PUmTDoi5aQVwh02A4y0O5qPtgCYQ2QXgXLxoiSGN1iubYlj6YQRaepYKmBpBxHRPA2krqBh1GS7q5C7K",
+	"cYU8aUd0GXOzlzlQ0mCiFmQTB+LOx4LYmBNJRWUHpPBFmoVZhMD+NAZ9mzdyF8ZLRFoQ+dFignwrjyYq",
+	"qCGgGMgS1bnNQrKooASQpXEVnXvPHjcdjL0mD0Vn14ZMozCeNNAEB3ntqcm1xd/QbgyKi7q34+KpIbod",
+	"9w6DaxaTgndJeBdGMYp5rDhdqSJbdZ5PWc0B4GTNZ8mTbcDzpvX3l73eoQqqlpl9v0nQR3S/HMCGoXiX",
+	"aiYqw/r1MhOTQYVjyFAazEcHz4KbNA0uwmQVXFn6CkAON4CZ7OmoNQTRfK4mEehLANgoAe09nOBWcPnI",
+	"/dbDHu13DTtqhH6jzR5pVZhrhUUzjsuSIlOTUapHssD7B8OQdkYHASpFmqC4Z42LKcI2C1Hvc6z2bPUb",
+	"xxGKKMKhQSGfjOGNsnlf9B8gK2+BXjw/499aRKDEuAMaCxrpWMp0SSByRd5nQIoQAlmfLM9Kw6BBQIum",
+	"JO7d3uKfZTFvCherD5flI6wvQWH2Y5LeJ2YeF+6/kkmsY/SZziEroV5Lpc8szUckqnW0/pQUXAnUWEk6",
+	"hVXE4S8r1FxRpAJd9kN70zleqqzD8BbZ2Or06ODIgLjmoDPiaTvnJ0phlNW+sioAwjUS/jMPF8SlkKwJ",
+	"mtt52MWTumz0pJ90grOp8+SvItXrS3JSluhB8IY/nFNrlyTzVm08vjS9kwAhVhkFljIBoTkBHsmfi84S",
+	"TUDHQc/KTyyQMkBmaGfUe+nzScBKna3zboj/akSz2LULny/gTyQFC1bDW/b9snoBagxI/lWZQ7YS7IJ+",
+	"mrhSE62VODPJibCW6BPukiGHaE2blwvhGvQWaVpH6zr2nhi8Y6MLPCAEbMRqMgR57CKAAQH8Aq+i4ICc",
+	"Gni2NfPly5H+NUJ01DYD1DA7kygfI6rmeiNodyKQD3+1k37e0zQp3xuCUFLxw+Sgzr55889W/TNjtcKv",
+	"ylba5o80zeOvruhfns/6iety+b973YI3tqffQ/30Lgrhf3DngTEACuO1QjMpYCnQO5+dU/wmRhioqqC1",
+	"eXwaqewGbkE0FfdYhXCEaJpN4Rr81/OLc70z/T4I4auFaP5zsQSTPTAMWNMEitKGWzaOw4wcqGi0tUpA",
+	"5TIbIzIQnDv4ICSrFuCv8DF0XeFIqKbrU0Zi3k8WxrC1i+/LDbkXEQuGMzcXIGYuL9rQkom8IXOwSQOG",
+	"nvTR11Pio12QwciDlZtrL2EBRfgRTWcgRrBrExGVrpkGVPVw9vhDOZnuKpzHXp91ES8G2sJXP5y3N+eX",
+	"exSKoN9hfifyTE7c9EbFao6ySwAwGCtrbKzZ2I4O958OXaNJm10BBOy2RHMgxBjvtTiFNsPJEkcGZX0R",
+	"cihDlIxTcu39dHiKbk2cGugfSAJDkZ5y8W4ix16ixdJv5cQvBdWNcbMCTO9GfOAUNKkD8jzKC6OfWt4n",
+	"75fouNccdjNTufJYk1xZkLBRkzQOpwH8u0sjIk/kmOtoiMbwSTI2XFZWlM/SZYyKE3p4C9ipIiZgTe+W",
+	"9ulD7tfJOeA9jLu9fgvc0uWdOF3VWPDe65wFwW/RuYsKCu7oLHDVhwcoq1SdL46vquJ7EXgMimiu0mVR",
+	"tnEe9vKdJhUCPyApP0zK6GskVYO9KNGmuI9CtSVCB1bTT0ShC0G5pdFIZ2XdQltoUCGWYw+QCCLYQDoe",
+	"qzh2yA8QEl4+qLgL4G9AR5oNn7ApV/4hu+e8v2PNnWkgo+EhGB2FlWcmseielQmt/ngU/AA4dQ869w3/",
+	"Vr1EDM3aieSHg3GGVK2Iwjh/sDn90FoenVHwpAFlxyDgDqZR7HEIXb66CPDnaIrBBqAgjGcgKrQcZQCp",
+	"KwZg0RMniALO85Z9WshNkPTATB/ViiYatu0NxO9yCuS67gYvUuA8+AZf30zBVQztEa4CdoC0+wloNFla",
+	"hMjpnAUaqso+aHRJAelHNsNKDDDJj2ybw1Xh0YTowEYqUGMNqhjvac4Ni8f/dcdZ4fXtIhRJvxqMwzWg",
+	"HIGeECukcKfPATuyJTmVMZYhuk1EQyttxwlKMQrcHL4iVpvj22HpfHAcPhfACPH5AI7jbLvzJRmGbs6v",
+	"QbS5YndYLgdjUGBI96t8WBshMw7XAUaP4wfJIovucOXwFlNUvDyVNXU3Hwx8vtHPqP1jDQ5G9C9dAiJ4",
+	"Y1r5Z1YdkJ6RixMojtG+SZ+hyAX4NZoDymFcAJzCFr4ljItxF4DhIOveP8Ph312dl775ALsAuQyIYGOM",
+	"VTTxeM5vyD169pI47YQGAHkDLhG6gDk2UbuAdUxByQP8/snxs4P2fm+/1z7oPX3afvbs2YcHOfYbQjQu",
+	"xLqE7NUGM4j9FUR1FQxv5LFsuMuxDa2HhitV0ESHAiC0fKiigWyjhSpBoQ/ZD4bNoCl4+yAqnn5CUTLA",
+	"+PRIYmJJkIPFGCwQJQsgb0QOA3RbZOMQXTitYJKSVoNkL83YSFCKNy7fthkw0bQd3KdZPPmPrWHXGGn1",
+	"UiK7TPR4JQRc36V1oXyloGwJVDb4tikysY5/zWHnb6dT5GQ/L1G4Rus9uTpCitzSAS2lxYhSD0uiXyd6",
+	"ddsFn58lE/XJO6COh/MOtS68XHYgY+qosy9c+5rgPnfErxBo7kR4erGJcEKjlEP23PA9Ha8Lf1dDjw96",
+	"tRDdgx4GwRl4w9oPQaUBDSpKT571er09+Cnfy9QiBYlhMZnu2Fg7r7mxEk7nLOYBYa52OTViYAIlUy1f",
+	"Y+BOMAmLMHh3dRbsDvHPEzdcFFb9PaoCj4/a3W53SNp3P0EBjHTYa/juvK2NvMEQOTCAYBhQFAlJ8kMC",
+	"itZ190ZLEKqKPWDB9JI/FHoO4u6An9Yo49nFKzRgaIHFoHtbG0XFZsEvMfCt9Gn2mmYgFpIYSoHrE9Ak",
+	"xsYSEhW55uIVcaYCm0bzjCdoj6LxpuUAX380llUJvxJ3Mjixxb1o4lRNkZinHCGtd1aiCBSKaOJzKRRR",
+	"zBNkNUIw2zu7ZfRmmSn4RIRtkMfFHOKrduFh/pVOnJk4+W5RCnR3+jUO1e7ThMk2Hu/rZU5rqi4RnisO",
+	"DHaFVjpNOiaWC/BcRDgIQU0tYlasw2CUTlYtDrDWMR/9xOxTnGViYeGbSAJ7vlzg3/lgSssaIpYMxaw2",
+	"lEjCgH/7HuPfHAcgxZ+6RptaCHrwAnRALeT50nAwMKsGCIy0olA6vX2gXLRtieYfoZ+aLdf3KrqdFejN",
+	"VmEyPOFzZXCRxC+JIGg+IigBiUGvOo5+R3ZyFOIIDjxSPgQNNcDfk+D8oGOFMvyCA6Zp3iREqw9MCNIF",
+	"uQ3sYvFNWq0EBEr0DicwTfUZE11T8ZRJmninSrvZwVhPnAUxyeJo9Z3aBZCNeEJV6QeDTkYjqgCxjeTZ",
+	"5LThSUaJPoFucIZkgUIPQBRFb+Qd7jDTh0WBrQWlveFhyVrKKROADsCe9E8VQ1ive9judZ98+ALB0HNF",
+	"my/juYk/rIQl46FzcKIlGMb/jcYwRQaJkZpqWVxc4csk9xuWQon0MM7y/UN9N4JXCVzlFfnOz1GuQYOl",
+	"5p30VVMSGxGFwWhVqHIs0dPDp08f9542mfdyVjQC+pCumA4TE+2DOYbWiMWxN0nv2U1YjY9w/ZW7T4OL",
+	"6EXVwvn4+PiwMYCE5iyv/6B39HRzeAN/yKvNzWrzFkU5ZIbuNK8Vp6ku9Xi/MVBNm1Pr8H78ZB90aJDM",
+	"GtasyZYH8mj25H3gRdtq2Y+PfDDef/zkyZOD/cfe4Hk/+l+gNQSvriODe7Q3Y+tly1UeDOf6wz28nh0U",
+	"5YByUKzMQlu6s/Be0IcNg2K56ycsxAYqAYWYeNMrJEhDgsIQXlbxhMg10akhW7eIXEU5mcfoxbaW3NBX",
+	"0k9YG56I24ipEYgLKsx8V8dv1jqzi23TRIhGzvxsruYtkeSCPpWSsMrKWqysxEpWb9RDR4qGtJRCc17Z",
+	"Pn2mY9DEcOPGSHcr131YScQwZBLwLMxWXrtERTqj0RoUQkZJBwzu6ew8xAfxzWRwv3/E77YaR1ro2hhn",
+	"fmne9kt7jbzk0p2mOUrrOrpNyOwLV2kS3UbMFTMMRXFMkhTBR78AVfjP67dvbIog+fSEpZC42geqnOcS",
+	"GcFL4AxhSbRbhCu8k4BpPfJRcahcQPz08MD5yA1mfUFo5CQmUhAQxVlkHDZTj3idM7XbOXmGInHCf3vj",
+	"qwhgjfbO6XLr0xJpuhmbz4ihaZFaq9vd4FquH0BqmYkDKSep8po5IdmEGYlOgmGfDWpsT+vvDPHFcuIb",
+	"v5rDu+/lZXY7yRcfyp+UZfxda5Zu4QC/9glm/R10aeHoPBT/hc9k/M/toPQq0Sa0lfP7zj9P8EX5q79D",
+	"tgX6dW+R3DpWBRjyc2WltLQO01+89G0W74eiMOByN6+XNJX6en2voiIDb37AZZSdpM4JoM5Fyf4Uwpwl",
+	"YmiyZvvqnaseabCLuYD3YTYJHFe0h3qsz3aUQ28cbWsy2TiN47Co4EzJaZG3tk65LDksHraOEhoQe56S",
+	"Tk1qJ7IKx6cilDM3mFJa4VdYauXfH/5ge88X8BkYC/QGdASWWAc8VLViA/KiCOoA9mmkw3AJPwAnYpXc",
+	"FjNP+mCTw4QoZyNfa8ivNkeNicmguPX2Dw7bHfj/o+PHoMP1njx99qGNzw8Oj+j58eMn+Bwef3iQ8dWd",
+	"qBE9Hc4piv2uFl0YUnxMfhx8uO/hQb6wb2Z2ciDTeHqTtYmtgmuA+nOV+O1S1n9jXrLuRXLwJySU5zbd",
+	"upTP8dSbCmPG8uYEL8RSDOoCnp2qL6FkGHwPkzzMd8nxRevyeZXNg5FYchM33Q1Ow0U4iuIIwYjYF4e/",
+	"RJWw6hxk/0xx+KGxfKdct0M5MbEjrIRis0pdBfArJgATBIcPJ2m5PoqBMXHmG2UsfKm0BrPjMj9HYrW3",
+	"iEPOHd5eo6jYLJtPcU7RnkQTKaaONDe9TMoYMczLGEDcO7qBfkr+s+CS9wZmGVetqVA0/bhy0/BxMFc5",
+	"pv5sJAI8iG/WHy7f+W+7iXVtBhkmF+LRgd7AoVVuiKy/LMRyEg4wsNh7FKfvXj4P9K8u+u0fdL3K2gTz",
+	"zNTAj+YuIzeaN39RGvzNj2cvz54Hz/d7vc71Py+OOke9H154Z8siWFzz8hEc/I53E8eHx9393hHwtmZ9",
+	"ux4HIOs2YEbzDpoTdhGUbUDQTM1j0PIWSzLBIXktx0bge5ujZqqn1oAqF14jPPyw5y4Qr283MPXAMHkK",
+	"I65vXl1dnN28GiCcVHKHYcfBLoVhc9T5KEp0JnEHZHt8hlL+c4pIJ0CU0uNgFEnTQbwB+S9TF+fmEfxq",
+	"kiBkd0iDVzI4wAvHfp1mQGRhqG7wGt7JsfYHDkwVLZxgb/wEAWm/wTmdjwh1vV/RATnf8TJ3QSN5e00U",
+	"X+83nU7xNVw5Pm7rEFyy+lWvWrfkDKAM9TZuaqetD5wnxvCk6dSbtKIDjJpsW+IK1p7Xml2suQpB5rim",
+	"G7XHMpb6X4t+fPH26r739x9u021K/zTFfflCqRo2rQl+SQILdjlTyglEFiNAqwYVo0ZvkusN+J3Lr8/T",
+	"DvJhY7kjdiT6vnAAABS+2UOM6qhKvLEqOjtaXiGFNYo1H6TwV2NCtIdJ5cWulhSKG+zi1fApy1QLZELh",
+	"xp70FfyNQmvzAoYtDX/QOzjq9PY7+8c3+72Tw95Jr/ffvvFvo2IAC577Chv9EKEMgr+hYDUre45HY1BP",
+	"jrxDpmvIf4oOHdqzj/zfpvvdg+NuzzssF6nYUJqC+DS/PdDe0K2qWejUk00fkGB4yqVw4KPGneqwXt82",
+	"QTjs+TZZQVuNc85uGAx22tIBlrCldA5mcz7EL5d1qEdOLSjRZ0L58fIqu+s40A4uSZROJOeGyAHlXcxA",
+	"oHQKn4YYh472T526aNKNbXkBEsDI7YSxzbmNNNPR45gNucxAajaF+gj5xc+T3mO9ur2ZCuNi9stQdKqc",
+	"cpXzNPj7ElTPRKGXSAKdOTjgXk0w8XKRghryUhI6MPMNt2k0llx5PYa1BZXMDYeeWoNYB4YSHPBTypwC",
+	"QObiD2SfdmkLbFJdJvxotdaLdVjzvHkD7uDP7C6MN+cuYGC+U1uSFgqgrucJlAMl9+cPiCPRKnWwm0rK",
+	"U8uq9xxBB/N2g1eUhiPcPre/ONLObyjO58vi2G+ChHtGoXsDUOtEsUZnbUykxiBqjnzM7W3gdpx7KULt",
+	"vroUqEFvlfnZa3sbwWirHW8IHtCHTQTvSgaoED5j1/uN35tUxd/0fVV1M4tp2325c3xogmPezPK3SFbf",
+	"XsX2lwFWJpXQhJHYWgpWtDI5QiaXs41pAmQLYE+tTYG3GWPdQKehY82tkeJ7FSYrLqNcynePrH1hm/zb",
+	"90255h98CCsnMgD9BFUBT/qDSQafwyskF5NsSbl+CWYKYhCVPtjhg2ohutY6z7m4t6BiCTUHMa5m34bx",
+	"fbjKbfXkPhc77O+0yvYYXQJxi9JwD7LXaHT/Uoiaa7M1SOsW0DXLWwtUVU2E3s5x4M/sqz3rRE87/yoe",
+	"mtvH1OJLoWqoztZQvZIv1kLVLM/YDDcVZxhnaZ53KCqEkuVHkfnH5gINNxKGTJurUJ9ucOoODeLRWAdt",
+	"/WupMo4NBGIKPDv4GSOj49X3/cROL5GxHLdXCSkUWySXJsxnmFw9oEGHOk+SLCc+IqUh1Lk76MwP0axT",
+	"AoCPNJW4UBOqVnaf1/GxMvMD0M5XhrPKvNwb5WNkPg75EFPlm1o5LJRqhGlwgqM3/aHJ5l8br2rvt6Ui",
+	"Nhcmda1uawzDfIUelvD1diGVy8YU1tEBGZBCykzs9NlLJ2CH8VmCi/i2Y51VDmLNh/0EC01HIpuevaRw",
+	"uiKXz7jwDE2TUxlnEhixPAna8zBVnSrpYuKZTI5Bj5iLJklKIWVG00UTiUBq89cyn2GAzv4TDozvHD0w",
+	"vxmLiNJl9phZAaASspubdYxUTMHFVIHZVE9HSm4Ao3U9U+MQM4bNu1wbLI5qcsc21dWdPhOd/GO06KRy",
+	"oh3Kx4A3uFK1qB9lOX8RRlTs11uYkmCQl8qdd4Oh/mRotQ79JpHJXSJUbTnzVoDv9xM4YSo6xjcAadw9",
+	"VREjvRjjf1amVlG3SvJEn7aUFR1eHASXKYp9jsQOR+PwxN8HkYT2wXzG4YR+I0uCzZR5P+EcwjJ/Kcet",
+	"t8qmVAd07nK9XGVDBEGFttakAFvRpKbeVYiuN34AweFj5JRPDUeSudedo16pcBaOzNGJkotM3ljSFLRl",
+	"U2dVUG4+HwgOGLlV2ym4L9/DqnIxJWVLeDNSApMbr9OHajf5DAvHTZbcjoNKKJYDdNjc+5IMM/IomC6T",
+	"SYhzA97j7w+6/XyKnl4wYQbYzQg4JWUkVndYVkDTgdLZVJcZOFkmub8g+GLgsaBdkf2GS2eRIYiqGiUr",
+	"Q4RY2GIq0w5m0e2MQmr5QlKKH4C059xVauJBgYBBjFHhmYmvd2KgBWmM+Qg9CzJL3byyLiivIcaAgWyR",
+	"cx03e2Bmrb5U83KUhLljPuDL1nzgF1JvqAdLebsa0AThlo5eo0OUpHnDRYYOcX/FgiTVyHdZZaSTPS23",
+	"1CmYJc/9AyRpXrrX003I4dusi9HKCXYx/LtUcJ5CYFo7XzerArFDFrgWKYh/1SUcj2/ipfbtClxNTLVA",
+	"9lEeDEE0gtsj3NmJP7lHI8EtGnx9aEOntiFz13uY/sxdv8hRORVjE+SBd7bqvlLKs6VF6+maYbw2wue3",
+	"xYvwHRz+Bibmd3rrhQZImiP0+y4zXQ2gwslbDud+kEroj85odH1fR/MIy2oVqwsFI4w9jESkRmBSxNRA",
+	"3BmnOfAKoRuTtOAWM7keCokEE5wsoD4z9scWqJRDtQT+PME0K24WMAFFiBBmlzL9a9+U5RienERlRB4z",
+	"mFeQsdvz51eHHLWHoXpPP7Tf73d7GJyHoXkj9xcM1RPw6PlrAXqhJ5mRMtYlhV0i8r5Hnwdc4KG+cJq6",
+	"ogeWAkXD99GHoSto8pfEOUfDbxS6Fw7E3bnVJjR54G/EMF4pt8HBVrp+znO6XCeuWLGXjgtVdLh/D2bc",
+	"OQkxKLm/o8pUpvTpMJRkD5vjsSq8nQRGPmmIusxUj2Kcxss5gPXnxtOonUAoRzMcvf/5w7c6jVHjaXg3",
+	"8occx2jb45gbwrJdr4IaSSo3LdD37/NaarZGDDPLedgimuWQa+JzAIQs+iTtDof8LtxkxBL/5f6GGFSV",
+	"U3gLawWVahUff0KUN4qk5qVaU2KjmoKyjnlqil8px7I+XqSxdkul3M4XVr7hSj4npjIcFRIQQ2CpkHFF",
+	"NwbFnXOtUXAemvG63AFNWhb6GxLBp0Nup0MeCfMt3FX9fKGcx62v3/pMD14a929ft8aOPafGwg9oLd3c",
+	"hIqKiYx1PVHdtbPeZGl9t4L1Vafa3O+U5bI/qKjU2iJMTQ2nOP0H6YzxF9C7Tb26UOHw5CHBI/yPzkb6",
+	"jy+woruFq8zC23LYPkT5keNk1oR//TsMS4b9lgFPD4xq8p1kteCj9t3aso//7tb8727NzfjS0J+oXqCX",
+	"3yt3RibW4NbzRM9ODV9iUATiL+2bdE6D0CmtYvWlo13TIF45eLuFlDwreFtqXhUNMLi8I0SZVcBwsNr4",
+	"RI2WVCmJP78PuRe0zsqw1EReqLd922qXpaVS9UzA2MblSsKU6EEE7G7wSH/GjaNB9QPM/4V7veRprNrB",
+	"IxTCpK10kS3JOjOhtHP4DdY1nRf8K3ebVtNpNCY/w0e1+itbqNHJAkLBoyRNF7pBdQyvdB2QOcvHCclF",
+	"imNjrRv4rAw25+WNoGuok1v3Ko+xOR9VNvXRpec/XQf8CpU1PXvppInDA667mK+SIvzEO1RjUDWDOE0/",
+	"LheYORDH1jMKgw2en56+ur4e/P3Vfw3OXmKCRJSlCblaqD47umYiU6u+HF63SpdZhxfTgbk7kVfKaa5t",
+	"fn3ohrab+uZSZvdRftgN5+EvaRLe51148RHK349sCXCsmcfHeBElZ2/Lkf3Vj6kCVnLOaafY/baeR0aQ",
+	"Glj4+4EvALVn8KUHcP3q9OrVjXMOv+EQeBLnLLyJcvAb7KOppaXx3fMu6V1pcEnXSvo4rQKnWPSD9u5b",
+	"Ns3CapFvySA5D/I83phy/CohGF1fn+/dnF/T3NeHSDsSRWbR3LisTrA0Mtu9YZ/tgLzZ0nMLg/0MKnkS",
+	"6zZS8i1bS3riIqn52wDR2lcWC83RsdSvl3cDfJdqxu+dXXIBqDgC3q4LIOXUYoJK7bfJ4UZjc+sDGQHF",
+	"Iiydpssk4ziAZSMAxMeBPBxEC24GDUArxwO/13/K7RpPkm75yf6zA5BRD7oPDMXTwAC6MNsWGPguFglB",
+	"TVs3DKPSkhwAhSUlMbGoBhSawwVKF/OlzMdYDjgcAf9ZFkreFeK09y5HDzoGrO21+COeBT+RopW8Hv3F",
+	"fNWR51x+KN+rwtMdE8lV7YOHwbF2jhtv0Qv8otSQy6JGkGFxdNj//sET1Dy6vb2nIAT3nL+fwFE/pn/t",
+	"g2SMp7//+Cn/+zH8+/Ez0ICO5N8tb56mRl7dDGDA/cfLKz/s+WLJUxYpKPjnLppg/TY9WoBXjX3lKFLr",
+	"Md0E8J7jd95vKq1lVocFsjwFtvZ7R0+Pnzzu9daWNAOs1QNJKzvuCMnltsptv8x4a5ziZV0Dnj4+MkXL",
+	"KDurkje/RfUyTkK+jybFbG/GJflgfQu4Wxiqy7U8TEvKTOG2yp3KefB1EK1T08+fRU4lGxKwkHFhfYM7",
+	"z4nSwhyU4kbtQHK4bqDUzpYjpDcikE9Guny7J/5S1AhubihNPqgRGJN+mzbDlV4JnzCprMMpnaY3Thf7",
+	"/fzlL8FPGNQHo8nA1KNJ5gD6focNtzRXOXdG5+wdswJHBHp+eUblfb77zlY0+EElgr3ffXcS3OhEcafm",
+	"yu7p+dllqxb+ywPRB7rZEY5wjf2wimhsY7BpPZQ8J8GFRAFNZ3DdlJ7HMx2QcCzrTc5URwewMeOn+BaJ",
+	"hOAvXy9RZMfP3ry6wpYDITD/qfg3uE3Prez1rlKALFjEIbb7oT5L+lZzwexCUedL7ICpMLBFYwajQzdK",
+	"uaqyYYvm6BQFAKHfxHN8GKmZLbGtNiwLe1Mqsv3a/l7wO6NkgJYFQDk6t3M6bAvKyqFzfxB4maSsy7NA",
+	"R7GBokJAqmOEWxrBSsglAz99aU6V7ATmaPHFq+c/wN1dAM9MeBb31HSoGJHOOWItFgDU0Ukhpi7jJ6cY",
+	"nUllRnWDFNLFMRKJ/N/kJc6iEUb1gZg64YkukXuMVx3qJ8Gvly4CZelKwS44QOzVgWIhvpGFRslryZG9",
+	"ViH+U07wL4HvijCmcRoKYpqL1aUWY7oaXmNjMR4JDomG2e5c9A1hkycKBViwCweQSmVOzxdUXPVOLuxd",
+	"FnFa7jQPyCnCZrs0IP4cOP1Rf+bOUJhCwtTbUoN8EWKAI41ERTDcdbEbUedN57qmty9zekjRXihF8WCS",
+	"nHxqgIIDvqPkmFIRKFH0d4e/qQyYFPwaCizwvp5iBg5OxoBhbAWCQoEYDMYMfWqA5HE7uItyFAZM/MNK",
+	"Q71EGauI89rSP3OZdJKKiZ9uBf/bxTBnDEx7JDxb4WDPG5vbNXao47FOOSAZxzjocJ/04ObmXDcOxnhl",
+	"OdnXQqRp7SUVs9pOTseyYRqduUuGqm+7B/dieTbidM3jEf+xRPL0i+Fjz8sd5RFr/sWv2NbFsFQDagd/",
+	"8fNStCmRPB1juCvRpbMQ2+HkUqpTB5amCfvd4misxCuhBQyQZq6o3i8Aw0Tol6UNy1OwN3zM+Y4FqbOa",
+	"cQDBcHKJ0UwfxotZuE+d3lgpxNRQkIIxMsmoOHzypBimvhKn14uYijBy2Uts6iN1E7mSt1SIzjUTKDPw",
+	"SrKVll4uBGkZAwjhbVfEOq5z2UGU78TCxkJRjisrjBghbRDw5Xe6XdpfTTIXPn6NKcjI7LG2BprPgHNE",
+	"Y70MwqsLc53qwoqprqGJjHuxO/JpA613gqDLN+3BNyaQm4n/0lGdFNlO7YidbudImw8Cvrp5lz7jfkQm",
+	"022EbamkxyC1KnQc8fqoqiwFHxvDC9e5tQVdpbgRlj7hQBJpxsflyN2YEnJrw10E1Bc8orRvqTKky2pa",
+	"rlUb4FMnmcgg1xSZwsVvxe9Dgj1FfKLogdJTnmJZlFyC6aWn4ITqmWMyB8y2nCN1QfGLHA74Dpq0uNdp",
+	"huKW6R9A8cZOcgeq9lbw4bMKJMWYfKa0QmnyGmaF6S+PCEkrZMWAzOOyen0Ar1izwX8Nh0Pccj/5FYd3",
+	"q0KazukBNjM3KyMO1uaX+ZyZx8EDfES4xQPILWnrn0r9Q/CVY9AF9Y/lhiP8q/lRMsDSjAfuw8Lhvzv4",
+	"8+d+8pl2QYTQqMZnE92c4YYdPmIGeJFOVlolkzYhVRTCZ+wW2Sp9VId4fC67m9AgwcHTXMwVxzro9b72",
+	"3OKOxrl9mPzA8XgTvkgaoi+Ye0cmWSxmS0aZo6+4I67c5VnBWQKST2RKYuO8x7/PvKLbiP6s5EUshzaf",
+	"U8Qco5iHkeXqlq4xvb7HvcKa+eFpmsAEZKPSHcY09XYLZNDdepTbcIzvJT4eGTSZYpzGY13PheCGWDc6",
+	"3OFb3IlyX7Xf+VJU+o35sNkAeCLNwf4MKK2NqgnIYWQuQIq9LDrptHMHOvhoGaO6ZZCjxes8+vbrZMlZ",
+	"eyEpAgi9NlMsR/2nuod88hZEfO846q/x2omijdIFx9xaI4QYX90iiyyUxhWbSN6VTubGmkE9lo1mzdYo",
+	"Urgxl8LVs0WTZG/Up6LNSWJoUcEoj0iufb1olmMr0wYlHMPRoesy8YXtGbNRmHT7kds+wjAmisCUdU27",
+	"0B1rbCPnNCBHhi1Q4axGG+86qe2K8913GvFr4YKtEy3tuUWfRZ2z+6+OQ2aG8qemDCXLkMY2ICAq2RC4",
+	"LX2mdGEyBphJkPymtcp9fZqb65dvVbKcjQ3fpmC5p5pb6wuViTSRNPAgIofsmCwf4r3BEYBcL5m6oO1U",
+	"lEQ6jmlMZn1ugH2HQwBmY6Ij9rdKs4/fWPMoRbMb8xiQqwiNjBz5o9WONicrmoCOVpMeU2pBwLTAqccS",
+	"ur0L8rJcz3iMWOcW36th10ld+nekd08XbpH6iSziS+8raI/4VM3tBNS2EjrQIW8z2DoqrV+btw+Fb32k",
+	"P2y8J2GwmKVYTn1KVcgKvDSeT7/s5kgVRrlAOHwJMGz8Y9eoNCcoN3tpOa3XJV0CCTii5ImMAtcnDjqv",
+	"xUzhBRL+jO7gv/4f2GcXlhfUWtE/7T3tWTbVpFtp3mntuN9IoCz1rUCu7oHNA0er9eD5ncXUco33qu7m",
+	"EpPymBX3NlGZjqYyalKvyF7KmGloUlOXt+yRag/Wn0jz+x3F3j+npKsvnkP9HWF3z22A6pd6X7HxmHOr",
+	"C2lFG9qSBLrV4eXL10BzcjbVRqLfir3KcB9gNRTPT8/bkgam+6jJy8S64N9RVmqoi+1281ov3X5ipDb+",
+	"xG6zy/5SWaa1gVMlC4n2OnErW1hBFOMREP8xW5QSXsyigL7BzmWwIf0IM4e3un9gOAFZ3BWzh5WWmJyZ",
+	"+vLt6T/7iWO9u8Qi6wze3aEtuz5skUmNrdFGsOxaS7VpSYk5EUDSS9HDOBOJUZQmwVbf4RZGva3Yul68",
+	"w8QaeuH+548vXl1FvU/nP//j7yVzoPY+uQbBmtXvYK3V76C3yaqnL4DGhN+HA1VbJ/8RLKPWptZDQuz9",
+	"0L3a/kwGjnnExu+5tis4dEeHFOBlaf2RpP5o//Dbz3vjtuBFIz5loQvFNEVHhA9wtQAiluiQcNnh/vG3",
+	"X6ttnY0l3yhs160ZxAWskXD+qRgl91G0CMZcUsdkAU1qKiiTV6oO67LdSH2xgjztVzw5XBezWIrfF3C7",
+	"LTxEnKZY3cmkBnFY0Cy95zI14hiWAnpcoo2dRbgrfjfMTQuUd7mCS0wFOIDVYC4EJSDkWGDLrjalUsfL",
+	"hLgw1iz2ktDijFMkvhnBKhVL95ygLmzuQKcm6BQmB6361p4txb32INEexT0/62Ul28a9xsdSLeJn+Ko2",
+	"h7EZqx4q4Ded4bv/MDEAFPeOFXepf++wEz0dwiWaTqNP2mgiHlye5HlT/dBgV+frkt3gMl6iMLZavyrX",
+	"OyxmEFPaa+OWKrEPXGaZovdN8T5Tq7ZiaXSMjGhH5HQnbU+0wowXRc/h5C50McVvhqWVCr9N/CHXETh/",
+	"FDvFrt5/RvfXuc/eyzeU8aZZDbniEEuFpLQp+sVER9h6YtQ4iAuNsQGQkLfp7p5y9MyVLswmDaTYzmtK",
+	"t2G32JN+st8NRDfS8+n6bHwrjHTfTw66MGZC+cpw+Uz1tn5y2A2uFdU2re5J9y1EyV32N+R1UE/bPLpN",
+	"pFWoyQ8ssOgpMhnqpI4R/bksmaLZx7DsdI4hMbawXJzeRuO6+d9GkmzjAKhceWMgrYU07fJXA/NDN02S",
+	"TxzcVg6JwuQBDIX4V50iluOiaOE36aLzhnbN5S5udDVIUm4YbYZai8LnHKjBNaBsTS6Ul7QAStnlXVsO",
+	"Tj4jDZDSzwUZpZhZqYSZNF4kldHWIHuU23RWqhuFR1/A+qTQGZwQnierolQxhWN7xSJeKYOGhT2xfiYc",
+	"sgk9hj0ZTOaAFDGrE4p566qYEBauUsh9z205vHGmuPQfLAn021jK3PWTkp2dlgQCx6VcQ/uMFGm0JGJP",
+	"XNMwmp48yu3eMgQqLRhJfylQRpfAl7qpUgKnElxTqdXHp9BP8AB09RmcEgAOp0Vhu3EcmAn1MmxBOqoS",
+	"NVrxMbWoZpkcvcUHyYhO0kRsb9RQWzhD11Oyju5gddLv7flTQcifufEUoQ5wijb6K6QhXRkddOO6j0l6",
+	"n3DXOioHhL27YavUYoKPpZ+cuVUxKTTo28cFVUwI5apkxgJAhJnfWlvk0XwglNCx1vdrVS0vZKRzGekk",
+	"IJp5u4wm6KtBQdtaIXAAKnip3w5eOwUvT4I3apnBhU1UwTVWAfD0cc0gjzlQmuJLcKAFOp1nNRazTdlS",
+	"heErnRwWCOPF0WjPfIp9zccfKSGF7Es6sNOS8M0VQMuSEgtzcle/kQmkXDz5d7Z9VGpdfnmsU7kGZF3y",
+	"uTQFPgnJ/238/iPlTZz9d7DHmJBxo6csEyvY7vq0nVZFGOYhrPC6smIri8Q2tndNaLRUi/aUggtvQ6yJ",
+	"5ivUJ7IwtkOWYtT9xBrOQX5Bk76pW6xd8ZOyrZDEU22FA/nVupOwDD/xiB+lBpwp/Q4fA6MhVzVlaqJM",
+	"GGKRqBHnV2wqFof9mCQcBj6USnT0vf27XByu1aUIX12NDmV3Vqqt0dykCBpBmGqSiSRsKkqaQOCTgB85",
+	"wde48Ped/Xaw/+H74BeVpWZCFil6NBKWozwBMBYS/IuSIqYZkXTOQ06pnSRsu+Kio1gWpzrlSfBK/72x",
+	"RuVmbk0tFrmkZMA1Jfn5qPK8HVAZyoDrUAqnJ2CJo502gdyxOciWEc7WsPtGLKheXfN3ZkOeen8eOmLf",
+	"0kX69I38UxniFZtxKPgJe41G+ZzE+onT77n154q0ZTAGurS8JoAOUSUia8rGNZJYXfQtXx9cK0ZBaxEu",
+	"bCE2EbRJHavXNdPeS/kXVRWzNJgst/JTDIsXZVDKIOG6fFKeXvQ3DGevFi38ne9XrRZfUzC6PqH/L+Wy",
+	"/wGxuPog6XrxpXRqwq2111dKxLVBVdel7bRfBf0BbHuo16jr+jwdP5qacd8MdavVAT2Ak1fKnow/wnpc",
+	"87Hc+VZGr9ER51RD1196AJO++S2TXIiRYzufP3z+fyCRsKUPyQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -18,19 +18,26 @@
 package termite
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/chunking"
 	"github.com/antflydb/antfly-go/libaf/embeddings"
 	"github.com/antflydb/antfly-go/libaf/s3"
 	"github.com/antflydb/antfly-go/libaf/scraping"
+	termchunking "github.com/antflydb/termite/pkg/termite/lib/chunking"
+	"github.com/antflydb/termite/pkg/termite/lib/hugot"
+	termreranking "github.com/antflydb/termite/pkg/termite/lib/reranking"
 	"github.com/bytedance/sonic/decoder"
 	"github.com/bytedance/sonic/encoder"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -49,14 +56,20 @@ func NewTermiteAPI(logger *zap.Logger, node *TermiteNode) http.Handler {
 		node:   node,
 	}
 	return HandlerWithOptions(api, StdHTTPServerOptions{
-		BaseURL:    "/api",
-		BaseRouter: http.NewServeMux(),
+		BaseURL:     "/api",
+		BaseRouter:  http.NewServeMux(),
+		Middlewares: []MiddlewareFunc{tracingMiddleware},
 	})
 }
 
 // GenerateEmbeddings implements ServerInterface
 func (t *TermiteAPI) GenerateEmbeddings(w http.ResponseWriter, r *http.Request) {
-	t.node.handleApiEmbed(w, r)
+	withCompression(t.node.withRequestQueue(t.node.withIdempotency(t.node.handleApiEmbed)))(w, r)
+}
+
+// GenerateDocumentEmbeddings implements ServerInterface
+func (t *TermiteAPI) GenerateDocumentEmbeddings(w http.ResponseWriter, r *http.Request) {
+	t.node.handleApiEmbedDocument(w, r)
 }
 
 // ChunkText implements ServerInterface
@@ -66,7 +79,22 @@ func (t *TermiteAPI) ChunkText(w http.ResponseWriter, r *http.Request) {
 
 // RerankPrompts implements ServerInterface
 func (t *TermiteAPI) RerankPrompts(w http.ResponseWriter, r *http.Request) {
-	t.node.handleApiRerank(w, r)
+	withCompression(t.node.handleApiRerank)(w, r)
+}
+
+// ComputeSimilarity implements ServerInterface
+func (t *TermiteAPI) ComputeSimilarity(w http.ResponseWriter, r *http.Request) {
+	withCompression(t.node.handleApiSimilarity)(w, r)
+}
+
+// TokenizeText implements ServerInterface
+func (t *TermiteAPI) TokenizeText(w http.ResponseWriter, r *http.Request) {
+	t.node.handleApiTokenize(w, r)
+}
+
+// DecodeTokens implements ServerInterface
+func (t *TermiteAPI) DecodeTokens(w http.ResponseWriter, r *http.Request) {
+	t.node.handleApiDecode(w, r)
 }
 
 // ListModels implements ServerInterface
@@ -79,14 +107,31 @@ func (t *TermiteAPI) ListModels(w http.ResponseWriter, r *http.Request) {
 
 	if t.node.cachedChunker != nil {
 		resp.Chunkers = t.node.cachedChunker.ListModels()
+		resp.ChunkerDetails = chunkerDetails(resp.Chunkers)
 	}
 
 	if t.node.embedderProvider != nil {
 		resp.Embedders = t.node.embedderProvider.List()
+		resp.EmbedderDetails = t.node.embedderDetails(resp.Embedders)
 	}
 
 	if t.node.rerankerRegistry != nil {
 		resp.Rerankers = t.node.rerankerRegistry.List()
+		resp.RerankerTypes = make(map[string]ModelsResponseRerankerTypes)
+		types := t.node.rerankerRegistry.Types()
+		for name, rerankerType := range types {
+			resp.RerankerTypes[name] = ModelsResponseRerankerTypes(rerankerType)
+		}
+		for _, name := range resp.Rerankers {
+			resp.RerankerDetails = append(resp.RerankerDetails, RerankerModelInfo{
+				Name: name,
+				Type: RerankerModelInfoType(types[name]),
+			})
+		}
+	}
+
+	if len(t.node.modelAliases) > 0 {
+		resp.Aliases = t.node.modelAliases.ByModel(resp.Embedders, resp.Rerankers)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -97,6 +142,47 @@ func (t *TermiteAPI) ListModels(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// embedderDetails reports the capabilities of each named embedder. Models
+// the lazy registry has not loaded yet are listed without capabilities rather
+// than being loaded just to describe them.
+func (ln *TermiteNode) embedderDetails(names []string) []EmbedderModelInfo {
+	details := make([]EmbedderModelInfo, 0, len(names))
+	for _, name := range names {
+		info := EmbedderModelInfo{Name: name}
+		if ln.lazyEmbedderRegistry != nil && !ln.lazyEmbedderRegistry.IsLoaded(name) && !ln.isCustomEmbedder(name) {
+			details = append(details, info)
+			continue
+		}
+
+		embedder, err := ln.embedderProvider.Get(name)
+		if err != nil {
+			// Removed by a concurrent reload
+			continue
+		}
+		caps := embedder.Capabilities()
+		info.Loaded = true
+		info.Dimensions = caps.Dimensions
+		info.DefaultDimension = caps.DefaultDimension
+		info.SupportedMimeTypes = getMIMETypeList(caps)
+		info.SupportsFusion = caps.SupportsFusion
+		details = append(details, info)
+	}
+	return details
+}
+
+// chunkerDetails distinguishes the built-in fixed-size strategies from
+// chunker models loaded from the models directory.
+func chunkerDetails(names []string) []ChunkerModelInfo {
+	details := make([]ChunkerModelInfo, len(names))
+	for i, name := range names {
+		details[i] = ChunkerModelInfo{
+			Name:    name,
+			Builtin: name == termchunking.ModelFixedBert || name == termchunking.ModelFixedBPE,
+		}
+	}
+	return details
+}
+
 // GetVersion implements ServerInterface
 func (t *TermiteAPI) GetVersion(w http.ResponseWriter, r *http.Request) {
 	resp := VersionResponse{
@@ -114,64 +200,141 @@ func (t *TermiteAPI) GetVersion(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleApiEmbed handles embedding generation requests using Ollama-compatible API
-// with OpenAI-compatible multimodal extension for CLIP models.
-func (ln *TermiteNode) handleApiEmbed(w http.ResponseWriter, r *http.Request) {
-	defer func() { _ = r.Body.Close() }()
+// GetInfo implements ServerInterface
+func (t *TermiteAPI) GetInfo(w http.ResponseWriter, r *http.Request) {
+	gpuInfo := hugot.GetGPUInfo()
+	resp := InfoResponse{
+		Backend: hugot.BackendName(),
+		GpuMode: GPUMode(hugot.GetGPUMode()),
+		Gpu: GPUInfo{
+			Available:     gpuInfo.Available,
+			Type:          gpuInfo.Type,
+			DeviceName:    gpuInfo.DeviceName,
+			DriverVersion: gpuInfo.DriverVer,
+			CudaVersion:   gpuInfo.CUDAVersion,
+		},
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Models:    t.node.modelCounts(),
+	}
 
-	// Check if embedder provider is available
-	if ln.embedderProvider == nil {
-		http.Error(w, "embedding not available: no models configured", http.StatusServiceUnavailable)
+	w.Header().Set("Content-Type", "application/json")
+	if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
+		t.logger.Error("encoding response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+}
 
-	// Apply backpressure via request queue
-	release, err := ln.requestQueue.Acquire(r.Context())
-	if err != nil {
-		switch err {
-		case ErrQueueFull:
-			RecordQueueRejection()
-			WriteQueueFullResponse(w, 5*time.Second)
-		case ErrRequestTimeout:
-			RecordQueueTimeout()
-			WriteTimeoutResponse(w)
-		default:
-			// Context cancelled
-			http.Error(w, "request cancelled", http.StatusRequestTimeout)
+// modelCounts reports how many models each registry can serve and has loaded.
+// Only the lazy embedder registry loads models on demand; the other registries
+// load every model they discover.
+func (ln *TermiteNode) modelCounts() ModelCounts {
+	var counts ModelCounts
+
+	switch {
+	case ln.lazyEmbedderRegistry != nil:
+		names := ln.embedderProvider.List()
+		counts.Embedders.Available = len(names)
+		for _, name := range names {
+			if ln.isCustomEmbedder(name) || ln.lazyEmbedderRegistry.IsLoaded(name) {
+				counts.Embedders.Loaded++
+			}
 		}
-		return
+	case ln.embedderProvider != nil:
+		n := len(ln.embedderProvider.List())
+		counts.Embedders = RegistryModelCounts{Available: n, Loaded: n}
 	}
-	defer release()
 
-	// Update queue metrics
-	UpdateQueueMetrics(ln.requestQueue.Stats())
+	if ln.cachedChunker != nil {
+		n := len(ln.cachedChunker.ListModels())
+		counts.Chunkers = RegistryModelCounts{Available: n, Loaded: n}
+	}
+
+	if ln.rerankerRegistry != nil {
+		n := len(ln.rerankerRegistry.List())
+		counts.Rerankers = RegistryModelCounts{Available: n, Loaded: n}
+	}
 
-	// Decode the request using generated types
+	return counts
+}
+
+// handleApiEmbed handles embedding generation requests using Ollama-compatible API
+// with OpenAI-compatible multimodal extension for CLIP models.
+//
+// Backpressure is applied by withRequestQueue, before withIdempotency buffers
+// the body. The request timeout applies again here because idempotent
+// requests run detached from the client's context.
+func (ln *TermiteNode) handleApiEmbed(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	r, cancel := ln.withRequestTimeout(r)
+	defer cancel()
+
+	// Decode the request using generated types. Multipart uploads carry
+	// their inputs already read, as raw files.
+	limits := ln.embedLimits.withDefaults()
 	var req EmbedRequest
-	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, fmt.Sprintf("decoding request: %v", err), http.StatusBadRequest)
+	var contents [][]ai.ContentPart
+	var err error
+	if isMultipartForm(r) {
+		req, contents, err = parseEmbedMultipart(r, limits)
+		if err != nil {
+			status := requestBodyStatus(err)
+			var limitErr embedLimitError
+			if errors.As(err, &limitErr) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+	} else if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decoding request: %v", err), requestBodyStatus(err))
 		return
 	}
 
-	// Validate model
+	// Validate the request before looking up the model, so that a malformed
+	// request is a 400 whether or not the node serves embedders
 	if req.Model == "" {
 		http.Error(w, "model is required", http.StatusBadRequest)
 		return
 	}
+	if err := checkPrecision(req.Precision); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if contents == nil {
+		if !hasEmbedInput(req.Input) {
+			http.Error(w, "input is required", http.StatusBadRequest)
+			return
+		}
+
+		// Reject oversized batches before loading the model or downloading images
+		if err := limits.checkCount(embedInputCount(req.Input)); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+	}
 
 	// Get embedder from provider (lazy loads if needed)
-	embedder, err := ln.embedderProvider.Get(req.Model)
+	modelName := ln.modelAliases.Resolve(req.Model)
+	embedder, releaseEmbedder, err := ln.acquireEmbedder(modelName)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("model not found: %s", req.Model), http.StatusNotFound)
+		http.Error(w, modelErrorMessage(req.Model, modelName, err), errorStatus(err))
 		return
 	}
+	defer releaseEmbedder()
 
 	// Parse input - supports text strings, arrays, and multimodal content parts
 	// Uses scraping package for URL downloads with security config and S3 credentials
-	contents, err := parseEmbedInput(r.Context(), req.Input, ln.contentSecurityConfig, ln.s3Credentials)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("invalid input: %v", err), http.StatusBadRequest)
-		return
+	if contents == nil {
+		contents, err = parseEmbedInput(r.Context(), req.Input, ln.contentSecurityConfig, ln.s3Credentials)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid input: %v", err), http.StatusBadRequest)
+			return
+		}
 	}
 
 	if len(contents) == 0 {
@@ -179,34 +342,64 @@ func (ln *TermiteNode) handleApiEmbed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := limits.checkSize(contents); err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
 	// Validate MIME types against embedder capabilities
 	if err := validateContentTypes(contents, embedder.Capabilities()); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	// Multi-part items need fusion, which needs a model that supports it
+	if err := checkFusion(req.Fusion, contents, req.Model, embedder.Capabilities()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		attrModel.String(modelName),
+		attrBatchSize.Int(len(contents)),
+	)
+
+	releaseModel, err := ln.modelLimiter.Acquire(r.Context(), modelName)
+	if err != nil {
+		writeModelLimitError(w, modelName, err)
+		return
+	}
+	defer releaseModel()
+
 	// Wrap embedder with caching for deduplicated requests
-	cachedEmbedder := ln.embeddingCache.WrapEmbedder(embedder, req.Model)
+	cachedEmbedder := ln.embeddingCache.WrapEmbedder(embedder, modelName)
 
-	// Generate embeddings (with caching and singleflight deduplication)
-	embeds, err := cachedEmbedder.Embed(r.Context(), contents)
+	// Generate embeddings (with caching and singleflight deduplication),
+	// one per item
+	embeds, err := embedWithFusion(r.Context(), cachedEmbedder, contents, req.Fusion)
 	if err != nil {
+		if requestTimedOut(r) {
+			ln.logger.Warn("embedding timed out",
+				zap.String("model", modelName),
+				zap.Duration("timeout", ln.requestTimeout))
+			WriteTimeoutResponse(w)
+			return
+		}
 		ln.logger.Error("failed to generate embeddings",
-			zap.String("model", req.Model),
+			zap.String("model", modelName),
 			zap.Error(err))
 		http.Error(w, fmt.Sprintf("generating embeddings: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Determine response format based on Accept header
-	acceptHeader := r.Header.Get("Accept")
-
-	switch acceptHeader {
+	switch negotiateContentType(r, "application/octet-stream", "application/json") {
 	case "application/json":
-		// JSON response using Ollama-compatible format
+		// JSON response using Ollama-compatible format, rounded to the
+		// requested precision
 		resp := EmbedResponse{
 			Model:      req.Model,
-			Embeddings: embeds,
+			Embeddings: roundEmbeddings(embeds, req.Precision),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
@@ -226,10 +419,25 @@ func (ln *TermiteNode) handleApiEmbed(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// hasEmbedInput reports whether input is present and not an empty string or
+// array. Items are validated when the input is parsed.
+func hasEmbedInput(input EmbedRequest_Input) bool {
+	raw, err := input.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	switch string(bytes.TrimSpace(raw)) {
+	case "", "null", `""`, "[]":
+		return false
+	}
+	return true
+}
+
 // parseEmbedInput parses the EmbedRequest input which can be:
 // - A single text string
 // - An array of text strings (Ollama-compatible)
 // - An array of ContentPart objects (OpenAI-compatible multimodal)
+// - An array of multi-part items, each an array of ContentPart objects
 //
 // For image_url content, supports:
 // - Data URIs: data:image/png;base64,...
@@ -256,36 +464,65 @@ func parseEmbedInput(
 		return [][]ai.ContentPart{{ai.TextContent{Text: str}}}, nil
 	}
 
+	// Try multi-part items before single parts: an array of arrays would
+	// otherwise be accepted as an array of (unrecognized) content parts
+	if items, err := input.AsEmbedRequestInput3(); err == nil && len(items) > 0 {
+		contents := make([][]ai.ContentPart, len(items))
+		for i, parts := range items {
+			if len(parts) == 0 {
+				return nil, fmt.Errorf("item at index %d has no content parts", i)
+			}
+			contents[i] = make([]ai.ContentPart, len(parts))
+			for j, part := range parts {
+				content, err := parseContentPart(ctx, part, securityConfig, s3Creds)
+				if err != nil {
+					return nil, fmt.Errorf("part %d of item at index %d: %w", j, i, err)
+				}
+				contents[i][j] = content
+			}
+		}
+		return contents, nil
+	}
+
 	// Try multimodal content parts (OpenAI-compatible)
 	if parts, err := input.AsEmbedRequestInput2(); err == nil && len(parts) > 0 {
 		contents := make([][]ai.ContentPart, len(parts))
 		for i, part := range parts {
-			// Try text content
-			if textPart, err := part.AsTextContentPart(); err == nil {
-				contents[i] = []ai.ContentPart{ai.TextContent{Text: textPart.Text}}
-				continue
+			content, err := parseContentPart(ctx, part, securityConfig, s3Creds)
+			if err != nil {
+				return nil, fmt.Errorf("content part at index %d: %w", i, err)
 			}
+			contents[i] = []ai.ContentPart{content}
+		}
+		return contents, nil
+	}
 
-			// Try image URL content
-			if imgPart, err := part.AsImageURLContentPart(); err == nil {
-				// Use scraping package - handles data:, http://, https://, file://, s3://
-				mimeType, data, err := scraping.DownloadContent(ctx, imgPart.ImageUrl.Url, securityConfig, s3Creds)
-				if err != nil {
-					return nil, fmt.Errorf("downloading image at index %d: %w", i, err)
-				}
-				contents[i] = []ai.ContentPart{ai.BinaryContent{
-					MIMEType: mimeType,
-					Data:     data,
-				}}
-				continue
-			}
+	return nil, errors.New("input must be a string, array of strings, array of content parts, or array of multi-part items")
+}
+
+// parseContentPart converts a text or image URL content part, downloading
+// the image
+func parseContentPart(
+	ctx context.Context,
+	part ContentPart,
+	securityConfig *scraping.ContentSecurityConfig,
+	s3Creds *s3.Credentials,
+) (ai.ContentPart, error) {
+	// Both variants decode from any part, so select on the type field
+	if textPart, err := part.AsTextContentPart(); err == nil && textPart.Type == TextContentPartTypeText {
+		return ai.TextContent{Text: textPart.Text}, nil
+	}
 
-			return nil, fmt.Errorf("unknown content type at index %d", i)
+	if imgPart, err := part.AsImageURLContentPart(); err == nil && imgPart.Type == ImageURLContentPartTypeImageUrl {
+		// Use scraping package - handles data:, http://, https://, file://, s3://
+		mimeType, data, err := scraping.DownloadContent(ctx, imgPart.ImageUrl.Url, securityConfig, s3Creds)
+		if err != nil {
+			return nil, fmt.Errorf("downloading image: %w", err)
 		}
-		return contents, nil
+		return ai.BinaryContent{MIMEType: mimeType, Data: data}, nil
 	}
 
-	return nil, errors.New("input must be a string, array of strings, or array of content parts")
+	return nil, errors.New("unknown content type")
 }
 
 // validateContentTypes checks that all content types in the input are supported
@@ -331,14 +568,17 @@ func getMIMETypeList(caps embeddings.EmbedderCapabilities) []string {
 func (ln *TermiteNode) handleApiChunk(w http.ResponseWriter, r *http.Request) {
 	defer func() { _ = r.Body.Close() }()
 
+	r, cancel := ln.withRequestTimeout(r)
+	defer cancel()
+
 	// Apply backpressure via request queue
 	release, err := ln.requestQueue.Acquire(r.Context())
 	if err != nil {
-		switch err {
-		case ErrQueueFull:
+		switch {
+		case errors.Is(err, ErrQueueFull):
 			RecordQueueRejection()
 			WriteQueueFullResponse(w, 5*time.Second)
-		case ErrRequestTimeout:
+		case errors.Is(err, ErrRequestTimeout):
 			RecordQueueTimeout()
 			WriteTimeoutResponse(w)
 		default:
@@ -357,12 +597,20 @@ func (ln *TermiteNode) handleApiChunk(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate the request
-	if req.Text == "" {
+	// Validate the request; blank text has nothing to chunk
+	if strings.TrimSpace(req.Text) == "" {
 		http.Error(w, "text is required", http.StatusBadRequest)
 		return
 	}
 
+	// Streamed chunks carry no token counts, so a tokenizer for them is
+	// rejected rather than ignored
+	streaming := negotiateContentType(r, "application/json", ndjsonContentType) == ndjsonContentType
+	if streaming && req.EmbeddingModel != "" {
+		http.Error(w, "embedding_model is not supported with streaming responses", http.StatusBadRequest)
+		return
+	}
+
 	// Convert ChunkConfig to internal chunkConfig type
 	internalConfig := chunkConfig{
 		Model:         req.Config.Model,
@@ -373,27 +621,74 @@ func (ln *TermiteNode) handleApiChunk(w http.ResponseWriter, r *http.Request) {
 		Threshold:     req.Config.Threshold,
 	}
 
+	// Unknown names fall back to the fixed chunker, so they share its limit
+	limiterKey := ln.cachedChunker.limiterModel(internalConfig.Model)
+	releaseModel, err := ln.modelLimiter.Acquire(r.Context(), limiterKey)
+	if err != nil {
+		writeModelLimitError(w, limiterKey, err)
+		return
+	}
+	defer releaseModel()
+
+	// Stream chunks as NDJSON when requested
+	if streaming {
+		ln.streamChunkResponse(w, r, req.Text, internalConfig, limiterKey)
+		return
+	}
+
+	// Count tokens with the tokenizer of the embedder the chunks are for
+	var counter termchunking.TokenCounter
+	if req.EmbeddingModel != "" {
+		embedModel := ln.modelAliases.Resolve(req.EmbeddingModel)
+		embedder, releaseEmbedder, err := ln.acquireEmbedder(embedModel)
+		if err != nil {
+			http.Error(w, modelErrorMessage(req.EmbeddingModel, embedModel, err), errorStatus(err))
+			return
+		}
+		defer releaseEmbedder()
+		tc, ok := embedder.(termchunking.TokenCounter)
+		if !ok {
+			http.Error(w, fmt.Sprintf("embedding model %q does not support token counting", req.EmbeddingModel), http.StatusBadRequest)
+			return
+		}
+		counter = tc
+	}
+
 	// Use cached chunker to process the request
-	chunks, cacheHit, err := ln.cachedChunker.Chunk(r.Context(), req.Text, internalConfig)
+	result, cacheHit, err := ln.cachedChunker.Chunk(r.Context(), req.Text, internalConfig)
 	if err != nil {
+		if requestTimedOut(r) {
+			ln.logger.Warn("chunking timed out", zap.Duration("timeout", ln.requestTimeout))
+			WriteTimeoutResponse(w)
+			return
+		}
 		ln.logger.Error("chunking failed", zap.Error(err))
 		http.Error(w, fmt.Sprintf("chunking text: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Record metrics
-	modelUsed := internalConfig.Model
-	if modelUsed == "" {
-		modelUsed = "default"
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		attrModel.String(limiterKey),
+		attrBatchSize.Int(len(result.Chunks)),
+		attrCacheHit.Bool(cacheHit),
+	)
+	RecordChunkerRequest(limiterKey)
+	RecordChunkCreation(limiterKey, len(result.Chunks))
+
+	if counter != nil {
+		result.TokenCounts = countChunkTokens(counter, result.Chunks)
 	}
-	RecordChunkerRequest(modelUsed)
-	RecordChunkCreation(modelUsed, len(chunks))
 
 	// Build response
 	resp := ChunkResponse{
-		Chunks:   chunks,
-		Model:    internalConfig.Model,
-		CacheHit: cacheHit,
+		Chunks:      result.Chunks,
+		Model:       internalConfig.Model,
+		CacheHit:    cacheHit,
+		TokenCounts: result.TokenCounts,
+	}
+	for _, n := range result.TokenCounts {
+		resp.TotalTokens += n
 	}
 
 	// Return response
@@ -405,24 +700,79 @@ func (ln *TermiteNode) handleApiChunk(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ndjsonContentType selects the streaming chunk and rerank responses.
+const ndjsonContentType = "application/x-ndjson"
+
+// streamChunkResponse writes chunks as newline-delimited JSON, flushing each
+// chunk as soon as the chunker produces it. Errors that occur before the first
+// chunk are reported with a 500 status; later errors are written as a final
+// {"error": "..."} line since the status has already been sent. Metrics are
+// recorded under modelUsed.
+func (ln *TermiteNode) streamChunkResponse(w http.ResponseWriter, r *http.Request, text string, config chunkConfig, modelUsed string) {
+	rc := http.NewResponseController(w)
+	enc := encoder.NewStreamEncoder(w)
+
+	numChunks := 0
+	cacheHit, err := ln.cachedChunker.ChunkStream(r.Context(), text, config, func(chunk chunking.Chunk) error {
+		if numChunks == 0 {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.WriteHeader(http.StatusOK)
+		}
+		numChunks++
+		if err := enc.Encode(chunk); err != nil {
+			return err
+		}
+		// Flushing is best-effort; writers without Flush still receive every chunk
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		ln.logger.Error("streaming chunking failed",
+			zap.Int("chunks_sent", numChunks),
+			zap.Error(err))
+		if numChunks == 0 {
+			if requestTimedOut(r) {
+				WriteTimeoutResponse(w)
+				return
+			}
+			http.Error(w, fmt.Sprintf("chunking text: %v", err), http.StatusInternalServerError)
+			return
+		}
+		_ = enc.Encode(Error{Error: err.Error()})
+		return
+	}
+	if numChunks == 0 {
+		// An empty stream is still a well-formed NDJSON response
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	RecordChunkerRequest(modelUsed)
+	RecordChunkCreation(modelUsed, numChunks)
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		attrModel.String(modelUsed),
+		attrBatchSize.Int(numChunks),
+		attrCacheHit.Bool(cacheHit),
+	)
+}
+
 // handleApiRerank handles reranking requests
 func (ln *TermiteNode) handleApiRerank(w http.ResponseWriter, r *http.Request) {
 	defer func() { _ = r.Body.Close() }()
 
-	// Check if reranking is available
-	if ln.rerankerRegistry == nil || len(ln.rerankerRegistry.List()) == 0 {
-		http.Error(w, "reranking not available", http.StatusServiceUnavailable)
-		return
-	}
+	r, cancel := ln.withRequestTimeout(r)
+	defer cancel()
 
 	// Apply backpressure via request queue
 	release, err := ln.requestQueue.Acquire(r.Context())
 	if err != nil {
-		switch err {
-		case ErrQueueFull:
+		switch {
+		case errors.Is(err, ErrQueueFull):
 			RecordQueueRejection()
 			WriteQueueFullResponse(w, 5*time.Second)
-		case ErrRequestTimeout:
+		case errors.Is(err, ErrRequestTimeout):
 			RecordQueueTimeout()
 			WriteTimeoutResponse(w)
 		default:
@@ -436,13 +786,9 @@ func (ln *TermiteNode) handleApiRerank(w http.ResponseWriter, r *http.Request) {
 	UpdateQueueMetrics(ln.requestQueue.Stats())
 
 	// Decode request
-	var req struct {
-		Model   string   `json:"model"`   // Model name to use (required)
-		Query   string   `json:"query"`   // Query text
-		Prompts []string `json:"prompts"` // Pre-rendered document texts to rerank
-	}
+	var req RerankRequest
 	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), requestBodyStatus(err))
 		return
 	}
 
@@ -459,22 +805,80 @@ func (ln *TermiteNode) handleApiRerank(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "prompts are required", http.StatusBadRequest)
 		return
 	}
+	if len(req.Ids) > 0 && len(req.Ids) != len(req.Prompts) {
+		http.Error(w,
+			fmt.Sprintf("ids must have one entry per prompt: got %d ids for %d prompts", len(req.Ids), len(req.Prompts)),
+			http.StatusBadRequest)
+		return
+	}
+	if req.TopN < 0 {
+		http.Error(w, "top_n must not be negative", http.StatusBadRequest)
+		return
+	}
+	// The best results are only known once every batch is scored
+	streaming := negotiateContentType(r, "application/json", ndjsonContentType) == ndjsonContentType
+	if streaming && req.TopN > 0 {
+		http.Error(w, "top_n is not supported when streaming results", http.StatusBadRequest)
+		return
+	}
+	mode := termreranking.Mode(req.Mode)
+	switch mode {
+	case "", termreranking.ModePairwise, termreranking.ModeSharedQuery:
+	default:
+		http.Error(w, fmt.Sprintf("invalid mode: %s", req.Mode), http.StatusBadRequest)
+		return
+	}
 
 	// Get model from registry
-	reranker, err := ln.rerankerRegistry.Get(req.Model)
+	modelName := ln.modelAliases.Resolve(req.Model)
+	reranker, releaseReranker, err := ln.acquireReranker(modelName)
+	if err != nil {
+		http.Error(w, modelErrorMessage(req.Model, modelName, err), errorStatus(err))
+		return
+	}
+	defer releaseReranker()
+
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		attrModel.String(modelName),
+		attrBatchSize.Int(len(req.Prompts)),
+	)
+
+	// Only bi-encoders can reuse a single query embedding
+	if mode == termreranking.ModeSharedQuery && termreranking.TypeOf(reranker) != termreranking.TypeBiEncoder {
+		http.Error(w,
+			fmt.Sprintf("mode %s requires a bi-encoder reranker, %s is a %s", mode, req.Model, termreranking.TypeOf(reranker)),
+			http.StatusBadRequest)
+		return
+	}
+
+	releaseModel, err := ln.modelLimiter.Acquire(r.Context(), modelName)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("model not found: %s", req.Model), http.StatusNotFound)
+		writeModelLimitError(w, modelName, err)
 		return
 	}
+	defer releaseModel()
 
 	// Wrap reranker with caching for deduplicated requests
-	cachedReranker := ln.rerankingCache.WrapReranker(reranker, req.Model)
+	cachedReranker := ln.rerankingCache.WrapRerankerWithMode(reranker, modelName, mode)
+
+	// Stream batches of results as NDJSON when requested
+	if streaming {
+		ln.streamRerankResponse(w, r, cachedReranker, modelName, req)
+		return
+	}
 
 	// Rerank prompts (with caching and singleflight deduplication)
-	scores, err := cachedReranker.Rerank(r.Context(), req.Query, req.Prompts)
+	results, scores, err := cachedReranker.RerankTop(r.Context(), req.Query, req.Prompts, req.TopN, req.MinScore)
 	if err != nil {
+		if requestTimedOut(r) {
+			ln.logger.Warn("reranking timed out",
+				zap.String("model", modelName),
+				zap.Duration("timeout", ln.requestTimeout))
+			WriteTimeoutResponse(w)
+			return
+		}
 		ln.logger.Error("reranking failed",
-			zap.String("model", req.Model),
+			zap.String("model", modelName),
 			zap.String("query", req.Query),
 			zap.Int("num_prompts", len(req.Prompts)),
 			zap.Error(err))
@@ -482,9 +886,16 @@ func (ln *TermiteNode) handleApiRerank(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Label results with the caller's document IDs; the cache only sees texts
+	if len(req.Ids) > 0 {
+		for i := range results {
+			results[i].Id = req.Ids[results[i].Index]
+		}
+	}
+
 	// Record metrics
-	RecordRerankerRequest(req.Model)
-	RecordRerankingCreation(req.Model, len(req.Prompts))
+	RecordRerankerRequest(modelName)
+	RecordRerankingCreation(modelName, len(req.Prompts))
 
 	// Validate response
 	if len(scores) != len(req.Prompts) {
@@ -495,18 +906,17 @@ func (ln *TermiteNode) handleApiRerank(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ln.logger.Info("reranking request completed",
-		zap.String("model", req.Model),
+		zap.String("model", modelName),
 		zap.String("query", req.Query),
 		zap.Int("num_prompts", len(req.Prompts)),
-		zap.Int("num_scores", len(scores)))
+		zap.Int("num_scores", len(scores)),
+		zap.Int("num_results", len(results)))
 
 	// Send response
-	resp := struct {
-		Model  string    `json:"model"`
-		Scores []float32 `json:"scores"`
-	}{
-		Model:  req.Model,
-		Scores: scores,
+	resp := RerankResponse{
+		Model:   req.Model,
+		Scores:  scores,
+		Results: results,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -516,3 +926,118 @@ func (ln *TermiteNode) handleApiRerank(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// rerankStreamBatchSize is the number of prompts scored per batch when
+// streaming rerank results
+const rerankStreamBatchSize = 32
+
+// streamRerankResponse writes rerank results as newline-delimited JSON,
+// flushing the results of each batch of prompts as soon as it is scored.
+// Errors that occur before the first batch are reported with a 500 status;
+// later errors are written as a final {"error": "..."} line.
+func (ln *TermiteNode) streamRerankResponse(w http.ResponseWriter, r *http.Request, reranker *CachedReranker, modelName string, req RerankRequest) {
+	rc := http.NewResponseController(w)
+	enc := encoder.NewStreamEncoder(w)
+
+	batches, numResults := 0, 0
+	_, err := reranker.RerankStream(r.Context(), req.Query, req.Prompts, rerankStreamBatchSize, req.MinScore, func(results []RerankResult) error {
+		if batches == 0 {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.WriteHeader(http.StatusOK)
+		}
+		batches++
+		for _, result := range results {
+			if len(req.Ids) > 0 {
+				result.Id = req.Ids[result.Index]
+			}
+			if err := enc.Encode(result); err != nil {
+				return err
+			}
+			numResults++
+		}
+		// Flushing is best-effort; writers without Flush still receive every result
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		ln.logger.Error("streaming reranking failed",
+			zap.String("model", modelName),
+			zap.Int("results_sent", numResults),
+			zap.Error(err))
+		if batches == 0 {
+			if requestTimedOut(r) {
+				WriteTimeoutResponse(w)
+				return
+			}
+			http.Error(w, fmt.Sprintf("reranking failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+		_ = enc.Encode(Error{Error: err.Error()})
+		return
+	}
+
+	RecordRerankerRequest(modelName)
+	RecordRerankingCreation(modelName, len(req.Prompts))
+	ln.logger.Info("streaming reranking request completed",
+		zap.String("model", modelName),
+		zap.Int("num_prompts", len(req.Prompts)),
+		zap.Int("num_results", numResults))
+}
+
+// withRequestTimeout bounds r by the configured request_timeout, covering
+// queue wait and inference. Model backends observe the deadline through the
+// request context.
+func (ln *TermiteNode) withRequestTimeout(r *http.Request) (*http.Request, context.CancelFunc) {
+	if ln.requestTimeout <= 0 {
+		return r, func() {}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), ln.requestTimeout)
+	return r.WithContext(ctx), cancel
+}
+
+// withRequestQueue applies backpressure via the request queue, holding a slot
+// while next runs
+func (ln *TermiteNode) withRequestQueue(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r, cancel := ln.withRequestTimeout(r)
+		defer cancel()
+
+		release, err := ln.requestQueue.Acquire(r.Context())
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrQueueFull):
+				RecordQueueRejection()
+				WriteQueueFullResponse(w, 5*time.Second)
+			case errors.Is(err, ErrRequestTimeout):
+				RecordQueueTimeout()
+				WriteTimeoutResponse(w)
+			default:
+				// Context cancelled
+				http.Error(w, "request cancelled", http.StatusRequestTimeout)
+			}
+			return
+		}
+		defer release()
+
+		// Update queue metrics
+		UpdateQueueMetrics(ln.requestQueue.Stats())
+
+		next(w, r)
+	}
+}
+
+// requestTimedOut reports whether r failed because its request_timeout expired
+func requestTimedOut(r *http.Request) bool {
+	return errors.Is(r.Context().Err(), context.DeadlineExceeded)
+}
+
+// modelNotFoundMessage describes a failed model lookup, naming the alias
+// target when the requested name is an alias.
+func modelNotFoundMessage(requested, resolved string) string {
+	if requested != resolved {
+		return fmt.Sprintf("model not found: %s (alias of %s)", requested, resolved)
+	}
+	return fmt.Sprintf("model not found: %s", requested)
+}
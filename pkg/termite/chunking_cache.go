@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"time"
 
@@ -43,9 +44,10 @@ type CachedChunker struct {
 
 // ChunkResult stores chunking results with metadata
 type ChunkResult struct {
-	Chunks   []chunking.Chunk `json:"chunks"`
-	Model    string           `json:"model"`
-	CachedAt time.Time        `json:"cached_at"`
+	Chunks      []chunking.Chunk `json:"chunks"`
+	TokenCounts []int            `json:"token_counts"` // Parallel to Chunks
+	Model       string           `json:"model"`
+	CachedAt    time.Time        `json:"cached_at"`
 }
 
 // NewCachedChunker creates a new cached chunker with model registry support
@@ -114,10 +116,11 @@ type chunkConfig struct {
 	Threshold     float32 `json:"threshold"`
 }
 
-// Chunk performs chunking with two-tier caching
-func (cc *CachedChunker) Chunk(ctx context.Context, text string, config chunkConfig) ([]chunking.Chunk, bool, error) {
+// Chunk performs chunking with two-tier caching. The result includes the
+// token count of each chunk under the tokenizer of the model that produced it.
+func (cc *CachedChunker) Chunk(ctx context.Context, text string, config chunkConfig) (ChunkResult, bool, error) {
 	if text == "" {
-		return nil, false, nil
+		return ChunkResult{}, false, nil
 	}
 
 	// Compute cache key based on config and text hash
@@ -129,7 +132,7 @@ func (cc *CachedChunker) Chunk(ctx context.Context, text string, config chunkCon
 			zap.Uint64("cache_key", cacheKey),
 			zap.String("model", item.Value().Model),
 			zap.Int("num_chunks", len(item.Value().Chunks)))
-		return item.Value().Chunks, true, nil
+		return item.Value(), true, nil
 	}
 
 	// Cache miss: Use singleflight to deduplicate concurrent identical requests
@@ -152,9 +155,10 @@ func (cc *CachedChunker) Chunk(ctx context.Context, text string, config chunkCon
 		}
 
 		result := ChunkResult{
-			Chunks:   chunks,
-			Model:    model,
-			CachedAt: time.Now(),
+			Chunks:      chunks,
+			TokenCounts: cc.countTokens(model, chunks),
+			Model:       model,
+			CachedAt:    time.Now(),
 		}
 
 		// Store in memory cache
@@ -175,15 +179,79 @@ func (cc *CachedChunker) Chunk(ctx context.Context, text string, config chunkCon
 	}
 
 	if err != nil {
-		return nil, false, err
+		return ChunkResult{}, false, err
 	}
 
-	result := v.(ChunkResult)
-	return result.Chunks, false, nil
+	return v.(ChunkResult), false, nil
+}
+
+// ChunkStream performs chunking like Chunk but emits each chunk as soon as it
+// is produced, so callers can start consuming early chunks of large documents.
+// Cached results are replayed from memory; fresh results are cached once the
+// stream completes. Streaming requests bypass singleflight deduplication since
+// every caller needs its own stream.
+func (cc *CachedChunker) ChunkStream(ctx context.Context, text string, config chunkConfig, emit termchunking.EmitFunc) (bool, error) {
+	if text == "" {
+		return false, nil
+	}
+
+	cacheKey := cc.computeCacheKey(text, config)
+
+	// Replay cached chunks
+	if item := cc.memCache.Get(cacheKey); item != nil {
+		cc.logger.Debug("Chunk cache hit (memory)",
+			zap.Uint64("cache_key", cacheKey),
+			zap.String("model", item.Value().Model),
+			zap.Int("num_chunks", len(item.Value().Chunks)))
+		for _, chunk := range item.Value().Chunks {
+			if err := emit(chunk); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	}
+
+	var chunks []chunking.Chunk
+	model, err := cc.streamChunks(ctx, text, config, func(chunk chunking.Chunk) error {
+		chunks = append(chunks, chunk)
+		return emit(chunk)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	cc.memCache.Set(cacheKey, ChunkResult{
+		Chunks:      chunks,
+		TokenCounts: cc.countTokens(model, chunks),
+		Model:       model,
+		CachedAt:    time.Now(),
+	}, ttlcache.DefaultTTL)
+
+	cc.logger.Info("Streaming chunking completed and cached",
+		zap.Uint64("cache_key", cacheKey),
+		zap.String("model", model),
+		zap.Int("num_chunks", len(chunks)),
+		zap.Int("text_length", len(text)))
+
+	return false, nil
 }
 
 // performChunking executes the actual chunking logic based on model
 func (cc *CachedChunker) performChunking(ctx context.Context, text string, config chunkConfig) (chunks []chunking.Chunk, model string, err error) {
+	model, err = cc.streamChunks(ctx, text, config, func(chunk chunking.Chunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return chunks, model, nil
+}
+
+// streamChunks runs the requested model, emitting chunks as they are produced.
+// If the ONNX model fails before emitting anything, it falls back to the fixed
+// chunker; once chunks have been emitted the error is returned instead.
+func (cc *CachedChunker) streamChunks(ctx context.Context, text string, config chunkConfig, emit termchunking.EmitFunc) (model string, err error) {
 	model = config.Model
 
 	// Build per-request options from config
@@ -198,15 +266,21 @@ func (cc *CachedChunker) performChunking(ctx context.Context, text string, confi
 			cc.logger.Debug("Using ONNX model from registry",
 				zap.String("model", model))
 
-			chunks, err = chunker.Chunk(ctx, text, opts)
-			if err != nil {
-				cc.logger.Warn("ONNX model failed, falling back to fixed-bert-tokenizer",
-					zap.String("model", model),
-					zap.Error(err))
-				// Fall through to fixed chunker
-			} else {
-				return chunks, model, nil
+			emitted := 0
+			err = termchunking.ChunkStream(ctx, chunker, text, opts, func(chunk chunking.Chunk) error {
+				emitted++
+				return emit(chunk)
+			})
+			if err == nil {
+				return model, nil
 			}
+			if emitted > 0 {
+				return "", fmt.Errorf("chunking failed with model %s: %w", model, err)
+			}
+			cc.logger.Warn("ONNX model failed, falling back to fixed-bert-tokenizer",
+				zap.String("model", model),
+				zap.Error(err))
+			// Fall through to fixed chunker
 		} else {
 			cc.logger.Debug("Model not found in registry, falling back to fixed-bert-tokenizer",
 				zap.String("requested", model),
@@ -216,14 +290,37 @@ func (cc *CachedChunker) performChunking(ctx context.Context, text string, confi
 
 	// Use fixed chunker as fallback
 	cc.logger.Debug("Using fixed chunker")
-	chunks, err = cc.fixedChunker.Chunk(ctx, text, opts)
 	model = termchunking.ModelFixedBert
+	if err := termchunking.ChunkStream(ctx, cc.fixedChunker, text, opts, emit); err != nil {
+		return "", fmt.Errorf("chunking failed with model %s: %w", model, err)
+	}
 
-	if err != nil {
-		return nil, "", fmt.Errorf("chunking failed with model %s: %w", model, err)
+	return model, nil
+}
+
+// countTokens counts the tokens of each chunk with the tokenizer of the model
+// that produced it, falling back to the fixed chunker's BERT tokenizer for
+// models that cannot count tokens themselves.
+func (cc *CachedChunker) countTokens(model string, chunks []chunking.Chunk) []int {
+	counter, _ := cc.fixedChunker.(termchunking.TokenCounter)
+	if chunker, err := cc.registry.Get(model); err == nil {
+		if tc, ok := chunker.(termchunking.TokenCounter); ok {
+			counter = tc
+		}
+	}
+	if counter == nil {
+		return nil
 	}
+	return countChunkTokens(counter, chunks)
+}
 
-	return chunks, model, nil
+// countChunkTokens counts the tokens of each chunk with counter
+func countChunkTokens(counter termchunking.TokenCounter, chunks []chunking.Chunk) []int {
+	counts := make([]int, len(chunks))
+	for i, chunk := range chunks {
+		counts[i] = counter.CountTokens(chunk.Text)
+	}
+	return counts
 }
 
 // buildChunkOptions converts internal chunkConfig to the chunking.ChunkOptions type.
@@ -293,6 +390,16 @@ func (cc *CachedChunker) logCacheStats(ctx context.Context) {
 	}
 }
 
+// limiterModel returns the name a chunking request for model is limited and
+// recorded under: the model itself when it is available, otherwise "default"
+// for the fixed chunker that serves empty and unknown names
+func (cc *CachedChunker) limiterModel(model string) string {
+	if slices.Contains(cc.ListModels(), model) {
+		return model
+	}
+	return "default"
+}
+
 // ListModels returns all available chunker models and strategies
 func (cc *CachedChunker) ListModels() []string {
 	models := cc.registry.List()
@@ -301,6 +408,12 @@ func (cc *CachedChunker) ListModels() []string {
 	return all
 }
 
+// Reload re-scans the chunker models directory (see ChunkerRegistry.Reload).
+// Cached results for models that are still present remain valid.
+func (cc *CachedChunker) Reload() (*ReloadResult, error) {
+	return cc.registry.Reload()
+}
+
 // Close releases resources
 func (cc *CachedChunker) Close() error {
 	cc.cancel()
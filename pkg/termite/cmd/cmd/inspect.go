@@ -0,0 +1,66 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/antflydb/termite/pkg/termite/lib/cli"
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <hf:owner/repo>",
+	Short: "Show the ONNX variants available in a HuggingFace repo",
+	Long: `List the ONNX variants a HuggingFace repo offers before pulling it.
+
+For each variant, shows a description and the total download size
+(model file plus tokenizer and config files).
+
+Examples:
+  # Inspect a public repo
+  termite inspect hf:onnx-community/embeddinggemma-300m-ONNX
+
+  # Inspect a gated or private repo
+  termite inspect --hf-token hf_xxx hf:my-org/private-model
+
+  # Print JSON for scripting
+  termite inspect -o json hf:onnx-community/embeddinggemma-300m-ONNX`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInspect,
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+
+	inspectCmd.Flags().String("hf-token", "",
+		"HuggingFace API token for gated or private models (or use HF_TOKEN env var)")
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	hfToken, _ := cmd.Flags().GetString("hf-token")
+
+	repoID, isHF := modelregistry.ParseHuggingFaceRef(args[0])
+	if !isHF {
+		return fmt.Errorf("inspect requires a HuggingFace reference (hf:owner/repo), got %q", args[0])
+	}
+
+	return cli.InspectHuggingFace(repoID, cli.InspectOptions{
+		HFToken:    hfToken,
+		BinaryName: "termite",
+		Output:     viper.GetString("output"),
+	})
+}
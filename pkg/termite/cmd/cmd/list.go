@@ -16,6 +16,7 @@ package cmd
 import (
 	"github.com/antflydb/termite/pkg/termite/lib/cli"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var listCmd = &cobra.Command{
@@ -34,7 +35,10 @@ Examples:
   termite list --remote
 
   # Filter by model type
-  termite list --type embedder`,
+  termite list --type embedder
+
+  # Print JSON for scripting
+  termite list --remote -o json`,
 	RunE: runList,
 }
 
@@ -55,6 +59,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		ModelsDir:   modelsDir,
 		TypeFilter:  typeFilter,
 		BinaryName:  "termite",
+		Output:      viper.GetString("output"),
 	}
 
 	if remote {
@@ -34,16 +34,23 @@ Models are downloaded to the appropriate directory based on their type:
   - Rerankers: models/rerankers/<model-name>/
 
 Variants:
-  f32     - FP32 baseline (default, highest accuracy)
+  f32     - FP32 baseline (highest accuracy)
   f16     - FP16 half precision (~50% smaller)
   i8      - INT8 dynamic quantization (smallest, fastest CPU)
   i8-st   - INT8 static quantization (calibrated)
   i4      - INT4 quantization
 
+Without --variants (or --variant for hf:, file://, s3:// and gs:// pulls),
+embedders default to INT8 (i8, or quantized) when the model has it, and
+chunkers and rerankers default to full precision.
+
 Examples:
-  # Pull default FP32 model
+  # Pull the default variant for the model's type
   termite pull bge-small-en-v1.5
 
+  # Pull the FP32 model of an embedder
+  termite pull --variants f32 bge-small-en-v1.5
+
   # Pull only INT8 variant (smaller download)
   termite pull --variants i8 bge-small-en-v1.5
 
@@ -57,7 +64,21 @@ Examples:
   termite pull --models-dir /opt/antfly/models bge-small-en-v1.5
 
   # Pull directly from HuggingFace
-  termite pull hf:onnx-community/embeddinggemma-300m-ONNX --type embedder`,
+  termite pull hf:onnx-community/embeddinggemma-300m-ONNX --type embedder
+
+  # Install a model staged on a local or mounted volume (air-gapped)
+  termite pull file:///mnt/models/embeddinggemma-300m-ONNX --type embedder
+
+  # Download a model staged in S3 or GCS (credentials from the AWS default
+  # chain or Google Application Default Credentials)
+  termite pull s3://my-bucket/models/bge-small-en-v1.5 --type embedder
+  termite pull gs://my-bucket/models/bge-small-en-v1.5 --type embedder --variant fp16
+
+  # Show what a HuggingFace pull would download without downloading it
+  termite pull hf:onnx-community/embeddinggemma-300m-ONNX --type embedder --variant q4 --dry-run
+
+  # Share identical files between models directories on the same node
+  termite pull --store-dir /var/lib/termite/store --models-dir /models bge-small-en-v1.5`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runPull,
 }
@@ -67,19 +88,28 @@ func init() {
 
 	// Pull command flags
 	pullCmd.Flags().StringSliceVar(&variants, "variants", nil,
-		"Variant IDs to download (f32,f16,i8,i8-st,i4). Defaults to f32 if not specified.")
+		"Variant IDs to download (f32,f16,i8,i8-st,i4). Defaults to i8 for embedders that have it, f32 otherwise.")
 	pullCmd.Flags().String("type", "",
-		"Model type (embedder, chunker, reranker) - required for hf: pulls")
+		"Model type (embedder, chunker, reranker) - required for hf:, file://, s3:// and gs:// pulls")
 	pullCmd.Flags().String("hf-token", "",
 		"HuggingFace API token for gated models (or use HF_TOKEN env var)")
 	pullCmd.Flags().String("variant", "",
-		"ONNX variant for HuggingFace models (fp16, q4, q4f16, quantized)")
+		"ONNX variant for HuggingFace, local and object storage models (fp32, fp16, q4, q4f16, quantized). Defaults to quantized for embedders that have it, fp32 otherwise.")
+	pullCmd.Flags().Bool("validate", false,
+		"Validate HuggingFace, local and object storage ONNX files (requires a build with -tags=\"onnx,ORT\")")
+	pullCmd.Flags().String("store-dir", "",
+		"Content-addressed store; files are kept once by SHA-256 and linked into the models directory")
+	pullCmd.Flags().Bool("dry-run", false,
+		"Print the files, total size and target directory of HuggingFace, local and object storage pulls without downloading")
 }
 
 func runPull(cmd *cobra.Command, args []string) error {
 	modelTypeStr, _ := cmd.Flags().GetString("type")
 	hfToken, _ := cmd.Flags().GetString("hf-token")
 	variant, _ := cmd.Flags().GetString("variant")
+	storeDir, _ := cmd.Flags().GetString("store-dir")
+	validate, _ := cmd.Flags().GetBool("validate")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	for _, modelRef := range args {
 		fmt.Printf("\n=== Pulling %s ===\n", modelRef)
@@ -91,6 +121,39 @@ func runPull(cmd *cobra.Command, args []string) error {
 				ModelType: modelTypeStr,
 				HFToken:   hfToken,
 				Variant:   variant,
+				StoreDir:  storeDir,
+				Validate:  validate,
+				DryRun:    dryRun,
+			}); err != nil {
+				return fmt.Errorf("failed to pull %s: %w", modelRef, err)
+			}
+			continue
+		}
+
+		// Check for file:// prefix to install a locally staged model
+		if path, isLocal := modelregistry.ParseLocalRef(modelRef); isLocal {
+			if err := cli.PullFromLocal(path, cli.SourceOptions{
+				ModelsDir: modelsDir,
+				ModelType: modelTypeStr,
+				Variant:   variant,
+				StoreDir:  storeDir,
+				Validate:  validate,
+				DryRun:    dryRun,
+			}); err != nil {
+				return fmt.Errorf("failed to pull %s: %w", modelRef, err)
+			}
+			continue
+		}
+
+		// Check for s3:// and gs:// prefixes to download from object storage
+		if _, _, _, isObject := modelregistry.ParseObjectStoreRef(modelRef); isObject {
+			if err := cli.PullFromObjectStore(modelRef, cli.SourceOptions{
+				ModelsDir: modelsDir,
+				ModelType: modelTypeStr,
+				Variant:   variant,
+				StoreDir:  storeDir,
+				Validate:  validate,
+				DryRun:    dryRun,
 			}); err != nil {
 				return fmt.Errorf("failed to pull %s: %w", modelRef, err)
 			}
@@ -98,10 +161,14 @@ func runPull(cmd *cobra.Command, args []string) error {
 		}
 
 		// Standard registry pull
+		if dryRun {
+			return fmt.Errorf("--dry-run is only supported for hf:, file://, s3:// and gs:// pulls")
+		}
 		if err := cli.PullFromRegistry(modelRef, cli.PullOptions{
 			RegistryURL: registryURL,
 			ModelsDir:   modelsDir,
 			Variants:    variants,
+			StoreDir:    storeDir,
 		}); err != nil {
 			return fmt.Errorf("failed to pull %s: %w", modelRef, err)
 		}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/antflydb/termite/pkg/termite/lib/cli"
 	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
 	"github.com/antflydb/termite/pkg/termite/lib/paths"
 	"github.com/spf13/cobra"
@@ -77,11 +78,14 @@ func init() {
 		StringVar(&registryURL, "registry", modelregistry.DefaultRegistryURL, "Model registry URL")
 	rootCmd.PersistentFlags().
 		StringVar(&modelsDir, "models-dir", paths.DefaultModelsDir(), "Directory for storing models (default: ~/.termite/models)")
+	rootCmd.PersistentFlags().
+		StringP("output", "o", cli.OutputText, "Output format for list and inspect (text, json)")
 
 	// Bind to viper
 	mustBindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	mustBindPFlag("log.level", rootCmd.PersistentFlags().Lookup("log-level"))
 	mustBindPFlag("log.style", rootCmd.PersistentFlags().Lookup("log-style"))
+	mustBindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 
 	// Default values
 	viper.SetDefault("api_url", "http://localhost:11433")
@@ -62,11 +62,17 @@ func runServer(cmd *cobra.Command, args []string) error {
 	cfg := termite.Config{
 		ApiUrl:          viper.GetString("api_url"),
 		ModelsDir:       modelsDir, // Set from --models-dir flag (defaults to ~/.termite/models)
+		ModelsManifest:  viper.GetString("models_manifest"),
 		Gpu:             termite.GPUMode(viper.GetString("gpu")),
 		KeepAlive:       viper.GetString("keep_alive"),
 		MaxLoadedModels: viper.GetInt("max_loaded_models"),
 		MaxMemoryMb:     viper.GetInt("max_memory_mb"),
 		Preload:         viper.GetStringSlice("preload"),
+		AdminToken:      viper.GetString("admin_token"),
+		OtlpEndpoint:    viper.GetString("otlp_endpoint"),
+		TlsCertFile:     viper.GetString("tls_cert_file"),
+		TlsKeyFile:      viper.GetString("tls_key_file"),
+		TlsClientCaFile: viper.GetString("tls_client_ca_file"),
 	}
 
 	// Parse model_strategies from config (map[string]string -> map[string]ConfigModelStrategies)
@@ -77,6 +83,11 @@ func runServer(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Parse model_aliases from config (alias -> canonical model name)
+	if rawAliases := viper.GetStringMapString("model_aliases"); len(rawAliases) > 0 {
+		cfg.ModelAliases = rawAliases
+	}
+
 	// Track readiness state
 	ready := &atomic.Bool{}
 	ready.Store(false)
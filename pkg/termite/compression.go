@@ -0,0 +1,161 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MaxDecompressedRequestBytes bounds the decompressed size of a gzip request
+// body, so a small compressed payload cannot expand without limit
+const MaxDecompressedRequestBytes = 256 << 20 // 256 MiB
+
+// withCompression accepts gzip-encoded request bodies and gzips the response
+// when the client accepts it. Request bodies are decompressed as the handler
+// reads them, so only requests holding a request queue slot inflate their
+// bodies, and reads fail with a *http.MaxBytesError once the decompressed
+// data exceeds MaxDecompressedRequestBytes.
+func withCompression(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if encoding := strings.TrimSpace(r.Header.Get("Content-Encoding")); encoding != "" && !strings.EqualFold(encoding, "identity") {
+			if !strings.EqualFold(encoding, "gzip") {
+				http.Error(w, fmt.Sprintf("unsupported Content-Encoding %q", encoding), http.StatusUnsupportedMediaType)
+				return
+			}
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid gzip request body: %v", err), http.StatusBadRequest)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, &gzipRequestBody{Reader: zr, body: r.Body}, MaxDecompressedRequestBytes)
+			r.ContentLength = -1
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+		next(gw, r)
+	}
+}
+
+// gzipRequestBody decompresses a request body, closing both the gzip reader
+// and the body
+type gzipRequestBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (b *gzipRequestBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.body.Close())
+}
+
+// requestBodyStatus returns the status for a request body that could not be
+// read or decoded: 413 if it exceeded its size limit, 400 otherwise
+func requestBodyStatus(err error) int {
+	if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip, either
+// by name or through a wildcard, with a non-zero quality
+func acceptsGzip(header string) bool {
+	for coding := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.TrimSpace(name)
+		if !strings.EqualFold(name, "gzip") && name != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the response body. The gzip stream starts
+// with the first write, so responses without a body stay empty.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+	compress    bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(code int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+	if code != http.StatusNoContent && code != http.StatusNotModified {
+		g.compress = true
+		g.Header().Set("Content-Encoding", "gzip")
+		g.Header().Del("Content-Length")
+	}
+	g.ResponseWriter.WriteHeader(code)
+}
+
+func (g *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	if !g.compress {
+		return g.ResponseWriter.Write(p)
+	}
+	if g.gz == nil {
+		g.gz = gzip.NewWriter(g.ResponseWriter)
+	}
+	return g.gz.Write(p)
+}
+
+// FlushError flushes compressed data to the client, for streaming responses
+func (g *gzipResponseWriter) FlushError() error {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.gz != nil {
+		if err := g.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(g.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (g *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return g.ResponseWriter
+}
+
+func (g *gzipResponseWriter) close() {
+	if g.gz != nil {
+		_ = g.gz.Close()
+	}
+}
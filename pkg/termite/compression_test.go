@@ -0,0 +1,184 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestTermiteNode_HandleApiEmbed_Gzip(t *testing.T) {
+	handler := newLimitsTestNode(t, &MockEmbedder{}, EmbedLimits{})
+
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput1([]string{"hello", "world!"}))
+	body, err := json.Marshal(EmbedRequest{Model: "bge-small-en", Input: input})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/embed", bytes.NewReader(gzipBytes(t, body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	var resp EmbedResponse
+	require.NoError(t, json.Unmarshal(decoded, &resp))
+	assert.Equal(t, [][]float32{{0, 5}, {1, 6}}, resp.Embeddings)
+}
+
+func TestTermiteNode_HandleApiEmbed_NoAcceptEncoding(t *testing.T) {
+	handler := newLimitsTestNode(t, &MockEmbedder{}, EmbedLimits{})
+
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput0("hello"))
+	w := postEmbedRequest(t, handler, EmbedRequest{Model: "bge-small-en", Input: input})
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	var resp EmbedResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Embeddings, 1)
+}
+
+func TestTermiteNode_HandleApiEmbed_GzipErrors(t *testing.T) {
+	handler := newLimitsTestNode(t, &MockEmbedder{}, EmbedLimits{})
+
+	post := func(body []byte, encoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/embed", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", encoding)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post([]byte("not gzip"), "gzip").Code)
+	assert.Equal(t, http.StatusUnsupportedMediaType, post([]byte("{}"), "br").Code)
+}
+
+func TestTermiteNode_HandleApiEmbed_GzipLimit(t *testing.T) {
+	handler := newLimitsTestNode(t, &MockEmbedder{}, EmbedLimits{})
+
+	// A JSON string of repeated letters compresses to a fraction of the
+	// limit but inflates past it
+	var bomb bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&bomb, gzip.BestSpeed)
+	require.NoError(t, err)
+	_, _ = zw.Write([]byte(`{"model":"test_model","input":["`))
+	chunk := bytes.Repeat([]byte("a"), 1<<20)
+	for range MaxDecompressedRequestBytes/len(chunk) + 1 {
+		_, _ = zw.Write(chunk)
+	}
+	_, _ = zw.Write([]byte(`"]}`))
+	require.NoError(t, zw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/embed", &bomb)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+}
+
+func TestWithCompression_DecompressesWhileHandlerReads(t *testing.T) {
+	// Hex digits of random bytes barely compress, so the body is much
+	// larger than the gzip reader's buffer
+	raw := make([]byte, 1<<16)
+	_, _ = rand.Read(raw)
+	plain := []byte(hex.EncodeToString(raw))
+	compressed := gzipBytes(t, plain)
+	body := &countingReader{Reader: bytes.NewReader(compressed)}
+
+	var read int64
+	handler := withCompression(func(w http.ResponseWriter, r *http.Request) {
+		// Only the gzip header has been consumed when the handler starts,
+		// e.g. before it acquires a request queue slot
+		read = body.n
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, plain, data)
+		assert.Equal(t, int64(-1), r.ContentLength)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/embed", body)
+	req.Header.Set("Content-Encoding", "gzip")
+	handler(httptest.NewRecorder(), req)
+
+	assert.Less(t, read, int64(len(compressed)))
+}
+
+// countingReader counts the bytes read from Reader
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.8", true},
+		{"GZIP", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"br, deflate", false},
+	}
+	for _, tt := range tests {
+		t.Run(strings.ReplaceAll(tt.header, " ", ""), func(t *testing.T) {
+			assert.Equal(t, tt.want, acceptsGzip(tt.header))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+)
+
+// NodeOption customizes the TermiteNode started by RunAsTermite
+type NodeOption func(*TermiteNode) error
+
+// WithEmbedder registers a custom embedder with the node; see
+// TermiteNode.RegisterEmbedder
+func WithEmbedder(name string, embedder embeddings.Embedder) NodeOption {
+	return func(ln *TermiteNode) error {
+		return ln.RegisterEmbedder(name, embedder)
+	}
+}
+
+// RegisterEmbedder adds an embedder implemented outside Termite, such as a
+// client for a remote embedding API, under name. It is listed by /api/models
+// and served by /api/embed like the models loaded from models_dir, and takes
+// precedence over a loaded model of the same name. The caller keeps
+// ownership of the embedder: the node never closes it.
+func (ln *TermiteNode) RegisterEmbedder(name string, embedder embeddings.Embedder) error {
+	if name == "" {
+		return errors.New("embedder name is required")
+	}
+	if embedder == nil {
+		return fmt.Errorf("embedder %s is nil", name)
+	}
+	if ln.customEmbedders == nil {
+		ln.customEmbedders = &customEmbedderProvider{
+			models: make(map[string]embeddings.Embedder),
+			base:   ln.embedderProvider,
+		}
+		ln.embedderProvider = ln.customEmbedders
+	}
+	ln.customEmbedders.register(name, embedder)
+	return nil
+}
+
+// isCustomEmbedder reports whether name was registered through
+// RegisterEmbedder
+func (ln *TermiteNode) isCustomEmbedder(name string) bool {
+	return ln.customEmbedders != nil && ln.customEmbedders.has(name)
+}
+
+// customEmbedderProvider serves embedders registered through
+// RegisterEmbedder, deferring other names to the registry of loaded models
+type customEmbedderProvider struct {
+	mu     sync.RWMutex
+	models map[string]embeddings.Embedder
+	base   EmbedderProvider // nil when no models are loaded from disk
+}
+
+func (p *customEmbedderProvider) register(name string, embedder embeddings.Embedder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.models[name] = embedder
+}
+
+// has reports whether name is a registered custom embedder
+func (p *customEmbedderProvider) has(name string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.models[name]
+	return ok
+}
+
+func (p *customEmbedderProvider) Get(modelName string) (embeddings.Embedder, error) {
+	p.mu.RLock()
+	embedder, ok := p.models[modelName]
+	p.mu.RUnlock()
+	if ok {
+		return embedder, nil
+	}
+	if p.base == nil {
+		return nil, fmt.Errorf("embedder %w: %s", ErrModelNotFound, modelName)
+	}
+	return p.base.Get(modelName)
+}
+
+func (p *customEmbedderProvider) Acquire(modelName string) (embeddings.Embedder, func(), error) {
+	p.mu.RLock()
+	embedder, ok := p.models[modelName]
+	p.mu.RUnlock()
+	if ok {
+		return embedder, func() {}, nil
+	}
+	if p.base == nil {
+		return nil, nil, fmt.Errorf("embedder %w: %s", ErrModelNotFound, modelName)
+	}
+	return p.base.Acquire(modelName)
+}
+
+func (p *customEmbedderProvider) List() []string {
+	var names []string
+	if p.base != nil {
+		names = p.base.List()
+	}
+	p.mu.RLock()
+	for name := range p.models {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	p.mu.RUnlock()
+	slices.Sort(names)
+	return names
+}
+
+// Close closes the registry of loaded models; custom embedders belong to
+// whoever registered them
+func (p *customEmbedderProvider) Close() error {
+	if p.base == nil {
+		return nil
+	}
+	return p.base.Close()
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTermiteNode_RegisterEmbedder(t *testing.T) {
+	loaded := &MockEmbedder{}
+	remote := &MockEmbedder{}
+	node := newEmbedTestNode(t, "bge-small-en", loaded)
+	require.NoError(t, WithEmbedder("remote-embedder", remote)(node))
+	handler := NewTermiteAPI(node.logger, node)
+
+	// The custom embedder is listed alongside the loaded model
+	req := httptest.NewRequest(http.MethodGet, "/api/models", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var models ModelsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&models))
+	assert.Equal(t, []string{"bge-small-en", "remote-embedder"}, models.Embedders)
+
+	// Both are served by /api/embed
+	w = postEmbed(t, handler, "remote-embedder")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp EmbedResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, [][]float32{{0, 5}, {1, 5}}, resp.Embeddings)
+	assert.Equal(t, int32(1), remote.GetCallCount())
+
+	w = postEmbed(t, handler, "bge-small-en")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, int32(1), loaded.GetCallCount())
+}
+
+func TestTermiteNode_RegisterEmbedderWithoutModels(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	node := &TermiteNode{
+		logger:         logger,
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		embeddingCache: NewEmbeddingCache(logger.Named("embedding-cache")),
+	}
+	t.Cleanup(node.embeddingCache.Close)
+	require.NoError(t, node.RegisterEmbedder("remote-embedder", &MockEmbedder{}))
+	handler := NewTermiteAPI(logger, node)
+
+	assert.Equal(t, http.StatusOK, postEmbed(t, handler, "remote-embedder").Code)
+	assert.Equal(t, http.StatusNotFound, postEmbed(t, handler, "bge-small-en").Code)
+}
+
+func TestTermiteNode_RegisterEmbedderInvalid(t *testing.T) {
+	node := &TermiteNode{logger: zaptest.NewLogger(t)}
+	assert.Error(t, node.RegisterEmbedder("", &MockEmbedder{}))
+	assert.Error(t, node.RegisterEmbedder("remote-embedder", nil))
+	assert.Nil(t, node.embedderProvider)
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/scraping"
+	"github.com/antflydb/termite/pkg/termite/lib/docextract"
+	"github.com/bytedance/sonic/decoder"
+	"github.com/bytedance/sonic/encoder"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// handleApiEmbedDocument extracts the text of a document, chunks it and
+// embeds each chunk. Formats other than plain text need a binary built with
+// the docextract tag.
+func (ln *TermiteNode) handleApiEmbedDocument(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	r, cancel := ln.withRequestTimeout(r)
+	defer cancel()
+
+	// Apply backpressure via request queue
+	release, err := ln.requestQueue.Acquire(r.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrQueueFull):
+			RecordQueueRejection()
+			WriteQueueFullResponse(w, 5*time.Second)
+		case errors.Is(err, ErrRequestTimeout):
+			RecordQueueTimeout()
+			WriteTimeoutResponse(w)
+		default:
+			http.Error(w, "request cancelled", http.StatusRequestTimeout)
+		}
+		return
+	}
+	defer release()
+
+	// Update queue metrics
+	UpdateQueueMetrics(ln.requestQueue.Stats())
+
+	var req EmbedDocumentRequest
+	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decoding request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.Model == "" {
+		http.Error(w, "model is required", http.StatusBadRequest)
+		return
+	}
+	if req.Document == "" {
+		http.Error(w, "document is required", http.StatusBadRequest)
+		return
+	}
+	if ln.cachedChunker == nil {
+		http.Error(w, "chunking not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Get embedder from provider (lazy loads if needed)
+	modelName := ln.modelAliases.Resolve(req.Model)
+	embedder, releaseEmbedder, err := ln.acquireEmbedder(modelName)
+	if err != nil {
+		http.Error(w, modelErrorMessage(req.Model, modelName, err), errorStatus(err))
+		return
+	}
+	defer releaseEmbedder()
+
+	mimeType, data, err := scraping.DownloadContent(r.Context(), req.Document, ln.contentSecurityConfig, ln.s3Credentials)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("downloading document: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.MimeType != "" {
+		mimeType = req.MimeType
+	}
+	mimeType = docextract.Detect(mimeType, data)
+
+	text, err := docextract.Extract(mimeType, data)
+	if errors.Is(err, docextract.ErrUnsupported) {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("extracting %s document: %v", mimeType, err), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(text) == "" {
+		http.Error(w, "document contains no extractable text", http.StatusBadRequest)
+		return
+	}
+
+	internalConfig := chunkConfig{
+		Model:         req.Chunking.Model,
+		TargetTokens:  req.Chunking.TargetTokens,
+		OverlapTokens: req.Chunking.OverlapTokens,
+		Separator:     req.Chunking.Separator,
+		MaxChunks:     req.Chunking.MaxChunks,
+		Threshold:     req.Chunking.Threshold,
+	}
+	chunked, _, err := ln.cachedChunker.Chunk(r.Context(), text, internalConfig)
+	if err != nil {
+		if requestTimedOut(r) {
+			ln.logger.Warn("chunking timed out", zap.Duration("timeout", ln.requestTimeout))
+			WriteTimeoutResponse(w)
+			return
+		}
+		ln.logger.Error("chunking document failed", zap.Error(err))
+		http.Error(w, fmt.Sprintf("chunking text: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// The chunks are embedded in one batch, subject to the /embed limits
+	limits := ln.embedLimits.withDefaults()
+	if err := limits.checkCount(len(chunked.Chunks)); err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	contents := make([][]ai.ContentPart, len(chunked.Chunks))
+	for i, chunk := range chunked.Chunks {
+		contents[i] = []ai.ContentPart{ai.TextContent{Text: chunk.Text}}
+	}
+	if err := limits.checkSize(contents); err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		attrModel.String(modelName),
+		attrBatchSize.Int(len(contents)),
+	)
+
+	releaseModel, err := ln.modelLimiter.Acquire(r.Context(), modelName)
+	if err != nil {
+		writeModelLimitError(w, modelName, err)
+		return
+	}
+	defer releaseModel()
+
+	embeds, err := ln.embeddingCache.WrapEmbedder(embedder, modelName).Embed(r.Context(), contents)
+	if err != nil {
+		if requestTimedOut(r) {
+			ln.logger.Warn("embedding timed out",
+				zap.String("model", modelName),
+				zap.Duration("timeout", ln.requestTimeout))
+			WriteTimeoutResponse(w)
+			return
+		}
+		ln.logger.Error("failed to generate document embeddings",
+			zap.String("model", modelName),
+			zap.Error(err))
+		http.Error(w, fmt.Sprintf("generating embeddings: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp := EmbedDocumentResponse{
+		Model:    req.Model,
+		MimeType: mimeType,
+		Chunks:   make([]DocumentChunk, len(chunked.Chunks)),
+	}
+	for i, chunk := range chunked.Chunks {
+		resp.Chunks[i] = DocumentChunk{
+			Id:        int(chunk.Id),
+			Text:      chunk.Text,
+			StartChar: chunk.StartChar,
+			EndChar:   chunk.EndChar,
+			Embedding: embeds[i],
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
+		ln.logger.Error("encoding response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
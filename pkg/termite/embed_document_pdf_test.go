@@ -0,0 +1,57 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build docextract
+
+package termite
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermiteAPI_EmbedDocument_PDF(t *testing.T) {
+	handler, embedded := newDocumentTestNode(t)
+
+	pdf, err := os.ReadFile("lib/docextract/testdata/sample.pdf")
+	require.NoError(t, err)
+
+	// Served as a generic type, the PDF is detected from its content
+	w := postEmbedDocument(t, handler, EmbedDocumentRequest{
+		Model:    "bge-small-en",
+		Document: dataURI("application/octet-stream", pdf),
+		Chunking: ChunkConfig{TargetTokens: 10, OverlapTokens: 0},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp EmbedDocumentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "application/pdf", resp.MimeType)
+	assert.Greater(t, len(resp.Chunks), 1, "document should be split into several chunks")
+	assertChunksEmbedded(t, resp, embedded())
+
+	var text strings.Builder
+	for _, chunk := range resp.Chunks {
+		text.WriteString(chunk.Text + " ")
+	}
+	assert.Contains(t, text.String(), "Termite extracts text")
+	assert.Contains(t, text.String(), "uncompressed")
+	assert.NotContains(t, text.String(), "FlateDecode", "raw PDF syntax leaked into the extracted text")
+}
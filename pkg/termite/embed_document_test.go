@@ -0,0 +1,150 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDocumentTestNode returns a node with the aliases test embedder and a
+// cached chunker, recording the texts the embedder receives
+func newDocumentTestNode(t *testing.T) (http.Handler, func() []string) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		embedded []string
+	)
+	embedder := &MockEmbedder{embedFunc: func(_ context.Context, values []string) ([][]float32, error) {
+		mu.Lock()
+		embedded = append(embedded, values...)
+		mu.Unlock()
+		result := make([][]float32, len(values))
+		for i, v := range values {
+			result[i] = []float32{float32(i), float32(len(v))}
+		}
+		return result, nil
+	}}
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+
+	cachedChunker, err := NewCachedChunker("", nil, node.logger.Named("chunker"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cachedChunker.Close() })
+	node.cachedChunker = cachedChunker
+
+	return NewTermiteAPI(node.logger, node), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return embedded
+	}
+}
+
+func postEmbedDocument(t *testing.T, handler http.Handler, req EmbedDocumentRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/embed/document", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func dataURI(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// assertChunksEmbedded checks that every chunk of the response was embedded
+// from its own text, in order
+func assertChunksEmbedded(t *testing.T, resp EmbedDocumentResponse, embedded []string) {
+	t.Helper()
+	require.Len(t, embedded, len(resp.Chunks))
+	for i, chunk := range resp.Chunks {
+		assert.Equal(t, i, chunk.Id)
+		assert.NotEmpty(t, chunk.Text)
+		assert.Equal(t, embedded[i], chunk.Text)
+		assert.Equal(t, []float32{float32(i), float32(len(chunk.Text))}, chunk.Embedding)
+		assert.Less(t, chunk.StartChar, chunk.EndChar)
+		if i > 0 {
+			assert.GreaterOrEqual(t, chunk.StartChar, resp.Chunks[i-1].StartChar)
+		}
+	}
+}
+
+func TestTermiteAPI_EmbedDocument_PlainText(t *testing.T) {
+	handler, embedded := newDocumentTestNode(t)
+
+	text := "Termite chunks documents before embedding them.\n\n" +
+		"Every chunk gets its own embedding and character offsets.\n\n" +
+		"Offsets refer to the extracted text of the document."
+	w := postEmbedDocument(t, handler, EmbedDocumentRequest{
+		Model:    "bge-small-en",
+		Document: dataURI("text/plain", []byte(text)),
+		Chunking: ChunkConfig{TargetTokens: 12, OverlapTokens: 0},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp EmbedDocumentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "bge-small-en", resp.Model)
+	assert.Equal(t, "text/plain", resp.MimeType)
+	assert.Greater(t, len(resp.Chunks), 1, "document should be split into several chunks")
+	assertChunksEmbedded(t, resp, embedded())
+}
+
+func TestTermiteAPI_EmbedDocument_MimeTypeOverride(t *testing.T) {
+	handler, _ := newDocumentTestNode(t)
+
+	w := postEmbedDocument(t, handler, EmbedDocumentRequest{
+		Model:    "bge-small-en",
+		Document: dataURI("application/octet-stream", []byte("plain text served as binary")),
+		MimeType: "text/plain",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestTermiteAPI_EmbedDocument_Errors(t *testing.T) {
+	handler, embedded := newDocumentTestNode(t)
+
+	tests := []struct {
+		name string
+		req  EmbedDocumentRequest
+		code int
+	}{
+		{"missing model", EmbedDocumentRequest{Document: dataURI("text/plain", []byte("x"))}, http.StatusBadRequest},
+		{"missing document", EmbedDocumentRequest{Model: "bge-small-en"}, http.StatusBadRequest},
+		{"unknown model", EmbedDocumentRequest{Model: "unknown", Document: dataURI("text/plain", []byte("x"))}, http.StatusNotFound},
+		{"no text", EmbedDocumentRequest{Model: "bge-small-en", Document: dataURI("text/plain", []byte(" \n "))}, http.StatusBadRequest},
+		{"unsupported type", EmbedDocumentRequest{Model: "bge-small-en", Document: dataURI("image/png", []byte{0x89, 'P', 'N', 'G'})},
+			http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postEmbedDocument(t, handler, tt.req)
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+	assert.Empty(t, embedded())
+}
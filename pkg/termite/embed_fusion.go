@@ -0,0 +1,148 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+)
+
+// checkFusion validates an embed request's fusion settings. Multi-part items
+// require fusion, and fusion requires a model that supports it.
+func checkFusion(fusion EmbedFusion, contents [][]ai.ContentPart, model string, caps embeddings.EmbedderCapabilities) error {
+	if fusion.Mode == "" {
+		for i, parts := range contents {
+			if len(parts) > 1 {
+				return fmt.Errorf("item at index %d has %d content parts: multi-part items require fusion", i, len(parts))
+			}
+		}
+		return nil
+	}
+
+	if !caps.SupportsFusion {
+		return fmt.Errorf("model %s does not support fusion (supports_fusion is false)", model)
+	}
+
+	switch fusion.Mode {
+	case EmbedFusionModeNative:
+		if len(fusion.Weights) > 0 {
+			return fmt.Errorf("fusion weights are only supported with mode %s", EmbedFusionModeWeightedMean)
+		}
+	case EmbedFusionModeWeightedMean:
+		for i, w := range fusion.Weights {
+			if w < 0 || math.IsNaN(float64(w)) || math.IsInf(float64(w), 0) {
+				return fmt.Errorf("fusion weight at index %d must be a non-negative number, got %g", i, w)
+			}
+		}
+		if len(fusion.Weights) > 0 {
+			for i, parts := range contents {
+				if len(parts) > len(fusion.Weights) {
+					return fmt.Errorf("item at index %d has %d content parts but only %d fusion weights",
+						i, len(parts), len(fusion.Weights))
+				}
+				if partWeight(fusion.Weights, len(parts)) == 0 {
+					return fmt.Errorf("fusion weights for item at index %d sum to zero", i)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("invalid fusion mode %q: must be %s or %s",
+			fusion.Mode, EmbedFusionModeWeightedMean, EmbedFusionModeNative)
+	}
+	return nil
+}
+
+// embedWithFusion returns one embedding per item of contents. Without fusion
+// and with native fusion the items go to the embedder as they are; with
+// weighted_mean fusion every part is embedded on its own and the vectors of
+// each item are averaged.
+func embedWithFusion(ctx context.Context, embedder embeddings.Embedder, contents [][]ai.ContentPart, fusion EmbedFusion) ([][]float32, error) {
+	if fusion.Mode != EmbedFusionModeWeightedMean {
+		return embedder.Embed(ctx, contents)
+	}
+
+	var parts [][]ai.ContentPart
+	for _, item := range contents {
+		for _, part := range item {
+			parts = append(parts, []ai.ContentPart{part})
+		}
+	}
+	partEmbeds, err := embedder.Embed(ctx, parts)
+	if err != nil {
+		return nil, err
+	}
+	if len(partEmbeds) != len(parts) {
+		return nil, fmt.Errorf("embedder returned %d embeddings for %d content parts", len(partEmbeds), len(parts))
+	}
+
+	fused := make([][]float32, len(contents))
+	next := 0
+	for i, item := range contents {
+		vec, err := weightedMean(partEmbeds[next:next+len(item)], fusion.Weights)
+		if err != nil {
+			return nil, fmt.Errorf("fusing item at index %d: %w", i, err)
+		}
+		fused[i] = vec
+		next += len(item)
+	}
+	return fused, nil
+}
+
+// weightedMean averages vectors, weighting vectors[i] by weights[i] (equal
+// weights when none are given), and L2-normalizes the result
+func weightedMean(vectors [][]float32, weights []float32) ([]float32, error) {
+	dim := len(vectors[0])
+	sum := make([]float64, dim)
+	for i, vec := range vectors {
+		if len(vec) != dim {
+			return nil, fmt.Errorf("part embeddings have different dimensions (%d and %d)", dim, len(vec))
+		}
+		w := 1.0
+		if len(weights) > 0 {
+			w = float64(weights[i])
+		}
+		for j, x := range vec {
+			sum[j] += w * float64(x)
+		}
+	}
+
+	// Dividing by the total weight would not change the direction, so
+	// normalizing the weighted sum yields the normalized weighted mean
+	var norm float64
+	for _, x := range sum {
+		norm += x * x
+	}
+	norm = math.Sqrt(norm)
+	fused := make([]float32, dim)
+	for j, x := range sum {
+		if norm > 0 {
+			fused[j] = float32(x / norm)
+		}
+	}
+	return fused, nil
+}
+
+// partWeight returns the total weight of the first n parts
+func partWeight(weights []float32, n int) float32 {
+	var total float32
+	for _, w := range weights[:n] {
+		total += w
+	}
+	return total
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fusionEmbedder supports fusion and embeds each item as the sum of fixed
+// per-text vectors, recording how many parts each item it receives has
+type fusionEmbedder struct {
+	vectors map[string][]float32
+
+	mu        sync.Mutex
+	itemParts []int
+}
+
+func (f *fusionEmbedder) Capabilities() embeddings.EmbedderCapabilities {
+	return embeddings.EmbedderCapabilities{
+		SupportedMIMETypes: []embeddings.MIMETypeSupport{{MIMEType: "text/plain"}},
+		SupportsFusion:     true,
+	}
+}
+
+func (f *fusionEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart) ([][]float32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make([][]float32, len(contents))
+	for i, parts := range contents {
+		f.itemParts = append(f.itemParts, len(parts))
+		result[i] = make([]float32, 2)
+		for _, part := range parts {
+			for j, x := range f.vectors[part.(ai.TextContent).Text] {
+				result[i][j] += x
+			}
+		}
+	}
+	return result, nil
+}
+
+// textItems builds multi-part input from the texts of each item
+func textItems(t *testing.T, items ...[]string) EmbedRequest_Input {
+	t.Helper()
+	input := make(EmbedRequestInput3, len(items))
+	for i, texts := range items {
+		for _, text := range texts {
+			var part ContentPart
+			require.NoError(t, part.FromTextContentPart(TextContentPart{Text: text, Type: TextContentPartTypeText}))
+			input[i] = append(input[i], part)
+		}
+	}
+	var union EmbedRequest_Input
+	require.NoError(t, union.FromEmbedRequestInput3(input))
+	return union
+}
+
+func TestTermiteNode_HandleApiEmbed_WeightedMeanFusion(t *testing.T) {
+	embedder := &fusionEmbedder{vectors: map[string][]float32{
+		"title": {1, 0},
+		"body":  {0, 1},
+	}}
+	node := newEmbedTestNode(t, "fusion-model", embedder)
+	handler := NewTermiteAPI(node.logger, node)
+
+	w := postEmbedRequest(t, handler, EmbedRequest{
+		Model:  "fusion-model",
+		Input:  textItems(t, []string{"title", "body"}, []string{"body"}),
+		Fusion: EmbedFusion{Mode: EmbedFusionModeWeightedMean, Weights: []float32{3, 1}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp EmbedResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Embeddings, 2, "one vector per item")
+	assert.InDeltaSlice(t, []float32{0.9486833, 0.31622776}, resp.Embeddings[0], 1e-6)
+	assert.InDeltaSlice(t, []float32{0, 1}, resp.Embeddings[1], 1e-6)
+
+	// Each part is embedded on its own
+	assert.Equal(t, []int{1, 1, 1}, embedder.itemParts)
+}
+
+func TestTermiteNode_HandleApiEmbed_NativeFusion(t *testing.T) {
+	embedder := &fusionEmbedder{vectors: map[string][]float32{
+		"title": {1, 0},
+		"body":  {0, 1},
+	}}
+	node := newEmbedTestNode(t, "fusion-model", embedder)
+	handler := NewTermiteAPI(node.logger, node)
+
+	w := postEmbedRequest(t, handler, EmbedRequest{
+		Model:  "fusion-model",
+		Input:  textItems(t, []string{"title", "body"}, []string{"title"}),
+		Fusion: EmbedFusion{Mode: EmbedFusionModeNative},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp EmbedResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, [][]float32{{1, 1}, {1, 0}}, resp.Embeddings)
+
+	// The model receives whole items to fuse itself
+	assert.Equal(t, []int{2, 1}, embedder.itemParts)
+}
+
+func TestTermiteNode_HandleApiEmbed_FusionUnsupported(t *testing.T) {
+	embedder := &MockEmbedder{} // Text-only, SupportsFusion false
+	node := newEmbedTestNode(t, "fusion-model", embedder)
+	handler := NewTermiteAPI(node.logger, node)
+
+	for _, mode := range []EmbedFusionMode{EmbedFusionModeWeightedMean, EmbedFusionModeNative} {
+		w := postEmbedRequest(t, handler, EmbedRequest{
+			Model:  "fusion-model",
+			Input:  textItems(t, []string{"title", "body"}),
+			Fusion: EmbedFusion{Mode: mode},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, mode)
+		assert.Contains(t, w.Body.String(), "does not support fusion", mode)
+	}
+	assert.Zero(t, embedder.GetCallCount())
+}
+
+func TestTermiteNode_HandleApiEmbed_MultiPartRequiresFusion(t *testing.T) {
+	embedder := &fusionEmbedder{}
+	node := newEmbedTestNode(t, "fusion-model", embedder)
+	handler := NewTermiteAPI(node.logger, node)
+
+	w := postEmbedRequest(t, handler, EmbedRequest{
+		Model: "fusion-model",
+		Input: textItems(t, []string{"title", "body"}),
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "multi-part items require fusion")
+	assert.Empty(t, embedder.itemParts)
+}
+
+func TestCheckFusion(t *testing.T) {
+	caps := (&fusionEmbedder{}).Capabilities()
+	contents := [][]ai.ContentPart{
+		{ai.TextContent{Text: "title"}, ai.TextContent{Text: "body"}},
+		{ai.TextContent{Text: "body"}},
+	}
+
+	tests := []struct {
+		name    string
+		fusion  EmbedFusion
+		wantErr string
+	}{
+		{name: "equal weights", fusion: EmbedFusion{Mode: EmbedFusionModeWeightedMean}},
+		{name: "weights", fusion: EmbedFusion{Mode: EmbedFusionModeWeightedMean, Weights: []float32{1, 2}}},
+		{name: "native", fusion: EmbedFusion{Mode: EmbedFusionModeNative}},
+		{
+			name:    "invalid mode",
+			fusion:  EmbedFusion{Mode: "max"},
+			wantErr: `invalid fusion mode "max"`,
+		},
+		{
+			name:    "too few weights",
+			fusion:  EmbedFusion{Mode: EmbedFusionModeWeightedMean, Weights: []float32{1}},
+			wantErr: "only 1 fusion weights",
+		},
+		{
+			name:    "negative weight",
+			fusion:  EmbedFusion{Mode: EmbedFusionModeWeightedMean, Weights: []float32{1, -1}},
+			wantErr: "non-negative",
+		},
+		{
+			name:    "zero weights",
+			fusion:  EmbedFusion{Mode: EmbedFusionModeWeightedMean, Weights: []float32{0, 1}},
+			wantErr: "item at index 1 sum to zero",
+		},
+		{
+			name:    "weights with native",
+			fusion:  EmbedFusion{Mode: EmbedFusionModeNative, Weights: []float32{1, 1}},
+			wantErr: "only supported with mode weighted_mean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFusion(tt.fusion, contents, "fusion-model", caps)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
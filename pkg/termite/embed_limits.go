@@ -0,0 +1,97 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"fmt"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+)
+
+// Default embed request limits, applied when a limit is not configured
+const (
+	DefaultMaxEmbedInputs       = 2048
+	DefaultMaxEmbedInputBytes   = 8 << 20  // 8 MiB
+	DefaultMaxEmbedRequestBytes = 64 << 20 // 64 MiB
+)
+
+// withDefaults fills unset limits with their defaults
+func (l EmbedLimits) withDefaults() EmbedLimits {
+	if l.MaxInputs <= 0 {
+		l.MaxInputs = DefaultMaxEmbedInputs
+	}
+	if l.MaxInputBytes <= 0 {
+		l.MaxInputBytes = DefaultMaxEmbedInputBytes
+	}
+	if l.MaxRequestBytes <= 0 {
+		l.MaxRequestBytes = DefaultMaxEmbedRequestBytes
+	}
+	return l
+}
+
+// checkCount rejects requests with more inputs than allowed. It runs before
+// inputs are parsed so oversized batches never trigger image downloads.
+func (l EmbedLimits) checkCount(n int) error {
+	if n > l.MaxInputs {
+		return fmt.Errorf("too many inputs: %d exceeds embed_limits.max_inputs (%d)", n, l.MaxInputs)
+	}
+	return nil
+}
+
+// checkSize rejects any input larger than MaxInputBytes and requests whose
+// inputs together exceed MaxRequestBytes
+func (l EmbedLimits) checkSize(contents [][]ai.ContentPart) error {
+	total := 0
+	for i, parts := range contents {
+		size := 0
+		for _, part := range parts {
+			switch p := part.(type) {
+			case ai.TextContent:
+				size += len(p.Text)
+			case ai.BinaryContent:
+				size += len(p.Data)
+			}
+		}
+		if size > l.MaxInputBytes {
+			return fmt.Errorf("input at index %d is %d bytes, exceeding embed_limits.max_input_bytes (%d)",
+				i, size, l.MaxInputBytes)
+		}
+		total += size
+	}
+	if total > l.MaxRequestBytes {
+		return fmt.Errorf("inputs total %d bytes, exceeding embed_limits.max_request_bytes (%d)",
+			total, l.MaxRequestBytes)
+	}
+	return nil
+}
+
+// embedInputCount returns the number of inputs in an embed request without
+// parsing content parts. Each part of a multi-part item counts as an input.
+func embedInputCount(input EmbedRequest_Input) int {
+	if arr, err := input.AsEmbedRequestInput1(); err == nil {
+		return len(arr)
+	}
+	if items, err := input.AsEmbedRequestInput3(); err == nil {
+		n := 0
+		for _, parts := range items {
+			n += len(parts)
+		}
+		return n
+	}
+	if parts, err := input.AsEmbedRequestInput2(); err == nil {
+		return len(parts)
+	}
+	return 1
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLimitsTestNode(t *testing.T, embedder *MockEmbedder, limits EmbedLimits) http.Handler {
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+	node.embedLimits = limits
+	return NewTermiteAPI(node.logger, node)
+}
+
+func TestTermiteNode_HandleApiEmbed_TooManyInputs(t *testing.T) {
+	embedder := &MockEmbedder{}
+	handler := newLimitsTestNode(t, embedder, EmbedLimits{MaxInputs: 2})
+
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput1([]string{"a", "b", "c"}))
+	w := postEmbedRequest(t, handler, EmbedRequest{Model: "bge-small-en", Input: input})
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "embed_limits.max_inputs")
+	assert.Zero(t, embedder.GetCallCount())
+
+	require.NoError(t, input.FromEmbedRequestInput1([]string{"a", "b"}))
+	w = postEmbedRequest(t, handler, EmbedRequest{Model: "bge-small-en", Input: input})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestTermiteNode_HandleApiEmbed_InputTooLong(t *testing.T) {
+	embedder := &MockEmbedder{}
+	handler := newLimitsTestNode(t, embedder, EmbedLimits{MaxInputBytes: 16})
+
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput0(strings.Repeat("x", 17)))
+	w := postEmbedRequest(t, handler, EmbedRequest{Model: "bge-small-en", Input: input})
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "embed_limits.max_input_bytes")
+	assert.Contains(t, w.Body.String(), "index 0")
+	assert.Zero(t, embedder.GetCallCount())
+}
+
+func TestTermiteNode_HandleApiEmbed_RequestTooLarge(t *testing.T) {
+	embedder := &MockEmbedder{}
+	handler := newLimitsTestNode(t, embedder, EmbedLimits{MaxInputBytes: 16, MaxRequestBytes: 32})
+
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput1([]string{
+		strings.Repeat("x", 16), strings.Repeat("y", 16), "z",
+	}))
+	w := postEmbedRequest(t, handler, EmbedRequest{Model: "bge-small-en", Input: input})
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "embed_limits.max_request_bytes")
+	assert.Zero(t, embedder.GetCallCount())
+}
+
+func TestEmbedLimits_Defaults(t *testing.T) {
+	limits := EmbedLimits{MaxInputs: 10}.withDefaults()
+	assert.Equal(t, 10, limits.MaxInputs)
+	assert.Equal(t, DefaultMaxEmbedInputBytes, limits.MaxInputBytes)
+	assert.Equal(t, DefaultMaxEmbedRequestBytes, limits.MaxRequestBytes)
+
+	assert.NoError(t, limits.checkCount(10))
+	assert.Error(t, limits.checkCount(11))
+}
+
+func TestEmbedLimits_CountsImageBytes(t *testing.T) {
+	limits := EmbedLimits{MaxInputBytes: 4}.withDefaults()
+
+	err := limits.checkSize([][]ai.ContentPart{
+		{ai.TextContent{Text: "ok"}},
+		{ai.BinaryContent{MIMEType: "image/png", Data: []byte("12345")}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index 1")
+}
+
+func TestEmbedInputCount(t *testing.T) {
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput0("hello"))
+	assert.Equal(t, 1, embedInputCount(input))
+
+	require.NoError(t, input.FromEmbedRequestInput1([]string{"a", "b", "c"}))
+	assert.Equal(t, 3, embedInputCount(input))
+
+	require.NoError(t, json.Unmarshal([]byte(`[{"type": "text", "text": "a"}, {"type": "text", "text": "b"}]`), &input))
+	assert.Equal(t, 2, embedInputCount(input))
+
+	require.NoError(t, json.Unmarshal([]byte(`[[{"type": "text", "text": "a"}, {"type": "text", "text": "b"}], [{"type": "text", "text": "c"}]]`), &input))
+	assert.Equal(t, 3, embedInputCount(input))
+}
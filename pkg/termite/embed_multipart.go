@@ -0,0 +1,129 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+)
+
+// embedLimitError is an input rejected by the embed limits, answered with
+// 413 Request Entity Too Large
+type embedLimitError struct{ err error }
+
+func (e embedLimitError) Error() string { return e.err.Error() }
+
+// isMultipartForm reports whether r carries a multipart/form-data body
+func isMultipartForm(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// parseEmbedMultipart reads an embed request sent as multipart/form-data: a
+// model field, an optional precision field, plus input text fields and file
+// image uploads, each one input
+// in the order the parts appear. Files are read as raw bytes, sparing
+// clients the base64 encoding of data URIs; their type is detected from the
+// content. Inputs are checked against limits as they are read, so an
+// oversized upload is rejected without buffering it whole.
+func parseEmbedMultipart(r *http.Request, limits EmbedLimits) (req EmbedRequest, contents [][]ai.ContentPart, err error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return req, nil, fmt.Errorf("reading multipart form: %w", err)
+	}
+
+	total := 0
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return req, nil, fmt.Errorf("reading multipart form: %w", err)
+		}
+
+		name := part.FormName()
+		data, err := io.ReadAll(io.LimitReader(part, int64(limits.MaxInputBytes)+1))
+		_ = part.Close()
+		if err != nil {
+			return req, nil, fmt.Errorf("reading form field %q: %w", name, err)
+		}
+		if len(data) > limits.MaxInputBytes {
+			return req, nil, embedLimitError{fmt.Errorf("form field %q at index %d exceeds embed_limits.max_input_bytes (%d)",
+				name, len(contents), limits.MaxInputBytes)}
+		}
+
+		switch name {
+		case "model":
+			req.Model = string(data)
+			continue
+		case "precision":
+			if req.Precision, err = strconv.Atoi(string(data)); err != nil {
+				return req, nil, fmt.Errorf("invalid precision %q: expected an integer", data)
+			}
+			continue
+		case "input":
+			contents = append(contents, []ai.ContentPart{ai.TextContent{Text: string(data)}})
+		case "file":
+			mimeType, err := uploadedImageType(part.FileName(), part.Header.Get("Content-Type"), data)
+			if err != nil {
+				return req, nil, err
+			}
+			contents = append(contents, []ai.ContentPart{ai.BinaryContent{MIMEType: mimeType, Data: data}})
+		default:
+			return req, nil, fmt.Errorf("unexpected form field %q: expected model, precision, input or file", name)
+		}
+
+		if err := limits.checkCount(len(contents)); err != nil {
+			return req, nil, embedLimitError{err}
+		}
+		total += len(data)
+		if total > limits.MaxRequestBytes {
+			return req, nil, embedLimitError{fmt.Errorf("inputs total more than %d bytes, exceeding embed_limits.max_request_bytes",
+				limits.MaxRequestBytes)}
+		}
+	}
+
+	if len(contents) == 0 {
+		return req, nil, errors.New("input is required: add input fields or file uploads")
+	}
+	return req, contents, nil
+}
+
+// uploadedImageType returns the MIME type of an uploaded file, detected from
+// its content. The file must be an image, and a declared type other than
+// application/octet-stream must match the content.
+func uploadedImageType(filename, declared string, data []byte) (string, error) {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !strings.HasPrefix(detected, "image/") {
+		return "", fmt.Errorf("file %q is not an image (detected %s)", filename, detected)
+	}
+	if declared != "" {
+		mediaType, _, err := mime.ParseMediaType(declared)
+		if err != nil {
+			return "", fmt.Errorf("file %q: invalid Content-Type %q", filename, declared)
+		}
+		if mediaType != "application/octet-stream" && mediaType != detected {
+			return "", fmt.Errorf("file %q is declared %s but contains %s", filename, mediaType, detected)
+		}
+	}
+	return detected, nil
+}
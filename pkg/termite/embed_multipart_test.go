@@ -0,0 +1,197 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"sync"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// visualEmbedder stands in for CLIP: images go through a "visual encoder"
+// whose output depends on the image bytes, and every image seen is recorded
+type visualEmbedder struct {
+	mu     sync.Mutex
+	images []ai.BinaryContent
+}
+
+func (e *visualEmbedder) Capabilities() embeddings.EmbedderCapabilities {
+	return embeddings.EmbedderCapabilities{
+		SupportedMIMETypes: []embeddings.MIMETypeSupport{{MIMEType: "text/plain"}, {MIMEType: "image/png"}},
+	}
+}
+
+func (e *visualEmbedder) Embed(_ context.Context, contents [][]ai.ContentPart) ([][]float32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := make([][]float32, len(contents))
+	for i, parts := range contents {
+		switch p := parts[0].(type) {
+		case ai.BinaryContent:
+			e.images = append(e.images, p)
+			var sum float32
+			for _, b := range p.Data {
+				sum += float32(b)
+			}
+			result[i] = []float32{1, float32(len(p.Data)), sum}
+		case ai.TextContent:
+			result[i] = []float32{0, float32(len(p.Text)), 0}
+		}
+	}
+	return result, nil
+}
+
+func newMultipartTestServer(t *testing.T, embedder embeddings.Embedder, limits EmbedLimits) *httptest.Server {
+	node := newEmbedTestNode(t, "clip-vit-base", embedder)
+	node.embedLimits = limits
+	server := httptest.NewServer(NewTermiteAPI(node.logger, node))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 2, color.RGBA{R: 200, G: 10, B: 30, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// formFile is one file part of a multipart embed request
+type formFile struct {
+	name        string
+	contentType string
+	data        []byte
+}
+
+// postEmbedMultipart posts fields and files to /api/embed as
+// multipart/form-data, fields first
+func postEmbedMultipart(t *testing.T, url string, fields [][2]string, files ...formFile) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, f := range fields {
+		require.NoError(t, mw.WriteField(f[0], f[1]))
+	}
+	for _, f := range files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+f.name+`"`)
+		if f.contentType != "" {
+			header.Set("Content-Type", f.contentType)
+		}
+		part, err := mw.CreatePart(header)
+		require.NoError(t, err)
+		_, err = part.Write(f.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	req, err := http.NewRequest(http.MethodPost, url+"/api/embed", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func decodeEmbedResponse(t *testing.T, resp *http.Response) EmbedResponse {
+	t.Helper()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var embedResp EmbedResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&embedResp))
+	return embedResp
+}
+
+func TestTermiteNode_HandleApiEmbed_MultipartMatchesBase64(t *testing.T) {
+	img := testPNG(t)
+
+	// Base64 data URI in JSON
+	jsonEmbedder := &visualEmbedder{}
+	jsonServer := newMultipartTestServer(t, jsonEmbedder, EmbedLimits{})
+	var input EmbedRequest_Input
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type": "text", "text": "a red dot"},
+		{"type": "image_url", "image_url": {"url": "`+dataURI("image/png", img)+`"}}
+	]`), &input))
+	body, err := json.Marshal(EmbedRequest{Model: "clip-vit-base", Input: input})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, jsonServer.URL+"/api/embed", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	want := decodeEmbedResponse(t, resp)
+
+	// Raw upload, with the part type left to detection
+	multipartEmbedder := &visualEmbedder{}
+	multipartServer := newMultipartTestServer(t, multipartEmbedder, EmbedLimits{})
+	got := decodeEmbedResponse(t, postEmbedMultipart(t, multipartServer.URL,
+		[][2]string{{"model", "clip-vit-base"}, {"input", "a red dot"}},
+		formFile{name: "dot.png", contentType: "application/octet-stream", data: img}))
+
+	assert.Equal(t, want, got)
+	require.Len(t, got.Embeddings, 2)
+	require.Len(t, multipartEmbedder.images, 1, "the upload should reach the visual encoder")
+	assert.Equal(t, ai.BinaryContent{MIMEType: "image/png", Data: img}, multipartEmbedder.images[0])
+	assert.Equal(t, jsonEmbedder.images, multipartEmbedder.images)
+}
+
+func TestTermiteNode_HandleApiEmbed_MultipartValidation(t *testing.T) {
+	img := testPNG(t)
+	server := newMultipartTestServer(t, &visualEmbedder{}, EmbedLimits{MaxInputBytes: 1024, MaxInputs: 2})
+	model := [2]string{"model", "clip-vit-base"}
+
+	tests := []struct {
+		name   string
+		fields [][2]string
+		files  []formFile
+		want   int
+	}{
+		{name: "declared type", fields: [][2]string{model}, files: []formFile{{name: "a.png", contentType: "image/png", data: img}}, want: http.StatusOK},
+		{name: "missing model", files: []formFile{{name: "a.png", data: img}}, want: http.StatusBadRequest},
+		{name: "no inputs", fields: [][2]string{model}, want: http.StatusBadRequest},
+		{name: "not an image", fields: [][2]string{model}, files: []formFile{{name: "a.txt", data: []byte("hello")}}, want: http.StatusBadRequest},
+		{name: "declared type mismatch", fields: [][2]string{model}, files: []formFile{{name: "a.png", contentType: "image/jpeg", data: img}}, want: http.StatusBadRequest},
+		{name: "unsupported image type", fields: [][2]string{model}, files: []formFile{{name: "a.gif", data: []byte("GIF89a\x01\x00\x01\x00")}}, want: http.StatusBadRequest},
+		{name: "unknown field", fields: [][2]string{model, {"fusion", "native"}}, files: []formFile{{name: "a.png", data: img}}, want: http.StatusBadRequest},
+		{name: "file too large", fields: [][2]string{model}, files: []formFile{{name: "big.png", data: append(img, make([]byte, 1024)...)}}, want: http.StatusRequestEntityTooLarge},
+		{name: "too many inputs", fields: [][2]string{model, {"input", "a"}, {"input", "b"}, {"input", "c"}}, want: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := postEmbedMultipart(t, server.URL, tt.fields, tt.files...)
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxEmbedPrecision is the largest precision a request may ask for. float32
+// carries about 7 significant digits, so 9 always round-trips exactly.
+const maxEmbedPrecision = 9
+
+// checkPrecision rejects precisions outside 0 (full precision) to
+// maxEmbedPrecision significant digits
+func checkPrecision(precision EmbedPrecision) error {
+	if precision < 0 || precision > maxEmbedPrecision {
+		return fmt.Errorf("precision must be between 0 and %d, got %d", maxEmbedPrecision, precision)
+	}
+	return nil
+}
+
+// roundEmbeddings returns embeds with every value rounded to precision
+// significant digits, so that they encode to shorter JSON numbers. The
+// embeddings may be shared with the cache, so they are copied rather than
+// rounded in place. A precision of 0 returns embeds unchanged.
+func roundEmbeddings(embeds [][]float32, precision int) [][]float32 {
+	if precision <= 0 {
+		return embeds
+	}
+	rounded := make([][]float32, len(embeds))
+	for i, embed := range embeds {
+		rounded[i] = make([]float32, len(embed))
+		for j, v := range embed {
+			rounded[i][j] = roundSignificant(v, precision)
+		}
+	}
+	return rounded
+}
+
+// roundSignificant rounds v to digits significant digits
+func roundSignificant(v float32, digits int) float32 {
+	f := float64(v)
+	if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return v
+	}
+	scale := math.Pow(10, float64(digits-1)-math.Floor(math.Log10(math.Abs(f))))
+	return float32(math.Round(f*scale) / scale)
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// denseEmbedder returns vectors of values using every float32 digit, like a
+// real model's output
+type denseEmbedder struct{}
+
+func (denseEmbedder) Capabilities() embeddings.EmbedderCapabilities {
+	return embeddings.EmbedderCapabilities{
+		SupportedMIMETypes: []embeddings.MIMETypeSupport{{MIMEType: "text/plain"}},
+	}
+}
+
+func (denseEmbedder) Embed(_ context.Context, contents [][]ai.ContentPart) ([][]float32, error) {
+	result := make([][]float32, len(contents))
+	for i := range contents {
+		result[i] = make([]float32, 384)
+		for j := range result[i] {
+			result[i][j] = float32(math.Sin(float64(i*384+j+1)) / 7)
+		}
+	}
+	return result, nil
+}
+
+func TestRoundSignificant(t *testing.T) {
+	tests := []struct {
+		v      float32
+		digits int
+		want   float32
+	}{
+		{0.123456789, 3, 0.123},
+		{-0.0456789, 2, -0.046},
+		{1234.5678, 4, 1235},
+		{0.000123456, 3, 0.000123},
+		{0, 3, 0},
+		{0.123456789, 9, 0.123456789},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, roundSignificant(tt.v, tt.digits), "roundSignificant(%v, %d)", tt.v, tt.digits)
+	}
+	assert.True(t, math.IsNaN(float64(roundSignificant(float32(math.NaN()), 3))))
+}
+
+func TestRoundEmbeddings_CopiesInput(t *testing.T) {
+	embeds := [][]float32{{0.123456, 0.654321}}
+	rounded := roundEmbeddings(embeds, 2)
+	assert.Equal(t, [][]float32{{0.12, 0.65}}, rounded)
+	assert.Equal(t, [][]float32{{0.123456, 0.654321}}, embeds, "cached embeddings must not be modified")
+	assert.Equal(t, embeds, roundEmbeddings(embeds, 0))
+}
+
+// postEmbedJSON posts an embed request for inputs at precision and returns
+// the raw JSON response body
+func postEmbedJSON(t *testing.T, url string, precision int, inputs ...string) []byte {
+	t.Helper()
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput1(inputs))
+	body, err := json.Marshal(EmbedRequest{Model: "clip-vit-base", Input: input, Precision: precision})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, url+"/api/embed", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(respBody))
+	return respBody
+}
+
+func TestTermiteNode_HandleApiEmbed_Precision(t *testing.T) {
+	server := newMultipartTestServer(t, denseEmbedder{}, EmbedLimits{})
+	inputs := []string{"first", "second", "third"}
+
+	fullBody := postEmbedJSON(t, server.URL, 0, inputs...)
+	var full EmbedResponse
+	require.NoError(t, json.Unmarshal(fullBody, &full))
+	want, err := denseEmbedder{}.Embed(context.Background(), make([][]ai.ContentPart, len(inputs)))
+	require.NoError(t, err)
+	assert.Equal(t, want, full.Embeddings, "precision 0 keeps full precision")
+
+	for _, digits := range []int{3, 5} {
+		body := postEmbedJSON(t, server.URL, digits, inputs...)
+		var rounded EmbedResponse
+		require.NoError(t, json.Unmarshal(body, &rounded))
+
+		assert.Less(t, len(body), len(fullBody), "precision %d should shrink the payload", digits)
+		// Rounding error, plus float32 representation error
+		tolerance := math.Pow(10, float64(1-digits))/2 + 1e-6
+		require.Len(t, rounded.Embeddings, len(want))
+		for i := range want {
+			require.Len(t, rounded.Embeddings[i], len(want[i]))
+			for j, v := range want[i] {
+				relErr := math.Abs(float64(rounded.Embeddings[i][j]-v)) / math.Abs(float64(v))
+				require.LessOrEqual(t, relErr, tolerance, "embeddings[%d][%d] = %v, want %v to %d digits",
+					i, j, rounded.Embeddings[i][j], v, digits)
+			}
+		}
+	}
+}
+
+func TestTermiteNode_HandleApiEmbed_PrecisionValidation(t *testing.T) {
+	server := newMultipartTestServer(t, denseEmbedder{}, EmbedLimits{})
+
+	for _, precision := range []int{-1, maxEmbedPrecision + 1} {
+		body, err := json.Marshal(map[string]any{"model": "clip-vit-base", "input": "text", "precision": precision})
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL+"/api/embed", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "precision %d", precision)
+	}
+
+	model := [2]string{"model", "clip-vit-base"}
+	resp := postEmbedMultipart(t, server.URL, [][2]string{model, {"precision", "two"}, {"input", "text"}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = postEmbedMultipart(t, server.URL, [][2]string{model, {"precision", "2"}, {"input", "text"}})
+	got := decodeEmbedResponse(t, resp)
+	require.Len(t, got.Embeddings, 1)
+	assert.Equal(t, roundSignificant(float32(math.Sin(1)/7), 2), got.Embeddings[0][0])
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/antflydb/antfly-go/libaf/embeddings"
 	"github.com/cespare/xxhash/v2"
 	"github.com/jellydator/ttlcache/v3"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/singleflight"
 )
@@ -73,15 +75,19 @@ func (c *CachedEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart)
 	key := c.cacheKey(contents)
 
 	// Check cache first
+	span := trace.SpanFromContext(ctx)
 	if item := c.cache.Get(key); item != nil {
 		c.hits.Add(1)
 		RecordCacheHit("embedding")
+		span.SetAttributes(attrCacheHit.Bool(true))
 		c.logger.Debug("Embedding cache hit",
 			zap.String("model", c.model),
 			zap.Int("num_embeddings", len(item.Value())))
 		return item.Value(), nil
 	}
 
+	span.SetAttributes(attrCacheHit.Bool(false))
+
 	// Use singleflight to deduplicate concurrent identical requests
 	result, err, shared := c.sfGroup.Do(key, func() (any, error) {
 		c.misses.Add(1)
@@ -148,10 +154,29 @@ func (c *CachedEmbedder) cacheKey(contents [][]ai.ContentPart) string {
 		_, _ = h.WriteString("||")
 	}
 
-	// Convert uint64 hash to hex string
+	return modelCacheKey(c.model, h.Sum64())
+}
+
+// modelCacheKey prefixes a content hash with the model name so that a
+// model's entries can be found again by InvalidateModel
+func modelCacheKey(model string, hash uint64) string {
 	var buf [8]byte
-	binary.BigEndian.PutUint64(buf[:], h.Sum64())
-	return string(buf[:])
+	binary.BigEndian.PutUint64(buf[:], hash)
+	return model + "\x00" + string(buf[:])
+}
+
+// deleteModelEntries removes all entries of model from cache and returns how
+// many were removed
+func deleteModelEntries[V any](cache *ttlcache.Cache[string, V], model string) int {
+	prefix := model + "\x00"
+	var removed int
+	for _, key := range cache.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			cache.Delete(key)
+			removed++
+		}
+	}
+	return removed
 }
 
 // Close closes the underlying embedder
@@ -212,6 +237,12 @@ func (ec *EmbeddingCache) WrapEmbedder(embedder embeddings.Embedder, model strin
 	return NewCachedEmbedder(embedder, model, ec.cache, ec.logger.Named(model))
 }
 
+// InvalidateModel removes all cached embeddings of model, e.g. after its
+// weights changed, and returns how many entries were removed
+func (ec *EmbeddingCache) InvalidateModel(model string) int {
+	return deleteModelEntries(ec.cache, model)
+}
+
 // Close stops the cache
 func (ec *EmbeddingCache) Close() {
 	ec.cancel()
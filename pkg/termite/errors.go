@@ -0,0 +1,80 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/antflydb/antfly-go/libaf/reranking"
+)
+
+var (
+	// ErrModelNotFound is returned when a registry has no model of the
+	// requested name
+	ErrModelNotFound = errors.New("model not found")
+
+	// ErrNoModelsConfigured is returned when a request needs a kind of model
+	// the node has none of
+	ErrNoModelsConfigured = errors.New("no models configured")
+)
+
+// errorStatus maps an error from model lookup or request admission to its
+// HTTP status code. Errors are matched by kind, not by the order in which a
+// handler checks for them.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrModelNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNoModelsConfigured), errors.Is(err, ErrQueueFull):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrRequestTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// modelErrorMessage describes a failed model lookup for requested, which
+// resolved to the registered name resolved
+func modelErrorMessage(requested, resolved string, err error) string {
+	if errors.Is(err, ErrModelNotFound) {
+		return modelNotFoundMessage(requested, resolved)
+	}
+	return err.Error()
+}
+
+// acquireEmbedder acquires the named embedder, or fails with
+// ErrNoModelsConfigured if the node serves no embedders
+func (ln *TermiteNode) acquireEmbedder(modelName string) (embeddings.Embedder, func(), error) {
+	if ln.embedderProvider == nil {
+		return nil, nil, fmt.Errorf("embedding not available: %w", ErrNoModelsConfigured)
+	}
+	return ln.embedderProvider.Acquire(modelName)
+}
+
+// acquireReranker acquires the named reranker, or fails with
+// ErrNoModelsConfigured if the node serves no rerankers
+func (ln *TermiteNode) acquireReranker(modelName string) (reranking.Model, func(), error) {
+	if ln.rerankerRegistry == nil || len(ln.rerankerRegistry.List()) == 0 {
+		return nil, nil, fmt.Errorf("reranking not available: %w", ErrNoModelsConfigured)
+	}
+	return ln.rerankerRegistry.Acquire(modelName)
+}
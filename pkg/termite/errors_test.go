@@ -0,0 +1,77 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "model not found", err: ErrModelNotFound, want: http.StatusNotFound},
+		{name: "wrapped model not found", err: fmt.Errorf("embedder %w: bge", ErrModelNotFound), want: http.StatusNotFound},
+		{name: "variant not loaded", err: fmt.Errorf("quantized variant of clip not loaded: %w", ErrModelNotFound), want: http.StatusNotFound},
+		{name: "no models configured", err: fmt.Errorf("embedding not available: %w", ErrNoModelsConfigured), want: http.StatusServiceUnavailable},
+		{name: "queue full", err: ErrQueueFull, want: http.StatusServiceUnavailable},
+		{name: "request timeout", err: ErrRequestTimeout, want: http.StatusGatewayTimeout},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: http.StatusGatewayTimeout},
+		{name: "cancelled", err: context.Canceled, want: http.StatusRequestTimeout},
+		{name: "load failure", err: errors.New("loading model: corrupt weights"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorStatus(tt.err))
+		})
+	}
+}
+
+func TestTermiteNode_HandleApiEmbed_ErrorStatus(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	registry := &EmbedderRegistry{
+		models: map[string]embeddings.Embedder{"bge-small-en": &MockEmbedder{}},
+		logger: logger,
+	}
+
+	tests := []struct {
+		name     string
+		provider EmbedderProvider
+		model    string
+		want     int
+	}{
+		{name: "no models configured", model: "bge-small-en", want: http.StatusServiceUnavailable},
+		{name: "missing model", provider: registry, model: "gte-base", want: http.StatusNotFound},
+		{name: "missing model name", provider: registry, want: http.StatusBadRequest},
+		{name: "missing model name without models", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &TermiteNode{logger: logger, embedderProvider: tt.provider}
+			w := postEmbed(t, NewTermiteAPI(logger, node), tt.model)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+}
@@ -30,6 +30,7 @@ var (
 // HealthResponse is the response for /healthz endpoint
 type HealthResponse struct {
 	Status string `json:"status"`
+	Error  string `json:"error,omitempty"` // Why the deep liveness check failed
 }
 
 // ReadyResponse is the response for /readyz endpoint
@@ -46,9 +47,22 @@ type ReadyModels struct {
 	Rerankers int `json:"rerankers"`
 }
 
-// handleHealthz returns 200 if the service is running (liveness check)
+// handleHealthz returns 200 if the service is running (liveness check).
+// With the deep liveness check enabled, it returns 503 once the canary
+// inference has failed or hung too many times in a row.
 func (ln *TermiteNode) handleHealthz(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	if ln.liveness != nil {
+		if ok, err := ln.liveness.healthy(); !ok {
+			resp := HealthResponse{Status: "unhealthy"}
+			if err != nil {
+				resp.Error = err.Error()
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = encoder.NewStreamEncoder(w).Encode(resp)
+			return
+		}
+	}
 	w.WriteHeader(http.StatusOK)
 	_ = encoder.NewStreamEncoder(w).Encode(HealthResponse{Status: "ok"})
 }
@@ -0,0 +1,179 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"golang.org/x/sync/singleflight"
+)
+
+// IdempotencyKeyHeader names the client-supplied token that identifies
+// retries of the same request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed for a retry
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// Idempotency cache defaults. The cache is bounded by the total size of the
+// response bodies it keeps, and responses larger than
+// IdempotencyMaxResponseBytes are not kept at all, so that retries of them
+// compute again.
+const (
+	IdempotencyTTL              = 5 * time.Minute
+	IdempotencyCapacity         = 1024
+	IdempotencyMaxBytes         = 64 << 20 // 64 MiB
+	IdempotencyMaxResponseBytes = 4 << 20  // 4 MiB
+)
+
+// recordedResponse is a response kept for replay to retries
+type recordedResponse struct {
+	bodyHash [sha256.Size]byte // Hash of the request body it answered
+	status   int
+	header   http.Header
+	body     []byte
+}
+
+// IdempotencyCache deduplicates requests that carry the same Idempotency-Key:
+// concurrent retries wait for the first request, and later retries within
+// the TTL get its response replayed. Only successful responses are kept, so
+// a retry after a failure computes again.
+type IdempotencyCache struct {
+	cache *ttlcache.Cache[string, *recordedResponse]
+	group singleflight.Group
+	// maxResponseBytes is the largest response body kept for replay
+	maxResponseBytes int
+}
+
+// NewIdempotencyCache creates a started IdempotencyCache
+func NewIdempotencyCache() *IdempotencyCache {
+	cache := ttlcache.New(
+		ttlcache.WithTTL[string, *recordedResponse](IdempotencyTTL),
+		ttlcache.WithCapacity[string, *recordedResponse](IdempotencyCapacity),
+		ttlcache.WithMaxCost[string, *recordedResponse](IdempotencyMaxBytes,
+			func(item ttlcache.CostItem[string, *recordedResponse]) uint64 {
+				return uint64(len(item.Value.body))
+			}),
+	)
+	go cache.Start()
+	return &IdempotencyCache{cache: cache, maxResponseBytes: IdempotencyMaxResponseBytes}
+}
+
+// Close stops the cache
+func (ic *IdempotencyCache) Close() {
+	ic.cache.Stop()
+}
+
+// withIdempotency serves requests with an Idempotency-Key through the node's
+// idempotency cache. A key reused with a different body is rejected. It
+// buffers the whole body, so it must run inside withRequestQueue.
+func (ln *TermiteNode) withIdempotency(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || ln.idempotencyCache == nil {
+			next(w, r)
+			return
+		}
+		ic := ln.idempotencyCache
+
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "reading request body: "+err.Error(), requestBodyStatus(err))
+			return
+		}
+		hash := sha256.Sum256(body)
+
+		if item := ic.cache.Get(key); item != nil {
+			if item.Value().bodyHash != hash {
+				writeIdempotencyKeyReused(w)
+				return
+			}
+			item.Value().replay(w, true)
+			return
+		}
+
+		// Retries usually follow a client timeout, so the first request
+		// finishes (within the request timeout) even if its client went away
+		leader := false
+		result, _, _ := ic.group.Do(key, func() (any, error) {
+			leader = true
+			req := r.Clone(context.WithoutCancel(r.Context()))
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			rec := &recordedResponse{bodyHash: hash, status: http.StatusOK, header: make(http.Header)}
+			next(&responseRecorder{rec: rec}, req)
+			if rec.status >= 200 && rec.status < 300 && len(rec.body) <= ic.maxResponseBytes {
+				ic.cache.Set(key, rec, ttlcache.DefaultTTL)
+			}
+			return rec, nil
+		})
+		rec := result.(*recordedResponse)
+		if rec.bodyHash != hash {
+			writeIdempotencyKeyReused(w)
+			return
+		}
+		rec.replay(w, !leader)
+	}
+}
+
+// writeIdempotencyKeyReused rejects a request whose Idempotency-Key was
+// already used with a different body
+func writeIdempotencyKeyReused(w http.ResponseWriter) {
+	http.Error(w, IdempotencyKeyHeader+" was already used for a different request", http.StatusUnprocessableEntity)
+}
+
+// replay writes the recorded response to w
+func (rr *recordedResponse) replay(w http.ResponseWriter, replayed bool) {
+	for name, values := range rr.header {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+	if replayed {
+		w.Header().Set(IdempotentReplayedHeader, "true")
+	}
+	w.WriteHeader(rr.status)
+	_, _ = w.Write(rr.body)
+}
+
+// responseRecorder captures a handler's response into a recordedResponse
+type responseRecorder struct {
+	rec         *recordedResponse
+	wroteHeader bool
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.rec.header
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.rec.status = code
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	r.rec.body = append(r.rec.body, p...)
+	return len(p), nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newIdempotencyTestNode(t *testing.T, embedder *MockEmbedder) (*TermiteNode, http.Handler) {
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+	node.idempotencyCache = NewIdempotencyCache()
+	t.Cleanup(node.idempotencyCache.Close)
+	return node, NewTermiteAPI(node.logger, node)
+}
+
+func postIdempotentEmbed(t *testing.T, handler http.Handler, key, text string) *httptest.ResponseRecorder {
+	var input EmbedRequest_Input
+	require.NoError(t, input.FromEmbedRequestInput0(text))
+	req := newEmbedRequest(t, EmbedRequest{Model: "bge-small-en", Input: input})
+	req.Header.Set(IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyKey_Retry(t *testing.T) {
+	embedder := &MockEmbedder{}
+	node, handler := newIdempotencyTestNode(t, embedder)
+
+	first := postIdempotentEmbed(t, handler, "req-1", "hello")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	// Bypass the embedding cache, which would also answer the retry
+	node.embeddingCache.InvalidateModel("bge-small-en")
+
+	retry := postIdempotentEmbed(t, handler, "req-1", "hello")
+	require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, int32(1), embedder.GetCallCount())
+
+	// A new key computes again
+	node.embeddingCache.InvalidateModel("bge-small-en")
+	other := postIdempotentEmbed(t, handler, "req-2", "hello")
+	require.Equal(t, http.StatusOK, other.Code)
+	assert.Equal(t, int32(2), embedder.GetCallCount())
+}
+
+func TestIdempotencyKey_DifferentBody(t *testing.T) {
+	_, handler := newIdempotencyTestNode(t, &MockEmbedder{})
+
+	require.Equal(t, http.StatusOK, postIdempotentEmbed(t, handler, "req-1", "hello").Code)
+	w := postIdempotentEmbed(t, handler, "req-1", "goodbye")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotencyKey_ConcurrentDifferentBody(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var once sync.Once
+	embedder := &MockEmbedder{embedFunc: func(ctx context.Context, values []string) ([][]float32, error) {
+		once.Do(func() {
+			close(started)
+			<-unblock
+		})
+		return [][]float32{{1, 2}}, nil
+	}}
+	_, handler := newIdempotencyTestNode(t, embedder)
+
+	first := make(chan int)
+	go func() { first <- postIdempotentEmbed(t, handler, "req-1", "hello").Code }()
+	<-started
+
+	// The second request waits for the first under the same key, then is
+	// rejected because its body differs
+	second := make(chan int)
+	go func() { second <- postIdempotentEmbed(t, handler, "req-1", "goodbye").Code }()
+	time.Sleep(50 * time.Millisecond)
+	close(unblock)
+
+	assert.Equal(t, http.StatusOK, <-first)
+	assert.Equal(t, http.StatusUnprocessableEntity, <-second)
+	assert.Equal(t, int32(1), embedder.GetCallCount())
+}
+
+func TestIdempotencyKey_QueueBeforeBuffering(t *testing.T) {
+	node, handler := newIdempotencyTestNode(t, &MockEmbedder{})
+	node.requestQueue = NewRequestQueue(RequestQueueConfig{MaxConcurrentRequests: 1, MaxQueueSize: -1}, zaptest.NewLogger(t))
+	release, err := node.requestQueue.Acquire(t.Context())
+	require.NoError(t, err)
+	defer release()
+
+	// A full queue rejects the request without reading its body
+	body := &countingReader{Reader: strings.NewReader(`{"model":"bge-small-en","input":"hello"}`)}
+	req := httptest.NewRequest(http.MethodPost, "/api/embed", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, "req-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Zero(t, body.n)
+}
+
+func TestIdempotencyKey_LargeResponseNotKept(t *testing.T) {
+	embedder := &MockEmbedder{}
+	node, handler := newIdempotencyTestNode(t, embedder)
+	node.idempotencyCache.maxResponseBytes = 16
+
+	first := postIdempotentEmbed(t, handler, "req-1", "hello")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	require.Greater(t, first.Body.Len(), 16)
+
+	// The response was too large to keep, so the retry computes again
+	node.embeddingCache.InvalidateModel("bge-small-en")
+	retry := postIdempotentEmbed(t, handler, "req-1", "hello")
+	require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+	assert.Empty(t, retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, int32(2), embedder.GetCallCount())
+}
+
+func TestIdempotencyCache_MaxBytes(t *testing.T) {
+	ic := NewIdempotencyCache()
+	defer ic.Close()
+
+	// Responses are evicted, oldest first, once their bodies exceed the
+	// total size bound
+	for _, key := range []string{"a", "b", "c"} {
+		ic.cache.Set(key, &recordedResponse{body: make([]byte, IdempotencyMaxBytes/2)}, IdempotencyTTL)
+	}
+	assert.Nil(t, ic.cache.Get("a"))
+	assert.NotNil(t, ic.cache.Get("b"))
+	assert.NotNil(t, ic.cache.Get("c"))
+}
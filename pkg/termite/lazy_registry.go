@@ -18,8 +18,8 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -60,6 +60,9 @@ type LazyEmbedderRegistry struct {
 	pinned   map[string]embeddings.Embedder
 	pinnedMu sync.RWMutex
 
+	// In-flight uses of loaded models, which delay closing evicted models
+	refs modelRefs
+
 	// Configuration
 	keepAlive       time.Duration
 	maxLoadedModels uint64
@@ -139,15 +142,18 @@ func NewLazyEmbedderRegistry(
 		logger.Info("Unloading embedder model",
 			zap.String("model", modelName),
 			zap.String("reason", reasonStr))
-
-		// Close the embedder to free resources
-		if closer, ok := embedder.(interface{ Close() error }); ok {
-			if err := closer.Close(); err != nil {
-				logger.Warn("Error closing embedder",
-					zap.String("model", modelName),
-					zap.Error(err))
+		ClearModelMemoryEstimate(modelName, "embedder")
+
+		// Close the embedder to free resources once requests using it finish
+		registry.refs.retire(embedder, func() {
+			if closer, ok := embedder.(interface{ Close() error }); ok {
+				if err := closer.Close(); err != nil {
+					logger.Warn("Error closing embedder",
+						zap.String("model", modelName),
+						zap.Error(err))
+				}
 			}
-		}
+		})
 	})
 
 	// Start the cache cleanup goroutine
@@ -175,68 +181,81 @@ func (r *LazyEmbedderRegistry) discoverModels() error {
 		return nil
 	}
 
-	entries, err := os.ReadDir(r.modelsDir)
+	if _, err := r.Reload(); err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	discovered := len(r.discovered)
+	r.mu.RUnlock()
+
+	r.logger.Info("Embedder model discovery complete",
+		zap.Int("models_discovered", discovered),
+		zap.Duration("keep_alive", r.keepAlive),
+		zap.Uint64("max_loaded_models", r.maxLoadedModels))
+
+	return nil
+}
+
+// Reload re-scans the models directory. New models become available for lazy
+// loading and models removed from disk are unloaded, including pinned ones.
+// Models that are still present keep their loaded state and keep-alive.
+func (r *LazyEmbedderRegistry) Reload() (*ReloadResult, error) {
+	found, err := discoverModelFiles(r.modelsDir, "embedder", r.logger)
 	if err != nil {
-		return fmt.Errorf("reading models directory: %w", err)
+		return nil, err
 	}
 
 	poolSize := min(runtime.NumCPU(), 4)
+	result := &ReloadResult{}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
+	r.mu.Lock()
+	for name, mf := range found {
+		if _, ok := r.discovered[name]; ok {
 			continue
 		}
-
-		modelName := entry.Name()
-		modelPath := filepath.Join(r.modelsDir, modelName)
-
-		// Discover all available variants using shared helper
-		variants := discoverModelVariants(modelPath)
-		if len(variants) == 0 {
-			r.logger.Debug("Skipping directory without model files",
-				zap.String("dir", modelName))
-			continue
+		r.discovered[name] = &ModelInfo{
+			Name:         name,
+			Path:         mf.Path,
+			OnnxFilename: mf.OnnxFilename,
+			PoolSize:     poolSize,
+			ModelType:    "embedder",
+			Variants:     mf.Variants,
 		}
-
-		// Collect variant IDs for logging
-		variantIDs := make([]string, 0, len(variants))
-		for v := range variants {
-			if v == "" {
-				variantIDs = append(variantIDs, "default")
-			} else {
-				variantIDs = append(variantIDs, v)
-			}
+		result.Added = append(result.Added, name)
+	}
+	for name := range r.discovered {
+		if _, ok := found[name]; !ok {
+			delete(r.discovered, name)
+			result.Removed = append(result.Removed, name)
 		}
+	}
+	r.mu.Unlock()
 
-		r.logger.Info("Discovered embedder model (not loaded)",
-			zap.String("name", modelName),
-			zap.String("path", modelPath),
-			zap.Strings("variants", variantIDs))
+	for _, name := range result.Removed {
+		r.pinnedMu.Lock()
+		embedder, isPinned := r.pinned[name]
+		delete(r.pinned, name)
+		r.pinnedMu.Unlock()
 
-		// Register each variant
-		for variantID, onnxFilename := range variants {
-			registryName := modelName
-			if variantID != "" {
-				registryName = modelName + "-" + variantID
-			}
-
-			r.discovered[registryName] = &ModelInfo{
-				Name:         registryName,
-				Path:         modelPath,
-				OnnxFilename: onnxFilename,
-				PoolSize:     poolSize,
-				ModelType:    "embedder",
-				Variants:     variantIDs,
-			}
+		if isPinned {
+			ClearModelMemoryEstimate(name, "embedder")
+			r.refs.retire(embedder, func() {
+				if err := closeEmbedder(embedder); err != nil {
+					r.logger.Warn("Error closing removed pinned embedder",
+						zap.String("model", name),
+						zap.Error(err))
+				}
+			})
+			continue
 		}
+		// Triggers the eviction callback, which closes the model
+		r.cache.Delete(name)
 	}
 
-	r.logger.Info("Embedder model discovery complete",
-		zap.Int("models_discovered", len(r.discovered)),
-		zap.Duration("keep_alive", r.keepAlive),
-		zap.Uint64("max_loaded_models", r.maxLoadedModels))
-
-	return nil
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	return result, nil
 }
 
 // Get returns an embedder by model name, loading it if necessary
@@ -264,13 +283,44 @@ func (r *LazyEmbedderRegistry) Get(modelName string) (embeddings.Embedder, error
 	r.mu.RUnlock()
 
 	if !known {
-		return nil, fmt.Errorf("embedder model not found: %s", modelName)
+		return nil, fmt.Errorf("embedder %w: %s", ErrModelNotFound, modelName)
 	}
 
 	// Load the model (with synchronization to prevent double-loading)
 	return r.loadModel(info)
 }
 
+// Acquire returns an embedder by model name for use by a request, loading it
+// if necessary. The model stays open, even if it is evicted or a reload
+// removes it, until release is called.
+func (r *LazyEmbedderRegistry) Acquire(modelName string) (embeddings.Embedder, func(), error) {
+	for {
+		embedder, err := r.Get(modelName)
+		if err != nil {
+			return nil, nil, err
+		}
+		release := r.refs.acquire(embedder)
+		if r.isCurrent(modelName, embedder) {
+			return embedder, release, nil
+		}
+		// Evicted between lookup and acquire, possibly already closed
+		release()
+	}
+}
+
+// isCurrent reports whether embedder is still the loaded instance of
+// modelName, pinned or cached
+func (r *LazyEmbedderRegistry) isCurrent(modelName string, embedder embeddings.Embedder) bool {
+	r.pinnedMu.RLock()
+	pinned, isPinned := r.pinned[modelName]
+	r.pinnedMu.RUnlock()
+	if isPinned {
+		return pinned == embedder
+	}
+	item := r.cache.Get(modelName, ttlcache.WithDisableTouchOnHit[string, embeddings.Embedder]())
+	return item != nil && item.Value() == embedder
+}
+
 // loadModel loads a model on demand
 func (r *LazyEmbedderRegistry) loadModel(info *ModelInfo) (embeddings.Embedder, error) {
 	r.mu.Lock()
@@ -287,6 +337,7 @@ func (r *LazyEmbedderRegistry) loadModel(info *ModelInfo) (embeddings.Embedder,
 		zap.String("onnx_filename", info.OnnxFilename),
 		zap.Int("pool_size", info.PoolSize))
 
+	start := time.Now()
 	embedder, err := termembeddings.NewPooledHugotEmbedderWithSession(
 		info.Path,
 		info.OnnxFilename,
@@ -301,11 +352,19 @@ func (r *LazyEmbedderRegistry) loadModel(info *ModelInfo) (embeddings.Embedder,
 		return nil, fmt.Errorf("loading embedder model %s: %w", info.Name, err)
 	}
 
+	loadTime := time.Since(start)
+	RecordModelLoadDuration(info.Name, "embedder", loadTime.Seconds())
+	SetModelMemoryEstimate(info.Name, "embedder", estimateModelMemory(modelFile{
+		Path:         info.Path,
+		OnnxFilename: info.OnnxFilename,
+	}, info.PoolSize))
+
 	// Store in cache with TTL
 	r.cache.Set(info.Name, embedder, ttlcache.DefaultTTL)
 
 	r.logger.Info("Successfully loaded embedder model",
 		zap.String("model", info.Name),
+		zap.Duration("load_time", loadTime),
 		zap.Duration("keep_alive", r.keepAlive))
 
 	return embedder, nil
@@ -320,7 +379,7 @@ func (r *LazyEmbedderRegistry) Touch(modelName string) {
 	}
 }
 
-// List returns all available (discovered) model names
+// List returns all available (discovered) model names, sorted
 func (r *LazyEmbedderRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -329,10 +388,12 @@ func (r *LazyEmbedderRegistry) List() []string {
 	for name := range r.discovered {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
-// ListLoaded returns currently loaded model names (from cache and pinned)
+// ListLoaded returns currently loaded model names (from cache and pinned):
+// pinned models first, then cached ones, each sorted
 func (r *LazyEmbedderRegistry) ListLoaded() []string {
 	// Get cache keys
 	keys := r.cache.Keys()
@@ -344,6 +405,8 @@ func (r *LazyEmbedderRegistry) ListLoaded() []string {
 		pinnedNames = append(pinnedNames, name)
 	}
 	r.pinnedMu.RUnlock()
+	sort.Strings(pinnedNames)
+	sort.Strings(keys)
 
 	// Combine (pinned first, then cache)
 	names := make([]string, 0, len(keys)+len(pinnedNames))
@@ -489,6 +552,7 @@ func (r *LazyEmbedderRegistry) Stats() map[string]any {
 		pinnedNames = append(pinnedNames, name)
 	}
 	r.pinnedMu.RUnlock()
+	sort.Strings(pinnedNames)
 
 	return map[string]any{
 		"discovered":    len(r.discovered),
@@ -35,8 +35,11 @@ const (
 	ModelFixedBPE = "fixed-bpe-tokenizer"
 )
 
-// Ensure FixedChunker implements the Chunker interface
-var _ chunking.Chunker = (*FixedChunker)(nil)
+// Ensure FixedChunker implements the Chunker and StreamingChunker interfaces
+var (
+	_ chunking.Chunker = (*FixedChunker)(nil)
+	_ StreamingChunker = (*FixedChunker)(nil)
+)
 
 // FixedChunkerConfig contains configuration for the fixed chunker.
 type FixedChunkerConfig struct {
@@ -131,14 +134,30 @@ func NewFixedChunker(config FixedChunkerConfig) (*FixedChunker, error) {
 
 // Chunk splits text into chunks with per-request config overrides.
 func (s *FixedChunker) Chunk(ctx context.Context, text string, opts chunking.ChunkOptions) ([]chunking.Chunk, error) {
-	if text == "" {
+	chunks := make([]chunking.Chunk, 0)
+	err := s.ChunkStream(ctx, text, opts, func(c chunking.Chunk) error {
+		chunks = append(chunks, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 0 {
 		return nil, nil
 	}
+	return chunks, nil
+}
+
+// ChunkStream splits text like Chunk, emitting each chunk as soon as it is finalized.
+func (s *FixedChunker) ChunkStream(ctx context.Context, text string, opts chunking.ChunkOptions, emit EmitFunc) error {
+	if text == "" {
+		return nil
+	}
 
 	// Check context cancellation
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return ctx.Err()
 	default:
 	}
 
@@ -177,10 +196,10 @@ func (s *FixedChunker) Chunk(ctx context.Context, text string, opts chunking.Chu
 	}
 
 	if len(sections) == 0 {
-		return nil, nil
+		return nil
 	}
 
-	chunks := make([]chunking.Chunk, 0)
+	numChunks := 0
 	currentChunk := strings.Builder{}
 	currentStartChar := 0
 	currentTokens := 0
@@ -190,7 +209,7 @@ func (s *FixedChunker) Chunk(ctx context.Context, text string, opts chunking.Chu
 		// Check context cancellation periodically
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 
@@ -207,16 +226,19 @@ func (s *FixedChunker) Chunk(ctx context.Context, text string, opts chunking.Chu
 			// Finalize current chunk
 			chunkText := strings.TrimSpace(currentChunk.String())
 			if chunkText != "" {
-				chunks = append(chunks, chunking.Chunk{
-					Id:        uint32(len(chunks)),
+				if err := emit(chunking.Chunk{
+					Id:        uint32(numChunks),
 					Text:      chunkText,
 					StartChar: currentStartChar,
 					EndChar:   currentStartChar + len(chunkText),
-				})
+				}); err != nil {
+					return err
+				}
+				numChunks++
 
 				// Check max chunks limit
-				if len(chunks) >= effectiveConfig.MaxChunks {
-					break
+				if numChunks >= effectiveConfig.MaxChunks {
+					return nil
 				}
 
 				previousChunkText = chunkText
@@ -251,18 +273,21 @@ func (s *FixedChunker) Chunk(ctx context.Context, text string, opts chunking.Chu
 
 	// Add final chunk
 	chunkText := strings.TrimSpace(currentChunk.String())
-	if chunkText != "" && len(chunks) < effectiveConfig.MaxChunks {
-		chunks = append(chunks, chunking.Chunk{
-			Id:        uint32(len(chunks)),
+	if chunkText != "" && numChunks < effectiveConfig.MaxChunks {
+		if err := emit(chunking.Chunk{
+			Id:        uint32(numChunks),
 			Text:      chunkText,
 			StartChar: currentStartChar,
 			EndChar:   currentStartChar + len(chunkText),
-		})
+		}); err != nil {
+			return err
+		}
+		numChunks++
 	}
 
 	// If no chunks were created (text too short), return single chunk
-	if len(chunks) == 0 {
-		chunks = append(chunks, chunking.Chunk{
+	if numChunks == 0 {
+		return emit(chunking.Chunk{
 			Id:        0,
 			Text:      strings.TrimSpace(text),
 			StartChar: 0,
@@ -270,7 +295,7 @@ func (s *FixedChunker) Chunk(ctx context.Context, text string, opts chunking.Chu
 		})
 	}
 
-	return chunks, nil
+	return nil
 }
 
 // extractOverlap extracts the last N tokens from text for overlap
@@ -288,6 +313,11 @@ func (s *FixedChunker) extractOverlap(text string, targetTokens int) string {
 	return text[len(text)-overlapChars:]
 }
 
+// CountTokens returns the number of tokens in text using the chunker's tokenizer
+func (s *FixedChunker) CountTokens(text string) int {
+	return s.tokenizer.CountTokens(text)
+}
+
 // Close releases tokenizer resources
 func (s *FixedChunker) Close() error {
 	// Tokenizer doesn't need explicit closing
@@ -25,6 +25,7 @@ import (
 
 	"github.com/antflydb/antfly-go/libaf/chunking"
 	"github.com/antflydb/termite/pkg/termite/lib/hugot"
+	"github.com/antflydb/termite/pkg/termite/lib/tokenizer"
 	khugot "github.com/knights-analytics/hugot"
 	"github.com/knights-analytics/hugot/pipelines"
 	"go.uber.org/zap"
@@ -62,7 +63,8 @@ type HugotChunker struct {
 	session       *khugot.Session
 	pipeline      *pipelines.TokenClassificationPipeline
 	config        HugotChunkerConfig
-	chunkLabel    string // The label that indicates a chunk boundary (e.g., "separator")
+	chunkLabel    string              // The label that indicates a chunk boundary (e.g., "separator")
+	tokenizer     tokenizer.Tokenizer // nil if the model ships no tokenizer.json
 	logger        *zap.Logger
 	sessionShared bool // true if session is shared and shouldn't be destroyed
 }
@@ -140,7 +142,7 @@ func NewHugotChunkerWithSession(config HugotChunkerConfig, modelPath string, onn
 	if err != nil {
 		// Only destroy session if we created it (not shared)
 		if !sessionShared {
-			_ = session.Destroy()
+			_ = hugot.ReleaseSession(session)
 		}
 		logger.Error("Failed to create pipeline", zap.Error(err))
 		return nil, fmt.Errorf("creating token classification pipeline: %w", err)
@@ -157,6 +159,7 @@ func NewHugotChunkerWithSession(config HugotChunkerConfig, modelPath string, onn
 		pipeline:      pipeline,
 		config:        config,
 		chunkLabel:    "separator", // Chonky model uses "separator" for boundaries, "O" for content
+		tokenizer:     loadModelTokenizer(modelPath, logger),
 		logger:        logger,
 		sessionShared: sessionShared,
 	}, nil
@@ -380,6 +383,15 @@ func (h *HugotChunker) estimateTokens(text string) int {
 	return len(text) / 4
 }
 
+// CountTokens returns the number of tokens in text using the model's
+// tokenizer, or an estimate if the model has no tokenizer.json.
+func (h *HugotChunker) CountTokens(text string) int {
+	if h.tokenizer == nil {
+		return h.estimateTokens(text)
+	}
+	return h.tokenizer.CountTokens(text)
+}
+
 // aggregateByTargetTokens combines consecutive chunks until they reach target token count.
 func (h *HugotChunker) aggregateByTargetTokens(chunks []chunking.Chunk, config HugotChunkerConfig) []chunking.Chunk {
 	if len(chunks) == 0 {
@@ -441,8 +453,8 @@ func (h *HugotChunker) aggregateByTargetTokens(chunks []chunking.Chunk, config H
 // Only destroys the session if it was created by this chunker (not shared).
 func (h *HugotChunker) Close() error {
 	if h.session != nil && !h.sessionShared {
-		h.logger.Info("Destroying Hugot session (owned by this chunker)")
-		return h.session.Destroy()
+		h.logger.Info("Releasing Hugot session (owned by this chunker)")
+		return hugot.ReleaseSession(h.session)
 	} else if h.sessionShared {
 		h.logger.Debug("Skipping session destruction (shared session)")
 	}
@@ -458,6 +470,7 @@ type PooledHugotChunker struct {
 	nextPipeline  atomic.Uint64
 	config        HugotChunkerConfig
 	chunkLabel    string
+	tokenizer     tokenizer.Tokenizer // nil if the model ships no tokenizer.json
 	logger        *zap.Logger
 	sessionShared bool
 	poolSize      int
@@ -541,7 +554,7 @@ func NewPooledHugotChunkerWithSession(config HugotChunkerConfig, modelPath strin
 		if err != nil {
 			// Clean up already-created pipelines
 			if !sessionShared {
-				_ = session.Destroy()
+				_ = hugot.ReleaseSession(session)
 			}
 			logger.Error("Failed to create pipeline",
 				zap.Int("index", i),
@@ -562,6 +575,7 @@ func NewPooledHugotChunkerWithSession(config HugotChunkerConfig, modelPath strin
 		sem:           semaphore.NewWeighted(int64(poolSize)),
 		config:        config,
 		chunkLabel:    "separator",
+		tokenizer:     loadModelTokenizer(modelPath, logger),
 		logger:        logger,
 		sessionShared: sessionShared,
 		poolSize:      poolSize,
@@ -742,6 +756,15 @@ func (p *PooledHugotChunker) estimateTokens(text string) int {
 	return len(text) / 4
 }
 
+// CountTokens returns the number of tokens in text using the model's
+// tokenizer, or an estimate if the model has no tokenizer.json.
+func (p *PooledHugotChunker) CountTokens(text string) int {
+	if p.tokenizer == nil {
+		return p.estimateTokens(text)
+	}
+	return p.tokenizer.CountTokens(text)
+}
+
 // aggregateByTargetTokens combines consecutive chunks until they reach target token count.
 func (p *PooledHugotChunker) aggregateByTargetTokens(chunks []chunking.Chunk, config HugotChunkerConfig) []chunking.Chunk {
 	if len(chunks) == 0 {
@@ -793,8 +816,8 @@ func (p *PooledHugotChunker) aggregateByTargetTokens(chunks []chunking.Chunk, co
 // Only destroys the session if it was created by this chunker (not shared).
 func (p *PooledHugotChunker) Close() error {
 	if p.session != nil && !p.sessionShared {
-		p.logger.Info("Destroying Hugot session (owned by this pooled chunker)")
-		return p.session.Destroy()
+		p.logger.Info("Releasing Hugot session (owned by this pooled chunker)")
+		return hugot.ReleaseSession(p.session)
 	} else if p.sessionShared {
 		p.logger.Debug("Skipping session destruction (shared session)")
 	}
@@ -0,0 +1,54 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunking
+
+import (
+	"context"
+
+	"github.com/antflydb/antfly-go/libaf/chunking"
+)
+
+// EmitFunc receives chunks in order as they are produced. Returning an error
+// stops chunking and the error is returned to the caller.
+type EmitFunc func(chunking.Chunk) error
+
+// StreamingChunker is implemented by chunkers that can emit chunks
+// incrementally instead of returning them all at once.
+type StreamingChunker interface {
+	chunking.Chunker
+
+	// ChunkStream splits text like Chunk, calling emit for each chunk as soon
+	// as it is finalized.
+	ChunkStream(ctx context.Context, text string, opts chunking.ChunkOptions, emit EmitFunc) error
+}
+
+// ChunkStream streams chunks from chunker, falling back to emitting the
+// result of Chunk when the chunker does not support streaming.
+func ChunkStream(ctx context.Context, chunker chunking.Chunker, text string, opts chunking.ChunkOptions, emit EmitFunc) error {
+	if sc, ok := chunker.(StreamingChunker); ok {
+		return sc.ChunkStream(ctx, text, opts, emit)
+	}
+
+	chunks, err := chunker.Chunk(ctx, text, opts)
+	if err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		if err := emit(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunking
+
+import (
+	"path/filepath"
+
+	"github.com/antflydb/termite/pkg/termite/lib/tokenizer"
+	"go.uber.org/zap"
+)
+
+// TokenCounter is implemented by chunkers that can count tokens the way their
+// model tokenizes text, so callers can report exact per-chunk token counts.
+type TokenCounter interface {
+	CountTokens(text string) int
+}
+
+// Ensure the built-in chunkers implement TokenCounter
+var (
+	_ TokenCounter = (*FixedChunker)(nil)
+	_ TokenCounter = (*HugotChunker)(nil)
+	_ TokenCounter = (*PooledHugotChunker)(nil)
+)
+
+// loadModelTokenizer loads the tokenizer.json shipped with a model. It returns
+// nil if the model has none, in which case token counts are estimated.
+func loadModelTokenizer(modelPath string, logger *zap.Logger) tokenizer.Tokenizer {
+	tk, err := tokenizer.NewHuggingFaceTokenizer(filepath.Join(modelPath, "tokenizer.json"))
+	if err != nil {
+		logger.Warn("Model tokenizer unavailable, estimating token counts", zap.Error(err))
+		return nil
+	}
+	return tk
+}
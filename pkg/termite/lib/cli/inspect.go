@@ -0,0 +1,147 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+)
+
+// InspectOptions contains options for inspecting a HuggingFace repo
+type InspectOptions struct {
+	HFToken    string
+	BinaryName string // Used for help messages (e.g., "termite" or "antfly termite")
+	Output     string // Output format: OutputText (default) or OutputJSON
+}
+
+// huggingFaceInspector is the subset of HuggingFaceClient used by inspect
+type huggingFaceInspector interface {
+	ListRepoFiles(ctx context.Context, repoID string) ([]string, error)
+	DetectAvailableVariants(ctx context.Context, repoID string) ([]string, error)
+	RepoFileSizes(ctx context.Context, repoID string) (map[string]int64, error)
+}
+
+// InspectHuggingFace lists the ONNX variants available in a HuggingFace repo
+// and the download size of each
+func InspectHuggingFace(repoID string, opts InspectOptions) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	hfToken := opts.HFToken
+	if hfToken == "" {
+		hfToken = os.Getenv("HF_TOKEN")
+	}
+
+	client := modelregistry.NewHuggingFaceClient(
+		modelregistry.WithHFToken(hfToken),
+	)
+	return inspectHuggingFace(ctx, client, os.Stdout, repoID, opts)
+}
+
+func inspectHuggingFace(ctx context.Context, client huggingFaceInspector, out io.Writer, repoID string, opts InspectOptions) error {
+	if err := validateOutput(opts.Output); err != nil {
+		return err
+	}
+
+	files, err := client.ListRepoFiles(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list files in %s: %w", repoID, err)
+	}
+
+	variants, err := client.DetectAvailableVariants(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to detect variants in %s: %w", repoID, err)
+	}
+
+	// Sizes are informational; still list variants if the tree API is unavailable
+	var sizes map[string]int64
+	if len(variants) > 0 {
+		sizes, err = client.RepoFileSizes(ctx, repoID)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: could not fetch file sizes: %v\n", err)
+		}
+	}
+
+	// Detection order is random; list variants in their canonical order
+	order := modelregistry.ValidVariants()
+	slices.SortFunc(variants, func(a, b string) int {
+		return slices.Index(order, variantID(a)) - slices.Index(order, variantID(b))
+	})
+
+	result := RepoInspection{
+		Repository: repoID,
+		Files:      len(files),
+		Variants:   make([]VariantListing, 0, len(variants)),
+	}
+	for _, v := range variants {
+		listing := VariantListing{
+			Variant:     v,
+			Description: modelregistry.VariantDescription(variantID(v)),
+		}
+		if sizes != nil {
+			listing.Size = modelregistry.VariantDownloadSize(sizes, variantID(v))
+		}
+		result.Variants = append(result.Variants, listing)
+	}
+
+	if opts.Output == OutputJSON {
+		return writeJSON(out, result)
+	}
+
+	_, _ = fmt.Fprintf(out, "Repository: %s\n", result.Repository)
+	_, _ = fmt.Fprintf(out, "Files: %d\n\n", result.Files)
+
+	if len(result.Variants) == 0 {
+		_, _ = fmt.Fprintln(out, "No ONNX variants found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VARIANT\tDESCRIPTION\tSIZE")
+	for _, v := range result.Variants {
+		size := "-"
+		if sizes != nil {
+			size = FormatBytes(v.Size)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", v.Variant, v.Description, size)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	binaryName := opts.BinaryName
+	if binaryName == "" {
+		binaryName = "termite"
+	}
+	_, _ = fmt.Fprintf(out, "\nPull a variant with: %s pull hf:%s --type <type> [--variant <variant>]\n", binaryName, repoID)
+	return nil
+}
+
+// variantID maps the "default" label reported by DetectAvailableVariants back
+// to the empty variant ID accepted by PullFromHuggingFace
+func variantID(label string) string {
+	if label == "default" {
+		return ""
+	}
+	return label
+}
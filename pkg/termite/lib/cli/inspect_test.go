@@ -0,0 +1,162 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mockInspector struct {
+	files    []string
+	variants []string
+	sizes    map[string]int64
+	sizesErr error
+}
+
+func (m *mockInspector) ListRepoFiles(ctx context.Context, repoID string) ([]string, error) {
+	return m.files, nil
+}
+
+func (m *mockInspector) DetectAvailableVariants(ctx context.Context, repoID string) ([]string, error) {
+	return m.variants, nil
+}
+
+func (m *mockInspector) RepoFileSizes(ctx context.Context, repoID string) (map[string]int64, error) {
+	return m.sizes, m.sizesErr
+}
+
+func TestInspectHuggingFace(t *testing.T) {
+	client := &mockInspector{
+		files: []string{
+			"config.json",
+			"tokenizer.json",
+			"onnx/model.onnx",
+			"onnx/model_fp16.onnx",
+			"onnx/model_quantized.onnx",
+		},
+		// Detection order is not stable
+		variants: []string{"quantized", "default", "fp16"},
+		sizes: map[string]int64{
+			"config.json":               1024,
+			"tokenizer.json":            1024,
+			"onnx/model.onnx":           4 * 1024 * 1024,
+			"onnx/model_fp16.onnx":      2 * 1024 * 1024,
+			"onnx/model_quantized.onnx": 1024 * 1024,
+		},
+	}
+
+	var out bytes.Buffer
+	err := inspectHuggingFace(context.Background(), client, &out, "owner/repo", InspectOptions{})
+	if err != nil {
+		t.Fatalf("inspectHuggingFace() error = %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"Repository: owner/repo",
+		"Files: 5",
+		"termite pull hf:owner/repo",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	// Variant rows in canonical order with description and size
+	var rows []string
+	for line := range strings.Lines(got) {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && (fields[0] == "default" || fields[0] == "fp16" || fields[0] == "quantized") {
+			rows = append(rows, strings.Join(fields, " "))
+		}
+	}
+	want := []string{
+		"default full precision 4.0 MB",
+		"fp16 half precision (FP16) 2.0 MB",
+		"quantized INT8 quantized 1.0 MB",
+	}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") {
+		t.Errorf("variant rows = %q, want %q", rows, want)
+	}
+}
+
+func TestInspectHuggingFace_SizesUnavailable(t *testing.T) {
+	client := &mockInspector{
+		files:    []string{"onnx/model.onnx"},
+		variants: []string{"default"},
+		sizesErr: errors.New("status 401"),
+	}
+
+	var out bytes.Buffer
+	err := inspectHuggingFace(context.Background(), client, &out, "owner/private", InspectOptions{})
+	if err != nil {
+		t.Fatalf("inspectHuggingFace() error = %v", err)
+	}
+
+	// Variants are still listed, with unknown sizes
+	var found bool
+	for line := range strings.Lines(out.String()) {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == "default" {
+			found = true
+			if last := fields[len(fields)-1]; last != "-" {
+				t.Errorf("default variant size = %q, want -", last)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("output missing default variant:\n%s", out.String())
+	}
+}
+
+func TestInspectHuggingFace_JSON(t *testing.T) {
+	client := &mockInspector{
+		files:    []string{"tokenizer.json", "onnx/model.onnx", "onnx/model_q4.onnx"},
+		variants: []string{"q4", "default"},
+		sizes: map[string]int64{
+			"tokenizer.json":     100,
+			"onnx/model.onnx":    4000,
+			"onnx/model_q4.onnx": 1000,
+		},
+	}
+
+	var out bytes.Buffer
+	err := inspectHuggingFace(context.Background(), client, &out, "owner/repo", InspectOptions{Output: OutputJSON})
+	if err != nil {
+		t.Fatalf("inspectHuggingFace() error = %v", err)
+	}
+
+	var got RepoInspection
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+
+	want := RepoInspection{
+		Repository: "owner/repo",
+		Files:      3,
+		Variants: []VariantListing{
+			{Variant: "default", Description: "full precision", Size: 4100},
+			{Variant: "q4", Description: "4-bit quantized", Size: 1100},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inspection = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+)
+
+const (
+	// OutputText prints human-readable tables (default)
+	OutputText = "text"
+	// OutputJSON prints machine-readable JSON for scripting
+	OutputJSON = "json"
+)
+
+// ModelList is the JSON output of the list commands
+type ModelList struct {
+	Models []ModelListing `json:"models"`
+}
+
+// ModelListing describes a local or remote model
+type ModelListing struct {
+	Name        string                  `json:"name"`
+	Type        modelregistry.ModelType `json:"type"`
+	Size        int64                   `json:"size"` // Total size in bytes
+	Variants    []string                `json:"variants"`
+	Description string                  `json:"description,omitempty"`
+}
+
+// RepoInspection is the JSON output of the inspect command
+type RepoInspection struct {
+	Repository string           `json:"repository"`
+	Files      int              `json:"files"`
+	Variants   []VariantListing `json:"variants"`
+}
+
+// VariantListing describes an ONNX variant available in a HuggingFace repo
+type VariantListing struct {
+	Variant     string `json:"variant"`
+	Description string `json:"description"`
+	Size        int64  `json:"size,omitempty"` // Download size in bytes; omitted if unknown
+}
+
+// validateOutput checks that format is a supported output format
+func validateOutput(format string) error {
+	switch format {
+	case "", OutputText, OutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q, valid options: %s, %s", format, OutputText, OutputJSON)
+	}
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressRedrawInterval throttles in-place redraws; the registry client
+// reports progress on every read
+const progressRedrawInterval = 100 * time.Millisecond
+
+// progressTracker accumulates per-file progress callbacks into overall
+// progress, transfer rate and ETA
+type progressTracker struct {
+	total int64 // Expected bytes across all files, 0 if unknown
+
+	start       time.Time
+	completed   int64 // Bytes of finished files
+	transferred int64 // Bytes actually downloaded by finished files
+
+	file        string
+	fileDone    int64
+	fileTotal   int64
+	fileSkipped bool // File was already complete on its first callback
+}
+
+// progressSnapshot is the state of a pull after a progress callback
+type progressSnapshot struct {
+	File        string
+	FileDone    int64
+	FileTotal   int64
+	FilePercent float64 // -1 if the file size is unknown
+
+	Done    int64
+	Total   int64
+	Percent float64 // -1 if the total size is unknown
+
+	Rate float64       // Bytes per second, 0 until measurable
+	ETA  time.Duration // -1 if unknown
+}
+
+func (p *progressTracker) update(now time.Time, downloaded, total int64, filename string) progressSnapshot {
+	if p.start.IsZero() {
+		p.start = now
+	}
+
+	if filename != p.file {
+		p.finishFile()
+		p.file = filename
+		// Files that exist locally (or are copied from a cache) report
+		// completion immediately and must not inflate the transfer rate
+		p.fileSkipped = total > 0 && downloaded >= total
+	}
+	p.fileDone = downloaded
+	p.fileTotal = total
+
+	s := progressSnapshot{
+		File:        filename,
+		FileDone:    downloaded,
+		FileTotal:   total,
+		FilePercent: -1,
+		Done:        p.completed + downloaded,
+		Total:       p.total,
+		Percent:     -1,
+		ETA:         -1,
+	}
+	if total > 0 {
+		s.FilePercent = percentOf(downloaded, total)
+	}
+	if p.total > 0 {
+		s.Percent = percentOf(s.Done, p.total)
+	}
+
+	transferred := p.transferred
+	if !p.fileSkipped {
+		transferred += downloaded
+	}
+	if elapsed := now.Sub(p.start).Seconds(); elapsed > 0 && transferred > 0 {
+		s.Rate = float64(transferred) / elapsed
+		if p.total > 0 {
+			remaining := max(p.total-s.Done, 0)
+			s.ETA = time.Duration(float64(remaining) / s.Rate * float64(time.Second))
+		}
+	}
+	return s
+}
+
+func (p *progressTracker) finishFile() {
+	p.completed += p.fileDone
+	if !p.fileSkipped {
+		p.transferred += p.fileDone
+	}
+	p.file, p.fileDone, p.fileTotal = "", 0, 0
+}
+
+func percentOf(done, total int64) float64 {
+	return min(float64(done)*100/float64(total), 100)
+}
+
+// ProgressRenderer renders pull progress with per-file and overall
+// percentage, transfer rate and ETA. On a terminal it redraws a single line
+// in place; otherwise it logs one line per completed file.
+type ProgressRenderer struct {
+	out io.Writer
+	tty bool
+	now func() time.Time
+
+	mu         sync.Mutex
+	tracker    progressTracker
+	lastDraw   time.Time
+	lastWidth  int
+	lastLogged string
+}
+
+// NewProgressRenderer creates a renderer writing to out. total is the
+// expected size of the whole pull in bytes, or 0 if unknown.
+func NewProgressRenderer(out *os.File, total int64) *ProgressRenderer {
+	return &ProgressRenderer{
+		out:     out,
+		tty:     isTerminal(out),
+		now:     time.Now,
+		tracker: progressTracker{total: total},
+	}
+}
+
+// SetTotal sets the expected size of the whole pull in bytes
+func (r *ProgressRenderer) SetTotal(total int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tracker.total = total
+}
+
+// Handle is a modelregistry.ProgressHandler
+func (r *ProgressRenderer) Handle(downloaded, total int64, filename string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	s := r.tracker.update(now, downloaded, total, filename)
+	fileComplete := total > 0 && downloaded >= total
+
+	if !r.tty {
+		if fileComplete && filename != r.lastLogged {
+			r.lastLogged = filename
+			_, _ = fmt.Fprintln(r.out, formatProgressLog(s))
+		}
+		return
+	}
+
+	if !fileComplete && now.Sub(r.lastDraw) < progressRedrawInterval {
+		return
+	}
+	r.lastDraw = now
+
+	line := formatProgressLine(s)
+	// Pad to clear leftovers of a longer previous line
+	pad := max(r.lastWidth-len(line), 0)
+	r.lastWidth = len(line)
+	_, _ = fmt.Fprintf(r.out, "\r%s%s", line, strings.Repeat(" ", pad))
+	if fileComplete {
+		_, _ = fmt.Fprintln(r.out)
+		r.lastWidth = 0
+	}
+}
+
+func formatProgressLine(s progressSnapshot) string {
+	var b strings.Builder
+	if s.FilePercent >= 0 {
+		barWidth := 30
+		filled := int(float64(barWidth) * s.FilePercent / 100)
+		fmt.Fprintf(&b, "  %s: [%s%s] %.1f%% (%s/%s)", s.File,
+			strings.Repeat("=", filled), strings.Repeat("-", barWidth-filled),
+			s.FilePercent, FormatBytes(s.FileDone), FormatBytes(s.FileTotal))
+	} else {
+		fmt.Fprintf(&b, "  %s: %s", s.File, FormatBytes(s.FileDone))
+	}
+	b.WriteString(formatProgressSummary(s))
+	return b.String()
+}
+
+func formatProgressLog(s progressSnapshot) string {
+	return fmt.Sprintf("  %s: %s done%s", s.File, FormatBytes(s.FileDone), formatProgressSummary(s))
+}
+
+// formatProgressSummary formats overall progress, rate and ETA
+func formatProgressSummary(s progressSnapshot) string {
+	var parts []string
+	if s.Percent >= 0 {
+		parts = append(parts, fmt.Sprintf("total %.1f%%", s.Percent))
+	}
+	if s.Rate > 0 {
+		parts = append(parts, FormatBytes(int64(s.Rate))+"/s")
+	}
+	if s.ETA >= 0 && s.Percent < 100 {
+		parts = append(parts, "ETA "+formatETA(s.ETA))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " | " + strings.Join(parts, ", ")
+}
+
+// formatETA rounds an ETA to whole seconds, e.g. "1m5s"
+func formatETA(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
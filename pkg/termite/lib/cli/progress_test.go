@@ -0,0 +1,122 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker_RateAndETA(t *testing.T) {
+	start := time.Unix(0, 0)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	// 1000 bytes overall: a 200 byte file already on disk, then an 800 byte download
+	p := progressTracker{total: 1000}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		downloaded  int64
+		total       int64
+		filename    string
+		wantPercent float64
+		wantFile    float64
+		wantRate    float64
+		wantETA     time.Duration
+	}{
+		{
+			name: "skipped file", now: at(0), downloaded: 200, total: 200, filename: "tokenizer.json",
+			wantPercent: 20, wantFile: 100, wantRate: 0, wantETA: -1,
+		},
+		{
+			name: "download started", now: at(1), downloaded: 100, total: 800, filename: "model.onnx",
+			wantPercent: 30, wantFile: 12.5, wantRate: 100, wantETA: 7 * time.Second,
+		},
+		{
+			name: "download halfway", now: at(4), downloaded: 400, total: 800, filename: "model.onnx",
+			wantPercent: 60, wantFile: 50, wantRate: 100, wantETA: 4 * time.Second,
+		},
+		{
+			name: "download complete", now: at(5), downloaded: 800, total: 800, filename: "model.onnx",
+			wantPercent: 100, wantFile: 100, wantRate: 160, wantETA: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		s := p.update(tt.now, tt.downloaded, tt.total, tt.filename)
+		if s.Percent != tt.wantPercent {
+			t.Errorf("%s: Percent = %v, want %v", tt.name, s.Percent, tt.wantPercent)
+		}
+		if s.FilePercent != tt.wantFile {
+			t.Errorf("%s: FilePercent = %v, want %v", tt.name, s.FilePercent, tt.wantFile)
+		}
+		if s.Rate != tt.wantRate {
+			t.Errorf("%s: Rate = %v, want %v", tt.name, s.Rate, tt.wantRate)
+		}
+		if s.ETA != tt.wantETA {
+			t.Errorf("%s: ETA = %v, want %v", tt.name, s.ETA, tt.wantETA)
+		}
+	}
+}
+
+func TestProgressTracker_UnknownTotal(t *testing.T) {
+	start := time.Unix(0, 0)
+	p := progressTracker{}
+
+	p.update(start, 0, 0, "model.onnx")
+	s := p.update(start.Add(2*time.Second), 500, 0, "model.onnx")
+
+	if s.Percent != -1 || s.FilePercent != -1 {
+		t.Errorf("Percent = %v, FilePercent = %v, want -1 when sizes are unknown", s.Percent, s.FilePercent)
+	}
+	if s.Rate != 250 {
+		t.Errorf("Rate = %v, want 250", s.Rate)
+	}
+	if s.ETA != -1 {
+		t.Errorf("ETA = %v, want -1 when total is unknown", s.ETA)
+	}
+}
+
+func TestProgressRenderer_NonTTYLogsCompletedFiles(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Unix(0, 0)
+	r := &ProgressRenderer{
+		out:     &out,
+		now:     func() time.Time { return now },
+		tracker: progressTracker{total: 2048},
+	}
+
+	r.Handle(512, 1024, "a.onnx")
+	now = now.Add(time.Second)
+	r.Handle(1024, 1024, "a.onnx")
+	r.Handle(1024, 1024, "b.onnx")
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want one per completed file:\n%s", len(lines), out.String())
+	}
+	if strings.Contains(out.String(), "\r") {
+		t.Errorf("non-TTY output contains carriage returns: %q", out.String())
+	}
+	if want := "  a.onnx: 1.0 KB done | total 50.0%, 1.0 KB/s, ETA 1s"; lines[0] != want {
+		t.Errorf("line = %q, want %q", lines[0], want)
+	}
+	if !strings.HasPrefix(lines[1], "  b.onnx: 1.0 KB done | total 100.0%") {
+		t.Errorf("line = %q, want b.onnx completion", lines[1])
+	}
+}
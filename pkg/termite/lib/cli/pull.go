@@ -19,6 +19,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -35,6 +36,7 @@ type PullOptions struct {
 	RegistryURL string
 	ModelsDir   string
 	Variants    []string // Variant IDs to download (e.g., ["f16", "i8"])
+	StoreDir    string   // Content-addressed store shared between models (optional)
 }
 
 // HuggingFaceOptions contains options for pulling from HuggingFace
@@ -43,6 +45,20 @@ type HuggingFaceOptions struct {
 	ModelType string
 	HFToken   string
 	Variant   string
+	StoreDir  string // Content-addressed store shared between models (optional)
+	Validate  bool   // Check downloaded ONNX files load and have the expected inputs/outputs
+	DryRun    bool   // Resolve and print the files to download without downloading them
+}
+
+// SourceOptions contains options for pulling a model staged on the local
+// filesystem or in object storage
+type SourceOptions struct {
+	ModelsDir string
+	ModelType string
+	Variant   string
+	StoreDir  string // Content-addressed store shared between models (optional)
+	Validate  bool   // Check installed ONNX files load and have the expected inputs/outputs
+	DryRun    bool   // Resolve and print the files to install without installing them
 }
 
 // ListOptions contains options for listing models
@@ -51,6 +67,7 @@ type ListOptions struct {
 	ModelsDir   string
 	TypeFilter  string
 	BinaryName  string // Used for help messages (e.g., "termite" or "antfly termite")
+	Output      string // Output format: OutputText (default) or OutputJSON
 }
 
 // knownVariants are the recognized model variant suffixes
@@ -86,10 +103,15 @@ func PullFromRegistry(modelRef string, opts PullOptions) error {
 		}
 	}
 
-	client := modelregistry.NewClient(
+	progress := NewProgressRenderer(os.Stdout, 0)
+	clientOpts := []modelregistry.ClientOption{
 		modelregistry.WithBaseURL(opts.RegistryURL),
-		modelregistry.WithProgressHandler(PrintProgress),
-	)
+		modelregistry.WithProgressHandler(progress.Handle),
+	}
+	if opts.StoreDir != "" {
+		clientOpts = append(clientOpts, modelregistry.WithStoreDir(opts.StoreDir))
+	}
+	client := modelregistry.NewClient(clientOpts...)
 
 	fmt.Printf("Fetching manifest for %s...\n", modelName)
 	manifest, err := client.FetchManifest(ctx, modelName)
@@ -103,10 +125,11 @@ func PullFromRegistry(modelRef string, opts PullOptions) error {
 		fmt.Printf("Description: %s\n", manifest.Description)
 	}
 
-	// Default to f32 if no variants specified (matches PullModel behavior)
+	// Default to the model type's preferred variant if none are specified
+	// (matches PullModel behavior)
 	effectiveVariants := variants
 	if len(effectiveVariants) == 0 {
-		effectiveVariants = []string{modelregistry.VariantF32}
+		effectiveVariants = []string{manifest.DefaultVariant()}
 	}
 
 	// Calculate size of what will actually be downloaded
@@ -142,6 +165,7 @@ func PullFromRegistry(modelRef string, opts PullOptions) error {
 	fmt.Printf("Variants: %v\n", effectiveVariants)
 	fmt.Printf("Total size: %s\n", FormatBytes(totalSize))
 	fmt.Println()
+	progress.SetTotal(totalSize)
 
 	fmt.Println("Downloading files...")
 	if err := client.PullModel(ctx, manifest, opts.ModelsDir, variants); err != nil {
@@ -168,7 +192,7 @@ func PullFromHuggingFace(repoID string, opts HuggingFaceOptions) error {
 	}
 
 	if opts.Variant != "" && !modelregistry.IsValidVariant(opts.Variant) {
-		return fmt.Errorf("invalid variant %q, valid options: fp16, q4, q4f16, quantized", opts.Variant)
+		return fmt.Errorf("invalid variant %q, valid options: fp32, fp16, q4, q4f16, quantized", opts.Variant)
 	}
 
 	hfToken := opts.HFToken
@@ -176,19 +200,35 @@ func PullFromHuggingFace(repoID string, opts HuggingFaceOptions) error {
 		hfToken = os.Getenv("HF_TOKEN")
 	}
 
-	client := modelregistry.NewHuggingFaceClient(
+	progress := NewProgressRenderer(os.Stdout, 0)
+	clientOpts := []modelregistry.HFClientOption{
 		modelregistry.WithHFToken(hfToken),
-		modelregistry.WithHFProgressHandler(PrintProgress),
-	)
+		modelregistry.WithHFProgressHandler(progress.Handle),
+	}
+	if opts.StoreDir != "" {
+		clientOpts = append(clientOpts, modelregistry.WithHFStoreDir(opts.StoreDir))
+	}
+	if opts.Validate {
+		clientOpts = append(clientOpts, modelregistry.WithHFValidation())
+	}
+	client := modelregistry.NewHuggingFaceClient(clientOpts...)
 
 	fmt.Printf("Pulling from HuggingFace: %s\n", repoID)
 	fmt.Printf("Type: %s\n", modelType)
-	if opts.Variant != "" {
-		fmt.Printf("Variant: %s (%s)\n", opts.Variant, modelregistry.VariantDescription(opts.Variant))
-	} else {
-		fmt.Printf("Variant: %s\n", modelregistry.VariantDescription(""))
-	}
+	fmt.Printf("Variant: %s\n", describeVariant(opts.Variant, modelType))
 	fmt.Println()
+
+	plan, err := client.PlanHuggingFacePull(ctx, repoID, modelType, opts.ModelsDir, opts.Variant)
+	if opts.DryRun {
+		if err != nil {
+			return fmt.Errorf("failed to resolve model: %w", err)
+		}
+		return printPullPlan(os.Stdout, plan)
+	}
+	if err == nil {
+		setPlannedTotal(progress, plan)
+	}
+
 	fmt.Println("Downloading files...")
 
 	if err := client.PullFromHuggingFace(ctx, repoID, modelType, opts.ModelsDir, opts.Variant); err != nil {
@@ -200,27 +240,158 @@ func PullFromHuggingFace(repoID string, opts HuggingFaceOptions) error {
 	return nil
 }
 
+// PullFromLocal installs a model staged in a local directory (e.g., a mounted
+// volume in an air-gapped environment), selecting files like a HuggingFace pull
+func PullFromLocal(path string, opts SourceOptions) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	fmt.Printf("Pulling from local directory: %s\n", path)
+	return pullFromSource(ctx, modelregistry.NewLocalSource(path), opts, NewProgressRenderer(os.Stdout, 0))
+}
+
+// PullFromObjectStore downloads a model staged under an s3://bucket/prefix or
+// gs://bucket/prefix reference, selecting files like a HuggingFace pull.
+// Credentials come from the environment (see modelregistry.OpenObjectStoreSource).
+func PullFromObjectStore(ref string, opts SourceOptions) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	progress := NewProgressRenderer(os.Stdout, 0)
+	src, err := modelregistry.OpenObjectStoreSource(ctx, ref,
+		modelregistry.WithObjectStoreProgressHandler(progress.Handle))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	fmt.Printf("Pulling from object storage: %s\n", ref)
+	return pullFromSource(ctx, src, opts, progress)
+}
+
+// pullFromSource installs a model from src, reporting to progress (shared with
+// the source when it downloads)
+func pullFromSource(ctx context.Context, src modelregistry.Source, opts SourceOptions, progress *ProgressRenderer) error {
+	if opts.ModelType == "" {
+		return fmt.Errorf("--type flag is required for local and object storage pulls (embedder, chunker, reranker)")
+	}
+
+	modelType, err := modelregistry.ParseModelType(opts.ModelType)
+	if err != nil {
+		return err
+	}
+
+	if opts.Variant != "" && !modelregistry.IsValidVariant(opts.Variant) {
+		return fmt.Errorf("invalid variant %q, valid options: fp32, fp16, q4, q4f16, quantized", opts.Variant)
+	}
+
+	pullOpts := modelregistry.SourcePullOptions{
+		Validate:        opts.Validate,
+		ProgressHandler: progress.Handle,
+	}
+	if opts.StoreDir != "" {
+		pullOpts.Store = modelregistry.NewStore(opts.StoreDir)
+	}
+
+	fmt.Printf("Type: %s\n", modelType)
+	fmt.Printf("Variant: %s\n", describeVariant(opts.Variant, modelType))
+	fmt.Println()
+
+	plan, err := modelregistry.PlanPull(ctx, src, modelType, opts.ModelsDir, opts.Variant)
+	if opts.DryRun {
+		if err != nil {
+			return fmt.Errorf("failed to resolve model: %w", err)
+		}
+		return printPullPlan(os.Stdout, plan)
+	}
+	if err == nil {
+		setPlannedTotal(progress, plan)
+	}
+
+	fmt.Println("Fetching files...")
+
+	if err := modelregistry.PullFromSource(ctx, src, modelType, opts.ModelsDir, opts.Variant, pullOpts); err != nil {
+		return fmt.Errorf("failed to pull model: %w", err)
+	}
+
+	destDir := filepath.Join(opts.ModelsDir, modelType.DirName(), src.Name())
+	fmt.Printf("\n✓ Model pulled successfully to %s\n", destDir)
+	return nil
+}
+
+// setPlannedTotal gives progress the size of the whole pull when the plan
+// knows every file's size; a partial sum would make the ETA misleading.
+// Planning errors are left for the pull itself to report.
+func setPlannedTotal(progress *ProgressRenderer, plan *modelregistry.PullPlan) {
+	for _, f := range plan.Files {
+		if f.Size < 0 {
+			return
+		}
+	}
+	progress.SetTotal(plan.TotalSize)
+}
+
+// describeVariant describes the ONNX variant a pull installs, which for an
+// empty variant is the model type's default when the model has it
+func describeVariant(variant string, modelType modelregistry.ModelType) string {
+	if variant != "" {
+		return fmt.Sprintf("%s (%s)", variant, modelregistry.VariantDescription(variant))
+	}
+	if d := modelType.DefaultONNXVariant(); d != "" {
+		return fmt.Sprintf("%s (%s) if available, otherwise %s", d, modelregistry.VariantDescription(d), modelregistry.VariantDescription(""))
+	}
+	return modelregistry.VariantDescription("") + " (default)"
+}
+
+// printPullPlan prints the files a dry-run pull would install
+func printPullPlan(out io.Writer, plan *modelregistry.PullPlan) error {
+	_, _ = fmt.Fprintln(out, "Dry run: nothing will be downloaded")
+	_, _ = fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FILE\tSIZE")
+	for _, f := range plan.Files {
+		size := "unknown"
+		if f.Size >= 0 {
+			size = FormatBytes(f.Size)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", f.Path, size)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(out, "\nTotal size: %s\n", FormatBytes(plan.TotalSize))
+	_, _ = fmt.Fprintf(out, "Target directory: %s\n", plan.ModelDir)
+	return nil
+}
+
 // ListRemoteModels lists models available in the remote registry
 func ListRemoteModels(opts ListOptions) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	return listRemoteModels(ctx, os.Stdout, opts)
+}
+
+func listRemoteModels(ctx context.Context, out io.Writer, opts ListOptions) error {
+	if err := validateOutput(opts.Output); err != nil {
+		return err
+	}
+
 	client := modelregistry.NewClient(
 		modelregistry.WithBaseURL(opts.RegistryURL),
 	)
 
-	fmt.Printf("Fetching model list from %s...\n\n", opts.RegistryURL)
+	if opts.Output != OutputJSON {
+		_, _ = fmt.Fprintf(out, "Fetching model list from %s...\n\n", opts.RegistryURL)
+	}
 
 	index, err := client.FetchIndex(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch registry index: %w", err)
 	}
 
-	if len(index.Models) == 0 {
-		fmt.Println("No models available in registry")
-		return nil
-	}
-
 	var filteredType modelregistry.ModelType
 	if opts.TypeFilter != "" {
 		filteredType, err = modelregistry.ParseModelType(opts.TypeFilter)
@@ -229,19 +400,37 @@ func ListRemoteModels(opts ListOptions) error {
 		}
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tTYPE\tSIZE\tVARIANTS\tDESCRIPTION")
-
+	models := []ModelListing{}
 	for _, model := range index.Models {
 		if filteredType != "" && model.Type != filteredType {
 			continue
 		}
-
-		variantsStr := ""
-		if len(model.Variants) > 0 {
-			variantsStr = strings.Join(model.Variants, ",")
+		variants := model.Variants
+		if variants == nil {
+			variants = []string{}
 		}
+		models = append(models, ModelListing{
+			Name:        model.Name,
+			Type:        model.Type,
+			Size:        model.Size,
+			Variants:    variants,
+			Description: model.Description,
+		})
+	}
 
+	if opts.Output == OutputJSON {
+		return writeJSON(out, ModelList{Models: models})
+	}
+
+	if len(index.Models) == 0 {
+		_, _ = fmt.Fprintln(out, "No models available in registry")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tTYPE\tSIZE\tVARIANTS\tDESCRIPTION")
+
+	for _, model := range models {
 		desc := model.Description
 		if len(desc) > 50 {
 			desc = desc[:47] + "..."
@@ -251,7 +440,7 @@ func ListRemoteModels(opts ListOptions) error {
 			model.Name,
 			model.Type,
 			FormatBytes(model.Size),
-			variantsStr,
+			strings.Join(model.Variants, ","),
 			desc,
 		)
 	}
@@ -260,7 +449,13 @@ func ListRemoteModels(opts ListOptions) error {
 
 // ListLocalModels lists locally installed models
 func ListLocalModels(opts ListOptions) error {
-	fmt.Printf("Local models in %s:\n\n", opts.ModelsDir)
+	return listLocalModels(os.Stdout, opts)
+}
+
+func listLocalModels(out io.Writer, opts ListOptions) error {
+	if err := validateOutput(opts.Output); err != nil {
+		return err
+	}
 
 	modelTypes := []modelregistry.ModelType{
 		modelregistry.ModelTypeEmbedder,
@@ -277,10 +472,7 @@ func ListLocalModels(opts ListOptions) error {
 		}
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tTYPE\tSIZE\tVARIANTS")
-
-	totalModels := 0
+	models := []ModelListing{}
 
 	for _, modelType := range modelTypes {
 		if filteredType != "" && modelType != filteredType {
@@ -303,7 +495,7 @@ func ListLocalModels(opts ListOptions) error {
 
 			hasStandard := false
 			var totalSize int64
-			var variants []string
+			variants := []string{}
 
 			if info, err := os.Stat(standardPath); err == nil {
 				hasStandard = true
@@ -318,6 +510,7 @@ func ListLocalModels(opts ListOptions) error {
 					totalSize += info.Size()
 				}
 			}
+			slices.Sort(variants)
 
 			if !hasStandard && len(variants) == 0 {
 				continue
@@ -339,32 +532,43 @@ func ListLocalModels(opts ListOptions) error {
 				}
 			}
 
-			variantsStr := ""
-			if len(variants) > 0 {
-				variantsStr = strings.Join(variants, ",")
-			}
-
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-				entry.Name(),
-				modelType,
-				FormatBytes(totalSize),
-				variantsStr,
-			)
-			totalModels++
+			models = append(models, ModelListing{
+				Name:     entry.Name(),
+				Type:     modelType,
+				Size:     totalSize,
+				Variants: variants,
+			})
 		}
 	}
+
+	if opts.Output == OutputJSON {
+		return writeJSON(out, ModelList{Models: models})
+	}
+
+	_, _ = fmt.Fprintf(out, "Local models in %s:\n\n", opts.ModelsDir)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tTYPE\tSIZE\tVARIANTS")
+	for _, model := range models {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			model.Name,
+			model.Type,
+			FormatBytes(model.Size),
+			strings.Join(model.Variants, ","),
+		)
+	}
 	if err := w.Flush(); err != nil {
 		return err
 	}
 
-	if totalModels == 0 {
+	if len(models) == 0 {
 		binaryName := opts.BinaryName
 		if binaryName == "" {
 			binaryName = "termite"
 		}
-		fmt.Println("No models found locally.")
-		fmt.Printf("\nUse '%s pull <model-name>' to download models.\n", binaryName)
-		fmt.Printf("Use '%s list --remote' to see available models.\n", binaryName)
+		_, _ = fmt.Fprintln(out, "No models found locally.")
+		_, _ = fmt.Fprintf(out, "\nUse '%s pull <model-name>' to download models.\n", binaryName)
+		_, _ = fmt.Fprintf(out, "Use '%s list --remote' to see available models.\n", binaryName)
 	}
 
 	return nil
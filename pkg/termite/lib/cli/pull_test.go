@@ -0,0 +1,205 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+)
+
+func TestListLocalModels_JSON(t *testing.T) {
+	modelsDir := t.TempDir()
+	modelDir := filepath.Join(modelsDir, "embedders", "bge-small-en-v1.5")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"model.onnx", "model_i8.onnx", "tokenizer.json"} {
+		if err := os.WriteFile(filepath.Join(modelDir, name), []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := listLocalModels(&out, ListOptions{ModelsDir: modelsDir, Output: OutputJSON}); err != nil {
+		t.Fatalf("listLocalModels() error = %v", err)
+	}
+
+	var got ModelList
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if len(got.Models) != 1 {
+		t.Fatalf("len(Models) = %d, want 1", len(got.Models))
+	}
+
+	model := got.Models[0]
+	if model.Name != "bge-small-en-v1.5" {
+		t.Errorf("Name = %q, want bge-small-en-v1.5", model.Name)
+	}
+	if model.Type != modelregistry.ModelTypeEmbedder {
+		t.Errorf("Type = %q, want %q", model.Type, modelregistry.ModelTypeEmbedder)
+	}
+	if model.Size == 0 {
+		t.Error("Size = 0, want total size of model files")
+	}
+	if want := []string{"f32", "i8"}; !reflect.DeepEqual(model.Variants, want) {
+		t.Errorf("Variants = %v, want %v", model.Variants, want)
+	}
+}
+
+func TestListLocalModels_JSONEmpty(t *testing.T) {
+	var out bytes.Buffer
+	if err := listLocalModels(&out, ListOptions{ModelsDir: t.TempDir(), Output: OutputJSON}); err != nil {
+		t.Fatalf("listLocalModels() error = %v", err)
+	}
+
+	if got := out.String(); got != "{\n  \"models\": []\n}\n" {
+		t.Errorf("output = %q, want empty models array", got)
+	}
+}
+
+func TestListRemoteModels_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/index.json" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"schemaVersion": 1,
+				"models": [
+					{"name": "bge-small", "type": "embedder", "size": 1000, "variants": ["f16", "i8"], "description": "Small embedder"},
+					{"name": "mxbai-rerank", "type": "reranker", "size": 2000}
+				]
+			}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	err := listRemoteModels(context.Background(), &out, ListOptions{
+		RegistryURL: server.URL + "/v1",
+		TypeFilter:  "embedder",
+		Output:      OutputJSON,
+	})
+	if err != nil {
+		t.Fatalf("listRemoteModels() error = %v", err)
+	}
+
+	var got ModelList
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+
+	want := ModelList{Models: []ModelListing{{
+		Name:        "bge-small",
+		Type:        modelregistry.ModelTypeEmbedder,
+		Size:        1000,
+		Variants:    []string{"f16", "i8"},
+		Description: "Small embedder",
+	}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("models = %+v, want %+v", got, want)
+	}
+}
+
+func TestListLocalModels_InvalidOutput(t *testing.T) {
+	var out bytes.Buffer
+	if err := listLocalModels(&out, ListOptions{ModelsDir: t.TempDir(), Output: "yaml"}); err == nil {
+		t.Error("listLocalModels() error = nil, want invalid output format error")
+	}
+}
+
+func TestPullFromSource_DryRun(t *testing.T) {
+	staged := filepath.Join(t.TempDir(), "bge-small-en-v1.5")
+	if err := os.MkdirAll(filepath.Join(staged, "onnx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"tokenizer.json": "tokenizer", "onnx/model.onnx": "graph"} {
+		if err := os.WriteFile(filepath.Join(staged, filepath.FromSlash(name)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modelsDir := filepath.Join(t.TempDir(), "models")
+
+	err := pullFromSource(context.Background(), modelregistry.NewLocalSource(staged), SourceOptions{
+		ModelsDir: modelsDir,
+		ModelType: "embedder",
+		DryRun:    true,
+	}, NewProgressRenderer(os.Stdout, 0))
+	if err != nil {
+		t.Fatalf("pullFromSource() error = %v", err)
+	}
+	if _, err := os.Stat(modelsDir); !os.IsNotExist(err) {
+		t.Errorf("models directory was created by a dry run: %v", err)
+	}
+}
+
+func TestPrintPullPlan(t *testing.T) {
+	var out bytes.Buffer
+	err := printPullPlan(&out, &modelregistry.PullPlan{
+		ModelDir: "models/embedders/bge-small-en-v1.5",
+		Files: []modelregistry.PlannedFile{
+			{Path: "tokenizer.json", Size: 2048},
+			{Path: "onnx/model.onnx", Size: -1},
+		},
+		TotalSize: 2048,
+	})
+	if err != nil {
+		t.Fatalf("printPullPlan() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"tokenizer.json   2.0 KB",
+		"onnx/model.onnx  unknown",
+		"Total size: 2.0 KB",
+		"Target directory: models/embedders/bge-small-en-v1.5",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSetPlannedTotal(t *testing.T) {
+	known := &modelregistry.PullPlan{
+		Files:     []modelregistry.PlannedFile{{Path: "model.onnx", Size: 1000}, {Path: "tokenizer.json", Size: 24}},
+		TotalSize: 1024,
+	}
+	r := &ProgressRenderer{}
+	setPlannedTotal(r, known)
+	if r.tracker.total != 1024 {
+		t.Errorf("total = %d, want 1024", r.tracker.total)
+	}
+
+	partial := &modelregistry.PullPlan{
+		Files:     []modelregistry.PlannedFile{{Path: "model.onnx", Size: -1}, {Path: "tokenizer.json", Size: 24}},
+		TotalSize: 24,
+	}
+	r = &ProgressRenderer{}
+	setPlannedTotal(r, partial)
+	if r.tracker.total != 0 {
+		t.Errorf("total = %d, want 0 (unknown) when a file size is unknown", r.tracker.total)
+	}
+}
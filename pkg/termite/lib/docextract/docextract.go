@@ -0,0 +1,119 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docextract extracts the plain text of documents so that they can be
+// chunked and embedded.
+//
+// Plain text is always supported. Parsers for other formats (PDF, DOCX) are
+// only compiled into binaries built with the docextract tag, keeping them out
+// of the default binary:
+//
+//	go build -tags docextract ./...
+package docextract
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnsupported is returned when no extractor is registered for a document's
+// MIME type
+var ErrUnsupported = errors.New("unsupported document type")
+
+// maxInflatedSize bounds the decompressed size of a document (the text part
+// of a DOCX file, or all the streams of a PDF), so that a small compressed
+// document cannot exhaust memory
+const maxInflatedSize = 64 << 20
+
+// Extractor returns the plain text of a document
+type Extractor func(data []byte) (string, error)
+
+type format struct {
+	extract Extractor
+	// sniff reports whether data is a document of this format, for documents
+	// served with a generic MIME type. Nil if the format cannot be detected.
+	sniff func(data []byte) bool
+}
+
+var formats = map[string]format{
+	"text/plain": {extract: extractPlainText},
+}
+
+// register adds an extractor for a MIME type. It is called from the init
+// functions of optional formats.
+func register(mimeType string, extract Extractor, sniff func(data []byte) bool) {
+	formats[mimeType] = format{extract: extract, sniff: sniff}
+}
+
+// MIMETypes returns the MIME types this binary can extract, sorted
+func MIMETypes() []string {
+	types := make([]string, 0, len(formats))
+	for t := range formats {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Supported reports whether documents of the given MIME type can be extracted
+func Supported(mimeType string) bool {
+	_, ok := formats[mediaType(mimeType)]
+	return ok
+}
+
+// Detect returns the MIME type to extract data as. A supported mimeType is
+// kept; otherwise (e.g. "application/octet-stream" or "application/zip" for a
+// DOCX file) the content is matched against the registered formats, falling
+// back to mimeType without parameters.
+func Detect(mimeType string, data []byte) string {
+	mt := mediaType(mimeType)
+	if _, ok := formats[mt]; ok {
+		return mt
+	}
+	for _, t := range MIMETypes() {
+		if sniff := formats[t].sniff; sniff != nil && sniff(data) {
+			return t
+		}
+	}
+	return mt
+}
+
+// Extract returns the plain text of a document of the given MIME type. MIME
+// type parameters such as charset are ignored.
+func Extract(mimeType string, data []byte) (string, error) {
+	f, ok := formats[mediaType(mimeType)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s (supported: %s)", ErrUnsupported, mimeType, strings.Join(MIMETypes(), ", "))
+	}
+	return f.extract(data)
+}
+
+// mediaType strips parameters from a MIME type
+func mediaType(mimeType string) string {
+	if mt, _, err := mime.ParseMediaType(mimeType); err == nil {
+		return mt
+	}
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+func extractPlainText(data []byte) (string, error) {
+	if !utf8.Valid(data) {
+		return "", errors.New("text is not valid UTF-8")
+	}
+	return string(data), nil
+}
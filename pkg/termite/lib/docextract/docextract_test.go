@@ -0,0 +1,44 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docextract
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract_PlainText(t *testing.T) {
+	text, err := Extract("text/plain; charset=utf-8", []byte("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", text)
+
+	_, err = Extract("text/plain", []byte{0xff, 0xfe})
+	assert.Error(t, err)
+}
+
+func TestExtract_Unsupported(t *testing.T) {
+	_, err := Extract("image/png", []byte{0x89, 'P', 'N', 'G'})
+	require.ErrorIs(t, err, ErrUnsupported)
+	assert.Contains(t, err.Error(), "text/plain")
+	assert.False(t, Supported("image/png"))
+}
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, "text/plain", Detect("text/plain; charset=utf-8", []byte("hello")))
+	assert.Equal(t, "application/octet-stream", Detect("application/octet-stream", []byte{0, 1, 2}))
+	assert.Contains(t, MIMETypes(), "text/plain")
+}
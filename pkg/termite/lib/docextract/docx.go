@@ -0,0 +1,115 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build docextract
+
+package docextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const mimeTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// wordNamespace is the XML namespace of WordprocessingML elements
+const wordNamespace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+
+// docxDocumentPart is the archive member holding the main document text
+const docxDocumentPart = "word/document.xml"
+
+func init() {
+	register(mimeTypeDOCX, extractDOCX, isDOCX)
+}
+
+// isDOCX reports whether data is a ZIP archive with a Word document part,
+// since DOCX files are usually served as application/zip
+func isDOCX(data []byte) bool {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return false
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	_, err = zr.Open(docxDocumentPart)
+	return err == nil
+}
+
+// extractDOCX returns the text of the main document part of a DOCX file,
+// with paragraphs separated by blank lines
+func extractDOCX(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("reading DOCX archive: %w", err)
+	}
+	part, err := zr.Open(docxDocumentPart)
+	if err != nil {
+		return "", errors.New("DOCX archive has no " + docxDocumentPart)
+	}
+	defer func() { _ = part.Close() }()
+
+	var (
+		paragraphs []string
+		paragraph  strings.Builder
+		inText     bool
+	)
+	dec := xml.NewDecoder(io.LimitReader(part, maxInflatedSize))
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("parsing %s: %w", docxDocumentPart, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space != wordNamespace {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				paragraph.WriteByte('\t')
+			case "br", "cr":
+				paragraph.WriteByte('\n')
+			}
+		case xml.EndElement:
+			if t.Name.Space != wordNamespace {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if s := strings.TrimSpace(paragraph.String()); s != "" {
+					paragraphs = append(paragraphs, s)
+				}
+				paragraph.Reset()
+			}
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+		}
+	}
+	return strings.Join(paragraphs, "\n\n"), nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build docextract
+
+package docextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildDOCX returns a minimal DOCX archive with the given document part
+func buildDOCX(t *testing.T, documentXML string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(docxDocumentPart)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(documentXML))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestExtractDOCX(t *testing.T) {
+	data := buildDOCX(t, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:r><w:t>Quarterly</w:t></w:r><w:r><w:t xml:space="preserve"> report</w:t></w:r></w:p>
+    <w:p><w:r><w:t>Revenue</w:t><w:tab/><w:t>grew</w:t><w:br/><w:t>again</w:t></w:r></w:p>
+    <w:p/>
+    <w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Outlook &amp; risks</w:t></w:r></w:p>
+  </w:body>
+</w:document>`)
+
+	assert.Equal(t, mimeTypeDOCX, Detect("application/zip", data))
+	text, err := Extract(mimeTypeDOCX, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Quarterly report\n\nRevenue\tgrew\nagain\n\nOutlook & risks", text)
+}
+
+func TestExtractDOCX_NotWord(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	_, err := zw.Create("readme.txt")
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	assert.False(t, isDOCX(buf.Bytes()))
+	_, err = extractDOCX(buf.Bytes())
+	assert.Error(t, err)
+}
@@ -0,0 +1,597 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build docextract
+
+package docextract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+const mimeTypePDF = "application/pdf"
+
+// maxFormDepth bounds the nesting of form XObjects drawn from a page, which
+// may refer to each other in a cycle
+const maxFormDepth = 8
+
+func init() {
+	register(mimeTypePDF, extractPDF, isPDF)
+}
+
+var pdfMagic = []byte("%PDF-")
+
+func isPDF(data []byte) bool {
+	return bytes.HasPrefix(data, pdfMagic)
+}
+
+// extractPDF returns the text of the pages of a PDF, separated by blank
+// lines. Document structure, stream filters, font encodings and documents
+// encrypted with an empty user password are handled by the pdf package; the
+// content streams themselves are tokenized here, since its lexer does not
+// skip the binary data of inline images. Text shown in a composite font
+// without a Unicode mapping cannot be recovered and is an error, and the
+// decompressed streams of the whole document are bounded by maxInflatedSize.
+func extractPDF(data []byte) (text string, err error) {
+	if !isPDF(data) {
+		return "", errors.New("not a PDF document")
+	}
+	// The pdf package reports malformed documents by panicking
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("malformed PDF: %v", r)
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("reading PDF: %w", err)
+	}
+	doc := pdfText{remaining: maxInflatedSize}
+	var pages []string
+	for i := 1; i <= r.NumPage(); i++ {
+		page := r.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		text, err := doc.content(page.V.Key("Contents"), page.Resources(), 0)
+		if err != nil {
+			return "", fmt.Errorf("page %d: %w", i, err)
+		}
+		if text != "" {
+			pages = append(pages, text)
+		}
+	}
+	return strings.Join(pages, "\n\n"), nil
+}
+
+// pdfText extracts the text of the pages of a document, charging every
+// stream it decompresses against a budget shared by the whole document
+type pdfText struct {
+	remaining int64
+}
+
+// content returns the text shown by a content stream, or an array of them,
+// drawn with the given resources
+func (d *pdfText) content(strm, resources pdf.Value, depth int) (string, error) {
+	data, err := d.read(strm)
+	if err != nil {
+		return "", err
+	}
+	encoders := make(map[string]pdf.TextEncoding)
+	return contentText(data, contentResources{
+		font: func(name string) (pdf.TextEncoding, error) {
+			if enc, ok := encoders[name]; ok {
+				return enc, nil
+			}
+			enc, err := d.encoder(resources.Key("Font").Key(name))
+			if err != nil {
+				return nil, fmt.Errorf("font %s: %w", name, err)
+			}
+			encoders[name] = enc
+			return enc, nil
+		},
+		form: func(name string) (string, error) {
+			form := resources.Key("XObject").Key(name)
+			if form.Key("Subtype").Name() != "Form" || depth >= maxFormDepth {
+				return "", nil
+			}
+			formResources := form.Key("Resources")
+			if formResources.IsNull() {
+				formResources = resources
+			}
+			return d.content(form, formResources, depth+1)
+		},
+	})
+}
+
+// read returns the decoded data of a stream, or the concatenated data of an
+// array of streams
+func (d *pdfText) read(strm pdf.Value) ([]byte, error) {
+	if strm.Kind() == pdf.Array {
+		var data []byte
+		for i := range strm.Len() {
+			part, err := d.readStream(strm.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			data = append(append(data, part...), '\n')
+		}
+		return data, nil
+	}
+	return d.readStream(strm)
+}
+
+func (d *pdfText) readStream(strm pdf.Value) ([]byte, error) {
+	if strm.Kind() != pdf.Stream {
+		return nil, nil
+	}
+	r := strm.Reader()
+	defer func() { _ = r.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(r, d.remaining+1))
+	if int64(len(data)) > d.remaining {
+		return nil, fmt.Errorf("decompressed streams exceed %d bytes", maxInflatedSize)
+	}
+	d.remaining -= int64(len(data))
+	// Streams truncated by sloppy writers still hold usable text
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return data, nil
+}
+
+// encoder returns the encoding that maps the codes shown by a font to text,
+// or nil for simple fonts decoded as WinAnsi text
+func (d *pdfText) encoder(font pdf.Value) (pdf.TextEncoding, error) {
+	if font.Kind() != pdf.Dict {
+		return nil, nil
+	}
+	// The pdf package parses the ToUnicode CMap itself; reading it here first
+	// charges it against the budget
+	toUnicode := font.Key("ToUnicode")
+	if _, err := d.readStream(toUnicode); err != nil {
+		return nil, err
+	}
+
+	encoding := font.Key("Encoding")
+	if font.Key("Subtype").Name() == "Type0" {
+		// The codes of a composite font are glyph IDs, which only a ToUnicode
+		// CMap or a Unicode CMap can map to text
+		switch {
+		case encoding.Name() == "UniGB-UCS2-H":
+		case (encoding.Name() == "Identity-H" || encoding.IsNull()) && toUnicode.Kind() == pdf.Stream:
+		default:
+			return nil, fmt.Errorf("composite font %s has no Unicode mapping", font.Key("BaseFont").Name())
+		}
+		return (&pdf.Font{V: font}).Encoder(), nil
+	}
+	switch encoding.Kind() {
+	case pdf.Dict, pdf.Null:
+		return (&pdf.Font{V: font}).Encoder(), nil
+	case pdf.Name:
+		if name := encoding.Name(); name == "WinAnsiEncoding" || name == "MacRomanEncoding" {
+			return (&pdf.Font{V: font}).Encoder(), nil
+		}
+	}
+	return nil, nil
+}
+
+// contentResources looks up the resources named by a content stream
+type contentResources struct {
+	// font returns the encoding of a font, or nil to decode its strings as
+	// WinAnsi text
+	font func(name string) (pdf.TextEncoding, error)
+	// form returns the text drawn by a form XObject
+	form func(name string) (string, error)
+}
+
+// wordSpacing is the TJ adjustment, in thousandths of a text space unit,
+// beyond which a gap between strings is taken as a word break
+const wordSpacing = -200
+
+// contentText returns the text shown by the operators of a content stream,
+// decoding strings with the current font and drawing form XObjects from res.
+// Text objects and moves to a new line start new lines; gaps in TJ arrays
+// wider than wordSpacing, which writers use in place of spaces, become spaces.
+func contentText(content []byte, res contentResources) (string, error) {
+	var (
+		text     strings.Builder
+		lex      = contentLexer{data: content}
+		operands []token
+		array    []token
+		inArray  bool
+		lastY    float64
+		haveY    bool
+		enc      pdf.TextEncoding
+	)
+	show := func(raw string) {
+		if enc == nil {
+			text.WriteString(decodeText([]byte(raw)))
+			return
+		}
+		text.WriteString(cleanText(enc.Decode(raw)))
+	}
+	newline := func() {
+		if text.Len() > 0 && !strings.HasSuffix(text.String(), "\n") {
+			text.WriteByte('\n')
+		}
+	}
+	space := func() {
+		s := text.String()
+		if s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+			text.WriteByte(' ')
+		}
+	}
+	lastString := func() (string, bool) {
+		if n := len(operands); n > 0 && operands[n-1].kind == tokenString {
+			return operands[n-1].value, true
+		}
+		return "", false
+	}
+	number := func(i int) float64 {
+		if i < len(operands) && operands[i].kind == tokenNumber {
+			return operands[i].number
+		}
+		return 0
+	}
+
+	for {
+		tok := lex.next()
+		if tok.kind == tokenEOF {
+			break
+		}
+		if inArray {
+			if tok.kind == tokenArrayEnd {
+				inArray = false
+				operands = append(operands, token{kind: tokenArray, elems: array})
+			} else {
+				array = append(array, tok)
+			}
+			continue
+		}
+
+		if tok.kind == tokenArrayStart {
+			inArray = true
+			array = nil
+			continue
+		}
+		if tok.kind != tokenOperator {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch tok.value {
+		case "ET", "T*":
+			newline()
+		case "Tf":
+			if len(operands) > 0 && operands[0].kind == tokenName && res.font != nil {
+				var err error
+				if enc, err = res.font(operands[0].value); err != nil {
+					return "", err
+				}
+			}
+		case "Do":
+			if n := len(operands); n > 0 && operands[n-1].kind == tokenName && res.form != nil {
+				form, err := res.form(operands[n-1].value)
+				if err != nil {
+					return "", err
+				}
+				if form != "" {
+					newline()
+					text.WriteString(form)
+					newline()
+				}
+			}
+		case "Tj":
+			if s, ok := lastString(); ok {
+				show(s)
+			}
+		case "'", `"`:
+			newline()
+			if s, ok := lastString(); ok {
+				show(s)
+			}
+		case "TJ":
+			if n := len(operands); n > 0 && operands[n-1].kind == tokenArray {
+				for _, elem := range operands[n-1].elems {
+					switch {
+					case elem.kind == tokenString:
+						show(elem.value)
+					case elem.kind == tokenNumber && elem.number < wordSpacing:
+						space()
+					}
+				}
+			}
+		case "Td", "TD":
+			if number(1) != 0 {
+				newline()
+			} else if number(0) > 0 {
+				space()
+			}
+		case "Tm":
+			if y := number(5); haveY && y != lastY {
+				newline()
+			} else if haveY {
+				space()
+			}
+			lastY, haveY = number(5), true
+		case "ID":
+			lex.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+
+	var lines []string
+	for line := range strings.SplitSeq(text.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenArrayStart
+	tokenArrayEnd
+	tokenArray
+	tokenOperator
+	tokenName
+	tokenOther // Booleans and dictionary delimiters
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	number float64
+	elems  []token
+}
+
+// contentLexer splits a content stream into tokens
+type contentLexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *contentLexer) next() token {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return token{kind: tokenEOF}
+	}
+	switch c := l.data[l.pos]; {
+	case c == '(':
+		return token{kind: tokenString, value: l.literalString()}
+	case c == '<':
+		if l.peek(1) == '<' {
+			l.pos += 2
+			return token{kind: tokenOther}
+		}
+		return token{kind: tokenString, value: l.hexString()}
+	case c == '>':
+		l.pos++
+		if l.peek(0) == '>' {
+			l.pos++
+		}
+		return token{kind: tokenOther}
+	case c == '[':
+		l.pos++
+		return token{kind: tokenArrayStart}
+	case c == ']':
+		l.pos++
+		return token{kind: tokenArrayEnd}
+	case c == '/':
+		l.pos++
+		return token{kind: tokenName, value: l.regular()}
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		word := l.regular()
+		if n, err := strconv.ParseFloat(word, 64); err == nil {
+			return token{kind: tokenNumber, number: n}
+		}
+		return token{kind: tokenOther, value: word}
+	}
+	word := l.regular()
+	if word == "" {
+		l.pos++ // Stray delimiter
+		return token{kind: tokenOther}
+	}
+	return token{kind: tokenOperator, value: word}
+}
+
+func (l *contentLexer) peek(offset int) byte {
+	if l.pos+offset < len(l.data) {
+		return l.data[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *contentLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case isSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// regular reads a run of regular (non-space, non-delimiter) characters
+func (l *contentLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+func (l *contentLexer) literalString() string {
+	l.pos++ // (
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(b)
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return string(b)
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.peek(0) == '\n' {
+					l.pos++
+				}
+				continue // Line continuation
+			case '\n':
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				v := int(e - '0')
+				for i := 0; i < 2 && l.peek(0) >= '0' && l.peek(0) <= '7'; i++ {
+					v = v*8 + int(l.peek(0)-'0')
+					l.pos++
+				}
+				c = byte(v)
+			default:
+				c = e // \(, \), \\ and unknown escapes
+			}
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+func (l *contentLexer) hexString() string {
+	l.pos++ // <
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // >
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	b, err := hex.DecodeString(string(digits))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// skipInlineImage skips the binary data of an inline image, which follows
+// the ID operator and ends with the EI operator
+func (l *contentLexer) skipInlineImage() {
+	for l.pos < len(l.data) {
+		i := bytes.Index(l.data[l.pos:], []byte("EI"))
+		if i < 0 {
+			l.pos = len(l.data)
+			return
+		}
+		end := l.pos + i
+		l.pos = end + 2
+		if end > 0 && isSpace(l.data[end-1]) && (l.pos == len(l.data) || isSpace(l.data[l.pos])) {
+			return
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\f', 0:
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// winAnsiPunctuation maps the WinAnsiEncoding codes in 0x80-0x9F that
+// differ from Latin-1 and commonly appear in text
+var winAnsiPunctuation = map[byte]rune{
+	0x80: '€', 0x85: '…', 0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+}
+
+// cleanText replaces tabs in decoded text with spaces and drops other
+// control characters
+func cleanText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case r < 0x20 || r == 0x7f:
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// decodeText decodes a string shown by a simple font as WinAnsi text,
+// dropping control characters
+func decodeText(b []byte) string {
+	var s strings.Builder
+	for _, c := range b {
+		switch {
+		case c == '\t':
+			s.WriteByte(' ')
+		case c < 0x20 || c == 0x7f:
+		case c >= 0x80 && c <= 0x9f:
+			if r, ok := winAnsiPunctuation[c]; ok {
+				s.WriteRune(r)
+			}
+		default:
+			s.WriteRune(rune(c))
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build docextract
+
+package docextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPDF_Fixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.pdf")
+	require.NoError(t, err)
+
+	assert.Equal(t, mimeTypePDF, Detect("application/octet-stream", data))
+	text, err := Extract(mimeTypePDF, data)
+	require.NoError(t, err)
+
+	// The first page is FlateDecode-compressed, the second is not; pages are
+	// separated by a blank line
+	assert.Equal(t, "Termite extracts text from PDF documents.\n"+
+		"Each chunk is embedded separately.\n\n"+
+		"The second page (with escapes) is uncompressed.\n"+
+		"Hex strings", text)
+}
+
+// testPDF returns a document made of the given objects, numbered from 1, with
+// the first as its catalog
+func testPDF(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+func testStream(dict string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func deflate(t *testing.T, data []byte) []byte {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return compressed.Bytes()
+}
+
+func TestExtractPDF_FormXObjects(t *testing.T) {
+	data := testPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R"+
+			" /Resources << /Font << /F1 5 0 R >> /XObject << /Fm1 6 0 R /Im1 7 0 R >> >> >>",
+		testStream("", []byte("BT /F1 12 Tf (page) Tj ET /Im1 Do /Fm1 Do")),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		testStream("/Type /XObject /Subtype /Form /Filter /FlateDecode",
+			deflate(t, []byte("BT /F1 12 Tf (\\223form\\224) Tj ET /Fm1 Do"))),
+		testStream("/Type /XObject /Subtype /Image /Width 1 /Height 1", []byte("BT (")),
+	)
+
+	// The form draws itself, which is cut off at maxFormDepth
+	text, err := extractPDF(data)
+	require.NoError(t, err)
+	assert.Equal(t, "page\n"+strings.TrimSuffix(strings.Repeat("“form”\n", maxFormDepth), "\n"), text)
+}
+
+func TestExtractPDF_CompositeFonts(t *testing.T) {
+	toUnicode := "/CIDInit /ProcSet findresource begin 12 dict begin begincmap\n" +
+		"1 begincodespacerange <0000> <FFFF> endcodespacerange\n" +
+		"2 beginbfchar <0001> <0048> <0002> <0069> endbfchar\n" +
+		"endcmap CMapName currentdict /CMap defineresource pop end end"
+	document := func(content string) []byte {
+		return testPDF(
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+			"<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>",
+			testStream("", []byte(content)),
+			"<< /Type /Font /Subtype /Type0 /BaseFont /Mapped /Encoding /Identity-H /ToUnicode 7 0 R >>",
+			"<< /Type /Font /Subtype /Type0 /BaseFont /Unmapped /Encoding /Identity-H >>",
+			testStream("", []byte(toUnicode)),
+		)
+	}
+
+	text, err := extractPDF(document("BT /F1 12 Tf <00010002> Tj ET"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hi", text)
+
+	// Glyph IDs without a Unicode mapping would be extracted as garbage
+	_, err = extractPDF(document("BT /F2 12 Tf <00010002> Tj ET"))
+	assert.ErrorContains(t, err, "Unmapped has no Unicode mapping")
+}
+
+func TestExtractPDF_InflateBudget(t *testing.T) {
+	// Each stream is within the budget; together they exceed it
+	half := deflate(t, bytes.Repeat([]byte(" "), maxInflatedSize/2+1))
+	data := testPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents [4 0 R 4 0 R] >>",
+		testStream("/Filter /FlateDecode", half),
+	)
+
+	_, err := extractPDF(data)
+	assert.ErrorContains(t, err, "exceed")
+}
+
+func TestExtractPDF_Errors(t *testing.T) {
+	_, err := extractPDF([]byte("not a pdf"))
+	assert.Error(t, err)
+
+	_, err = extractPDF([]byte("%PDF-1.4\ntruncated"))
+	assert.Error(t, err)
+}
+
+func TestContentText(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"show string", "BT (Hello) Tj ET", "Hello"},
+		{"escapes", `BT (a\(b\)\\c\101\
+d) Tj ET`, `a(b)\cAd`},
+		{"nested parentheses", "BT (f(x) = y) Tj ET", "f(x) = y"},
+		{"kerning is not a space", "BT [(Ke) -30 (rning) -300 (works)] TJ ET", "Kerning works"},
+		{"new lines", "BT (one) Tj 0 -14 Td (two) Tj T* (three) Tj (four) ' ET", "one\ntwo\nthree\nfour"},
+		{"text matrix", "BT 1 0 0 1 72 700 Tm (left) Tj 1 0 0 1 200 700 Tm (right) Tj 1 0 0 1 72 680 Tm (below) Tj ET",
+			"left right\nbelow"},
+		{"text objects", "BT (first) Tj ET BT (second) Tj ET", "first\nsecond"},
+		{"WinAnsi punctuation", "BT (\\223quoted\\224 \\227 dash) Tj ET", "“quoted” — dash"},
+		{"comments and graphics", "% comment\nq 1 0 0 1 0 0 cm /Im1 Do Q BT /F1 12 Tf (text) Tj ET", "text"},
+		{"inline image", "BI /W 2 /H 1 ID \x00(EI) Tj\xff EI BT (after) Tj ET", "after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := contentText([]byte(tt.content), contentResources{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
@@ -17,22 +17,20 @@
 package embeddings
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/antflydb/antfly-go/libaf/ai"
 	libafembed "github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/antflydb/termite/pkg/termite/lib/hugot"
 	ort "github.com/yalue/onnxruntime_go"
 	"go.uber.org/zap"
 	_ "golang.org/x/image/webp"
@@ -50,66 +48,15 @@ type CLIPEmbedder struct {
 	textProjectionPath   string
 	tokenizer            *CLIPTokenizer
 	config               *CLIPConfig
+	preprocessor         *PreprocessorConfig
 	logger               *zap.Logger
 	caps                 libafembed.EmbedderCapabilities
 	modelPath            string
+	options              CLIPOptions
+	imageSize            int        // Side length images are resized to
 	mu                   sync.Mutex // Protects session operations
 }
 
-// CLIPConfig holds the CLIP model configuration
-type CLIPConfig struct {
-	ModelType     string           `json:"model_type"`
-	VisionConfig  CLIPVisionConfig `json:"vision_config"`
-	TextConfig    CLIPTextConfig   `json:"text_config"`
-	ProjectionDim int              `json:"projection_dim"`
-}
-
-// CLIPVisionConfig holds vision encoder configuration
-type CLIPVisionConfig struct {
-	HiddenSize    int `json:"hidden_size"`
-	ImageSize     int `json:"image_size"`
-	PatchSize     int `json:"patch_size"`
-	ProjectionDim int `json:"projection_dim"`
-}
-
-// CLIPTextConfig holds text encoder configuration
-type CLIPTextConfig struct {
-	HiddenSize            int `json:"hidden_size"`
-	MaxPositionEmbeddings int `json:"max_position_embeddings"`
-	ProjectionDim         int `json:"projection_dim"`
-}
-
-// CLIPTokenizer is a simple tokenizer for CLIP text encoding
-type CLIPTokenizer struct {
-	Vocab       map[string]int `json:"vocab"`
-	MergesRules []string       `json:"merges"`
-	MaxLength   int
-	PadTokenID  int
-	EOSTokenID  int
-	BOSTokenID  int
-}
-
-// PreprocessorConfig holds image preprocessing configuration
-type PreprocessorConfig struct {
-	DoResize      bool      `json:"do_resize"`
-	Size          ImageSize `json:"size"`
-	DoRescale     bool      `json:"do_rescale"`
-	RescaleFactor float32   `json:"rescale_factor"`
-	DoNormalize   bool      `json:"do_normalize"`
-	ImageMean     []float32 `json:"image_mean"`
-	ImageStd      []float32 `json:"image_std"`
-	DoCenterCrop  bool      `json:"do_center_crop"`
-	CropSize      ImageSize `json:"crop_size"`
-	DoConvertRGB  bool      `json:"do_convert_rgb"`
-}
-
-// ImageSize can be either an int or a struct with width/height
-type ImageSize struct {
-	ShortestEdge int `json:"shortest_edge,omitempty"`
-	Height       int `json:"height,omitempty"`
-	Width        int `json:"width,omitempty"`
-}
-
 // ONNX Runtime initialization
 var (
 	ortInitOnce sync.Once
@@ -118,7 +65,7 @@ var (
 
 func initONNXRuntime() error {
 	ortInitOnce.Do(func() {
-		ortInitErr = ort.InitializeEnvironment()
+		ortInitErr = hugot.WrapORTInitError(ort.InitializeEnvironment())
 	})
 	return ortInitErr
 }
@@ -127,12 +74,22 @@ func initONNXRuntime() error {
 // The directory should contain:
 //   - visual_model.onnx (or visual_model_quantized.onnx)
 //   - text_model.onnx (or text_model_quantized.onnx)
+//   - visual_projection.onnx and text_projection.onnx (optional)
 //   - clip_config.json or config.json
 //   - preprocessor_config.json
 //   - tokenizer.json
 //
+// The ONNX files may also be one subdirectory down (e.g. onnx/visual_model.onnx),
+// as in repos pulled with their original layout.
+//
 // Build with -tags="onnx,ORT" to enable this embedder.
 func NewCLIPEmbedder(modelPath string, quantized bool, logger *zap.Logger) (*CLIPEmbedder, error) {
+	return NewCLIPEmbedderWithOptions(modelPath, quantized, DefaultCLIPOptions(), logger)
+}
+
+// NewCLIPEmbedderWithOptions creates a new CLIP embedder with the given options.
+// See NewCLIPEmbedder for the expected model directory layout.
+func NewCLIPEmbedderWithOptions(modelPath string, quantized bool, opts CLIPOptions, logger *zap.Logger) (*CLIPEmbedder, error) {
 	if modelPath == "" {
 		return nil, errors.New("model path is required")
 	}
@@ -150,42 +107,25 @@ func NewCLIPEmbedder(modelPath string, quantized bool, logger *zap.Logger) (*CLI
 	if err != nil {
 		return nil, fmt.Errorf("loading CLIP config: %w", err)
 	}
-
-	// Determine ONNX filenames
-	visualFile := "visual_model.onnx"
-	textFile := "text_model.onnx"
-	if quantized {
-		visualFile = "visual_model_quantized.onnx"
-		textFile = "text_model_quantized.onnx"
+	preprocessor, err := loadPreprocessorConfig(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading preprocessor config: %w", err)
 	}
 
-	visualPath := filepath.Join(modelPath, visualFile)
-	textPath := filepath.Join(modelPath, textFile)
-	visualProjectionPath := filepath.Join(modelPath, "visual_projection.onnx")
-	textProjectionPath := filepath.Join(modelPath, "text_projection.onnx")
-
-	// Verify files exist
-	if _, err := os.Stat(visualPath); err != nil {
-		return nil, fmt.Errorf("visual model not found: %s", visualPath)
+	// Locate ONNX files, which may sit in a subdirectory such as onnx/
+	visualFile, textFile := clipModelFiles(quantized)
+	visualPath := findCLIPModelFile(modelPath, visualFile)
+	if visualPath == "" {
+		return nil, fmt.Errorf("visual model not found: %s", filepath.Join(modelPath, visualFile))
 	}
-	if _, err := os.Stat(textPath); err != nil {
-		return nil, fmt.Errorf("text model not found: %s", textPath)
+	textPath := findCLIPModelFile(modelPath, textFile)
+	if textPath == "" {
+		return nil, fmt.Errorf("text model not found: %s", filepath.Join(modelPath, textFile))
 	}
 	// Check for projection layers (required for proper embedding projection)
-	hasProjections := true
-	if _, err := os.Stat(visualProjectionPath); err != nil {
-		hasProjections = false
-		logger.Warn("visual projection not found, embeddings may have mismatched dimensions",
-			zap.String("path", visualProjectionPath))
-	}
-	if _, err := os.Stat(textProjectionPath); err != nil {
-		hasProjections = false
-		logger.Warn("text projection not found, embeddings may have mismatched dimensions",
-			zap.String("path", textProjectionPath))
-	}
-	if !hasProjections {
-		visualProjectionPath = ""
-		textProjectionPath = ""
+	visualProjectionPath, textProjectionPath, err := findCLIPProjections(modelPath, opts.RequireProjections, logger)
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize ONNX Runtime
@@ -193,20 +133,46 @@ func NewCLIPEmbedder(modelPath string, quantized bool, logger *zap.Logger) (*CLI
 		return nil, fmt.Errorf("initializing ONNX runtime: %w", err)
 	}
 
+	// Check the graphs have the configured tensors; their output sizes take
+	// precedence over the config's hidden sizes
+	names := config.IONames
+	visualDim, visualInputs, err := checkCLIPGraph(visualPath, []string{names.PixelValues}, names.VisualOutput)
+	if err != nil {
+		return nil, fmt.Errorf("visual model %s: %w", visualPath, err)
+	}
+	textDim, _, err := checkCLIPGraph(textPath, []string{names.InputIDs, names.AttentionMask}, names.TextOutput)
+	if err != nil {
+		return nil, fmt.Errorf("text model %s: %w", textPath, err)
+	}
+	if visualDim > 0 {
+		config.VisionConfig.HiddenSize = visualDim
+	}
+	if textDim > 0 {
+		config.TextConfig.HiddenSize = textDim
+	}
+
+	hasProjections := visualProjectionPath != ""
+	dimensions := config.outputDimensions(hasProjections)
+	if len(dimensions) > 1 {
+		logger.Warn("image and text embeddings have different dimensions without projections",
+			zap.Ints("dimensions", dimensions))
+	}
+
 	// Load tokenizer
-	tokenizer, err := loadCLIPTokenizer(modelPath)
+	tokenizer, err := loadCLIPTokenizer(modelPath, config.textMaxLength())
 	if err != nil {
 		return nil, fmt.Errorf("loading tokenizer: %w", err)
 	}
 
-	// Determine image size from config
-	imageSize := 224
-	if config.VisionConfig.ImageSize > 0 {
-		imageSize = config.VisionConfig.ImageSize
+	// Determine image size from the options or config
+	imageSize, err := config.imageSize(opts.ImageSize, fixedImageSize(visualInputs[names.PixelValues]))
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Info("CLIP embedder initialized",
-		zap.Int("projectionDim", config.ProjectionDim),
+		zap.Bool("hasProjections", hasProjections),
+		zap.Ints("dimensions", dimensions),
 		zap.Int("imageSize", imageSize))
 
 	return &CLIPEmbedder{
@@ -216,8 +182,11 @@ func NewCLIPEmbedder(modelPath string, quantized bool, logger *zap.Logger) (*CLI
 		textProjectionPath:   textProjectionPath,
 		tokenizer:            tokenizer,
 		config:               config,
+		preprocessor:         preprocessor,
 		logger:               logger,
 		modelPath:            modelPath,
+		options:              opts,
+		imageSize:            imageSize,
 		caps: libafembed.EmbedderCapabilities{
 			SupportedMIMETypes: []libafembed.MIMETypeSupport{
 				{MIMEType: "text/plain"},
@@ -226,8 +195,8 @@ func NewCLIPEmbedder(modelPath string, quantized bool, logger *zap.Logger) (*CLI
 				{MIMEType: "image/gif"},
 				{MIMEType: "image/webp"},
 			},
-			Dimensions:       []int{config.ProjectionDim},
-			DefaultDimension: config.ProjectionDim,
+			Dimensions:       dimensions,
+			DefaultDimension: dimensions[0],
 			SupportsFusion:   false, // CLIP creates separate embeddings, not fused
 		},
 	}, nil
@@ -249,6 +218,10 @@ func (c *CLIPEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart) (
 	embeddings := make([][]float32, len(contents))
 
 	for i, parts := range contents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		var embedding []float32
 		var err error
 
@@ -283,25 +256,50 @@ func (c *CLIPEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart) (
 	return embeddings, nil
 }
 
+// EmbedFused embeds a mixed query of text and an image as one vector in
+// CLIP's shared space, so that it can be compared against text or image
+// embeddings. Both modalities are embedded separately and combined as a
+// normalized weighted sum; textWeight in [0, 1] is the share of the text,
+// DefaultCLIPFusionTextWeight weighing both equally.
+//
+// CLIP does not natively support fusion (Capabilities reports
+// SupportsFusion false), so the result is an approximation of the combined
+// meaning; see fuseCLIPEmbeddings.
+func (c *CLIPEmbedder) EmbedFused(ctx context.Context, text string, imageData []byte, textWeight float32) ([]float32, error) {
+	if textWeight < 0 || textWeight > 1 {
+		return nil, fmt.Errorf("text weight %g out of range [0, 1]", textWeight)
+	}
+
+	textEmbedding, err := c.embedText(text)
+	if err != nil {
+		return nil, fmt.Errorf("embedding text: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	imageEmbedding, err := c.embedImage(imageData)
+	if err != nil {
+		return nil, fmt.Errorf("embedding image: %w", err)
+	}
+
+	return fuseCLIPEmbeddings(textEmbedding, imageEmbedding, textWeight)
+}
+
 // embedImage processes an image and returns its embedding
 func (c *CLIPEmbedder) embedImage(imageData []byte) ([]float32, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Decode image
-	img, _, err := image.Decode(bytes.NewReader(imageData))
+	// Decode image (selecting the configured frame for animated GIF/WebP)
+	img, err := decodeImageFrame(imageData, c.options.Frame)
 	if err != nil {
 		return nil, fmt.Errorf("decoding image: %w", err)
 	}
 
-	// Get target size from config
-	targetSize := 224
-	if c.config.VisionConfig.ImageSize > 0 {
-		targetSize = c.config.VisionConfig.ImageSize
-	}
+	targetSize := c.imageSize
 
 	// Preprocess image to tensor
-	pixelValues := preprocessImage(img, targetSize)
+	pixelValues := preprocessImage(img, targetSize, c.preprocessor)
 
 	// Create input tensor [1, 3, H, W]
 	inputShape := ort.NewShape(1, 3, int64(targetSize), int64(targetSize))
@@ -311,14 +309,8 @@ func (c *CLIPEmbedder) embedImage(imageData []byte) ([]float32, error) {
 	}
 	defer inputTensor.Destroy()
 
-	// Create output tensors
-	// Visual model outputs: last_hidden_state [1, num_patches, hidden_size] and pooler_output [1, hidden_size]
-	hiddenSize := int64(c.config.VisionConfig.HiddenSize)
-	if hiddenSize == 0 {
-		hiddenSize = 768 // Default for ViT-B
-	}
-
-	// We only need pooler_output for embeddings
+	// Create output tensor for the pooled embedding [1, hidden_size]
+	hiddenSize := int64(c.config.visionHiddenSize())
 	outputShape := ort.NewShape(1, hiddenSize)
 	outputTensor, err := ort.NewEmptyTensor[float32](outputShape)
 	if err != nil {
@@ -329,8 +321,8 @@ func (c *CLIPEmbedder) embedImage(imageData []byte) ([]float32, error) {
 	// Create and run session
 	session, err := ort.NewAdvancedSession(
 		c.visualModelPath,
-		[]string{"pixel_values"},
-		[]string{"pooler_output"},
+		[]string{c.config.IONames.PixelValues},
+		[]string{c.config.IONames.VisualOutput},
 		[]ort.Value{inputTensor},
 		[]ort.Value{outputTensor},
 		nil,
@@ -394,10 +386,7 @@ func (c *CLIPEmbedder) embedText(text string) ([]float32, error) {
 	defer attMaskTensor.Destroy()
 
 	// Create output tensor
-	hiddenSize := int64(c.config.TextConfig.HiddenSize)
-	if hiddenSize == 0 {
-		hiddenSize = 512 // Default for CLIP text encoder
-	}
+	hiddenSize := int64(c.config.textHiddenSize())
 
 	outputShape := ort.NewShape(1, hiddenSize)
 	outputTensor, err := ort.NewEmptyTensor[float32](outputShape)
@@ -409,8 +398,8 @@ func (c *CLIPEmbedder) embedText(text string) ([]float32, error) {
 	// Create and run session
 	session, err := ort.NewAdvancedSession(
 		c.textModelPath,
-		[]string{"input_ids", "attention_mask"},
-		[]string{"pooler_output"},
+		[]string{c.config.IONames.InputIDs, c.config.IONames.AttentionMask},
+		[]string{c.config.IONames.TextOutput},
 		[]ort.Value{inputIDsTensor, attMaskTensor},
 		[]ort.Value{outputTensor},
 		nil,
@@ -442,6 +431,41 @@ func (c *CLIPEmbedder) embedText(text string) ([]float32, error) {
 	return normalizeL2(embedding), nil
 }
 
+// checkCLIPGraph verifies that the encoder at path has the given inputs and
+// output, and returns the output's embedding size (0 if dynamic) and the
+// shapes of the inputs by name. The output must be a pooled [batch, dim]
+// embedding.
+func checkCLIPGraph(path string, inputs []string, output string) (int, map[string][]int64, error) {
+	inputInfo, outputInfo, err := ort.GetInputOutputInfo(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading graph signature: %w", err)
+	}
+
+	inputNames := make([]string, len(inputInfo))
+	inputShapes := make(map[string][]int64, len(inputInfo))
+	for i, info := range inputInfo {
+		inputNames[i] = info.Name
+		inputShapes[info.Name] = info.Dimensions
+	}
+	if err := checkTensorNames("input", inputs, inputNames); err != nil {
+		return 0, nil, err
+	}
+
+	outputNames := make([]string, len(outputInfo))
+	for i, info := range outputInfo {
+		outputNames[i] = info.Name
+	}
+	if err := checkTensorNames("output", []string{output}, outputNames); err != nil {
+		return 0, nil, err
+	}
+
+	info := outputInfo[slices.Index(outputNames, output)]
+	if len(info.Dimensions) != 2 {
+		return 0, nil, fmt.Errorf("output %q has shape %v, want a pooled [batch, dim] embedding", output, info.Dimensions)
+	}
+	return int(max(info.Dimensions[1], 0)), inputShapes, nil
+}
+
 // applyProjection runs an embedding through a projection ONNX model
 func (c *CLIPEmbedder) applyProjection(projPath string, input []float32, inputDim, outputDim int64) ([]float32, error) {
 	// Create input tensor [1, inputDim]
@@ -491,214 +515,3 @@ func (c *CLIPEmbedder) Close() error {
 	// No persistent sessions to close in this implementation
 	return nil
 }
-
-// Helper functions
-
-func loadCLIPConfig(modelPath string) (*CLIPConfig, error) {
-	configPaths := []string{
-		filepath.Join(modelPath, "clip_config.json"),
-		filepath.Join(modelPath, "config.json"),
-	}
-
-	var config CLIPConfig
-	for _, path := range configPaths {
-		data, err := os.ReadFile(path)
-		if err != nil {
-			continue
-		}
-
-		if err := json.Unmarshal(data, &config); err != nil {
-			continue
-		}
-
-		// Check if it's a valid CLIP config
-		if config.ProjectionDim > 0 || config.VisionConfig.ProjectionDim > 0 {
-			if config.ProjectionDim == 0 {
-				config.ProjectionDim = config.VisionConfig.ProjectionDim
-			}
-			return &config, nil
-		}
-	}
-
-	// Return default config for CLIP ViT-B/32
-	return &CLIPConfig{
-		ModelType:     "clip",
-		ProjectionDim: 512,
-		VisionConfig: CLIPVisionConfig{
-			HiddenSize:    768,
-			ImageSize:     224,
-			PatchSize:     32,
-			ProjectionDim: 512,
-		},
-		TextConfig: CLIPTextConfig{
-			HiddenSize:            512,
-			MaxPositionEmbeddings: 77,
-			ProjectionDim:         512,
-		},
-	}, nil
-}
-
-func loadCLIPTokenizer(modelPath string) (*CLIPTokenizer, error) {
-	tokenizerPath := filepath.Join(modelPath, "tokenizer.json")
-	data, err := os.ReadFile(tokenizerPath)
-	if err != nil {
-		return nil, fmt.Errorf("reading tokenizer.json: %w", err)
-	}
-
-	var tokenizerData struct {
-		Model struct {
-			Vocab  map[string]int `json:"vocab"`
-			Merges []string       `json:"merges"`
-		} `json:"model"`
-		AddedTokens []struct {
-			ID      int    `json:"id"`
-			Content string `json:"content"`
-		} `json:"added_tokens"`
-	}
-
-	if err := json.Unmarshal(data, &tokenizerData); err != nil {
-		return nil, fmt.Errorf("parsing tokenizer.json: %w", err)
-	}
-
-	tokenizer := &CLIPTokenizer{
-		Vocab:       tokenizerData.Model.Vocab,
-		MergesRules: tokenizerData.Model.Merges,
-		MaxLength:   77, // CLIP's default max length
-		PadTokenID:  0,
-		EOSTokenID:  49407, // <|endoftext|>
-		BOSTokenID:  49406, // <|startoftext|>
-	}
-
-	// Find special token IDs from added_tokens
-	for _, token := range tokenizerData.AddedTokens {
-		switch token.Content {
-		case "<|endoftext|>":
-			tokenizer.EOSTokenID = token.ID
-		case "<|startoftext|>":
-			tokenizer.BOSTokenID = token.ID
-		}
-	}
-
-	return tokenizer, nil
-}
-
-// Encode tokenizes text for CLIP.
-// Returns input_ids and attention_mask.
-// Note: This is a simplified tokenizer. For production, use a proper BPE implementation.
-func (t *CLIPTokenizer) Encode(text string) ([]int, []int) {
-	text = strings.ToLower(text)
-	words := strings.Fields(text)
-
-	// Start with BOS token
-	inputIDs := []int{t.BOSTokenID}
-
-	// Tokenize each word
-	for _, word := range words {
-		// Add space prefix for BPE compatibility
-		wordWithSpace := " " + word
-		if id, ok := t.Vocab[wordWithSpace]; ok {
-			inputIDs = append(inputIDs, id)
-		} else {
-			// Try without space prefix
-			if id, ok := t.Vocab[word]; ok {
-				inputIDs = append(inputIDs, id)
-			} else {
-				// Character-level fallback
-				for _, char := range word {
-					if id, ok := t.Vocab[string(char)]; ok {
-						inputIDs = append(inputIDs, id)
-					}
-				}
-			}
-		}
-	}
-
-	// Add EOS token
-	inputIDs = append(inputIDs, t.EOSTokenID)
-
-	// Truncate if needed
-	if len(inputIDs) > t.MaxLength {
-		inputIDs = inputIDs[:t.MaxLength-1]
-		inputIDs = append(inputIDs, t.EOSTokenID)
-	}
-
-	// Create attention mask and pad
-	attentionMask := make([]int, len(inputIDs))
-	for i := range attentionMask {
-		attentionMask[i] = 1
-	}
-
-	for len(inputIDs) < t.MaxLength {
-		inputIDs = append(inputIDs, t.PadTokenID)
-		attentionMask = append(attentionMask, 0)
-	}
-
-	return inputIDs, attentionMask
-}
-
-// preprocessImage resizes and normalizes an image for CLIP
-func preprocessImage(img image.Image, targetSize int) []float32 {
-	// CLIP normalization values
-	mean := []float32{0.48145466, 0.4578275, 0.40821073}
-	std := []float32{0.26862954, 0.26130258, 0.27577711}
-
-	// Resize image
-	resized := resizeImage(img, targetSize, targetSize)
-
-	// Convert to float32 tensor in [C, H, W] format
-	pixels := make([]float32, 3*targetSize*targetSize)
-
-	for y := 0; y < targetSize; y++ {
-		for x := 0; x < targetSize; x++ {
-			r, g, b, _ := resized.At(x, y).RGBA()
-
-			// Convert to 0-1 range and normalize
-			rf := float32(r>>8) / 255.0
-			gf := float32(g>>8) / 255.0
-			bf := float32(b>>8) / 255.0
-
-			// Apply normalization
-			rf = (rf - mean[0]) / std[0]
-			gf = (gf - mean[1]) / std[1]
-			bf = (bf - mean[2]) / std[2]
-
-			// Store in CHW format
-			idx := y*targetSize + x
-			pixels[0*targetSize*targetSize+idx] = rf // R channel
-			pixels[1*targetSize*targetSize+idx] = gf // G channel
-			pixels[2*targetSize*targetSize+idx] = bf // B channel
-		}
-	}
-
-	return pixels
-}
-
-// resizeImage performs nearest-neighbor resize
-func resizeImage(img image.Image, width, height int) image.Image {
-	bounds := img.Bounds()
-	srcW := bounds.Dx()
-	srcH := bounds.Dy()
-
-	dst := image.NewRGBA(image.Rect(0, 0, width, height))
-
-	xRatio := float64(srcW) / float64(width)
-	yRatio := float64(srcH) / float64(height)
-
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			srcX := int(float64(x) * xRatio)
-			srcY := int(float64(y) * yRatio)
-
-			if srcX >= srcW {
-				srcX = srcW - 1
-			}
-			if srcY >= srcH {
-				srcY = srcH - 1
-			}
-
-			dst.Set(x, y, img.At(bounds.Min.X+srcX, bounds.Min.Y+srcY))
-		}
-	}
-
-	return dst
-}
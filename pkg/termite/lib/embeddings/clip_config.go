@@ -0,0 +1,369 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// CLIPConfig holds the CLIP model configuration
+type CLIPConfig struct {
+	ModelType     string           `json:"model_type"`
+	VisionConfig  CLIPVisionConfig `json:"vision_config"`
+	TextConfig    CLIPTextConfig   `json:"text_config"`
+	ProjectionDim int              `json:"projection_dim"`
+	IONames       CLIPIONames      `json:"io_names"`
+}
+
+// CLIPIONames names the input and output tensors of the CLIP encoder graphs.
+// Exports differ (some emit "image_embeds" or "text_embeds" rather than
+// "pooler_output"), so names can be set under "io_names" in the model config
+// or in an adjacent io_names.json, which takes precedence. Unset names keep
+// their defaults.
+type CLIPIONames struct {
+	PixelValues   string `json:"pixel_values,omitempty"`
+	InputIDs      string `json:"input_ids,omitempty"`
+	AttentionMask string `json:"attention_mask,omitempty"`
+	VisualOutput  string `json:"visual_output,omitempty"`
+	TextOutput    string `json:"text_output,omitempty"`
+}
+
+// DefaultCLIPIONames returns the tensor names of CLIP encoders exported with
+// Hugging Face Optimum.
+func DefaultCLIPIONames() CLIPIONames {
+	return CLIPIONames{
+		PixelValues:   "pixel_values",
+		InputIDs:      "input_ids",
+		AttentionMask: "attention_mask",
+		VisualOutput:  "pooler_output",
+		TextOutput:    "pooler_output",
+	}
+}
+
+// overlay returns n with the names set in o replacing its own.
+func (n CLIPIONames) overlay(o CLIPIONames) CLIPIONames {
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&n.PixelValues, o.PixelValues},
+		{&n.InputIDs, o.InputIDs},
+		{&n.AttentionMask, o.AttentionMask},
+		{&n.VisualOutput, o.VisualOutput},
+		{&n.TextOutput, o.TextOutput},
+	} {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+	return n
+}
+
+// checkTensorNames returns an error naming the first of want missing from a
+// graph's available inputs or outputs (kind).
+func checkTensorNames(kind string, want, available []string) error {
+	for _, name := range want {
+		if !slices.Contains(available, name) {
+			return fmt.Errorf("%s %q not found in graph (has %s); set io_names in clip_config.json or io_names.json",
+				kind, name, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// CLIPVisionConfig holds vision encoder configuration
+type CLIPVisionConfig struct {
+	HiddenSize    int `json:"hidden_size"`
+	ImageSize     int `json:"image_size"`
+	PatchSize     int `json:"patch_size"`
+	ProjectionDim int `json:"projection_dim"`
+}
+
+// CLIPTextConfig holds text encoder configuration
+type CLIPTextConfig struct {
+	HiddenSize            int `json:"hidden_size"`
+	MaxPositionEmbeddings int `json:"max_position_embeddings"`
+	ProjectionDim         int `json:"projection_dim"`
+}
+
+// CLIP image sizes: the ViT-B/32 default, used when the config omits
+// image_size, and the largest size an override may ask for.
+const (
+	DefaultCLIPImageSize = 224
+	MaxCLIPImageSize     = 1024
+)
+
+// imageSize returns the side length images are resized to: override when
+// set, otherwise the config's image_size. fixed is the visual model's input
+// size when its spatial dimensions are static, 0 when they are dynamic.
+func (c *CLIPConfig) imageSize(override, fixed int) (int, error) {
+	if override == 0 {
+		if c.VisionConfig.ImageSize > 0 {
+			return c.VisionConfig.ImageSize, nil
+		}
+		return DefaultCLIPImageSize, nil
+	}
+	if override < 0 || override > MaxCLIPImageSize {
+		return 0, fmt.Errorf("image size %d out of range [1, %d]", override, MaxCLIPImageSize)
+	}
+	if patch := c.VisionConfig.PatchSize; patch > 0 && override%patch != 0 {
+		return 0, fmt.Errorf("image size %d is not a multiple of the patch size %d", override, patch)
+	}
+	if fixed > 0 && override != fixed {
+		return 0, fmt.Errorf("image size %d not supported: the visual model takes %dx%d images", override, fixed, fixed)
+	}
+	return override, nil
+}
+
+// fixedImageSize returns the spatial size of a [batch, channels, height,
+// width] pixel input, or 0 unless both dimensions are static and equal.
+func fixedImageSize(shape []int64) int {
+	if len(shape) != 4 || shape[2] <= 0 || shape[2] != shape[3] {
+		return 0
+	}
+	return int(shape[2])
+}
+
+// Default encoder hidden sizes for CLIP ViT-B/32, used when the config omits them.
+const (
+	defaultCLIPVisionHiddenSize = 768
+	defaultCLIPTextHiddenSize   = 512
+)
+
+// visionHiddenSize returns the vision encoder's pooled output size.
+func (c *CLIPConfig) visionHiddenSize() int {
+	if c.VisionConfig.HiddenSize > 0 {
+		return c.VisionConfig.HiddenSize
+	}
+	return defaultCLIPVisionHiddenSize
+}
+
+// textHiddenSize returns the text encoder's pooled output size.
+func (c *CLIPConfig) textHiddenSize() int {
+	if c.TextConfig.HiddenSize > 0 {
+		return c.TextConfig.HiddenSize
+	}
+	return defaultCLIPTextHiddenSize
+}
+
+// DefaultCLIPMaxTextLength is CLIP's text context length, used when the
+// config omits max_position_embeddings.
+const DefaultCLIPMaxTextLength = 77
+
+// textMaxLength returns the number of tokens texts are padded or truncated
+// to: the text encoder's max_position_embeddings, which long-context CLIP
+// derivatives raise above CLIP's 77.
+func (c *CLIPConfig) textMaxLength() int {
+	// A sequence needs room for at least the BOS and EOS tokens
+	if c.TextConfig.MaxPositionEmbeddings >= 2 {
+		return c.TextConfig.MaxPositionEmbeddings
+	}
+	return DefaultCLIPMaxTextLength
+}
+
+// outputDimensions returns the embedding dimensions the model actually produces.
+// With projections, both encoders map into the shared ProjectionDim space.
+// Without them, each encoder emits its own hidden size; the visual size is
+// listed first and used as the default.
+func (c *CLIPConfig) outputDimensions(hasProjections bool) []int {
+	if hasProjections {
+		return []int{c.ProjectionDim}
+	}
+	vision, text := c.visionHiddenSize(), c.textHiddenSize()
+	if vision == text {
+		return []int{vision}
+	}
+	return []int{vision, text}
+}
+
+// clipModelFiles returns the visual and text encoder file names for the
+// standard or quantized variant.
+func clipModelFiles(quantized bool) (visual, text string) {
+	if quantized {
+		return "visual_model_quantized.onnx", "text_model_quantized.onnx"
+	}
+	return "visual_model.onnx", "text_model.onnx"
+}
+
+// findCLIPModelFile returns the path of name in modelPath or, for repos pulled
+// with their original layout, in one of its immediate subdirectories (onnx/
+// first). It returns "" if the file is not found.
+func findCLIPModelFile(modelPath, name string) string {
+	if path := filepath.Join(modelPath, name); isRegularFile(path) {
+		return path
+	}
+	entries, err := os.ReadDir(modelPath)
+	if err != nil {
+		return ""
+	}
+	// ReadDir sorts by name; check onnx/ before any other subdirectory
+	slices.SortStableFunc(entries, func(a, b os.DirEntry) int {
+		switch {
+		case a.Name() == "onnx" && b.Name() != "onnx":
+			return -1
+		case b.Name() == "onnx" && a.Name() != "onnx":
+			return 1
+		}
+		return 0
+	})
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if path := filepath.Join(modelPath, entry.Name(), name); isRegularFile(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// HasCLIPModelFiles reports whether modelPath contains both CLIP encoders for
+// the standard or quantized variant, either at the top level or one
+// subdirectory down.
+func HasCLIPModelFiles(modelPath string, quantized bool) bool {
+	visual, text := clipModelFiles(quantized)
+	return findCLIPModelFile(modelPath, visual) != "" && findCLIPModelFile(modelPath, text) != ""
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// findCLIPProjections locates the visual and text projection models in modelPath.
+// Both paths are returned empty if either projection is missing, since the two
+// encoders are only comparable when both are projected. When required is true,
+// a missing projection is an error instead.
+func findCLIPProjections(modelPath string, required bool, logger *zap.Logger) (visual, text string, err error) {
+	visual = findCLIPModelFile(modelPath, "visual_projection.onnx")
+	text = findCLIPModelFile(modelPath, "text_projection.onnx")
+
+	hasProjections := true
+	for _, p := range []struct{ name, path string }{
+		{"visual_projection.onnx", visual},
+		{"text_projection.onnx", text},
+	} {
+		if p.path == "" {
+			missing := filepath.Join(modelPath, p.name)
+			if required {
+				return "", "", fmt.Errorf("projection required but not found: %s", missing)
+			}
+			hasProjections = false
+			logger.Warn("projection not found, embeddings will use encoder hidden size",
+				zap.String("path", missing))
+		}
+	}
+	if !hasProjections {
+		return "", "", nil
+	}
+	return visual, text, nil
+}
+
+// loadCLIPConfig reads clip_config.json or config.json, falling back to ViT-B/32 defaults.
+// Tensor names are resolved by loadCLIPIONames.
+func loadCLIPConfig(modelPath string) (*CLIPConfig, error) {
+	ioNames, err := loadCLIPIONames(modelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config CLIPConfig
+	for _, path := range clipConfigPaths(modelPath) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		if err := json.Unmarshal(data, &config); err != nil {
+			continue
+		}
+
+		// Check if it's a valid CLIP config
+		if config.ProjectionDim > 0 || config.VisionConfig.ProjectionDim > 0 {
+			if config.ProjectionDim == 0 {
+				config.ProjectionDim = config.VisionConfig.ProjectionDim
+			}
+			config.IONames = ioNames
+			return &config, nil
+		}
+	}
+
+	// Return default config for CLIP ViT-B/32
+	return &CLIPConfig{
+		IONames:       ioNames,
+		ModelType:     "clip",
+		ProjectionDim: 512,
+		VisionConfig: CLIPVisionConfig{
+			HiddenSize:    768,
+			ImageSize:     DefaultCLIPImageSize,
+			PatchSize:     32,
+			ProjectionDim: 512,
+		},
+		TextConfig: CLIPTextConfig{
+			HiddenSize:            512,
+			MaxPositionEmbeddings: DefaultCLIPMaxTextLength,
+			ProjectionDim:         512,
+		},
+	}, nil
+}
+
+// clipConfigPaths returns the model config files in order of preference.
+func clipConfigPaths(modelPath string) []string {
+	return []string{
+		filepath.Join(modelPath, "clip_config.json"),
+		filepath.Join(modelPath, "config.json"),
+	}
+}
+
+// loadCLIPIONames resolves the encoder tensor names: the defaults, overlaid
+// with "io_names" from the first model config that sets it, then with
+// io_names.json if present.
+func loadCLIPIONames(modelPath string) (CLIPIONames, error) {
+	names := DefaultCLIPIONames()
+	for _, path := range clipConfigPaths(modelPath) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var config struct {
+			IONames *CLIPIONames `json:"io_names"`
+		}
+		if err := json.Unmarshal(data, &config); err == nil && config.IONames != nil {
+			names = names.overlay(*config.IONames)
+			break
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(modelPath, "io_names.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return names, nil
+	} else if err != nil {
+		return CLIPIONames{}, fmt.Errorf("reading io_names.json: %w", err)
+	}
+	var override CLIPIONames
+	if err := json.Unmarshal(data, &override); err != nil {
+		return CLIPIONames{}, fmt.Errorf("parsing io_names.json: %w", err)
+	}
+	return names.overlay(override), nil
+}
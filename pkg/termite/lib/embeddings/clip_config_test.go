@@ -0,0 +1,255 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testCLIPConfig = `{
+	"model_type": "clip",
+	"projection_dim": 512,
+	"vision_config": {"hidden_size": 768, "image_size": 224, "patch_size": 32},
+	"text_config": {"hidden_size": 512, "max_position_embeddings": 77}
+}`
+
+// writeCLIPModelDir creates a fake CLIP model directory with a config and,
+// optionally, the projection models.
+func writeCLIPModelDir(t *testing.T, withVisualProj, withTextProj bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(testCLIPConfig), 0o644))
+	if withVisualProj {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "visual_projection.onnx"), nil, 0o644))
+	}
+	if withTextProj {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "text_projection.onnx"), nil, 0o644))
+	}
+	return dir
+}
+
+func TestCLIPDimensions_WithProjections(t *testing.T) {
+	dir := writeCLIPModelDir(t, true, true)
+
+	config, err := loadCLIPConfig(dir)
+	require.NoError(t, err)
+
+	visual, text, err := findCLIPProjections(dir, true, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "visual_projection.onnx"), visual)
+	assert.Equal(t, filepath.Join(dir, "text_projection.onnx"), text)
+
+	assert.Equal(t, []int{512}, config.outputDimensions(visual != ""))
+}
+
+func TestCLIPDimensions_WithoutProjections(t *testing.T) {
+	dir := writeCLIPModelDir(t, false, false)
+
+	config, err := loadCLIPConfig(dir)
+	require.NoError(t, err)
+
+	visual, text, err := findCLIPProjections(dir, false, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Empty(t, visual)
+	assert.Empty(t, text)
+
+	// Without projections each encoder emits its hidden size, not projection_dim
+	assert.Equal(t, []int{768, 512}, config.outputDimensions(false))
+}
+
+func TestCLIPDimensions_PartialProjections(t *testing.T) {
+	dir := writeCLIPModelDir(t, true, false)
+
+	// Lenient mode drops both projections so image and text stay consistent
+	visual, text, err := findCLIPProjections(dir, false, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Empty(t, visual)
+	assert.Empty(t, text)
+}
+
+func TestCLIPDimensions_StrictModeMissingProjection(t *testing.T) {
+	dir := writeCLIPModelDir(t, true, false)
+
+	_, _, err := findCLIPProjections(dir, true, zaptest.NewLogger(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "text_projection.onnx")
+}
+
+func TestCLIPDimensions_EqualHiddenSizes(t *testing.T) {
+	config := &CLIPConfig{
+		ProjectionDim: 256,
+		VisionConfig:  CLIPVisionConfig{HiddenSize: 512},
+		TextConfig:    CLIPTextConfig{HiddenSize: 512},
+	}
+	assert.Equal(t, []int{512}, config.outputDimensions(false))
+	assert.Equal(t, []int{256}, config.outputDimensions(true))
+}
+
+// writeNestedCLIPModelDir creates a CLIP model directory with config at the
+// top level and the ONNX files under subdir, as pulled with the repo layout.
+func writeNestedCLIPModelDir(t *testing.T, subdir string, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(testCLIPConfig), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, subdir), 0o755))
+	for _, name := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, subdir, name), nil, 0o644))
+	}
+	return dir
+}
+
+func TestCLIPModelFiles_NestedLayout(t *testing.T) {
+	dir := writeNestedCLIPModelDir(t, "onnx",
+		"visual_model.onnx", "text_model.onnx",
+		"visual_projection.onnx", "text_projection.onnx")
+
+	assert.True(t, HasCLIPModelFiles(dir, false))
+	assert.False(t, HasCLIPModelFiles(dir, true))
+	assert.Equal(t, filepath.Join(dir, "onnx", "visual_model.onnx"), findCLIPModelFile(dir, "visual_model.onnx"))
+
+	visual, text, err := findCLIPProjections(dir, true, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "onnx", "visual_projection.onnx"), visual)
+	assert.Equal(t, filepath.Join(dir, "onnx", "text_projection.onnx"), text)
+}
+
+func TestCLIPModelFiles_QuantizedNestedLayout(t *testing.T) {
+	dir := writeNestedCLIPModelDir(t, "onnx", "visual_model_quantized.onnx", "text_model_quantized.onnx")
+
+	assert.False(t, HasCLIPModelFiles(dir, false))
+	assert.True(t, HasCLIPModelFiles(dir, true))
+}
+
+func TestCLIPModelFiles_Precedence(t *testing.T) {
+	dir := writeNestedCLIPModelDir(t, "models", "visual_model.onnx", "text_model.onnx")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "onnx"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "onnx", "visual_model.onnx"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "text_model.onnx"), nil, 0o644))
+
+	// Top level wins, then onnx/, then other subdirectories
+	assert.Equal(t, filepath.Join(dir, "text_model.onnx"), findCLIPModelFile(dir, "text_model.onnx"))
+	assert.Equal(t, filepath.Join(dir, "onnx", "visual_model.onnx"), findCLIPModelFile(dir, "visual_model.onnx"))
+	assert.Empty(t, findCLIPModelFile(dir, "visual_projection.onnx"))
+}
+
+func TestCLIPModelFiles_IgnoresDeeperNesting(t *testing.T) {
+	dir := writeNestedCLIPModelDir(t, filepath.Join("onnx", "fp32"), "visual_model.onnx", "text_model.onnx")
+
+	assert.False(t, HasCLIPModelFiles(dir, false))
+}
+
+func TestCLIPIONames_Defaults(t *testing.T) {
+	dir := writeCLIPModelDir(t, false, false)
+
+	config, err := loadCLIPConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCLIPIONames(), config.IONames)
+}
+
+func TestCLIPIONames_ConfigOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "clip_config.json"), []byte(`{
+		"projection_dim": 512,
+		"io_names": {"visual_output": "image_embeds"}
+	}`), 0o644))
+
+	config, err := loadCLIPConfig(dir)
+	require.NoError(t, err)
+
+	want := DefaultCLIPIONames()
+	want.VisualOutput = "image_embeds"
+	assert.Equal(t, want, config.IONames)
+}
+
+func TestCLIPIONames_FileTakesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	// Names in a config that is not a valid CLIP config still apply
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{
+		"io_names": {"visual_output": "image_embeds", "text_output": "text_embeds"}
+	}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "io_names.json"),
+		[]byte(`{"text_output": "last_text_embeds"}`), 0o644))
+
+	config, err := loadCLIPConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "image_embeds", config.IONames.VisualOutput)
+	assert.Equal(t, "last_text_embeds", config.IONames.TextOutput)
+	assert.Equal(t, "pixel_values", config.IONames.PixelValues)
+}
+
+func TestCLIPIONames_InvalidFile(t *testing.T) {
+	dir := writeCLIPModelDir(t, false, false)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "io_names.json"), []byte(`{`), 0o644))
+
+	_, err := loadCLIPConfig(dir)
+	require.ErrorContains(t, err, "parsing io_names.json")
+}
+
+func TestCheckTensorNames(t *testing.T) {
+	available := []string{"last_hidden_state", "image_embeds"}
+	require.NoError(t, checkTensorNames("output", []string{"image_embeds"}, available))
+
+	err := checkTensorNames("output", []string{"pooler_output"}, available)
+	require.ErrorContains(t, err, `output "pooler_output" not found in graph (has last_hidden_state, image_embeds)`)
+}
+
+func TestCLIPImageSize_Override(t *testing.T) {
+	config, err := loadCLIPConfig(writeCLIPModelDir(t, false, false))
+	require.NoError(t, err)
+
+	size, err := config.imageSize(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 224, size)
+
+	size, err = config.imageSize(448, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 448, size)
+
+	// The override changes the preprocessed tensor to [3, 448, 448]
+	pixels := preprocessImage(solidImage(64, color.RGBA{R: 255, A: 255}), size, nil)
+	assert.Len(t, pixels, 3*448*448)
+}
+
+func TestCLIPImageSize_Unsupported(t *testing.T) {
+	config, err := loadCLIPConfig(writeCLIPModelDir(t, false, false))
+	require.NoError(t, err)
+
+	_, err = config.imageSize(300, 0)
+	require.ErrorContains(t, err, "not a multiple of the patch size 32")
+
+	_, err = config.imageSize(2048, 0)
+	require.ErrorContains(t, err, "out of range")
+
+	// A visual model with static spatial dimensions only takes its own size
+	_, err = config.imageSize(448, 224)
+	require.ErrorContains(t, err, "takes 224x224 images")
+	size, err := config.imageSize(224, 224)
+	require.NoError(t, err)
+	assert.Equal(t, 224, size)
+}
+
+func TestFixedImageSize(t *testing.T) {
+	assert.Equal(t, 224, fixedImageSize([]int64{-1, 3, 224, 224}))
+	assert.Equal(t, 0, fixedImageSize([]int64{-1, 3, -1, -1}))
+	assert.Equal(t, 0, fixedImageSize([]int64{1, 3, 224, 336}))
+	assert.Equal(t, 0, fixedImageSize(nil))
+}
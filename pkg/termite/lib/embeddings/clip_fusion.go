@@ -0,0 +1,53 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultCLIPFusionTextWeight weighs text and image equally when fusing
+const DefaultCLIPFusionTextWeight float32 = 0.5
+
+// fuseCLIPEmbeddings combines L2-normalized text and image embeddings from
+// CLIP's shared space into one unit-length vector: the normalized weighted
+// sum textWeight*text + (1-textWeight)*image.
+//
+// CLIP is trained to align separate text and image embeddings, not to
+// compose them, so the fused vector only approximates the meaning of the
+// combined query. It tends to work for refining an image with a short text
+// (and vice versa) but should not be treated as a true joint embedding.
+func fuseCLIPEmbeddings(text, image []float32, textWeight float32) ([]float32, error) {
+	if textWeight < 0 || textWeight > 1 {
+		return nil, fmt.Errorf("text weight %g out of range [0, 1]", textWeight)
+	}
+	if len(text) != len(image) {
+		return nil, fmt.Errorf("text embedding has %d dimensions, image embedding has %d", len(text), len(image))
+	}
+
+	imageWeight := 1 - textWeight
+	fused := make([]float32, len(text))
+	var nonZero bool
+	for i := range fused {
+		fused[i] = textWeight*text[i] + imageWeight*image[i]
+		nonZero = nonZero || fused[i] != 0
+	}
+	if !nonZero {
+		// Opposite embeddings with equal weights cancel out
+		return nil, errors.New("fused embedding is zero")
+	}
+	return normalizeL2(fused), nil
+}
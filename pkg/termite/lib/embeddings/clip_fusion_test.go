@@ -0,0 +1,78 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func TestFuseCLIPEmbeddings_UnitLength(t *testing.T) {
+	text := normalizeL2([]float32{1, 2, 3, 4})
+	image := normalizeL2([]float32{4, -1, 0, 2})
+
+	for _, w := range []float32{0, 0.25, DefaultCLIPFusionTextWeight, 0.75, 1} {
+		fused, err := fuseCLIPEmbeddings(text, image, w)
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, math.Sqrt(dot(fused, fused)), 1e-6, "weight %g", w)
+	}
+}
+
+func TestFuseCLIPEmbeddings_WeightShiftsTowardModality(t *testing.T) {
+	text := normalizeL2([]float32{1, 0, 0, 1})
+	image := normalizeL2([]float32{0, 1, 1, 0})
+
+	var prevText, prevImage float64
+	for i, w := range []float32{0, 0.25, 0.5, 0.75, 1} {
+		fused, err := fuseCLIPEmbeddings(text, image, w)
+		require.NoError(t, err)
+		textSim, imageSim := dot(fused, text), dot(fused, image)
+		if i > 0 {
+			assert.Greater(t, textSim, prevText, "text similarity at weight %g", w)
+			assert.Less(t, imageSim, prevImage, "image similarity at weight %g", w)
+		}
+		prevText, prevImage = textSim, imageSim
+	}
+
+	// The extremes reproduce the single-modality embeddings
+	fused, err := fuseCLIPEmbeddings(text, image, 1)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, text, fused, 1e-6)
+	fused, err = fuseCLIPEmbeddings(text, image, 0)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, image, fused, 1e-6)
+}
+
+func TestFuseCLIPEmbeddings_Invalid(t *testing.T) {
+	_, err := fuseCLIPEmbeddings([]float32{1, 0}, []float32{0, 1}, 1.5)
+	assert.Error(t, err)
+
+	_, err = fuseCLIPEmbeddings([]float32{1, 0}, []float32{0, 1, 0}, 0.5)
+	assert.Error(t, err)
+
+	_, err = fuseCLIPEmbeddings([]float32{1, 0}, []float32{-1, 0}, 0.5)
+	assert.Error(t, err)
+}
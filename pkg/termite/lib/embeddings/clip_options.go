@@ -0,0 +1,40 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+// CLIPOptions holds optional settings for a CLIPEmbedder.
+// It is defined outside the onnx build tag so callers can construct it
+// regardless of which backend is compiled in.
+type CLIPOptions struct {
+	// Frame selects which frame of an animated GIF or WebP is embedded.
+	// Defaults to the first frame.
+	Frame FrameSelection
+
+	// RequireProjections fails construction when visual_projection.onnx or
+	// text_projection.onnx is missing, instead of falling back to the
+	// encoders' hidden sizes.
+	RequireProjections bool
+
+	// ImageSize overrides vision_config.image_size, for vision encoders
+	// that accept other resolutions. It must be a multiple of the patch
+	// size, at most MaxCLIPImageSize, and match the visual model's input
+	// when that has a fixed size. 0 uses the model config.
+	ImageSize int
+}
+
+// DefaultCLIPOptions returns the options used by NewCLIPEmbedder.
+func DefaultCLIPOptions() CLIPOptions {
+	return CLIPOptions{}
+}
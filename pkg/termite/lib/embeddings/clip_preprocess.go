@@ -0,0 +1,157 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// PreprocessorConfig holds image preprocessing configuration
+type PreprocessorConfig struct {
+	DoResize      bool      `json:"do_resize"`
+	Size          ImageSize `json:"size"`
+	DoRescale     bool      `json:"do_rescale"`
+	RescaleFactor float32   `json:"rescale_factor"`
+	DoNormalize   bool      `json:"do_normalize"`
+	ImageMean     []float32 `json:"image_mean"`
+	ImageStd      []float32 `json:"image_std"`
+	DoCenterCrop  bool      `json:"do_center_crop"`
+	CropSize      ImageSize `json:"crop_size"`
+	DoConvertRGB  bool      `json:"do_convert_rgb"`
+}
+
+// ImageSize can be either an int or a struct with width/height
+type ImageSize struct {
+	ShortestEdge int `json:"shortest_edge,omitempty"`
+	Height       int `json:"height,omitempty"`
+	Width        int `json:"width,omitempty"`
+}
+
+// CLIP normalization values
+var (
+	clipImageMean = []float32{0.48145466, 0.4578275, 0.40821073}
+	clipImageStd  = []float32{0.26862954, 0.26130258, 0.27577711}
+)
+
+// defaultPreprocessorConfig returns the CLIPImageProcessor defaults, which
+// apply to any setting preprocessor_config.json leaves out.
+func defaultPreprocessorConfig() *PreprocessorConfig {
+	return &PreprocessorConfig{
+		DoResize:      true,
+		DoRescale:     true,
+		RescaleFactor: 1.0 / 255.0,
+		DoNormalize:   true,
+		ImageMean:     slices.Clone(clipImageMean),
+		ImageStd:      slices.Clone(clipImageStd),
+		DoCenterCrop:  true,
+		DoConvertRGB:  true,
+	}
+}
+
+// loadPreprocessorConfig reads preprocessor_config.json, falling back to the
+// CLIPImageProcessor defaults when the file is absent.
+func loadPreprocessorConfig(modelPath string) (*PreprocessorConfig, error) {
+	config := defaultPreprocessorConfig()
+	data, err := os.ReadFile(filepath.Join(modelPath, "preprocessor_config.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// preprocessImage resizes, rescales and normalizes an image for CLIP. Pixel
+// values start in the 0-255 range and are multiplied by the configured
+// rescale factor only when rescaling is enabled; a nil config uses the
+// CLIPImageProcessor defaults.
+func preprocessImage(img image.Image, targetSize int, config *PreprocessorConfig) []float32 {
+	if config == nil {
+		config = defaultPreprocessorConfig()
+	}
+	var scale float32 = 1
+	if config.DoRescale {
+		scale = config.RescaleFactor
+	}
+	mean, std := clipImageMean, clipImageStd
+
+	// Resize image
+	resized := resizeImage(img, targetSize, targetSize)
+
+	// Convert to float32 tensor in [C, H, W] format
+	pixels := make([]float32, 3*targetSize*targetSize)
+
+	for y := 0; y < targetSize; y++ {
+		for x := 0; x < targetSize; x++ {
+			r, g, b, _ := resized.At(x, y).RGBA()
+
+			// Rescale from the 0-255 range
+			rf := float32(r>>8) * scale
+			gf := float32(g>>8) * scale
+			bf := float32(b>>8) * scale
+
+			// Apply normalization
+			rf = (rf - mean[0]) / std[0]
+			gf = (gf - mean[1]) / std[1]
+			bf = (bf - mean[2]) / std[2]
+
+			// Store in CHW format
+			idx := y*targetSize + x
+			pixels[0*targetSize*targetSize+idx] = rf // R channel
+			pixels[1*targetSize*targetSize+idx] = gf // G channel
+			pixels[2*targetSize*targetSize+idx] = bf // B channel
+		}
+	}
+
+	return pixels
+}
+
+// resizeImage performs nearest-neighbor resize
+func resizeImage(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+	srcW := bounds.Dx()
+	srcH := bounds.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	xRatio := float64(srcW) / float64(width)
+	yRatio := float64(srcH) / float64(height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			srcX := int(float64(x) * xRatio)
+			srcY := int(float64(y) * yRatio)
+
+			if srcX >= srcW {
+				srcX = srcW - 1
+			}
+			if srcY >= srcH {
+				srcY = srcH - 1
+			}
+
+			dst.Set(x, y, img.At(bounds.Min.X+srcX, bounds.Min.Y+srcY))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// solidImage returns a size×size image filled with c
+func solidImage(size int, c color.RGBA) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestLoadPreprocessorConfig_Defaults(t *testing.T) {
+	config, err := loadPreprocessorConfig(t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, config.DoRescale)
+	assert.InDelta(t, 1.0/255.0, config.RescaleFactor, 1e-9)
+}
+
+func TestLoadPreprocessorConfig_File(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "preprocessor_config.json"),
+		[]byte(`{"do_rescale": false, "image_mean": [0.5, 0.5, 0.5]}`), 0o644))
+
+	config, err := loadPreprocessorConfig(dir)
+	require.NoError(t, err)
+	assert.False(t, config.DoRescale)
+	assert.InDelta(t, 1.0/255.0, config.RescaleFactor, 1e-9, "unset fields keep their defaults")
+	assert.True(t, config.DoNormalize)
+}
+
+func TestPreprocessImage_Rescale(t *testing.T) {
+	img := solidImage(4, color.RGBA{R: 255, G: 128, B: 0, A: 255})
+	want := func(value float32, channel int) float32 {
+		return (value - clipImageMean[channel]) / clipImageStd[channel]
+	}
+
+	tests := []struct {
+		name   string
+		config *PreprocessorConfig
+		scale  float32
+	}{
+		{"nil config defaults to 1/255", nil, 1.0 / 255.0},
+		{"custom factor", &PreprocessorConfig{DoRescale: true, RescaleFactor: 1.0 / 127.5}, 1.0 / 127.5},
+		{"rescale disabled", &PreprocessorConfig{DoRescale: false, RescaleFactor: 1.0 / 255.0}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pixels := preprocessImage(img, 4, tt.config)
+			require.Len(t, pixels, 3*4*4)
+			plane := 4 * 4
+			assert.InDelta(t, want(255*tt.scale, 0), pixels[0], 1e-4)
+			assert.InDelta(t, want(128*tt.scale, 1), pixels[plane], 1e-4)
+			assert.InDelta(t, want(0, 2), pixels[2*plane], 1e-4)
+		})
+	}
+}
+
+func TestPreprocessImage_NoRescaleKeepsPixelRange(t *testing.T) {
+	img := solidImage(2, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	pixels := preprocessImage(img, 2, &PreprocessorConfig{DoRescale: false})
+
+	// Normalizing raw 0-255 values puts white far above the rescaled [~1.9, ~2.2]
+	for i, p := range pixels {
+		channel := i / 4
+		assert.InDelta(t, (255-clipImageMean[channel])/clipImageStd[channel], p, 1e-3)
+		assert.Greater(t, p, float32(900))
+	}
+}
@@ -30,3 +30,8 @@ type CLIPEmbedder struct{}
 func NewCLIPEmbedder(modelPath string, quantized bool, logger *zap.Logger) (*CLIPEmbedder, error) {
 	return nil, errors.New("CLIP embedder not available: build with -tags=\"onnx,ORT\" to enable")
 }
+
+// NewCLIPEmbedderWithOptions returns an error when CLIP support is disabled.
+func NewCLIPEmbedderWithOptions(modelPath string, quantized bool, opts CLIPOptions, logger *zap.Logger) (*CLIPEmbedder, error) {
+	return NewCLIPEmbedder(modelPath, quantized, logger)
+}
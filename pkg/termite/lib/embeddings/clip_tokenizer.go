@@ -0,0 +1,133 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CLIPTokenizer is a simple tokenizer for CLIP text encoding
+type CLIPTokenizer struct {
+	Vocab       map[string]int `json:"vocab"`
+	MergesRules []string       `json:"merges"`
+	MaxLength   int            // Sequence length texts are padded or truncated to
+	PadTokenID  int
+	EOSTokenID  int
+	BOSTokenID  int
+}
+
+// loadCLIPTokenizer loads tokenizer.json from modelPath, producing
+// sequences of maxLength tokens
+func loadCLIPTokenizer(modelPath string, maxLength int) (*CLIPTokenizer, error) {
+	tokenizerPath := filepath.Join(modelPath, "tokenizer.json")
+	data, err := os.ReadFile(tokenizerPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading tokenizer.json: %w", err)
+	}
+
+	var tokenizerData struct {
+		Model struct {
+			Vocab  map[string]int `json:"vocab"`
+			Merges []string       `json:"merges"`
+		} `json:"model"`
+		AddedTokens []struct {
+			ID      int    `json:"id"`
+			Content string `json:"content"`
+		} `json:"added_tokens"`
+	}
+
+	if err := json.Unmarshal(data, &tokenizerData); err != nil {
+		return nil, fmt.Errorf("parsing tokenizer.json: %w", err)
+	}
+
+	tokenizer := &CLIPTokenizer{
+		Vocab:       tokenizerData.Model.Vocab,
+		MergesRules: tokenizerData.Model.Merges,
+		MaxLength:   maxLength,
+		PadTokenID:  0,
+		EOSTokenID:  49407, // <|endoftext|>
+		BOSTokenID:  49406, // <|startoftext|>
+	}
+
+	// Find special token IDs from added_tokens
+	for _, token := range tokenizerData.AddedTokens {
+		switch token.Content {
+		case "<|endoftext|>":
+			tokenizer.EOSTokenID = token.ID
+		case "<|startoftext|>":
+			tokenizer.BOSTokenID = token.ID
+		}
+	}
+
+	return tokenizer, nil
+}
+
+// Encode tokenizes text for CLIP.
+// Returns input_ids and attention_mask.
+// Note: This is a simplified tokenizer. For production, use a proper BPE implementation.
+func (t *CLIPTokenizer) Encode(text string) ([]int, []int) {
+	text = strings.ToLower(text)
+	words := strings.Fields(text)
+
+	// Start with BOS token
+	inputIDs := []int{t.BOSTokenID}
+
+	// Tokenize each word
+	for _, word := range words {
+		// Add space prefix for BPE compatibility
+		wordWithSpace := " " + word
+		if id, ok := t.Vocab[wordWithSpace]; ok {
+			inputIDs = append(inputIDs, id)
+		} else {
+			// Try without space prefix
+			if id, ok := t.Vocab[word]; ok {
+				inputIDs = append(inputIDs, id)
+			} else {
+				// Character-level fallback
+				for _, char := range word {
+					if id, ok := t.Vocab[string(char)]; ok {
+						inputIDs = append(inputIDs, id)
+					}
+				}
+			}
+		}
+	}
+
+	// Add EOS token
+	inputIDs = append(inputIDs, t.EOSTokenID)
+
+	// Truncate if needed
+	if len(inputIDs) > t.MaxLength {
+		inputIDs = inputIDs[:t.MaxLength-1]
+		inputIDs = append(inputIDs, t.EOSTokenID)
+	}
+
+	// Create attention mask and pad
+	attentionMask := make([]int, len(inputIDs))
+	for i := range attentionMask {
+		attentionMask[i] = 1
+	}
+
+	for len(inputIDs) < t.MaxLength {
+		inputIDs = append(inputIDs, t.PadTokenID)
+		attentionMask = append(attentionMask, 0)
+	}
+
+	return inputIDs, attentionMask
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCLIPTokenizer = `{
+	"model": {"vocab": {"a": 320, " photo": 1125, " of": 539, " cat": 2368}, "merges": []},
+	"added_tokens": [
+		{"id": 49406, "content": "<|startoftext|>"},
+		{"id": 49407, "content": "<|endoftext|>"}
+	]
+}`
+
+// loadTestCLIPTokenizer writes a model directory with the given config and
+// loads its tokenizer as the embedder does
+func loadTestCLIPTokenizer(t *testing.T, config string) *CLIPTokenizer {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tokenizer.json"), []byte(testCLIPTokenizer), 0o644))
+
+	cfg, err := loadCLIPConfig(dir)
+	require.NoError(t, err)
+	tokenizer, err := loadCLIPTokenizer(dir, cfg.textMaxLength())
+	require.NoError(t, err)
+	return tokenizer
+}
+
+func TestCLIPTokenizer_DefaultMaxLength(t *testing.T) {
+	tokenizer := loadTestCLIPTokenizer(t, `{"model_type": "clip", "projection_dim": 512, "text_config": {"hidden_size": 512}}`)
+
+	ids, mask := tokenizer.Encode("a photo of a cat")
+	assert.Len(t, ids, DefaultCLIPMaxTextLength)
+	assert.Len(t, mask, DefaultCLIPMaxTextLength)
+}
+
+func TestCLIPTokenizer_LongContext(t *testing.T) {
+	tokenizer := loadTestCLIPTokenizer(t,
+		`{"model_type": "clip", "projection_dim": 512, "text_config": {"hidden_size": 512, "max_position_embeddings": 248}}`)
+	require.Equal(t, 248, tokenizer.MaxLength)
+
+	// Short texts are padded to the configured length
+	ids, mask := tokenizer.Encode("a photo of a cat")
+	require.Len(t, ids, 248)
+	require.Len(t, mask, 248)
+	assert.Equal(t, []int{49406, 320, 1125, 539, 320, 2368, 49407}, ids[:7])
+	assert.Equal(t, 7, countOnes(mask))
+	assert.Equal(t, 0, ids[247])
+
+	// Long texts are truncated to it, still ending in EOS. At 200 words the
+	// text fits a long-context model but not CLIP's 77 tokens.
+	ids, mask = tokenizer.Encode(strings.Repeat("cat ", 200))
+	require.Len(t, ids, 248)
+	assert.Equal(t, 202, countOnes(mask))
+	assert.Equal(t, 49407, ids[201])
+
+	ids, mask = tokenizer.Encode(strings.Repeat("cat ", 300))
+	require.Len(t, ids, 248)
+	assert.Equal(t, 248, countOnes(mask))
+	assert.Equal(t, 49407, ids[247])
+}
+
+func TestCLIPConfig_TextMaxLength(t *testing.T) {
+	for _, tt := range []struct {
+		maxPositions int
+		want         int
+	}{
+		{0, DefaultCLIPMaxTextLength},
+		{1, DefaultCLIPMaxTextLength},
+		{77, 77},
+		{248, 248},
+	} {
+		cfg := CLIPConfig{TextConfig: CLIPTextConfig{MaxPositionEmbeddings: tt.maxPositions}}
+		assert.Equal(t, tt.want, cfg.textMaxLength(), "max_position_embeddings %d", tt.maxPositions)
+	}
+}
+
+func countOnes(mask []int) int {
+	n := 0
+	for _, m := range mask {
+		n += m
+	}
+	return n
+}
@@ -19,12 +19,14 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"path/filepath"
 	"runtime"
 	"sync/atomic"
 
 	"github.com/antflydb/antfly-go/libaf/ai"
 	"github.com/antflydb/antfly-go/libaf/embeddings"
 	"github.com/antflydb/termite/pkg/termite/lib/hugot"
+	"github.com/antflydb/termite/pkg/termite/lib/tokenizer"
 	khugot "github.com/knights-analytics/hugot"
 	"github.com/knights-analytics/hugot/backends"
 	"github.com/knights-analytics/hugot/pipelines"
@@ -43,6 +45,7 @@ type HugotEmbedder struct {
 	logger        *zap.Logger
 	sessionShared bool // true if session is shared and shouldn't be destroyed
 	caps          embeddings.EmbedderCapabilities
+	tokenizer     tokenizer.Tokenizer // nil if the model ships no tokenizer.json
 }
 
 // NewHugotEmbedder creates a new embedder using the Hugot ONNX runtime.
@@ -107,7 +110,7 @@ func NewHugotEmbedderWithSession(modelPath string, onnxFilename string, sharedSe
 	if err != nil {
 		// Only destroy session if we created it (not shared)
 		if !sessionShared {
-			_ = session.Destroy()
+			_ = hugot.ReleaseSession(session)
 		}
 		logger.Error("Failed to create pipeline", zap.Error(err))
 		return nil, fmt.Errorf("creating feature extraction pipeline: %w", err)
@@ -120,9 +123,16 @@ func NewHugotEmbedderWithSession(modelPath string, onnxFilename string, sharedSe
 		logger:        logger,
 		sessionShared: sessionShared,
 		caps:          embeddings.TextOnlyCapabilities(), // ONNX embedders are typically text-only
+		tokenizer:     loadModelTokenizer(modelPath, logger),
 	}, nil
 }
 
+// CountTokens returns the number of tokens in text using the model's
+// tokenizer, or an estimate if the model has no tokenizer.json.
+func (h *HugotEmbedder) CountTokens(text string) int {
+	return countTokens(h.tokenizer, text)
+}
+
 // Capabilities returns the capabilities of this embedder
 func (h *HugotEmbedder) Capabilities() embeddings.EmbedderCapabilities {
 	return h.caps
@@ -150,6 +160,13 @@ func (h *HugotEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart)
 		zap.Int("numTexts", len(values)),
 	)
 
+	// Check context cancellation
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
 	// Run feature extraction inference
 	h.logger.Debug("About to call pipeline.RunPipeline")
 	output, err := h.pipeline.RunPipeline(values)
@@ -188,23 +205,33 @@ func (h *HugotEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart)
 	return result, nil
 }
 
+// DefaultNormalizationEpsilon is the L2 norm below which a vector is treated
+// as zero instead of being normalized
+const DefaultNormalizationEpsilon = 1e-12
+
 // normalizeL2 performs L2 normalization on a vector
 func normalizeL2(vec []float32) []float32 {
-	// Calculate L2 norm
-	var sum float32
-	for _, v := range vec {
-		sum += v * v
-	}
+	return NormalizeL2(vec, DefaultNormalizationEpsilon)
+}
 
-	if sum == 0 {
-		return vec // Avoid division by zero
+// NormalizeL2 scales vec to unit L2 norm. A vector whose norm is below
+// epsilon, such as the embedding of a blank image, is returned as a zero
+// vector: dividing by a (near-)zero norm would yield NaN or Inf components
+// that poison downstream indexes.
+func NormalizeL2(vec []float32, epsilon float64) []float32 {
+	// Accumulate in float64 so that tiny components do not underflow
+	var sum float64
+	for _, v := range vec {
+		sum += float64(v) * float64(v)
 	}
 
-	// Normalize
-	norm := float32(1.0) / float32(math.Sqrt(float64(sum)))
 	normalized := make([]float32, len(vec))
+	norm := math.Sqrt(sum)
+	if norm == 0 || norm < epsilon {
+		return normalized
+	}
 	for i, v := range vec {
-		normalized[i] = v * norm
+		normalized[i] = float32(float64(v) / norm)
 	}
 
 	return normalized
@@ -214,8 +241,8 @@ func normalizeL2(vec []float32) []float32 {
 // Only destroys the session if it was created by this embedder (not shared)
 func (h *HugotEmbedder) Close() error {
 	if h.session != nil && !h.sessionShared {
-		h.logger.Info("Destroying Hugot session (owned by this embedder)")
-		return h.session.Destroy()
+		h.logger.Info("Releasing Hugot session (owned by this embedder)")
+		return hugot.ReleaseSession(h.session)
 	} else if h.sessionShared {
 		h.logger.Debug("Skipping session destruction (shared session)")
 	}
@@ -233,6 +260,7 @@ type PooledHugotEmbedder struct {
 	sessionShared bool
 	poolSize      int
 	caps          embeddings.EmbedderCapabilities
+	tokenizer     tokenizer.Tokenizer // nil if the model ships no tokenizer.json
 }
 
 // NewPooledHugotEmbedder creates a new pooled embedder using the Hugot ONNX runtime.
@@ -302,7 +330,7 @@ func NewPooledHugotEmbedderWithSession(modelPath string, onnxFilename string, po
 		if err != nil {
 			// Clean up already-created pipelines
 			if !sessionShared {
-				_ = session.Destroy()
+				_ = hugot.ReleaseSession(session)
 			}
 			logger.Error("Failed to create pipeline",
 				zap.Int("index", i),
@@ -323,9 +351,16 @@ func NewPooledHugotEmbedderWithSession(modelPath string, onnxFilename string, po
 		sessionShared: sessionShared,
 		poolSize:      poolSize,
 		caps:          embeddings.TextOnlyCapabilities(),
+		tokenizer:     loadModelTokenizer(modelPath, logger),
 	}, nil
 }
 
+// CountTokens returns the number of tokens in text using the model's
+// tokenizer, or an estimate if the model has no tokenizer.json.
+func (p *PooledHugotEmbedder) CountTokens(text string) int {
+	return countTokens(p.tokenizer, text)
+}
+
 // Capabilities returns the capabilities of this embedder
 func (p *PooledHugotEmbedder) Capabilities() embeddings.EmbedderCapabilities {
 	return p.caps
@@ -355,6 +390,13 @@ func (p *PooledHugotEmbedder) Embed(ctx context.Context, contents [][]ai.Content
 		zap.Int("pipelineIndex", idx),
 		zap.Int("numTexts", len(values)))
 
+	// The slot may have been granted after the deadline
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
 	// Run feature extraction inference
 	output, err := pipeline.RunPipeline(values)
 	if err != nil {
@@ -389,10 +431,29 @@ func (p *PooledHugotEmbedder) Embed(ctx context.Context, contents [][]ai.Content
 // Only destroys the session if it was created by this embedder (not shared).
 func (p *PooledHugotEmbedder) Close() error {
 	if p.session != nil && !p.sessionShared {
-		p.logger.Info("Destroying Hugot session (owned by this pooled embedder)")
-		return p.session.Destroy()
+		p.logger.Info("Releasing Hugot session (owned by this pooled embedder)")
+		return hugot.ReleaseSession(p.session)
 	} else if p.sessionShared {
 		p.logger.Debug("Skipping session destruction (shared session)")
 	}
 	return nil
 }
+
+// loadModelTokenizer loads the tokenizer.json shipped with a model. It returns
+// nil if the model has none, in which case token counts are estimated.
+func loadModelTokenizer(modelPath string, logger *zap.Logger) tokenizer.Tokenizer {
+	tk, err := tokenizer.NewHuggingFaceTokenizer(filepath.Join(modelPath, "tokenizer.json"))
+	if err != nil {
+		logger.Warn("Model tokenizer unavailable, estimating token counts", zap.Error(err))
+		return nil
+	}
+	return tk
+}
+
+// countTokens counts with tk, or estimates (1 token ≈ 4 chars) without one
+func countTokens(tk tokenizer.Tokenizer, text string) int {
+	if tk == nil {
+		return len(text) / 4
+	}
+	return tk.CountTokens(text)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertFinite(t *testing.T, vec []float32) {
+	t.Helper()
+	for i, v := range vec {
+		f := float64(v)
+		assert.False(t, math.IsNaN(f) || math.IsInf(f, 0), "component %d is %g", i, v)
+	}
+}
+
+func TestNormalizeL2(t *testing.T) {
+	normalized := normalizeL2([]float32{3, 4})
+	assert.InDeltaSlice(t, []float32{0.6, 0.8}, normalized, 1e-6)
+
+	// Zero and near-zero vectors come back as zero vectors
+	for _, vec := range [][]float32{
+		{0, 0, 0},
+		{1e-20, -1e-20, 1e-20},
+		{math.SmallestNonzeroFloat32, 0, 0},
+	} {
+		normalized := normalizeL2(vec)
+		assertFinite(t, normalized)
+		assert.Equal(t, make([]float32, len(vec)), normalized)
+	}
+
+	// Small vectors above epsilon are normalized without underflowing
+	normalized = normalizeL2([]float32{3e-10, 4e-10})
+	assertFinite(t, normalized)
+	assert.InDeltaSlice(t, []float32{0.6, 0.8}, normalized, 1e-6)
+}
+
+func TestNormalizeL2_Epsilon(t *testing.T) {
+	vec := []float32{0.003, 0.004}
+	assert.Equal(t, []float32{0, 0}, NormalizeL2(vec, 0.01))
+	assert.InDeltaSlice(t, []float32{0.6, 0.8}, NormalizeL2(vec, 0.001), 1e-6)
+
+	// A zero epsilon still never divides by zero
+	normalized := NormalizeL2([]float32{0, 0}, 0)
+	assertFinite(t, normalized)
+	assert.Equal(t, []float32{0, 0}, normalized)
+}
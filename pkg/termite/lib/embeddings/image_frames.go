@@ -0,0 +1,328 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+
+	"golang.org/x/image/webp"
+)
+
+// ErrFrameOutOfRange is returned when the requested frame index does not
+// exist in an animated image.
+var ErrFrameOutOfRange = errors.New("frame index out of range")
+
+// FrameSelection controls which frame of an animated image (GIF or WebP) is
+// embedded. Single-frame images are always decoded as-is, regardless of the
+// selection.
+type FrameSelection struct {
+	// Index is the zero-based frame to embed. Ignored when Middle is true.
+	Index int
+	// Middle selects the middle frame (frameCount/2) instead of Index.
+	Middle bool
+}
+
+// resolve returns the frame index to use for an image with frameCount frames.
+func (s FrameSelection) resolve(frameCount int) (int, error) {
+	idx := s.Index
+	if s.Middle {
+		idx = frameCount / 2
+	}
+	if idx < 0 || idx >= frameCount {
+		return 0, fmt.Errorf("%w: requested frame %d, image has %d frames",
+			ErrFrameOutOfRange, idx, frameCount)
+	}
+	return idx, nil
+}
+
+// decodeImageFrame decodes image data, selecting a single frame from animated
+// GIF and WebP images. Frames are composited onto the full canvas so that the
+// returned image matches what a viewer would display at that point in the
+// animation. All other formats are decoded with image.Decode.
+func decodeImageFrame(data []byte, sel FrameSelection) (image.Image, error) {
+	switch {
+	case isGIF(data):
+		return decodeGIFFrame(data, sel)
+	case isAnimatedWebP(data):
+		return decodeWebPFrame(data, sel)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func isGIF(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a"))
+}
+
+// decodeGIFFrame decodes all GIF frames and returns the selected one,
+// honoring each frame's disposal method.
+func decodeGIFFrame(data []byte, sel FrameSelection) (image.Image, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(g.Image) == 0 {
+		return nil, errors.New("gif: no frames")
+	}
+	if len(g.Image) == 1 {
+		return g.Image[0], nil
+	}
+
+	idx, err := sel.resolve(len(g.Image))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		for _, frame := range g.Image {
+			bounds = bounds.Union(frame.Bounds())
+		}
+	}
+
+	canvas := image.NewRGBA(bounds)
+	for i, frame := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		if i == idx {
+			return canvas, nil
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	// Unreachable: idx is always within range.
+	return canvas, nil
+}
+
+// WebP container constants (see https://developers.google.com/speed/webp/docs/riff_container).
+const (
+	webpAnimationFlag = 1 << 1
+	webpAlphaFlag     = 1 << 4
+
+	// ANMF flags
+	webpDisposeBackground = 1 << 0
+	webpNoBlend           = 1 << 1
+
+	webpANMFHeaderLen = 16
+)
+
+// webpChunk is a single chunk from a RIFF container.
+type webpChunk struct {
+	id   string
+	data []byte
+}
+
+// readWebPChunks splits a RIFF WEBP container into its top-level chunks.
+func readWebPChunks(data []byte) ([]webpChunk, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errors.New("webp: invalid RIFF header")
+	}
+	return splitRIFFChunks(data[12:])
+}
+
+// splitRIFFChunks parses a sequence of RIFF chunks, accounting for the
+// padding byte that follows odd-sized chunk payloads.
+func splitRIFFChunks(data []byte) ([]webpChunk, error) {
+	var chunks []webpChunk
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errors.New("webp: truncated chunk header")
+		}
+		id := string(data[0:4])
+		size := int(binary.LittleEndian.Uint32(data[4:8]))
+		data = data[8:]
+		if size < 0 || size > len(data) {
+			return nil, fmt.Errorf("webp: chunk %q exceeds container size", id)
+		}
+		chunks = append(chunks, webpChunk{id: id, data: data[:size]})
+		if size%2 == 1 && size < len(data) {
+			size++
+		}
+		data = data[size:]
+	}
+	return chunks, nil
+}
+
+// isAnimatedWebP reports whether data is a WebP with the VP8X animation flag set.
+func isAnimatedWebP(data []byte) bool {
+	if len(data) < 21 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return false
+	}
+	return string(data[12:16]) == "VP8X" && data[20]&webpAnimationFlag != 0
+}
+
+// webpFrame is a decoded ANMF frame with its placement on the canvas.
+type webpFrame struct {
+	img     image.Image
+	rect    image.Rectangle
+	dispose bool
+	blend   bool
+}
+
+// decodeWebPFrame decodes an animated WebP and returns the selected frame.
+// golang.org/x/image/webp only understands still images, so each ANMF frame
+// is rewrapped as a standalone WebP before being decoded and composited.
+func decodeWebPFrame(data []byte, sel FrameSelection) (image.Image, error) {
+	chunks, err := readWebPChunks(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var canvasRect image.Rectangle
+	var anmf [][]byte
+	for _, c := range chunks {
+		switch c.id {
+		case "VP8X":
+			if len(c.data) < 10 {
+				return nil, errors.New("webp: invalid VP8X chunk")
+			}
+			canvasRect = image.Rect(0, 0, int(readUint24(c.data[4:]))+1, int(readUint24(c.data[7:]))+1)
+		case "ANMF":
+			anmf = append(anmf, c.data)
+		}
+	}
+	if len(anmf) == 0 {
+		return nil, errors.New("webp: animated image has no frames")
+	}
+
+	idx, err := sel.resolve(len(anmf))
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := image.NewRGBA(canvasRect)
+	for i := 0; i <= idx; i++ {
+		frame, err := decodeANMF(anmf[i])
+		if err != nil {
+			return nil, fmt.Errorf("decoding frame %d: %w", i, err)
+		}
+
+		op := draw.Over
+		if !frame.blend {
+			op = draw.Src
+		}
+		draw.Draw(canvas, frame.rect, frame.img, frame.img.Bounds().Min, op)
+		if i == idx {
+			break
+		}
+		if frame.dispose {
+			draw.Draw(canvas, frame.rect, image.Transparent, image.Point{}, draw.Src)
+		}
+	}
+	return canvas, nil
+}
+
+// decodeANMF decodes a single ANMF chunk payload.
+func decodeANMF(data []byte) (*webpFrame, error) {
+	if len(data) < webpANMFHeaderLen {
+		return nil, errors.New("webp: invalid ANMF chunk")
+	}
+	x := int(readUint24(data[0:])) * 2
+	y := int(readUint24(data[3:])) * 2
+	w := int(readUint24(data[6:])) + 1
+	h := int(readUint24(data[9:])) + 1
+	flags := data[15]
+	frameData := data[webpANMFHeaderLen:]
+
+	subChunks, err := splitRIFFChunks(frameData)
+	if err != nil {
+		return nil, err
+	}
+	hasAlpha := false
+	for _, c := range subChunks {
+		if c.id == "ALPH" {
+			hasAlpha = true
+		}
+	}
+
+	// Rebuild a still WebP: an ALPH chunk is only valid after a VP8X header.
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	if hasAlpha {
+		vp8x := make([]byte, 10)
+		vp8x[0] = webpAlphaFlag
+		putUint24(vp8x[4:], uint32(w-1))
+		putUint24(vp8x[7:], uint32(h-1))
+		writeRIFFChunk(&body, "VP8X", vp8x)
+	}
+	body.Write(frameData)
+
+	var riff bytes.Buffer
+	riff.WriteString("RIFF")
+	_ = binary.Write(&riff, binary.LittleEndian, uint32(body.Len()))
+	riff.Write(body.Bytes())
+
+	img, err := webp.Decode(&riff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webpFrame{
+		img:     img,
+		rect:    image.Rect(x, y, x+w, y+h),
+		dispose: flags&webpDisposeBackground != 0,
+		blend:   flags&webpNoBlend == 0,
+	}, nil
+}
+
+func writeRIFFChunk(buf *bytes.Buffer, id string, data []byte) {
+	buf.WriteString(id)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	if len(data)%2 == 1 {
+		buf.WriteByte(0)
+	}
+}
+
+func readUint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
+
+func putUint24(b []byte, v uint32) {
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
+
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var frameColors = []color.RGBA{
+	{R: 255, A: 255},
+	{G: 255, A: 255},
+	{B: 255, A: 255},
+}
+
+// makeTestGIF builds a 3-frame 8x8 GIF where each frame is a solid color.
+func makeTestGIF(t *testing.T) []byte {
+	t.Helper()
+	palette := color.Palette{frameColors[0], frameColors[1], frameColors[2]}
+	g := &gif.GIF{}
+	for i := range frameColors {
+		frame := image.NewPaletted(image.Rect(0, 0, 8, 8), palette)
+		for p := range frame.Pix {
+			frame.Pix[p] = uint8(i)
+		}
+		g.Image = append(g.Image, frame)
+		g.Delay = append(g.Delay, 10)
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, g))
+	return buf.Bytes()
+}
+
+func colorAt(img image.Image, x, y int) color.RGBA {
+	r, g, b, a := img.At(x, y).RGBA()
+	return color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}
+}
+
+func TestDecodeImageFrame_GIFSelection(t *testing.T) {
+	data := makeTestGIF(t)
+
+	tests := []struct {
+		name string
+		sel  FrameSelection
+		want color.RGBA
+	}{
+		{name: "default first frame", sel: FrameSelection{}, want: frameColors[0]},
+		{name: "explicit index", sel: FrameSelection{Index: 2}, want: frameColors[2]},
+		{name: "middle frame", sel: FrameSelection{Middle: true}, want: frameColors[1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := decodeImageFrame(data, tt.sel)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, colorAt(img, 4, 4))
+		})
+	}
+}
+
+func TestDecodeImageFrame_SelectionChangesPixels(t *testing.T) {
+	data := makeTestGIF(t)
+
+	first, err := decodeImageFrame(data, FrameSelection{Index: 0})
+	require.NoError(t, err)
+	last, err := decodeImageFrame(data, FrameSelection{Index: 2})
+	require.NoError(t, err)
+
+	// Different frames must feed different pixels into the visual encoder
+	assert.NotEqual(t, colorAt(first, 0, 0), colorAt(last, 0, 0))
+}
+
+func TestDecodeImageFrame_OutOfRange(t *testing.T) {
+	data := makeTestGIF(t)
+
+	_, err := decodeImageFrame(data, FrameSelection{Index: 3})
+	require.ErrorIs(t, err, ErrFrameOutOfRange)
+
+	_, err = decodeImageFrame(data, FrameSelection{Index: -1})
+	require.ErrorIs(t, err, ErrFrameOutOfRange)
+}
+
+func TestDecodeImageFrame_DisposalBackground(t *testing.T) {
+	palette := color.Palette{color.Transparent, frameColors[0], frameColors[1]}
+
+	// Frame 0 fills the canvas, frame 1 only covers the top-left quadrant.
+	full := image.NewPaletted(image.Rect(0, 0, 8, 8), palette)
+	for p := range full.Pix {
+		full.Pix[p] = 1
+	}
+	partial := image.NewPaletted(image.Rect(0, 0, 4, 4), palette)
+	for p := range partial.Pix {
+		partial.Pix[p] = 2
+	}
+
+	g := &gif.GIF{
+		Image:    []*image.Paletted{full, partial},
+		Delay:    []int{10, 10},
+		Disposal: []byte{gif.DisposalBackground, gif.DisposalNone},
+		Config:   image.Config{Width: 8, Height: 8, ColorModel: palette},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, g))
+
+	img, err := decodeImageFrame(buf.Bytes(), FrameSelection{Index: 1})
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 8, 8), img.Bounds())
+	assert.Equal(t, frameColors[1], colorAt(img, 1, 1))
+	// Frame 0 was disposed to background, so the uncovered area is transparent
+	assert.Equal(t, color.RGBA{}, colorAt(img, 6, 6))
+}
+
+func TestDecodeImageFrame_SingleFrameUnaffected(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range src.Pix {
+		src.Pix[i] = 200
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	// Frame selection is ignored for single-frame formats
+	img, err := decodeImageFrame(buf.Bytes(), FrameSelection{Index: 5})
+	require.NoError(t, err)
+	assert.Equal(t, src.Bounds(), img.Bounds())
+}
+
+func TestFrameSelection_Resolve(t *testing.T) {
+	idx, err := FrameSelection{Middle: true}.resolve(5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, idx)
+
+	idx, err = FrameSelection{Middle: true, Index: 4}.resolve(1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, idx)
+
+	_, err = FrameSelection{Index: 1}.resolve(1)
+	assert.ErrorIs(t, err, ErrFrameOutOfRange)
+}
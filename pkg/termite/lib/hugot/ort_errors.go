@@ -0,0 +1,70 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugot
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// ErrONNXRuntimeUnavailable reports that the ONNX Runtime shared library
+// could not be loaded, usually because it is not installed or not on the
+// dynamic linker's search path
+var ErrONNXRuntimeUnavailable = errors.New("ONNX Runtime library could not be loaded")
+
+// libraryLoadErrors are fragments of the dynamic linker errors ONNX Runtime
+// initialization fails with when its shared library cannot be found
+var libraryLoadErrors = []string{
+	"error loading onnx shared library",
+	"cannot open shared object file",
+	"dlopen",
+	"library not loaded",
+	"image not found",
+}
+
+// WrapORTInitError turns a failure to load the ONNX Runtime shared library
+// into an error that says how to install it and which library path variable
+// to set. Other errors, including nil, are returned unchanged.
+func WrapORTInitError(err error) error {
+	return wrapORTInitError(err, runtime.GOOS)
+}
+
+func wrapORTInitError(err error, goos string) error {
+	if err == nil || errors.Is(err, ErrONNXRuntimeUnavailable) || !isLibraryLoadError(err) {
+		return err
+	}
+
+	envVar, libDir, install := "LD_LIBRARY_PATH", "/usr/local/onnxruntime/lib",
+		"download it from https://github.com/microsoft/onnxruntime/releases"
+	if goos == "darwin" {
+		envVar, libDir, install = "DYLD_LIBRARY_PATH", "/opt/homebrew/opt/onnxruntime/lib",
+			"brew install onnxruntime"
+	}
+	return fmt.Errorf("%w: %w; install ONNX Runtime (%s) and set %s to its lib directory "+
+		"before starting termite, e.g. export %s=%s",
+		ErrONNXRuntimeUnavailable, err, install, envVar, envVar, libDir)
+}
+
+func isLibraryLoadError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range libraryLoadErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapORTInitError_LibraryMissing(t *testing.T) {
+	tests := []struct {
+		goos   string
+		cause  string
+		envVar string
+	}{
+		{"linux", `Error loading ONNX shared library "onnxruntime.so": onnxruntime.so: cannot open shared object file: No such file or directory`, "LD_LIBRARY_PATH"},
+		{"darwin", `dlopen(libonnxruntime.dylib, 0x0001): tried: 'libonnxruntime.dylib' (no such file)`, "DYLD_LIBRARY_PATH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			cause := errors.New(tt.cause)
+			err := wrapORTInitError(cause, tt.goos)
+
+			require.ErrorIs(t, err, ErrONNXRuntimeUnavailable)
+			require.ErrorIs(t, err, cause)
+			assert.Contains(t, err.Error(), "export "+tt.envVar+"=")
+			assert.Contains(t, err.Error(), "install ONNX Runtime")
+		})
+	}
+}
+
+func TestWrapORTInitError_OtherErrors(t *testing.T) {
+	assert.NoError(t, WrapORTInitError(nil))
+
+	other := errors.New("invalid model: unsupported opset 21")
+	assert.Equal(t, other, WrapORTInitError(other))
+
+	// Already wrapped errors are not wrapped twice
+	wrapped := wrapORTInitError(errors.New("dlopen failed"), "darwin")
+	assert.Equal(t, wrapped, WrapORTInitError(wrapped))
+}
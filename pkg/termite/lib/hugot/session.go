@@ -33,18 +33,26 @@ import (
 // Without build tags (default): Uses pure Go backend (goMLX) - no CGO required
 // With -tags=onnx: Uses ONNX Runtime backend - requires CGO, faster inference
 //
+// ONNX Runtime allows only one active session per process, so with that backend
+// NewSession returns the process-wide shared session instead of creating a
+// second one. Sessions from NewSession must be released with ReleaseSession
+// rather than destroyed directly.
+//
 // The implementation of this function is provided by either:
 //   - session_go.go (default, pure Go backend)
 //   - session_onnx.go (ONNX Runtime backend, requires -tags=onnx)
 func NewSession(opts ...options.WithOption) (*hugot.Session, error) {
-	return newSessionImpl(opts...)
+	return coordinator.acquire(opts...)
 }
 
-// NewSessionOrUseExisting returns the provided session if non-nil, otherwise creates a new one.
+// NewSessionOrUseExisting returns the provided session if non-nil, otherwise
+// acquires one from NewSession.
 // This is useful when you want to share a single session across multiple models/pipelines.
 //
 // IMPORTANT: With ONNX Runtime backend, only ONE session can be active at a time.
-// Use this function to share a session across chunkers, rerankers, and embedders.
+// Passing nil is safe under ONNX Runtime: concurrent callers all receive the
+// same reference-counted session, which is destroyed once every holder has
+// called ReleaseSession.
 //
 // Example:
 //
@@ -53,17 +61,26 @@ func NewSession(opts ...options.WithOption) (*hugot.Session, error) {
 //	if err != nil {
 //		return err
 //	}
-//	defer sharedSession.Destroy()
+//	defer hugot.ReleaseSession(sharedSession)
 //
 //	// Reuse session for multiple models
 //	session1, _ := hugot.NewSessionOrUseExisting(sharedSession)  // Returns sharedSession
 //	session2, _ := hugot.NewSessionOrUseExisting(sharedSession)  // Returns sharedSession
-//	session3, _ := hugot.NewSessionOrUseExisting(nil)            // Creates new session
+//	session3, _ := hugot.NewSessionOrUseExisting(nil)            // New session (shared under ONNX Runtime)
+//	defer hugot.ReleaseSession(session3)
 func NewSessionOrUseExisting(existingSession *hugot.Session, opts ...options.WithOption) (*hugot.Session, error) {
 	if existingSession != nil {
 		return existingSession, nil
 	}
-	return newSessionImpl(opts...)
+	return coordinator.acquire(opts...)
+}
+
+// ReleaseSession releases a session obtained from NewSession or from
+// NewSessionOrUseExisting(nil). Under ONNX Runtime the shared session is
+// destroyed when its last holder releases it; with other backends the
+// session is destroyed immediately.
+func ReleaseSession(session *hugot.Session) error {
+	return coordinator.release(session)
 }
 
 // BackendName returns a human-readable name of the backend being used.
@@ -0,0 +1,96 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugot
+
+import (
+	"sync"
+
+	"github.com/knights-analytics/hugot"
+	"github.com/knights-analytics/hugot/options"
+)
+
+// sessionCoordinator hands out sessions and tracks who holds them.
+//
+// When single is true (ONNX Runtime), every acquire returns the same
+// process-wide session and increments its reference count; the session is
+// destroyed when the last holder releases it. Otherwise each acquire creates
+// an independent session that is destroyed on release.
+type sessionCoordinator struct {
+	mu     sync.Mutex
+	single bool
+	shared *hugot.Session
+	refs   int
+
+	create  func(opts ...options.WithOption) (*hugot.Session, error)
+	destroy func(*hugot.Session) error
+}
+
+// coordinator is the process-wide session coordinator for the compiled backend.
+var coordinator = &sessionCoordinator{
+	single:  singleSessionBackend,
+	create:  newSessionImpl,
+	destroy: func(s *hugot.Session) error { return s.Destroy() },
+}
+
+// acquire returns a session the caller must hand back via release.
+// With a single-session backend, opts only apply to the first acquire.
+func (c *sessionCoordinator) acquire(opts ...options.WithOption) (*hugot.Session, error) {
+	if !c.single {
+		return c.create(opts...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shared == nil {
+		session, err := c.create(opts...)
+		if err != nil {
+			return nil, err
+		}
+		c.shared = session
+	}
+	c.refs++
+	return c.shared, nil
+}
+
+// release drops one reference to session. The shared session is destroyed
+// once its reference count reaches zero; sessions the coordinator does not
+// track are destroyed immediately.
+func (c *sessionCoordinator) release(session *hugot.Session) error {
+	if session == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	if c.single && session == c.shared {
+		c.refs--
+		if c.refs > 0 {
+			c.mu.Unlock()
+			return nil
+		}
+		c.shared = nil
+		c.refs = 0
+	}
+	c.mu.Unlock()
+
+	return c.destroy(session)
+}
+
+// refCount returns the number of outstanding references to the shared session.
+func (c *sessionCoordinator) refCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refs
+}
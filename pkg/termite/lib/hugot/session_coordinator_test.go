@@ -0,0 +1,115 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugot
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/knights-analytics/hugot"
+	"github.com/knights-analytics/hugot/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withFakeCoordinator swaps the package coordinator for one backed by fake
+// sessions, returning counters for creations and destructions.
+func withFakeCoordinator(t *testing.T, single bool) (created, destroyed *atomic.Int32) {
+	t.Helper()
+	created, destroyed = &atomic.Int32{}, &atomic.Int32{}
+
+	original := coordinator
+	coordinator = &sessionCoordinator{
+		single: single,
+		create: func(opts ...options.WithOption) (*hugot.Session, error) {
+			created.Add(1)
+			return &hugot.Session{}, nil
+		},
+		destroy: func(*hugot.Session) error {
+			destroyed.Add(1)
+			return nil
+		},
+	}
+	t.Cleanup(func() { coordinator = original })
+	return created, destroyed
+}
+
+func TestNewSessionOrUseExisting_ConcurrentNilSharesSession(t *testing.T) {
+	created, destroyed := withFakeCoordinator(t, true)
+
+	const goroutines = 64
+	sessions := make([]*hugot.Session, goroutines)
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Go(func() {
+			session, err := NewSessionOrUseExisting(nil)
+			assert.NoError(t, err)
+			sessions[i] = session
+		})
+	}
+	wg.Wait()
+
+	require.NotNil(t, sessions[0])
+	for i, s := range sessions {
+		assert.Same(t, sessions[0], s, "goroutine %d received a different session", i)
+	}
+	assert.Equal(t, int32(1), created.Load())
+	assert.Equal(t, goroutines, coordinator.refCount())
+
+	// The shared session survives until the last holder releases it
+	for _, s := range sessions[1:] {
+		require.NoError(t, ReleaseSession(s))
+	}
+	assert.Equal(t, int32(0), destroyed.Load())
+
+	require.NoError(t, ReleaseSession(sessions[0]))
+	assert.Equal(t, int32(1), destroyed.Load())
+	assert.Equal(t, 0, coordinator.refCount())
+
+	// A new session is created after the previous one was destroyed
+	next, err := NewSession()
+	require.NoError(t, err)
+	assert.NotSame(t, sessions[0], next)
+	assert.Equal(t, int32(2), created.Load())
+}
+
+func TestNewSessionOrUseExisting_ReturnsExisting(t *testing.T) {
+	created, _ := withFakeCoordinator(t, true)
+
+	existing := &hugot.Session{}
+	session, err := NewSessionOrUseExisting(existing)
+	require.NoError(t, err)
+	assert.Same(t, existing, session)
+	assert.Equal(t, int32(0), created.Load())
+	assert.Equal(t, 0, coordinator.refCount())
+}
+
+func TestSessionCoordinator_MultiSessionBackend(t *testing.T) {
+	created, destroyed := withFakeCoordinator(t, false)
+
+	a, err := NewSessionOrUseExisting(nil)
+	require.NoError(t, err)
+	b, err := NewSessionOrUseExisting(nil)
+	require.NoError(t, err)
+
+	assert.NotSame(t, a, b)
+	assert.Equal(t, int32(2), created.Load())
+
+	require.NoError(t, ReleaseSession(a))
+	assert.Equal(t, int32(1), destroyed.Load())
+	require.NoError(t, ReleaseSession(b))
+	assert.Equal(t, int32(2), destroyed.Load())
+}
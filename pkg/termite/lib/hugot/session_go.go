@@ -36,6 +36,10 @@ func newSessionImpl(opts ...options.WithOption) (*hugot.Session, error) {
 	return hugot.NewGoSession(opts...)
 }
 
+// singleSessionBackend reports whether the backend requires all models to share
+// one session. The pure Go backend supports any number of independent sessions.
+const singleSessionBackend = false
+
 // backendNameImpl returns the name of the pure Go backend.
 func backendNameImpl() string {
 	return "goMLX (Pure Go)"
@@ -103,9 +103,17 @@ func newSessionImpl(opts ...options.WithOption) (*hugot.Session, error) {
 		cudaOpts := []options.WithOption{options.WithCuda(nil)}
 		opts = append(cudaOpts, opts...)
 	}
-	return hugot.NewORTSession(opts...)
+	session, err := hugot.NewORTSession(opts...)
+	if err != nil {
+		return nil, WrapORTInitError(err)
+	}
+	return session, nil
 }
 
+// singleSessionBackend reports whether the backend requires all models to share
+// one session. ONNX Runtime allows only one active session per process.
+const singleSessionBackend = true
+
 // backendNameImpl returns the name of the ONNX Runtime backend.
 func backendNameImpl() string {
 	if useCUDA() {
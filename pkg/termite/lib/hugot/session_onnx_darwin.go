@@ -48,9 +48,17 @@ func newSessionImpl(opts ...options.WithOption) (*hugot.Session, error) {
 	// Prepend CoreML provider - user options can override if needed
 	coremlOpts := []options.WithOption{options.WithCoreML(nil)}
 	opts = append(coremlOpts, opts...)
-	return hugot.NewORTSession(opts...)
+	session, err := hugot.NewORTSession(opts...)
+	if err != nil {
+		return nil, WrapORTInitError(err)
+	}
+	return session, nil
 }
 
+// singleSessionBackend reports whether the backend requires all models to share
+// one session. ONNX Runtime allows only one active session per process.
+const singleSessionBackend = true
+
 // backendNameImpl returns the name of the ONNX Runtime backend with CoreML.
 func backendNameImpl() string {
 	return "ONNX Runtime (CoreML)"
@@ -114,6 +114,10 @@ func newSessionImpl(opts ...options.WithOption) (*hugot.Session, error) {
 	return hugot.NewXLASession(opts...)
 }
 
+// singleSessionBackend reports whether the backend requires all models to share
+// one session. The XLA backend supports any number of independent sessions.
+const singleSessionBackend = false
+
 // backendNameImpl returns a human-readable name of the XLA backend.
 func backendNameImpl() string {
 	device := getXLADevice()
@@ -47,6 +47,7 @@ type Client struct {
 	downloadClient  *http.Client
 	logger          *zap.Logger
 	progressHandler ProgressHandler
+	store           *Store
 }
 
 // ProgressHandler is called to report download progress
@@ -76,6 +77,14 @@ func WithProgressHandler(h ProgressHandler) ClientOption {
 	}
 }
 
+// WithStoreDir enables the content-addressed store at dir: files are
+// downloaded once into the store and linked into model directories
+func WithStoreDir(dir string) ClientOption {
+	return func(c *Client) {
+		c.store = NewStore(dir)
+	}
+}
+
 // WithTimeout sets the HTTP timeout for metadata requests
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
@@ -171,9 +180,9 @@ func (c *Client) PullModel(ctx context.Context, manifest *ModelManifest, modelsD
 	// Determine output directory based on model type
 	modelDir := filepath.Join(modelsDir, manifest.Type.DirName(), manifest.Name)
 
-	// Default to f32 if no variants specified
+	// Default to the model type's preferred variant if none are specified
 	if len(variants) == 0 {
-		variants = []string{VariantF32}
+		variants = []string{manifest.DefaultVariant()}
 	}
 
 	c.logger.Info("Pulling model",
@@ -250,6 +259,20 @@ func (c *Client) downloadFile(ctx context.Context, file ModelFile, destDir strin
 		return nil
 	}
 
+	// Reuse a file already in the shared store
+	if c.store != nil && c.store.Has(file.Digest) {
+		c.logger.Debug("File found in store, linking",
+			zap.String("file", file.Name),
+			zap.String("digest", file.Digest))
+		if err := c.store.Link(file.Digest, destPath); err != nil {
+			return err
+		}
+		if c.progressHandler != nil {
+			c.progressHandler(file.Size, file.Size, file.Name)
+		}
+		return nil
+	}
+
 	// Construct blob URL from digest
 	url := fmt.Sprintf("%s/blobs/%s", c.baseURL, file.Digest)
 	c.logger.Debug("Downloading file",
@@ -272,12 +295,18 @@ func (c *Client) downloadFile(ctx context.Context, file ModelFile, destDir strin
 		return fmt.Errorf("download returned status %d", resp.StatusCode)
 	}
 
-	// Create temp file for download
-	tmpPath := destPath + ".tmp"
-	tmpFile, err := os.Create(tmpPath)
+	// Create temp file for download (staged in the store when enabled, so
+	// the verified file can be renamed into it)
+	var tmpFile *os.File
+	if c.store != nil {
+		tmpFile, err = c.store.TempFile()
+	} else {
+		tmpFile, err = os.Create(destPath + ".tmp")
+	}
 	if err != nil {
 		return fmt.Errorf("creating temp file: %w", err)
 	}
+	tmpPath := tmpFile.Name()
 	defer func() {
 		_ = tmpFile.Close()
 		_ = os.Remove(tmpPath) // Clean up on error
@@ -319,9 +348,19 @@ func (c *Client) downloadFile(ctx context.Context, file ModelFile, destDir strin
 		return fmt.Errorf("closing temp file: %w", err)
 	}
 
-	// Rename to final destination
-	if err := os.Rename(tmpPath, destPath); err != nil {
-		return fmt.Errorf("renaming file: %w", err)
+	if c.store == nil {
+		// Rename to final destination
+		if err := os.Rename(tmpPath, destPath); err != nil {
+			return fmt.Errorf("renaming file: %w", err)
+		}
+	} else {
+		// Move into the store and link from the model directory
+		if err := c.store.Commit(tmpPath, file.Digest); err != nil {
+			return err
+		}
+		if err := c.store.Link(file.Digest, destPath); err != nil {
+			return err
+		}
 	}
 
 	c.logger.Debug("File downloaded successfully",
@@ -196,6 +196,77 @@ func TestClientSkipsExistingFile(t *testing.T) {
 	}
 }
 
+func TestClientPullModelSharedStore(t *testing.T) {
+	digestOf := func(content []byte) string {
+		sum := sha256.Sum256(content)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	blobs := map[string][]byte{}
+	for _, content := range [][]byte{[]byte("shared model"), []byte("tokenizer a"), []byte("tokenizer b")} {
+		blobs[digestOf(content)] = content
+	}
+
+	downloads := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		digest := filepath.Base(r.URL.Path)
+		content, ok := blobs[digest]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		downloads[digest]++
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	storeDir := t.TempDir()
+	client := NewClient(
+		WithBaseURL(server.URL+"/v1"),
+		WithLogger(zap.NewNop()),
+		WithStoreDir(storeDir),
+	)
+
+	manifest := func(name, tokenizer string) *ModelManifest {
+		return &ModelManifest{
+			SchemaVersion: 1,
+			Name:          name,
+			Type:          ModelTypeEmbedder,
+			Files: []ModelFile{
+				{Name: "model.onnx", Digest: digestOf([]byte("shared model")), Size: 12},
+				{Name: "tokenizer.json", Digest: digestOf([]byte(tokenizer)), Size: int64(len(tokenizer))},
+			},
+		}
+	}
+
+	// Pull overlapping models into separate models directories, as two pools would
+	dirA, dirB := t.TempDir(), t.TempDir()
+	if err := client.PullModel(context.Background(), manifest("model-a", "tokenizer a"), dirA, nil); err != nil {
+		t.Fatalf("PullModel(a) error = %v", err)
+	}
+	if err := client.PullModel(context.Background(), manifest("model-b", "tokenizer b"), dirB, nil); err != nil {
+		t.Fatalf("PullModel(b) error = %v", err)
+	}
+
+	if n := downloads[digestOf([]byte("shared model"))]; n != 1 {
+		t.Errorf("shared file downloaded %d times, want 1", n)
+	}
+	if n := downloads[digestOf([]byte("tokenizer b"))]; n != 1 {
+		t.Errorf("new file downloaded %d times, want 1", n)
+	}
+
+	pathA := filepath.Join(dirA, "embedders", "model-a", "model.onnx")
+	pathB := filepath.Join(dirB, "embedders", "model-b", "model.onnx")
+	content, err := os.ReadFile(pathB)
+	if err != nil || string(content) != "shared model" {
+		t.Fatalf("model-b model.onnx = %q, %v", content, err)
+	}
+	infoA, _ := os.Stat(pathA)
+	infoB, _ := os.Stat(pathB)
+	if !os.SameFile(infoA, infoB) {
+		t.Error("model files are not shared through the store")
+	}
+}
+
 func TestClientHashMismatch(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("wrong content"))
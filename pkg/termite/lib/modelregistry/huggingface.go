@@ -16,8 +16,11 @@ package modelregistry
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -26,10 +29,17 @@ import (
 	"github.com/gomlx/go-huggingface/hub"
 )
 
+// DefaultHuggingFaceEndpoint is the HuggingFace Hub API endpoint
+const DefaultHuggingFaceEndpoint = "https://huggingface.co"
+
 // HuggingFaceClient pulls ONNX models from HuggingFace Hub
 type HuggingFaceClient struct {
 	token           string
+	endpoint        string
+	httpClient      *http.Client
 	progressHandler ProgressHandler
+	store           *Store
+	validate        bool
 }
 
 // HFClientOption configures the HuggingFace client
@@ -37,7 +47,12 @@ type HFClientOption func(*HuggingFaceClient)
 
 // NewHuggingFaceClient creates a new HuggingFace client
 func NewHuggingFaceClient(opts ...HFClientOption) *HuggingFaceClient {
-	c := &HuggingFaceClient{}
+	c := &HuggingFaceClient{
+		endpoint: DefaultHuggingFaceEndpoint,
+		httpClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -49,13 +64,31 @@ func WithHFToken(token string) HFClientOption {
 	return func(c *HuggingFaceClient) { c.token = token }
 }
 
+// WithHFEndpoint sets the HuggingFace Hub API endpoint used for metadata requests
+func WithHFEndpoint(url string) HFClientOption {
+	return func(c *HuggingFaceClient) { c.endpoint = strings.TrimSuffix(url, "/") }
+}
+
+// WithHFStoreDir enables the content-addressed store at dir (see WithStoreDir)
+func WithHFStoreDir(dir string) HFClientOption {
+	return func(c *HuggingFaceClient) { c.store = NewStore(dir) }
+}
+
+// WithHFValidation checks each downloaded ONNX file with ValidateONNXModel,
+// so a repo with unexpected inputs or outputs fails at pull time rather than
+// at first inference. Requires the ONNX Runtime backend.
+func WithHFValidation() HFClientOption {
+	return func(c *HuggingFaceClient) { c.validate = true }
+}
+
 // WithHFProgressHandler sets the progress handler for downloads
 func WithHFProgressHandler(h ProgressHandler) HFClientOption {
 	return func(c *HuggingFaceClient) { c.progressHandler = h }
 }
 
 // PullFromHuggingFace downloads ONNX model files from a HuggingFace repo.
-// variant can be: "", "fp16", "q4", "q4f16", "quantized"
+// variant can be: "", "fp32", "fp16", "q4", "q4f16", "quantized", where ""
+// selects the model type's default when available
 func (c *HuggingFaceClient) PullFromHuggingFace(
 	ctx context.Context,
 	repoID string,
@@ -63,63 +96,148 @@ func (c *HuggingFaceClient) PullFromHuggingFace(
 	destDir string,
 	variant string,
 ) error {
-	repo := hub.New(repoID)
-	if c.token != "" {
-		repo = repo.WithAuth(c.token)
-	}
+	src := c.source(repoID)
+	defer func() { _ = src.Close() }()
+	return PullFromSource(ctx, src, modelType, destDir, variant, SourcePullOptions{
+		Store:           c.store,
+		Validate:        c.validate,
+		ProgressHandler: c.progressHandler,
+	})
+}
 
-	// List all files in repo
-	var files []string
-	for fileName, err := range repo.IterFileNames() {
-		if err != nil {
-			return fmt.Errorf("listing files: %w", err)
-		}
-		files = append(files, fileName)
+// PlanHuggingFacePull resolves the files PullFromHuggingFace would download
+// for variant from the repo tree, without downloading anything
+func (c *HuggingFaceClient) PlanHuggingFacePull(
+	ctx context.Context,
+	repoID string,
+	modelType ModelType,
+	destDir string,
+	variant string,
+) (*PullPlan, error) {
+	sizes, err := c.RepoFileSizes(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("listing files: %w", err)
+	}
+	files := make([]string, 0, len(sizes))
+	for f := range sizes {
+		files = append(files, f)
 	}
+	slices.Sort(files)
+	return planPull(filepath.Base(repoID), files, sizes, modelType, destDir, variant)
+}
 
-	// Filter and select files to download
-	toDownload := selectONNXFiles(files, variant)
-	if len(toDownload) == 0 {
-		return fmt.Errorf("no ONNX files found in %s", repoID)
+// Source returns the HuggingFace repo as a Source
+func (c *HuggingFaceClient) Source(repoID string) Source {
+	return c.source(repoID)
+}
+
+func (c *HuggingFaceClient) source(repoID string) *huggingFaceSource {
+	return &huggingFaceSource{
+		client: c,
+		repoID: repoID,
+		// Downloads are bounded by their context rather than the metadata
+		// request timeout
+		downloadClient: &http.Client{Transport: c.httpClient.Transport},
 	}
+}
+
+// huggingFaceSource downloads files from a HuggingFace repo into a temporary
+// directory, removed by Close
+type huggingFaceSource struct {
+	client         *HuggingFaceClient
+	repoID         string
+	downloadClient *http.Client
+	tmpDir         string
+}
+
+func (s *huggingFaceSource) Name() string {
+	return filepath.Base(s.repoID)
+}
 
-	// Create destination directory
-	modelName := filepath.Base(repoID)
-	modelDir := filepath.Join(destDir, modelType.DirName(), modelName)
-	if err := os.MkdirAll(modelDir, 0755); err != nil {
-		return fmt.Errorf("creating directory: %w", err)
+func (s *huggingFaceSource) ListFiles(ctx context.Context) ([]string, error) {
+	tree, err := s.client.repoTree(ctx, s.repoID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(tree))
+	for _, e := range tree {
+		files = append(files, e.Path)
 	}
+	return files, nil
+}
 
-	// Download each file
-	for _, fileName := range toDownload {
-		localPath, err := repo.DownloadFile(fileName)
+// Fetch downloads file from the repo's main revision. The download stops
+// when ctx is done, leaving no partial file behind.
+func (s *huggingFaceSource) Fetch(ctx context.Context, file string) (string, error) {
+	if s.tmpDir == "" {
+		dir, err := os.MkdirTemp("", "termite-pull-*")
 		if err != nil {
-			return fmt.Errorf("downloading %s: %w", fileName, err)
+			return "", fmt.Errorf("creating temp directory: %w", err)
 		}
+		s.tmpDir = dir
+	}
 
-		// Flatten path (e.g., "onnx/model.onnx" -> "model.onnx")
-		destName := filepath.Base(fileName)
-		destPath := filepath.Join(modelDir, destName)
+	segments := strings.Split(file, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	fileURL := fmt.Sprintf("%s/%s/resolve/main/%s", s.client.endpoint, s.repoID, strings.Join(segments, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	if s.client.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.client.token)
+	}
+	resp, err := s.downloadClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", file, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: huggingface returned status %d", file, resp.StatusCode)
+	}
 
-		// Report progress before copy
-		if c.progressHandler != nil {
-			c.progressHandler(0, 0, destName)
-		}
+	localPath := filepath.Join(s.tmpDir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return "", fmt.Errorf("creating temp directory: %w", err)
+	}
+	f, err := os.Create(localPath)
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(localPath)
+		return "", fmt.Errorf("downloading %s: %w", file, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("closing temp file: %w", err)
+	}
+	return localPath, nil
+}
 
-		// Copy from cache to destination
-		if err := copyFile(localPath, destPath); err != nil {
-			return fmt.Errorf("copying %s: %w", fileName, err)
-		}
+// Close removes downloaded files
+func (s *huggingFaceSource) Close() error {
+	if s.tmpDir == "" {
+		return nil
+	}
+	return os.RemoveAll(s.tmpDir)
+}
 
-		// Report completion
-		if c.progressHandler != nil {
-			if info, err := os.Stat(destPath); err == nil {
-				c.progressHandler(info.Size(), info.Size(), destName)
-			}
+// Digests returns the SHA-256 digests of LFS files from the repo tree
+func (s *huggingFaceSource) Digests(ctx context.Context) (map[string]string, error) {
+	tree, err := s.client.repoTree(ctx, s.repoID)
+	if err != nil {
+		return nil, err
+	}
+	digests := make(map[string]string, len(tree))
+	for _, e := range tree {
+		if e.LFS != nil && e.LFS.Oid != "" {
+			digests[e.Path] = "sha256:" + e.LFS.Oid
 		}
 	}
-
-	return nil
+	return digests, nil
 }
 
 // selectONNXFiles filters files based on variant preference.
@@ -165,8 +283,9 @@ func selectONNXFiles(files []string, variant string) []string {
 	return result
 }
 
-// copyFile copies a file from src to dst
-func copyFile(src, dst string) error {
+// copyFile copies a file from src to dst, stopping when ctx is done. A
+// partially written dst is removed.
+func copyFile(ctx context.Context, src, dst string) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return fmt.Errorf("opening source: %w", err)
@@ -178,17 +297,31 @@ func copyFile(src, dst string) error {
 		return fmt.Errorf("creating destination: %w", err)
 	}
 
-	if _, err := io.Copy(dstFile, srcFile); err != nil {
+	if _, err := io.Copy(dstFile, &contextReader{ctx: ctx, r: srcFile}); err != nil {
 		_ = dstFile.Close()
+		_ = os.Remove(dst)
 		return fmt.Errorf("copying: %w", err)
 	}
 
 	return dstFile.Close()
 }
 
+// contextReader fails reads once ctx is done, so long copies stop promptly
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
 // ValidVariants returns the list of valid ONNX variant names
 func ValidVariants() []string {
-	return []string{"", "fp16", "q4", "q4f16", "quantized"}
+	return []string{"", "fp32", "fp16", "q4", "q4f16", "quantized"}
 }
 
 // IsValidVariant checks if a variant name is valid
@@ -199,8 +332,8 @@ func IsValidVariant(variant string) bool {
 // VariantDescription returns a human-readable description of a variant
 func VariantDescription(variant string) string {
 	switch variant {
-	case "":
-		return "full precision (default)"
+	case "", "fp32":
+		return "full precision"
 	case "fp16":
 		return "half precision (FP16)"
 	case "q4":
@@ -231,6 +364,99 @@ func (c *HuggingFaceClient) ListRepoFiles(ctx context.Context, repoID string) ([
 	return files, nil
 }
 
+// RepoFileSizes returns the size in bytes of each file in a HuggingFace repo,
+// keyed by path within the repo
+func (c *HuggingFaceClient) RepoFileSizes(ctx context.Context, repoID string) (map[string]int64, error) {
+	tree, err := c.repoTree(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64, len(tree))
+	for _, e := range tree {
+		sizes[e.Path] = e.Size
+	}
+	return sizes, nil
+}
+
+// hfTreeEntry is a file in the HuggingFace Hub tree API response
+type hfTreeEntry struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	LFS  *struct {
+		Oid string `json:"oid"` // SHA-256 of the file content
+	} `json:"lfs,omitempty"`
+}
+
+// repoTree lists the files of a HuggingFace repo via the tree API
+func (c *HuggingFaceClient) repoTree(ctx context.Context, repoID string) ([]hfTreeEntry, error) {
+	var files []hfTreeEntry
+	url := fmt.Sprintf("%s/api/models/%s/tree/main?recursive=true", c.endpoint, repoID)
+	for url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching file tree: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("huggingface returned status %d", resp.StatusCode)
+		}
+
+		var entries []hfTreeEntry
+		err = json.NewDecoder(resp.Body).Decode(&entries)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding file tree: %w", err)
+		}
+
+		for _, e := range entries {
+			if e.Type == "file" {
+				files = append(files, e)
+			}
+		}
+
+		// Large repos are paginated via the Link header
+		url = nextPageURL(resp.Header.Get("Link"))
+	}
+	return files, nil
+}
+
+// nextPageURL extracts the rel="next" target from a Link header
+func nextPageURL(link string) string {
+	for part := range strings.SplitSeq(link, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if ok && strings.Contains(params, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>")
+		}
+	}
+	return ""
+}
+
+// VariantDownloadSize returns the total size of the files PullFromHuggingFace
+// downloads for variant, given the repo's file sizes
+func VariantDownloadSize(sizes map[string]int64, variant string) int64 {
+	files := make([]string, 0, len(sizes))
+	for f := range sizes {
+		files = append(files, f)
+	}
+	slices.Sort(files)
+
+	var total int64
+	for _, f := range selectONNXFiles(files, variant) {
+		total += sizes[f]
+	}
+	return total
+}
+
 // DetectAvailableVariants returns which ONNX variants are available in a repo
 func (c *HuggingFaceClient) DetectAvailableVariants(ctx context.Context, repoID string) ([]string, error) {
 	files, err := c.ListRepoFiles(ctx, repoID)
@@ -0,0 +1,144 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHuggingFaceRepoFileSizes(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/models/owner/repo/tree/main" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want Bearer secret", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `<`+server.URL+`/api/models/owner/repo/tree/main?recursive=true&cursor=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[
+				{"type": "directory", "path": "onnx", "size": 0},
+				{"type": "file", "path": "tokenizer.json", "size": 100},
+				{"type": "file", "path": "onnx/model.onnx", "size": 4000}
+			]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"type": "file", "path": "onnx/model_fp16.onnx", "size": 2000}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewHuggingFaceClient(WithHFToken("secret"), WithHFEndpoint(server.URL))
+
+	sizes, err := client.RepoFileSizes(context.Background(), "owner/repo")
+	if err != nil {
+		t.Fatalf("RepoFileSizes() error = %v", err)
+	}
+	if len(sizes) != 3 {
+		t.Fatalf("len(sizes) = %d, want 3: %v", len(sizes), sizes)
+	}
+
+	if got := VariantDownloadSize(sizes, ""); got != 4100 {
+		t.Errorf("VariantDownloadSize(default) = %d, want 4100", got)
+	}
+	if got := VariantDownloadSize(sizes, "fp16"); got != 2100 {
+		t.Errorf("VariantDownloadSize(fp16) = %d, want 2100", got)
+	}
+}
+
+func TestHuggingFaceSourceFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want Bearer secret", got)
+		}
+		if r.URL.Path != "/owner/repo/resolve/main/onnx/model.onnx" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("onnx"))
+	}))
+	defer server.Close()
+
+	src := NewHuggingFaceClient(WithHFToken("secret"), WithHFEndpoint(server.URL)).source("owner/repo")
+	path, err := src.Fetch(context.Background(), "onnx/model.onnx")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "onnx" {
+		t.Errorf("fetched %q, %v; want onnx", data, err)
+	}
+	if _, err := src.Fetch(context.Background(), "missing.json"); err == nil {
+		t.Error("Fetch() of a missing file succeeded")
+	}
+
+	if err := src.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("downloaded file still present after Close: %v", err)
+	}
+}
+
+func TestHuggingFaceSourceFetch_Canceled(t *testing.T) {
+	aborted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000000")
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done() // The client stops the download
+		close(aborted)
+	}))
+	defer server.Close()
+
+	src := NewHuggingFaceClient(WithHFEndpoint(server.URL)).source("owner/repo")
+	defer func() { _ = src.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := src.Fetch(ctx, "model.onnx")
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Fetch() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Fetch() did not return after cancellation")
+	}
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("download kept running after cancellation")
+	}
+	if files, _ := filepath.Glob(filepath.Join(src.tmpDir, "*")); len(files) != 0 {
+		t.Errorf("partial files left behind: %v", files)
+	}
+}
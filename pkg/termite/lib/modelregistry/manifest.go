@@ -64,6 +64,26 @@ func (t ModelType) DirName() string {
 	}
 }
 
+// DefaultVariant returns the registry variant pulled for this model type when
+// none is requested. Embedders default to INT8, which is much smaller at
+// little cost in retrieval quality; chunkers and rerankers make finer
+// per-token and pairwise distinctions and keep full precision.
+func (t ModelType) DefaultVariant() string {
+	if t == ModelTypeEmbedder {
+		return VariantI8
+	}
+	return VariantF32
+}
+
+// DefaultONNXVariant is DefaultVariant for HuggingFace-style ONNX variant
+// names (see ValidVariants)
+func (t ModelType) DefaultONNXVariant() string {
+	if t == ModelTypeEmbedder {
+		return "quantized"
+	}
+	return ""
+}
+
 // ModelFile represents a single file in the model manifest
 type ModelFile struct {
 	// Name is the filename (e.g., "model.onnx", "tokenizer.json")
@@ -183,6 +203,17 @@ func (m *ModelManifest) Validate() error {
 	return nil
 }
 
+// DefaultVariant returns the variant pulled when none is requested: the
+// model type's default if the manifest has it, full precision otherwise
+func (m *ModelManifest) DefaultVariant() string {
+	if v := m.Type.DefaultVariant(); v != VariantF32 {
+		if _, ok := m.Variants[v]; ok {
+			return v
+		}
+	}
+	return VariantF32
+}
+
 // ParseManifest parses a JSON manifest
 func ParseManifest(data []byte) (*ModelManifest, error) {
 	var manifest ModelManifest
@@ -68,6 +68,50 @@ func TestModelTypeDirName(t *testing.T) {
 	}
 }
 
+func TestModelTypeDefaultVariant(t *testing.T) {
+	tests := []struct {
+		modelType   ModelType
+		variant     string
+		onnxVariant string
+	}{
+		{ModelTypeEmbedder, VariantI8, "quantized"},
+		{ModelTypeChunker, VariantF32, ""},
+		{ModelTypeReranker, VariantF32, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.modelType), func(t *testing.T) {
+			if got := tt.modelType.DefaultVariant(); got != tt.variant {
+				t.Errorf("DefaultVariant() = %q, want %q", got, tt.variant)
+			}
+			if got := tt.modelType.DefaultONNXVariant(); got != tt.onnxVariant {
+				t.Errorf("DefaultONNXVariant() = %q, want %q", got, tt.onnxVariant)
+			}
+		})
+	}
+}
+
+func TestModelManifestDefaultVariant(t *testing.T) {
+	i8 := map[string]ModelFile{VariantI8: {Name: "model_i8.onnx"}}
+	tests := []struct {
+		name     string
+		manifest ModelManifest
+		expected string
+	}{
+		{"embedder with i8", ModelManifest{Type: ModelTypeEmbedder, Variants: i8}, VariantI8},
+		{"embedder without i8", ModelManifest{Type: ModelTypeEmbedder}, VariantF32},
+		{"reranker with i8", ModelManifest{Type: ModelTypeReranker, Variants: i8}, VariantF32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.manifest.DefaultVariant(); got != tt.expected {
+				t.Errorf("DefaultVariant() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseManifest(t *testing.T) {
 	validManifest := `{
 		"schemaVersion": 1,
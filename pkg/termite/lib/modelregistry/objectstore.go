@@ -0,0 +1,238 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ObjectInfo describes an object listed from an object store
+type ObjectInfo struct {
+	Key  string
+	Size int64
+}
+
+// ObjectStore lists and reads objects in a single bucket
+type ObjectStore interface {
+	// List returns all objects whose keys start with prefix
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+
+	// Open returns the content of an object and, when the store can vouch
+	// for it, the hex MD5 of that content (empty otherwise)
+	Open(ctx context.Context, key string) (body io.ReadCloser, md5Hex string, err error)
+}
+
+// ObjectStoreSource is a model staged under a prefix in S3 or GCS. Files are
+// downloaded to a temporary directory that Close removes.
+type ObjectStoreSource struct {
+	store           ObjectStore
+	prefix          string // Without trailing slash
+	progressHandler ProgressHandler
+
+	objects map[string]ObjectInfo // Relative path -> object, from ListFiles
+	tmpDir  string
+}
+
+// ObjectStoreOption configures an ObjectStoreSource
+type ObjectStoreOption func(*ObjectStoreSource)
+
+// WithObjectStoreProgressHandler reports download progress for each object
+func WithObjectStoreProgressHandler(h ProgressHandler) ObjectStoreOption {
+	return func(s *ObjectStoreSource) { s.progressHandler = h }
+}
+
+// NewObjectStoreSource returns a source for the model stored under prefix
+func NewObjectStoreSource(store ObjectStore, prefix string, opts ...ObjectStoreOption) *ObjectStoreSource {
+	s := &ObjectStoreSource{
+		store:  store,
+		prefix: strings.Trim(prefix, "/"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// OpenObjectStoreSource returns a source for an "s3://bucket/prefix" or
+// "gs://bucket/prefix" reference, with credentials from the provider's
+// default chain:
+//   - S3: environment variables, the shared credentials file (AWS_PROFILE),
+//     then IAM (IRSA, container credentials or the EC2 instance profile).
+//     AWS_REGION and AWS_ENDPOINT_URL_S3 (or AWS_ENDPOINT_URL) select the
+//     region and S3-compatible stores.
+//   - GCS: Application Default Credentials, including GKE Workload Identity,
+//     and STORAGE_EMULATOR_HOST for emulators.
+//
+// Requests are anonymous when no credentials are found, for public buckets.
+func OpenObjectStoreSource(ctx context.Context, ref string, opts ...ObjectStoreOption) (*ObjectStoreSource, error) {
+	scheme, bucket, prefix, ok := ParseObjectStoreRef(ref)
+	if !ok {
+		return nil, fmt.Errorf("invalid object storage reference %q: expected s3://bucket/prefix or gs://bucket/prefix", ref)
+	}
+
+	var store ObjectStore
+	var err error
+	switch scheme {
+	case "s3":
+		store, err = newS3Store(bucket, s3ConfigFromEnv(), nil)
+	case "gs":
+		store, err = newGCSStoreFromEnv(ctx, bucket)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectStoreSource(store, prefix, opts...), nil
+}
+
+// ParseObjectStoreRef parses "s3://bucket/prefix" or "gs://bucket/prefix"
+func ParseObjectStoreRef(ref string) (scheme, bucket, prefix string, ok bool) {
+	for _, s := range []string{"s3", "gs"} {
+		if rest, found := strings.CutPrefix(ref, s+"://"); found {
+			bucket, prefix, _ = strings.Cut(rest, "/")
+			if bucket == "" {
+				return "", "", "", false
+			}
+			return s, bucket, strings.Trim(prefix, "/"), true
+		}
+	}
+	return "", "", "", false
+}
+
+// Name returns the last element of the prefix, or the bucket-relative root
+func (s *ObjectStoreSource) Name() string {
+	if s.prefix == "" {
+		return "model"
+	}
+	return path.Base(s.prefix)
+}
+
+// ListFiles lists the objects under the prefix
+func (s *ObjectStoreSource) ListFiles(ctx context.Context) ([]string, error) {
+	listPrefix := s.prefix
+	if listPrefix != "" {
+		listPrefix += "/"
+	}
+	objects, err := s.store.List(ctx, listPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	s.objects = make(map[string]ObjectInfo, len(objects))
+	files := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		rel := strings.TrimPrefix(obj.Key, listPrefix)
+		// Skip directory placeholder objects
+		if rel == "" || strings.HasSuffix(rel, "/") {
+			continue
+		}
+		s.objects[rel] = obj
+		files = append(files, rel)
+	}
+	return files, nil
+}
+
+// FileSizes returns the sizes of the objects under the prefix
+func (s *ObjectStoreSource) FileSizes(ctx context.Context) (map[string]int64, error) {
+	if s.objects == nil {
+		if _, err := s.ListFiles(ctx); err != nil {
+			return nil, err
+		}
+	}
+	sizes := make(map[string]int64, len(s.objects))
+	for rel, obj := range s.objects {
+		sizes[rel] = obj.Size
+	}
+	return sizes, nil
+}
+
+// Fetch downloads an object to the temporary directory, verifying its size
+// and, where the store reports one, its MD5 checksum
+func (s *ObjectStoreSource) Fetch(ctx context.Context, file string) (string, error) {
+	obj, ok := s.objects[file]
+	if !ok {
+		return "", fmt.Errorf("%s not found under %s", file, s.prefix)
+	}
+
+	if s.tmpDir == "" {
+		dir, err := os.MkdirTemp("", "termite-pull-*")
+		if err != nil {
+			return "", fmt.Errorf("creating temp directory: %w", err)
+		}
+		s.tmpDir = dir
+	}
+
+	body, md5Hex, err := s.store.Open(ctx, obj.Key)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = body.Close() }()
+
+	localPath := filepath.Join(s.tmpDir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return "", fmt.Errorf("creating temp directory: %w", err)
+	}
+	f, err := os.Create(localPath)
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := body
+	if s.progressHandler != nil {
+		reader = &progressReader{
+			reader:   body,
+			total:    obj.Size,
+			filename: path.Base(file),
+			handler:  s.progressHandler,
+		}
+	}
+
+	hasher := md5.New()
+	downloaded, err := io.Copy(io.MultiWriter(f, hasher), reader)
+	if err != nil {
+		return "", fmt.Errorf("writing file: %w", err)
+	}
+	if downloaded != obj.Size {
+		return "", fmt.Errorf("size mismatch: expected %d, got %d", obj.Size, downloaded)
+	}
+	if actual := hex.EncodeToString(hasher.Sum(nil)); md5Hex != "" && actual != md5Hex {
+		return "", fmt.Errorf("checksum mismatch: expected md5 %s, got %s", md5Hex, actual)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("closing temp file: %w", err)
+	}
+	return localPath, nil
+}
+
+// Close removes downloaded files and releases the store's client
+func (s *ObjectStoreSource) Close() error {
+	var errs []error
+	if closer, ok := s.store.(io.Closer); ok {
+		errs = append(errs, closer.Close())
+	}
+	if s.tmpDir != "" {
+		errs = append(errs, os.RemoveAll(s.tmpDir))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// gcsStore reads objects with the Cloud Storage client
+type gcsStore struct {
+	client *storage.Client
+	bucket *storage.BucketHandle
+	name   string
+}
+
+// newGCSStoreFromEnv authenticates with Application Default Credentials
+// (GOOGLE_APPLICATION_CREDENTIALS, gcloud user credentials, or the metadata
+// server, which covers GKE Workload Identity). Requests are anonymous when
+// no credentials are found, for public buckets. STORAGE_EMULATOR_HOST
+// points the client at an emulator.
+func newGCSStoreFromEnv(ctx context.Context, bucket string) (*gcsStore, error) {
+	var opts []option.ClientOption
+	if _, err := google.FindDefaultCredentials(ctx, storage.ScopeReadOnly); err != nil {
+		opts = append(opts, option.WithoutAuthentication())
+	}
+	return newGCSStore(ctx, bucket, opts...)
+}
+
+func newGCSStore(ctx context.Context, bucket string, opts ...option.ClientOption) (*gcsStore, error) {
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCS client: %w", err)
+	}
+	return &gcsStore{client: client, bucket: client.Bucket(bucket), name: bucket}, nil
+}
+
+func (s *gcsStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing gs://%s/%s: %w", s.name, prefix, err)
+		}
+		objects = append(objects, ObjectInfo{Key: attrs.Name, Size: attrs.Size})
+	}
+}
+
+// Open returns no MD5 since the client already verifies the CRC32C of every
+// object it reads in full
+func (s *gcsStore) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	r, err := s.bucket.Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading gs://%s/%s: %w", s.name, key, err)
+	}
+	return r, "", nil
+}
+
+// Close releases the client's connections
+func (s *gcsStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3Config holds S3 endpoint and credential settings
+type s3Config struct {
+	Endpoint string // Custom endpoint URL for S3-compatible stores (path-style); empty for AWS
+	Region   string
+	Creds    *credentials.Credentials
+}
+
+// s3ConfigFromEnv reads the endpoint and region from the standard AWS
+// environment variables and resolves credentials through the AWS default
+// chain: environment variables, the shared credentials file (AWS_PROFILE),
+// then IAM (IRSA web identity, ECS/EKS container credentials or the EC2
+// instance profile). Requests are anonymous when none of them has credentials.
+func s3ConfigFromEnv() s3Config {
+	cfg := s3Config{
+		Endpoint: os.Getenv("AWS_ENDPOINT_URL_S3"),
+		Region:   os.Getenv("AWS_REGION"),
+		Creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{},
+		}),
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return cfg
+}
+
+// s3Store reads objects with the MinIO S3 client
+type s3Store struct {
+	bucket string
+	client *minio.Client
+}
+
+func newS3Store(bucket string, cfg s3Config, transport http.RoundTripper) (*s3Store, error) {
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	opts := &minio.Options{
+		Creds:     cfg.Creds,
+		Secure:    true,
+		Region:    cfg.Region,
+		Transport: transport,
+	}
+	if opts.Creds == nil {
+		opts.Creds = credentials.NewStatic("", "", "", credentials.SignatureAnonymous)
+	}
+
+	host := "s3." + cfg.Region + ".amazonaws.com"
+	if cfg.Endpoint != "" {
+		u, err := url.Parse(cfg.Endpoint)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+		}
+		host = u.Host
+		opts.Secure = u.Scheme != "http"
+		opts.BucketLookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(host, opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 client: %w", err)
+	}
+	return &s3Store{bucket: bucket, client: client}, nil
+}
+
+func (s *s3Store) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("listing s3://%s/%s: %w", s.bucket, prefix, obj.Err)
+		}
+		objects = append(objects, ObjectInfo{Key: obj.Key, Size: obj.Size})
+	}
+	return objects, nil
+}
+
+func (s *s3Store) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading s3://%s/%s: %w", s.bucket, key, err)
+	}
+	info, err := obj.Stat()
+	if err != nil {
+		_ = obj.Close()
+		return nil, "", fmt.Errorf("downloading s3://%s/%s: %w", s.bucket, key, err)
+	}
+
+	// The ETag is the content MD5 only for single-part uploads that are not
+	// encrypted with KMS
+	md5Hex := strings.Trim(info.ETag, `"`)
+	if len(md5Hex) != 32 || strings.HasPrefix(info.Metadata.Get("X-Amz-Server-Side-Encryption"), "aws:kms") {
+		md5Hex = ""
+	}
+	return obj, md5Hex, nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// memoryObjectStore is an in-memory ObjectStore
+type memoryObjectStore struct {
+	objects map[string]string
+	badMD5  bool // Report a wrong checksum
+}
+
+func (m *memoryObjectStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for key, content := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, ObjectInfo{Key: key, Size: int64(len(content))})
+		}
+	}
+	slices.SortFunc(objects, func(a, b ObjectInfo) int { return strings.Compare(a.Key, b.Key) })
+	return objects, nil
+}
+
+func (m *memoryObjectStore) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	content, ok := m.objects[key]
+	if !ok {
+		return nil, "", fmt.Errorf("no such object %s", key)
+	}
+	sum := md5.Sum([]byte(content))
+	if m.badMD5 {
+		sum = md5.Sum([]byte("something else"))
+	}
+	return io.NopCloser(strings.NewReader(content)), hex.EncodeToString(sum[:]), nil
+}
+
+// stagedObjects is a model under models/bge-small plus a sibling model
+// sharing the prefix
+var stagedObjects = map[string]string{
+	"models/bge-small/":                      "",
+	"models/bge-small/README.md":             "readme",
+	"models/bge-small/tokenizer.json":        "tokenizer",
+	"models/bge-small/config.json":           "{}",
+	"models/bge-small/onnx/model.onnx":       "fp32 graph",
+	"models/bge-small/onnx/model_fp16.onnx":  "fp16 graph",
+	"models/bge-small-v2/onnx/model.onnx":    "other model",
+	"models/bge-small-v2/tokenizer.json":     "other tokenizer",
+	"models/bge-small/onnx/model_q4f16.onnx": "q4f16 graph",
+}
+
+func TestPullFromSource_ObjectStore(t *testing.T) {
+	var progressed []string
+	src := NewObjectStoreSource(&memoryObjectStore{objects: stagedObjects}, "models/bge-small/",
+		WithObjectStoreProgressHandler(func(downloaded, total int64, filename string) {
+			if downloaded == total {
+				progressed = append(progressed, filename)
+			}
+		}))
+	destDir := t.TempDir()
+
+	if err := PullFromSource(context.Background(), src, ModelTypeEmbedder, destDir, "fp16", SourcePullOptions{}); err != nil {
+		t.Fatalf("PullFromSource() error = %v", err)
+	}
+
+	modelDir := filepath.Join(destDir, "embedders", "bge-small")
+	want := []string{"config.json", "model_fp16.onnx", "tokenizer.json"}
+	if got := readDirNames(t, modelDir); !slices.Equal(got, want) {
+		t.Errorf("installed files = %v, want %v", got, want)
+	}
+	for name, content := range map[string]string{"model_fp16.onnx": "fp16 graph", "tokenizer.json": "tokenizer"} {
+		got, err := os.ReadFile(filepath.Join(modelDir, name))
+		if err != nil || string(got) != content {
+			t.Errorf("%s = %q, %v; want %q", name, got, err, content)
+		}
+	}
+	slices.Sort(progressed)
+	if !slices.Equal(progressed, want) {
+		t.Errorf("download progress completed for %v, want %v", progressed, want)
+	}
+
+	tmpDir := src.tmpDir
+	if err := src.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(tmpDir); !os.IsNotExist(err) {
+		t.Errorf("temp directory %s not removed", tmpDir)
+	}
+}
+
+func TestPullFromSource_ObjectStoreChecksumMismatch(t *testing.T) {
+	src := NewObjectStoreSource(&memoryObjectStore{objects: stagedObjects, badMD5: true}, "models/bge-small")
+	defer func() { _ = src.Close() }()
+
+	err := PullFromSource(context.Background(), src, ModelTypeEmbedder, t.TempDir(), "", SourcePullOptions{})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("PullFromSource() error = %v, want checksum mismatch", err)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(auth, "/eu-west-1/s3/aws4_request") {
+			t.Errorf("Authorization = %q", auth)
+		}
+		if r.Header.Get("x-amz-date") == "" || r.Header.Get("x-amz-security-token") != "session" {
+			t.Errorf("missing signing headers: %v", r.Header)
+		}
+
+		key, ok := strings.CutPrefix(r.URL.Path, "/bucket/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if key == "" {
+			// ListObjectsV2, paginated after the first object
+			prefix := r.URL.Query().Get("prefix")
+			var keys []string
+			for k := range stagedObjects {
+				if strings.HasPrefix(k, prefix) {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			page := keys[:1]
+			next := "page2"
+			if r.URL.Query().Get("continuation-token") == "page2" {
+				page, next = keys[1:], ""
+			}
+			var b bytes.Buffer
+			b.WriteString("<ListBucketResult>")
+			for _, k := range page {
+				fmt.Fprintf(&b, "<Contents><Key>%s</Key><Size>%d</Size></Contents>", k, len(stagedObjects[k]))
+			}
+			fmt.Fprintf(&b, "<IsTruncated>%t</IsTruncated><NextContinuationToken>%s</NextContinuationToken></ListBucketResult>", next != "", next)
+			_, _ = w.Write(b.Bytes())
+			return
+		}
+
+		content, ok := stagedObjects[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		sum := md5.Sum([]byte(content))
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+		w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	store, err := newS3Store("bucket", s3Config{
+		Endpoint: server.URL,
+		Region:   "eu-west-1",
+		Creds:    credentials.NewStaticV4("AKID", "secret", "session"),
+	}, server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := NewObjectStoreSource(store, "models/bge-small")
+	defer func() { _ = src.Close() }()
+
+	destDir := t.TempDir()
+	if err := PullFromSource(context.Background(), src, ModelTypeEmbedder, destDir, "", SourcePullOptions{}); err != nil {
+		t.Fatalf("PullFromSource() error = %v", err)
+	}
+	want := []string{"config.json", "model.onnx", "tokenizer.json"}
+	if got := readDirNames(t, filepath.Join(destDir, "embedders", "bge-small")); !slices.Equal(got, want) {
+		t.Errorf("installed files = %v, want %v", got, want)
+	}
+}
+
+func TestGCSStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/storage/v1/b/bucket/o" {
+			prefix := r.URL.Query().Get("prefix")
+			var result struct {
+				Items []map[string]string `json:"items"`
+			}
+			for k, content := range stagedObjects {
+				if strings.HasPrefix(k, prefix) {
+					result.Items = append(result.Items, map[string]string{
+						"bucket": "bucket",
+						"name":   k,
+						"size":   strconv.Itoa(len(content)),
+					})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(result)
+			return
+		}
+
+		key, ok := strings.CutPrefix(r.URL.Path, "/bucket/")
+		content, exists := stagedObjects[key]
+		if !ok || !exists {
+			http.NotFound(w, r)
+			return
+		}
+		crc := crc32.Checksum([]byte(content), crc32.MakeTable(crc32.Castagnoli))
+		w.Header().Set("X-Goog-Hash", "crc32c="+base64.StdEncoding.EncodeToString(binary.BigEndian.AppendUint32(nil, crc)))
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	t.Setenv("STORAGE_EMULATOR_HOST", server.URL)
+	store, err := newGCSStoreFromEnv(context.Background(), "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := NewObjectStoreSource(store, "models/bge-small")
+	defer func() { _ = src.Close() }()
+
+	destDir := t.TempDir()
+	if err := PullFromSource(context.Background(), src, ModelTypeChunker, destDir, "q4f16", SourcePullOptions{}); err != nil {
+		t.Fatalf("PullFromSource() error = %v", err)
+	}
+	want := []string{"config.json", "model_q4f16.onnx", "tokenizer.json"}
+	if got := readDirNames(t, filepath.Join(destDir, "chunkers", "bge-small")); !slices.Equal(got, want) {
+		t.Errorf("installed files = %v, want %v", got, want)
+	}
+}
+
+func TestParseObjectStoreRef(t *testing.T) {
+	tests := []struct {
+		ref                    string
+		scheme, bucket, prefix string
+		ok                     bool
+	}{
+		{"s3://models/embedders/bge-small/", "s3", "models", "embedders/bge-small", true},
+		{"gs://bucket/bge-small", "gs", "bucket", "bge-small", true},
+		{"gs://bucket", "gs", "bucket", "", true},
+		{"s3:///prefix", "", "", "", false},
+		{"hf:owner/repo", "", "", "", false},
+	}
+	for _, tt := range tests {
+		scheme, bucket, prefix, ok := ParseObjectStoreRef(tt.ref)
+		if scheme != tt.scheme || bucket != tt.bucket || prefix != tt.prefix || ok != tt.ok {
+			t.Errorf("ParseObjectStoreRef(%q) = %q, %q, %q, %v; want %q, %q, %q, %v",
+				tt.ref, scheme, bucket, prefix, ok, tt.scheme, tt.bucket, tt.prefix, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Source supplies the files of a model repository, such as a HuggingFace
+// repo or a model staged on a local volume. PullFromSource selects the files
+// for a variant from any Source and installs them the same way.
+type Source interface {
+	// Name returns the model name used for the destination directory
+	Name() string
+
+	// ListFiles returns the slash-separated paths of all files in the source
+	ListFiles(ctx context.Context) ([]string, error)
+
+	// Fetch returns a local path holding the contents of file, downloading
+	// it first if necessary
+	Fetch(ctx context.Context, file string) (string, error)
+}
+
+// digestSource is implemented by sources that know file digests up front,
+// letting files already in the store skip the fetch
+type digestSource interface {
+	// Digests maps file paths to "sha256:<hex>" digests where known
+	Digests(ctx context.Context) (map[string]string, error)
+}
+
+// SourcePullOptions configures PullFromSource
+type SourcePullOptions struct {
+	// Store links files through a content-addressed store when set
+	Store *Store
+
+	// Validate checks each ONNX file with ValidateONNXModel after install
+	Validate bool
+
+	// ProgressHandler reports progress per installed file
+	ProgressHandler ProgressHandler
+}
+
+// PullFromSource installs the ONNX files for variant, plus tokenizer and
+// config files, from src into destDir/<type>/<name>/.
+// variant can be: "", "fp32", "fp16", "q4", "q4f16", "quantized", where ""
+// selects the model type's default when available
+func PullFromSource(
+	ctx context.Context,
+	src Source,
+	modelType ModelType,
+	destDir string,
+	variant string,
+	opts SourcePullOptions,
+) error {
+	files, err := src.ListFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("listing files: %w", err)
+	}
+
+	// Filter and select files to install
+	toPull, err := selectVariantFiles(files, resolveVariant(files, modelType, variant), src.Name())
+	if err != nil {
+		return err
+	}
+
+	// With a store, known digests let files already in it skip the fetch
+	var digests map[string]string
+	if ds, ok := src.(digestSource); ok && opts.Store != nil {
+		// Digests are an optimization; fetch everything if they are unavailable
+		digests, _ = ds.Digests(ctx)
+	}
+
+	// Create destination directory
+	modelDir := filepath.Join(destDir, modelType.DirName(), src.Name())
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	for _, fileName := range toPull {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Flatten path (e.g., "onnx/model.onnx" -> "model.onnx")
+		destName := filepath.Base(fileName)
+		destPath := filepath.Join(modelDir, destName)
+
+		if digest, ok := digests[fileName]; ok && opts.Store.Has(digest) {
+			if err := opts.Store.Link(digest, destPath); err != nil {
+				return fmt.Errorf("linking %s: %w", fileName, err)
+			}
+			reportInstalled(opts.ProgressHandler, destPath, destName)
+			continue
+		}
+
+		// Report the start of the file; sources that download may report
+		// progress while fetching
+		if opts.ProgressHandler != nil {
+			opts.ProgressHandler(0, 0, destName)
+		}
+
+		localPath, err := src.Fetch(ctx, fileName)
+		if err != nil {
+			return fmt.Errorf("fetching %s: %w", fileName, err)
+		}
+
+		// Copy to destination, through the store when enabled
+		if opts.Store != nil {
+			digest, err := opts.Store.Import(ctx, localPath)
+			if err != nil {
+				return fmt.Errorf("storing %s: %w", fileName, err)
+			}
+			if err := opts.Store.Link(digest, destPath); err != nil {
+				return fmt.Errorf("linking %s: %w", fileName, err)
+			}
+		} else if err := copyFile(ctx, localPath, destPath); err != nil {
+			return fmt.Errorf("copying %s: %w", fileName, err)
+		}
+
+		reportInstalled(opts.ProgressHandler, destPath, destName)
+	}
+
+	if opts.Validate {
+		return validateInstalled(modelDir, toPull, modelType)
+	}
+
+	return nil
+}
+
+// validateInstalled checks every ONNX file installed in modelDir and removes
+// those that fail, so a rejected model is not left where the model loader
+// would pick it up. Validation runs after all files are installed because a
+// model may keep its weights in a separate external data file.
+func validateInstalled(modelDir string, files []string, modelType ModelType) error {
+	var errs []error
+	for _, fileName := range files {
+		if !strings.HasSuffix(fileName, ".onnx") {
+			continue
+		}
+		destPath := filepath.Join(modelDir, filepath.Base(fileName))
+		err := ValidateONNXModel(destPath, ExpectedSignature(modelType, fileName))
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrValidationUnavailable) {
+			// Nothing was checked, so there is nothing to reject
+			return fmt.Errorf("validating %s: %w", fileName, err)
+		}
+		if rmErr := os.Remove(destPath); rmErr != nil && !os.IsNotExist(rmErr) {
+			err = errors.Join(err, fmt.Errorf("removing invalid file: %w", rmErr))
+		}
+		errs = append(errs, fmt.Errorf("validating %s: %w", fileName, err))
+	}
+	return errors.Join(errs...)
+}
+
+// sizeSource is implemented by sources that know file sizes without
+// fetching, letting PlanPull total the download
+type sizeSource interface {
+	// FileSizes maps file paths to sizes in bytes where known
+	FileSizes(ctx context.Context) (map[string]int64, error)
+}
+
+// PullPlan describes what a pull would install, computed without fetching
+// or writing anything
+type PullPlan struct {
+	ModelDir  string        // Directory the files would be installed in
+	Files     []PlannedFile // In install order
+	TotalSize int64         // Sum of the known file sizes
+}
+
+// PlannedFile is one file a pull would install
+type PlannedFile struct {
+	Path string // Path within the source
+	Size int64  // Size in bytes, or -1 if the source cannot tell without fetching
+}
+
+// PlanPull resolves the files PullFromSource would install for variant
+// without fetching them or touching destDir, failing the same way when the
+// variant is not available
+func PlanPull(ctx context.Context, src Source, modelType ModelType, destDir, variant string) (*PullPlan, error) {
+	files, err := src.ListFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing files: %w", err)
+	}
+
+	var sizes map[string]int64
+	if ss, ok := src.(sizeSource); ok {
+		if sizes, err = ss.FileSizes(ctx); err != nil {
+			return nil, fmt.Errorf("listing file sizes: %w", err)
+		}
+	}
+	return planPull(src.Name(), files, sizes, modelType, destDir, variant)
+}
+
+// planPull selects the files for variant and looks up their sizes
+func planPull(name string, files []string, sizes map[string]int64, modelType ModelType, destDir, variant string) (*PullPlan, error) {
+	toPull, err := selectVariantFiles(files, resolveVariant(files, modelType, variant), name)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &PullPlan{ModelDir: filepath.Join(destDir, modelType.DirName(), name)}
+	for _, f := range toPull {
+		size, ok := sizes[f]
+		if ok {
+			plan.TotalSize += size
+		} else {
+			size = -1
+		}
+		plan.Files = append(plan.Files, PlannedFile{Path: f, Size: size})
+	}
+	return plan, nil
+}
+
+// resolveVariant returns the variant to install from files: variant itself
+// when given, otherwise modelType's default ONNX variant if files include it,
+// falling back to full precision
+func resolveVariant(files []string, modelType ModelType, variant string) string {
+	if variant != "" {
+		return variant
+	}
+	if v := modelType.DefaultONNXVariant(); v != "" && hasONNXModel(selectONNXFiles(files, v)) {
+		return v
+	}
+	return ""
+}
+
+// hasONNXModel reports whether files include an ONNX model
+func hasONNXModel(files []string) bool {
+	return slices.ContainsFunc(files, func(f string) bool { return strings.HasSuffix(f, ".onnx") })
+}
+
+// selectVariantFiles selects the files to install for variant, failing when
+// there is no ONNX model for it
+func selectVariantFiles(files []string, variant, name string) ([]string, error) {
+	toPull := selectONNXFiles(files, variant)
+	if !hasONNXModel(toPull) {
+		return nil, fmt.Errorf("no %s ONNX model found in %s", VariantDescription(variant), name)
+	}
+	return toPull, nil
+}
+
+// reportInstalled reports a file as complete
+func reportInstalled(h ProgressHandler, path, name string) {
+	if h == nil {
+		return
+	}
+	if info, err := os.Stat(path); err == nil {
+		h(info.Size(), info.Size(), name)
+	}
+}
+
+// LocalSource is a model staged in a local directory, such as a mounted
+// volume in an air-gapped environment
+type LocalSource struct {
+	dir string
+}
+
+// NewLocalSource returns a source reading the model files under dir
+func NewLocalSource(dir string) *LocalSource {
+	return &LocalSource{dir: filepath.Clean(dir)}
+}
+
+// Name returns the directory's base name
+func (s *LocalSource) Name() string {
+	return filepath.Base(s.dir)
+}
+
+// ListFiles returns all regular files under the directory. Symbolic links
+// are followed when their target stays inside the directory, as in staged
+// volumes that link weights into place; links that escape it are skipped.
+func (s *LocalSource) ListFiles(ctx context.Context) ([]string, error) {
+	root, err := filepath.EvalSymlinks(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", s.dir)
+	}
+
+	var files []string
+	ancestors := make(map[string]bool) // Resolved directories being walked, so link cycles end
+	var walk func(dir, rel string) error
+	walk = func(dir, rel string) error {
+		if ancestors[dir] {
+			return nil
+		}
+		ancestors[dir] = true
+		defer delete(ancestors, dir)
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			relPath := filepath.Join(rel, entry.Name())
+			mode := entry.Type()
+
+			if mode&fs.ModeSymlink != 0 {
+				target, err := filepath.EvalSymlinks(path)
+				if err != nil || !withinDir(root, target) {
+					continue // Dangling, or pointing outside the model
+				}
+				targetInfo, err := os.Stat(target)
+				if err != nil {
+					return err
+				}
+				path, mode = target, targetInfo.Mode().Type()
+			}
+
+			switch {
+			case mode.IsDir():
+				if err := walk(path, relPath); err != nil {
+					return err
+				}
+			case mode.IsRegular():
+				files = append(files, filepath.ToSlash(relPath))
+			}
+		}
+		return nil
+	}
+	if err := walk(root, ""); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// withinDir reports whether path is dir or inside it; both must be resolved
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Fetch returns the path of file inside the directory
+func (s *LocalSource) Fetch(ctx context.Context, file string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(file))
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// FileSizes returns the sizes of all regular files under the directory
+func (s *LocalSource) FileSizes(ctx context.Context) (map[string]int64, error) {
+	files, err := s.ListFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64, len(files))
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(s.dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+		sizes[file] = info.Size()
+	}
+	return sizes, nil
+}
+
+// ParseLocalRef parses a model reference like "file:///path/to/model" and
+// returns the local directory
+func ParseLocalRef(ref string) (path string, isLocal bool) {
+	if after, ok := strings.CutPrefix(ref, "file://"); ok {
+		return after, true
+	}
+	return "", false
+}
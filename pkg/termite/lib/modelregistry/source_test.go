@@ -0,0 +1,331 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// stageModel writes files (relative path -> content) under a new directory
+// named name and returns its path
+func stageModel(t *testing.T, name string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readDirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestPullFromSource_Local(t *testing.T) {
+	staged := stageModel(t, "embeddinggemma-300m-ONNX", map[string]string{
+		"README.md":            "readme",
+		"config.json":          "{}",
+		"tokenizer.json":       "tokenizer",
+		"onnx/model.onnx":      "fp32 graph",
+		"onnx/model.onnx_data": "fp32 weights",
+		"onnx/model_fp16.onnx": "fp16 graph",
+	})
+	destDir := t.TempDir()
+
+	var progressed []string
+	err := PullFromSource(context.Background(), NewLocalSource(staged), ModelTypeEmbedder, destDir, "", SourcePullOptions{
+		ProgressHandler: func(downloaded, total int64, filename string) {
+			if total > 0 && downloaded == total {
+				progressed = append(progressed, filename)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("PullFromSource() error = %v", err)
+	}
+
+	modelDir := filepath.Join(destDir, "embedders", "embeddinggemma-300m-ONNX")
+	want := []string{"config.json", "model.onnx", "model.onnx_data", "tokenizer.json"}
+	if got := readDirNames(t, modelDir); !slices.Equal(got, want) {
+		t.Errorf("installed files = %v, want %v", got, want)
+	}
+	slices.Sort(progressed)
+	if !slices.Equal(progressed, want) {
+		t.Errorf("completed progress = %v, want %v", progressed, want)
+	}
+
+	got, err := os.ReadFile(filepath.Join(modelDir, "model.onnx_data"))
+	if err != nil || string(got) != "fp32 weights" {
+		t.Errorf("model.onnx_data = %q, %v; want fp32 weights", got, err)
+	}
+
+	// The staged copy is left untouched
+	if _, err := os.Stat(filepath.Join(staged, "onnx", "model.onnx")); err != nil {
+		t.Errorf("staged file removed: %v", err)
+	}
+}
+
+func TestPullFromSource_DefaultVariant(t *testing.T) {
+	staged := stageModel(t, "model", map[string]string{
+		"tokenizer.json":       "tokenizer",
+		"model.onnx":           "fp32 graph",
+		"model_quantized.onnx": "int8 graph",
+	})
+
+	tests := []struct {
+		modelType ModelType
+		variant   string
+		want      string
+	}{
+		{ModelTypeEmbedder, "", "model_quantized.onnx"},
+		{ModelTypeChunker, "", "model.onnx"},
+		{ModelTypeReranker, "", "model.onnx"},
+		// An explicit variant overrides the model type's default
+		{ModelTypeEmbedder, "fp32", "model.onnx"},
+		{ModelTypeReranker, "quantized", "model_quantized.onnx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.modelType)+"/"+tt.variant, func(t *testing.T) {
+			destDir := t.TempDir()
+			if err := PullFromSource(context.Background(), NewLocalSource(staged), tt.modelType, destDir, tt.variant, SourcePullOptions{}); err != nil {
+				t.Fatalf("PullFromSource() error = %v", err)
+			}
+			want := []string{tt.want, "tokenizer.json"}
+			if got := readDirNames(t, filepath.Join(destDir, tt.modelType.DirName(), "model")); !slices.Equal(got, want) {
+				t.Errorf("installed files = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPullFromSource_LocalVariantAndStore(t *testing.T) {
+	staged := stageModel(t, "reranker", map[string]string{
+		"tokenizer.json":       "tokenizer",
+		"model.onnx":           "fp32 graph",
+		"model_quantized.onnx": "int8 graph",
+	})
+	destDir := t.TempDir()
+	store := NewStore(t.TempDir())
+
+	err := PullFromSource(context.Background(), NewLocalSource(staged), ModelTypeReranker, destDir, "quantized", SourcePullOptions{
+		Store: store,
+	})
+	if err != nil {
+		t.Fatalf("PullFromSource() error = %v", err)
+	}
+
+	modelDir := filepath.Join(destDir, "rerankers", "reranker")
+	want := []string{"model_quantized.onnx", "tokenizer.json"}
+	if got := readDirNames(t, modelDir); !slices.Equal(got, want) {
+		t.Errorf("installed files = %v, want %v", got, want)
+	}
+
+	digest, err := store.Import(context.Background(), filepath.Join(staged, "model_quantized.onnx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	storePath, _ := store.Path(digest)
+	storeInfo, err := os.Stat(storePath)
+	if err != nil {
+		t.Fatalf("variant not in store: %v", err)
+	}
+	installedInfo, err := os.Stat(filepath.Join(modelDir, "model_quantized.onnx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(storeInfo, installedInfo) {
+		t.Error("installed file is not linked to the store")
+	}
+}
+
+func TestPullFromSource_LocalNoONNX(t *testing.T) {
+	staged := stageModel(t, "empty", map[string]string{"README.md": "readme"})
+
+	err := PullFromSource(context.Background(), NewLocalSource(staged), ModelTypeEmbedder, t.TempDir(), "", SourcePullOptions{})
+	if err == nil {
+		t.Fatal("PullFromSource() error = nil, want error for a directory without ONNX files")
+	}
+}
+
+// cancelingSource cancels the pull once its first file has been fetched,
+// as an interrupt would
+type cancelingSource struct {
+	*LocalSource
+	cancel  context.CancelFunc
+	fetched []string
+}
+
+func (s *cancelingSource) Fetch(ctx context.Context, file string) (string, error) {
+	s.fetched = append(s.fetched, file)
+	s.cancel()
+	return s.LocalSource.Fetch(ctx, file)
+}
+
+func TestPullFromSource_Canceled(t *testing.T) {
+	staged := stageModel(t, "embedder", map[string]string{
+		"config.json":     "{}",
+		"tokenizer.json":  "tokenizer",
+		"onnx/model.onnx": "fp32 graph",
+	})
+	destDir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := &cancelingSource{LocalSource: NewLocalSource(staged), cancel: cancel}
+
+	err := PullFromSource(ctx, src, ModelTypeEmbedder, destDir, "", SourcePullOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("PullFromSource() error = %v, want context.Canceled", err)
+	}
+	if len(src.fetched) != 1 {
+		t.Errorf("fetched %v after cancellation, want a single file", src.fetched)
+	}
+
+	// The interrupted copy leaves no partial file behind
+	modelDir := filepath.Join(destDir, "embedders", "embedder")
+	if got := readDirNames(t, modelDir); len(got) != 0 {
+		t.Errorf("installed files = %v, want none", got)
+	}
+}
+
+func TestParseLocalRef(t *testing.T) {
+	tests := []struct {
+		ref       string
+		wantPath  string
+		wantLocal bool
+	}{
+		{"file:///mnt/models/bge-small", "/mnt/models/bge-small", true},
+		{"file://relative/model", "relative/model", true},
+		{"hf:owner/repo", "", false},
+		{"bge-small-en-v1.5", "", false},
+	}
+	for _, tt := range tests {
+		path, isLocal := ParseLocalRef(tt.ref)
+		if path != tt.wantPath || isLocal != tt.wantLocal {
+			t.Errorf("ParseLocalRef(%q) = %q, %v; want %q, %v", tt.ref, path, isLocal, tt.wantPath, tt.wantLocal)
+		}
+	}
+}
+
+func TestPlanPull_Local(t *testing.T) {
+	staged := stageModel(t, "reranker", map[string]string{
+		"README.md":            "readme",
+		"tokenizer.json":       "tokenizer",
+		"onnx/model.onnx":      "fp32 graph",
+		"onnx/model_fp16.onnx": "fp16",
+	})
+	destDir := filepath.Join(t.TempDir(), "models")
+
+	plan, err := PlanPull(context.Background(), NewLocalSource(staged), ModelTypeReranker, destDir, "fp16")
+	if err != nil {
+		t.Fatalf("PlanPull() error = %v", err)
+	}
+
+	want := []PlannedFile{{Path: "tokenizer.json", Size: 9}, {Path: "onnx/model_fp16.onnx", Size: 4}}
+	if !slices.Equal(plan.Files, want) {
+		t.Errorf("Files = %v, want %v", plan.Files, want)
+	}
+	if plan.TotalSize != 13 {
+		t.Errorf("TotalSize = %d, want 13", plan.TotalSize)
+	}
+	if want := filepath.Join(destDir, "rerankers", "reranker"); plan.ModelDir != want {
+		t.Errorf("ModelDir = %q, want %q", plan.ModelDir, want)
+	}
+
+	// A dry run writes nothing
+	if _, err := os.Stat(destDir); !os.IsNotExist(err) {
+		t.Errorf("destination directory was created: %v", err)
+	}
+}
+
+func TestPlanPull_VariantUnavailable(t *testing.T) {
+	staged := stageModel(t, "reranker", map[string]string{
+		"tokenizer.json": "tokenizer",
+		"model.onnx":     "fp32 graph",
+	})
+
+	_, err := PlanPull(context.Background(), NewLocalSource(staged), ModelTypeReranker, t.TempDir(), "q4")
+	if err == nil {
+		t.Fatal("PlanPull() succeeded for a missing variant, want error")
+	}
+
+	// A real pull fails the same way instead of installing only the tokenizer
+	destDir := t.TempDir()
+	if err := PullFromSource(context.Background(), NewLocalSource(staged), ModelTypeReranker, destDir, "q4", SourcePullOptions{}); err == nil {
+		t.Fatal("PullFromSource() succeeded for a missing variant, want error")
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "rerankers")); !os.IsNotExist(err) {
+		t.Errorf("model directory was created: %v", err)
+	}
+}
+
+func TestLocalSource_ListFilesFollowsContainedSymlinks(t *testing.T) {
+	staged := stageModel(t, "bge-small", map[string]string{
+		"blobs/abc123":   "fp32 graph",
+		"tokenizer.json": "tokenizer",
+	})
+	outside := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(outside, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"onnx":          "blobs",             // Directory inside the root
+		"model.onnx":    "blobs/abc123",      // File inside the root
+		"secret.onnx":   outside,             // Escapes the root
+		"loop":          ".",                 // Cycle back to the root
+		"dangling.onnx": "blobs/missing.bin", // No target
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(staged, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The staged directory is itself reached through a link, like a volume mount
+	mount := filepath.Join(t.TempDir(), "mount")
+	if err := os.Symlink(staged, mount); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := NewLocalSource(mount).ListFiles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"blobs/abc123", "model.onnx", "onnx/abc123", "tokenizer.json"}
+	if !slices.Equal(files, want) {
+		t.Errorf("ListFiles() = %v, want %v", files, want)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store is a content-addressed file store shared between model directories.
+// Files are kept under <dir>/sha256/<hex digest> and linked into each model
+// directory that uses them, so identical files pulled for different models
+// (or into different models directories) occupy disk space only once.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir. The directory is created on first write.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Path returns the store location of a file with the given "sha256:<hex>" digest
+func (s *Store) Path(digest string) (string, error) {
+	hexDigest, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok {
+		return "", fmt.Errorf("unsupported digest %q: expected sha256:<hex>", digest)
+	}
+	if _, err := hex.DecodeString(hexDigest); err != nil || len(hexDigest) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid sha256 digest %q", digest)
+	}
+	return filepath.Join(s.dir, "sha256", hexDigest), nil
+}
+
+// Has reports whether the store contains a file with the given digest
+func (s *Store) Has(digest string) bool {
+	path, err := s.Path(digest)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Commit moves a verified file at src into the store under digest. src must
+// be on the same filesystem as the store (see TempFile).
+func (s *Store) Commit(src, digest string) error {
+	path, err := s.Path(digest)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating store directory: %w", err)
+	}
+	if err := os.Rename(src, path); err != nil {
+		return fmt.Errorf("committing to store: %w", err)
+	}
+	return nil
+}
+
+// TempFile creates a temporary file inside the store for staging a download
+func (s *Store) TempFile() (*os.File, error) {
+	tmpDir := filepath.Join(s.dir, "tmp")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating store directory: %w", err)
+	}
+	return os.CreateTemp(tmpDir, "download-*")
+}
+
+// Import hashes the file at src and copies it into the store unless an
+// identical file is already present. It returns the file's digest. The copy
+// stops when ctx is done.
+func (s *Store) Import(ctx context.Context, src string) (string, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("opening source: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	tmp, err := s.TempFile()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name()) // Clean up unless committed
+	}()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hasher), &contextReader{ctx: ctx, r: f}); err != nil {
+		return "", fmt.Errorf("copying to store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("closing temp file: %w", err)
+	}
+
+	digest := "sha256:" + hex.EncodeToString(hasher.Sum(nil))
+	if s.Has(digest) {
+		return digest, nil
+	}
+	return digest, s.Commit(tmp.Name(), digest)
+}
+
+// Link makes dest refer to the stored file with the given digest, replacing
+// any existing file. It hardlinks where possible and falls back to a symlink
+// when the store is on a different filesystem.
+func (s *Store) Link(digest, dest string) error {
+	path, err := s.Path(digest)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(dest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("replacing %s: %w", dest, err)
+	}
+	if err := os.Link(path, dest); err == nil {
+		return nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Symlink(absPath, dest); err != nil {
+		return fmt.Errorf("linking %s to store: %w", dest, err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStorePath(t *testing.T) {
+	store := NewStore("/store")
+	digest := "sha256:" + hex.EncodeToString(make([]byte, sha256.Size))
+
+	path, err := store.Path(digest)
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if want := filepath.Join("/store", "sha256", digest[len("sha256:"):]); path != want {
+		t.Errorf("Path() = %v, want %v", path, want)
+	}
+
+	for _, bad := range []string{"md5:abc", "sha256:not-hex", "sha256:abcd", "sha256:../../etc/passwd"} {
+		if _, err := store.Path(bad); err == nil {
+			t.Errorf("Path(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestStoreImportAndLink(t *testing.T) {
+	store := NewStore(t.TempDir())
+	srcDir := t.TempDir()
+	content := []byte("shared tokenizer")
+
+	src := filepath.Join(srcDir, "tokenizer.json")
+	if err := os.WriteFile(src, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	digest, err := store.Import(context.Background(), src)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	sum := sha256.Sum256(content)
+	if want := "sha256:" + hex.EncodeToString(sum[:]); digest != want {
+		t.Errorf("Import() digest = %v, want %v", digest, want)
+	}
+	if !store.Has(digest) {
+		t.Fatal("Has() = false after Import")
+	}
+
+	// Importing identical content again is a no-op
+	if again, err := store.Import(context.Background(), src); err != nil || again != digest {
+		t.Errorf("second Import() = %v, %v; want %v, nil", again, err, digest)
+	}
+
+	modelA := filepath.Join(t.TempDir(), "tokenizer.json")
+	modelB := filepath.Join(t.TempDir(), "tokenizer.json")
+	for _, dest := range []string{modelA, modelB} {
+		if err := store.Link(digest, dest); err != nil {
+			t.Fatalf("Link(%s) error = %v", dest, err)
+		}
+		got, err := os.ReadFile(dest)
+		if err != nil || string(got) != string(content) {
+			t.Errorf("linked file content = %q, %v; want %q", got, err, content)
+		}
+	}
+
+	infoA, _ := os.Stat(modelA)
+	infoB, _ := os.Stat(modelB)
+	if !os.SameFile(infoA, infoB) {
+		t.Error("linked files do not share storage")
+	}
+}
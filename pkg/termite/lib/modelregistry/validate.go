@@ -0,0 +1,78 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelregistry
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrValidationUnavailable is returned by ValidateONNXModel in builds without
+// the ONNX Runtime backend
+var ErrValidationUnavailable = errors.New("ONNX model validation not available: build with -tags=\"onnx,ORT\" to enable")
+
+// ModelSignature lists the input and output names an ONNX model must declare
+type ModelSignature struct {
+	Inputs  []string
+	Outputs []string
+}
+
+// ExpectedSignature returns the inputs and outputs termite relies on for an
+// ONNX file of the given model type. Vision encoders (e.g. CLIP's
+// visual_model.onnx) take pixel values; all other models take token IDs.
+func ExpectedSignature(modelType ModelType, fileName string) ModelSignature {
+	base := strings.ToLower(filepath.Base(fileName))
+	if strings.Contains(base, "visual") || strings.Contains(base, "vision") {
+		return ModelSignature{Inputs: []string{"pixel_values"}}
+	}
+
+	sig := ModelSignature{Inputs: []string{"input_ids"}}
+	switch modelType {
+	case ModelTypeReranker, ModelTypeChunker:
+		// Cross-encoder scores and token classes are read from logits
+		sig.Outputs = []string{"logits"}
+	}
+	return sig
+}
+
+// ValidateONNXModel opens the model at path without running inference and
+// checks that it loads (including opset support) and declares every input and
+// output in sig. It requires the ONNX Runtime backend and returns
+// ErrValidationUnavailable otherwise.
+func ValidateONNXModel(path string, sig ModelSignature) error {
+	inputs, outputs, err := readONNXSignature(path)
+	if err != nil {
+		return err
+	}
+	return checkSignature(filepath.Base(path), sig, inputs, outputs)
+}
+
+// checkSignature reports the first expected input or output the model lacks
+func checkSignature(name string, sig ModelSignature, inputs, outputs []string) error {
+	for _, want := range sig.Inputs {
+		if !slices.Contains(inputs, want) {
+			return fmt.Errorf("%s is missing required input %q (model inputs: %v)", name, want, inputs)
+		}
+	}
+	for _, want := range sig.Outputs {
+		if !slices.Contains(outputs, want) {
+			return fmt.Errorf("%s is missing required output %q (model outputs: %v)", name, want, outputs)
+		}
+	}
+	return nil
+}
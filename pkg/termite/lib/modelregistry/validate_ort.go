@@ -0,0 +1,61 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build onnx && ORT
+
+package modelregistry
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/antflydb/termite/pkg/termite/lib/hugot"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+var (
+	ortInitOnce sync.Once
+	ortInitErr  error
+)
+
+func initONNXRuntime() error {
+	ortInitOnce.Do(func() {
+		if !ort.IsInitialized() {
+			ortInitErr = hugot.WrapORTInitError(ort.InitializeEnvironment())
+		}
+	})
+	return ortInitErr
+}
+
+// readONNXSignature returns the input and output names of an ONNX model.
+// ONNX Runtime parses the graph to answer, so unsupported opsets and corrupt
+// files surface here.
+func readONNXSignature(path string) (inputs, outputs []string, err error) {
+	if err := initONNXRuntime(); err != nil {
+		return nil, nil, fmt.Errorf("initializing ONNX Runtime: %w", err)
+	}
+
+	inputInfo, outputInfo, err := ort.GetInputOutputInfo(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading %s (unsupported opset or corrupt model?): %w", path, err)
+	}
+
+	for _, info := range inputInfo {
+		inputs = append(inputs, info.Name)
+	}
+	for _, info := range outputInfo {
+		outputs = append(outputs, info.Name)
+	}
+	return inputs, outputs, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build onnx && ORT
+
+package modelregistry
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Minimal protobuf encoding for hand-built ONNX test models
+func pbVarint(field int, v uint64) []byte {
+	b := binary.AppendUvarint(nil, uint64(field)<<3)
+	return binary.AppendUvarint(b, v)
+}
+
+func pbBytes(field int, data []byte) []byte {
+	b := binary.AppendUvarint(nil, uint64(field)<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+// floatTensorInfo encodes a ValueInfoProto for a float tensor of shape [1]
+func floatTensorInfo(name string) []byte {
+	shape := pbBytes(2, pbBytes(1, pbVarint(1, 1)))
+	tensorType := pbBytes(1, concat(pbVarint(1, 1), shape))
+	return concat(pbBytes(1, []byte(name)), pbBytes(2, tensorType))
+}
+
+// writeIdentityModel writes an opset 13 ONNX model copying input to output
+func writeIdentityModel(t *testing.T, input, output string) string {
+	t.Helper()
+	node := concat(
+		pbBytes(1, []byte(input)),
+		pbBytes(2, []byte(output)),
+		pbBytes(4, []byte("Identity")),
+	)
+	graph := concat(
+		pbBytes(1, node),
+		pbBytes(2, []byte("test")),
+		pbBytes(11, floatTensorInfo(input)),
+		pbBytes(12, floatTensorInfo(output)),
+	)
+	model := concat(
+		pbVarint(1, 8), // ir_version
+		pbBytes(7, graph),
+		pbBytes(8, pbVarint(2, 13)), // opset_import
+	)
+
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, model, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateONNXModel_MissingPixelValues(t *testing.T) {
+	path := writeIdentityModel(t, "input_ids", "image_embeds")
+
+	err := ValidateONNXModel(path, ExpectedSignature(ModelTypeEmbedder, "visual_model.onnx"))
+	if err == nil {
+		t.Fatal("ValidateONNXModel() error = nil, want missing pixel_values error")
+	}
+	if !strings.Contains(err.Error(), `missing required input "pixel_values"`) {
+		t.Errorf("error = %v, want it to name pixel_values", err)
+	}
+}
+
+func TestValidateONNXModel_Valid(t *testing.T) {
+	path := writeIdentityModel(t, "input_ids", "logits")
+
+	if err := ValidateONNXModel(path, ExpectedSignature(ModelTypeReranker, "model.onnx")); err != nil {
+		t.Errorf("ValidateONNXModel() error = %v", err)
+	}
+}
+
+func TestValidateONNXModel_MissingOutput(t *testing.T) {
+	path := writeIdentityModel(t, "input_ids", "last_hidden_state")
+
+	err := ValidateONNXModel(path, ExpectedSignature(ModelTypeChunker, "model.onnx"))
+	if err == nil || !strings.Contains(err.Error(), `missing required output "logits"`) {
+		t.Errorf("ValidateONNXModel() error = %v, want missing logits error", err)
+	}
+}
+
+func TestValidateInstalled_RemovesInvalidFiles(t *testing.T) {
+	path := writeIdentityModel(t, "input_ids", "last_hidden_state")
+	modelDir := filepath.Dir(path)
+
+	err := validateInstalled(modelDir, []string{"onnx/model.onnx", "tokenizer.json"}, ModelTypeReranker)
+	if err == nil || !strings.Contains(err.Error(), `missing required output "logits"`) {
+		t.Fatalf("validateInstalled() error = %v, want missing logits error", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("invalid model was not removed: stat error = %v", err)
+	}
+}
+
+func TestValidateInstalled_KeepsValidFiles(t *testing.T) {
+	path := writeIdentityModel(t, "input_ids", "logits")
+
+	if err := validateInstalled(filepath.Dir(path), []string{"model.onnx"}, ModelTypeReranker); err != nil {
+		t.Fatalf("validateInstalled() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("valid model was removed: %v", err)
+	}
+}
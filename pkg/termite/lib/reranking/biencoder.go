@@ -0,0 +1,228 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reranking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/antflydb/antfly-go/libaf/reranking"
+	"go.uber.org/zap"
+)
+
+// Type identifies how a reranker scores query/document pairs.
+type Type string
+
+const (
+	// TypeCrossEncoder rerankers score the query and each document jointly.
+	TypeCrossEncoder Type = "cross-encoder"
+
+	// TypeBiEncoder rerankers embed the query and documents separately and
+	// score them by the dot product of their embeddings.
+	TypeBiEncoder Type = "bi-encoder"
+)
+
+// Mode selects how a bi-encoder reranker computes its embeddings.
+type Mode string
+
+const (
+	// ModePairwise scores each (query, document) pair independently. This is
+	// the default and the only mode supported by cross-encoders.
+	ModePairwise Mode = "pairwise"
+
+	// ModeSharedQuery embeds the query once and reuses it for every document.
+	// Only supported by bi-encoders.
+	ModeSharedQuery Mode = "shared_query"
+)
+
+// ErrModeNotSupported is returned when a reranker cannot run the requested mode.
+var ErrModeNotSupported = errors.New("rerank mode not supported by this model")
+
+// Typed is implemented by rerankers that report their Type.
+type Typed interface {
+	Type() Type
+}
+
+// ModeReranker is implemented by rerankers that support modes other than ModePairwise.
+type ModeReranker interface {
+	reranking.Model
+	RerankWithMode(ctx context.Context, query string, prompts []string, mode Mode) ([]float32, error)
+}
+
+// TypeOf returns the Type of model, defaulting to TypeCrossEncoder for
+// rerankers that do not report one.
+func TypeOf(model reranking.Model) Type {
+	if t, ok := model.(Typed); ok {
+		return t.Type()
+	}
+	return TypeCrossEncoder
+}
+
+// RerankWithMode reranks prompts using the given mode. An empty mode or
+// ModePairwise calls Rerank directly; other modes require a ModeReranker.
+func RerankWithMode(ctx context.Context, model reranking.Model, query string, prompts []string, mode Mode) ([]float32, error) {
+	if mode == "" || mode == ModePairwise {
+		return model.Rerank(ctx, query, prompts)
+	}
+	if mr, ok := model.(ModeReranker); ok {
+		return mr.RerankWithMode(ctx, query, prompts, mode)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrModeNotSupported, mode)
+}
+
+// DetectType inspects a model directory to decide whether it holds a
+// cross-encoder or a bi-encoder. Sequence classification architectures are
+// cross-encoders; sentence-transformers layouts (modules.json or
+// sentence_bert_config.json) without one are bi-encoders. Anything else is
+// treated as a cross-encoder for backward compatibility.
+func DetectType(modelPath string) Type {
+	if data, err := os.ReadFile(filepath.Join(modelPath, "config.json")); err == nil {
+		var config struct {
+			Architectures []string `json:"architectures"`
+		}
+		if json.Unmarshal(data, &config) == nil {
+			for _, arch := range config.Architectures {
+				if strings.HasSuffix(arch, "ForSequenceClassification") {
+					return TypeCrossEncoder
+				}
+			}
+		}
+	}
+
+	for _, marker := range []string{"modules.json", "sentence_bert_config.json"} {
+		if _, err := os.Stat(filepath.Join(modelPath, marker)); err == nil {
+			return TypeBiEncoder
+		}
+	}
+	return TypeCrossEncoder
+}
+
+// Ensure BiEncoderReranker implements the Model and ModeReranker interfaces
+var (
+	_ reranking.Model = (*BiEncoderReranker)(nil)
+	_ ModeReranker    = (*BiEncoderReranker)(nil)
+)
+
+// BiEncoderReranker scores documents by the dot product of query and document
+// embeddings produced by an embedder. With normalized embeddings this is the
+// cosine similarity.
+type BiEncoderReranker struct {
+	embedder embeddings.Embedder
+	logger   *zap.Logger
+}
+
+// NewBiEncoderReranker creates a bi-encoder reranker backed by embedder.
+func NewBiEncoderReranker(embedder embeddings.Embedder, logger *zap.Logger) *BiEncoderReranker {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &BiEncoderReranker{
+		embedder: embedder,
+		logger:   logger,
+	}
+}
+
+// Type returns TypeBiEncoder
+func (b *BiEncoderReranker) Type() Type {
+	return TypeBiEncoder
+}
+
+// Rerank scores each prompt against the query pairwise
+func (b *BiEncoderReranker) Rerank(ctx context.Context, query string, prompts []string) ([]float32, error) {
+	return b.RerankWithMode(ctx, query, prompts, ModePairwise)
+}
+
+// RerankWithMode scores prompts against the query. ModePairwise embeds the
+// query together with each prompt; ModeSharedQuery embeds the query once and
+// all prompts in a single batch.
+func (b *BiEncoderReranker) RerankWithMode(ctx context.Context, query string, prompts []string, mode Mode) ([]float32, error) {
+	if len(prompts) == 0 {
+		return []float32{}, nil
+	}
+	if query == "" {
+		return nil, errors.New("query is required for reranking")
+	}
+
+	switch mode {
+	case "", ModePairwise:
+		scores := make([]float32, len(prompts))
+		for i, prompt := range prompts {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			embeds, err := b.embed(ctx, query, prompt)
+			if err != nil {
+				return nil, err
+			}
+			scores[i] = dot(embeds[0], embeds[1])
+		}
+		return scores, nil
+
+	case ModeSharedQuery:
+		embeds, err := b.embed(ctx, append([]string{query}, prompts...)...)
+		if err != nil {
+			return nil, err
+		}
+		queryEmbed := embeds[0]
+		scores := make([]float32, len(prompts))
+		for i, docEmbed := range embeds[1:] {
+			scores[i] = dot(queryEmbed, docEmbed)
+		}
+		return scores, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrModeNotSupported, mode)
+	}
+}
+
+// embed embeds texts, checking that one vector is returned per text.
+func (b *BiEncoderReranker) embed(ctx context.Context, texts ...string) ([][]float32, error) {
+	contents := make([][]ai.ContentPart, len(texts))
+	for i, t := range texts {
+		contents[i] = []ai.ContentPart{ai.TextContent{Text: t}}
+	}
+	embeds, err := b.embedder.Embed(ctx, contents)
+	if err != nil {
+		return nil, fmt.Errorf("embedding for bi-encoder rerank: %w", err)
+	}
+	if len(embeds) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeds))
+	}
+	return embeds, nil
+}
+
+// Close closes the underlying embedder if it supports closing
+func (b *BiEncoderReranker) Close() error {
+	if closer, ok := b.embedder.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// dot returns the dot product of a and b over their common length.
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range min(len(a), len(b)) {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
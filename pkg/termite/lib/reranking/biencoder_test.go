@@ -0,0 +1,192 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reranking
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// letterEmbedder embeds text as normalized letter frequencies and counts how
+// often each text is embedded.
+type letterEmbedder struct {
+	calls  int
+	counts map[string]int
+}
+
+func (e *letterEmbedder) Capabilities() embeddings.EmbedderCapabilities {
+	return embeddings.TextOnlyCapabilities()
+}
+
+func (e *letterEmbedder) Embed(ctx context.Context, contents [][]ai.ContentPart) ([][]float32, error) {
+	e.calls++
+	texts := embeddings.ExtractText(contents)
+	result := make([][]float32, len(texts))
+	for i, text := range texts {
+		e.counts[text]++
+		vec := make([]float32, 26)
+		var norm float64
+		for _, r := range text {
+			if r >= 'a' && r <= 'z' {
+				vec[r-'a']++
+			}
+		}
+		for _, v := range vec {
+			norm += float64(v * v)
+		}
+		for j := range vec {
+			vec[j] /= float32(math.Sqrt(norm))
+		}
+		result[i] = vec
+	}
+	return result, nil
+}
+
+func newLetterEmbedder() *letterEmbedder {
+	return &letterEmbedder{counts: make(map[string]int)}
+}
+
+// ranking returns prompt indices sorted by descending score.
+func ranking(scores []float32) []int {
+	idx := make([]int, len(scores))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int {
+		switch {
+		case scores[a] > scores[b]:
+			return -1
+		case scores[a] < scores[b]:
+			return 1
+		}
+		return 0
+	})
+	return idx
+}
+
+func TestBiEncoderReranker_SharedQueryReusesQueryEmbedding(t *testing.T) {
+	const query = "machine learning"
+	prompts := []string{
+		"cooking recipes for dinner",
+		"deep learning for machines",
+		"learning to garden",
+		"machine maintenance",
+	}
+
+	pairwiseEmbedder := newLetterEmbedder()
+	pairwise, err := NewBiEncoderReranker(pairwiseEmbedder, nil).
+		RerankWithMode(t.Context(), query, prompts, ModePairwise)
+	require.NoError(t, err)
+
+	sharedEmbedder := newLetterEmbedder()
+	shared, err := NewBiEncoderReranker(sharedEmbedder, nil).
+		RerankWithMode(t.Context(), query, prompts, ModeSharedQuery)
+	require.NoError(t, err)
+
+	// The naive path embeds the query once per prompt, the shared path once in total
+	assert.Equal(t, len(prompts), pairwiseEmbedder.counts[query])
+	assert.Equal(t, len(prompts), pairwiseEmbedder.calls)
+	assert.Equal(t, 1, sharedEmbedder.counts[query])
+	assert.Equal(t, 1, sharedEmbedder.calls)
+
+	// Both paths produce the same scores and ranking
+	require.Len(t, shared, len(prompts))
+	assert.InDeltaSlice(t, pairwise, shared, 1e-6)
+	assert.Equal(t, ranking(pairwise), ranking(shared))
+}
+
+func TestBiEncoderReranker_RerankDefaultsToPairwise(t *testing.T) {
+	embedder := newLetterEmbedder()
+	reranker := NewBiEncoderReranker(embedder, nil)
+
+	scores, err := reranker.Rerank(t.Context(), "query", []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Len(t, scores, 2)
+	assert.Equal(t, 2, embedder.counts["query"])
+	assert.Equal(t, TypeBiEncoder, TypeOf(reranker))
+}
+
+// plainReranker is a reranker that neither reports a type nor supports modes.
+type plainReranker struct{}
+
+func (plainReranker) Rerank(ctx context.Context, query string, prompts []string) ([]float32, error) {
+	return make([]float32, len(prompts)), nil
+}
+
+func (plainReranker) Close() error { return nil }
+
+func TestRerankWithMode_UnsupportedMode(t *testing.T) {
+	assert.Equal(t, TypeCrossEncoder, TypeOf(plainReranker{}))
+
+	scores, err := RerankWithMode(t.Context(), plainReranker{}, "q", []string{"a"}, ModePairwise)
+	require.NoError(t, err)
+	assert.Len(t, scores, 1)
+
+	_, err = RerankWithMode(t.Context(), plainReranker{}, "q", []string{"a"}, ModeSharedQuery)
+	assert.ErrorIs(t, err, ErrModeNotSupported)
+}
+
+func TestDetectType(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+		dir := t.TempDir()
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+		return dir
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  Type
+	}{
+		{
+			name:  "sequence classification is a cross-encoder",
+			files: map[string]string{"config.json": `{"architectures": ["XLMRobertaForSequenceClassification"]}`, "modules.json": "[]"},
+			want:  TypeCrossEncoder,
+		},
+		{
+			name:  "sentence-transformers layout is a bi-encoder",
+			files: map[string]string{"config.json": `{"architectures": ["BertModel"]}`, "modules.json": "[]"},
+			want:  TypeBiEncoder,
+		},
+		{
+			name:  "sentence_bert_config marks a bi-encoder",
+			files: map[string]string{"sentence_bert_config.json": `{"max_seq_length": 256}`},
+			want:  TypeBiEncoder,
+		},
+		{
+			name:  "unknown layout defaults to cross-encoder",
+			files: map[string]string{"model.onnx": ""},
+			want:  TypeCrossEncoder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectType(writeFiles(t, tt.files)))
+		})
+	}
+}
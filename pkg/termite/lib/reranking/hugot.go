@@ -104,7 +104,7 @@ func NewHugotRerankerWithSession(modelPath string, onnxFilename string, sharedSe
 	if err != nil {
 		// Only destroy session if we created it (not shared)
 		if !sessionShared {
-			_ = session.Destroy()
+			_ = hugot.ReleaseSession(session)
 		}
 		logger.Error("Failed to create pipeline", zap.Error(err))
 		return nil, fmt.Errorf("creating cross-encoder pipeline: %w", err)
@@ -142,6 +142,13 @@ func (h *HugotReranker) Rerank(ctx context.Context, query string, prompts []stri
 		zap.Int("numPrompts", len(prompts)),
 	)
 
+	// Check context cancellation
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
 	// Run cross-encoder inference
 	h.logger.Info("About to call pipeline.RunPipeline")
 	output, err := h.pipeline.RunPipeline(query, prompts)
@@ -167,12 +174,17 @@ func (h *HugotReranker) Rerank(ctx context.Context, query string, prompts []stri
 	return scores, nil
 }
 
+// Type returns TypeCrossEncoder
+func (h *HugotReranker) Type() Type {
+	return TypeCrossEncoder
+}
+
 // Close releases resources
 // Only destroys the session if it was created by this reranker (not shared)
 func (h *HugotReranker) Close() error {
 	if h.session != nil && !h.sessionShared {
-		h.logger.Info("Destroying Hugot session (owned by this reranker)")
-		return h.session.Destroy()
+		h.logger.Info("Releasing Hugot session (owned by this reranker)")
+		return hugot.ReleaseSession(h.session)
 	} else if h.sessionShared {
 		h.logger.Debug("Skipping session destruction (shared session)")
 	}
@@ -285,7 +297,7 @@ func NewPooledHugotRerankerWithSession(modelPath string, onnxFilename string, po
 		if err != nil {
 			// Clean up already-created pipelines
 			if !sessionShared {
-				_ = session.Destroy()
+				_ = hugot.ReleaseSession(session)
 			}
 			logger.Error("Failed to create pipeline",
 				zap.Int("index", i),
@@ -333,6 +345,13 @@ func (p *PooledHugotReranker) Rerank(ctx context.Context, query string, prompts
 		zap.Int("pipelineIndex", idx),
 		zap.Int("numPrompts", len(prompts)))
 
+	// The slot may have been granted after the deadline
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
 	// Run cross-encoder inference
 	output, err := pipeline.RunPipeline(query, prompts)
 	if err != nil {
@@ -357,12 +376,17 @@ func (p *PooledHugotReranker) Rerank(ctx context.Context, query string, prompts
 	return scores, nil
 }
 
+// Type returns TypeCrossEncoder
+func (p *PooledHugotReranker) Type() Type {
+	return TypeCrossEncoder
+}
+
 // Close releases resources.
 // Only destroys the session if it was created by this reranker (not shared).
 func (p *PooledHugotReranker) Close() error {
 	if p.session != nil && !p.sessionShared {
-		p.logger.Info("Destroying Hugot session (owned by this pooled reranker)")
-		return p.session.Destroy()
+		p.logger.Info("Releasing Hugot session (owned by this pooled reranker)")
+		return hugot.ReleaseSession(p.session)
 	} else if p.sessionShared {
 		p.logger.Debug("Skipping session destruction (shared session)")
 	}
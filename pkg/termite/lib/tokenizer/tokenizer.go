@@ -27,6 +27,7 @@ import (
 	"github.com/sugarme/tokenizer/model/wordpiece"
 	"github.com/sugarme/tokenizer/normalizer"
 	"github.com/sugarme/tokenizer/pretokenizer"
+	"github.com/sugarme/tokenizer/pretrained"
 	"github.com/sugarme/tokenizer/processor"
 	"github.com/sugarme/tokenizer/util"
 )
@@ -41,6 +42,20 @@ type Tokenizer interface {
 	CountTokens(text string) int
 }
 
+// Encoder is a Tokenizer that also exposes token IDs, for inspecting how
+// text is tokenized.
+type Encoder interface {
+	Tokenizer
+
+	// Encode returns the IDs of the tokens in text and the string form of
+	// each token. Its token count matches CountTokens.
+	Encode(text string) (ids []int, tokens []string, err error)
+
+	// Decode converts token IDs back to text. IDs outside the vocabulary
+	// are an error.
+	Decode(ids []int) (string, error)
+}
+
 // BertWordPieceTokenizer uses BERT's WordPiece tokenization.
 // Good for general-purpose text and multilingual content.
 type BertWordPieceTokenizer struct {
@@ -94,7 +109,9 @@ func NewBertWordPieceTokenizer() (*BertWordPieceTokenizer, error) {
 	tk.AddSpecialTokens([]tokenizer.AddedToken{tokenizer.NewAddedToken("[SEP]", true)})
 	tk.AddSpecialTokens([]tokenizer.AddedToken{tokenizer.NewAddedToken("[CLS]", true)})
 
-	tk.WithDecoder(decoder.DefaultWordpieceDecoder())
+	// DefaultWordpieceDecoder leaves its DecoderBase unset, which panics on
+	// Decode; the constructor wires it up
+	tk.WithDecoder(decoder.NewWordPieceDecoder("##", true))
 
 	return &BertWordPieceTokenizer{tokenizer: tk}, nil
 }
@@ -114,6 +131,80 @@ func (t *BertWordPieceTokenizer) CountTokens(text string) int {
 	return len(enc.Ids)
 }
 
+// Encode returns the WordPiece token IDs and tokens of the text.
+func (t *BertWordPieceTokenizer) Encode(text string) ([]int, []string, error) {
+	return encodeWith(t.tokenizer, text)
+}
+
+// Decode converts WordPiece token IDs back to (lowercased) text.
+func (t *BertWordPieceTokenizer) Decode(ids []int) (string, error) {
+	return decodeWith(t.tokenizer, ids)
+}
+
+// HuggingFaceTokenizer counts tokens with a model's own tokenizer.json, so
+// counts match what the model sees at inference time.
+type HuggingFaceTokenizer struct {
+	tokenizer *tokenizer.Tokenizer
+}
+
+// NewHuggingFaceTokenizer loads a HuggingFace tokenizer.json file.
+func NewHuggingFaceTokenizer(path string) (*HuggingFaceTokenizer, error) {
+	tk, err := pretrained.FromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer from %s: %w", path, err)
+	}
+	return &HuggingFaceTokenizer{tokenizer: tk}, nil
+}
+
+// CountTokens returns the number of tokens in the text.
+func (t *HuggingFaceTokenizer) CountTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	enc, err := t.tokenizer.EncodeSingle(text)
+	if err != nil {
+		// Fallback: rough approximation (1 token ≈ 4 chars for English)
+		return len(text) / 4
+	}
+
+	return len(enc.Ids)
+}
+
+// Encode returns the model's token IDs and tokens for the text.
+func (t *HuggingFaceTokenizer) Encode(text string) ([]int, []string, error) {
+	return encodeWith(t.tokenizer, text)
+}
+
+// Decode converts the model's token IDs back to text.
+func (t *HuggingFaceTokenizer) Decode(ids []int) (string, error) {
+	return decodeWith(t.tokenizer, ids)
+}
+
+// encodeWith encodes text the same way CountTokens does, so that the token
+// count matches.
+func encodeWith(tk *tokenizer.Tokenizer, text string) ([]int, []string, error) {
+	if text == "" {
+		return []int{}, []string{}, nil
+	}
+
+	enc, err := tk.EncodeSingle(text)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding text: %w", err)
+	}
+	return enc.Ids, enc.Tokens, nil
+}
+
+// decodeWith decodes ids, leaving out special tokens such as [CLS] and [SEP].
+func decodeWith(tk *tokenizer.Tokenizer, ids []int) (string, error) {
+	for _, id := range ids {
+		if _, ok := tk.IdToToken(id); !ok {
+			return "", fmt.Errorf("token ID %d is not in the vocabulary", id)
+		}
+	}
+	return tk.Decode(ids, true), nil
+}
+
 // BPETokenizer uses OpenAI's tiktoken BPE tokenization.
 // Good for GPT-style models and code.
 type BPETokenizer struct {
@@ -153,3 +244,25 @@ func (t *BPETokenizer) CountTokens(text string) int {
 	tokens := t.tiktoken.Encode(text, nil, nil)
 	return len(tokens)
 }
+
+// Encode returns the BPE token IDs of the text. Tokens are decoded one by
+// one, so a token holding part of a multi-byte character is not valid UTF-8
+// on its own.
+func (t *BPETokenizer) Encode(text string) ([]int, []string, error) {
+	ids := t.tiktoken.Encode(text, nil, nil)
+	tokens := make([]string, len(ids))
+	for i, id := range ids {
+		tokens[i] = t.tiktoken.Decode([]int{id})
+	}
+	return ids, tokens, nil
+}
+
+// Decode converts BPE token IDs back to text.
+func (t *BPETokenizer) Decode(ids []int) (string, error) {
+	for _, id := range ids {
+		if t.tiktoken.Decode([]int{id}) == "" {
+			return "", fmt.Errorf("token ID %d is not in the vocabulary", id)
+		}
+	}
+	return t.tiktoken.Decode(ids), nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"go.uber.org/zap"
+)
+
+// Defaults for the deep liveness check
+const (
+	DefaultLivenessInterval         = 30 * time.Second
+	DefaultLivenessTimeout          = 10 * time.Second
+	DefaultLivenessFailureThreshold = 3
+)
+
+// livenessCanaryText is embedded by each liveness check
+const livenessCanaryText = "liveness check"
+
+// livenessChecker periodically runs a trivial inference and reports the pod
+// unhealthy after threshold consecutive failures. An inference that hangs
+// cannot be abandoned, so while it is still running later checks fail
+// without starting another.
+type livenessChecker struct {
+	logger    *zap.Logger
+	model     string
+	interval  time.Duration
+	timeout   time.Duration
+	threshold int
+	probe     func(ctx context.Context) error
+
+	mu       sync.Mutex
+	failures int   // Consecutive failed checks
+	lastErr  error // Error of the last failed check
+	inFlight bool  // A probe is still running, possibly hung
+}
+
+// newLivenessChecker returns a checker for cfg, or nil when the check is
+// disabled. probe runs one inference against the canary model.
+func newLivenessChecker(cfg LivenessCheck, probe func(ctx context.Context) error, logger *zap.Logger) (*livenessChecker, error) {
+	if cfg.Model == "" {
+		return nil, nil
+	}
+	c := &livenessChecker{
+		logger:    logger,
+		model:     cfg.Model,
+		interval:  DefaultLivenessInterval,
+		timeout:   DefaultLivenessTimeout,
+		threshold: DefaultLivenessFailureThreshold,
+		probe:     probe,
+	}
+	var err error
+	if cfg.Interval != "" {
+		if c.interval, err = time.ParseDuration(cfg.Interval); err != nil || c.interval <= 0 {
+			return nil, fmt.Errorf("invalid liveness_check.interval %q", cfg.Interval)
+		}
+	}
+	if cfg.Timeout != "" {
+		if c.timeout, err = time.ParseDuration(cfg.Timeout); err != nil || c.timeout <= 0 {
+			return nil, fmt.Errorf("invalid liveness_check.timeout %q", cfg.Timeout)
+		}
+	}
+	if cfg.FailureThreshold < 0 {
+		return nil, fmt.Errorf("liveness_check.failure_threshold must be >= 0, got %d", cfg.FailureThreshold)
+	} else if cfg.FailureThreshold > 0 {
+		c.threshold = cfg.FailureThreshold
+	}
+	return c, nil
+}
+
+// run checks every interval until ctx is done
+func (c *livenessChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.check(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check runs one probe, waiting at most timeout for it, and records the result
+func (c *livenessChecker) check(ctx context.Context) {
+	c.mu.Lock()
+	if c.inFlight {
+		c.mu.Unlock()
+		c.record(errors.New("previous check is still running"))
+		return
+	}
+	c.inFlight = true
+	c.mu.Unlock()
+
+	probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	done := make(chan error, 1)
+	go func() {
+		defer cancel()
+		err := c.probe(probeCtx)
+		c.mu.Lock()
+		c.inFlight = false
+		c.mu.Unlock()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		c.record(err)
+	case <-time.After(c.timeout):
+		c.record(fmt.Errorf("inference did not complete within %s", c.timeout))
+	}
+}
+
+// record updates the consecutive failure count with the result of a check
+func (c *livenessChecker) record(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		if c.failures > 0 {
+			c.logger.Info("Liveness check recovered", zap.String("model", c.model))
+		}
+		c.failures, c.lastErr = 0, nil
+		return
+	}
+	c.failures++
+	c.lastErr = err
+	c.logger.Warn("Liveness check failed",
+		zap.String("model", c.model),
+		zap.Int("consecutiveFailures", c.failures),
+		zap.Error(err))
+}
+
+// healthy reports whether fewer than threshold consecutive checks failed,
+// and otherwise the last failure
+func (c *livenessChecker) healthy() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures < c.threshold {
+		return true, nil
+	}
+	return false, c.lastErr
+}
+
+// embedCanary embeds a short text with the named embedder, bypassing the
+// embedding cache so the model itself runs
+func (ln *TermiteNode) embedCanary(model string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if ln.embedderProvider == nil {
+			return errors.New("no embedders configured")
+		}
+		embedder, release, err := ln.embedderProvider.Acquire(ln.modelAliases.Resolve(model))
+		if err != nil {
+			return err
+		}
+		defer release()
+		_, err = embedder.Embed(ctx, [][]ai.ContentPart{{ai.TextContent{Text: livenessCanaryText}}})
+		return err
+	}
+}
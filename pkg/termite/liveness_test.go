@@ -0,0 +1,104 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLivenessCheck_HungModel(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	unblock := make(chan struct{})
+	canary := &MockEmbedder{
+		embedFunc: func(ctx context.Context, values []string) ([][]float32, error) {
+			// A wedged session ignores cancellation
+			<-unblock
+			return [][]float32{{1}}, nil
+		},
+	}
+	node := &TermiteNode{
+		logger: logger,
+		embedderProvider: &EmbedderRegistry{
+			models: map[string]embeddings.Embedder{"canary": canary},
+			logger: logger,
+		},
+	}
+	var err error
+	node.liveness, err = newLivenessChecker(LivenessCheck{
+		Model:            "canary",
+		Timeout:          "10ms",
+		FailureThreshold: 2,
+	}, node.embedCanary("canary"), logger)
+	require.NoError(t, err)
+
+	healthz := func() (int, HealthResponse) {
+		w := httptest.NewRecorder()
+		node.handleHealthz(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var resp HealthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return w.Code, resp
+	}
+
+	// One hung check stays below the threshold
+	node.liveness.check(context.Background())
+	code, _ := healthz()
+	assert.Equal(t, http.StatusOK, code)
+
+	// The probe is still hung, so the next check fails without another inference
+	node.liveness.check(context.Background())
+	code, resp := healthz()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unhealthy", resp.Status)
+	assert.Contains(t, resp.Error, "still running")
+	assert.Equal(t, int32(1), canary.GetCallCount())
+
+	// Once the model responds again, a successful check restores health
+	close(unblock)
+	require.Eventually(t, func() bool {
+		node.liveness.check(context.Background())
+		ok, _ := node.liveness.healthy()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	code, _ = healthz()
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestLivenessCheck_Config(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	probe := func(context.Context) error { return nil }
+
+	c, err := newLivenessChecker(LivenessCheck{}, probe, logger)
+	require.NoError(t, err)
+	assert.Nil(t, c, "the check is opt-in")
+
+	c, err = newLivenessChecker(LivenessCheck{Model: "canary"}, probe, logger)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLivenessInterval, c.interval)
+	assert.Equal(t, DefaultLivenessTimeout, c.timeout)
+	assert.Equal(t, DefaultLivenessFailureThreshold, c.threshold)
+
+	_, err = newLivenessChecker(LivenessCheck{Model: "canary", Interval: "soon"}, probe, logger)
+	assert.ErrorContains(t, err, "liveness_check.interval")
+}
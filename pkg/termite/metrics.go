@@ -85,6 +85,16 @@ var (
 		[]string{"model", "type"},
 	)
 
+	modelMemoryEstimateBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "antfly",
+			Subsystem: "termite",
+			Name:      "model_memory_estimate_bytes",
+			Help:      "Estimate of the memory held by a loaded model: the size of its ONNX files times the pipeline pool size, not measured allocation.",
+		},
+		[]string{"model", "type"},
+	)
+
 	requestDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "antfly",
@@ -162,6 +172,37 @@ var (
 			Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
 		},
 	)
+
+	// Per-model concurrency metrics
+	modelInFlightRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "antfly",
+			Subsystem: "termite",
+			Name:      "model_in_flight_requests",
+			Help:      "Number of inference requests currently running per model.",
+		},
+		[]string{"model"},
+	)
+
+	modelQueuedRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "antfly",
+			Subsystem: "termite",
+			Name:      "model_queued_requests",
+			Help:      "Number of requests waiting for a per-model inference slot.",
+		},
+		[]string{"model"},
+	)
+
+	modelRejectedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "antfly",
+			Subsystem: "termite",
+			Name:      "model_rejected_total",
+			Help:      "Total number of requests rejected because a model was at its concurrency limit.",
+		},
+		[]string{"model"},
+	)
 )
 
 func init() {
@@ -172,6 +213,7 @@ func init() {
 	prometheus.MustRegister(chunkerRequestOps)
 	prometheus.MustRegister(chunkCreationOps)
 	prometheus.MustRegister(modelLoadDuration)
+	prometheus.MustRegister(modelMemoryEstimateBytes)
 	prometheus.MustRegister(requestDuration)
 	prometheus.MustRegister(cacheHits)
 	prometheus.MustRegister(cacheMisses)
@@ -180,6 +222,9 @@ func init() {
 	prometheus.MustRegister(queueRejectedTotal)
 	prometheus.MustRegister(queueTimedOutTotal)
 	prometheus.MustRegister(queueWaitDuration)
+	prometheus.MustRegister(modelInFlightRequests)
+	prometheus.MustRegister(modelQueuedRequests)
+	prometheus.MustRegister(modelRejectedTotal)
 }
 
 // RecordModelLoadDuration records how long it took to load a model
@@ -187,6 +232,16 @@ func RecordModelLoadDuration(model, modelType string, seconds float64) {
 	modelLoadDuration.WithLabelValues(model, modelType).Observe(seconds)
 }
 
+// SetModelMemoryEstimate records the estimated memory held by a loaded model
+func SetModelMemoryEstimate(model, modelType string, bytes int64) {
+	modelMemoryEstimateBytes.WithLabelValues(model, modelType).Set(float64(bytes))
+}
+
+// ClearModelMemoryEstimate drops the memory estimate of an unloaded model
+func ClearModelMemoryEstimate(model, modelType string) {
+	modelMemoryEstimateBytes.DeleteLabelValues(model, modelType)
+}
+
 // RecordRequestDuration records how long a request took
 func RecordRequestDuration(endpoint, model, status string, seconds float64) {
 	requestDuration.WithLabelValues(endpoint, model, status).Observe(seconds)
@@ -223,6 +278,17 @@ func RecordQueueWaitTime(seconds float64) {
 	queueWaitDuration.Observe(seconds)
 }
 
+// UpdateModelQueueMetrics updates the per-model concurrency gauges
+func UpdateModelQueueMetrics(model string, stats QueueStats) {
+	modelInFlightRequests.WithLabelValues(model).Set(float64(stats.CurrentActive))
+	modelQueuedRequests.WithLabelValues(model).Set(float64(stats.CurrentQueued))
+}
+
+// RecordModelRejection increments the per-model rejected counter
+func RecordModelRejection(model string) {
+	modelRejectedTotal.WithLabelValues(model).Inc()
+}
+
 // RecordRerankerRequest increments the reranker request counter
 func RecordRerankerRequest(model string) {
 	rerankerRequestOps.WithLabelValues(model).Inc()
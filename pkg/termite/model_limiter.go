@@ -0,0 +1,143 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic/encoder"
+	"go.uber.org/zap"
+)
+
+// modelBusyRetryAfter is the Retry-After sent when a model is at its
+// concurrency limit; inference slots typically free up within seconds
+const modelBusyRetryAfter = 1 * time.Second
+
+// ModelLimiter bounds concurrent inference per model so a burst against one
+// model cannot exhaust GPU memory or CPU. Each model gets its own
+// RequestQueue, created on first use.
+type ModelLimiter struct {
+	config RequestQueueConfig
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	queues map[string]*RequestQueue
+}
+
+// NewModelLimiter creates a limiter allowing maxConcurrent in-flight requests
+// per model with up to maxQueued waiting. maxQueued of 0 rejects requests
+// that find every slot busy. It returns nil (no limit) when maxConcurrent is 0.
+func NewModelLimiter(maxConcurrent, maxQueued int, logger *zap.Logger) *ModelLimiter {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	queueSize := maxQueued
+	if queueSize <= 0 {
+		queueSize = -1 // RequestQueue treats 0 as unbounded
+	}
+	logger.Info("Per-model concurrency limit enabled",
+		zap.Int("max_concurrent_per_model", maxConcurrent),
+		zap.Int("max_queued_per_model", max(maxQueued, 0)))
+
+	return &ModelLimiter{
+		config: RequestQueueConfig{
+			MaxConcurrentRequests: maxConcurrent,
+			MaxQueueSize:          queueSize,
+		},
+		logger: logger,
+		queues: make(map[string]*RequestQueue),
+	}
+}
+
+// Acquire waits for an inference slot for model. The returned release must be
+// called once inference finishes, whether or not it succeeded. It returns
+// ErrQueueFull when the model's queue is full and ErrRequestTimeout when ctx
+// expires while queued. A nil limiter admits every request.
+func (l *ModelLimiter) Acquire(ctx context.Context, model string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	q := l.queue(model)
+	qRelease, err := q.Acquire(ctx)
+	if err != nil {
+		if errors.Is(err, ErrQueueFull) {
+			RecordModelRejection(model)
+		}
+		UpdateModelQueueMetrics(model, q.Stats())
+		return nil, err
+	}
+	UpdateModelQueueMetrics(model, q.Stats())
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			qRelease()
+			UpdateModelQueueMetrics(model, q.Stats())
+		})
+	}, nil
+}
+
+// Stats returns the queue statistics for model
+func (l *ModelLimiter) Stats(model string) QueueStats {
+	if l == nil {
+		return QueueStats{}
+	}
+	return l.queue(model).Stats()
+}
+
+func (l *ModelLimiter) queue(model string) *RequestQueue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	q, ok := l.queues[model]
+	if !ok {
+		q = NewRequestQueue(l.config, l.logger.Named(model))
+		l.queues[model] = q
+	}
+	return q
+}
+
+// writeModelLimitError writes the response for a failed ModelLimiter.Acquire
+func writeModelLimitError(w http.ResponseWriter, model string, err error) {
+	switch {
+	case errors.Is(err, ErrQueueFull):
+		WriteModelBusyResponse(w, model, modelBusyRetryAfter)
+	case errors.Is(err, ErrRequestTimeout):
+		WriteTimeoutResponse(w)
+	default:
+		http.Error(w, "request cancelled", http.StatusRequestTimeout)
+	}
+}
+
+// WriteModelBusyResponse writes a 429 response with Retry-After header
+func WriteModelBusyResponse(w http.ResponseWriter, model string, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = encoder.NewStreamEncoder(w).Encode(Error{
+		Error: fmt.Sprintf("model %s is at its concurrency limit, please retry later", model),
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestModelLimiter_RejectsBeyondLimit(t *testing.T) {
+	limiter := NewModelLimiter(1, 0, zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	release, err := limiter.Acquire(ctx, "model-a")
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire(ctx, "model-a")
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	// Limits are per model
+	releaseB, err := limiter.Acquire(ctx, "model-b")
+	require.NoError(t, err)
+	releaseB()
+
+	release()
+	release() // Releasing twice must not free a second slot
+	assert.Equal(t, int64(0), limiter.Stats("model-a").CurrentActive)
+
+	release, err = limiter.Acquire(ctx, "model-a")
+	require.NoError(t, err)
+	release()
+}
+
+func TestModelLimiter_ReleaseFreesSlot(t *testing.T) {
+	limiter := NewModelLimiter(1, 0, zaptest.NewLogger(t))
+
+	// Run in a goroutine so a release that never returns fails the test
+	// instead of hanging it
+	done := make(chan error, 1)
+	go func() {
+		for range 3 {
+			release, err := limiter.Acquire(context.Background(), "model-a")
+			if err != nil {
+				done <- err
+				return
+			}
+			release()
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("acquire/release cycle did not complete")
+	}
+	assert.Equal(t, int64(0), limiter.Stats("model-a").CurrentActive)
+}
+
+func TestModelLimiter_QueuesUpToBound(t *testing.T) {
+	limiter := NewModelLimiter(1, 1, zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	release, err := limiter.Acquire(ctx, "model-a")
+	require.NoError(t, err)
+
+	acquired := make(chan error, 1)
+	go func() {
+		queuedRelease, err := limiter.Acquire(ctx, "model-a")
+		if err == nil {
+			queuedRelease()
+		}
+		acquired <- err
+	}()
+	require.Eventually(t, func() bool {
+		return limiter.Stats("model-a").CurrentQueued == 1
+	}, time.Second, time.Millisecond)
+
+	// The queue is full; a third request is rejected
+	_, err = limiter.Acquire(ctx, "model-a")
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	release()
+	select {
+	case err := <-acquired:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("queued request was not admitted after release")
+	}
+}
+
+func TestModelLimiter_QueuedRequestTimesOut(t *testing.T) {
+	limiter := NewModelLimiter(1, 1, zaptest.NewLogger(t))
+
+	release, err := limiter.Acquire(context.Background(), "model-a")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx, "model-a")
+	assert.ErrorIs(t, err, ErrRequestTimeout)
+	assert.Equal(t, int64(0), limiter.Stats("model-a").CurrentQueued)
+}
+
+func TestModelLimiter_Disabled(t *testing.T) {
+	limiter := NewModelLimiter(0, 0, zaptest.NewLogger(t))
+	assert.Nil(t, limiter)
+
+	for range 3 {
+		release, err := limiter.Acquire(context.Background(), "model-a")
+		require.NoError(t, err)
+		defer release()
+	}
+}
+
+func TestTermiteNode_HandleApiEmbed_ModelLimit(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	started := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	fail := true
+	embedder := &MockEmbedder{
+		embedFunc: func(ctx context.Context, values []string) ([][]float32, error) {
+			if fail {
+				return nil, errors.New("inference failed")
+			}
+			started <- struct{}{}
+			<-unblock
+			return [][]float32{{1}, {2}}, nil
+		},
+	}
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+	node.modelLimiter = NewModelLimiter(1, 0, logger.Named("model-limiter"))
+	handler := NewTermiteAPI(logger, node)
+
+	// A failed inference releases its slot
+	w := postEmbed(t, handler, "bge-small-en")
+	require.Equal(t, http.StatusInternalServerError, w.Code, w.Body.String())
+	fail = false
+
+	// Hold the only slot with a request blocked in the embedder
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() { done <- postEmbed(t, handler, "bge-small-en") }()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("first request did not reach the embedder; slot not released after error?")
+	}
+
+	w = postEmbed(t, handler, "bge-small-en")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "bge-small-en")
+
+	close(unblock)
+	w = <-done
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, int64(0), node.modelLimiter.Stats("bge-small-en").CurrentActive)
+}
+
+func TestTermiteNode_HandleApiChunk_ModelLimitUnknownModels(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	cachedChunker, err := NewCachedChunker("", nil, logger.Named("chunker"))
+	require.NoError(t, err)
+	defer func() { _ = cachedChunker.Close() }()
+
+	node := &TermiteNode{
+		logger:        logger,
+		cachedChunker: cachedChunker,
+		requestQueue:  NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		modelLimiter:  NewModelLimiter(1, 0, logger.Named("model-limiter")),
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	// Hold the fixed chunker's only slot
+	release, err := node.modelLimiter.Acquire(t.Context(), "default")
+	require.NoError(t, err)
+
+	// Unknown names run the fixed chunker, so they share its limit rather
+	// than each getting a queue of their own
+	for _, model := range []string{"", "bogus-1", "bogus-2", "bogus-3"} {
+		body := `{"text": "alpha beta gamma", "config": {"model": "` + model + `"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/chunk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, "model %q: %s", model, w.Body.String())
+	}
+	assert.Len(t, node.modelLimiter.queues, 1)
+
+	release()
+	req := httptest.NewRequest(http.MethodPost, "/api/chunk", strings.NewReader(`{"text": "alpha", "config": {"model": "bogus-1"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import "sync"
+
+// modelRefs counts the requests using each loaded model so that a model
+// removed by a reload or evicted from the lazy cache is closed only after
+// the last of them finishes. Models are tracked by identity, so they must be
+// comparable (in practice they are pointers). The zero value is ready to use.
+type modelRefs struct {
+	mu      sync.Mutex
+	active  map[any]int    // model -> in-flight requests
+	retired map[any]func() // model -> close, deferred until active reaches zero
+}
+
+// acquire records a request using model. The returned release must be called
+// once the request is done with it; extra calls are ignored.
+func (m *modelRefs) acquire(model any) (release func()) {
+	if m == nil {
+		return func() {}
+	}
+	m.mu.Lock()
+	if m.active == nil {
+		m.active = make(map[any]int)
+	}
+	m.active[model]++
+	m.mu.Unlock()
+
+	var once sync.Once
+	return func() { once.Do(func() { m.release(model) }) }
+}
+
+func (m *modelRefs) release(model any) {
+	m.mu.Lock()
+	m.active[model]--
+	var closeModel func()
+	if m.active[model] <= 0 {
+		delete(m.active, model)
+		closeModel = m.retired[model]
+		delete(m.retired, model)
+	}
+	m.mu.Unlock()
+
+	if closeModel != nil {
+		closeModel()
+	}
+}
+
+// retire calls closeModel once no request is using model, immediately if it
+// is idle. The caller must have removed model from its lookup map first so
+// that no new request can acquire it.
+func (m *modelRefs) retire(model any, closeModel func()) {
+	if m != nil {
+		m.mu.Lock()
+		if m.active[model] > 0 {
+			if m.retired == nil {
+				m.retired = make(map[any]func())
+			}
+			m.retired[model] = closeModel
+			m.mu.Unlock()
+			return
+		}
+		m.mu.Unlock()
+	}
+	closeModel()
+}
+
+// inUse returns the number of requests currently using model
+func (m *modelRefs) inUse(model any) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.active[model]
+}
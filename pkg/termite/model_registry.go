@@ -19,7 +19,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/antflydb/antfly-go/libaf/chunking"
 	"github.com/antflydb/antfly-go/libaf/embeddings"
@@ -52,32 +54,23 @@ func discoverModelVariants(modelPath string) map[string]string {
 	return variants
 }
 
-// ChunkerRegistry manages multiple chunker models loaded from a directory
-type ChunkerRegistry struct {
-	models map[string]chunking.Chunker // model name -> chunker instance
-	mu     sync.RWMutex
-	logger *zap.Logger
+// modelFile identifies a single loadable model variant on disk.
+type modelFile struct {
+	Path         string   // model directory
+	OnnxFilename string   // e.g., "model.onnx", "model_i8.onnx"
+	Variants     []string // all variant IDs found in Path (e.g., ["default", "i8"])
 }
 
-// NewChunkerRegistry creates a registry and discovers models in the given directory
-// Directory structure: modelsDir/model_name/model.onnx
-// If sharedSession is provided, all models will share the same Hugot session (required for ONNX Runtime)
-func NewChunkerRegistry(modelsDir string, sharedSession *khugot.Session, logger *zap.Logger) (*ChunkerRegistry, error) {
-	registry := &ChunkerRegistry{
-		models: make(map[string]chunking.Chunker),
-		logger: logger,
-	}
-
+// discoverModelFiles scans modelsDir and returns every model variant keyed by
+// registry name ("<dir>" for the default variant, "<dir>-<variant>" otherwise).
+// A missing or unset directory yields no models.
+func discoverModelFiles(modelsDir string, modelType string, logger *zap.Logger) (map[string]modelFile, error) {
+	found := make(map[string]modelFile)
 	if modelsDir == "" {
-		logger.Info("No chunker models directory configured, only built-in fixed tokenizer models available")
-		return registry, nil
+		return found, nil
 	}
-
-	// Check if directory exists
 	if _, err := os.Stat(modelsDir); os.IsNotExist(err) {
-		logger.Warn("Chunker models directory does not exist, only built-in fixed tokenizer models available",
-			zap.String("dir", modelsDir))
-		return registry, nil
+		return found, nil
 	}
 
 	// Scan directory for model subdirectories
@@ -113,42 +106,184 @@ func NewChunkerRegistry(modelsDir string, sharedSession *khugot.Session, logger
 				variantIDs = append(variantIDs, v)
 			}
 		}
-		logger.Info("Discovered chunker model directory",
+		logger.Info("Discovered "+modelType+" model directory",
 			zap.String("name", modelName),
 			zap.String("path", modelPath),
 			zap.Strings("variants", variantIDs))
 
-		// Pool size for concurrent pipeline access
-		// Cap at 4 to avoid excessive memory usage (each pipeline loads full model)
-		poolSize := min(runtime.NumCPU(), 4)
-
-		// Load each variant
 		for variantID, onnxFilename := range variants {
 			// Determine registry name
 			registryName := modelName
 			if variantID != "" {
 				registryName = modelName + "-" + variantID
 			}
+			found[registryName] = modelFile{
+				Path:         modelPath,
+				OnnxFilename: onnxFilename,
+				Variants:     variantIDs,
+			}
+		}
+	}
+
+	return found, nil
+}
 
-			// Create chunker config for this model with sensible defaults
-			config := termchunking.DefaultHugotChunkerConfig()
+// ReloadResult summarizes the changes made by re-scanning a models directory.
+type ReloadResult struct {
+	Added   []string          `json:"added"`
+	Removed []string          `json:"removed"`
+	Failed  map[string]string `json:"failed,omitempty"` // model name -> load error
+}
 
-			// Pass model path, ONNX filename, and shared session to pooled chunker
-			chunker, err := termchunking.NewPooledHugotChunkerWithSession(config, modelPath, onnxFilename, poolSize, sharedSession, logger.Named(registryName))
-			if err != nil {
-				logger.Warn("Failed to load chunker model variant",
-					zap.String("name", registryName),
-					zap.String("onnxFile", onnxFilename),
+// reloadModels syncs a registry's loaded models with the models found on disk.
+// New models are loaded without holding the registry lock so in-flight requests
+// are not blocked, and each is published as soon as it is loaded. Models
+// already loaded are kept as-is, and models no longer on disk are removed and
+// closed once refs shows no request still using them. A model that fails to
+// load is recorded in the result without affecting the others. Each model is
+// loaded or removed under its own reload lock, so concurrent reloads do not
+// load a model twice but do not wait on each other's unrelated models.
+func reloadModels[T any](
+	mu *sync.RWMutex,
+	models map[string]T,
+	refs *modelRefs,
+	found map[string]modelFile,
+	modelType string,
+	load func(name string, mf modelFile) (T, error),
+	closeModel func(T) error,
+	logger *zap.Logger,
+) *ReloadResult {
+	result := &ReloadResult{}
+
+	mu.RLock()
+	var toLoad, toRemove []string
+	for name := range found {
+		if _, ok := models[name]; !ok {
+			toLoad = append(toLoad, name)
+		}
+	}
+	for name := range models {
+		if _, ok := found[name]; !ok {
+			toRemove = append(toRemove, name)
+		}
+	}
+	mu.RUnlock()
+	sort.Strings(toLoad)
+	sort.Strings(toRemove)
+
+	for _, name := range toLoad {
+		unlock := reloadLocks.lock(modelType + "/" + name)
+
+		// A concurrent reload may have loaded it while we waited
+		mu.RLock()
+		_, loaded := models[name]
+		mu.RUnlock()
+		if loaded {
+			unlock()
+			continue
+		}
+
+		start := time.Now()
+		model, err := load(name, found[name])
+		if err != nil {
+			unlock()
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[name] = err.Error()
+			continue
+		}
+		RecordModelLoadDuration(name, modelType, time.Since(start).Seconds())
+
+		mu.Lock()
+		models[name] = model
+		mu.Unlock()
+		unlock()
+		result.Added = append(result.Added, name)
+	}
+
+	for _, name := range toRemove {
+		unlock := reloadLocks.lock(modelType + "/" + name)
+
+		mu.Lock()
+		model, ok := models[name]
+		delete(models, name)
+		mu.Unlock()
+		if !ok {
+			// Already removed by a concurrent reload
+			unlock()
+			continue
+		}
+		result.Removed = append(result.Removed, name)
+
+		// Requests that acquired the model before its removal keep it open
+		// until they release it
+		ClearModelMemoryEstimate(name, modelType)
+		refs.retire(model, func() {
+			if err := closeModel(model); err != nil {
+				logger.Warn("Error closing removed model",
+					zap.String("name", name),
 					zap.Error(err))
-			} else {
-				registry.models[registryName] = chunker
-				logger.Info("Successfully loaded chunker model",
-					zap.String("name", registryName),
-					zap.String("onnxFile", onnxFilename),
-					zap.Int("poolSize", poolSize))
 			}
+		})
+		unlock()
+	}
+
+	return result
+}
+
+// estimateModelMemory approximates the memory held by a loaded model. The
+// runtime does not report per-model usage, but each pipeline in a pool loads
+// its own copy of the ONNX weights, including external data files.
+func estimateModelMemory(mf modelFile, poolSize int) int64 {
+	onnxFilename := mf.OnnxFilename
+	if onnxFilename == "" {
+		onnxFilename = "model.onnx"
+	}
+	var size int64
+	for _, name := range []string{onnxFilename, onnxFilename + "_data", onnxFilename + ".data"} {
+		if info, err := os.Stat(filepath.Join(mf.Path, name)); err == nil {
+			size += info.Size()
 		}
 	}
+	return size * int64(max(poolSize, 1))
+}
+
+// ChunkerRegistry manages multiple chunker models loaded from a directory
+type ChunkerRegistry struct {
+	models        map[string]chunking.Chunker // model name -> chunker instance
+	modelsDir     string
+	sharedSession *khugot.Session
+	mu            sync.RWMutex
+	logger        *zap.Logger
+}
+
+// NewChunkerRegistry creates a registry and discovers models in the given directory
+// Directory structure: modelsDir/model_name/model.onnx
+// If sharedSession is provided, all models will share the same Hugot session (required for ONNX Runtime)
+func NewChunkerRegistry(modelsDir string, sharedSession *khugot.Session, logger *zap.Logger) (*ChunkerRegistry, error) {
+	registry := &ChunkerRegistry{
+		models:        make(map[string]chunking.Chunker),
+		modelsDir:     modelsDir,
+		sharedSession: sharedSession,
+		logger:        logger,
+	}
+
+	if modelsDir == "" {
+		logger.Info("No chunker models directory configured, only built-in fixed tokenizer models available")
+		return registry, nil
+	}
+
+	// Check if directory exists
+	if _, err := os.Stat(modelsDir); os.IsNotExist(err) {
+		logger.Warn("Chunker models directory does not exist, only built-in fixed tokenizer models available",
+			zap.String("dir", modelsDir))
+		return registry, nil
+	}
+
+	if _, err := registry.Reload(); err != nil {
+		return nil, err
+	}
 
 	logger.Info("Chunker registry initialized",
 		zap.Int("models_loaded", len(registry.models)))
@@ -156,6 +291,44 @@ func NewChunkerRegistry(modelsDir string, sharedSession *khugot.Session, logger
 	return registry, nil
 }
 
+// Reload re-scans the models directory, loading new models and dropping
+// models that were removed from disk. Already loaded models are untouched.
+func (r *ChunkerRegistry) Reload() (*ReloadResult, error) {
+	found, err := discoverModelFiles(r.modelsDir, "chunker", r.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pool size for concurrent pipeline access
+	// Cap at 4 to avoid excessive memory usage (each pipeline loads full model)
+	poolSize := min(runtime.NumCPU(), 4)
+
+	load := func(name string, mf modelFile) (chunking.Chunker, error) {
+		// Create chunker config for this model with sensible defaults
+		config := termchunking.DefaultHugotChunkerConfig()
+
+		// Pass model path, ONNX filename, and shared session to pooled chunker
+		chunker, err := termchunking.NewPooledHugotChunkerWithSession(config, mf.Path, mf.OnnxFilename, poolSize, r.sharedSession, r.logger.Named(name))
+		if err != nil {
+			r.logger.Warn("Failed to load chunker model variant",
+				zap.String("name", name),
+				zap.String("onnxFile", mf.OnnxFilename),
+				zap.Error(err))
+			return nil, err
+		}
+		SetModelMemoryEstimate(name, "chunker", estimateModelMemory(mf, poolSize))
+		r.logger.Info("Successfully loaded chunker model",
+			zap.String("name", name),
+			zap.String("onnxFile", mf.OnnxFilename),
+			zap.Int("poolSize", poolSize))
+		return chunker, nil
+	}
+
+	closeModel := func(c chunking.Chunker) error { return c.Close() }
+
+	return reloadModels(&r.mu, r.models, nil, found, "chunker", load, closeModel, r.logger), nil
+}
+
 // Get returns a chunker by model name
 func (r *ChunkerRegistry) Get(modelName string) (chunking.Chunker, error) {
 	r.mu.RLock()
@@ -163,12 +336,12 @@ func (r *ChunkerRegistry) Get(modelName string) (chunking.Chunker, error) {
 
 	chunker, ok := r.models[modelName]
 	if !ok {
-		return nil, fmt.Errorf("chunker model not found: %s", modelName)
+		return nil, fmt.Errorf("chunker %w: %s", ErrModelNotFound, modelName)
 	}
 	return chunker, nil
 }
 
-// List returns all available model names
+// List returns all available model names, sorted
 func (r *ChunkerRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -177,6 +350,7 @@ func (r *ChunkerRegistry) List() []string {
 	for name := range r.models {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -197,17 +371,22 @@ func (r *ChunkerRegistry) Close() error {
 
 // RerankerRegistry manages multiple reranker models loaded from a directory
 type RerankerRegistry struct {
-	models map[string]reranking.Model // model name -> reranker instance
-	mu     sync.RWMutex
-	logger *zap.Logger
+	models        map[string]reranking.Model // model name -> reranker instance
+	modelsDir     string
+	sharedSession *khugot.Session
+	mu            sync.RWMutex
+	logger        *zap.Logger
+	refs          modelRefs // In-flight uses of loaded models
 }
 
 // NewRerankerRegistry creates a registry and discovers models in the given directory
 // If sharedSession is provided, all models will share the same Hugot session (required for ONNX Runtime)
 func NewRerankerRegistry(modelsDir string, sharedSession *khugot.Session, logger *zap.Logger) (*RerankerRegistry, error) {
 	registry := &RerankerRegistry{
-		models: make(map[string]reranking.Model),
-		logger: logger,
+		models:        make(map[string]reranking.Model),
+		modelsDir:     modelsDir,
+		sharedSession: sharedSession,
+		logger:        logger,
 	}
 
 	if modelsDir == "" {
@@ -222,77 +401,64 @@ func NewRerankerRegistry(modelsDir string, sharedSession *khugot.Session, logger
 		return registry, nil
 	}
 
-	// Scan directory for model subdirectories
-	entries, err := os.ReadDir(modelsDir)
-	if err != nil {
-		return nil, fmt.Errorf("reading models directory: %w", err)
+	if _, err := registry.Reload(); err != nil {
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		modelName := entry.Name()
-		modelPath := filepath.Join(modelsDir, modelName)
-
-		// Discover all available model variants
-		variants := discoverModelVariants(modelPath)
+	logger.Info("Reranker registry initialized",
+		zap.Int("models_loaded", len(registry.models)))
 
-		// Skip if no model files exist
-		if len(variants) == 0 {
-			logger.Debug("Skipping directory without model files",
-				zap.String("dir", modelName))
-			continue
-		}
+	return registry, nil
+}
 
-		// Log discovered variants
-		variantIDs := make([]string, 0, len(variants))
-		for v := range variants {
-			if v == "" {
-				variantIDs = append(variantIDs, "default")
-			} else {
-				variantIDs = append(variantIDs, v)
-			}
-		}
-		logger.Info("Discovered reranker model directory",
-			zap.String("name", modelName),
-			zap.String("path", modelPath),
-			zap.Strings("variants", variantIDs))
+// Reload re-scans the models directory, loading new models and dropping
+// models that were removed from disk. Already loaded models are untouched.
+func (r *RerankerRegistry) Reload() (*ReloadResult, error) {
+	found, err := discoverModelFiles(r.modelsDir, "reranker", r.logger)
+	if err != nil {
+		return nil, err
+	}
 
-		// Pool size for concurrent pipeline access
-		// Cap at 4 to avoid excessive memory usage (each pipeline loads full model)
-		poolSize := min(runtime.NumCPU(), 4)
+	// Pool size for concurrent pipeline access
+	// Cap at 4 to avoid excessive memory usage (each pipeline loads full model)
+	poolSize := min(runtime.NumCPU(), 4)
 
-		// Load each variant
-		for variantID, onnxFilename := range variants {
-			// Determine registry name
-			registryName := modelName
-			if variantID != "" {
-				registryName = modelName + "-" + variantID
-			}
+	load := func(name string, mf modelFile) (reranking.Model, error) {
+		rerankerType := termreranking.DetectType(mf.Path)
 
-			// Pass model path, ONNX filename, and shared session to pooled reranker
-			model, err := termreranking.NewPooledHugotRerankerWithSession(modelPath, onnxFilename, poolSize, sharedSession, logger.Named(registryName))
-			if err != nil {
-				logger.Warn("Failed to load reranker model variant",
-					zap.String("name", registryName),
-					zap.String("onnxFile", onnxFilename),
-					zap.Error(err))
-			} else {
-				registry.models[registryName] = model
-				logger.Info("Successfully loaded reranker model",
-					zap.String("name", registryName),
-					zap.String("onnxFile", onnxFilename),
-					zap.Int("poolSize", poolSize))
+		// Pass model path, ONNX filename, and shared session to the pooled model
+		var model reranking.Model
+		var err error
+		switch rerankerType {
+		case termreranking.TypeBiEncoder:
+			var embedder *termembeddings.PooledHugotEmbedder
+			embedder, err = termembeddings.NewPooledHugotEmbedderWithSession(mf.Path, mf.OnnxFilename, poolSize, r.sharedSession, r.logger.Named(name))
+			if err == nil {
+				model = termreranking.NewBiEncoderReranker(embedder, r.logger.Named(name))
 			}
+		default:
+			model, err = termreranking.NewPooledHugotRerankerWithSession(mf.Path, mf.OnnxFilename, poolSize, r.sharedSession, r.logger.Named(name))
+		}
+		if err != nil {
+			r.logger.Warn("Failed to load reranker model variant",
+				zap.String("name", name),
+				zap.String("onnxFile", mf.OnnxFilename),
+				zap.String("type", string(rerankerType)),
+				zap.Error(err))
+			return nil, err
 		}
+		SetModelMemoryEstimate(name, "reranker", estimateModelMemory(mf, poolSize))
+		r.logger.Info("Successfully loaded reranker model",
+			zap.String("name", name),
+			zap.String("onnxFile", mf.OnnxFilename),
+			zap.String("type", string(rerankerType)),
+			zap.Int("poolSize", poolSize))
+		return model, nil
 	}
 
-	logger.Info("Reranker registry initialized",
-		zap.Int("models_loaded", len(registry.models)))
+	closeModel := func(m reranking.Model) error { return m.Close() }
 
-	return registry, nil
+	return reloadModels(&r.mu, r.models, &r.refs, found, "reranker", load, closeModel, r.logger), nil
 }
 
 // Get returns a reranker by model name
@@ -302,12 +468,25 @@ func (r *RerankerRegistry) Get(modelName string) (reranking.Model, error) {
 
 	model, ok := r.models[modelName]
 	if !ok {
-		return nil, fmt.Errorf("reranker model not found: %s", modelName)
+		return nil, fmt.Errorf("reranker %w: %s", ErrModelNotFound, modelName)
 	}
 	return model, nil
 }
 
-// List returns all available model names
+// Acquire returns a reranker by model name for use by a request. The model
+// stays open, even if a reload removes it, until release is called.
+func (r *RerankerRegistry) Acquire(modelName string) (model reranking.Model, release func(), err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	model, ok := r.models[modelName]
+	if !ok {
+		return nil, nil, fmt.Errorf("reranker %w: %s", ErrModelNotFound, modelName)
+	}
+	return model, r.refs.acquire(model), nil
+}
+
+// List returns all available model names, sorted
 func (r *RerankerRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -316,9 +495,22 @@ func (r *RerankerRegistry) List() []string {
 	for name := range r.models {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
+// Types returns the reranker type (cross-encoder or bi-encoder) of each model
+func (r *RerankerRegistry) Types() map[string]termreranking.Type {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make(map[string]termreranking.Type, len(r.models))
+	for name, model := range r.models {
+		types[name] = termreranking.TypeOf(model)
+	}
+	return types
+}
+
 // Close closes all loaded models
 func (r *RerankerRegistry) Close() error {
 	r.mu.Lock()
@@ -336,17 +528,22 @@ func (r *RerankerRegistry) Close() error {
 
 // EmbedderRegistry manages multiple embedder models loaded from a directory
 type EmbedderRegistry struct {
-	models map[string]embeddings.Embedder // model name -> embedder instance
-	mu     sync.RWMutex
-	logger *zap.Logger
+	models        map[string]embeddings.Embedder // model name -> embedder instance
+	modelsDir     string
+	sharedSession *khugot.Session
+	mu            sync.RWMutex
+	logger        *zap.Logger
+	refs          modelRefs // In-flight uses of loaded models
 }
 
 // NewEmbedderRegistry creates a registry and discovers models in the given directory
 // If sharedSession is provided, all models will share the same Hugot session (required for ONNX Runtime)
 func NewEmbedderRegistry(modelsDir string, sharedSession *khugot.Session, logger *zap.Logger) (*EmbedderRegistry, error) {
 	registry := &EmbedderRegistry{
-		models: make(map[string]embeddings.Embedder),
-		logger: logger,
+		models:        make(map[string]embeddings.Embedder),
+		modelsDir:     modelsDir,
+		sharedSession: sharedSession,
+		logger:        logger,
 	}
 
 	if modelsDir == "" {
@@ -361,77 +558,47 @@ func NewEmbedderRegistry(modelsDir string, sharedSession *khugot.Session, logger
 		return registry, nil
 	}
 
-	// Scan directory for model subdirectories
-	entries, err := os.ReadDir(modelsDir)
-	if err != nil {
-		return nil, fmt.Errorf("reading models directory: %w", err)
+	if _, err := registry.Reload(); err != nil {
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		modelName := entry.Name()
-		modelPath := filepath.Join(modelsDir, modelName)
-
-		// Discover all available model variants
-		variants := discoverModelVariants(modelPath)
-
-		// Skip if no model files exist
-		if len(variants) == 0 {
-			logger.Debug("Skipping directory without model files",
-				zap.String("dir", modelName))
-			continue
-		}
+	logger.Info("Embedder registry initialized",
+		zap.Int("models_loaded", len(registry.models)))
 
-		// Log discovered variants
-		variantIDs := make([]string, 0, len(variants))
-		for v := range variants {
-			if v == "" {
-				variantIDs = append(variantIDs, "default")
-			} else {
-				variantIDs = append(variantIDs, v)
-			}
-		}
-		logger.Info("Discovered embedder model directory",
-			zap.String("name", modelName),
-			zap.String("path", modelPath),
-			zap.Strings("variants", variantIDs))
+	return registry, nil
+}
 
-		// Pool size for concurrent pipeline access
-		// Cap at 4 to avoid excessive memory usage (each pipeline loads full model)
-		poolSize := min(runtime.NumCPU(), 4)
+// Reload re-scans the models directory, loading new models and dropping
+// models that were removed from disk. Already loaded models are untouched.
+func (r *EmbedderRegistry) Reload() (*ReloadResult, error) {
+	found, err := discoverModelFiles(r.modelsDir, "embedder", r.logger)
+	if err != nil {
+		return nil, err
+	}
 
-		// Load each variant
-		for variantID, onnxFilename := range variants {
-			// Determine registry name
-			registryName := modelName
-			if variantID != "" {
-				registryName = modelName + "-" + variantID
-			}
+	// Pool size for concurrent pipeline access
+	// Cap at 4 to avoid excessive memory usage (each pipeline loads full model)
+	poolSize := min(runtime.NumCPU(), 4)
 
-			// Pass model path, ONNX filename, and shared session to pooled embedder
-			model, err := termembeddings.NewPooledHugotEmbedderWithSession(modelPath, onnxFilename, poolSize, sharedSession, logger.Named(registryName))
-			if err != nil {
-				logger.Warn("Failed to load embedder model variant",
-					zap.String("name", registryName),
-					zap.String("onnxFile", onnxFilename),
-					zap.Error(err))
-			} else {
-				registry.models[registryName] = model
-				logger.Info("Successfully loaded embedder model",
-					zap.String("name", registryName),
-					zap.String("onnxFile", onnxFilename),
-					zap.Int("poolSize", poolSize))
-			}
+	load := func(name string, mf modelFile) (embeddings.Embedder, error) {
+		// Pass model path, ONNX filename, and shared session to pooled embedder
+		model, err := termembeddings.NewPooledHugotEmbedderWithSession(mf.Path, mf.OnnxFilename, poolSize, r.sharedSession, r.logger.Named(name))
+		if err != nil {
+			r.logger.Warn("Failed to load embedder model variant",
+				zap.String("name", name),
+				zap.String("onnxFile", mf.OnnxFilename),
+				zap.Error(err))
+			return nil, err
 		}
+		SetModelMemoryEstimate(name, "embedder", estimateModelMemory(mf, poolSize))
+		r.logger.Info("Successfully loaded embedder model",
+			zap.String("name", name),
+			zap.String("onnxFile", mf.OnnxFilename),
+			zap.Int("poolSize", poolSize))
+		return model, nil
 	}
 
-	logger.Info("Embedder registry initialized",
-		zap.Int("models_loaded", len(registry.models)))
-
-	return registry, nil
+	return reloadModels(&r.mu, r.models, &r.refs, found, "embedder", load, closeEmbedder, r.logger), nil
 }
 
 // Get returns an embedder by model name
@@ -441,12 +608,25 @@ func (r *EmbedderRegistry) Get(modelName string) (embeddings.Embedder, error) {
 
 	model, ok := r.models[modelName]
 	if !ok {
-		return nil, fmt.Errorf("embedder model not found: %s", modelName)
+		return nil, fmt.Errorf("embedder %w: %s", ErrModelNotFound, modelName)
 	}
 	return model, nil
 }
 
-// List returns all available model names
+// Acquire returns an embedder by model name for use by a request. The model
+// stays open, even if a reload removes it, until release is called.
+func (r *EmbedderRegistry) Acquire(modelName string) (model embeddings.Embedder, release func(), err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	model, ok := r.models[modelName]
+	if !ok {
+		return nil, nil, fmt.Errorf("embedder %w: %s", ErrModelNotFound, modelName)
+	}
+	return model, r.refs.acquire(model), nil
+}
+
+// List returns all available model names, sorted
 func (r *EmbedderRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -455,6 +635,7 @@ func (r *EmbedderRegistry) List() []string {
 	for name := range r.models {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -464,14 +645,7 @@ func (r *EmbedderRegistry) Close() error {
 	defer r.mu.Unlock()
 
 	for name, model := range r.models {
-		var err error
-		switch emb := model.(type) {
-		case *termembeddings.HugotEmbedder:
-			err = emb.Close()
-		case *termembeddings.PooledHugotEmbedder:
-			err = emb.Close()
-		}
-		if err != nil {
+		if err := closeEmbedder(model); err != nil {
 			r.logger.Warn("Error closing embedder model",
 				zap.String("name", name),
 				zap.Error(err))
@@ -479,3 +653,14 @@ func (r *EmbedderRegistry) Close() error {
 	}
 	return nil
 }
+
+// closeEmbedder closes embedders that hold resources.
+func closeEmbedder(model embeddings.Embedder) error {
+	switch emb := model.(type) {
+	case *termembeddings.HugotEmbedder:
+		return emb.Close()
+	case *termembeddings.PooledHugotEmbedder:
+		return emb.Close()
+	}
+	return nil
+}
@@ -16,19 +16,53 @@ package termite
 
 import (
 	"context"
-	"math"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/antflydb/antfly-go/libaf/chunking"
 	"github.com/antflydb/antfly-go/libaf/embeddings"
 	"github.com/antflydb/antfly-go/libaf/reranking"
 	"github.com/antflydb/termite/pkg/termite/lib/hugot"
 	termreranking "github.com/antflydb/termite/pkg/termite/lib/reranking"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
+// registryTestNames returns model names in reverse order, so that a listing
+// in insertion or map order is unlikely to come out sorted
+func registryTestNames() []string {
+	names := make([]string, 20)
+	for i := range names {
+		names[i] = fmt.Sprintf("model-%02d", len(names)-i)
+	}
+	return names
+}
+
+func TestRegistryList_Sorted(t *testing.T) {
+	names := registryTestNames()
+	want := slices.Sorted(slices.Values(names))
+
+	embedders := &EmbedderRegistry{models: map[string]embeddings.Embedder{}}
+	chunkers := &ChunkerRegistry{models: map[string]chunking.Chunker{}}
+	rerankers := &RerankerRegistry{models: map[string]reranking.Model{}}
+	lazy := &LazyEmbedderRegistry{discovered: map[string]*ModelInfo{}}
+	for _, name := range names {
+		embedders.models[name] = nil
+		chunkers.models[name] = nil
+		rerankers.models[name] = nil
+		lazy.discovered[name] = &ModelInfo{Name: name}
+	}
+
+	assert.Equal(t, want, embedders.List())
+	assert.Equal(t, want, chunkers.List())
+	assert.Equal(t, want, rerankers.List())
+	assert.Equal(t, want, lazy.List())
+}
+
 // skipIfNoModels skips the test if the models directory doesn't exist or is empty
 func skipIfNoModels(t testing.TB, modelsDir string) {
 	t.Helper()
@@ -64,7 +98,7 @@ func TestRerankerRegistryLoading(t *testing.T) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(t, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	// Create registry
 	registry, err := NewRerankerRegistry(modelsDir, session, logger)
@@ -184,7 +218,7 @@ func TestCompareAllRerankerModels(t *testing.T) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(t, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	// Create registry
 	registry, err := NewRerankerRegistry(modelsDir, session, logger)
@@ -375,7 +409,7 @@ func BenchmarkAllRerankerModels(b *testing.B) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(b, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	// Create registry once for all benchmarks
 	registry, err := NewRerankerRegistry(modelsDir, session, logger)
@@ -432,7 +466,7 @@ func TestEmbedderRegistryLoading(t *testing.T) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(t, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	// Create registry
 	registry, err := NewEmbedderRegistry(modelsDir, session, logger)
@@ -467,7 +501,7 @@ func TestEmbedderModelEmbedding(t *testing.T) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(t, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	// Create registry
 	registry, err := NewEmbedderRegistry(modelsDir, session, logger)
@@ -519,7 +553,7 @@ func TestEmbedderQuantizedVsNonQuantized(t *testing.T) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(t, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	// Create registry
 	registry, err := NewEmbedderRegistry(modelsDir, session, logger)
@@ -605,7 +639,7 @@ func BenchmarkEmbedderQuantizedVsNonQuantized(b *testing.B) {
 	// Create hugot session
 	session, err := hugot.NewSession()
 	require.NoError(b, err)
-	defer func() { _ = session.Destroy() }()
+	defer func() { _ = hugot.ReleaseSession(session) }()
 
 	registry, err := NewEmbedderRegistry(modelsDir, session, logger)
 	require.NoError(b, err)
@@ -634,23 +668,3 @@ func BenchmarkEmbedderQuantizedVsNonQuantized(b *testing.B) {
 		})
 	}
 }
-
-// cosineSimilarity computes cosine similarity between two vectors
-func cosineSimilarity(a, b []float32) float32 {
-	if len(a) != len(b) {
-		return 0
-	}
-
-	var dotProduct, normA, normB float64
-	for i := range a {
-		dotProduct += float64(a[i]) * float64(b[i])
-		normA += float64(a[i]) * float64(a[i])
-		normB += float64(b[i]) * float64(b[i])
-	}
-
-	if normA == 0 || normB == 0 {
-		return 0
-	}
-
-	return float32(dotProduct / (math.Sqrt(normA) * math.Sqrt(normB)))
-}
@@ -0,0 +1,100 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+)
+
+// QuantizedVariantSuffix names the quantized variant of a multimodal model,
+// registered alongside its full-precision variant (e.g. "clip-vit-base-i8-qt"
+// for the quantized encoders of "clip-vit-base")
+const QuantizedVariantSuffix = "-i8-qt"
+
+// Model variants a lookup may ask for explicitly
+const (
+	VariantFull      = "full"      // Only the full-precision model
+	VariantQuantized = "quantized" // Only a quantized variant
+)
+
+// quantizedSuffixes are the name suffixes of quantized variants, in order of
+// preference: the multimodal suffix, then the quantized registry variants
+// named "<model>-<variant>"
+var quantizedSuffixes = []string{
+	QuantizedVariantSuffix,
+	"-" + modelregistry.VariantI8,
+	"-" + modelregistry.VariantI8Static,
+	"-" + modelregistry.VariantI4,
+}
+
+// splitQuantizedSuffix returns the base model name of a quantized variant
+// name, and whether name had a quantized suffix
+func splitQuantizedSuffix(name string) (base string, quantized bool) {
+	for _, suffix := range quantizedSuffixes {
+		if base, ok := strings.CutSuffix(name, suffix); ok && base != "" {
+			return base, true
+		}
+	}
+	return name, false
+}
+
+// resolveModelVariant returns the registered name serving model in the
+// requested variant, where has reports whether a name is registered.
+//
+// With no variant, a registered name is used as is, so both variants stay
+// addressable by their own names; an unregistered full-precision name falls
+// back to its quantized variant. VariantFull and VariantQuantized select the
+// variant of model's base name regardless of which name was given.
+func resolveModelVariant(model, variant string, has func(string) bool) (string, error) {
+	base, _ := splitQuantizedSuffix(model)
+	switch variant {
+	case "":
+		if has(model) {
+			return model, nil
+		}
+		if base != model {
+			break // A quantized name does not fall back
+		}
+		if name, ok := findQuantized(base, has); ok {
+			return name, nil
+		}
+	case VariantFull:
+		if has(base) {
+			return base, nil
+		}
+		return "", fmt.Errorf("full-precision variant of %s not loaded: %w", base, ErrModelNotFound)
+	case VariantQuantized:
+		if name, ok := findQuantized(base, has); ok {
+			return name, nil
+		}
+		return "", fmt.Errorf("quantized variant of %s not loaded: %w", base, ErrModelNotFound)
+	default:
+		return "", fmt.Errorf("invalid variant %q: must be %q or %q", variant, VariantFull, VariantQuantized)
+	}
+	return "", fmt.Errorf("%w: %s", ErrModelNotFound, model)
+}
+
+// findQuantized returns the first registered quantized variant of base
+func findQuantized(base string, has func(string) bool) (string, bool) {
+	for _, suffix := range quantizedSuffixes {
+		if has(base + suffix) {
+			return base + suffix, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveModelVariant(t *testing.T) {
+	registered := func(names ...string) func(string) bool {
+		return func(name string) bool {
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	both := registered("clip-vit-base", "clip-vit-base-i8-qt")
+	quantizedOnly := registered("clip-vit-base-i8-qt")
+
+	tests := []struct {
+		name    string
+		model   string
+		variant string
+		has     func(string) bool
+		want    string
+		wantErr string
+	}{
+		{name: "full precision by name", model: "clip-vit-base", has: both, want: "clip-vit-base"},
+		{name: "quantized by name", model: "clip-vit-base-i8-qt", has: both, want: "clip-vit-base-i8-qt"},
+		{name: "falls back to quantized", model: "clip-vit-base", has: quantizedOnly, want: "clip-vit-base-i8-qt"},
+		{name: "registry variant suffix", model: "bge-small", has: registered("bge-small-i8"), want: "bge-small-i8"},
+		{name: "explicit quantized", model: "clip-vit-base", variant: VariantQuantized, has: both, want: "clip-vit-base-i8-qt"},
+		{name: "explicit full from quantized name", model: "clip-vit-base-i8-qt", variant: VariantFull, has: both, want: "clip-vit-base"},
+		{name: "explicit full not loaded", model: "clip-vit-base", variant: VariantFull, has: quantizedOnly, wantErr: "full-precision variant of clip-vit-base not loaded"},
+		{name: "quantized name does not fall back", model: "clip-vit-base-i8-qt", has: registered("clip-vit-base"), wantErr: "model not found"},
+		{name: "unknown model", model: "siglip", has: both, wantErr: "model not found: siglip"},
+		{name: "invalid variant", model: "clip-vit-base", variant: "fp8", has: both, wantErr: "invalid variant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveModelVariant(tt.model, tt.variant, tt.has)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
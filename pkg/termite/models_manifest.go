@@ -0,0 +1,201 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+	"go.yaml.in/yaml/v3"
+)
+
+// ModelsManifest declares the models a node serves. In Kubernetes it is
+// typically mounted from a ConfigMap and referenced by models_manifest:
+//
+//	models:
+//	  - name: bge-small-en-v1.5
+//	    variants: [i8]
+//	    aliases: [text-embedding-3-small]
+//	    strategy: eager
+//	  - name: mxbai-rerank-base-v1
+//	    type: reranker
+type ModelsManifest struct {
+	Models []ManifestModel `yaml:"models"`
+}
+
+// ManifestModel is a model declared in a ModelsManifest
+type ManifestModel struct {
+	// Name is the model directory name, as pulled from the registry
+	Name string `yaml:"name"`
+
+	// Type is embedder (default), chunker or reranker
+	Type string `yaml:"type,omitempty"`
+
+	// Variants lists the variants to load (default: f32). The first one
+	// is the target of the model's aliases.
+	Variants []string `yaml:"variants,omitempty"`
+
+	// Aliases are extra names the model is served under
+	Aliases []string `yaml:"aliases,omitempty"`
+
+	// Strategy is the loading strategy of an embedder: eager, lazy or bounded
+	Strategy ConfigModelStrategies `yaml:"strategy,omitempty"`
+}
+
+// LoadModelsManifest reads and validates a models manifest file
+func LoadModelsManifest(path string) (*ModelsManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading models manifest: %w", err)
+	}
+	return ParseModelsManifest(data)
+}
+
+// ParseModelsManifest parses and validates a models manifest. Unknown fields
+// are rejected so that typos do not silently drop settings.
+func ParseModelsManifest(data []byte) (*ModelsManifest, error) {
+	var manifest ModelsManifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parsing models manifest: %w", err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Validate checks that every model has a unique name, a known type, valid
+// variants and strategy, and aliases that name no other model or alias.
+func (m *ModelsManifest) Validate() error {
+	validVariants := slices.Sorted(maps.Keys(modelregistry.VariantFilenames))
+
+	var errs []error
+	names := make(map[string]bool)
+	aliases := make(map[string]bool)
+	for i, model := range m.Models {
+		if model.Name == "" {
+			errs = append(errs, fmt.Errorf("models[%d]: name is required", i))
+			continue
+		}
+		if names[model.Name] {
+			errs = append(errs, fmt.Errorf("models[%d]: duplicate model %s", i, model.Name))
+		}
+		names[model.Name] = true
+
+		if model.Type != "" {
+			if _, err := modelregistry.ParseModelType(model.Type); err != nil {
+				errs = append(errs, fmt.Errorf("models[%d] (%s): %w", i, model.Name, err))
+			}
+		}
+		for _, variant := range model.Variants {
+			if _, ok := modelregistry.VariantFilenames[variant]; !ok {
+				errs = append(errs, fmt.Errorf("models[%d] (%s): unknown variant %q (valid: %s)",
+					i, model.Name, variant, strings.Join(validVariants, ", ")))
+			}
+		}
+		switch model.Strategy {
+		case "", ConfigModelStrategiesEager, ConfigModelStrategiesLazy, ConfigModelStrategiesBounded:
+		default:
+			errs = append(errs, fmt.Errorf("models[%d] (%s): unknown strategy %q (valid: eager, lazy, bounded)",
+				i, model.Name, model.Strategy))
+		}
+		for _, alias := range model.Aliases {
+			if alias == "" {
+				errs = append(errs, fmt.Errorf("models[%d] (%s): aliases cannot be empty", i, model.Name))
+				continue
+			}
+			if aliases[alias] {
+				errs = append(errs, fmt.Errorf("models[%d] (%s): duplicate alias %s", i, model.Name, alias))
+			}
+			aliases[alias] = true
+		}
+	}
+	for alias := range aliases {
+		if names[alias] {
+			errs = append(errs, fmt.Errorf("alias %s shadows a declared model", alias))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid models manifest: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// modelType returns the declared type of the model, embedder by default
+func (m ManifestModel) modelType() modelregistry.ModelType {
+	t, err := modelregistry.ParseModelType(m.Type)
+	if err != nil {
+		return modelregistry.ModelTypeEmbedder
+	}
+	return t
+}
+
+// RegistryNames returns the names the model's variants are loaded under:
+// the model name for the default variant, "<name>-<variant>" otherwise
+func (m ManifestModel) RegistryNames() []string {
+	if len(m.Variants) == 0 {
+		return []string{m.Name}
+	}
+	names := make([]string, 0, len(m.Variants))
+	for _, variant := range m.Variants {
+		if variant == modelregistry.VariantF32 {
+			names = append(names, m.Name)
+		} else {
+			names = append(names, m.Name+"-"+variant)
+		}
+	}
+	return names
+}
+
+// apply merges the manifest into config: declared embedders are preloaded,
+// and pinned when eager, and aliases point at each model's first variant.
+// Preloads, strategies and aliases already set in config take precedence.
+func (m *ModelsManifest) apply(config *Config) {
+	for _, model := range m.Models {
+		names := model.RegistryNames()
+		if model.modelType() == modelregistry.ModelTypeEmbedder {
+			for _, name := range names {
+				if !slices.Contains(config.Preload, name) {
+					config.Preload = append(config.Preload, name)
+				}
+				if model.Strategy == "" {
+					continue
+				}
+				if config.ModelStrategies == nil {
+					config.ModelStrategies = make(map[string]ConfigModelStrategies)
+				}
+				if _, ok := config.ModelStrategies[name]; !ok {
+					config.ModelStrategies[name] = model.Strategy
+				}
+			}
+		}
+		for _, alias := range model.Aliases {
+			if config.ModelAliases == nil {
+				config.ModelAliases = make(map[string]string)
+			}
+			if _, ok := config.ModelAliases[alias]; !ok {
+				config.ModelAliases[alias] = names[0]
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleModelsManifest = `
+models:
+  - name: bge-small-en-v1.5
+    variants: [i8, f32]
+    aliases: [text-embedding-3-small]
+    strategy: eager
+  - name: mxbai-rerank-base-v1
+    type: reranker
+    aliases: [rerank-default]
+`
+
+func TestLoadModelsManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(sampleModelsManifest), 0644))
+
+	manifest, err := LoadModelsManifest(path)
+	require.NoError(t, err)
+	require.Len(t, manifest.Models, 2)
+
+	assert.Equal(t, []string{"bge-small-en-v1.5-i8", "bge-small-en-v1.5"}, manifest.Models[0].RegistryNames())
+	assert.Equal(t, []string{"mxbai-rerank-base-v1"}, manifest.Models[1].RegistryNames())
+}
+
+func TestModelsManifest_Apply(t *testing.T) {
+	manifest, err := ParseModelsManifest([]byte(sampleModelsManifest))
+	require.NoError(t, err)
+
+	config := Config{
+		Preload:         []string{"bge-small-en-v1.5"},
+		ModelStrategies: map[string]ConfigModelStrategies{"bge-small-en-v1.5": ConfigModelStrategiesLazy},
+		ModelAliases:    map[string]string{"rerank-default": "other-reranker"},
+	}
+	manifest.apply(&config)
+
+	// Rerankers are not preloaded; models already listed are not repeated
+	assert.Equal(t, []string{"bge-small-en-v1.5", "bge-small-en-v1.5-i8"}, config.Preload)
+	assert.Equal(t, map[string]ConfigModelStrategies{
+		"bge-small-en-v1.5":    ConfigModelStrategiesLazy,
+		"bge-small-en-v1.5-i8": ConfigModelStrategiesEager,
+	}, config.ModelStrategies)
+	assert.Equal(t, map[string]string{
+		"text-embedding-3-small": "bge-small-en-v1.5-i8",
+		"rerank-default":         "other-reranker",
+	}, config.ModelAliases)
+}
+
+func TestParseModelsManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		errMsg   string
+	}{
+		{
+			name:     "unknown field",
+			manifest: "models:\n  - name: bge\n    variant: i8\n",
+			errMsg:   "field variant not found",
+		},
+		{
+			name:     "unknown variant",
+			manifest: "models:\n  - name: bge\n    variants: [int8]\n",
+			errMsg:   `unknown variant "int8"`,
+		},
+		{
+			name:     "unknown type",
+			manifest: "models:\n  - name: bge\n    type: generator\n",
+			errMsg:   "models[0] (bge)",
+		},
+		{
+			name:     "unknown strategy",
+			manifest: "models:\n  - name: bge\n    strategy: always\n",
+			errMsg:   `unknown strategy "always"`,
+		},
+		{
+			name:     "duplicate model",
+			manifest: "models:\n  - name: bge\n  - name: bge\n",
+			errMsg:   "duplicate model bge",
+		},
+		{
+			name:     "duplicate alias",
+			manifest: "models:\n  - name: a\n    aliases: [x]\n  - name: b\n    aliases: [x]\n",
+			errMsg:   "duplicate alias x",
+		},
+		{
+			name:     "alias shadows model",
+			manifest: "models:\n  - name: a\n    aliases: [b]\n  - name: b\n",
+			errMsg:   "alias b shadows a declared model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseModelsManifest([]byte(tt.manifest))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
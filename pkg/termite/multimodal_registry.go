@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/antflydb/antfly-go/libaf/embeddings"
@@ -42,6 +43,9 @@ type MultimodalEmbedderRegistry struct {
 // It scans the models directory for CLIP-style models containing:
 //   - visual_model.onnx (or visual_model_quantized.onnx)
 //   - text_model.onnx (or text_model_quantized.onnx)
+//
+// The ONNX files may be at the top of the model directory or one
+// subdirectory down (e.g. onnx/).
 func NewMultimodalEmbedderRegistry(modelsDir string, logger *zap.Logger) (*MultimodalEmbedderRegistry, error) {
 	registry := &MultimodalEmbedderRegistry{
 		models: make(map[string]embeddings.Embedder),
@@ -75,13 +79,8 @@ func NewMultimodalEmbedderRegistry(modelsDir string, logger *zap.Logger) (*Multi
 		modelPath := filepath.Join(modelsDir, modelName)
 
 		// Check for CLIP-style model structure
-		visualPath := filepath.Join(modelPath, "visual_model.onnx")
-		textPath := filepath.Join(modelPath, "text_model.onnx")
-		visualQuantizedPath := filepath.Join(modelPath, "visual_model_quantized.onnx")
-		textQuantizedPath := filepath.Join(modelPath, "text_model_quantized.onnx")
-
-		hasStandard := fileExists(visualPath) && fileExists(textPath)
-		hasQuantized := fileExists(visualQuantizedPath) && fileExists(textQuantizedPath)
+		hasStandard := termembeddings.HasCLIPModelFiles(modelPath, false)
+		hasQuantized := termembeddings.HasCLIPModelFiles(modelPath, true)
 
 		if !hasStandard && !hasQuantized {
 			logger.Debug("Skipping directory without CLIP model files",
@@ -109,9 +108,9 @@ func NewMultimodalEmbedderRegistry(modelsDir string, logger *zap.Logger) (*Multi
 			}
 		}
 
-		// Load quantized model if it exists (register with -i8-qt suffix)
+		// Load quantized model if it exists (register with QuantizedVariantSuffix)
 		if hasQuantized {
-			quantizedName := modelName + "-i8-qt"
+			quantizedName := modelName + QuantizedVariantSuffix
 			model, err := termembeddings.NewCLIPEmbedder(modelPath, true, logger.Named(quantizedName))
 			if err != nil {
 				logger.Warn("Failed to load quantized CLIP model",
@@ -131,19 +130,29 @@ func NewMultimodalEmbedderRegistry(modelsDir string, logger *zap.Logger) (*Multi
 	return registry, nil
 }
 
-// Get returns an embedder by model name
+// Get returns an embedder by model name. A full-precision name that is not
+// loaded falls back to its quantized variant (see resolveModelVariant).
 func (r *MultimodalEmbedderRegistry) Get(modelName string) (embeddings.Embedder, error) {
+	return r.GetVariant(modelName, "")
+}
+
+// GetVariant returns the embedder for variant of a model: "" for Get's
+// behavior, VariantFull or VariantQuantized to pick one explicitly
+func (r *MultimodalEmbedderRegistry) GetVariant(modelName, variant string) (embeddings.Embedder, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	model, ok := r.models[modelName]
-	if !ok {
-		return nil, fmt.Errorf("multimodal model not found: %s", modelName)
+	name, err := resolveModelVariant(modelName, variant, func(name string) bool {
+		_, ok := r.models[name]
+		return ok
+	})
+	if err != nil {
+		return nil, fmt.Errorf("multimodal %w", err)
 	}
-	return model, nil
+	return r.models[name], nil
 }
 
-// List returns all available model names
+// List returns all available model names, sorted
 func (r *MultimodalEmbedderRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -152,6 +161,7 @@ func (r *MultimodalEmbedderRegistry) List() []string {
 	for name := range r.models {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -171,8 +181,3 @@ func (r *MultimodalEmbedderRegistry) Close() error {
 	}
 	return nil
 }
-
-func fileExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
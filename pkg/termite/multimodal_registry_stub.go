@@ -43,6 +43,11 @@ func (r *MultimodalEmbedderRegistry) Get(modelName string) (embeddings.Embedder,
 	return nil, fmt.Errorf("multimodal model %s not available: build with -tags=\"onnx,ORT\" to enable CLIP support", modelName)
 }
 
+// GetVariant always returns an error when CLIP support is disabled.
+func (r *MultimodalEmbedderRegistry) GetVariant(modelName, variant string) (embeddings.Embedder, error) {
+	return r.Get(modelName)
+}
+
 // List returns an empty list when CLIP support is disabled.
 func (r *MultimodalEmbedderRegistry) List() []string {
 	return nil
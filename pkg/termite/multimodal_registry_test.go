@@ -0,0 +1,65 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build onnx && ORT
+
+package termite
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/embeddings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultimodalEmbedderRegistry_ListSorted(t *testing.T) {
+	names := registryTestNames()
+	registry := &MultimodalEmbedderRegistry{models: map[string]embeddings.Embedder{}}
+	for _, name := range names {
+		registry.models[name] = nil
+	}
+
+	assert.Equal(t, slices.Sorted(slices.Values(names)), registry.List())
+}
+
+func TestMultimodalEmbedderRegistry_QuantizedVariant(t *testing.T) {
+	full, quantized := &MockEmbedder{}, &MockEmbedder{}
+	registry := &MultimodalEmbedderRegistry{models: map[string]embeddings.Embedder{
+		"clip-vit-base":                          full,
+		"clip-vit-base" + QuantizedVariantSuffix: quantized,
+		"siglip" + QuantizedVariantSuffix:        quantized,
+	}}
+
+	// Both variants stay addressable by name
+	got, err := registry.Get("clip-vit-base")
+	require.NoError(t, err)
+	assert.Same(t, full, got)
+	got, err = registry.Get("clip-vit-base-i8-qt")
+	require.NoError(t, err)
+	assert.Same(t, quantized, got)
+
+	// A model loaded only quantized is served under its base name
+	got, err = registry.Get("siglip")
+	require.NoError(t, err)
+	assert.Same(t, quantized, got)
+
+	got, err = registry.GetVariant("clip-vit-base", VariantQuantized)
+	require.NoError(t, err)
+	assert.Same(t, quantized, got)
+
+	_, err = registry.GetVariant("siglip", VariantFull)
+	assert.Error(t, err)
+}
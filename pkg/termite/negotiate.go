@@ -0,0 +1,85 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// negotiateContentType returns the offered media type that the Accept header
+// of r prefers. Each offer takes the q-value of the most specific media range
+// matching it (type/subtype, then type/*, then */*), and ties go to the
+// earlier offer. The first offer is the default, returned when the header is
+// missing or accepts none of the offers.
+func negotiateContentType(r *http.Request, offers ...string) string {
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+	var ranges []mediaRange
+	for _, value := range r.Header.Values("Accept") {
+		for field := range strings.SplitSeq(value, ",") {
+			if strings.TrimSpace(field) == "" {
+				continue
+			}
+			mediaType, params, err := mime.ParseMediaType(field)
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(v, 64); err != nil {
+					continue
+				}
+			}
+			ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+		}
+	}
+
+	best, bestQ := offers[0], 0.0
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, rng := range ranges {
+			if s := mediaRangeSpecificity(rng.mediaType, offer); s > specificity {
+				q, specificity = rng.q, s
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// mediaRangeSpecificity returns how specifically mediaRange matches
+// mediaType: 2 for an exact match, 1 for type/*, 0 for */* and -1 when it
+// does not match
+func mediaRangeSpecificity(mediaRange, mediaType string) int {
+	if mediaRange == mediaType {
+		return 2
+	}
+	if mediaRange == "*/*" {
+		return 0
+	}
+	rangeType, subtype, _ := strings.Cut(mediaRange, "/")
+	offerType, _, _ := strings.Cut(mediaType, "/")
+	if subtype == "*" && rangeType == offerType {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/octet-stream", "application/json"}
+	tests := []struct {
+		accept []string
+		want   string
+	}{
+		{nil, "application/octet-stream"},
+		{[]string{"application/json"}, "application/json"},
+		{[]string{"application/json; q=0.9"}, "application/json"},
+		{[]string{"text/html, application/json;q=0.8, */*;q=0.1"}, "application/json"},
+		{[]string{"application/octet-stream;q=0.2, application/json"}, "application/json"},
+		{[]string{"application/json;q=0.5, application/octet-stream"}, "application/octet-stream"},
+		{[]string{"application/*"}, "application/octet-stream"},
+		{[]string{"*/*;q=0.5, application/json;q=0"}, "application/octet-stream"},
+		{[]string{"text/html"}, "application/octet-stream"},
+		{[]string{"text/html", "application/json"}, "application/json"},
+		{[]string{"application/json;q=bogus"}, "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/api/embed", nil)
+		for _, v := range tt.accept {
+			r.Header.Add("Accept", v)
+		}
+		assert.Equal(t, tt.want, negotiateContentType(r, offers...), "Accept: %q", tt.accept)
+	}
+}
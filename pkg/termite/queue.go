@@ -36,7 +36,7 @@ var (
 // RequestQueue manages concurrent request limiting and queuing with backpressure
 type RequestQueue struct {
 	maxConcurrent int64         // Max concurrent requests (0 = unlimited)
-	maxQueueSize  int64         // Max queued requests (0 = unlimited)
+	maxQueueSize  int64         // Max queued requests (0 = unlimited, <0 = no queue)
 	timeout       time.Duration // Request timeout (0 = no timeout)
 
 	// Semaphore channel for concurrency control
@@ -55,7 +55,7 @@ type RequestQueue struct {
 // RequestQueueConfig holds configuration for the request queue
 type RequestQueueConfig struct {
 	MaxConcurrentRequests int           // 0 = unlimited
-	MaxQueueSize          int           // 0 = unlimited (only when MaxConcurrent > 0)
+	MaxQueueSize          int           // 0 = unlimited, <0 = reject instead of queuing (only when MaxConcurrent > 0)
 	RequestTimeout        time.Duration // 0 = no timeout
 }
 
@@ -89,7 +89,12 @@ func NewRequestQueue(config RequestQueueConfig, logger *zap.Logger) *RequestQueu
 // Acquire attempts to acquire a slot for processing a request.
 // Returns a release function that must be called when the request is done.
 // Returns an error if the queue is full or the context is cancelled.
+// A nil queue admits every request.
 func (q *RequestQueue) Acquire(ctx context.Context) (release func(), err error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
 	// If no concurrency limit, just track metrics
 	if q.sem == nil {
 		q.currentActive.Add(1)
@@ -123,9 +128,9 @@ func (q *RequestQueue) Acquire(ctx context.Context) (release func(), err error)
 	}
 
 	// Check queue capacity before waiting
-	if q.maxQueueSize > 0 {
+	if q.maxQueueSize != 0 {
 		queued := q.currentQueued.Load()
-		if queued >= q.maxQueueSize {
+		if q.maxQueueSize < 0 || queued >= q.maxQueueSize {
 			q.totalRejected.Add(1)
 			q.logger.Warn("Request rejected: queue full",
 				zap.Int64("queued", queued),
@@ -176,6 +181,9 @@ func (q *RequestQueue) makeRelease() func() {
 
 // Stats returns current queue statistics
 func (q *RequestQueue) Stats() QueueStats {
+	if q == nil {
+		return QueueStats{}
+	}
 	return QueueStats{
 		CurrentActive:  q.currentActive.Load(),
 		CurrentQueued:  q.currentQueued.Load(),
@@ -0,0 +1,229 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/sonic/encoder"
+	"go.uber.org/zap"
+)
+
+// NodeReloadResult reports the outcome of reloading each model registry.
+type NodeReloadResult struct {
+	Embedders *ReloadResult `json:"embedders,omitempty"`
+	Chunkers  *ReloadResult `json:"chunkers,omitempty"`
+	Rerankers *ReloadResult `json:"rerankers,omitempty"`
+}
+
+// reloadLocks serializes loading and removing each model across reloads
+// triggered concurrently via SIGHUP and the admin endpoint, so that a model is
+// never loaded twice while reloads of unrelated models proceed in parallel.
+var reloadLocks modelLocks
+
+// modelLocks is a set of mutexes keyed by model, created on demand and
+// dropped once no caller holds or waits for them
+type modelLocks struct {
+	mu    sync.Mutex
+	locks map[string]*modelLock
+}
+
+type modelLock struct {
+	mu    sync.Mutex
+	users int // Holders and waiters
+}
+
+// lock locks the mutex of key and returns its unlock function
+func (l *modelLocks) lock(key string) (unlock func()) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*modelLock)
+	}
+	ml, ok := l.locks[key]
+	if !ok {
+		ml = &modelLock{}
+		l.locks[key] = ml
+	}
+	ml.users++
+	l.mu.Unlock()
+
+	ml.mu.Lock()
+	return func() {
+		ml.mu.Unlock()
+		l.mu.Lock()
+		if ml.users--; ml.users == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Reload re-scans the models directory and updates every registry in place.
+// Newly added models become available, removed models are dropped, and models
+// that are unchanged keep their loaded instances and caches. Requests that are
+// already running are not interrupted.
+//
+// A registry that fails to re-scan does not prevent the others from reloading;
+// the returned error joins all registry errors. Individual model load failures
+// are reported in the result rather than as an error.
+func (ln *TermiteNode) Reload() (*NodeReloadResult, error) {
+	result := &NodeReloadResult{}
+	var errs []error
+
+	switch {
+	case ln.lazyEmbedderRegistry != nil:
+		res, err := ln.lazyEmbedderRegistry.Reload()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reloading embedders: %w", err))
+		}
+		result.Embedders = res
+	case ln.embedderRegistry != nil:
+		res, err := ln.embedderRegistry.Reload()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reloading embedders: %w", err))
+		}
+		result.Embedders = res
+	}
+
+	if ln.cachedChunker != nil {
+		res, err := ln.cachedChunker.Reload()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reloading chunkers: %w", err))
+		}
+		result.Chunkers = res
+	}
+
+	if ln.rerankerRegistry != nil {
+		res, err := ln.rerankerRegistry.Reload()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reloading rerankers: %w", err))
+		}
+		result.Rerankers = res
+	}
+
+	// Cached results of added or removed models may come from different
+	// weights stored under the same name
+	ln.invalidateReloadedCaches(result)
+
+	// Tokenizers are cheap to reload and may belong to replaced models
+	if ln.tokenizers != nil {
+		ln.tokenizers.Reset()
+	}
+
+	ln.logger.Info("Model reload complete",
+		zap.Any("embedders", result.Embedders),
+		zap.Any("chunkers", result.Chunkers),
+		zap.Any("rerankers", result.Rerankers),
+		zap.Errors("errors", errs))
+
+	return result, errors.Join(errs...)
+}
+
+// handleAdminReload handles POST /admin/reload
+func (ln *TermiteNode) handleAdminReload(w http.ResponseWriter, r *http.Request) {
+	if !ln.checkAdminToken(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	result, err := ln.Reload()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		ln.logger.Error("model reload failed", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = encoder.NewStreamEncoder(w).Encode(struct {
+			*NodeReloadResult
+			Error string `json:"error"`
+		}{result, err.Error()})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_ = encoder.NewStreamEncoder(w).Encode(result)
+}
+
+// invalidateReloadedCaches drops cached results of the models a reload added
+// or removed
+func (ln *TermiteNode) invalidateReloadedCaches(result *NodeReloadResult) {
+	if ln.embeddingCache != nil && result.Embedders != nil {
+		for _, model := range slices.Concat(result.Embedders.Added, result.Embedders.Removed) {
+			ln.embeddingCache.InvalidateModel(model)
+		}
+	}
+	if ln.rerankingCache != nil && result.Rerankers != nil {
+		for _, model := range slices.Concat(result.Rerankers.Added, result.Rerankers.Removed) {
+			ln.rerankingCache.InvalidateModel(model)
+		}
+	}
+}
+
+// CacheInvalidationResult reports how many cached entries were removed for a
+// model.
+type CacheInvalidationResult struct {
+	Model      string `json:"model"`
+	Embeddings int    `json:"embeddings"`
+	Rerankings int    `json:"rerankings"`
+}
+
+// handleAdminInvalidateCache handles POST /admin/cache/invalidate?model=<name>,
+// flushing a model's cached embeddings and reranking scores, e.g. after its
+// weights were replaced in place.
+func (ln *TermiteNode) handleAdminInvalidateCache(w http.ResponseWriter, r *http.Request) {
+	if !ln.checkAdminToken(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	model := r.URL.Query().Get("model")
+	if model == "" {
+		http.Error(w, "model is required", http.StatusBadRequest)
+		return
+	}
+
+	// Cache entries are keyed by the resolved model name
+	result := CacheInvalidationResult{Model: ln.modelAliases.Resolve(model)}
+	if ln.embeddingCache != nil {
+		result.Embeddings = ln.embeddingCache.InvalidateModel(result.Model)
+	}
+	if ln.rerankingCache != nil {
+		result.Rerankings = ln.rerankingCache.InvalidateModel(result.Model)
+	}
+	ln.logger.Info("Invalidated model caches",
+		zap.String("model", result.Model),
+		zap.Int("embeddings", result.Embeddings),
+		zap.Int("rerankings", result.Rerankings))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = encoder.NewStreamEncoder(w).Encode(result)
+}
+
+// checkAdminToken reports whether the request carries the configured admin bearer token.
+func (ln *TermiteNode) checkAdminToken(r *http.Request) bool {
+	if ln.adminToken == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(ln.adminToken)) == 1
+}
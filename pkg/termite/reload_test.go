@@ -0,0 +1,456 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/reranking"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// writeFakeModel creates modelsDir/name/model.onnx so the model is discovered.
+func writeFakeModel(t *testing.T, modelsDir, name string) {
+	t.Helper()
+	dir := filepath.Join(modelsDir, name)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.onnx"), []byte("onnx"), 0o644))
+}
+
+func TestTermiteNode_AdminReload_DiscoversNewModel(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	modelsDir := t.TempDir()
+	writeFakeModel(t, modelsDir, "existing-model")
+
+	registry, err := NewLazyEmbedderRegistry(LazyEmbedderConfig{
+		ModelsDir: modelsDir,
+		KeepAlive: time.Minute,
+	}, nil, logger)
+	require.NoError(t, err)
+	defer func() { _ = registry.Close() }()
+	require.Contains(t, registry.List(), "existing-model")
+
+	node := &TermiteNode{
+		logger:               logger,
+		embedderProvider:     registry,
+		lazyEmbedderRegistry: registry,
+		adminToken:           "secret",
+	}
+
+	// Drop a new model into the directory
+	writeFakeModel(t, modelsDir, "new-model")
+	_, err = registry.Get("new-model")
+	require.Error(t, err, "new model should not be visible before reload")
+
+	// Missing or wrong token is rejected
+	req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	w := httptest.NewRecorder()
+	node.handleAdminReload(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	w = httptest.NewRecorder()
+	node.handleAdminReload(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Authorized reload picks up the new model
+	req = httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	node.handleAdminReload(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp NodeReloadResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Embedders)
+	assert.Contains(t, resp.Embedders.Added, "new-model")
+	assert.NotContains(t, resp.Embedders.Added, "existing-model")
+	assert.Empty(t, resp.Embedders.Removed)
+	assert.Contains(t, node.embedderProvider.List(), "existing-model")
+	assert.Contains(t, node.embedderProvider.List(), "new-model")
+
+	// Removing a model from disk drops it on the next reload
+	require.NoError(t, os.RemoveAll(filepath.Join(modelsDir, "existing-model")))
+	result, err := node.Reload()
+	require.NoError(t, err)
+	assert.Contains(t, result.Embedders.Removed, "existing-model")
+	assert.NotContains(t, node.embedderProvider.List(), "existing-model")
+	assert.Contains(t, node.embedderProvider.List(), "new-model")
+}
+
+func TestTermiteNode_AdminReload_DisabledWithoutToken(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	node := &TermiteNode{logger: logger}
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+	node.handleAdminReload(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// slowReranker blocks inside Rerank until proceed is closed and records
+// whether it was closed while inference was still running.
+type slowReranker struct {
+	started            chan struct{}
+	proceed            chan struct{}
+	closed             atomic.Bool
+	closedDuringRerank atomic.Bool
+}
+
+func (m *slowReranker) Rerank(ctx context.Context, query string, prompts []string) ([]float32, error) {
+	close(m.started)
+	<-m.proceed
+	m.closedDuringRerank.Store(m.closed.Load())
+	return make([]float32, len(prompts)), nil
+}
+
+func (m *slowReranker) Close() error {
+	m.closed.Store(true)
+	return nil
+}
+
+func TestTermiteNode_Reload_RemovedModelClosedAfterInFlightRequest(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	rerankingCache := NewRerankingCache(logger)
+	defer rerankingCache.Close()
+
+	model := &slowReranker{started: make(chan struct{}), proceed: make(chan struct{})}
+	registry := &RerankerRegistry{
+		models:    map[string]reranking.Model{"slow-reranker": model},
+		modelsDir: t.TempDir(), // Empty, so a reload removes the model
+		logger:    logger,
+	}
+	node := &TermiteNode{
+		logger:           logger,
+		rerankerRegistry: registry,
+		rerankingCache:   rerankingCache,
+		requestQueue:     NewRequestQueue(RequestQueueConfig{}, logger),
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		body := `{"model": "slow-reranker", "query": "q", "prompts": ["a", "b"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/rerank", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		done <- w
+	}()
+	<-model.started
+
+	result, err := node.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slow-reranker"}, result.Rerankers.Removed)
+	assert.NotContains(t, registry.List(), "slow-reranker")
+	assert.False(t, model.closed.Load(), "model closed while a request was using it")
+	assert.Equal(t, 1, registry.refs.inUse(model))
+
+	close(model.proceed)
+	w := <-done
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.False(t, model.closedDuringRerank.Load())
+	assert.True(t, model.closed.Load(), "model should be closed once the request finishes")
+	assert.Zero(t, registry.refs.inUse(model))
+}
+
+func TestModelRefs_RetireIdleClosesImmediately(t *testing.T) {
+	var refs modelRefs
+	model := &fakeReloadable{name: "idle"}
+
+	release := refs.acquire(model)
+	release()
+	release() // Extra releases are ignored
+
+	closed := false
+	refs.retire(model, func() { closed = true })
+	assert.True(t, closed)
+	assert.Zero(t, refs.inUse(model))
+}
+
+// fakeReloadable is a minimal model used to exercise reloadModels.
+type fakeReloadable struct {
+	name   string
+	closed bool
+}
+
+func TestReloadModels_PartialFailure(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	kept := &fakeReloadable{name: "kept"}
+	stale := &fakeReloadable{name: "stale"}
+	models := map[string]*fakeReloadable{
+		"kept":  kept,
+		"stale": stale,
+	}
+	var mu sync.RWMutex
+
+	found := map[string]modelFile{
+		"kept":   {Path: "/models/kept"},
+		"added":  {Path: "/models/added"},
+		"broken": {Path: "/models/broken"},
+	}
+
+	var loadedNames []string
+	load := func(name string, mf modelFile) (*fakeReloadable, error) {
+		loadedNames = append(loadedNames, name)
+		if name == "broken" {
+			return nil, errors.New("corrupt model")
+		}
+		return &fakeReloadable{name: name}, nil
+	}
+	closeModel := func(m *fakeReloadable) error {
+		m.closed = true
+		return nil
+	}
+
+	result := reloadModels(&mu, models, nil, found, "test", load, closeModel, logger)
+
+	// Only new models are loaded; unchanged models keep their instance
+	assert.ElementsMatch(t, []string{"added", "broken"}, loadedNames)
+	assert.Same(t, kept, models["kept"])
+	assert.False(t, kept.closed)
+
+	assert.Equal(t, []string{"added"}, result.Added)
+	assert.Equal(t, []string{"stale"}, result.Removed)
+	assert.Equal(t, map[string]string{"broken": "corrupt model"}, result.Failed)
+
+	assert.True(t, stale.closed)
+	assert.NotContains(t, models, "stale")
+	assert.NotContains(t, models, "broken")
+	assert.Contains(t, models, "added")
+}
+
+func TestReloadModels_RecordsLoadDuration(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	models := map[string]*fakeReloadable{}
+	var mu sync.RWMutex
+	found := map[string]modelFile{"timed": {Path: "/models/timed"}}
+
+	load := func(name string, mf modelFile) (*fakeReloadable, error) {
+		time.Sleep(10 * time.Millisecond)
+		return &fakeReloadable{name: name}, nil
+	}
+	closeModel := func(m *fakeReloadable) error { return nil }
+
+	before, _ := loadDurationSamples(t, "timed", "load-test")
+	reloadModels(&mu, models, nil, found, "load-test", load, closeModel, logger)
+
+	count, sum := loadDurationSamples(t, "timed", "load-test")
+	assert.Equal(t, before+1, count)
+	assert.GreaterOrEqual(t, sum, 0.01)
+}
+
+func TestReloadModels_ConcurrentReloadsLoadOnce(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	models := map[string]*fakeReloadable{}
+	var mu sync.RWMutex
+	found := map[string]modelFile{"shared": {Path: "/models/shared"}}
+
+	var loads atomic.Int32
+	load := func(name string, mf modelFile) (*fakeReloadable, error) {
+		loads.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return &fakeReloadable{name: name}, nil
+	}
+	closeModel := func(m *fakeReloadable) error { return nil }
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			reloadModels(&mu, models, nil, found, "concurrent-test", load, closeModel, logger)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Contains(t, models, "shared")
+}
+
+func TestReloadModels_UnrelatedModelsDoNotWait(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	closeModel := func(m *fakeReloadable) error { return nil }
+
+	// A slow load of one model is in progress...
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		var mu sync.RWMutex
+		slowLoad := func(name string, mf modelFile) (*fakeReloadable, error) {
+			close(started)
+			<-unblock
+			return &fakeReloadable{name: name}, nil
+		}
+		reloadModels(&mu, map[string]*fakeReloadable{}, nil,
+			map[string]modelFile{"slow": {Path: "/models/slow"}}, "embedder", slowLoad, closeModel, logger)
+	}()
+	<-started
+
+	// ...and does not hold up reloading another model
+	var mu sync.RWMutex
+	models := map[string]*fakeReloadable{}
+	fastLoad := func(name string, mf modelFile) (*fakeReloadable, error) {
+		return &fakeReloadable{name: name}, nil
+	}
+	done := make(chan *ReloadResult)
+	go func() {
+		done <- reloadModels(&mu, models, nil,
+			map[string]modelFile{"fast": {Path: "/models/fast"}}, "embedder", fastLoad, closeModel, logger)
+	}()
+	select {
+	case result := <-done:
+		assert.Equal(t, []string{"fast"}, result.Added)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reloading an unrelated model waited for the slow load")
+	}
+
+	close(unblock)
+	<-slowDone
+}
+
+// loadDurationSamples returns the sample count and sum of the model load
+// duration histogram for a model
+func loadDurationSamples(t *testing.T, model, modelType string) (uint64, float64) {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "antfly_termite_model_load_duration_seconds" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			if labels["model"] == model && labels["type"] == modelType {
+				return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+	return 0, 0
+}
+
+// fillModelCaches caches one embedding and one reranking result per model and
+// returns the embedders so that later cache misses can be detected.
+func fillModelCaches(t *testing.T, ec *EmbeddingCache, rc *RerankingCache, models ...string) map[string]*MockEmbedder {
+	t.Helper()
+	embedders := make(map[string]*MockEmbedder)
+	for _, model := range models {
+		embedders[model] = &MockEmbedder{}
+		_, err := ec.WrapEmbedder(embedders[model], model).Embed(context.Background(), [][]ai.ContentPart{{ai.TextContent{Text: "hello"}}})
+		require.NoError(t, err)
+		_, err = rc.WrapReranker(mockReranker{}, model).Rerank(context.Background(), "q", []string{"a", "b"})
+		require.NoError(t, err)
+	}
+	return embedders
+}
+
+func TestTermiteNode_AdminInvalidateCache(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	embeddingCache := NewEmbeddingCache(logger)
+	defer embeddingCache.Close()
+	rerankingCache := NewRerankingCache(logger)
+	defer rerankingCache.Close()
+	embedders := fillModelCaches(t, embeddingCache, rerankingCache, "model-a", "model-b")
+
+	node := &TermiteNode{
+		logger:         logger,
+		embeddingCache: embeddingCache,
+		rerankingCache: rerankingCache,
+		modelAliases:   ModelAliases{"alias-a": "model-a"},
+		adminToken:     "secret",
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/cache/invalidate?model=alias-a", nil)
+	w := httptest.NewRecorder()
+	node.handleAdminInvalidateCache(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/cache/invalidate?model=alias-a", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	node.handleAdminInvalidateCache(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp CacheInvalidationResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, CacheInvalidationResult{Model: "model-a", Embeddings: 1, Rerankings: 1}, resp)
+	assert.Equal(t, 1, embeddingCache.cache.Len())
+	assert.Equal(t, 1, rerankingCache.cache.Len())
+
+	// model-a is recomputed, model-b is still served from the cache
+	for _, model := range []string{"model-a", "model-b"} {
+		_, err := embeddingCache.WrapEmbedder(embedders[model], model).Embed(context.Background(), [][]ai.ContentPart{{ai.TextContent{Text: "hello"}}})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), embedders["model-a"].GetCallCount())
+	assert.Equal(t, int32(1), embedders["model-b"].GetCallCount())
+
+	// Missing model
+	req = httptest.NewRequest(http.MethodPost, "/admin/cache/invalidate", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	node.handleAdminInvalidateCache(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTermiteNode_Reload_InvalidatesRemovedModelCaches(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	embeddingCache := NewEmbeddingCache(logger)
+	defer embeddingCache.Close()
+	rerankingCache := NewRerankingCache(logger)
+	defer rerankingCache.Close()
+	fillModelCaches(t, embeddingCache, rerankingCache, "removed-reranker", "other-model")
+
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models:    map[string]reranking.Model{"removed-reranker": mockReranker{}},
+			modelsDir: t.TempDir(),
+			logger:    logger,
+		},
+		embeddingCache: embeddingCache,
+		rerankingCache: rerankingCache,
+	}
+
+	result, err := node.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []string{"removed-reranker"}, result.Rerankers.Removed)
+
+	// Only the reloaded registry's cache is flushed, and only for that model
+	assert.Equal(t, 1, rerankingCache.cache.Len())
+	assert.Equal(t, 2, embeddingCache.cache.Len())
+}
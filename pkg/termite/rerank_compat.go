@@ -0,0 +1,199 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bytedance/sonic/decoder"
+	"github.com/bytedance/sonic/encoder"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// cohereRerankRequest is the body of Cohere's rerank API (v1 and v2)
+type cohereRerankRequest struct {
+	Model           string           `json:"model"`
+	Query           string           `json:"query"`
+	Documents       []cohereDocument `json:"documents"`
+	TopN            *int             `json:"top_n,omitempty"`
+	ReturnDocuments bool             `json:"return_documents,omitempty"`
+}
+
+// cohereDocument is a document to rerank, given either as a string or as
+// an object with a text field
+type cohereDocument struct {
+	Text string `json:"text"`
+}
+
+func (d *cohereDocument) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		return json.Unmarshal(data, &d.Text)
+	}
+	var doc struct {
+		Text *string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Text == nil {
+		return errors.New("document objects must have a text field")
+	}
+	d.Text = *doc.Text
+	return nil
+}
+
+// cohereRerankResponse is the response of Cohere's rerank API
+type cohereRerankResponse struct {
+	Results []cohereRerankResult `json:"results"`
+}
+
+type cohereRerankResult struct {
+	Index          int             `json:"index"`
+	RelevanceScore float32         `json:"relevance_score"`
+	Document       *cohereDocument `json:"document,omitempty"`
+}
+
+// writeCohereError writes an error in Cohere's error envelope
+func writeCohereError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = encoder.NewStreamEncoder(w).Encode(struct {
+		Message string `json:"message"`
+	}{Message: message})
+}
+
+// handleCohereRerank serves Cohere's rerank API so that existing Cohere
+// clients can point at Termite. Documents are scored with the requested
+// reranker in pairwise mode and returned sorted by relevance.
+func (ln *TermiteNode) handleCohereRerank(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	r, cancel := ln.withRequestTimeout(r)
+	defer cancel()
+
+	release, err := ln.requestQueue.Acquire(r.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrQueueFull):
+			RecordQueueRejection()
+			w.Header().Set("Retry-After", "5")
+			writeCohereError(w, http.StatusServiceUnavailable, "service overloaded, please retry later")
+		case errors.Is(err, ErrRequestTimeout):
+			RecordQueueTimeout()
+			writeCohereError(w, http.StatusGatewayTimeout, "request timeout exceeded")
+		default:
+			writeCohereError(w, http.StatusRequestTimeout, "request cancelled")
+		}
+		return
+	}
+	defer release()
+
+	UpdateQueueMetrics(ln.requestQueue.Stats())
+
+	var req cohereRerankRequest
+	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
+		writeCohereError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	switch {
+	case req.Model == "":
+		writeCohereError(w, http.StatusBadRequest, "model is required")
+		return
+	case req.Query == "":
+		writeCohereError(w, http.StatusBadRequest, "query is required")
+		return
+	case len(req.Documents) == 0:
+		writeCohereError(w, http.StatusBadRequest, "documents are required")
+		return
+	case req.TopN != nil && *req.TopN < 1:
+		writeCohereError(w, http.StatusBadRequest, "top_n must be at least 1")
+		return
+	}
+	topN := 0
+	if req.TopN != nil {
+		topN = *req.TopN
+	}
+
+	modelName := ln.modelAliases.Resolve(req.Model)
+	reranker, releaseReranker, err := ln.acquireReranker(modelName)
+	if err != nil {
+		writeCohereError(w, errorStatus(err), modelErrorMessage(req.Model, modelName, err))
+		return
+	}
+	defer releaseReranker()
+
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		attrModel.String(modelName),
+		attrBatchSize.Int(len(req.Documents)),
+	)
+
+	releaseModel, err := ln.modelLimiter.Acquire(r.Context(), modelName)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrQueueFull):
+			w.Header().Set("Retry-After", strconv.Itoa(int(modelBusyRetryAfter.Seconds())))
+			writeCohereError(w, http.StatusTooManyRequests,
+				fmt.Sprintf("model %s is at its concurrency limit, please retry later", modelName))
+		case errors.Is(err, ErrRequestTimeout):
+			writeCohereError(w, http.StatusGatewayTimeout, "request timeout exceeded")
+		default:
+			writeCohereError(w, http.StatusRequestTimeout, "request cancelled")
+		}
+		return
+	}
+	defer releaseModel()
+
+	prompts := make([]string, len(req.Documents))
+	for i, doc := range req.Documents {
+		prompts[i] = doc.Text
+	}
+
+	cachedReranker := ln.rerankingCache.WrapReranker(reranker, modelName)
+	results, _, err := cachedReranker.RerankTop(r.Context(), req.Query, prompts, topN, nil)
+	if err != nil {
+		if requestTimedOut(r) {
+			writeCohereError(w, http.StatusGatewayTimeout, "request timeout exceeded")
+			return
+		}
+		ln.logger.Error("reranking failed",
+			zap.String("model", modelName),
+			zap.Int("num_prompts", len(prompts)),
+			zap.Error(err))
+		writeCohereError(w, http.StatusInternalServerError, fmt.Sprintf("reranking failed: %v", err))
+		return
+	}
+
+	RecordRerankerRequest(modelName)
+	RecordRerankingCreation(modelName, len(prompts))
+
+	resp := cohereRerankResponse{Results: make([]cohereRerankResult, len(results))}
+	for i, result := range results {
+		resp.Results[i] = cohereRerankResult{Index: result.Index, RelevanceScore: result.Score}
+		if req.ReturnDocuments {
+			resp.Results[i].Document = &req.Documents[result.Index]
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
+		ln.logger.Error("encoding response", zap.Error(err))
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/reranking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newCohereRerankTestNode(t *testing.T) *TermiteNode {
+	logger := zaptest.NewLogger(t)
+
+	// Scores the documents "low", "high", "mid" as 0.1, 0.9, 0.5
+	mockModel := &MockModel{
+		rerankFunc: func(ctx context.Context, query string, prompts []string) ([]float32, error) {
+			byText := map[string]float32{"low": 0.1, "high": 0.9, "mid": 0.5}
+			scores := make([]float32, len(prompts))
+			for i, p := range prompts {
+				scores[i] = byText[p]
+			}
+			return scores, nil
+		},
+	}
+	rerankingCache := NewRerankingCache(logger.Named("reranking-cache"))
+	t.Cleanup(rerankingCache.Close)
+	return &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"test_model": mockModel},
+			logger: logger,
+		},
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		rerankingCache: rerankingCache,
+	}
+}
+
+func postCohereRerank(node *TermiteNode, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/v1/rerank", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	node.handleCohereRerank(w, req)
+	return w
+}
+
+func TestHandleCohereRerank(t *testing.T) {
+	node := newCohereRerankTestNode(t)
+
+	w := postCohereRerank(node, `{"model": "test_model", "query": "q", "documents": ["low", "high", "mid"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var raw struct {
+		Results []map[string]any `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+	require.Len(t, raw.Results, 3)
+	for _, result := range raw.Results {
+		assert.ElementsMatch(t, []string{"index", "relevance_score"}, slices.Collect(maps.Keys(result)))
+	}
+	var resp cohereRerankResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []cohereRerankResult{
+		{Index: 1, RelevanceScore: 0.9},
+		{Index: 2, RelevanceScore: 0.5},
+		{Index: 0, RelevanceScore: 0.1},
+	}, resp.Results)
+
+	// Documents may be objects, are echoed on request and top_n limits the results
+	w = postCohereRerank(node, `{
+		"model": "test_model",
+		"query": "q",
+		"documents": [{"text": "low"}, "high", {"text": "mid"}],
+		"top_n": 2,
+		"return_documents": true
+	}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	resp = cohereRerankResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []cohereRerankResult{
+		{Index: 1, RelevanceScore: 0.9, Document: &cohereDocument{Text: "high"}},
+		{Index: 2, RelevanceScore: 0.5, Document: &cohereDocument{Text: "mid"}},
+	}, resp.Results)
+}
+
+func TestHandleCohereRerank_Errors(t *testing.T) {
+	node := newCohereRerankTestNode(t)
+
+	for _, tt := range []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"unknown model", `{"model": "missing", "query": "q", "documents": ["a"]}`, http.StatusNotFound},
+		{"missing query", `{"model": "test_model", "documents": ["a"]}`, http.StatusBadRequest},
+		{"no documents", `{"model": "test_model", "query": "q", "documents": []}`, http.StatusBadRequest},
+		{"document without text", `{"model": "test_model", "query": "q", "documents": [{"title": "a"}]}`, http.StatusBadRequest},
+		{"zero top_n", `{"model": "test_model", "query": "q", "documents": ["a"], "top_n": 0}`, http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postCohereRerank(node, tt.body)
+			assert.Equal(t, tt.status, w.Code)
+
+			var envelope map[string]string
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&envelope))
+			assert.NotEmpty(t, envelope["message"])
+		})
+	}
+
+	w := postCohereRerank(node, `{"model": "missing", "query": "q", "documents": ["a"]}`)
+	var envelope map[string]string
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&envelope))
+	assert.Equal(t, map[string]string{"message": "model not found: missing"}, envelope)
+}
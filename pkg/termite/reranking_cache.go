@@ -15,14 +15,19 @@
 package termite
 
 import (
+	"cmp"
 	"context"
-	"encoding/binary"
+	"fmt"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	"github.com/antflydb/antfly-go/libaf/reranking"
+	termreranking "github.com/antflydb/termite/pkg/termite/lib/reranking"
 	"github.com/cespare/xxhash/v2"
 	"github.com/jellydator/ttlcache/v3"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/singleflight"
 )
@@ -34,6 +39,7 @@ const RerankingCacheTTL = 2 * time.Minute
 type CachedReranker struct {
 	reranker reranking.Model
 	model    string
+	mode     termreranking.Mode
 	cache    *ttlcache.Cache[string, []float32]
 	sfGroup  *singleflight.Group
 	logger   *zap.Logger
@@ -51,9 +57,25 @@ func NewCachedReranker(
 	cache *ttlcache.Cache[string, []float32],
 	logger *zap.Logger,
 ) *CachedReranker {
+	return NewCachedRerankerWithMode(reranker, model, termreranking.ModePairwise, cache, logger)
+}
+
+// NewCachedRerankerWithMode wraps a reranker with caching, scoring prompts
+// with the given mode. Results for different modes are cached separately.
+func NewCachedRerankerWithMode(
+	reranker reranking.Model,
+	model string,
+	mode termreranking.Mode,
+	cache *ttlcache.Cache[string, []float32],
+	logger *zap.Logger,
+) *CachedReranker {
+	if mode == "" {
+		mode = termreranking.ModePairwise
+	}
 	return &CachedReranker{
 		reranker: reranker,
 		model:    model,
+		mode:     mode,
 		cache:    cache,
 		sfGroup:  &singleflight.Group{},
 		logger:   logger,
@@ -62,11 +84,26 @@ func NewCachedReranker(
 
 // Rerank scores prompts with caching support
 func (c *CachedReranker) Rerank(ctx context.Context, query string, prompts []string) ([]float32, error) {
+	parent := trace.SpanFromContext(ctx)
+	ctx, span := tracer().Start(ctx, "CachedReranker.Rerank", trace.WithAttributes(
+		attrModel.String(c.model),
+		attrBatchSize.Int(len(prompts)),
+	))
+	defer span.End()
+
 	// Generate cache key from model + query + prompts hash
 	key := c.cacheKey(query, prompts)
 
 	// Check cache first
-	if item := c.cache.Get(key); item != nil {
+	_, lookupSpan := tracer().Start(ctx, "rerank.cache_lookup")
+	item := c.cache.Get(key)
+	lookupSpan.SetAttributes(attrCacheHit.Bool(item != nil))
+	lookupSpan.End()
+
+	// Report the outcome on both this span and the caller's request span
+	parent.SetAttributes(attrCacheHit.Bool(item != nil))
+	span.SetAttributes(attrCacheHit.Bool(item != nil))
+	if item != nil {
 		c.hits.Add(1)
 		RecordCacheHit("reranking")
 		c.logger.Debug("Reranking cache hit",
@@ -80,9 +117,18 @@ func (c *CachedReranker) Rerank(ctx context.Context, query string, prompts []str
 		c.misses.Add(1)
 		RecordCacheMiss("reranking")
 
+		modelCtx, modelSpan := tracer().Start(ctx, "rerank.model", trace.WithAttributes(
+			attrModel.String(c.model),
+			attrBatchSize.Int(len(prompts)),
+		))
+		defer modelSpan.End()
+
 		start := time.Now()
-		scores, err := c.reranker.Rerank(ctx, query, prompts)
+		scores, err := termreranking.RerankWithMode(modelCtx, c.reranker, query, prompts, c.mode)
+		modelSpan.SetAttributes(attrDurationMs.Int64(time.Since(start).Milliseconds()))
 		if err != nil {
+			modelSpan.RecordError(err)
+			modelSpan.SetStatus(codes.Error, err.Error())
 			return nil, err
 		}
 
@@ -101,6 +147,7 @@ func (c *CachedReranker) Rerank(ctx context.Context, query string, prompts []str
 	})
 
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
@@ -113,7 +160,105 @@ func (c *CachedReranker) Rerank(ctx context.Context, query string, prompts []str
 	return result.([]float32), nil
 }
 
-// cacheKey generates a unique cache key from model + query + prompts
+// RerankTop scores prompts like Rerank and also returns the results sorted by
+// score (highest first), keeping at most topN results (0 keeps all) and
+// dropping those scoring below minScore when it is non-nil. The full scores,
+// in prompt order, are returned alongside so callers can still report them.
+func (c *CachedReranker) RerankTop(ctx context.Context, query string, prompts []string, topN int, minScore *float32) ([]RerankResult, []float32, error) {
+	scores, err := c.Rerank(ctx, query, prompts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rankScores(scores, topN, minScore), scores, nil
+}
+
+// RerankStream scores prompts in batches of batchSize (0 scores them in one
+// batch), calling emit with the results of each batch, sorted by score and
+// filtered by minScore, as soon as the batch is scored. Cached scores are
+// replayed in the same batches; fresh scores are cached under the same key as
+// Rerank once every batch is scored. Streaming requests bypass singleflight
+// deduplication since every caller needs its own stream.
+func (c *CachedReranker) RerankStream(ctx context.Context, query string, prompts []string, batchSize int, minScore *float32, emit func([]RerankResult) error) (bool, error) {
+	if batchSize <= 0 {
+		batchSize = len(prompts)
+	}
+
+	key := c.cacheKey(query, prompts)
+	var cached []float32
+	if item := c.cache.Get(key); item != nil {
+		cached = item.Value()
+		c.hits.Add(1)
+		RecordCacheHit("reranking")
+	} else {
+		c.misses.Add(1)
+		RecordCacheMiss("reranking")
+	}
+	cacheHit := cached != nil
+	trace.SpanFromContext(ctx).SetAttributes(attrCacheHit.Bool(cacheHit))
+
+	start := time.Now()
+	scores := make([]float32, 0, len(prompts))
+	for offset := 0; offset < len(prompts); offset += batchSize {
+		end := min(offset+batchSize, len(prompts))
+
+		var batch []float32
+		if cacheHit {
+			batch = cached[offset:end]
+		} else {
+			var err error
+			batch, err = termreranking.RerankWithMode(ctx, c.reranker, query, prompts[offset:end], c.mode)
+			if err != nil {
+				return false, err
+			}
+			if len(batch) != end-offset {
+				return false, fmt.Errorf("expected %d scores, got %d", end-offset, len(batch))
+			}
+			scores = append(scores, batch...)
+		}
+
+		results := rankScores(batch, 0, minScore)
+		for i := range results {
+			results[i].Index += offset
+		}
+		if err := emit(results); err != nil {
+			return cacheHit, err
+		}
+	}
+
+	if !cacheHit {
+		RecordRequestDuration("rerank", c.model, "200", time.Since(start).Seconds())
+		c.cache.Set(key, scores, ttlcache.DefaultTTL)
+		c.logger.Debug("Streaming reranking completed and cached",
+			zap.String("model", c.model),
+			zap.Int("num_prompts", len(prompts)),
+			zap.Duration("duration", time.Since(start)))
+	}
+
+	return cacheHit, nil
+}
+
+// rankScores pairs each score with its index, sorts by score descending
+// (ties keep prompt order), then applies the minScore and topN limits.
+func rankScores(scores []float32, topN int, minScore *float32) []RerankResult {
+	results := make([]RerankResult, 0, len(scores))
+	for i, score := range scores {
+		if minScore != nil && score < *minScore {
+			continue
+		}
+		results = append(results, RerankResult{Index: i, Score: score})
+	}
+
+	slices.SortStableFunc(results, func(a, b RerankResult) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	if topN > 0 && topN < len(results) {
+		results = results[:topN]
+	}
+	return results
+}
+
+// cacheKey generates a unique cache key from model + mode + query + prompts
 func (c *CachedReranker) cacheKey(query string, prompts []string) string {
 	h := xxhash.New()
 
@@ -121,6 +266,11 @@ func (c *CachedReranker) cacheKey(query string, prompts []string) string {
 	_, _ = h.WriteString(c.model)
 	_, _ = h.WriteString("|")
 
+	// Include mode, since modes may score differently
+	_, _ = h.WriteString("m:")
+	_, _ = h.WriteString(string(c.mode))
+	_, _ = h.WriteString("|")
+
 	// Include query
 	_, _ = h.WriteString("q:")
 	_, _ = h.WriteString(query)
@@ -136,10 +286,7 @@ func (c *CachedReranker) cacheKey(query string, prompts []string) string {
 		_, _ = h.WriteString("|")
 	}
 
-	// Convert uint64 hash to string key
-	var buf [8]byte
-	binary.BigEndian.PutUint64(buf[:], h.Sum64())
-	return string(buf[:])
+	return modelCacheKey(c.model, h.Sum64())
 }
 
 // Close closes the underlying reranker
@@ -200,6 +347,17 @@ func (rc *RerankingCache) WrapReranker(reranker reranking.Model, model string) *
 	return NewCachedReranker(reranker, model, rc.cache, rc.logger.Named(model))
 }
 
+// WrapRerankerWithMode wraps a reranker with caching using the given rerank mode
+func (rc *RerankingCache) WrapRerankerWithMode(reranker reranking.Model, model string, mode termreranking.Mode) *CachedReranker {
+	return NewCachedRerankerWithMode(reranker, model, mode, rc.cache, rc.logger.Named(model))
+}
+
+// InvalidateModel removes all cached scores of model, e.g. after its weights
+// changed, and returns how many entries were removed
+func (rc *RerankingCache) InvalidateModel(model string) int {
+	return deleteModelEntries(rc.cache, model)
+}
+
 // Close stops the cache
 func (rc *RerankingCache) Close() {
 	rc.cancel()
@@ -0,0 +1,143 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"testing"
+
+	termreranking "github.com/antflydb/termite/pkg/termite/lib/reranking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRankScores(t *testing.T) {
+	scores := []float32{0.2, 0.9, -1.5, 0.9, 0.5}
+	minScore := float32(0.3)
+	zero := float32(0)
+
+	tests := []struct {
+		name     string
+		topN     int
+		minScore *float32
+		want     []RerankResult
+	}{
+		{
+			name: "sorted descending with original indices",
+			want: []RerankResult{
+				{Index: 1, Score: 0.9},
+				{Index: 3, Score: 0.9},
+				{Index: 4, Score: 0.5},
+				{Index: 0, Score: 0.2},
+				{Index: 2, Score: -1.5},
+			},
+		},
+		{
+			name: "top n",
+			topN: 2,
+			want: []RerankResult{
+				{Index: 1, Score: 0.9},
+				{Index: 3, Score: 0.9},
+			},
+		},
+		{
+			name: "top n larger than prompt count returns all",
+			topN: 10,
+			want: []RerankResult{
+				{Index: 1, Score: 0.9},
+				{Index: 3, Score: 0.9},
+				{Index: 4, Score: 0.5},
+				{Index: 0, Score: 0.2},
+				{Index: 2, Score: -1.5},
+			},
+		},
+		{
+			name:     "min score",
+			minScore: &minScore,
+			want: []RerankResult{
+				{Index: 1, Score: 0.9},
+				{Index: 3, Score: 0.9},
+				{Index: 4, Score: 0.5},
+			},
+		},
+		{
+			name:     "zero min score drops negative logits",
+			minScore: &zero,
+			topN:     4,
+			want: []RerankResult{
+				{Index: 1, Score: 0.9},
+				{Index: 3, Score: 0.9},
+				{Index: 4, Score: 0.5},
+				{Index: 0, Score: 0.2},
+			},
+		},
+		{
+			name:     "min score and top n",
+			minScore: &minScore,
+			topN:     1,
+			want:     []RerankResult{{Index: 1, Score: 0.9}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rankScores(scores, tt.topN, tt.minScore))
+		})
+	}
+}
+
+func TestCachedReranker_RerankTop(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	cache := NewRerankingCache(logger)
+	defer cache.Close()
+
+	model := &MockModel{
+		rerankFunc: func(ctx context.Context, query string, prompts []string) ([]float32, error) {
+			scores := make([]float32, len(prompts))
+			for i, p := range prompts {
+				scores[i] = float32(len(p))
+			}
+			return scores, nil
+		},
+	}
+	reranker := cache.WrapReranker(model, "length")
+
+	prompts := []string{"bb", "dddd", "a", "ccc"}
+	results, scores, err := reranker.RerankTop(t.Context(), "query", prompts, 3, nil)
+	require.NoError(t, err)
+
+	// Scores keep prompt order; results map back to the original prompts
+	assert.Equal(t, []float32{2, 4, 1, 3}, scores)
+	require.Len(t, results, 3)
+	assert.Equal(t, "dddd", prompts[results[0].Index])
+	assert.Equal(t, "ccc", prompts[results[1].Index])
+	assert.Equal(t, "bb", prompts[results[2].Index])
+}
+
+func TestCachedReranker_CacheKeyDistinguishesModes(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	cache := NewRerankingCache(logger)
+	defer cache.Close()
+
+	model := &MockModel{}
+	pairwise := cache.WrapReranker(model, "model")
+	explicitPairwise := cache.WrapRerankerWithMode(model, "model", termreranking.ModePairwise)
+	shared := cache.WrapRerankerWithMode(model, "model", termreranking.ModeSharedQuery)
+
+	prompts := []string{"a", "b"}
+	assert.Equal(t, pairwise.cacheKey("q", prompts), explicitPairwise.cacheKey("q", prompts))
+	assert.NotEqual(t, pairwise.cacheKey("q", prompts), shared.cacheKey("q", prompts))
+}
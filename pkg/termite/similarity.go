@@ -0,0 +1,161 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/bytedance/sonic/decoder"
+	"github.com/bytedance/sonic/encoder"
+	"go.uber.org/zap"
+)
+
+// handleApiSimilarity scores two sets of precomputed vectors against each other
+func (ln *TermiteNode) handleApiSimilarity(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	var req SimilarityRequest
+	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decoding request: %v", err), requestBodyStatus(err))
+		return
+	}
+
+	a, err := similarityVectors("a", req.A, req.ABinary)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	b, err := similarityVectors("b", req.B, req.BBinary)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	metric := req.Metric
+	if metric == "" {
+		metric = SimilarityMetricCosine
+	}
+	scores, err := similarityMatrix(a, b, metric)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := SimilarityResponse{
+		Metric: metric,
+		Scores: scores,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
+		ln.logger.Error("encoding response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// similarityVectors returns the vectors for one side of a similarity request,
+// sent either as JSON arrays or in the binary embedding format
+func similarityVectors(name string, vectors [][]float32, binary []byte) ([][]float32, error) {
+	if len(vectors) > 0 && len(binary) > 0 {
+		return nil, fmt.Errorf("only one of %s and %s_binary may be set", name, name)
+	}
+	if len(binary) > 0 {
+		decoded, err := DeserializeFloatArrays(bytes.NewReader(binary))
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s_binary: %w", name, err)
+		}
+		vectors = decoded
+	}
+	if len(vectors) == 0 {
+		return nil, fmt.Errorf("%s is required", name)
+	}
+	return vectors, nil
+}
+
+// similarityMatrix scores every vector in a against every vector in b. All
+// vectors must share one non-zero dimension.
+func similarityMatrix(a, b [][]float32, metric SimilarityMetric) ([][]float32, error) {
+	var score func(x, y []float32) float32
+	switch metric {
+	case SimilarityMetricCosine:
+		score = cosineSimilarity
+	case SimilarityMetricDot:
+		score = dotProduct
+	case SimilarityMetricEuclidean:
+		score = euclideanDistance
+	default:
+		return nil, fmt.Errorf("invalid metric: %s", metric)
+	}
+
+	dim := len(a[0])
+	if dim == 0 {
+		return nil, errors.New("vectors must not be empty")
+	}
+	for _, set := range []struct {
+		name    string
+		vectors [][]float32
+	}{{"a", a}, {"b", b}} {
+		for i, v := range set.vectors {
+			if len(v) != dim {
+				return nil, fmt.Errorf("dimension mismatch: %s[%d] has %d dimensions, expected %d",
+					set.name, i, len(v), dim)
+			}
+		}
+	}
+
+	scores := make([][]float32, len(a))
+	for i, x := range a {
+		scores[i] = make([]float32, len(b))
+		for j, y := range b {
+			scores[i][j] = score(x, y)
+		}
+	}
+	return scores, nil
+}
+
+func dotProduct(x, y []float32) float32 {
+	var sum float64
+	for i := range x {
+		sum += float64(x[i]) * float64(y[i])
+	}
+	return float32(sum)
+}
+
+// cosineSimilarity returns 0 when either vector is all zeros
+func cosineSimilarity(x, y []float32) float32 {
+	var dot, normX, normY float64
+	for i := range x {
+		dot += float64(x[i]) * float64(y[i])
+		normX += float64(x[i]) * float64(x[i])
+		normY += float64(y[i]) * float64(y[i])
+	}
+	if normX == 0 || normY == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normX) * math.Sqrt(normY)))
+}
+
+func euclideanDistance(x, y []float32) float32 {
+	var sum float64
+	for i := range x {
+		d := float64(x[i]) - float64(y[i])
+		sum += d * d
+	}
+	return float32(math.Sqrt(sum))
+}
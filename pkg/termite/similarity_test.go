@@ -0,0 +1,142 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func postSimilarity(t *testing.T, req SimilarityRequest) *httptest.ResponseRecorder {
+	logger := zaptest.NewLogger(t)
+	handler := NewTermiteAPI(logger, &TermiteNode{logger: logger})
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/similarity", bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httpReq)
+	return w
+}
+
+func TestTermiteAPI_ComputeSimilarity_Cosine(t *testing.T) {
+	w := postSimilarity(t, SimilarityRequest{
+		A: [][]float32{{0.6, 0.8}, {1, 0}},
+		B: [][]float32{{0.6, 0.8}, {0, 1}, {0, 0}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp SimilarityResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, SimilarityMetricCosine, resp.Metric)
+	require.Len(t, resp.Scores, 2)
+	require.Len(t, resp.Scores[0], 3)
+
+	assert.InDelta(t, 1.0, resp.Scores[0][0], 1e-6, "identical normalized vectors")
+	assert.InDelta(t, 0.8, resp.Scores[0][1], 1e-6)
+	assert.InDelta(t, 0.0, resp.Scores[1][1], 1e-6, "orthogonal vectors")
+	assert.Zero(t, resp.Scores[1][2], "zero vectors score 0")
+}
+
+func TestTermiteAPI_ComputeSimilarity_Metrics(t *testing.T) {
+	a := [][]float32{{1, 2, 3}}
+	b := [][]float32{{4, 6, 3}}
+
+	tests := []struct {
+		metric SimilarityMetric
+		want   float32
+	}{
+		{SimilarityMetricDot, 25},
+		{SimilarityMetricEuclidean, 5},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			w := postSimilarity(t, SimilarityRequest{A: a, B: b, Metric: tt.metric})
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp SimilarityResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tt.metric, resp.Metric)
+			assert.InDelta(t, tt.want, resp.Scores[0][0], 1e-5)
+		})
+	}
+}
+
+func TestTermiteAPI_ComputeSimilarity_Binary(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, SerializeFloatArrays(&buf, [][]float32{{0, 1}, {1, 0}}))
+
+	w := postSimilarity(t, SimilarityRequest{
+		ABinary: buf.Bytes(),
+		B:       [][]float32{{0, 2}},
+		Metric:  SimilarityMetricCosine,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp SimilarityResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Scores, 2)
+	assert.InDelta(t, 1.0, resp.Scores[0][0], 1e-6)
+	assert.InDelta(t, 0.0, resp.Scores[1][0], 1e-6)
+}
+
+func TestTermiteAPI_ComputeSimilarity_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     SimilarityRequest
+		wantErr string
+	}{
+		{
+			name:    "dimension mismatch",
+			req:     SimilarityRequest{A: [][]float32{{1, 0}}, B: [][]float32{{1, 0, 0}}},
+			wantErr: "dimension mismatch: b[0] has 3 dimensions, expected 2",
+		},
+		{
+			name:    "ragged input",
+			req:     SimilarityRequest{A: [][]float32{{1, 0}, {1}}, B: [][]float32{{1, 0}}},
+			wantErr: "dimension mismatch: a[1]",
+		},
+		{
+			name:    "missing b",
+			req:     SimilarityRequest{A: [][]float32{{1, 0}}},
+			wantErr: "b is required",
+		},
+		{
+			name:    "both forms",
+			req:     SimilarityRequest{A: [][]float32{{1}}, ABinary: []byte{0}, B: [][]float32{{1}}},
+			wantErr: "only one of a and a_binary",
+		},
+		{
+			name:    "unknown metric",
+			req:     SimilarityRequest{A: [][]float32{{1}}, B: [][]float32{{1}}, Metric: "manhattan"},
+			wantErr: "invalid metric",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postSimilarity(t, tt.req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantErr)
+		})
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/antflydb/antfly-go/libaf/embeddings"
@@ -33,6 +36,9 @@ import (
 // EmbedderProvider abstracts over eager and lazy embedder registries
 type EmbedderProvider interface {
 	Get(modelName string) (embeddings.Embedder, error)
+	// Acquire is Get for a request that runs inference: the model is not
+	// closed until release is called, even if it is unloaded meanwhile
+	Acquire(modelName string) (model embeddings.Embedder, release func(), err error)
 	List() []string
 	Close() error
 }
@@ -51,17 +57,45 @@ type TermiteNode struct {
 	// Lazy registry (when keep_alive is configured)
 	lazyEmbedderRegistry *LazyEmbedderRegistry
 
+	// Embedders registered through RegisterEmbedder; when set, it wraps the
+	// registry above as embedderProvider
+	customEmbedders *customEmbedderProvider
+
 	cachedChunker         *CachedChunker
 	rerankerRegistry      *RerankerRegistry
 	contentSecurityConfig *scraping.ContentSecurityConfig
 	s3Credentials         *s3.Credentials
 
+	// Embed request size limits (zero fields use defaults)
+	embedLimits EmbedLimits
+
 	// Request queue for backpressure control
 	requestQueue *RequestQueue
 
+	// Bound on queue wait plus inference per request (0 = unbounded)
+	requestTimeout time.Duration
+
+	// Per-model bound on in-flight inference (nil = unlimited)
+	modelLimiter *ModelLimiter
+
 	// Caches for embeddings and reranking
 	embeddingCache *EmbeddingCache
 	rerankingCache *RerankingCache
+
+	// Responses of embed requests by Idempotency-Key
+	idempotencyCache *IdempotencyCache
+
+	// Bearer token for /admin endpoints (empty disables them)
+	adminToken string
+
+	// Alias -> canonical name for embedder and reranker lookups
+	modelAliases ModelAliases
+
+	// Model tokenizers for /tokenize and /decode
+	tokenizers *TokenizerRegistry
+
+	// Deep liveness check reported by /healthz (nil when disabled)
+	liveness *livenessChecker
 }
 
 // corsMiddleware adds permissive CORS headers for the Termite API
@@ -84,9 +118,14 @@ func corsMiddleware(next http.Handler) http.Handler {
 // DefaultShutdownTimeout is the default time to wait for graceful shutdown
 const DefaultShutdownTimeout = 30 * time.Second
 
+// DefaultRequestTimeout bounds embed, chunk and rerank requests when
+// request_timeout is not configured
+const DefaultRequestTimeout = 30 * time.Second
+
 // RunAsTermite implements a leader node that monitors and manages the cluster.
 // If readyC is non-nil, it will be closed when the server is ready to accept requests.
-func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC chan struct{}) {
+// Options are applied to the node before it starts serving.
+func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC chan struct{}, opts ...NodeOption) {
 	zl = zl.Named("termite")
 	zl.Info("Starting termite node", zap.Any("config", config))
 
@@ -95,6 +134,17 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 		zl.Fatal("Invalid API URL", zap.String("url", config.ApiUrl), zap.Error(err))
 	}
 
+	// Configure tracing; a no-op unless an OTLP endpoint is set
+	shutdownTracing, err := InitTracing(ctx, config.OtlpEndpoint, zl)
+	if err != nil {
+		zl.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			zl.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
 	// Configure GPU mode before creating session
 	if config.Gpu != "" {
 		gpuMode := hugot.GPUMode(config.Gpu)
@@ -109,6 +159,19 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 		zap.String("type", gpuInfo.Type),
 		zap.String("device", gpuInfo.DeviceName))
 
+	// Merge models declared in the manifest into the preload, strategy
+	// and alias settings
+	if config.ModelsManifest != "" {
+		manifest, err := LoadModelsManifest(config.ModelsManifest)
+		if err != nil {
+			zl.Fatal("Failed to load models manifest", zap.String("path", config.ModelsManifest), zap.Error(err))
+		}
+		manifest.apply(&config)
+		zl.Info("Loaded models manifest",
+			zap.String("path", config.ModelsManifest),
+			zap.Int("models", len(manifest.Models)))
+	}
+
 	// Parse keep_alive duration
 	var keepAlive time.Duration
 	if config.KeepAlive != "" && config.KeepAlive != "0" {
@@ -142,7 +205,7 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 		if err != nil {
 			zl.Fatal("Failed to create shared Hugot session", zap.Error(err))
 		}
-		defer func() { _ = sharedSession.Destroy() }()
+		defer func() { _ = hugot.ReleaseSession(sharedSession) }()
 
 		backendName := hugot.BackendName()
 		zl.Info("Created shared Hugot session for all models", zap.String("backend", backendName))
@@ -256,8 +319,10 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 	}
 
 	// Initialize request queue for backpressure control
-	var requestTimeout time.Duration
-	if config.RequestTimeout != "" && config.RequestTimeout != "0" {
+	requestTimeout := DefaultRequestTimeout
+	if config.RequestTimeout == "0" {
+		requestTimeout = 0
+	} else if config.RequestTimeout != "" {
 		requestTimeout, err = time.ParseDuration(config.RequestTimeout)
 		if err != nil {
 			zl.Fatal("Invalid request_timeout duration", zap.String("request_timeout", config.RequestTimeout), zap.Error(err))
@@ -277,6 +342,9 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 	rerankingCache := NewRerankingCache(zl.Named("reranking-cache"))
 	defer rerankingCache.Close()
 
+	idempotencyCache := NewIdempotencyCache()
+	defer idempotencyCache.Close()
+
 	// Build S3 credentials from config (optional)
 	var s3Creds *s3.Credentials
 	if config.S3Credentials.Endpoint != "" {
@@ -292,13 +360,51 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 		cachedChunker:         cachedChunker,
 		rerankerRegistry:      rerankerRegistry,
 		contentSecurityConfig: contentSecurityConfig,
+		embedLimits:           config.EmbedLimits,
 		s3Credentials:         s3Creds,
 		requestQueue:          requestQueue,
+		requestTimeout:        requestTimeout,
+		modelLimiter:          NewModelLimiter(config.MaxConcurrentPerModel, config.MaxQueuedPerModel, zl.Named("model-limiter")),
 		embeddingCache:        embeddingCache,
 		rerankingCache:        rerankingCache,
+		idempotencyCache:      idempotencyCache,
+		adminToken:            config.AdminToken,
+		modelAliases:          ModelAliases(config.ModelAliases),
+		tokenizers:            NewTokenizerRegistry(config.ModelsDir, zl.Named("tokenizer")),
 
 		client: client,
 	}
+	for _, opt := range opts {
+		if err := opt(node); err != nil {
+			zl.Fatal("Failed to apply node option", zap.Error(err))
+		}
+	}
+
+	// Resolve configured model aliases against the loaded and registered models
+	if len(node.modelAliases) > 0 {
+		var embedders, rerankers []string
+		if node.embedderProvider != nil {
+			embedders = node.embedderProvider.List()
+		}
+		if rerankerRegistry != nil {
+			rerankers = rerankerRegistry.List()
+		}
+		node.modelAliases.warnUnresolved(zl, embedders, rerankers)
+	}
+
+	// Start the deep liveness check once custom embedders are registered
+	node.liveness, err = newLivenessChecker(config.LivenessCheck, node.embedCanary(config.LivenessCheck.Model), zl.Named("liveness"))
+	if err != nil {
+		zl.Fatal("Invalid liveness_check configuration", zap.Error(err))
+	}
+	if node.liveness != nil {
+		zl.Info("Deep liveness check enabled",
+			zap.String("model", node.liveness.model),
+			zap.Duration("interval", node.liveness.interval),
+			zap.Duration("timeout", node.liveness.timeout),
+			zap.Int("failure_threshold", node.liveness.threshold))
+		go node.liveness.run(ctx)
+	}
 
 	// Create API handler using generated ServerInterface
 	apiHandler := NewTermiteAPI(zl, node)
@@ -310,20 +416,64 @@ func RunAsTermite(ctx context.Context, zl *zap.Logger, config Config, readyC cha
 	rootMux.HandleFunc("GET /healthz", node.handleHealthz)
 	rootMux.HandleFunc("GET /readyz", node.handleReadyz)
 
+	// Admin endpoints require a bearer token and are disabled without one
+	if config.AdminToken != "" {
+		rootMux.HandleFunc("POST /admin/reload", node.handleAdminReload)
+		rootMux.HandleFunc("POST /admin/cache/invalidate", node.handleAdminInvalidateCache)
+	}
+
+	// Cohere-compatible rerank API, for clients built against Cohere
+	rootMux.HandleFunc("POST /v1/rerank", node.handleCohereRerank)
+	rootMux.HandleFunc("POST /v2/rerank", node.handleCohereRerank)
+
 	// Mount the OpenAPI-generated API handler (includes /api/version)
 	rootMux.Handle("/api/", apiHandler)
 
+	// Reload models on SIGHUP
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+	go func() {
+		for {
+			select {
+			case <-sighup:
+				zl.Info("SIGHUP received, reloading models")
+				if _, err := node.Reload(); err != nil {
+					zl.Error("Model reload failed", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	tlsConfig, err := newServerTLSConfig(config, zl.Named("tls"))
+	if err != nil {
+		zl.Fatal("Invalid TLS configuration", zap.Error(err))
+	}
+
 	srv := &http.Server{
 		Addr:        u.Host,
 		Handler:     corsMiddleware(rootMux),
 		ReadTimeout: 540 * time.Second,
+		TLSConfig:   tlsConfig,
 	}
 
 	// Start server in goroutine
 	serverErr := make(chan error, 1)
 	go func() {
-		zl.Info("Termite's api server starting", zap.String("address", config.ApiUrl))
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		zl.Info("Termite's api server starting",
+			zap.String("address", config.ApiUrl),
+			zap.Bool("tls", tlsConfig != nil),
+			zap.Bool("mtls", config.TlsClientCaFile != ""))
+		var err error
+		if tlsConfig != nil {
+			// Certificates come from tlsConfig.GetCertificate
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			serverErr <- err
 		}
 		close(serverErr)
@@ -82,8 +82,27 @@ func TestTermiteNode_EmbedEndpoint_NoModels(t *testing.T) {
 		require.NoError(t, err)
 		defer func() { _ = resp.Body.Close() }()
 
-		// 503 because embedderRegistry is nil, checked before model validation
-		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		// A malformed request is a client error whatever the node serves
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	// Test that input is required
+	t.Run("EmbedRequiresInput", func(t *testing.T) {
+		for _, body := range []string{
+			`{"model":"test-model"}`,
+			`{"model":"test-model","input":""}`,
+			`{"model":"test-model","input":[]}`,
+		} {
+			req, err := http.NewRequest("POST", server.URL+"/api/embed", bytes.NewReader([]byte(body)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+		}
 	})
 
 	// Test invalid JSON
@@ -100,10 +119,7 @@ func TestTermiteNode_EmbedEndpoint_NoModels(t *testing.T) {
 		require.NoError(t, err)
 		defer func() { _ = resp.Body.Close() }()
 
-		// Still returns 503 because embedderRegistry check happens first
-		// This is fine - the order of checks is implementation detail
-		assert.True(t, resp.StatusCode == http.StatusServiceUnavailable ||
-			resp.StatusCode == http.StatusBadRequest)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
 
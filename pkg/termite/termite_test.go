@@ -15,17 +15,29 @@
 package termite
 
 import (
+	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/antflydb/antfly-go/libaf/ai"
+	"github.com/antflydb/antfly-go/libaf/chunking"
 	"github.com/antflydb/antfly-go/libaf/embeddings"
 	"github.com/antflydb/antfly-go/libaf/reranking"
+	termchunking "github.com/antflydb/termite/pkg/termite/lib/chunking"
+	"github.com/antflydb/termite/pkg/termite/lib/hugot"
+	"github.com/antflydb/termite/pkg/termite/lib/tokenizer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -59,6 +71,50 @@ func (m *MockEmbedder) GetCallCount() int32 {
 	return m.callCount.Load()
 }
 
+// newEmbedTestNode returns a node serving embedder as model, whose embedding
+// cache is closed when the test ends
+func newEmbedTestNode(t *testing.T, model string, embedder embeddings.Embedder) *TermiteNode {
+	logger := zaptest.NewLogger(t)
+	node := &TermiteNode{
+		logger: logger,
+		embedderProvider: &EmbedderRegistry{
+			models: map[string]embeddings.Embedder{model: embedder},
+			logger: logger,
+		},
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		embeddingCache: NewEmbeddingCache(logger.Named("embedding-cache")),
+	}
+	t.Cleanup(node.embeddingCache.Close)
+	return node
+}
+
+// newEmbedRequest builds a JSON /api/embed request
+func newEmbedRequest(t *testing.T, embedReq EmbedRequest) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(embedReq)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/embed", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req
+}
+
+func postEmbedRequest(t *testing.T, handler http.Handler, embedReq EmbedRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newEmbedRequest(t, embedReq))
+	return w
+}
+
+// postEmbed embeds two texts with model
+func postEmbed(t *testing.T, handler http.Handler, model string) *httptest.ResponseRecorder {
+	t.Helper()
+	embedReq := EmbedRequest{Model: model}
+	require.NoError(t, embedReq.Input.FromEmbedRequestInput1([]string{"hello", "world"}))
+	return postEmbedRequest(t, handler, embedReq)
+}
+
 func TestTermiteNode_HandleApiEmbed_NoRegistry(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 
@@ -95,11 +151,11 @@ func TestTermiteNode_HandleApiEmbed_InvalidRequest(t *testing.T) {
 	}
 	handler := NewTermiteAPI(logger, node)
 
-	// Test invalid JSON - should return 503 (registry check first) or 400
+	// Invalid JSON is rejected before the missing registry is noticed
 	req := httptest.NewRequest("POST", "/api/embed", bytes.NewReader([]byte("invalid json")))
 	w := httptest.NewRecorder()
 	handler.ServeHTTP(w, req)
-	assert.True(t, w.Code == http.StatusServiceUnavailable || w.Code == http.StatusBadRequest)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestTermiteReranker_InitWithoutModel(t *testing.T) {
@@ -237,6 +293,195 @@ func TestTermiteNode_HandleApiRerank_Success(t *testing.T) {
 	assert.Equal(t, int32(1), mockModel.GetCallCount())
 }
 
+func TestTermiteNode_HandleApiRerank_DocumentIDs(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	// Scores the prompts "low", "high", "mid" as 0.1, 0.9, 0.5
+	mockModel := &MockModel{
+		rerankFunc: func(ctx context.Context, query string, prompts []string) ([]float32, error) {
+			byText := map[string]float32{"low": 0.1, "high": 0.9, "mid": 0.5}
+			scores := make([]float32, len(prompts))
+			for i, p := range prompts {
+				scores[i] = byText[p]
+			}
+			return scores, nil
+		},
+	}
+	rerankingCache := NewRerankingCache(logger.Named("reranking-cache"))
+	defer rerankingCache.Close()
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"test_model": mockModel},
+			logger: logger,
+		},
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		rerankingCache: rerankingCache,
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	rerank := func(req RerankRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		httpReq := httptest.NewRequest("POST", "/api/rerank", bytes.NewReader(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httpReq)
+		return w
+	}
+
+	prompts := []string{"low", "high", "mid"}
+	w := rerank(RerankRequest{Model: "test_model", Query: "q", Prompts: prompts, Ids: []string{"doc-a", "doc-b", "doc-c"}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp RerankResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []RerankResult{
+		{Id: "doc-b", Index: 1, Score: 0.9},
+		{Id: "doc-c", Index: 2, Score: 0.5},
+		{Id: "doc-a", Index: 0, Score: 0.1},
+	}, resp.Results)
+
+	// Same texts with different IDs are served from the cache under the new IDs
+	w = rerank(RerankRequest{Model: "test_model", Query: "q", Prompts: prompts, Ids: []string{"x", "y", "z"}, TopN: 1})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	resp = RerankResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []RerankResult{{Id: "y", Index: 1, Score: 0.9}}, resp.Results)
+	assert.Equal(t, int32(1), mockModel.GetCallCount(), "IDs must not be part of the cache key")
+
+	// Without IDs, results carry no id field
+	w = rerank(RerankRequest{Model: "test_model", Query: "q", Prompts: prompts})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), `"id"`)
+
+	// IDs must line up with prompts
+	w = rerank(RerankRequest{Model: "test_model", Query: "q", Prompts: prompts, Ids: []string{"doc-a"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTermiteNode_HandleApiRerank_Streaming(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	// Scores each "doc-N" prompt by a scrambled function of N
+	mockModel := &MockModel{
+		rerankFunc: func(ctx context.Context, query string, prompts []string) ([]float32, error) {
+			scores := make([]float32, len(prompts))
+			for i, p := range prompts {
+				n, err := strconv.Atoi(strings.TrimPrefix(p, "doc-"))
+				require.NoError(t, err)
+				scores[i] = float32(n*37%50) / 50
+			}
+			return scores, nil
+		},
+	}
+	rerankingCache := NewRerankingCache(logger.Named("reranking-cache"))
+	defer rerankingCache.Close()
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"test_model": mockModel},
+			logger: logger,
+		},
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		rerankingCache: rerankingCache,
+	}
+	server := httptest.NewServer(NewTermiteAPI(logger, node))
+	defer server.Close()
+
+	prompts := make([]string, 3*rerankStreamBatchSize+5)
+	for i := range prompts {
+		prompts[i] = fmt.Sprintf("doc-%d", i)
+	}
+	body, err := json.Marshal(RerankRequest{Model: "test_model", Query: "q", Prompts: prompts})
+	require.NoError(t, err)
+
+	stream := func() []RerankResult {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+"/api/rerank", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/x-ndjson")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		var results []RerankResult
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var result RerankResult
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &result), scanner.Text())
+			results = append(results, result)
+		}
+		require.NoError(t, scanner.Err())
+		return results
+	}
+
+	// Every prompt is scored exactly once, one model call per batch
+	streamed := stream()
+	require.Len(t, streamed, len(prompts))
+	seen := make(map[int]bool)
+	for _, result := range streamed {
+		assert.False(t, seen[result.Index], "index %d streamed twice", result.Index)
+		seen[result.Index] = true
+	}
+	assert.Equal(t, int32(4), mockModel.GetCallCount())
+
+	// Each batch arrives sorted, and the first batch only holds its own prompts
+	first := streamed[:rerankStreamBatchSize]
+	assert.True(t, slices.IsSortedFunc(first, func(a, b RerankResult) int { return cmp.Compare(b.Score, a.Score) }))
+	for _, result := range first {
+		assert.Less(t, result.Index, rerankStreamBatchSize)
+	}
+
+	// Sorting the stream reproduces the non-streaming ranking, which is
+	// served from the scores cached by the stream
+	req := httptest.NewRequest(http.MethodPost, "/api/rerank", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	NewTermiteAPI(logger, node).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp RerankResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int32(4), mockModel.GetCallCount(), "non-streaming request should hit the cache")
+
+	ranked := slices.Clone(streamed)
+	slices.SortStableFunc(ranked, func(a, b RerankResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Index, b.Index))
+	})
+	assert.Equal(t, resp.Results, ranked)
+
+	// A repeated stream is replayed from the cache
+	assert.Equal(t, streamed, stream())
+	assert.Equal(t, int32(4), mockModel.GetCallCount())
+}
+
+func TestTermiteNode_HandleApiRerank_StreamingRejectsTopN(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	mockModel := &MockModel{}
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"test_model": mockModel},
+			logger: logger,
+		},
+		requestQueue: NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+	}
+
+	body, err := json.Marshal(RerankRequest{Model: "test_model", Query: "q", Prompts: []string{"a", "b"}, TopN: 1})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/rerank", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	NewTermiteAPI(logger, node).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "top_n")
+	assert.Equal(t, int32(0), mockModel.GetCallCount())
+}
+
 func TestTermiteNode_HandleApiRerank_NotAvailable(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 
@@ -345,6 +590,39 @@ func TestTermiteNode_HandleApiRerank_InvalidRequest(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantError:  "prompts are required",
 		},
+		{
+			name: "negative top_n",
+			body: `{
+				"model": "test_model",
+				"query": "test query",
+				"prompts": ["doc1"],
+				"top_n": -1
+			}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "top_n must not be negative",
+		},
+		{
+			name: "invalid mode",
+			body: `{
+				"model": "test_model",
+				"query": "test query",
+				"prompts": ["doc1"],
+				"mode": "bogus"
+			}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid mode",
+		},
+		{
+			name: "shared_query on cross-encoder",
+			body: `{
+				"model": "test_model",
+				"query": "test query",
+				"prompts": ["doc1"],
+				"mode": "shared_query"
+			}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "requires a bi-encoder reranker",
+		},
 	}
 
 	for _, tt := range tests {
@@ -362,3 +640,426 @@ func TestTermiteNode_HandleApiRerank_InvalidRequest(t *testing.T) {
 		})
 	}
 }
+
+// gatedStreamingChunker emits its first chunk immediately and waits for
+// release before emitting the rest, simulating a large document.
+type gatedStreamingChunker struct {
+	release chan struct{}
+}
+
+func (g *gatedStreamingChunker) Chunk(ctx context.Context, text string, opts chunking.ChunkOptions) ([]chunking.Chunk, error) {
+	var chunks []chunking.Chunk
+	err := g.ChunkStream(ctx, text, opts, func(c chunking.Chunk) error {
+		chunks = append(chunks, c)
+		return nil
+	})
+	return chunks, err
+}
+
+func (g *gatedStreamingChunker) ChunkStream(ctx context.Context, text string, opts chunking.ChunkOptions, emit termchunking.EmitFunc) error {
+	words := strings.Fields(text)
+	for i, word := range words {
+		if i == 1 {
+			select {
+			case <-g.release:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := emit(chunking.Chunk{Id: uint32(i), Text: word}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *gatedStreamingChunker) Close() error {
+	return nil
+}
+
+func TestTermiteNode_HandleApiChunk_Streaming(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	cachedChunker, err := NewCachedChunker("", nil, logger.Named("chunker"))
+	require.NoError(t, err)
+	defer func() { _ = cachedChunker.Close() }()
+
+	gated := &gatedStreamingChunker{release: make(chan struct{})}
+	cachedChunker.registry.models["gated"] = gated
+
+	node := &TermiteNode{
+		logger:        logger,
+		cachedChunker: cachedChunker,
+		requestQueue:  NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+	}
+	server := httptest.NewServer(NewTermiteAPI(logger, node))
+	defer server.Close()
+
+	body := `{"text": "alpha beta gamma", "config": {"model": "gated"}}`
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+"/api/chunk", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readChunk := func() chunking.Chunk {
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		var chunk chunking.Chunk
+		require.NoError(t, json.Unmarshal(line, &chunk))
+		return chunk
+	}
+
+	// The first chunk arrives while the chunker is still blocked on the rest
+	first := readChunk()
+	assert.Equal(t, "alpha", first.Text)
+
+	close(gated.release)
+	assert.Equal(t, "beta", readChunk().Text)
+	assert.Equal(t, "gamma", readChunk().Text)
+
+	_, err = reader.ReadBytes('\n')
+	assert.ErrorIs(t, err, io.EOF)
+
+	// The completed stream is cached and replayed by the non-streaming endpoint
+	req = httptest.NewRequest(http.MethodPost, "/api/chunk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	NewTermiteAPI(logger, node).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var chunkResp ChunkResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &chunkResp))
+	assert.True(t, chunkResp.CacheHit)
+	assert.Len(t, chunkResp.Chunks, 3)
+}
+
+func TestTermiteNode_HandleApiChunk_StreamingNegotiation(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	cachedChunker, err := NewCachedChunker("", nil, logger.Named("chunker"))
+	require.NoError(t, err)
+	defer func() { _ = cachedChunker.Close() }()
+
+	node := &TermiteNode{
+		logger:        logger,
+		cachedChunker: cachedChunker,
+		requestQueue:  NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	chunk := func(body, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/chunk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Parameters and lists of types still select the stream
+	for _, accept := range []string{"application/x-ndjson; q=0.9", "application/json;q=0.5, application/x-ndjson"} {
+		w := chunk(`{"text": "alpha beta gamma"}`, accept)
+		require.Equal(t, http.StatusOK, w.Code, accept)
+		assert.Equal(t, ndjsonContentType, w.Header().Get("Content-Type"), accept)
+		assert.NotEmpty(t, w.Body.String(), accept)
+	}
+
+	// Blank text is rejected instead of streaming an empty 200
+	w := chunk(`{"text": "  \n\t "}`, ndjsonContentType)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTermiteNode_HandleApiChunk_TokenCounts(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	cachedChunker, err := NewCachedChunker("", nil, logger.Named("chunker"))
+	require.NoError(t, err)
+	defer func() { _ = cachedChunker.Close() }()
+
+	node := &TermiteNode{
+		logger:        logger,
+		cachedChunker: cachedChunker,
+		requestQueue:  NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+	}
+
+	text := strings.Repeat("Termite splits long documents into chunks for embedding. ", 20) +
+		"\n\n" + strings.Repeat("Each chunk reports how many tokens it contains. ", 20)
+	body, err := json.Marshal(ChunkRequest{
+		Text:   text,
+		Config: ChunkConfig{Model: termchunking.ModelFixedBert, TargetTokens: 150, OverlapTokens: 10},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/chunk", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	NewTermiteAPI(logger, node).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp ChunkResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Greater(t, len(resp.Chunks), 1)
+	require.Len(t, resp.TokenCounts, len(resp.Chunks))
+
+	tk, err := tokenizer.NewBertWordPieceTokenizer()
+	require.NoError(t, err)
+	total := 0
+	for i, chunk := range resp.Chunks {
+		expected := tk.CountTokens(chunk.Text)
+		assert.Equal(t, expected, resp.TokenCounts[i], "chunk %d", i)
+		total += expected
+	}
+	assert.Equal(t, total, resp.TotalTokens)
+}
+
+// wordCountingEmbedder counts one token per whitespace-separated word, a
+// tokenizer distinct from the fixed chunker's WordPiece
+type wordCountingEmbedder struct {
+	MockEmbedder
+}
+
+func (e *wordCountingEmbedder) CountTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestTermiteNode_HandleApiChunk_EmbeddingModelTokenCounts(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	cachedChunker, err := NewCachedChunker("", nil, logger.Named("chunker"))
+	require.NoError(t, err)
+	defer func() { _ = cachedChunker.Close() }()
+
+	node := &TermiteNode{
+		logger:        logger,
+		cachedChunker: cachedChunker,
+		requestQueue:  NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		embedderProvider: &EmbedderRegistry{
+			models: map[string]embeddings.Embedder{
+				"words":    &wordCountingEmbedder{},
+				"no-count": &MockEmbedder{},
+			},
+			logger: logger,
+		},
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	text := strings.Repeat("Tokenizers disagree about unbelievably long words. ", 40)
+	postAccept := func(embeddingModel, accept string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(ChunkRequest{
+			Text:           text,
+			Config:         ChunkConfig{Model: termchunking.ModelFixedBert, TargetTokens: 100},
+			EmbeddingModel: embeddingModel,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/chunk", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+	post := func(embeddingModel string) *httptest.ResponseRecorder {
+		return postAccept(embeddingModel, "application/json")
+	}
+
+	w := post("words")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp ChunkResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.TokenCounts, len(resp.Chunks))
+	total := 0
+	for i, chunk := range resp.Chunks {
+		expected := len(strings.Fields(chunk.Text))
+		assert.Equal(t, expected, resp.TokenCounts[i], "chunk %d", i)
+		total += expected
+	}
+	assert.Equal(t, total, resp.TotalTokens)
+
+	// The cached chunking result keeps the chunker's own counts
+	w = post("")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var defaultResp ChunkResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &defaultResp))
+	assert.True(t, defaultResp.CacheHit)
+	assert.NotEqual(t, resp.TotalTokens, defaultResp.TotalTokens)
+
+	assert.Equal(t, http.StatusBadRequest, post("no-count").Code)
+	assert.Equal(t, http.StatusNotFound, post("missing").Code)
+
+	// Streamed chunks carry no token counts, so an embedding model is
+	// rejected, known or not
+	for _, model := range []string{"words", "missing"} {
+		w = postAccept(model, ndjsonContentType)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "embedding_model")
+	}
+	w = postAccept("", ndjsonContentType)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, ndjsonContentType, w.Header().Get("Content-Type"))
+}
+
+func TestTermiteAPI_GetInfo(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{
+				"reranker-a": &MockModel{},
+				"reranker-b": &MockModel{},
+			},
+			logger: logger,
+		},
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp InfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, hugot.BackendName(), resp.Backend)
+	assert.Equal(t, GPUMode(hugot.GetGPUMode()), resp.GpuMode)
+	assert.Equal(t, hugot.GetGPUInfo().Available, resp.Gpu.Available)
+	assert.Equal(t, Version, resp.Version)
+	assert.NotEmpty(t, resp.GoVersion)
+
+	assert.Equal(t, RegistryModelCounts{Available: 2, Loaded: 2}, resp.Models.Rerankers)
+	assert.Equal(t, RegistryModelCounts{}, resp.Models.Embedders)
+}
+
+// capsEmbedder is a MockEmbedder reporting custom capabilities
+type capsEmbedder struct {
+	MockEmbedder
+	caps embeddings.EmbedderCapabilities
+}
+
+func (c *capsEmbedder) Capabilities() embeddings.EmbedderCapabilities {
+	return c.caps
+}
+
+func TestTermiteAPI_ListModels_Details(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	clip := &capsEmbedder{caps: embeddings.EmbedderCapabilities{
+		SupportedMIMETypes: []embeddings.MIMETypeSupport{
+			{MIMEType: "text/plain"},
+			{MIMEType: "image/png"},
+		},
+		Dimensions:       []int{512},
+		DefaultDimension: 512,
+	}}
+	node := &TermiteNode{
+		logger: logger,
+		embedderProvider: &EmbedderRegistry{
+			models: map[string]embeddings.Embedder{"clip-vit-base": clip},
+			logger: logger,
+		},
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"reranker-a": &MockModel{}},
+			logger: logger,
+		},
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/models", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp ModelsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	// Name lists are unchanged
+	assert.Equal(t, []string{"clip-vit-base"}, resp.Embedders)
+	assert.Equal(t, []string{"reranker-a"}, resp.Rerankers)
+
+	require.Len(t, resp.EmbedderDetails, 1)
+	assert.Equal(t, EmbedderModelInfo{
+		Name:               "clip-vit-base",
+		Loaded:             true,
+		Dimensions:         []int{512},
+		DefaultDimension:   512,
+		SupportedMimeTypes: []string{"text/plain", "image/png"},
+	}, resp.EmbedderDetails[0])
+
+	assert.Equal(t, []RerankerModelInfo{
+		{Name: "reranker-a", Type: RerankerModelInfoTypeCrossEncoder},
+	}, resp.RerankerDetails)
+}
+
+// sleepUntilCancelled blocks past any test timeout unless ctx is cancelled
+func sleepUntilCancelled(ctx context.Context, cancelled *atomic.Bool) error {
+	select {
+	case <-ctx.Done():
+		cancelled.Store(true)
+		return ctx.Err()
+	case <-time.After(10 * time.Second):
+		return nil
+	}
+}
+
+func TestTermiteNode_HandleApiEmbed_RequestTimeout(t *testing.T) {
+	var cancelled atomic.Bool
+	embedder := &MockEmbedder{
+		embedFunc: func(ctx context.Context, values []string) ([][]float32, error) {
+			return nil, sleepUntilCancelled(ctx, &cancelled)
+		},
+	}
+	node := newEmbedTestNode(t, "bge-small-en", embedder)
+	node.requestTimeout = 50 * time.Millisecond
+	handler := NewTermiteAPI(node.logger, node)
+
+	start := time.Now()
+	w := postEmbed(t, handler, "bge-small-en")
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code, w.Body.String())
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.True(t, cancelled.Load(), "embedder should observe cancellation")
+}
+
+func TestTermiteNode_HandleApiRerank_RequestTimeout(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	var cancelled atomic.Bool
+	mockModel := &MockModel{
+		rerankFunc: func(ctx context.Context, query string, prompts []string) ([]float32, error) {
+			return nil, sleepUntilCancelled(ctx, &cancelled)
+		},
+	}
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"test_model": mockModel},
+			logger: logger,
+		},
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger.Named("queue")),
+		requestTimeout: 50 * time.Millisecond,
+		rerankingCache: NewRerankingCache(logger.Named("reranking-cache")),
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	body, err := json.Marshal(RerankRequest{
+		Model:   "test_model",
+		Query:   "test query",
+		Prompts: []string{"first document", "second document"},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/rerank", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code, w.Body.String())
+	assert.True(t, cancelled.Load(), "reranker should observe cancellation")
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// certReloader serves a certificate from disk and reloads it when the
+// certificate or key file changes, so rotated certificates (e.g. a Secret
+// updated by cert-manager) are used for new connections without a restart
+type certReloader struct {
+	certFile string
+	keyFile  string
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod time.Time
+	keyMod  time.Time
+}
+
+func newCertReloader(certFile, keyFile string, logger *zap.Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. A certificate that
+// fails to load, e.g. while only one of the files has been rotated, is
+// logged and the previous certificate kept.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.changed() {
+		if err := r.reload(); err != nil {
+			r.logger.Warn("Failed to reload TLS certificate, keeping the previous one",
+				zap.String("cert_file", r.certFile),
+				zap.String("key_file", r.keyFile),
+				zap.Error(err))
+		} else {
+			r.logger.Info("Reloaded TLS certificate", zap.String("cert_file", r.certFile))
+		}
+	}
+	return r.cert, nil
+}
+
+// changed reports whether either file's modification time differs from
+// when the certificate was last loaded
+func (r *certReloader) changed() bool {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return false
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return false
+	}
+	return !certInfo.ModTime().Equal(r.certMod) || !keyInfo.ModTime().Equal(r.keyMod)
+}
+
+func (r *certReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("reading TLS certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("reading TLS key: %w", err)
+	}
+	// Record the modification times even if loading fails, so a bad pair
+	// is retried once the files change again rather than on every handshake
+	r.certMod, r.keyMod = certInfo.ModTime(), keyInfo.ModTime()
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS key pair: %w", err)
+	}
+	r.cert = &cert
+	return nil
+}
+
+// newServerTLSConfig builds the API server's TLS configuration, or returns
+// nil if TLS is not configured. With a client CA, clients must present a
+// certificate it signed.
+func newServerTLSConfig(config Config, logger *zap.Logger) (*tls.Config, error) {
+	if config.TlsCertFile == "" && config.TlsKeyFile == "" {
+		if config.TlsClientCaFile != "" {
+			return nil, errors.New("tls_client_ca_file requires tls_cert_file and tls_key_file")
+		}
+		return nil, nil
+	}
+	if config.TlsCertFile == "" || config.TlsKeyFile == "" {
+		return nil, errors.New("tls_cert_file and tls_key_file must be set together")
+	}
+
+	reloader, err := newCertReloader(config.TlsCertFile, config.TlsKeyFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if config.TlsClientCaFile != "" {
+		pem, err := os.ReadFile(config.TlsClientCaFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.TlsClientCaFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// writeSelfSignedCert writes a self-signed certificate for 127.0.0.1 and its
+// key to dir, returning the certificate. The files' modification time is set
+// to mod so that tests can simulate rotation deterministically.
+func writeSelfSignedCert(t *testing.T, dir, commonName string, mod time.Time) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(certFile, mod, mod))
+	require.NoError(t, os.Chtimes(keyFile, mod, mod))
+	return cert
+}
+
+// startTLSServer serves the Termite API over TLS on a random local port
+func startTLSServer(t *testing.T, config Config) string {
+	t.Helper()
+	logger := zaptest.NewLogger(t)
+	tlsConfig, err := newServerTLSConfig(config, logger)
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig)
+
+	node := newEmbedTestNode(t, "bge-small-en", &MockEmbedder{})
+	srv := &http.Server{Handler: NewTermiteAPI(logger, node), TLSConfig: tlsConfig}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.ServeTLS(ln, "", "") }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return "https://" + ln.Addr().String()
+}
+
+// httpsClient trusts roots and, if set, presents clientCert
+func httpsClient(roots []*x509.Certificate, clientCert *tls.Certificate) *http.Client {
+	pool := x509.NewCertPool()
+	for _, root := range roots {
+		pool.AddCert(root)
+	}
+	tlsConfig := &tls.Config{RootCAs: pool}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	// A fresh transport per client so every request performs a handshake
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}, Timeout: 10 * time.Second}
+}
+
+func postEmbedHTTPS(t *testing.T, client *http.Client, baseURL string) (*http.Response, error) {
+	t.Helper()
+	reqBody := EmbedRequest{Model: "bge-small-en"}
+	require.NoError(t, reqBody.Input.FromEmbedRequestInput1([]string{"hello"}))
+	body, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/api/embed", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return client.Do(req)
+}
+
+func TestTLS_Embed(t *testing.T) {
+	dir := t.TempDir()
+	cert := writeSelfSignedCert(t, dir, "termite", time.Now())
+	baseURL := startTLSServer(t, Config{
+		TlsCertFile: filepath.Join(dir, "tls.crt"),
+		TlsKeyFile:  filepath.Join(dir, "tls.key"),
+	})
+
+	resp, err := postEmbedHTTPS(t, httpsClient([]*x509.Certificate{cert}, nil), baseURL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var embedResp EmbedResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&embedResp))
+	assert.Equal(t, "bge-small-en", embedResp.Model)
+}
+
+func TestTLS_CertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Minute)
+	first := writeSelfSignedCert(t, dir, "first", start)
+	baseURL := startTLSServer(t, Config{
+		TlsCertFile: filepath.Join(dir, "tls.crt"),
+		TlsKeyFile:  filepath.Join(dir, "tls.key"),
+	})
+
+	resp, err := postEmbedHTTPS(t, httpsClient([]*x509.Certificate{first}, nil), baseURL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Rotate the certificate in place; new connections get the new one
+	second := writeSelfSignedCert(t, dir, "second", start.Add(30*time.Second))
+
+	resp, err = postEmbedHTTPS(t, httpsClient([]*x509.Certificate{second}, nil), baseURL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "second", resp.TLS.PeerCertificates[0].Subject.CommonName)
+
+	_, err = postEmbedHTTPS(t, httpsClient([]*x509.Certificate{first}, nil), baseURL)
+	assert.Error(t, err, "old certificate still served")
+}
+
+func TestTLS_ClientCertificates(t *testing.T) {
+	serverDir, clientDir := t.TempDir(), t.TempDir()
+	serverCert := writeSelfSignedCert(t, serverDir, "termite", time.Now())
+	writeSelfSignedCert(t, clientDir, "client", time.Now())
+	baseURL := startTLSServer(t, Config{
+		TlsCertFile:     filepath.Join(serverDir, "tls.crt"),
+		TlsKeyFile:      filepath.Join(serverDir, "tls.key"),
+		TlsClientCaFile: filepath.Join(clientDir, "tls.crt"), // Self-signed: its own CA
+	})
+	roots := []*x509.Certificate{serverCert}
+
+	_, err := postEmbedHTTPS(t, httpsClient(roots, nil), baseURL)
+	assert.Error(t, err, "request without a client certificate accepted")
+
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(clientDir, "tls.crt"), filepath.Join(clientDir, "tls.key"))
+	require.NoError(t, err)
+	resp, err := postEmbedHTTPS(t, httpsClient(roots, &clientCert), baseURL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewServerTLSConfig_Validation(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+
+	tlsConfig, err := newServerTLSConfig(Config{}, logger)
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig, "TLS enabled without certificates")
+
+	_, err = newServerTLSConfig(Config{TlsCertFile: "tls.crt"}, logger)
+	assert.ErrorContains(t, err, "must be set together")
+
+	_, err = newServerTLSConfig(Config{TlsClientCaFile: "ca.crt"}, logger)
+	assert.ErrorContains(t, err, "requires tls_cert_file")
+
+	dir := t.TempDir()
+	_, err = newServerTLSConfig(Config{
+		TlsCertFile: filepath.Join(dir, "missing.crt"),
+		TlsKeyFile:  filepath.Join(dir, "missing.key"),
+	}, logger)
+	assert.Error(t, err)
+}
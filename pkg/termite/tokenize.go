@@ -0,0 +1,218 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/antflydb/termite/pkg/termite/lib/chunking"
+	"github.com/antflydb/termite/pkg/termite/lib/modelregistry"
+	"github.com/antflydb/termite/pkg/termite/lib/tokenizer"
+	"github.com/bytedance/sonic/decoder"
+	"github.com/bytedance/sonic/encoder"
+	"go.uber.org/zap"
+)
+
+// errTokenizerNotFound is returned for models without a tokenizer
+var errTokenizerNotFound = errors.New("tokenizer not found")
+
+// tokenizerModelDirs are the models_dir subdirectories searched for a
+// model's tokenizer.json
+var tokenizerModelDirs = []string{"embedders", "chunkers", "rerankers"}
+
+// TokenizerRegistry loads model tokenizers on first use for /tokenize and
+// /decode. Tokenizers are independent of the ONNX models, so tokenizing
+// never loads a model.
+type TokenizerRegistry struct {
+	modelsDir string
+	logger    *zap.Logger
+
+	mu         sync.Mutex
+	tokenizers map[string]tokenizer.Encoder
+}
+
+// NewTokenizerRegistry creates a registry for the models under modelsDir.
+// The built-in fixed chunker tokenizers are available even without one.
+func NewTokenizerRegistry(modelsDir string, logger *zap.Logger) *TokenizerRegistry {
+	return &TokenizerRegistry{
+		modelsDir:  modelsDir,
+		logger:     logger,
+		tokenizers: make(map[string]tokenizer.Encoder),
+	}
+}
+
+// Get returns the tokenizer of the named model, or errTokenizerNotFound
+func (r *TokenizerRegistry) Get(modelName string) (tokenizer.Encoder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tk, ok := r.tokenizers[modelName]; ok {
+		return tk, nil
+	}
+	tk, err := r.load(modelName)
+	if err != nil {
+		return nil, err
+	}
+	r.tokenizers[modelName] = tk
+	return tk, nil
+}
+
+// Reset drops all loaded tokenizers so they are reloaded from disk
+func (r *TokenizerRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.tokenizers)
+}
+
+func (r *TokenizerRegistry) load(modelName string) (tokenizer.Encoder, error) {
+	switch modelName {
+	case chunking.ModelFixedBert:
+		return tokenizer.NewBertWordPieceTokenizer()
+	case chunking.ModelFixedBPE:
+		return tokenizer.NewBPETokenizer("cl100k_base")
+	}
+
+	// Model names are directory names, optionally with a variant suffix
+	if r.modelsDir == "" || modelName == "" || modelName != filepath.Base(modelName) ||
+		modelName == "." || modelName == ".." {
+		return nil, errTokenizerNotFound
+	}
+	candidates := []string{modelName}
+	for variant := range modelregistry.VariantFilenames {
+		if base, ok := strings.CutSuffix(modelName, "-"+variant); ok && base != "" {
+			candidates = append(candidates, base)
+		}
+	}
+
+	for _, name := range candidates {
+		for _, dir := range tokenizerModelDirs {
+			path := filepath.Join(r.modelsDir, dir, name, "tokenizer.json")
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			r.logger.Debug("Loading tokenizer",
+				zap.String("model", modelName),
+				zap.String("path", path))
+			return tokenizer.NewHuggingFaceTokenizer(path)
+		}
+	}
+	return nil, errTokenizerNotFound
+}
+
+// handleApiTokenize tokenizes text with a model's tokenizer
+func (ln *TermiteNode) handleApiTokenize(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	var req TokenizeRequest
+	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decoding request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.Model == "" {
+		http.Error(w, "model is required", http.StatusBadRequest)
+		return
+	}
+
+	tk, ok := ln.getTokenizer(w, req.Model)
+	if !ok {
+		return
+	}
+	ids, tokens, err := tk.Encode(req.Text)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("tokenizing: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if ids == nil {
+		ids, tokens = []int{}, []string{}
+	}
+
+	resp := TokenizeResponse{
+		Model:  req.Model,
+		Ids:    ids,
+		Tokens: tokens,
+		Count:  len(ids),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
+		ln.logger.Error("encoding response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleApiDecode converts token IDs back to text with a model's tokenizer
+func (ln *TermiteNode) handleApiDecode(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	var req DecodeRequest
+	if err := decoder.NewStreamDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decoding request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.Model == "" {
+		http.Error(w, "model is required", http.StatusBadRequest)
+		return
+	}
+
+	tk, ok := ln.getTokenizer(w, req.Model)
+	if !ok {
+		return
+	}
+	text, err := tk.Decode(req.Ids)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := DecodeResponse{
+		Model: req.Model,
+		Text:  text,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := encoder.NewStreamEncoder(w).Encode(resp); err != nil {
+		ln.logger.Error("encoding response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// getTokenizer resolves aliases and returns the model's tokenizer, writing
+// an error response if it cannot be loaded
+func (ln *TermiteNode) getTokenizer(w http.ResponseWriter, model string) (tokenizer.Encoder, bool) {
+	if ln.tokenizers == nil {
+		http.Error(w, "tokenization not available", http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	modelName := ln.modelAliases.Resolve(model)
+	tk, err := ln.tokenizers.Get(modelName)
+	if errors.Is(err, errTokenizerNotFound) {
+		http.Error(w, modelNotFoundMessage(model, modelName), http.StatusNotFound)
+		return nil, false
+	} else if err != nil {
+		ln.logger.Error("failed to load tokenizer",
+			zap.String("model", modelName),
+			zap.Error(err))
+		http.Error(w, fmt.Sprintf("loading tokenizer: %v", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	return tk, true
+}
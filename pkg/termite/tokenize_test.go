@@ -0,0 +1,130 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/antflydb/termite/pkg/termite/lib/chunking"
+	"github.com/antflydb/termite/pkg/termite/lib/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTokenizeTestHandler(t *testing.T) http.Handler {
+	logger := zaptest.NewLogger(t)
+	return NewTermiteAPI(logger, &TermiteNode{
+		logger:     logger,
+		tokenizers: NewTokenizerRegistry(t.TempDir(), logger),
+	})
+}
+
+func postJSON(t *testing.T, handler http.Handler, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func tokenize(t *testing.T, handler http.Handler, model, text string) TokenizeResponse {
+	t.Helper()
+	w := postJSON(t, handler, "/api/tokenize", TokenizeRequest{Model: model, Text: text})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp TokenizeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	return resp
+}
+
+func decode(t *testing.T, handler http.Handler, model string, ids []int) string {
+	t.Helper()
+	w := postJSON(t, handler, "/api/decode", DecodeRequest{Model: model, Ids: ids})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp DecodeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	return resp.Text
+}
+
+func TestTermiteAPI_Tokenize_BPERoundTrip(t *testing.T) {
+	handler := newTokenizeTestHandler(t)
+	text := "Hello, world! Tokenizers split text into pieces."
+
+	resp := tokenize(t, handler, chunking.ModelFixedBPE, text)
+	assert.Equal(t, chunking.ModelFixedBPE, resp.Model)
+	require.NotEmpty(t, resp.Ids)
+	assert.Len(t, resp.Tokens, len(resp.Ids))
+	assert.Equal(t, len(resp.Ids), resp.Count)
+
+	bpe, err := tokenizer.NewBPETokenizer("cl100k_base")
+	require.NoError(t, err)
+	assert.Equal(t, bpe.CountTokens(text), resp.Count, "count matches chunk token counts")
+
+	assert.Equal(t, text, decode(t, handler, chunking.ModelFixedBPE, resp.Ids))
+}
+
+func TestTermiteAPI_Tokenize_BertRoundTrip(t *testing.T) {
+	handler := newTokenizeTestHandler(t)
+	text := "Hello World, tokenization"
+
+	resp := tokenize(t, handler, chunking.ModelFixedBert, text)
+	require.NotEmpty(t, resp.Ids)
+	assert.Len(t, resp.Tokens, len(resp.Ids))
+	assert.Equal(t, len(resp.Ids), resp.Count)
+	assert.Contains(t, resp.Tokens, "hello")
+
+	bert, err := tokenizer.NewBertWordPieceTokenizer()
+	require.NoError(t, err)
+	assert.Equal(t, bert.CountTokens(text), resp.Count, "count matches chunk token counts")
+
+	// WordPiece lowercases, so decoding restores the normalized text
+	decoded := decode(t, handler, chunking.ModelFixedBert, resp.Ids)
+	assert.Equal(t, resp.Ids, tokenize(t, handler, chunking.ModelFixedBert, decoded).Ids)
+}
+
+func TestTermiteAPI_Tokenize_EmptyText(t *testing.T) {
+	handler := newTokenizeTestHandler(t)
+
+	resp := tokenize(t, handler, chunking.ModelFixedBert, "")
+	assert.Empty(t, resp.Ids)
+	assert.Zero(t, resp.Count)
+}
+
+func TestTermiteAPI_Tokenize_ModelNotFound(t *testing.T) {
+	handler := newTokenizeTestHandler(t)
+
+	for _, model := range []string{"missing-model", "../chunkers/missing-model"} {
+		w := postJSON(t, handler, "/api/tokenize", TokenizeRequest{Model: model, Text: "hello"})
+		assert.Equal(t, http.StatusNotFound, w.Code, model)
+	}
+
+	w := postJSON(t, handler, "/api/decode", DecodeRequest{Model: "missing-model", Ids: []int{1}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTermiteAPI_Decode_UnknownTokenID(t *testing.T) {
+	handler := newTokenizeTestHandler(t)
+
+	w := postJSON(t, handler, "/api/decode", DecodeRequest{Model: chunking.ModelFixedBert, Ids: []int{-1}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "not in the vocabulary")
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// tracerName is the instrumentation scope for spans created by Termite.
+const tracerName = "github.com/antflydb/termite/pkg/termite"
+
+// Span attribute keys shared by the API handlers and caches.
+const (
+	attrModel      = attribute.Key("termite.model")
+	attrBatchSize  = attribute.Key("termite.batch_size")
+	attrCacheHit   = attribute.Key("termite.cache_hit")
+	attrDurationMs = attribute.Key("termite.duration_ms")
+)
+
+// tracer returns the Termite tracer from the global provider, which is a
+// no-op unless InitTracing configured an exporter.
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// InitTracing installs a global OpenTelemetry tracer provider that exports
+// spans to the given OTLP/HTTP endpoint, along with the W3C trace context
+// propagator. When endpoint is empty, tracing stays a no-op. The returned
+// function flushes and stops the exporter.
+func InitTracing(ctx context.Context, endpoint string, logger *zap.Logger) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("termite"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info("OpenTelemetry tracing enabled", zap.String("endpoint", endpoint))
+	return provider.Shutdown, nil
+}
+
+// tracingMiddleware starts a server span for each API request, continuing any
+// trace context carried in the incoming headers (e.g., W3C traceparent).
+// Handlers add model-specific attributes via trace.SpanFromContext.
+func tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		name := r.Pattern
+		if name == "" {
+			name = r.Method + " " + r.URL.Path
+		}
+		ctx, span := tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			))
+		defer span.End()
+
+		start := time.Now()
+		sw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		span.SetAttributes(
+			semconv.HTTPResponseStatusCode(sw.status),
+			attrDurationMs.Int64(time.Since(start).Milliseconds()),
+		)
+		if sw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(sw.status))
+		}
+	})
+}
+
+// statusRecorder captures the response status code for tracing.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Antfly, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/antflydb/antfly-go/libaf/reranking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zaptest"
+)
+
+// mockReranker scores each prompt by its length.
+type mockReranker struct{}
+
+func (mockReranker) Rerank(ctx context.Context, query string, prompts []string) ([]float32, error) {
+	scores := make([]float32, len(prompts))
+	for i, p := range prompts {
+		scores[i] = float32(len(p))
+	}
+	return scores, nil
+}
+
+func (mockReranker) Close() error { return nil }
+
+// withSpanRecorder installs an in-memory tracer provider for the duration of the test.
+func withSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	originalProvider := otel.GetTracerProvider()
+	originalPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+		otel.SetTracerProvider(originalProvider)
+		otel.SetTextMapPropagator(originalPropagator)
+	})
+	return recorder
+}
+
+// spanAttrs flattens a span's attributes into a map for assertions.
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	for _, s := range spans {
+		if s.Name() == name {
+			return s
+		}
+	}
+	require.Failf(t, "span not found", "no span named %q", name)
+	return nil
+}
+
+func TestTracing_RerankRequestSpan(t *testing.T) {
+	recorder := withSpanRecorder(t)
+	logger := zaptest.NewLogger(t)
+
+	rerankingCache := NewRerankingCache(logger)
+	defer rerankingCache.Close()
+
+	node := &TermiteNode{
+		logger: logger,
+		rerankerRegistry: &RerankerRegistry{
+			models: map[string]reranking.Model{"mock-reranker": mockReranker{}},
+			logger: logger,
+		},
+		rerankingCache: rerankingCache,
+		requestQueue:   NewRequestQueue(RequestQueueConfig{}, logger),
+	}
+	handler := NewTermiteAPI(logger, node)
+
+	body, err := json.Marshal(map[string]any{
+		"model":   "mock-reranker",
+		"query":   "what is termite",
+		"prompts": []string{"a", "bb", "ccc"},
+	})
+	require.NoError(t, err)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	doRequest := func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/rerank", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// First request misses the cache and calls the model
+	doRequest()
+	spans := recorder.Ended()
+
+	server := findSpan(t, spans, "POST /api/rerank")
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, traceID, server.SpanContext().TraceID().String(), "incoming traceparent should be continued")
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+
+	attrs := spanAttrs(server)
+	assert.Equal(t, "mock-reranker", attrs[attrModel].AsString())
+	assert.Equal(t, int64(3), attrs[attrBatchSize].AsInt64())
+	assert.False(t, attrs[attrCacheHit].AsBool())
+	assert.Contains(t, attrs, attrDurationMs)
+
+	lookup := findSpan(t, spans, "rerank.cache_lookup")
+	assert.False(t, spanAttrs(lookup)[attrCacheHit].AsBool())
+	model := findSpan(t, spans, "rerank.model")
+	assert.Equal(t, traceID, model.SpanContext().TraceID().String())
+
+	// Second identical request is served from the cache without a model span
+	recorder.Reset()
+	doRequest()
+	spans = recorder.Ended()
+
+	server = findSpan(t, spans, "POST /api/rerank")
+	assert.True(t, spanAttrs(server)[attrCacheHit].AsBool())
+	for _, s := range spans {
+		assert.NotEqual(t, "rerank.model", s.Name(), "cache hit should not call the model")
+	}
+}